@@ -0,0 +1,166 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package command
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// buildHashFileSuffix is the suffix of the hidden file, stored next to a plugin
+// binary, which contains the hash of the inputs the binary was built from.
+const buildHashFileSuffix = ".build_hash"
+
+// goPackage holds the fields of the 'go list -json' output used to compute the build hash.
+type goPackage struct {
+	ImportPath string
+	Dir        string
+	Standard   bool
+	GoFiles    []string
+	CgoFiles   []string
+	CFiles     []string
+	CXXFiles   []string
+	HFiles     []string
+	SFiles     []string
+	SysoFiles  []string
+	EmbedFiles []string
+	Module     *goModule
+}
+
+type goModule struct {
+	Path    string
+	Version string
+	GoMod   string
+	Replace *goModule
+}
+
+// goVersion returns the version of the go toolchain used to build the plugins.
+var goVersion = sync.OnceValues(func() (string, error) {
+	b, err := goCommand("version").Output()
+	return strings.TrimSpace(string(b)), err
+})
+
+// buildHash returns a hash of all the inputs of the build of the target: the go
+// toolchain, the build flags and environment, and the sources of every package
+// the target depends on.  Packages of versioned modules are identified by their
+// module version instead of their sources.
+func (t target) buildHash(targetPath, modPath, ldflags, tags, goflags string) (string, error) {
+	v, err := goVersion()
+	if err != nil {
+		return "", errors.Wrap(err, "unable to get the go version")
+	}
+
+	h := sha256.New()
+	fmt.Fprintln(h, v)
+	fmt.Fprintln(h, targetPath, ldflags, tags, goflags, t.env, sbomFormats)
+
+	cmd := goCommand("list", "-deps", "-json", "-tags", tags, fmt.Sprintf("./%s", targetPath))
+	cmd.Env = append(cmd.Env, os.Environ()...)
+	cmd.Env = append(cmd.Env, t.env...)
+	if modPath != "" {
+		cmd.Dir = modPath
+	}
+	output, err := cmd.Output()
+	if err != nil {
+		return "", errors.Wrapf(err, "unable to list the dependencies of %q", targetPath)
+	}
+
+	goModFiles := map[string]bool{}
+	decoder := json.NewDecoder(bytes.NewReader(output))
+	for decoder.More() {
+		var pkg goPackage
+		if err := decoder.Decode(&pkg); err != nil {
+			return "", err
+		}
+		if pkg.Standard {
+			continue
+		}
+
+		module := pkg.Module
+		if module != nil && module.Replace != nil {
+			module = module.Replace
+		}
+		if module != nil && module.Version != "" {
+			fmt.Fprintln(h, pkg.ImportPath, module.Path, module.Version)
+			continue
+		}
+		if module != nil && module.GoMod != "" {
+			goModFiles[module.GoMod] = true
+		}
+
+		fmt.Fprintln(h, pkg.ImportPath)
+		for _, files := range [][]string{pkg.GoFiles, pkg.CgoFiles, pkg.CFiles, pkg.CXXFiles, pkg.HFiles, pkg.SFiles, pkg.SysoFiles, pkg.EmbedFiles} {
+			for _, f := range files {
+				if err := hashFile(h, filepath.Join(pkg.Dir, f)); err != nil {
+					return "", err
+				}
+			}
+		}
+	}
+
+	// The go.mod and go.sum files of the local modules determine the versions of the dependencies
+	var goMods []string
+	for goMod := range goModFiles {
+		goMods = append(goMods, goMod)
+	}
+	sort.Strings(goMods)
+	for _, goMod := range goMods {
+		if err := hashFile(h, goMod); err != nil {
+			return "", err
+		}
+		goSum := filepath.Join(filepath.Dir(goMod), "go.sum")
+		if err := hashFile(h, goSum); err != nil && !os.IsNotExist(err) {
+			return "", err
+		}
+	}
+	return fmt.Sprintf("%x", h.Sum(nil)), nil
+}
+
+// hashFile adds the path and the content of the file to the hash.
+func hashFile(h hash.Hash, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	fmt.Fprintln(h, path)
+	_, err = io.Copy(h, f)
+	return err
+}
+
+// getBuildHashFile returns the path of the file containing the build hash of the binary.
+func getBuildHashFile(binaryPath string) string {
+	return filepath.Join(filepath.Dir(binaryPath), "."+filepath.Base(binaryPath)+buildHashFileSuffix)
+}
+
+// isUpToDate returns true if the binary exists and was built from the inputs
+// matching the build hash, unless a build is forced.
+func isUpToDate(binaryPath, buildHash string) bool {
+	if forceBuild {
+		return false
+	}
+	if _, err := os.Stat(binaryPath); err != nil {
+		return false
+	}
+	b, err := os.ReadFile(getBuildHashFile(binaryPath))
+	return err == nil && string(b) == buildHash
+}
+
+// saveBuildHash stores the build hash of the binary so that the next build can
+// skip it if its inputs have not changed.
+func saveBuildHash(binaryPath, buildHash string) error {
+	return os.WriteFile(getBuildHashFile(binaryPath), []byte(buildHash), 0644)
+}
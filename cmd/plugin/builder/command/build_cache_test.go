@@ -0,0 +1,82 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package command
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/tj/assert"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
+)
+
+func TestBuildHash(t *testing.T) {
+	assert := assert.New(t)
+
+	modPath := t.TempDir()
+	assert.Nil(os.WriteFile(filepath.Join(modPath, "go.mod"), []byte("module example.com/foo\n\ngo 1.22\n"), 0644))
+	assert.Nil(os.MkdirAll(filepath.Join(modPath, "test"), 0755))
+	mainFile := filepath.Join(modPath, "main.go")
+	assert.Nil(os.WriteFile(mainFile, []byte("package main\n\nfunc main() {}\n"), 0644))
+	assert.Nil(os.WriteFile(filepath.Join(modPath, "test", "main.go"), []byte("package main\n\nfunc main() {}\n"), 0644))
+
+	tgt := archMap[cli.LinuxAMD64]("foo", t.TempDir())
+	hash, err := tgt.buildHash(".", modPath, "-s", "", "")
+	assert.Nil(err)
+	assert.NotEmpty(hash)
+
+	// The hash is stable
+	sameHash, err := tgt.buildHash(".", modPath, "-s", "", "")
+	assert.Nil(err)
+	assert.Equal(hash, sameHash)
+
+	// The output directory does not affect the hash
+	sameHash, err = archMap[cli.LinuxAMD64]("foo", t.TempDir()).buildHash(".", modPath, "-s", "", "")
+	assert.Nil(err)
+	assert.Equal(hash, sameHash)
+
+	// Changes to the build flags, the target arch or the target path change the hash
+	otherHash, err := tgt.buildHash(".", modPath, "-w -s", "", "")
+	assert.Nil(err)
+	assert.NotEqual(hash, otherHash)
+
+	otherHash, err = archMap[cli.LinuxARM64]("foo", t.TempDir()).buildHash(".", modPath, "-s", "", "")
+	assert.Nil(err)
+	assert.NotEqual(hash, otherHash)
+
+	otherHash, err = tgt.buildHash("test", modPath, "-s", "", "")
+	assert.Nil(err)
+	assert.NotEqual(hash, otherHash)
+
+	// Changes to the sources change the hash
+	assert.Nil(os.WriteFile(mainFile, []byte("package main\n\nfunc main() { println() }\n"), 0644))
+	otherHash, err = tgt.buildHash(".", modPath, "-s", "", "")
+	assert.Nil(err)
+	assert.NotEqual(hash, otherHash)
+}
+
+func TestIsUpToDate(t *testing.T) {
+	assert := assert.New(t)
+	defer func() { forceBuild = false }()
+
+	binaryPath := filepath.Join(t.TempDir(), "tanzu-foo-linux_amd64")
+
+	// No binary
+	assert.False(isUpToDate(binaryPath, "hash"))
+
+	// No build hash
+	assert.Nil(os.WriteFile(binaryPath, []byte("binary"), 0644))
+	assert.False(isUpToDate(binaryPath, "hash"))
+
+	assert.Nil(saveBuildHash(binaryPath, "hash"))
+	_, err := os.Stat(filepath.Join(filepath.Dir(binaryPath), ".tanzu-foo-linux_amd64.build_hash"))
+	assert.Nil(err)
+	assert.True(isUpToDate(binaryPath, "hash"))
+	assert.False(isUpToDate(binaryPath, "other-hash"))
+
+	forceBuild = true
+	assert.False(isUpToDate(binaryPath, "hash"))
+}
@@ -0,0 +1,111 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package command
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+
+	"github.com/vmware-tanzu/tanzu-cli/cmd/plugin/builder/helpers"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
+)
+
+// BuildReportFileName is the file name of the build report.
+const BuildReportFileName = "build_report.yaml"
+
+// BuildReport lists the plugin binaries present in the artifacts directory along
+// with their digest.  It can be used to attest the binaries that were built.
+type BuildReport struct {
+	// SourceDateEpoch is the timestamp used for the build, if any.
+	SourceDateEpoch int64 `json:"sourceDateEpoch,omitempty" yaml:"sourceDateEpoch,omitempty"`
+
+	// Artifacts is the list of plugin binaries that were built.
+	Artifacts []BuildReportArtifact `json:"artifacts" yaml:"artifacts"`
+}
+
+// BuildReportArtifact is a plugin binary of the build report.
+type BuildReportArtifact struct {
+	// Path of the binary relative to the artifacts directory.
+	Path string `json:"path" yaml:"path"`
+
+	// Digest is the SHA256 hash of the binary.
+	Digest string `json:"digest" yaml:"digest"`
+}
+
+// generateBuildReport computes the digest of every plugin binary found in the
+// artifacts directory.  The artifacts are listed in lexical order of their path.
+func generateBuildReport(artifactsDir string, sourceDateEpoch int64) (*BuildReport, error) {
+	report := &BuildReport{
+		SourceDateEpoch: sourceDateEpoch,
+		Artifacts:       []BuildReportArtifact{},
+	}
+
+	err := filepath.WalkDir(artifactsDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !strings.HasPrefix(d.Name(), cli.ArtifactNamePrefix+"-") {
+			return nil
+		}
+
+		digest, err := helpers.GetDigest(path)
+		if err != nil {
+			return err
+		}
+		relPath, err := filepath.Rel(artifactsDir, path)
+		if err != nil {
+			return err
+		}
+		report.Artifacts = append(report.Artifacts, BuildReportArtifact{
+			Path:   filepath.ToSlash(relPath),
+			Digest: digest,
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// saveBuildReport writes the build report of the artifacts directory to the
+// BuildReportFileName file of that directory.
+func saveBuildReport(artifactsDir string, sourceDateEpoch int64) error {
+	log.Info("saving build report...")
+
+	report, err := generateBuildReport(artifactsDir, sourceDateEpoch)
+	if err != nil {
+		return err
+	}
+
+	b, err := yaml.Marshal(report)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(artifactsDir, BuildReportFileName), b, 0644)
+}
+
+// UpdateBuildReport regenerates the build report of the artifacts directory,
+// if there is one, for example after the plugin binaries were signed.
+func UpdateBuildReport(artifactsDir string) error {
+	b, err := os.ReadFile(filepath.Join(artifactsDir, BuildReportFileName))
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	var report BuildReport
+	err = yaml.Unmarshal(b, &report)
+	if err != nil {
+		return errors.Wrapf(err, "unable to read the build report of %q", artifactsDir)
+	}
+	return saveBuildReport(artifactsDir, report.SourceDateEpoch)
+}
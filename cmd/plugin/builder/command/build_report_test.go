@@ -0,0 +1,82 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package command
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/tj/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func TestSaveBuildReport(t *testing.T) {
+	assert := assert.New(t)
+
+	artifactsDir := t.TempDir()
+	files := map[string]string{
+		"linux/amd64/global/foo/v0.0.1/tanzu-foo-linux_amd64":                "linux",
+		"darwin/arm64/global/foo/v0.0.1/tanzu-foo-darwin_arm64":              "darwin",
+		"darwin/arm64/global/foo/v0.0.1/test/tanzu-foo-test-darwin_arm64":    "darwin-test",
+		"darwin/arm64/plugin_manifest.yaml":                                  "manifest",
+		"windows/amd64/global/foo/v0.0.1/tanzu-foo-windows_amd64.exe":        "windows",
+		"windows/amd64/global/foo/v0.0.1/not-a-plugin-tanzu-foo-windows.exe": "other",
+	}
+	for path, content := range files {
+		fullPath := filepath.Join(artifactsDir, filepath.FromSlash(path))
+		assert.Nil(os.MkdirAll(filepath.Dir(fullPath), 0755))
+		assert.Nil(os.WriteFile(fullPath, []byte(content), 0644))
+	}
+
+	err := saveBuildReport(artifactsDir, 1700000000)
+	assert.Nil(err)
+
+	b, err := os.ReadFile(filepath.Join(artifactsDir, BuildReportFileName))
+	assert.Nil(err)
+	var report BuildReport
+	assert.Nil(yaml.Unmarshal(b, &report))
+
+	assert.Equal(int64(1700000000), report.SourceDateEpoch)
+	assert.Equal(4, len(report.Artifacts))
+	assert.Equal("darwin/arm64/global/foo/v0.0.1/tanzu-foo-darwin_arm64", report.Artifacts[0].Path)
+	assert.Equal("darwin/arm64/global/foo/v0.0.1/test/tanzu-foo-test-darwin_arm64", report.Artifacts[1].Path)
+	assert.Equal("linux/amd64/global/foo/v0.0.1/tanzu-foo-linux_amd64", report.Artifacts[2].Path)
+	assert.Equal("windows/amd64/global/foo/v0.0.1/tanzu-foo-windows_amd64.exe", report.Artifacts[3].Path)
+
+	for _, a := range report.Artifacts {
+		assert.Equal(fmt.Sprintf("%x", sha256.Sum256([]byte(files[a.Path]))), a.Digest)
+	}
+}
+
+func TestUpdateBuildReport(t *testing.T) {
+	assert := assert.New(t)
+
+	artifactsDir := t.TempDir()
+
+	// Nothing to update when there is no build report
+	assert.Nil(UpdateBuildReport(artifactsDir))
+	_, err := os.Stat(filepath.Join(artifactsDir, BuildReportFileName))
+	assert.True(os.IsNotExist(err))
+
+	binaryPath := filepath.Join(artifactsDir, "darwin", "arm64", "global", "foo", "v0.0.1", "tanzu-foo-darwin_arm64")
+	assert.Nil(os.MkdirAll(filepath.Dir(binaryPath), 0755))
+	assert.Nil(os.WriteFile(binaryPath, []byte("unsigned"), 0644))
+	assert.Nil(saveBuildReport(artifactsDir, 1700000000))
+
+	// Simulate the signing of the binary
+	assert.Nil(os.WriteFile(binaryPath, []byte("signed"), 0644))
+	assert.Nil(UpdateBuildReport(artifactsDir))
+
+	b, err := os.ReadFile(filepath.Join(artifactsDir, BuildReportFileName))
+	assert.Nil(err)
+	var report BuildReport
+	assert.Nil(yaml.Unmarshal(b, &report))
+
+	assert.Equal(int64(1700000000), report.SourceDateEpoch)
+	assert.Equal(1, len(report.Artifacts))
+	assert.Equal(fmt.Sprintf("%x", sha256.Sum256([]byte("signed"))), report.Artifacts[0].Digest)
+}
@@ -38,19 +38,13 @@ func AddPlugin(name, description string, dryRun bool) error {
 		return errors.New("plugin description is required")
 	}
 
-	data := struct {
-		PluginName  string
-		Description string
-	}{
+	data := scaffoldData{
 		PluginName:  name,
 		Description: description,
 	}
-	targets := template.DefaultPluginTargets
-	for _, target := range targets {
-		err := target.Run("", data, dryRun)
-		if err != nil {
-			return err
-		}
+	err := runTargets("", template.DefaultPluginTargets, data, dryRun)
+	if err != nil {
+		return err
 	}
 	log.Success("successfully created plugin")
 
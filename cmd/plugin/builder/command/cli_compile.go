@@ -13,6 +13,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
@@ -27,6 +28,7 @@ import (
 	"github.com/vmware-tanzu/tanzu-cli/cmd/plugin/builder/helpers"
 	"github.com/vmware-tanzu/tanzu-cli/cmd/plugin/builder/types"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/sbom"
 	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
 )
 
@@ -43,6 +45,9 @@ var (
 	goflags                        string
 	targetArch                     []string
 	groupByOSArch                  bool
+	sbomFormats                    []sbom.Format
+	sourceDateEpoch                int64
+	forceBuild                     bool
 )
 
 type plugin struct {
@@ -53,6 +58,8 @@ type plugin struct {
 	modPath  string
 	buildID  string
 	target   string
+	// runtimeVersion is the version of the Tanzu Plugin Runtime the plugin is built with
+	runtimeVersion string
 }
 
 // PluginCompileArgs contains the values to use for compiling plugins.
@@ -70,6 +77,10 @@ type PluginCompileArgs struct {
 	TargetArch                 []string
 	GroupByOSArch              bool
 	DebugSymbols               bool
+	Reproducible               bool
+	SourceDateEpoch            int64
+	SBOMFormats                []sbom.Format
+	Force                      bool
 }
 
 const local = "local"
@@ -78,7 +89,7 @@ func getBuildArch(arch []string) []cli.Arch {
 	var arrArch []cli.Arch
 	for _, buildArch := range arch {
 		if buildArch == string(AllTargets) {
-			for arch := range archMap {
+			for arch := range getAllTargets() {
 				arrArch = append(arrArch, arch)
 			}
 		} else if buildArch == local {
@@ -101,6 +112,9 @@ func setGlobals(compileArgs *PluginCompileArgs) {
 	targetArch = compileArgs.TargetArch
 	groupByOSArch = compileArgs.GroupByOSArch
 	goflags = compileArgs.GoFlags
+	sbomFormats = compileArgs.SBOMFormats
+	sourceDateEpoch = compileArgs.SourceDateEpoch
+	forceBuild = compileArgs.Force
 
 	// Append version specific ldflag by default so that user doesn't need to pass this ldflag always.
 	ldflags = fmt.Sprintf("%s -X 'github.com/vmware-tanzu/tanzu-plugin-runtime/plugin/buildinfo.Version=%s'", ldflags, version)
@@ -110,13 +124,25 @@ func setGlobals(compileArgs *PluginCompileArgs) {
 		ldflags = fmt.Sprintf("%s -w -s", ldflags)
 	}
 
+	// Use the source date as the build date so that it does not vary between builds
+	if compileArgs.SourceDateEpoch > 0 {
+		buildDate := time.Unix(compileArgs.SourceDateEpoch, 0).UTC().Format("2006-01-02")
+		ldflags = fmt.Sprintf("%s -X 'github.com/vmware-tanzu/tanzu-plugin-runtime/plugin/buildinfo.Date=%s'", ldflags, buildDate)
+	}
+
 	// Disable function inlining to reduce binary size
-	disableInlining := "-gcflags=all=-l"
+	defaultGoFlags := "-gcflags=all=-l"
+	if compileArgs.Reproducible {
+		// Remove the file system paths, the VCS information and the build ID from the
+		// binaries so that identical sources produce identical binaries
+		defaultGoFlags = fmt.Sprintf("%s -trimpath -buildvcs=false", defaultGoFlags)
+		ldflags = fmt.Sprintf("%s -buildid=", ldflags)
+	}
 	if goflags != "" {
 		// Append the user-defined goflags so they can override the default if needed
-		goflags = fmt.Sprintf("%s %s", disableInlining, goflags)
+		goflags = fmt.Sprintf("%s %s", defaultGoFlags, goflags)
 	} else {
-		goflags = disableInlining
+		goflags = defaultGoFlags
 	}
 }
 
@@ -126,8 +152,12 @@ func Compile(compileArgs *PluginCompileArgs) error {
 
 	log.Infof("building local repository at %s, %v, %v", compileArgs.ArtifactsDir, compileArgs.Version, compileArgs.TargetArch)
 
+	createdTime := time.Now()
+	if compileArgs.SourceDateEpoch > 0 {
+		createdTime = time.Unix(compileArgs.SourceDateEpoch, 0).UTC()
+	}
 	manifest := cli.Manifest{
-		CreatedTime: time.Now(),
+		CreatedTime: createdTime,
 		Plugins:     []cli.Plugin{},
 	}
 
@@ -158,10 +188,11 @@ func Compile(compileArgs *PluginCompileArgs) error {
 						fatalErrors <- helpers.ErrInfo{Err: err, Path: fullPath, ID: id}
 					} else {
 						plug := cli.Plugin{
-							Name:        p.Name,
-							Description: p.Description,
-							Target:      p.target,
-							Versions:    []string{p.Version},
+							Name:                 p.Name,
+							Description:          p.Description,
+							Target:               p.target,
+							Versions:             []string{p.Version},
+							PluginRuntimeVersion: p.runtimeVersion,
 						}
 						plugins <- plug
 					}
@@ -207,6 +238,13 @@ func Compile(compileArgs *PluginCompileArgs) error {
 		return err
 	}
 
+	if compileArgs.GroupByOSArch {
+		err = saveBuildReport(compileArgs.ArtifactsDir, compileArgs.SourceDateEpoch)
+		if err != nil {
+			return err
+		}
+	}
+
 	log.Success("successfully built local repository")
 	return nil
 }
@@ -249,6 +287,12 @@ func buildPlugin(path, id string) (plugin, error) {
 		return plugin{}, err
 	}
 
+	// The info command also provides the version of the plugin runtime the plugin is built with
+	var runtimeInfo struct {
+		PluginRuntimeVersion string `json:"pluginRuntimeVersion"`
+	}
+	_ = json.Unmarshal(b, &runtimeInfo)
+
 	testPath := filepath.Join(path, "test")
 	_, err = os.Stat(testPath)
 	if err != nil {
@@ -276,6 +320,7 @@ func buildPlugin(path, id string) (plugin, error) {
 		docPath:          docPath,
 		buildID:          id,
 		target:           target,
+		runtimeVersion:   runtimeInfo.PluginRuntimeVersion,
 	}
 
 	if modPath != "" {
@@ -383,6 +428,18 @@ var archMap = map[cli.Arch]targetBuilder{
 			},
 		}
 	},
+	cli.LinuxPPC64LE: func(pluginName, outPath string) target {
+		return target{
+			env: []string{
+				CGOEnabled(),
+				"GOARCH=ppc64le",
+				"GOOS=linux",
+			},
+			args: []string{
+				"-o", filepath.Join(outPath, cli.MakeArtifactName(pluginName, cli.LinuxPPC64LE)),
+			},
+		}
+	},
 	cli.DarwinAMD64: func(pluginName, outPath string) target {
 		return target{
 			env: []string{
@@ -440,6 +497,41 @@ var archMap = map[cli.Arch]targetBuilder{
 	},
 }
 
+// optInArchs are the architectures which are only built when explicitly
+// requested and are not part of AllTargets.
+var optInArchs = []cli.Arch{cli.LinuxPPC64LE}
+
+// getAllTargets returns the targets built when AllTargets is requested.
+func getAllTargets() map[cli.Arch]targetBuilder {
+	targets := map[cli.Arch]targetBuilder{}
+	for arch, builder := range archMap {
+		if !slices.Contains(optInArchs, arch) {
+			targets[arch] = builder
+		}
+	}
+	return targets
+}
+
+// IsSupportedBuildArch returns true if 'osArch' is a valid value for the
+// architectures to build.
+func IsSupportedBuildArch(osArch string) bool {
+	if osArch == string(AllTargets) || osArch == local {
+		return true
+	}
+	if _, ok := archMap[cli.Arch(osArch)]; ok {
+		return true
+	}
+	_, ok := universalArchMap[cli.Arch(osArch)]
+	return ok
+}
+
+// universalArchMap maps each universal arch to the architectures it combines.
+// Universal binaries are only built when explicitly requested and are not part
+// of AllTargets.
+var universalArchMap = map[cli.Arch][]cli.Arch{
+	cli.DarwinUniversal: {cli.DarwinAMD64, cli.DarwinARM64},
+}
+
 func (p *plugin) compile() error {
 	absArtifactsDir, err := filepath.Abs(artifactsDir)
 	if err != nil {
@@ -479,15 +571,20 @@ func buildTargets(targetPath, artifactsDir, pluginName, target, id, modPath stri
 	}
 
 	targets := map[cli.Arch]targetBuilder{}
+	universalTargets := []cli.Arch{}
 	for _, buildArch := range targetArch {
 		if buildArch == string(AllTargets) {
-			targets = archMap
+			for arch, builder := range getAllTargets() {
+				targets[arch] = builder
+			}
 		} else if buildArch == local {
 			localArch := cli.BuildArch()
 			targets[localArch] = archMap[localArch]
 		} else {
 			bArch := cli.Arch(buildArch)
-			if val, ok := archMap[bArch]; !ok {
+			if _, ok := universalArchMap[bArch]; ok {
+				universalTargets = append(universalTargets, bArch)
+			} else if val, ok := archMap[bArch]; !ok {
 				log.Errorf("%q build architecture is not supported", buildArch)
 			} else {
 				targets[cli.Arch(buildArch)] = val
@@ -496,20 +593,144 @@ func buildTargets(targetPath, artifactsDir, pluginName, target, id, modPath stri
 	}
 
 	for arch, targetBuilder := range targets {
-		pn := pluginName
+		pn, outputDir := getTargetOutput(artifactsDir, pluginName, target, arch, isTest)
 
-		outputDir := artifactsDir
-		if groupByOSArch {
-			outputDir = filepath.Join(outputDir, arch.OS(), arch.Arch(), target)
+		tgt := targetBuilder(pn, outputDir)
+		binaryPath := filepath.Join(outputDir, cli.MakeArtifactName(pn, arch))
+		buildHash, err := tgt.buildHash(targetPath, modPath, ldflags, tags, goflags)
+		if err != nil {
+			return err
 		}
-		outputDir = filepath.Join(outputDir, pn, version)
-		if isTest {
-			outputDir = filepath.Join(outputDir, "test")
-			pn = fmt.Sprintf("%s-test", pn)
+		if isUpToDate(binaryPath, buildHash) {
+			log.Infof("%sskipping %q which is up to date", id, binaryPath)
+			continue
 		}
 
-		tgt := targetBuilder(pn, outputDir)
-		err := tgt.build(targetPath, id, modPath, ldflags, tags, goflags)
+		err = tgt.build(targetPath, id, modPath, ldflags, tags, goflags)
+		if err != nil {
+			return err
+		}
+
+		if !isTest {
+			err = saveSBOMs(binaryPath, outputDir, pn, id)
+			if err != nil {
+				return err
+			}
+		}
+
+		err = saveBuildHash(binaryPath, buildHash)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, arch := range universalTargets {
+		pn, outputDir := getTargetOutput(artifactsDir, pluginName, target, arch, isTest)
+
+		err := buildUniversalTarget(targetPath, id, modPath, pn, outputDir, arch, isTest)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getTargetOutput returns the name of the plugin binary and the directory in which
+// it must be generated for the specified arch.
+func getTargetOutput(artifactsDir, pluginName, target string, arch cli.Arch, isTest bool) (string, string) {
+	pn := pluginName
+
+	outputDir := artifactsDir
+	if groupByOSArch {
+		outputDir = filepath.Join(outputDir, arch.OS(), arch.Arch(), target)
+	}
+	outputDir = filepath.Join(outputDir, pn, version)
+	if isTest {
+		outputDir = filepath.Join(outputDir, "test")
+		pn = fmt.Sprintf("%s-test", pn)
+	}
+	return pn, outputDir
+}
+
+// buildUniversalTarget builds the binaries of each architecture combined by the
+// 'universalArch' and merges them into a single universal binary using 'lipo'.
+func buildUniversalTarget(targetPath, prefix, modPath, pluginName, outputDir string, universalArch cli.Arch, isTest bool) error {
+	lipo, err := exec.LookPath("lipo")
+	if err != nil {
+		return fmt.Errorf("building %q binaries requires the 'lipo' tool: %w", universalArch, err)
+	}
+
+	tmpDir, err := os.MkdirTemp("", "tanzu-builder-universal")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	// The universal binary is up to date if all the binaries it combines are
+	binaryPath := filepath.Join(outputDir, cli.MakeArtifactName(pluginName, universalArch))
+	var buildHashes []string
+	for _, arch := range universalArchMap[universalArch] {
+		buildHash, err := archMap[arch](pluginName, tmpDir).buildHash(targetPath, modPath, ldflags, tags, goflags)
+		if err != nil {
+			return err
+		}
+		buildHashes = append(buildHashes, buildHash)
+	}
+	buildHash := strings.Join(buildHashes, ",")
+	if isUpToDate(binaryPath, buildHash) {
+		log.Infof("%sskipping %q which is up to date", prefix, binaryPath)
+		return nil
+	}
+
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return err
+	}
+
+	cmd := exec.Command(lipo, "-create", "-output", binaryPath)
+	for _, arch := range universalArchMap[universalArch] {
+		tgt := archMap[arch](pluginName, tmpDir)
+		err := tgt.build(targetPath, prefix, modPath, ldflags, tags, goflags)
+		if err != nil {
+			return err
+		}
+		cmd.Args = append(cmd.Args, filepath.Join(tmpDir, cli.MakeArtifactName(pluginName, arch)))
+	}
+
+	log.Infof("%s$ %s", prefix, cmd.String())
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		log.Errorf("%serror: %v", prefix, err)
+		log.Errorf("%soutput: %v", prefix, string(output))
+		return err
+	}
+
+	if !isTest {
+		// The build information cannot be read from a universal binary, but it is the
+		// same as the one of each of the binaries it combines
+		componentArch := universalArchMap[universalArch][0]
+		err = saveSBOMs(filepath.Join(tmpDir, cli.MakeArtifactName(pluginName, componentArch)), outputDir, pluginName, prefix)
+		if err != nil {
+			return err
+		}
+	}
+	return saveBuildHash(binaryPath, buildHash)
+}
+
+// saveSBOMs generates the SBOM of the plugin binary in each of the requested
+// formats and saves them in the output directory.
+func saveSBOMs(binaryPath, outputDir, pluginName, prefix string) error {
+	created := time.Now()
+	if sourceDateEpoch > 0 {
+		created = time.Unix(sourceDateEpoch, 0)
+	}
+
+	for _, format := range sbomFormats {
+		log.Infof("%sgenerating %s SBOM for %q", prefix, format, binaryPath)
+		b, err := sbom.Generate(binaryPath, pluginName, version, format, created)
+		if err != nil {
+			return err
+		}
+		err = os.WriteFile(filepath.Join(outputDir, format.FileName()), b, 0644)
 		if err != nil {
 			return err
 		}
@@ -4,6 +4,7 @@
 package command
 
 import (
+	"path/filepath"
 	"testing"
 
 	"github.com/tj/assert"
@@ -196,3 +197,101 @@ func TestMergePluginGroupManifest(t *testing.T) {
 		assert.Equal(foundPlugin.Version, plugin.Version)
 	}
 }
+
+func TestGetTargetOutput(t *testing.T) {
+	assert := assert.New(t)
+
+	origGroupByOSArch, origVersion := groupByOSArch, version
+	defer func() {
+		groupByOSArch, version = origGroupByOSArch, origVersion
+	}()
+	groupByOSArch = true
+	version = "v1.0.0"
+
+	tests := []struct {
+		arch         cli.Arch
+		isTest       bool
+		expectedName string
+		expectedDir  string
+	}{
+		{cli.LinuxARM64, false, "foo", filepath.Join("artifacts", "linux", "arm64", "global", "foo", "v1.0.0")},
+		{cli.LinuxPPC64LE, false, "foo", filepath.Join("artifacts", "linux", "ppc64le", "global", "foo", "v1.0.0")},
+		{cli.WinARM64, false, "foo", filepath.Join("artifacts", "windows", "arm64", "global", "foo", "v1.0.0")},
+		{cli.DarwinUniversal, false, "foo", filepath.Join("artifacts", "darwin", "universal", "global", "foo", "v1.0.0")},
+		{cli.LinuxPPC64LE, true, "foo-test", filepath.Join("artifacts", "linux", "ppc64le", "global", "foo", "v1.0.0", "test")},
+	}
+	for _, tt := range tests {
+		name, dir := getTargetOutput("artifacts", "foo", "global", tt.arch, tt.isTest)
+		assert.Equal(tt.expectedName, name)
+		assert.Equal(tt.expectedDir, dir)
+	}
+
+	groupByOSArch = false
+	name, dir := getTargetOutput("artifacts", "foo", "global", cli.LinuxPPC64LE, false)
+	assert.Equal("foo", name)
+	assert.Equal(filepath.Join("artifacts", "foo", "v1.0.0"), dir)
+}
+
+func TestArchMapTargets(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		arch         cli.Arch
+		expectedEnv  []string
+		expectedFile string
+	}{
+		{cli.LinuxARM64, []string{"GOARCH=arm64", "GOOS=linux"}, "tanzu-foo-linux_arm64"},
+		{cli.LinuxPPC64LE, []string{"GOARCH=ppc64le", "GOOS=linux"}, "tanzu-foo-linux_ppc64le"},
+		{cli.WinARM64, []string{"GOARCH=arm64", "GOOS=windows"}, "tanzu-foo-windows_arm64.exe"},
+	}
+	for _, tt := range tests {
+		targetBuilder, ok := archMap[tt.arch]
+		assert.True(ok, "missing target for %s", tt.arch)
+
+		tgt := targetBuilder("foo", "out")
+		for _, env := range tt.expectedEnv {
+			assert.Contains(tgt.env, env)
+		}
+		assert.Equal([]string{"-o", filepath.Join("out", tt.expectedFile)}, tgt.args)
+	}
+}
+
+func TestGetBuildArch(t *testing.T) {
+	assert := assert.New(t)
+
+	allArch := getBuildArch([]string{string(AllTargets)})
+	assert.Contains(allArch, cli.LinuxARM64)
+	assert.Contains(allArch, cli.WinARM64)
+	assert.NotContains(allArch, cli.LinuxPPC64LE)
+	assert.NotContains(allArch, cli.DarwinUniversal)
+
+	allArch = getBuildArch([]string{string(AllTargets), string(cli.LinuxPPC64LE)})
+	assert.Contains(allArch, cli.LinuxPPC64LE)
+
+	assert.Equal([]cli.Arch{cli.DarwinUniversal}, getBuildArch([]string{string(cli.DarwinUniversal)}))
+}
+
+func TestIsSupportedBuildArch(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, osArch := range []string{"all", "local", "linux_arm64", "linux_ppc64le", "windows_arm64", "darwin_universal"} {
+		assert.True(IsSupportedBuildArch(osArch), osArch)
+	}
+	for _, osArch := range []string{"", "linux", "linux_s390x", "windows_universal"} {
+		assert.False(IsSupportedBuildArch(osArch), osArch)
+	}
+}
+
+func TestSetGlobalsReproducible(t *testing.T) {
+	assert := assert.New(t)
+
+	setGlobals(&PluginCompileArgs{Version: "v1.0.0", GoFlags: "-race"})
+	assert.Equal("-gcflags=all=-l -race", goflags)
+	assert.NotContains(ldflags, "-buildid=")
+	assert.NotContains(ldflags, "buildinfo.Date")
+
+	setGlobals(&PluginCompileArgs{Version: "v1.0.0", GoFlags: "-race", Reproducible: true, SourceDateEpoch: 1700000000})
+	assert.Equal("-gcflags=all=-l -trimpath -buildvcs=false -race", goflags)
+	assert.Contains(ldflags, "-buildid=")
+	assert.Contains(ldflags, "-X 'github.com/vmware-tanzu/tanzu-plugin-runtime/plugin/buildinfo.Date=2023-11-14'")
+}
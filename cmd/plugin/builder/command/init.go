@@ -6,8 +6,14 @@ package command
 import (
 	"fmt"
 	"os/exec"
+	"path"
+	"regexp"
 	"strings"
 
+	"github.com/pkg/errors"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+
 	"github.com/vmware-tanzu/tanzu-cli/cmd/plugin/builder/template"
 	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
 )
@@ -16,34 +22,126 @@ const (
 	github = "github"
 )
 
+// scaffoldData is the data used to render the scaffolding templates.
+type scaffoldData struct {
+	RepositoryName string
+	PluginName     string
+	Description    string
+	// Target of the plugin as specified in the plugin-scope-association file
+	Target string
+	// TargetType is the name of the tanzu-plugin-runtime constant for the Target,
+	// it is empty if the target of the plugin is to be set by the developer.
+	TargetType string
+}
+
+// targetTypes maps the plugin targets to the name of their tanzu-plugin-runtime constant
+var targetTypes = map[configtypes.Target]string{
+	configtypes.TargetGlobal:     "TargetGlobal",
+	configtypes.TargetK8s:        "TargetK8s",
+	configtypes.TargetTMC:        "TargetTMC",
+	configtypes.TargetOperations: "TargetOperations",
+}
+
+var pluginNameRegexp = regexp.MustCompile(`^[a-z][a-z0-9-]*$`)
+
 func Initialize(name, repoType string, dryRun bool) error {
-	data := struct {
-		RepositoryName string
-	}{
+	data := scaffoldData{
 		RepositoryName: name,
 	}
-	targets := template.DefaultInitTargets
+	err := runTargets(name, getInitTargets(repoType), data, dryRun)
+	if err != nil || dryRun {
+		return err
+	}
+
+	err = gitInit(name)
+	if err != nil {
+		return err
+	}
+	log.Success("successfully created repository")
+	return nil
+}
+
+// PluginInitArgs contains the values to use for scaffolding a plugin repository.
+type PluginInitArgs struct {
+	PluginName  string
+	ModuleName  string
+	Description string
+	Target      string
+	RepoType    string
+	DryRun      bool
+}
+
+// InitializePlugin generates a new repository, in a directory named after the
+// last element of the module name, containing the skeleton of a single plugin
+// which can be built, tested and published using the builder.
+func InitializePlugin(initArgs *PluginInitArgs) error {
+	if !pluginNameRegexp.MatchString(initArgs.PluginName) {
+		return errors.Errorf("invalid plugin name %q, it must start with a lowercase letter and only contain lowercase letters, digits and dashes", initArgs.PluginName)
+	}
+	if initArgs.Description == "" {
+		return errors.New("plugin description is required")
+	}
+	target := configtypes.StringToTarget(strings.ToLower(initArgs.Target))
+	targetType, ok := targetTypes[target]
+	if !ok {
+		return errors.Errorf("invalid target %q for plugin %q", initArgs.Target, initArgs.PluginName)
+	}
+
+	moduleName := initArgs.ModuleName
+	if moduleName == "" {
+		moduleName = initArgs.PluginName
+	}
+	rootDir := path.Base(moduleName)
+
+	data := scaffoldData{
+		RepositoryName: moduleName,
+		PluginName:     initArgs.PluginName,
+		Description:    initArgs.Description,
+		Target:         string(target),
+		TargetType:     targetType,
+	}
+	targets := getInitTargets(initArgs.RepoType)
+	targets = append(targets, template.DefaultPluginTargets...)
+	targets = append(targets, template.PluginScopeAssociation)
+
+	err := runTargets(rootDir, targets, data, initArgs.DryRun)
+	if err != nil || initArgs.DryRun {
+		return err
+	}
+
+	err = gitInit(rootDir)
+	if err != nil {
+		return err
+	}
+	log.Successf("successfully created the repository of plugin %q in %q", initArgs.PluginName, rootDir)
+	log.Infof("run 'make gomod' in %q to fetch the dependencies, then 'make plugin-build-install-local' to build and install the plugin", rootDir)
+	return nil
+}
+
+// getInitTargets returns the targets of a new repository using the CI of the repository type.
+func getInitTargets(repoType string) []template.Target {
+	targets := append([]template.Target{}, template.DefaultInitTargets...)
 	if strings.EqualFold(repoType, github) {
-		targets = append(targets, template.GitHubCI)
-	} else {
-		targets = append(targets, template.GitLabCI)
+		return append(targets, template.GitHubCI)
 	}
+	return append(targets, template.GitLabCI)
+}
+
+func runTargets(rootDir string, targets []template.Target, data scaffoldData, dryRun bool) error {
 	for _, target := range targets {
-		err := target.Run(name, data, dryRun)
+		err := target.Run(rootDir, data, dryRun)
 		if err != nil {
 			return err
 		}
 	}
+	return nil
+}
 
-	if dryRun {
-		return nil
-	}
-
-	c := exec.Command("git", "init", name)
+func gitInit(dir string) error {
+	c := exec.Command("git", "init", dir)
 	b, err := c.CombinedOutput()
 	if err != nil {
 		return fmt.Errorf("%s -- %s", err, string(b))
 	}
-	log.Success("successfully created repository")
 	return nil
 }
@@ -57,3 +57,67 @@ func Test_BuilderInitAndAddPlugin(t *testing.T) {
 	err = cmd.Execute()
 	assert.Nil(err)
 }
+
+func Test_BuilderPluginInit(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := os.MkdirTemp("", "plugin-init")
+	if err != nil {
+		t.Error(err)
+	}
+	defer os.RemoveAll(dir)
+	err = os.Chdir(dir)
+	assert.Nil(err)
+
+	var stdout, stderr bytes.Buffer
+
+	// Assert an invalid target is rejected
+	cmd := newPluginInitCmd()
+	cmd.SetArgs([]string{"foo", "--description", "manage foo", "--target", "invalid"})
+	cmd.SetOut(&stdout)
+	cmd.SetErr(&stderr)
+	err = cmd.Execute()
+	assert.NotNil(err)
+	assert.Contains(err.Error(), `invalid target "invalid"`)
+
+	// Assert dry-run does not create a repo
+	cmd = newPluginInitCmd()
+	cmd.SetArgs([]string{"foo", "--description", "manage foo", "--module", "example.com/tanzu-foo-plugin", "--dry-run"})
+	cmd.SetOut(&stdout)
+	cmd.SetErr(&stderr)
+	err = cmd.Execute()
+	assert.Nil(err)
+	_, err = os.Stat(filepath.Join(dir, "tanzu-foo-plugin"))
+	assert.True(os.IsNotExist(err))
+
+	// Assert repo creation
+	cmd = newPluginInitCmd()
+	cmd.SetArgs([]string{"foo", "--description", "manage foo", "--module", "example.com/tanzu-foo-plugin", "--target", "k8s"})
+	cmd.SetOut(&stdout)
+	cmd.SetErr(&stderr)
+	err = cmd.Execute()
+	assert.Nil(err)
+
+	repoDir := filepath.Join(dir, "tanzu-foo-plugin")
+	for _, f := range []string{"Makefile", "plugin-tooling.mk", ".github/workflows/build.yaml", "cmd/plugin/foo/README.md", "cmd/plugin/foo/test/main.go"} {
+		_, err = os.Stat(filepath.Join(repoDir, f))
+		assert.Nil(err, f)
+	}
+
+	b, err := os.ReadFile(filepath.Join(repoDir, "go.mod"))
+	assert.Nil(err)
+	assert.Contains(string(b), "module example.com/tanzu-foo-plugin")
+
+	b, err = os.ReadFile(filepath.Join(repoDir, "cmd", "plugin", "foo", "main.go"))
+	assert.Nil(err)
+	assert.Contains(string(b), "Target:      types.TargetK8s,")
+	assert.NotContains(string(b), "FIXME")
+
+	b, err = os.ReadFile(filepath.Join(repoDir, "cmd", "plugin", "plugin-scope-association.yaml"))
+	assert.Nil(err)
+	assert.Contains(string(b), "- name: foo\n  target: kubernetes\n")
+
+	b, err = os.ReadFile(filepath.Join(repoDir, "Makefile"))
+	assert.Nil(err)
+	assert.Contains(string(b), "PLUGIN_SCOPE_ASSOCIATION_FILE ?= ./cmd/plugin/plugin-scope-association.yaml")
+}
@@ -4,11 +4,21 @@
 package crane
 
 import (
+	"archive/tar"
+	"crypto/sha256"
+	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 
 	"github.com/google/go-containerregistry/cmd/crane/cmd"
+	"github.com/google/go-containerregistry/pkg/authn"
 	"github.com/google/go-containerregistry/pkg/crane"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/pkg/errors"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/sbom"
 )
 
 // CraneOptions implements the CraneWrapper interface by using `crane` library
@@ -30,3 +40,43 @@ func (co *CraneOptions) PushImage(pluginTarFilePath, image string) error {
 	cranePushCmd := cmd.NewCmdPush(&[]crane.Option{})
 	return cranePushCmd.RunE(cranePushCmd, []string{pluginTarFilePath, image})
 }
+
+// AttachSBOM publish the SBOM file as an artifact referring to the remote image
+func (co *CraneOptions) AttachSBOM(image, sbomFilePath string, format sbom.Format) error {
+	content, err := os.ReadFile(sbomFilePath)
+	if err != nil {
+		return err
+	}
+	return sbom.Attach(image, content, format, remote.WithAuthFromKeychain(authn.DefaultKeychain))
+}
+
+// GetFileDigestFromTar returns the sha256 digest of a file included in the image saved as a tar file
+func (co *CraneOptions) GetFileDigestFromTar(pluginTarFilePath, fileName string) (string, error) {
+	img, err := crane.Load(pluginTarFilePath)
+	if err != nil {
+		return "", err
+	}
+
+	reader := mutate.Extract(img)
+	defer reader.Close()
+
+	tr := tar.NewReader(reader)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+		if header.Typeflag != tar.TypeReg || filepath.Base(header.Name) != fileName {
+			continue
+		}
+		hash := sha256.New()
+		if _, err := io.Copy(hash, tr); err != nil { //nolint:gosec
+			return "", err
+		}
+		return fmt.Sprintf("%x", hash.Sum(nil)), nil
+	}
+	return "", errors.Errorf("file %q not found in %q", fileName, pluginTarFilePath)
+}
@@ -4,12 +4,20 @@
 // Package crane implements helper function for crane library
 package crane
 
+import (
+	"github.com/vmware-tanzu/tanzu-cli/pkg/sbom"
+)
+
 // CraneWrapper defines the crane command wrapper functions
 type CraneWrapper interface {
 	// SaveImage image as an tar file
 	SaveImage(image, pluginTarFilePath string) error
 	// PushImage publish the tar file to remote container registry
 	PushImage(pluginTarFilePath, image string) error
+	// AttachSBOM publish the SBOM file as an artifact referring to the remote image
+	AttachSBOM(image, sbomFilePath string, format sbom.Format) error
+	// GetFileDigestFromTar returns the sha256 digest of a file included in the image saved as a tar file
+	GetFileDigestFromTar(pluginTarFilePath, fileName string) (string, error)
 }
 
 // NewCraneWrapper creates new CraneWrapper instance
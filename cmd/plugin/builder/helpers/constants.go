@@ -5,4 +5,10 @@ package helpers
 
 const (
 	PluginInventoryDBImageName = "plugin-inventory"
+	PluginAssociationImageName = "plugin-association"
+	PluginAssociationFileName  = "plugin-association.yaml"
+
+	// compressedPluginImageSuffix is appended to the repository of the image of a plugin binary
+	// to get the repository of the image of the zstd-compressed plugin binary
+	compressedPluginImageSuffix = "/zstd"
 )
@@ -10,6 +10,7 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/pkg/errors"
 
@@ -56,6 +57,24 @@ func GetPluginArchiveRelativePath(plugin cli.Plugin, osArch cli.Arch, version st
 	return filepath.Join(osArch.OS(), osArch.Arch(), plugin.Target, plugin.Name, version, pluginTarFileName)
 }
 
+// GetCompressedPluginArchiveRelativePath creates the relative path of the archive of the
+// zstd-compressed plugin binary from metadata
+func GetCompressedPluginArchiveRelativePath(plugin cli.Plugin, osArch cli.Arch, version string) string {
+	pluginTarFileName := fmt.Sprintf("%s-%s-zstd.tar", plugin.Name, osArch.String())
+	return filepath.Join(osArch.OS(), osArch.Arch(), plugin.Target, plugin.Name, version, pluginTarFileName)
+}
+
+// GetCompressedPluginImage returns the image of the zstd-compressed plugin binary from the
+// image of the plugin binary referenced by tag, e.g. "<repository>/<name>/zstd:<version>"
+// for "<repository>/<name>:<version>"
+func GetCompressedPluginImage(pluginImage string) string {
+	idx := strings.LastIndex(pluginImage, ":")
+	if idx == -1 || strings.Contains(pluginImage[idx:], "/") {
+		return pluginImage + compressedPluginImageSuffix
+	}
+	return pluginImage[:idx] + compressedPluginImageSuffix + pluginImage[idx:]
+}
+
 // GetDigest computes the sha256 digest of the specified file
 func GetDigest(filePath string) (string, error) {
 	f, err := os.Open(filePath)
@@ -95,7 +114,15 @@ func GetNumberOfIndividualPluginBinariesFromManifest(pluginManifest *cli.Manifes
 	for i := range pluginManifest.Plugins {
 		numberOfPluginPackages += len(pluginManifest.Plugins[i].Versions)
 	}
-	return numberOfPluginPackages * len(cli.AllOSArch)
+	return numberOfPluginPackages * len(GetAllOSArch())
+}
+
+// GetAllOSArch returns all the OS/ARCH combinations for which plugin binaries
+// can be packaged and published, including universal binaries.
+func GetAllOSArch() []cli.Arch {
+	allOSArch := make([]cli.Arch, 0, len(cli.AllOSArch)+len(cli.UniversalOSArch))
+	allOSArch = append(allOSArch, cli.AllOSArch...)
+	return append(allOSArch, cli.UniversalOSArch...)
 }
 
 // IsRequiredOSArch returns true if 'osArch' is one of the require OSArch
@@ -108,3 +135,22 @@ func IsRequiredOSArch(osArch cli.Arch) bool {
 	}
 	return false
 }
+
+// GetHostPluginBinaryPath returns the path of the plugin binary, in the binary
+// artifact directory, that can be run on the host or an empty string if there is none.
+func GetHostPluginBinaryPath(binaryArtifactDir string, p *cli.Plugin, version string) string {
+	osArchs := []cli.Arch{cli.BuildArch()}
+	for _, osArch := range cli.UniversalOSArch {
+		if osArch.OS() == cli.BuildArch().OS() {
+			osArchs = append(osArchs, osArch)
+		}
+	}
+	for _, osArch := range osArchs {
+		binaryPath := filepath.Join(binaryArtifactDir, osArch.OS(), osArch.Arch(),
+			p.Target, p.Name, version, cli.MakeArtifactName(p.Name, osArch))
+		if utils.PathExists(binaryPath) {
+			return binaryPath
+		}
+	}
+	return ""
+}
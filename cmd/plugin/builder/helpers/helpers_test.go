@@ -8,6 +8,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
 )
 
 func TestNonEmptyValidatePluginBinary(t *testing.T) {
@@ -59,3 +61,16 @@ func TestEmptyValidatePluginBinary(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, false, valid)
 }
+
+func TestGetAllOSArch(t *testing.T) {
+	allOSArch := GetAllOSArch()
+	assert.Equal(t, len(cli.AllOSArch)+len(cli.UniversalOSArch), len(allOSArch))
+	assert.Contains(t, allOSArch, cli.DarwinUniversal)
+	assert.Equal(t, cli.AllOSArch[0], allOSArch[0])
+}
+
+func TestGetCompressedPluginImage(t *testing.T) {
+	assert.Equal(t, "localhost:5000/vmware/tkg/linux/amd64/global/foo/zstd:v1.0.0", GetCompressedPluginImage("localhost:5000/vmware/tkg/linux/amd64/global/foo:v1.0.0"))
+	assert.Equal(t, "vmware/tkg/linux/amd64/global/foo/zstd:v1.0.0", GetCompressedPluginImage("vmware/tkg/linux/amd64/global/foo:v1.0.0"))
+	assert.Equal(t, "localhost:5000/vmware/foo/zstd", GetCompressedPluginImage("localhost:5000/vmware/foo"))
+}
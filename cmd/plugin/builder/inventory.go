@@ -8,6 +8,7 @@ import (
 
 	"github.com/vmware-tanzu/tanzu-cli/cmd/plugin/builder/inventory"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/carvelhelpers"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/plugininventory"
 )
 
 // newInventoryCmd creates a new command for inventory operations.
@@ -21,6 +22,9 @@ func newInventoryCmd() *cobra.Command {
 		newInventoryInitCmd(),
 		newInventoryPluginCmd(),
 		newInventoryPluginGroupCmd(),
+		newInventoryPluginAdvisoryCmd(),
+		newInventoryMigrateCmd(),
+		newInventoryGenerateCmd(),
 	)
 
 	return inventoryCmd
@@ -57,3 +61,125 @@ func newInventoryInitCmd() *cobra.Command {
 
 	return pluginInventoryInitCmd
 }
+
+type inventoryMigrateFlags struct {
+	DiscoveryDir      string
+	DistributionDir   string
+	Repository        string
+	InventoryImageTag string
+	Publisher         string
+	Vendor            string
+	InventoryDBFile   string
+	DeactivatePlugins bool
+	DryRun            bool
+}
+
+func newInventoryMigrateCmd() *cobra.Command {
+	var imFlags = &inventoryMigrateFlags{}
+
+	var inventoryMigrateCmd = &cobra.Command{
+		Use:   "migrate",
+		Short: "Migrate the plugins of a legacy discovery to the inventory database available on the remote repository",
+		Long: `Migrate the plugins of a legacy local discovery, made of CLIPlugin resources, to a central repository.
+The plugin binaries referenced by the CLIPlugin resources are published as OCI images of the repository
+and the plugins are added to the inventory database.  Plugin binaries referenced through a relative URI
+are looked up in the legacy distribution directory.`,
+		SilenceUsage: true,
+		Example: `
+    # Migrate the plugins of a legacy discovery and distribution to the repository
+    tanzu builder inventory migrate --discovery-dir ./legacy/discovery/standalone --distribution-dir ./legacy/distribution --repository localhost:5002/test/v1/tanzu-cli/plugins --vendor vmware --publisher tkg
+
+    # Show the plugins that would be migrated without publishing anything
+    tanzu builder inventory migrate --discovery-dir ./legacy/discovery/standalone --distribution-dir ./legacy/distribution --repository localhost:5002/test/v1/tanzu-cli/plugins --vendor vmware --publisher tkg --dry-run`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			imOptions := inventory.InventoryMigrateOptions{
+				DiscoveryDir:        imFlags.DiscoveryDir,
+				DistributionDir:     imFlags.DistributionDir,
+				Repository:          imFlags.Repository,
+				InventoryImageTag:   imFlags.InventoryImageTag,
+				Vendor:              imFlags.Vendor,
+				Publisher:           imFlags.Publisher,
+				InventoryDBFile:     imFlags.InventoryDBFile,
+				DeactivatePlugins:   imFlags.DeactivatePlugins,
+				DryRun:              imFlags.DryRun,
+				ImageOperationsImpl: carvelhelpers.NewImageOperationsImpl(),
+			}
+			return imOptions.Migrate()
+		},
+	}
+
+	inventoryMigrateCmd.Flags().StringVarP(&imFlags.DiscoveryDir, "discovery-dir", "", "", "directory of the legacy discovery containing the CLIPlugin resources")
+	inventoryMigrateCmd.Flags().StringVarP(&imFlags.DistributionDir, "distribution-dir", "", "", "directory of the legacy distribution in which to look up plugin binaries referenced through a relative URI")
+	inventoryMigrateCmd.Flags().StringVarP(&imFlags.Repository, "repository", "", "", "repository to publish plugins and the plugin inventory image")
+	inventoryMigrateCmd.Flags().StringVarP(&imFlags.InventoryImageTag, "plugin-inventory-image-tag", "", "latest", "tag to which plugin inventory image needs to be published")
+	inventoryMigrateCmd.Flags().StringVarP(&imFlags.Vendor, "vendor", "", "", "name of the vendor")
+	inventoryMigrateCmd.Flags().StringVarP(&imFlags.Publisher, "publisher", "", "", "name of the publisher")
+	inventoryMigrateCmd.Flags().StringVarP(&imFlags.InventoryDBFile, "plugin-inventory-db-file", "", "", "local file for the inventory database")
+	inventoryMigrateCmd.Flags().BoolVarP(&imFlags.DeactivatePlugins, "deactivate", "", false, "mark plugins as deactivated")
+	inventoryMigrateCmd.Flags().BoolVarP(&imFlags.DryRun, "dry-run", "", false, "show the plugins that would be migrated without publishing them")
+
+	_ = inventoryMigrateCmd.MarkFlagRequired("discovery-dir")
+	_ = inventoryMigrateCmd.MarkFlagRequired("repository")
+	_ = inventoryMigrateCmd.MarkFlagRequired("vendor")
+	_ = inventoryMigrateCmd.MarkFlagRequired("publisher")
+
+	return inventoryMigrateCmd
+}
+
+type inventoryGenerateFlags struct {
+	InventoryDBFile   string
+	Repository        string
+	InventoryImageTag string
+	Plugins           int
+	Versions          int
+	Platforms         []string
+	Groups            int
+	PluginsPerGroup   int
+}
+
+func newInventoryGenerateCmd() *cobra.Command {
+	var igFlags = &inventoryGenerateFlags{}
+
+	var inventoryGenerateCmd = &cobra.Command{
+		Use:   "generate",
+		Short: "Generate a plugin inventory database of a configurable size for scale testing",
+		Long: `Generate a plugin inventory database containing generated plugins and plugin groups
+to measure the performance of the CLI with large central repositories. The generated plugins
+cannot be installed as no plugin binary is published.`,
+		Hidden:       true,
+		SilenceUsage: true,
+		Example: `
+    # Generate a database with 10000 plugins of 20 versions for 8 platforms
+    tanzu builder inventory generate --plugins 10000 --versions 20 --platforms linux/amd64,linux/arm64,darwin/amd64,darwin/arm64,windows/amd64,windows/arm64,linux/386,windows/386 --plugin-inventory-db-file ./plugin_inventory.db
+
+    # Generate a database and publish it to the repository
+    tanzu builder inventory generate --plugins 1000 --versions 10 --repository localhost:5002/test/v1/tanzu-cli/plugins`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			igOptions := inventory.InventoryGenerateOptions{
+				GeneratorOptions: plugininventory.GeneratorOptions{
+					Plugins:           igFlags.Plugins,
+					VersionsPerPlugin: igFlags.Versions,
+					Platforms:         igFlags.Platforms,
+					Groups:            igFlags.Groups,
+					PluginsPerGroup:   igFlags.PluginsPerGroup,
+				},
+				InventoryDBFile:     igFlags.InventoryDBFile,
+				Repository:          igFlags.Repository,
+				InventoryImageTag:   igFlags.InventoryImageTag,
+				ImageOperationsImpl: carvelhelpers.NewImageOperationsImpl(),
+			}
+			return igOptions.GenerateInventory()
+		},
+	}
+
+	inventoryGenerateCmd.Flags().StringVarP(&igFlags.InventoryDBFile, "plugin-inventory-db-file", "", "", "local file for the generated inventory database")
+	inventoryGenerateCmd.Flags().StringVarP(&igFlags.Repository, "repository", "", "", "repository to publish the generated plugin inventory image, if specified")
+	inventoryGenerateCmd.Flags().StringVarP(&igFlags.InventoryImageTag, "plugin-inventory-image-tag", "", "latest", "tag to which plugin inventory image needs to be published")
+	inventoryGenerateCmd.Flags().IntVarP(&igFlags.Plugins, "plugins", "", 1000, "number of plugins")
+	inventoryGenerateCmd.Flags().IntVarP(&igFlags.Versions, "versions", "", 10, "number of versions of each plugin")
+	inventoryGenerateCmd.Flags().StringSliceVarP(&igFlags.Platforms, "platforms", "", []string{"linux/amd64", "darwin/amd64", "windows/amd64"}, "platforms of the binaries of each plugin version, in the <os>/<arch> format")
+	inventoryGenerateCmd.Flags().IntVarP(&igFlags.Groups, "groups", "", 10, "number of plugin groups")
+	inventoryGenerateCmd.Flags().IntVarP(&igFlags.PluginsPerGroup, "plugins-per-group", "", 20, "number of plugins of each plugin group")
+
+	return inventoryGenerateCmd
+}
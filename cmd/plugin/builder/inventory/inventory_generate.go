@@ -0,0 +1,62 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package inventory
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
+
+	"github.com/vmware-tanzu/tanzu-cli/cmd/plugin/builder/helpers"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/carvelhelpers"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/plugininventory"
+)
+
+// InventoryGenerateOptions defines options for inventory generate
+type InventoryGenerateOptions struct {
+	plugininventory.GeneratorOptions
+
+	InventoryDBFile   string
+	Repository        string
+	InventoryImageTag string
+
+	ImageOperationsImpl carvelhelpers.ImageOperationsImpl
+}
+
+// GenerateInventory generates an inventory database of the requested size for
+// scale testing and publishes it to the repository if one is specified
+func (igo *InventoryGenerateOptions) GenerateInventory() error {
+	dbFile := igo.InventoryDBFile
+	if dbFile == "" {
+		dbFile = filepath.Join(os.TempDir(), plugininventory.SQliteDBFileName)
+	}
+	_ = os.Remove(dbFile)
+
+	start := time.Now()
+	err := plugininventory.GenerateInventoryDB(dbFile, &igo.GeneratorOptions)
+	if err != nil {
+		return errors.Wrap(err, "error while generating the database")
+	}
+	rows := igo.Plugins * igo.VersionsPerPlugin * len(igo.Platforms)
+	log.Infof("generated database with %d plugin binaries locally at: %q in %v", rows, dbFile, time.Since(start).Round(time.Millisecond))
+
+	if igo.Repository == "" {
+		return nil
+	}
+
+	pluginInventoryDBImage := fmt.Sprintf("%s/%s:%s", igo.Repository, helpers.PluginInventoryDBImageName, igo.InventoryImageTag)
+	log.Infof("publishing database at: %q", pluginInventoryDBImage)
+	err = igo.ImageOperationsImpl.PushImage(pluginInventoryDBImage, []string{dbFile})
+	if err != nil {
+		return errors.Wrapf(err, "error while publishing database to the repository as image: %q", pluginInventoryDBImage)
+	}
+	log.Infof("successfully published plugin inventory database")
+
+	return nil
+}
@@ -0,0 +1,72 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package inventory
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/fakes"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/plugininventory"
+)
+
+var _ = Describe("Unit tests for inventory generate", func() {
+	var (
+		tmpDir            string
+		fakeImgpkgWrapper *fakes.ImageOperationsImpl
+		igo               InventoryGenerateOptions
+	)
+
+	BeforeEach(func() {
+		var err error
+		tmpDir, err = os.MkdirTemp("", "")
+		Expect(err).To(BeNil())
+		fakeImgpkgWrapper = &fakes.ImageOperationsImpl{}
+		igo = InventoryGenerateOptions{
+			GeneratorOptions: plugininventory.GeneratorOptions{
+				Plugins:           5,
+				VersionsPerPlugin: 2,
+				Platforms:         []string{"linux/amd64"},
+			},
+			InventoryDBFile:     filepath.Join(tmpDir, plugininventory.SQliteDBFileName),
+			InventoryImageTag:   "latest",
+			ImageOperationsImpl: fakeImgpkgWrapper,
+		}
+	})
+	AfterEach(func() {
+		os.RemoveAll(tmpDir)
+	})
+
+	var _ = It("generates the database locally when no repository is specified", func() {
+		err := igo.GenerateInventory()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fakeImgpkgWrapper.PushImageCallCount()).To(Equal(0))
+
+		plugins, err := plugininventory.NewSQLiteInventory(igo.InventoryDBFile, "").GetAllPlugins()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(plugins).To(HaveLen(5))
+	})
+
+	var _ = It("publishes the database to the repository", func() {
+		igo.Repository = "test-repo.com"
+		err := igo.GenerateInventory()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fakeImgpkgWrapper.PushImageCallCount()).To(Equal(1))
+		image, files := fakeImgpkgWrapper.PushImageArgsForCall(0)
+		Expect(image).To(Equal("test-repo.com/plugin-inventory:latest"))
+		Expect(files).To(Equal([]string{igo.InventoryDBFile}))
+	})
+
+	var _ = It("fails when the database cannot be published", func() {
+		igo.Repository = "test-repo.com"
+		fakeImgpkgWrapper.PushImageReturns(errors.New("unable to push image"))
+		err := igo.GenerateInventory()
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("unable to push image"))
+	})
+})
@@ -0,0 +1,179 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package inventory
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/artifact"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/carvelhelpers"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/discovery"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/distribution"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/plugininventory"
+)
+
+// InventoryMigrateOptions defines options for migrating plugins from a legacy
+// discovery and distribution to the inventory database
+type InventoryMigrateOptions struct {
+	DiscoveryDir      string
+	DistributionDir   string
+	Repository        string
+	InventoryImageTag string
+	Publisher         string
+	Vendor            string
+	InventoryDBFile   string
+	DeactivatePlugins bool
+	DryRun            bool
+
+	ImageOperationsImpl carvelhelpers.ImageOperationsImpl
+}
+
+// Migrate reads the CLIPlugin resources of a legacy local discovery, publishes the plugin
+// binaries they refer to as OCI images of the repository and adds the plugins to the
+// inventory database.  Plugin binaries referenced through a relative URI are looked up
+// in the legacy distribution directory.
+func (imo *InventoryMigrateOptions) Migrate() error {
+	discoveryDir, err := filepath.Abs(imo.DiscoveryDir)
+	if err != nil {
+		return err
+	}
+	discovered, err := discovery.NewLocalDiscovery("legacy", discoveryDir).List()
+	if err != nil {
+		return errors.Wrapf(err, "unable to read the legacy discovery from %q", imo.DiscoveryDir)
+	}
+	if len(discovered) == 0 {
+		return errors.Errorf("no plugin found in the legacy discovery %q", imo.DiscoveryDir)
+	}
+
+	// The inventory database is updated following the same flow as 'inventory plugin add'
+	// where a dry-run only validates the updates against a copy of the database
+	ipuo := &InventoryPluginUpdateOptions{
+		Repository:          imo.Repository,
+		InventoryImageTag:   imo.InventoryImageTag,
+		InventoryDBFile:     imo.InventoryDBFile,
+		ValidateOnly:        imo.DryRun,
+		ImageOperationsImpl: imo.ImageOperationsImpl,
+	}
+	dbFile, err := ipuo.getInventoryDBFile()
+	if err != nil {
+		return err
+	}
+
+	tempDir, err := os.MkdirTemp("", "")
+	if err != nil {
+		return errors.Wrap(err, "unable to create temporary directory")
+	}
+	defer os.RemoveAll(tempDir)
+
+	db := plugininventory.NewSQLiteInventory(dbFile, "")
+	for i := range discovered {
+		entry, err := imo.migratePlugin(&discovered[i], tempDir)
+		if err != nil {
+			return errors.Wrapf(err, "error while migrating plugin %q", discovered[i].Name)
+		}
+		err = db.InsertPlugin(entry)
+		if err != nil {
+			return errors.Wrapf(err, "error while inserting plugin '%s_%s'", entry.Name, entry.Target)
+		}
+	}
+
+	return ipuo.putInventoryDBFile(dbFile)
+}
+
+// migratePlugin publishes every binary of the discovered plugin to the repository and
+// returns the corresponding plugin inventory entry
+func (imo *InventoryMigrateOptions) migratePlugin(dp *discovery.Discovered, tempDir string) (*plugininventory.PluginInventoryEntry, error) {
+	artifacts, ok := dp.Distribution.(distribution.Artifacts)
+	if !ok {
+		return nil, errors.Errorf("unsupported distribution for plugin %q", dp.Name)
+	}
+
+	target := dp.Target
+	if target == configtypes.TargetUnknown {
+		// Legacy standalone plugins did not always specify a target
+		target = configtypes.TargetGlobal
+	}
+
+	entry := &plugininventory.PluginInventoryEntry{
+		Name:        dp.Name,
+		Target:      target,
+		Description: dp.Description,
+		Publisher:   imo.Publisher,
+		Vendor:      imo.Vendor,
+		Artifacts:   make(map[string]distribution.ArtifactList),
+		Hidden:      imo.DeactivatePlugins,
+	}
+
+	for _, version := range dp.SupportedVersions {
+		for _, a := range artifacts[version] {
+			binary, err := imo.fetchLegacyBinary(a)
+			if err != nil {
+				return nil, errors.Wrapf(err, "unable to fetch the binary of version %q for %s_%s", version, a.OS, a.Arch)
+			}
+			digest := fmt.Sprintf("%x", sha256.Sum256(binary))
+			if a.Digest != "" && a.Digest != digest {
+				return nil, errors.Errorf("the digest of the binary of version %q for %s_%s is %q but %q was expected", version, a.OS, a.Arch, digest, a.Digest)
+			}
+
+			osArch := cli.Arch(fmt.Sprintf("%s_%s", a.OS, a.Arch))
+			pluginImageBasePath := fmt.Sprintf("%s/%s/%s/%s/%s/%s:%s", imo.Vendor, imo.Publisher, a.OS, a.Arch, target, dp.Name, version)
+			pluginImage := fmt.Sprintf("%s/%s", imo.Repository, pluginImageBasePath)
+
+			if imo.DryRun {
+				log.Infof("publish plugin 'name:%s' 'target:%s' 'os:%s' 'arch:%s' 'version:%s' at '%s'", dp.Name, target, a.OS, a.Arch, version, pluginImage)
+			} else {
+				binaryPath := filepath.Join(tempDir, cli.MakeArtifactName(dp.Name, osArch))
+				err = os.WriteFile(binaryPath, binary, 0755)
+				if err != nil {
+					return nil, err
+				}
+				err = imo.ImageOperationsImpl.PushImage(pluginImage, []string{binaryPath})
+				if err != nil {
+					return nil, errors.Wrapf(err, "unable to publish plugin binary as image %q", pluginImage)
+				}
+				log.Infof("published plugin 'name:%s' 'target:%s' 'os:%s' 'arch:%s' 'version:%s' at '%s'", dp.Name, target, a.OS, a.Arch, version, pluginImage)
+			}
+
+			entry.Artifacts[version] = append(entry.Artifacts[version], distribution.Artifact{
+				OS:     a.OS,
+				Arch:   a.Arch,
+				Digest: digest,
+				Image:  pluginImageBasePath,
+			})
+		}
+	}
+	return entry, nil
+}
+
+// fetchLegacyBinary returns the plugin binary referenced by a legacy artifact
+func (imo *InventoryMigrateOptions) fetchLegacyBinary(a distribution.Artifact) ([]byte, error) {
+	if a.Image != "" {
+		return artifact.NewOCIArtifact(a.Image).Fetch()
+	}
+	if a.URI == "" {
+		return nil, errors.New("the artifact has neither an image nor a URI")
+	}
+	if imo.DistributionDir != "" && !strings.Contains(a.URI, "://") && !filepath.IsAbs(a.URI) {
+		path, err := filepath.Abs(filepath.Join(imo.DistributionDir, a.URI))
+		if err != nil {
+			return nil, err
+		}
+		return artifact.NewLocalArtifact(path).Fetch()
+	}
+	u, err := artifact.NewURIArtifact(a.URI)
+	if err != nil {
+		return nil, err
+	}
+	return u.Fetch()
+}
@@ -0,0 +1,154 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package inventory
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/fakes"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/plugininventory"
+)
+
+const legacyCLIPlugin = `apiVersion: cli.tanzu.vmware.com/v1alpha1
+kind: CLIPlugin
+metadata:
+  name: foo
+spec:
+  description: Foo plugin
+  recommendedVersion: v0.0.1
+  target: kubernetes
+  artifacts:
+    v0.0.1:
+    - type: local
+      uri: linux/amd64/cli/foo/v0.0.1/tanzu-foo-linux_amd64
+      os: linux
+      arch: amd64
+      digest: %s
+    - type: local
+      uri: darwin/amd64/cli/foo/v0.0.1/tanzu-foo-darwin_amd64
+      os: darwin
+      arch: amd64
+`
+
+// createLegacyLayout creates a legacy discovery and distribution containing plugin 'foo'
+// and returns their directories
+func createLegacyLayout(linuxDigest string) (string, string) {
+	dir, err := os.MkdirTemp("", "legacy")
+	Expect(err).ToNot(HaveOccurred())
+
+	discoveryDir := filepath.Join(dir, "discovery", "standalone")
+	Expect(os.MkdirAll(discoveryDir, 0755)).To(Succeed())
+	Expect(os.WriteFile(filepath.Join(discoveryDir, "foo.yaml"), []byte(fmt.Sprintf(legacyCLIPlugin, linuxDigest)), 0644)).To(Succeed())
+
+	distributionDir := filepath.Join(dir, "distribution")
+	for _, osName := range []string{"linux", "darwin"} {
+		binaryDir := filepath.Join(distributionDir, osName, "amd64", "cli", "foo", "v0.0.1")
+		Expect(os.MkdirAll(binaryDir, 0755)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(binaryDir, "tanzu-foo-"+osName+"_amd64"), []byte("foo-"+osName), 0755)).To(Succeed())
+	}
+	return discoveryDir, distributionDir
+}
+
+var _ = Describe("Unit tests for inventory migrate", func() {
+	var referencedDBFile string
+	var fakeImgpkgWrapper *fakes.ImageOperationsImpl
+	var imo InventoryMigrateOptions
+
+	// pullDBImageStub create new empty database with the table schemas created
+	//nolint:unparam
+	pullDBImageStub := func(_, path string) error {
+		dbFile := filepath.Join(path, plugininventory.SQliteDBFileName)
+		db := plugininventory.NewSQLiteInventory(dbFile, "")
+		err := db.CreateSchema()
+		Expect(err).ToNot(HaveOccurred())
+		referencedDBFile = dbFile
+		return nil
+	}
+
+	linuxDigest := fmt.Sprintf("%x", sha256.Sum256([]byte("foo-linux")))
+
+	BeforeEach(func() {
+		discoveryDir, distributionDir := createLegacyLayout(linuxDigest)
+		fakeImgpkgWrapper = &fakes.ImageOperationsImpl{}
+		imo = InventoryMigrateOptions{
+			DiscoveryDir:        discoveryDir,
+			DistributionDir:     distributionDir,
+			Repository:          "test-repo.com",
+			InventoryImageTag:   "latest",
+			Vendor:              "fakevendor",
+			Publisher:           "fakepublisher",
+			ImageOperationsImpl: fakeImgpkgWrapper,
+		}
+	})
+
+	var _ = Context("tests for the inventory migrate function", func() {
+
+		var _ = It("when plugin inventory database cannot be pulled from the repository", func() {
+			fakeImgpkgWrapper.DownloadImageAndSaveFilesToDirReturns(errors.New("unable to pull inventory database"))
+
+			err := imo.Migrate()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("error while pulling database from the image"))
+		})
+
+		var _ = It("when the digest of a legacy binary does not match", func() {
+			discoveryDir, _ := createLegacyLayout("bad-digest")
+			imo.DiscoveryDir = discoveryDir
+			fakeImgpkgWrapper.DownloadImageAndSaveFilesToDirCalls(pullDBImageStub)
+
+			err := imo.Migrate()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(`but "bad-digest" was expected`))
+			Expect(fakeImgpkgWrapper.PushImageCallCount()).To(Equal(0))
+		})
+
+		var _ = It("when all configuration are correct", func() {
+			fakeImgpkgWrapper.DownloadImageAndSaveFilesToDirCalls(pullDBImageStub)
+
+			err := imo.Migrate()
+			Expect(err).NotTo(HaveOccurred())
+
+			// The two plugin binaries and the inventory database are published
+			Expect(fakeImgpkgWrapper.PushImageCallCount()).To(Equal(3))
+			image, files := fakeImgpkgWrapper.PushImageArgsForCall(0)
+			Expect(image).To(Equal("test-repo.com/fakevendor/fakepublisher/linux/amd64/kubernetes/foo:v0.0.1"))
+			Expect(filepath.Base(files[0])).To(Equal("tanzu-foo-linux_amd64"))
+			image, _ = fakeImgpkgWrapper.PushImageArgsForCall(2)
+			Expect(image).To(Equal("test-repo.com/plugin-inventory:latest"))
+
+			db := plugininventory.NewSQLiteInventory(referencedDBFile, "")
+			pluginInventoryEntries, err := db.GetAllPlugins()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(len(pluginInventoryEntries)).To(Equal(1))
+			Expect(pluginInventoryEntries[0].Name).To(Equal("foo"))
+			Expect(pluginInventoryEntries[0].Target).To(Equal(types.TargetK8s))
+			Expect(pluginInventoryEntries[0].Description).To(Equal("Foo plugin"))
+			Expect(pluginInventoryEntries[0].Publisher).To(Equal("fakepublisher"))
+			Expect(pluginInventoryEntries[0].Vendor).To(Equal("fakevendor"))
+			Expect(pluginInventoryEntries[0].Artifacts["v0.0.1"]).To(HaveLen(2))
+			artifact, err := pluginInventoryEntries[0].Artifacts.GetArtifact("v0.0.1", "linux", "amd64")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(artifact.Digest).To(Equal(linuxDigest))
+			Expect(artifact.Image).To(HaveSuffix("fakevendor/fakepublisher/linux/amd64/kubernetes/foo:v0.0.1"))
+		})
+
+		var _ = It("when running with dry-run", func() {
+			fakeImgpkgWrapper.DownloadImageAndSaveFilesToDirCalls(pullDBImageStub)
+			imo.DryRun = true
+
+			err := imo.Migrate()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeImgpkgWrapper.PushImageCallCount()).To(Equal(0))
+		})
+	})
+})
@@ -8,8 +8,10 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"sync"
 
+	"github.com/Masterminds/semver"
 	"github.com/pkg/errors"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
 
@@ -34,6 +36,18 @@ type InventoryPluginUpdateOptions struct {
 	InventoryDBFile   string
 	DeactivatePlugins bool
 	ValidateOnly      bool
+	// PinImageDigest records the plugin images in the inventory database
+	// by digest instead of by tag
+	PinImageDigest bool
+	// Compressed records in the inventory database the images of the zstd-compressed
+	// plugin binaries published along with the plugin binaries
+	Compressed bool
+	// MinCLIVersion records in the inventory database the minimum version
+	// of the Tanzu CLI required by the plugin binaries
+	MinCLIVersion string
+	// KubernetesVersions records in the inventory database the semantic version
+	// constraint of the Kubernetes versions supported by the plugin binaries
+	KubernetesVersions string
 
 	ImageOperationsImpl carvelhelpers.ImageOperationsImpl
 }
@@ -41,6 +55,16 @@ type InventoryPluginUpdateOptions struct {
 // PluginAdd add plugin entry to the inventory database by downloading the database from the repository, updating it locally
 // and publishing the inventory database as OCI image on the remote repository
 func (ipuo *InventoryPluginUpdateOptions) PluginAdd() error {
+	if ipuo.MinCLIVersion != "" {
+		if _, err := semver.NewVersion(ipuo.MinCLIVersion); err != nil {
+			return errors.Wrapf(err, "invalid minimum CLI version %q", ipuo.MinCLIVersion)
+		}
+	}
+	if ipuo.KubernetesVersions != "" {
+		if _, err := semver.NewConstraint(ipuo.KubernetesVersions); err != nil {
+			return errors.Wrapf(err, "invalid Kubernetes versions constraint %q", ipuo.KubernetesVersions)
+		}
+	}
 	pluginAddFunc := func(dbFile string, entry *plugininventory.PluginInventoryEntry) error {
 		db := plugininventory.NewSQLiteInventory(dbFile, "")
 		err := db.InsertPlugin(entry)
@@ -99,8 +123,10 @@ func (ipuo *InventoryPluginUpdateOptions) preparePluginInventoryEntriesFromManif
 	var pluginInventoryEntries []*plugininventory.PluginInventoryEntry
 
 	pluginBinaryDigestMap := map[string]string{}
+	pluginImageDigestMap := map[string]string{}
+	compressedImageDigestMap := map[string]string{}
 	if !ipuo.ValidateOnly {
-		pluginBinaryDigestMap, err = ipuo.fetchPluginBinaryDigest(pluginManifest)
+		pluginBinaryDigestMap, pluginImageDigestMap, compressedImageDigestMap, err = ipuo.fetchPluginBinaryDigest(pluginManifest)
 		if err != nil {
 			return nil, err
 		}
@@ -109,9 +135,9 @@ func (ipuo *InventoryPluginUpdateOptions) preparePluginInventoryEntriesFromManif
 	for i := range pluginManifest.Plugins {
 		var pluginInventoryEntry *plugininventory.PluginInventoryEntry
 
-		for _, osArch := range cli.AllOSArch {
+		for _, osArch := range helpers.GetAllOSArch() {
 			for _, version := range pluginManifest.Plugins[i].Versions {
-				pluginInventoryEntry, err = ipuo.updatePluginInventoryEntry(pluginInventoryEntry, pluginManifest.Plugins[i], osArch, version, pluginBinaryDigestMap)
+				pluginInventoryEntry, err = ipuo.updatePluginInventoryEntry(pluginInventoryEntry, pluginManifest.Plugins[i], osArch, version, pluginBinaryDigestMap, pluginImageDigestMap, compressedImageDigestMap)
 				if err != nil {
 					return nil, err
 				}
@@ -124,8 +150,13 @@ func (ipuo *InventoryPluginUpdateOptions) preparePluginInventoryEntriesFromManif
 	return pluginInventoryEntries, nil
 }
 
-func (ipuo *InventoryPluginUpdateOptions) fetchPluginBinaryDigest(pluginManifest *cli.Manifest) (map[string]string, error) {
+// fetchPluginBinaryDigest returns the digest of the plugin binary of each plugin image as well
+// as, if the images are to be pinned by digest, the digest of each plugin image and, if the
+// plugin binaries are also published compressed, the digest of each compressed image
+func (ipuo *InventoryPluginUpdateOptions) fetchPluginBinaryDigest(pluginManifest *cli.Manifest) (map[string]string, map[string]string, map[string]string, error) {
 	pluginBinaryDigestMap := map[string]string{}
+	pluginImageDigestMap := map[string]string{}
+	compressedImageDigestMap := map[string]string{}
 
 	// Limit the number of concurrent operations we perform so we don't overwhelm the system.
 	maxConcurrent := helpers.GetMaxParallelism()
@@ -158,17 +189,53 @@ func (ipuo *InventoryPluginUpdateOptions) fetchPluginBinaryDigest(pluginManifest
 			} else {
 				log.Infof("%s ignoring unavailable plugin for optional os/arch: %s", threadID, osArch.String())
 			}
-		} else {
-			mutex.Lock()
-			pluginBinaryDigestMap[pluginImage] = digest
-			mutex.Unlock()
+			return
 		}
+
+		imageDigest := ""
+		if ipuo.PinImageDigest {
+			// The image was just pulled, so its digest is expected to be available
+			algorithm, hex, err := ipuo.ImageOperationsImpl.GetImageDigest(pluginImage)
+			if err == nil && (algorithm == "" || hex == "") {
+				err = errors.New("empty digest")
+			}
+			if err != nil {
+				fatalErrors <- helpers.ErrInfo{Err: errors.Wrapf(err, "error while getting the digest of the image %q", pluginImage), ID: threadID, Path: pluginImage}
+				return
+			}
+			imageDigest = algorithm + ":" + hex
+		}
+
+		compressedImageDigest := ""
+		if ipuo.Compressed {
+			// Getting the digest of the compressed image also verifies it was published
+			compressedImage := helpers.GetCompressedPluginImage(pluginImage)
+			algorithm, hex, err := ipuo.ImageOperationsImpl.GetImageDigest(compressedImage)
+			if err == nil && (algorithm == "" || hex == "") {
+				err = errors.New("empty digest")
+			}
+			if err != nil {
+				fatalErrors <- helpers.ErrInfo{Err: errors.Wrapf(err, "error while getting the digest of the compressed image %q", compressedImage), ID: threadID, Path: compressedImage}
+				return
+			}
+			compressedImageDigest = algorithm + ":" + hex
+		}
+
+		mutex.Lock()
+		pluginBinaryDigestMap[pluginImage] = digest
+		if imageDigest != "" {
+			pluginImageDigestMap[pluginImage] = imageDigest
+		}
+		if compressedImageDigest != "" {
+			compressedImageDigestMap[pluginImage] = compressedImageDigest
+		}
+		mutex.Unlock()
 	}
 
 	if !ipuo.ValidateOnly {
 		id := 0
 		for i := range pluginManifest.Plugins {
-			for _, osArch := range cli.AllOSArch {
+			for _, osArch := range helpers.GetAllOSArch() {
 				for _, version := range pluginManifest.Plugins[i].Versions {
 					wg.Add(1)
 					guard <- struct{}{}
@@ -188,19 +255,20 @@ func (ipuo *InventoryPluginUpdateOptions) fetchPluginBinaryDigest(pluginManifest
 			errList = append(errList, err.Err)
 		}
 		if len(errList) > 0 {
-			return pluginBinaryDigestMap, kerrors.NewAggregate(errList)
+			return pluginBinaryDigestMap, pluginImageDigestMap, compressedImageDigestMap, kerrors.NewAggregate(errList)
 		}
 	}
-	return pluginBinaryDigestMap, nil
+	return pluginBinaryDigestMap, pluginImageDigestMap, compressedImageDigestMap, nil
 }
 
 // Take the image download logic to get the digest out of the updatePluginInventoryEntry and run it in parallel
 // Pass the digest map to this function to update the plugin inventory entry in sync operation
-func (ipuo *InventoryPluginUpdateOptions) updatePluginInventoryEntry(pluginInventoryEntry *plugininventory.PluginInventoryEntry, plugin cli.Plugin, osArch cli.Arch, version string, pluginBinaryDigestMap map[string]string) (*plugininventory.PluginInventoryEntry, error) {
+func (ipuo *InventoryPluginUpdateOptions) updatePluginInventoryEntry(pluginInventoryEntry *plugininventory.PluginInventoryEntry, plugin cli.Plugin, osArch cli.Arch, version string, pluginBinaryDigestMap, pluginImageDigestMap, compressedImageDigestMap map[string]string) (*plugininventory.PluginInventoryEntry, error) {
 	var digest string
 	var exists bool
 
 	pluginImageBasePath := fmt.Sprintf("%s/%s/%s/%s/%s/%s:%s", ipuo.Vendor, ipuo.Publisher, osArch.OS(), osArch.Arch(), plugin.Target, plugin.Name, version)
+	compressedImageBasePath := ""
 	if !ipuo.ValidateOnly {
 		// If we are only validating the plugin's existence, we don't need to waste
 		// resources downloading the image to get the digest which won't actually be used.
@@ -214,6 +282,19 @@ func (ipuo *InventoryPluginUpdateOptions) updatePluginInventoryEntry(pluginInven
 		if digest == "" {
 			return nil, errors.Errorf("plugin binary digest cannot be empty for image %q", pluginImage)
 		}
+		if ipuo.PinImageDigest {
+			imageDigest, exists := pluginImageDigestMap[pluginImage]
+			if !exists {
+				return nil, errors.Errorf("image digest cannot be empty for image %q", pluginImage)
+			}
+			pluginImageBasePath = fmt.Sprintf("%s/%s/%s/%s/%s/%s@%s", ipuo.Vendor, ipuo.Publisher, osArch.OS(), osArch.Arch(), plugin.Target, plugin.Name, imageDigest)
+		}
+		if compressedImageDigest, exists := compressedImageDigestMap[pluginImage]; exists {
+			compressedImageBasePath = helpers.GetCompressedPluginImage(fmt.Sprintf("%s/%s/%s/%s/%s/%s:%s", ipuo.Vendor, ipuo.Publisher, osArch.OS(), osArch.Arch(), plugin.Target, plugin.Name, version))
+			if ipuo.PinImageDigest {
+				compressedImageBasePath = fmt.Sprintf("%s@%s", strings.TrimSuffix(compressedImageBasePath, ":"+version), compressedImageDigest)
+			}
+		}
 	}
 
 	if pluginInventoryEntry == nil {
@@ -233,10 +314,14 @@ func (ipuo *InventoryPluginUpdateOptions) updatePluginInventoryEntry(pluginInven
 	}
 
 	artifact := distribution.Artifact{
-		OS:     osArch.OS(),
-		Arch:   osArch.Arch(),
-		Digest: digest,
-		Image:  pluginImageBasePath,
+		OS:                   osArch.OS(),
+		Arch:                 osArch.Arch(),
+		Digest:               digest,
+		Image:                pluginImageBasePath,
+		CompressedImage:      compressedImageBasePath,
+		MinCLIVersion:        ipuo.MinCLIVersion,
+		KubernetesVersions:   ipuo.KubernetesVersions,
+		PluginRuntimeVersion: plugin.PluginRuntimeVersion,
 	}
 	pluginInventoryEntry.Artifacts[version] = append(pluginInventoryEntry.Artifacts[version], artifact)
 	return pluginInventoryEntry, nil
@@ -0,0 +1,76 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package inventory
+
+import (
+	"strings"
+
+	"github.com/Masterminds/semver"
+	"github.com/pkg/errors"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/carvelhelpers"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/plugininventory"
+)
+
+// InventoryPluginAdvisoryOptions defines options for recording a security
+// advisory affecting a plugin version in the inventory database
+type InventoryPluginAdvisoryOptions struct {
+	Repository        string
+	InventoryImageTag string
+	InventoryDBFile   string
+	PluginName        string
+	Target            string
+	Version           string
+	ID                string
+	Severity          string
+	Summary           string
+	URL               string
+	FixedVersion      string
+
+	ImageOperationsImpl carvelhelpers.ImageOperationsImpl
+}
+
+// AdvisoryAdd adds the advisory to the inventory database by downloading the database from the
+// repository, updating it locally and publishing the inventory database as OCI image on the remote repository
+func (ipao *InventoryPluginAdvisoryOptions) AdvisoryAdd() error {
+	if !configtypes.IsValidTarget(strings.ToLower(ipao.Target), true, false) {
+		return errors.Errorf("invalid target %q", ipao.Target)
+	}
+	target := configtypes.StringToTarget(strings.ToLower(ipao.Target))
+	if ipao.FixedVersion != "" {
+		if _, err := semver.NewVersion(ipao.FixedVersion); err != nil {
+			return errors.Wrapf(err, "invalid fixed version %q", ipao.FixedVersion)
+		}
+	}
+
+	// The inventory database is updated following the same flow as 'inventory plugin add'
+	ipuo := &InventoryPluginUpdateOptions{
+		Repository:          ipao.Repository,
+		InventoryImageTag:   ipao.InventoryImageTag,
+		InventoryDBFile:     ipao.InventoryDBFile,
+		ImageOperationsImpl: ipao.ImageOperationsImpl,
+	}
+	dbFile, err := ipuo.getInventoryDBFile()
+	if err != nil {
+		return err
+	}
+
+	advisory := &plugininventory.PluginAdvisory{
+		PluginIdentifier: plugininventory.PluginIdentifier{Name: ipao.PluginName, Target: target, Version: ipao.Version},
+		ID:               ipao.ID,
+		Severity:         strings.ToLower(ipao.Severity),
+		Summary:          ipao.Summary,
+		URL:              ipao.URL,
+		FixedVersion:     ipao.FixedVersion,
+	}
+	db := plugininventory.NewSQLiteInventory(dbFile, "")
+	err = db.InsertPluginAdvisory(advisory)
+	if err != nil {
+		return errors.Wrapf(err, "error while inserting advisory %q of plugin '%s_%s'", ipao.ID, ipao.PluginName, target)
+	}
+
+	return ipuo.putInventoryDBFile(dbFile)
+}
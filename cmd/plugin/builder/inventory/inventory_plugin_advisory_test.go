@@ -0,0 +1,114 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package inventory
+
+import (
+	"errors"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/distribution"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/fakes"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/plugininventory"
+)
+
+var _ = Describe("Unit tests for inventory plugin advisory add", func() {
+	var referencedDBFile string
+	var fakeImgpkgWrapper *fakes.ImageOperationsImpl
+	var ipao InventoryPluginAdvisoryOptions
+
+	// pullDBImageStub creates a new database containing version v1.2.0 of plugin "foo"
+	//nolint:unparam
+	pullDBImageStub := func(_, path string) error {
+		dbFile := filepath.Join(path, plugininventory.SQliteDBFileName)
+		db := plugininventory.NewSQLiteInventory(dbFile, "")
+		Expect(db.CreateSchema()).To(Succeed())
+		Expect(db.InsertPlugin(&plugininventory.PluginInventoryEntry{
+			Name:        "foo",
+			Target:      types.TargetK8s,
+			Description: "Foo plugin",
+			Publisher:   "fakepublisher",
+			Vendor:      "fakevendor",
+			Artifacts: distribution.Artifacts{
+				"v1.2.0": []distribution.Artifact{
+					{OS: "linux", Arch: "amd64", Digest: "0000000000", Image: "fakevendor/fakepublisher/linux/amd64/kubernetes/foo:v1.2.0"},
+				},
+			},
+		})).To(Succeed())
+		referencedDBFile = dbFile
+		return nil
+	}
+
+	BeforeEach(func() {
+		fakeImgpkgWrapper = &fakes.ImageOperationsImpl{}
+		ipao = InventoryPluginAdvisoryOptions{
+			Repository:          "test-repo.com",
+			InventoryImageTag:   "latest",
+			PluginName:          "foo",
+			Target:              "k8s",
+			Version:             "v1.2.0",
+			ID:                  "CVE-2024-12345",
+			Severity:            "HIGH",
+			Summary:             "Credentials are logged",
+			FixedVersion:        "v1.2.1",
+			ImageOperationsImpl: fakeImgpkgWrapper,
+		}
+	})
+
+	var _ = Context("tests for the advisory add function", func() {
+
+		var _ = It("when the target is invalid", func() {
+			ipao.Target = "unknown"
+
+			err := ipao.AdvisoryAdd()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(`invalid target "unknown"`))
+		})
+
+		var _ = It("when plugin inventory database cannot be pulled from the repository", func() {
+			fakeImgpkgWrapper.DownloadImageAndSaveFilesToDirReturns(errors.New("unable to pull inventory database"))
+
+			err := ipao.AdvisoryAdd()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("error while pulling database from the image"))
+		})
+
+		var _ = It("when the plugin version is not in the database", func() {
+			ipao.Version = "v1.0.0"
+			fakeImgpkgWrapper.DownloadImageAndSaveFilesToDirCalls(pullDBImageStub)
+
+			err := ipao.AdvisoryAdd()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("is not present in the database"))
+			Expect(fakeImgpkgWrapper.PushImageCallCount()).To(Equal(0))
+		})
+
+		var _ = It("when all configuration are correct", func() {
+			fakeImgpkgWrapper.DownloadImageAndSaveFilesToDirCalls(pullDBImageStub)
+
+			err := ipao.AdvisoryAdd()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeImgpkgWrapper.PushImageCallCount()).To(Equal(1))
+			image, _ := fakeImgpkgWrapper.PushImageArgsForCall(0)
+			Expect(image).To(Equal("test-repo.com/plugin-inventory:latest"))
+
+			db := plugininventory.NewSQLiteInventory(referencedDBFile, "")
+			plugins, err := db.GetPlugins(&plugininventory.PluginInventoryFilter{Name: "foo"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(plugins).To(HaveLen(1))
+			advisories := plugins[0].Advisories["v1.2.0"]
+			Expect(advisories).To(HaveLen(1))
+			Expect(advisories[0].ID).To(Equal("CVE-2024-12345"))
+			Expect(advisories[0].Target).To(Equal(types.TargetK8s))
+			Expect(advisories[0].Severity).To(Equal("high"))
+			Expect(advisories[0].Summary).To(Equal("Credentials are logged"))
+			Expect(advisories[0].FixedVersion).To(Equal("v1.2.1"))
+		})
+	})
+})
@@ -7,6 +7,7 @@ import (
 	"errors"
 	"os"
 	"path/filepath"
+	"strings"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -170,6 +171,172 @@ var _ = Describe("Unit tests for inventory plugin add", func() {
 		})
 	})
 
+	var _ = Context("tests for the inventory plugin add function with images pinned by digest", func() {
+		BeforeEach(func() {
+			iip.PinImageDigest = true
+			iip.DeactivatePlugins = false
+		})
+		AfterEach(func() {
+			iip.PinImageDigest = false
+		})
+
+		var _ = It("when the digest of a plugin image cannot be resolved", func() {
+			fakeImgpkgWrapper.PushImageReturns(nil)
+			fakeImgpkgWrapper.DownloadImageAndSaveFilesToDirCalls(pullDBImageStub)
+			fakeImgpkgWrapper.GetFileDigestFromImageReturns("fake-digest", nil)
+			fakeImgpkgWrapper.GetImageDigestReturns("", "", errors.New("image digest not found"))
+
+			err := iip.PluginAdd()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("image digest not found"))
+			Expect(err.Error()).To(ContainSubstring("error while getting the digest of the image"))
+		})
+
+		var _ = It("when all configuration are correct the plugin images are referenced by digest", func() {
+			fakeImgpkgWrapper.PushImageReturns(nil)
+			fakeImgpkgWrapper.DownloadImageAndSaveFilesToDirCalls(pullDBImageStub)
+			fakeImgpkgWrapper.GetFileDigestFromImageReturns("fake-digest", nil)
+			fakeImgpkgWrapper.GetImageDigestReturns("sha256", "fake-image-digest", nil)
+
+			err := iip.PluginAdd()
+			Expect(err).NotTo(HaveOccurred())
+
+			db := plugininventory.NewSQLiteInventory(referencedDBFile, "")
+			pluginInventoryEntries, err := db.GetAllPlugins()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(len(pluginInventoryEntries)).To(Equal(1))
+			Expect(pluginInventoryEntries[0].Artifacts["v0.0.2"]).NotTo(BeEmpty())
+			for _, a := range pluginInventoryEntries[0].Artifacts["v0.0.2"] {
+				Expect(a.Image).To(HaveSuffix("/foo@sha256:fake-image-digest"))
+				Expect(a.Digest).To(Equal("fake-digest"))
+			}
+		})
+	})
+
+	var _ = Context("tests for the inventory plugin add function with compressed plugin binaries", func() {
+		BeforeEach(func() {
+			iip.PinImageDigest = true
+			iip.Compressed = true
+			iip.DeactivatePlugins = false
+		})
+		AfterEach(func() {
+			iip.PinImageDigest = false
+			iip.Compressed = false
+		})
+
+		var _ = It("when the compressed images were not published", func() {
+			fakeImgpkgWrapper.PushImageReturns(nil)
+			fakeImgpkgWrapper.DownloadImageAndSaveFilesToDirCalls(pullDBImageStub)
+			fakeImgpkgWrapper.GetFileDigestFromImageReturns("fake-digest", nil)
+			fakeImgpkgWrapper.GetImageDigestCalls(func(image string) (string, string, error) {
+				if strings.Contains(image, "/zstd:") {
+					return "", "", errors.New("image not found")
+				}
+				return "sha256", "fake-image-digest", nil
+			})
+
+			err := iip.PluginAdd()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("error while getting the digest of the compressed image"))
+		})
+
+		var _ = It("when all configuration are correct the compressed images are referenced by digest", func() {
+			fakeImgpkgWrapper.PushImageReturns(nil)
+			fakeImgpkgWrapper.DownloadImageAndSaveFilesToDirCalls(pullDBImageStub)
+			fakeImgpkgWrapper.GetFileDigestFromImageReturns("fake-digest", nil)
+			fakeImgpkgWrapper.GetImageDigestCalls(func(image string) (string, string, error) {
+				if strings.Contains(image, "/zstd:") {
+					return "sha256", "fake-compressed-image-digest", nil
+				}
+				return "sha256", "fake-image-digest", nil
+			})
+
+			err := iip.PluginAdd()
+			Expect(err).NotTo(HaveOccurred())
+
+			db := plugininventory.NewSQLiteInventory(referencedDBFile, "")
+			pluginInventoryEntries, err := db.GetAllPlugins()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(len(pluginInventoryEntries)).To(Equal(1))
+			Expect(pluginInventoryEntries[0].Artifacts["v0.0.2"]).NotTo(BeEmpty())
+			for _, a := range pluginInventoryEntries[0].Artifacts["v0.0.2"] {
+				Expect(a.Image).To(HaveSuffix("/foo@sha256:fake-image-digest"))
+				Expect(a.CompressedImage).To(HaveSuffix("/foo/zstd@sha256:fake-compressed-image-digest"))
+				Expect(a.Digest).To(Equal("fake-digest"))
+			}
+		})
+	})
+
+	var _ = Context("tests for the inventory plugin add function with a minimum CLI version", func() {
+		AfterEach(func() {
+			iip.MinCLIVersion = ""
+		})
+
+		var _ = It("when the minimum CLI version is invalid", func() {
+			iip.MinCLIVersion = "invalid"
+
+			err := iip.PluginAdd()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("invalid minimum CLI version"))
+		})
+
+		var _ = It("when all configuration are correct the minimum CLI version is recorded", func() {
+			fakeImgpkgWrapper.PushImageReturns(nil)
+			fakeImgpkgWrapper.DownloadImageAndSaveFilesToDirCalls(pullDBImageStub)
+			fakeImgpkgWrapper.GetFileDigestFromImageReturns("fake-digest", nil)
+			iip.DeactivatePlugins = false
+			iip.MinCLIVersion = "v1.3.0"
+
+			err := iip.PluginAdd()
+			Expect(err).NotTo(HaveOccurred())
+
+			db := plugininventory.NewSQLiteInventory(referencedDBFile, "")
+			pluginInventoryEntries, err := db.GetAllPlugins()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(len(pluginInventoryEntries)).To(Equal(1))
+			Expect(pluginInventoryEntries[0].Artifacts["v0.0.2"]).NotTo(BeEmpty())
+			for _, a := range pluginInventoryEntries[0].Artifacts["v0.0.2"] {
+				Expect(a.MinCLIVersion).To(Equal("v1.3.0"))
+				// The plugin runtime version stamped in the manifest is also recorded
+				Expect(a.PluginRuntimeVersion).To(Equal("v1.4.7"))
+			}
+		})
+	})
+
+	var _ = Context("tests for the inventory plugin add function with supported Kubernetes versions", func() {
+		AfterEach(func() {
+			iip.KubernetesVersions = ""
+		})
+
+		var _ = It("when the Kubernetes versions constraint is invalid", func() {
+			iip.KubernetesVersions = "not a constraint"
+
+			err := iip.PluginAdd()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("invalid Kubernetes versions constraint"))
+		})
+
+		var _ = It("when all configuration are correct the Kubernetes versions are recorded", func() {
+			fakeImgpkgWrapper.PushImageReturns(nil)
+			fakeImgpkgWrapper.DownloadImageAndSaveFilesToDirCalls(pullDBImageStub)
+			fakeImgpkgWrapper.GetFileDigestFromImageReturns("fake-digest", nil)
+			iip.DeactivatePlugins = false
+			iip.KubernetesVersions = ">=1.26.0, <1.30.0"
+
+			err := iip.PluginAdd()
+			Expect(err).NotTo(HaveOccurred())
+
+			db := plugininventory.NewSQLiteInventory(referencedDBFile, "")
+			pluginInventoryEntries, err := db.GetAllPlugins()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(len(pluginInventoryEntries)).To(Equal(1))
+			Expect(pluginInventoryEntries[0].Artifacts["v0.0.2"]).NotTo(BeEmpty())
+			for _, a := range pluginInventoryEntries[0].Artifacts["v0.0.2"] {
+				Expect(a.KubernetesVersions).To(Equal(">=1.26.0, <1.30.0"))
+			}
+		})
+	})
+
 	var _ = Context("tests for the inventory plugin UpdatePluginActivationState function", func() {
 
 		var _ = It("when specified pluginInventoryEntry doesn't exist in database", func() {
@@ -240,6 +407,7 @@ plugins:
       description: Foo plugin
       versions:
         - v0.0.2
+      pluginRuntimeVersion: v1.4.7
 `
 	tempManifestFile := filepath.Join(os.TempDir(), "plugin_manifets.yaml")
 	return filepath.Join(os.TempDir(), "plugin_manifets.yaml"), utils.SaveFile(tempManifestFile, []byte(manifestBytes))
@@ -27,14 +27,18 @@ func newInventoryPluginCmd() *cobra.Command {
 }
 
 type inventoryPluginAddFlags struct {
-	Repository        string
-	InventoryImageTag string
-	ManifestFile      string
-	Publisher         string
-	Vendor            string
-	InventoryDBFile   string
-	DeactivatePlugins bool
-	ValidateOnly      bool
+	Repository         string
+	InventoryImageTag  string
+	ManifestFile       string
+	Publisher          string
+	Vendor             string
+	InventoryDBFile    string
+	DeactivatePlugins  bool
+	ValidateOnly       bool
+	PinImageDigest     bool
+	Compressed         bool
+	MinCLIVersion      string
+	KubernetesVersions string
 }
 
 func newInventoryPluginAddCmd() *cobra.Command {
@@ -55,6 +59,10 @@ func newInventoryPluginAddCmd() *cobra.Command {
 				DeactivatePlugins:   ipaFlags.DeactivatePlugins,
 				InventoryDBFile:     ipaFlags.InventoryDBFile,
 				ValidateOnly:        ipaFlags.ValidateOnly,
+				PinImageDigest:      ipaFlags.PinImageDigest,
+				Compressed:          ipaFlags.Compressed,
+				MinCLIVersion:       ipaFlags.MinCLIVersion,
+				KubernetesVersions:  ipaFlags.KubernetesVersions,
 				ImageOperationsImpl: carvelhelpers.NewImageOperationsImpl(),
 			}
 			return paOptions.PluginAdd()
@@ -69,6 +77,10 @@ func newInventoryPluginAddCmd() *cobra.Command {
 	pluginAddCmd.Flags().StringVarP(&ipaFlags.InventoryDBFile, "plugin-inventory-db-file", "", "", "local file for the inventory database")
 	pluginAddCmd.Flags().BoolVarP(&ipaFlags.DeactivatePlugins, "deactivate", "", false, "mark plugins as deactivated")
 	pluginAddCmd.Flags().BoolVarP(&ipaFlags.ValidateOnly, "validate", "", false, "validate whether plugins already exists in the plugin inventory or not")
+	pluginAddCmd.Flags().BoolVarP(&ipaFlags.PinImageDigest, "pin-image-digest", "", false, "reference the plugin images by digest instead of by tag in the inventory database")
+	pluginAddCmd.Flags().BoolVarP(&ipaFlags.Compressed, "compressed", "", false, "also reference the zstd-compressed plugin images published with 'plugin publish-package' in the inventory database")
+	pluginAddCmd.Flags().StringVarP(&ipaFlags.MinCLIVersion, "min-cli-version", "", "", "minimum version of the Tanzu CLI required by the plugins, recorded in the inventory database")
+	pluginAddCmd.Flags().StringVarP(&ipaFlags.KubernetesVersions, "kubernetes-versions", "", "", "semantic version constraint of the Kubernetes versions supported by the plugins, recorded in the inventory database")
 
 	_ = pluginAddCmd.MarkFlagRequired("repository")
 	_ = pluginAddCmd.MarkFlagRequired("vendor")
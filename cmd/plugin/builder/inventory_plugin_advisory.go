@@ -0,0 +1,89 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/vmware-tanzu/tanzu-cli/cmd/plugin/builder/inventory"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/carvelhelpers"
+)
+
+// newInventoryPluginAdvisoryCmd creates a new command for plugin advisory inventory operations.
+func newInventoryPluginAdvisoryCmd() *cobra.Command {
+	var inventoryPluginAdvisoryCmd = &cobra.Command{
+		Use:   "plugin-advisory",
+		Short: "Plugin Advisory Inventory Operations",
+	}
+
+	inventoryPluginAdvisoryCmd.AddCommand(
+		newInventoryPluginAdvisoryAddCmd(),
+	)
+
+	return inventoryPluginAdvisoryCmd
+}
+
+type inventoryPluginAdvisoryAddFlags struct {
+	Repository        string
+	InventoryImageTag string
+	InventoryDBFile   string
+	PluginName        string
+	Target            string
+	Version           string
+	ID                string
+	Severity          string
+	Summary           string
+	URL               string
+	FixedVersion      string
+}
+
+func newInventoryPluginAdvisoryAddCmd() *cobra.Command {
+	var ipaaFlags = &inventoryPluginAdvisoryAddFlags{}
+
+	var advisoryAddCmd = &cobra.Command{
+		Use:          "add",
+		Short:        "Add a security advisory affecting a plugin version to the inventory database available on the remote repository",
+		SilenceUsage: true,
+		Example: `
+    # Record that version v1.2.0 of plugin "cluster" for target kubernetes is affected by CVE-2024-12345, fixed in v1.2.1
+    tanzu builder inventory plugin-advisory add --repository localhost:5002/test/v1/tanzu-cli/plugins --name cluster --target kubernetes --version v1.2.0 --id CVE-2024-12345 --severity high --summary "Credentials are logged" --url https://example.com/CVE-2024-12345 --fixed-version v1.2.1`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			paOptions := inventory.InventoryPluginAdvisoryOptions{
+				Repository:          ipaaFlags.Repository,
+				InventoryImageTag:   ipaaFlags.InventoryImageTag,
+				InventoryDBFile:     ipaaFlags.InventoryDBFile,
+				PluginName:          ipaaFlags.PluginName,
+				Target:              ipaaFlags.Target,
+				Version:             ipaaFlags.Version,
+				ID:                  ipaaFlags.ID,
+				Severity:            ipaaFlags.Severity,
+				Summary:             ipaaFlags.Summary,
+				URL:                 ipaaFlags.URL,
+				FixedVersion:        ipaaFlags.FixedVersion,
+				ImageOperationsImpl: carvelhelpers.NewImageOperationsImpl(),
+			}
+			return paOptions.AdvisoryAdd()
+		},
+	}
+
+	advisoryAddCmd.Flags().StringVarP(&ipaaFlags.Repository, "repository", "", "", "repository to publish plugin inventory image")
+	advisoryAddCmd.Flags().StringVarP(&ipaaFlags.InventoryImageTag, "plugin-inventory-image-tag", "", "latest", "tag to which plugin inventory image needs to be published")
+	advisoryAddCmd.Flags().StringVarP(&ipaaFlags.InventoryDBFile, "plugin-inventory-db-file", "", "", "local file for the inventory database")
+	advisoryAddCmd.Flags().StringVarP(&ipaaFlags.PluginName, "name", "", "", "name of the plugin affected by the advisory")
+	advisoryAddCmd.Flags().StringVarP(&ipaaFlags.Target, "target", "", "", "target of the plugin affected by the advisory")
+	advisoryAddCmd.Flags().StringVarP(&ipaaFlags.Version, "version", "", "", "version of the plugin affected by the advisory")
+	advisoryAddCmd.Flags().StringVarP(&ipaaFlags.ID, "id", "", "", "identifier of the advisory, e.g., CVE-2024-12345")
+	advisoryAddCmd.Flags().StringVarP(&ipaaFlags.Severity, "severity", "", "", "severity of the advisory, e.g., critical, high, medium or low")
+	advisoryAddCmd.Flags().StringVarP(&ipaaFlags.Summary, "summary", "", "", "summary of the advisory")
+	advisoryAddCmd.Flags().StringVarP(&ipaaFlags.URL, "url", "", "", "URL providing the details of the advisory")
+	advisoryAddCmd.Flags().StringVarP(&ipaaFlags.FixedVersion, "fixed-version", "", "", "first version of the plugin fixing the advisory")
+
+	_ = advisoryAddCmd.MarkFlagRequired("repository")
+	_ = advisoryAddCmd.MarkFlagRequired("name")
+	_ = advisoryAddCmd.MarkFlagRequired("target")
+	_ = advisoryAddCmd.MarkFlagRequired("version")
+	_ = advisoryAddCmd.MarkFlagRequired("id")
+
+	return advisoryAddCmd
+}
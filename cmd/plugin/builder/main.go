@@ -30,6 +30,7 @@ func main() {
 		NewInitCmd(),
 		NewPluginCmd(),
 		newInventoryCmd(),
+		newPublisherCmd(),
 	)
 
 	if err := p.Execute(); err != nil {
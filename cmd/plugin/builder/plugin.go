@@ -5,14 +5,25 @@ package main
 
 import (
 	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
 
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/component"
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+
 	"github.com/vmware-tanzu/tanzu-cli/cmd/plugin/builder/command"
 	"github.com/vmware-tanzu/tanzu-cli/cmd/plugin/builder/crane"
+	"github.com/vmware-tanzu/tanzu-cli/cmd/plugin/builder/helpers"
 	"github.com/vmware-tanzu/tanzu-cli/cmd/plugin/builder/plugin"
+	"github.com/vmware-tanzu/tanzu-cli/cmd/plugin/builder/signer"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/carvelhelpers"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/registry"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/sbom"
 )
 
 // NewPluginCmd creates a new command for plugin operations.
@@ -23,7 +34,12 @@ func NewPluginCmd() *cobra.Command {
 	}
 
 	pluginCmd.AddCommand(
+		newPluginInitCmd(),
 		newPluginBuildCmd(),
+		newPluginSignCmd(),
+		newPluginLintCmd(),
+		newPluginDocsCmd(),
+		newPluginBundleCmd(),
 		newPluginBuildPackageCmd(),
 		newPluginPublishPackageCmd(),
 	)
@@ -40,12 +56,34 @@ type pluginBuildFlags struct {
 	PluginScopeAssociationFile string
 	GoFlags                    string
 	DebugSymbols               bool
+	Reproducible               bool
+	SourceDateEpoch            int64
+	SBOMFormats                []string
+	Force                      bool
+	Sign                       pluginSignFlags
+}
+
+type pluginSignFlags struct {
+	WindowsCertFile     string
+	WindowsCertPassword string
+	WindowsTimestampURL string
+	MacOSIdentity       string
+	MacOSNotaryProfile  string
 }
 
 type pluginBuildPackageFlags struct {
 	BinaryArtifactDir  string
 	PackageArtifactDir string
 	localOCIRepository string
+	Compress           bool
+}
+
+type pluginBundleFlags struct {
+	BinaryArtifactDir string
+	Vendor            string
+	Publisher         string
+	ToTar             string
+	Serve             string
 }
 
 type pluginPublishPackageFlags struct {
@@ -54,6 +92,105 @@ type pluginPublishPackageFlags struct {
 	Publisher          string
 	Vendor             string
 	DryRun             bool
+	InventoryImageTag  string
+	AllowOverwrite     bool
+}
+
+const (
+	// sourceDateEpochEnv is the standard environment variable specifying the timestamp to use for reproducible builds
+	sourceDateEpochEnv = "SOURCE_DATE_EPOCH"
+	// windowsCertPasswordEnv is the environment variable specifying the password of the windows signing certificate
+	windowsCertPasswordEnv = "TANZU_BUILDER_WINDOWS_CERT_PASSWORD"
+)
+
+// addPluginSignFlags adds the flags configuring the signing of the plugin binaries
+func addPluginSignFlags(cmd *cobra.Command, psFlags *pluginSignFlags) {
+	cmd.Flags().StringVarP(&psFlags.WindowsCertFile, "windows-cert-file", "", "", "PKCS#12 certificate file used to sign the windows binaries with Authenticode")
+	cmd.Flags().StringVarP(&psFlags.WindowsCertPassword, "windows-cert-password", "", "", "password of the windows certificate file, defaults to the value of the "+windowsCertPasswordEnv+" environment variable")
+	cmd.Flags().StringVarP(&psFlags.WindowsTimestampURL, "windows-timestamp-url", "", "", "URL of the RFC 3161 timestamp server to use when signing the windows binaries")
+	cmd.Flags().StringVarP(&psFlags.MacOSIdentity, "macos-sign-identity", "", "", "identity used to sign the darwin binaries with codesign")
+	cmd.Flags().StringVarP(&psFlags.MacOSNotaryProfile, "macos-notary-profile", "", "", "keychain profile of the notarytool credentials used to notarize the darwin binaries")
+}
+
+// signers returns the signers configured by the flags
+func (psFlags *pluginSignFlags) signers() ([]signer.Signer, error) {
+	var signers []signer.Signer
+	if psFlags.WindowsCertFile != "" {
+		password := psFlags.WindowsCertPassword
+		if password == "" {
+			password = os.Getenv(windowsCertPasswordEnv)
+		}
+		signers = append(signers, &signer.AuthenticodeSigner{
+			CertFile:     psFlags.WindowsCertFile,
+			CertPassword: password,
+			TimestampURL: psFlags.WindowsTimestampURL,
+		})
+	}
+	if psFlags.MacOSIdentity != "" {
+		signers = append(signers, &signer.MacOSSigner{
+			Identity:      psFlags.MacOSIdentity,
+			NotaryProfile: psFlags.MacOSNotaryProfile,
+		})
+	} else if psFlags.MacOSNotaryProfile != "" {
+		return nil, errors.New("notarizing the darwin binaries requires signing them using the --macos-sign-identity flag")
+	}
+	return signers, nil
+}
+
+// signPlugins signs the plugin binaries of the artifacts directory and updates its build report
+func signPlugins(artifactsDir string, signers []signer.Signer) error {
+	spArgs := &plugin.SignPluginOptions{
+		BinaryArtifactDir: artifactsDir,
+		Signers:           signers,
+	}
+	err := spArgs.SignPlugins()
+	if err != nil {
+		return err
+	}
+	return command.UpdateBuildReport(artifactsDir)
+}
+
+func newPluginInitCmd() *cobra.Command {
+	var piArgs = &command.PluginInitArgs{}
+
+	var pluginInitCmd = &cobra.Command{
+		Use:   "init PLUGIN_NAME",
+		Short: "Initialize a new plugin repository",
+		Long: `Initialize a new repository containing a plugin, including:
+
+* The plugin main.go wired to the tanzu-plugin-runtime and its test harness
+* The plugin-scope-association file used to generate the plugin-group manifest
+* A Makefile using the builder to build, install and publish the plugin
+* GolangCI linting config
+* GitHub or GitLab CI config`,
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		Example: `
+    # Initialize the repository of the global 'foo' plugin in the 'foo' directory
+    tanzu builder plugin init foo --description "manage foo resources"
+
+    # Initialize the repository of the kubernetes 'foo' plugin in the 'tanzu-foo-plugin' directory using GitLab CI
+    tanzu builder plugin init foo --description "manage foo resources" --target kubernetes --module example.com/tanzu-foo-plugin --repo-type gitlab`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var err error
+			piArgs.PluginName = args[0]
+			if piArgs.Description == "" {
+				piArgs.Description, err = askDescription()
+				if err != nil {
+					return err
+				}
+			}
+			return command.InitializePlugin(piArgs)
+		},
+	}
+
+	pluginInitCmd.Flags().StringVarP(&piArgs.Description, "description", "", "", "description of the plugin")
+	pluginInitCmd.Flags().StringVarP(&piArgs.ModuleName, "module", "", "", "go module name of the repository, its last element is used as the repository directory (default to the plugin name)")
+	pluginInitCmd.Flags().StringVarP(&piArgs.Target, "target", "", string(configtypes.TargetGlobal), "target of the plugin (global, kubernetes, mission-control or operations)")
+	pluginInitCmd.Flags().StringVarP(&piArgs.RepoType, "repo-type", "", "github", "type of repository: github or gitlab")
+	pluginInitCmd.Flags().BoolVarP(&piArgs.DryRun, "dry-run", "", false, "print generated files to stdout")
+
+	return pluginInitCmd
 }
 
 func newPluginBuildCmd() *cobra.Command {
@@ -71,8 +208,51 @@ func newPluginBuildCmd() *cobra.Command {
     tanzu builder plugin build --path ./cmd/plugin --version v0.0.2 --os-arch darwin_amd64 --os-arch linux_amd64 --os-arch windows_amd64
 
     # Build only foo plugin under 'cmd/plugin' directory for all supported os-arch
-    tanzu builder plugin build --path ./cmd/plugin --version v0.0.2 --os-arch all --match foo`,
+    tanzu builder plugin build --path ./cmd/plugin --version v0.0.2 --os-arch all --match foo
+
+    # Build all plugins under 'cmd/plugin' directory for all supported os-arch as well as for 'linux_ppc64le'
+    tanzu builder plugin build --path ./cmd/plugin --version v0.0.2 --os-arch all --os-arch linux_ppc64le
+
+    # Build all plugins under 'cmd/plugin' directory as a single macOS universal binary (requires 'lipo')
+    tanzu builder plugin build --path ./cmd/plugin --version v0.0.2 --os-arch darwin_universal
+
+    # Build reproducible binaries of all plugins under 'cmd/plugin' directory using the date of the last commit
+    tanzu builder plugin build --path ./cmd/plugin --version v0.0.2 --reproducible --source-date-epoch $(git log -1 --format=%ct)
+
+    # Build all plugins under 'cmd/plugin' directory and generate an SPDX SBOM for each plugin binary
+    tanzu builder plugin build --path ./cmd/plugin --version v0.0.2 --sbom spdx
+
+    # Build all plugins under 'cmd/plugin' directory and sign the darwin binaries (requires macOS)
+    tanzu builder plugin build --path ./cmd/plugin --version v0.0.2 --macos-sign-identity "Developer ID Application: Example (ABCDE12345)"`,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			for _, osArch := range pbFlags.OSArch {
+				if !command.IsSupportedBuildArch(osArch) {
+					return errors.Errorf("unsupported os-arch %q", osArch)
+				}
+			}
+
+			if !cmd.Flags().Changed("source-date-epoch") && os.Getenv(sourceDateEpochEnv) != "" {
+				epoch, err := strconv.ParseInt(os.Getenv(sourceDateEpochEnv), 10, 64)
+				if err != nil {
+					return errors.Wrapf(err, "invalid %s value", sourceDateEpochEnv)
+				}
+				pbFlags.SourceDateEpoch = epoch
+			}
+
+			var sbomFormats []sbom.Format
+			for _, f := range pbFlags.SBOMFormats {
+				format, err := sbom.ParseFormat(f)
+				if err != nil {
+					return err
+				}
+				sbomFormats = append(sbomFormats, format)
+			}
+
+			signers, err := pbFlags.Sign.signers()
+			if err != nil {
+				return err
+			}
+
 			compileArgs := &command.PluginCompileArgs{
 				Match:                      pbFlags.Match,
 				TargetArch:                 pbFlags.OSArch,
@@ -84,27 +264,219 @@ func newPluginBuildCmd() *cobra.Command {
 				GroupByOSArch:              true,
 				GoFlags:                    pbFlags.GoFlags,
 				DebugSymbols:               pbFlags.DebugSymbols,
+				Reproducible:               pbFlags.Reproducible,
+				SourceDateEpoch:            pbFlags.SourceDateEpoch,
+				SBOMFormats:                sbomFormats,
+				Force:                      pbFlags.Force,
 			}
 
-			return command.Compile(compileArgs)
+			err = command.Compile(compileArgs)
+			if err != nil || len(signers) == 0 {
+				return err
+			}
+			return signPlugins(pbFlags.ArtifactDir, signers)
 		},
 	}
 
 	pluginBuildCmd.Flags().StringVarP(&pbFlags.PluginDir, "path", "", "./cmd/plugin", "path of plugin directory")
 	pluginBuildCmd.Flags().StringVarP(&pbFlags.ArtifactDir, "binary-artifacts", "", "./artifacts", "path to output artifacts directory")
 	pluginBuildCmd.Flags().StringVarP(&pbFlags.LDFlags, "ldflags", "", "", "ldflags to set on build")
-	pluginBuildCmd.Flags().StringArrayVarP(&pbFlags.OSArch, "os-arch", "", []string{"all"}, "compile for specific os-arch, use 'local' for host os, use '<os>_<arch>' for specific")
+	pluginBuildCmd.Flags().StringArrayVarP(&pbFlags.OSArch, "os-arch", "", []string{"all"}, "compile for specific os-arch, use 'local' for host os, use '<os>_<arch>' for specific, use 'darwin_universal' for a macOS universal binary; 'all' does not include 'linux_ppc64le' and 'darwin_universal' which must be enabled explicitly")
 	pluginBuildCmd.Flags().StringVarP(&pbFlags.Version, "version", "v", "", "version of the plugins")
 	pluginBuildCmd.Flags().StringVarP(&pbFlags.Match, "match", "", "*", "match a plugin name to build, supports globbing")
 	pluginBuildCmd.Flags().StringVarP(&pbFlags.PluginScopeAssociationFile, "plugin-scope-association-file", "", "", "file specifying plugin scope association")
 	pluginBuildCmd.Flags().StringVarP(&pbFlags.GoFlags, "goflags", "", "", "goflags to set on build")
 	pluginBuildCmd.Flags().BoolVarP(&pbFlags.DebugSymbols, "debug-symbols", "", false, "include debug symbols in the build")
+	pluginBuildCmd.Flags().BoolVarP(&pbFlags.Force, "force", "", false, "rebuild the plugin binaries even if their sources and build flags have not changed since they were last built")
+	pluginBuildCmd.Flags().BoolVarP(&pbFlags.Reproducible, "reproducible", "", false, "build binaries which are identical when built from identical sources")
+	pluginBuildCmd.Flags().StringArrayVarP(&pbFlags.SBOMFormats, "sbom", "", []string{}, "generate an SBOM of each plugin binary in the specified format, 'spdx' or 'cyclonedx'")
+	pluginBuildCmd.Flags().Int64VarP(&pbFlags.SourceDateEpoch, "source-date-epoch", "", 0, "unix timestamp to use as the build date, defaults to the value of the SOURCE_DATE_EPOCH environment variable")
+	addPluginSignFlags(pluginBuildCmd, &pbFlags.Sign)
 
 	_ = pluginBuildCmd.MarkFlagRequired("version")
 
 	return pluginBuildCmd
 }
 
+func newPluginSignCmd() *cobra.Command {
+	var binaryArtifactDir string
+	var psFlags = &pluginSignFlags{}
+
+	var pluginSignCmd = &cobra.Command{
+		Use:          "sign",
+		Short:        "Sign plugin binaries",
+		Long:         "Sign the plugin binaries so that they pass the gatekeeping of the operating system they are built for",
+		SilenceUsage: true,
+		Example: `
+    # Sign the windows binaries with Authenticode and timestamp the signatures
+    tanzu builder plugin sign --binary-artifacts ./artifacts/plugins --windows-cert-file ./cert.p12 --windows-timestamp-url http://timestamp.example.com
+
+    # Sign and notarize the darwin binaries (requires macOS)
+    tanzu builder plugin sign --binary-artifacts ./artifacts/plugins --macos-sign-identity "Developer ID Application: Example (ABCDE12345)" --macos-notary-profile example-notary`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			signers, err := psFlags.signers()
+			if err != nil {
+				return err
+			}
+			if len(signers) == 0 {
+				return errors.New("no signing configured, please specify the --windows-cert-file and/or --macos-sign-identity flags")
+			}
+			return signPlugins(binaryArtifactDir, signers)
+		},
+	}
+
+	pluginSignCmd.Flags().StringVarP(&binaryArtifactDir, "binary-artifacts", "", "./artifacts/plugins", "plugin binary artifact directory")
+	addPluginSignFlags(pluginSignCmd, psFlags)
+
+	return pluginSignCmd
+}
+
+func newPluginLintCmd() *cobra.Command {
+	var binaryArtifactDir, outputFormat string
+
+	var pluginLintCmd = &cobra.Command{
+		Use:   "lint",
+		Short: "Lint plugins",
+		Long: `Validate the plugins of the plugin manifest and the plugin binaries built for the host os-arch.
+The names, targets, versions and descriptions of the plugins are validated and the binaries are run to verify
+their 'info' and '--help' output and their conformance with the Tanzu CLI style guide.`,
+		SilenceUsage: true,
+		Example: `
+    # Lint the plugins available under the './artifacts/plugins' directory
+    tanzu builder plugin lint --binary-artifacts ./artifacts/plugins
+
+    # Lint the plugins and output the findings as JSON
+    tanzu builder plugin lint --binary-artifacts ./artifacts/plugins -o json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			lpArgs := &plugin.LintPluginOptions{
+				BinaryArtifactDir: binaryArtifactDir,
+			}
+			findings, err := lpArgs.LintPlugins()
+			if err != nil {
+				return err
+			}
+
+			output := component.NewOutputWriter(cmd.OutOrStdout(), outputFormat, "plugin", "target", "version", "check", "severity", "message")
+			numErrors := 0
+			for _, f := range findings {
+				output.AddRow(f.Plugin, f.Target, f.Version, f.Check, f.Severity, f.Message)
+				if f.Severity == plugin.LintSeverityError {
+					numErrors++
+				}
+			}
+			output.Render()
+
+			if numErrors > 0 {
+				return errors.Errorf("linting found %d error(s)", numErrors)
+			}
+			return nil
+		},
+	}
+
+	pluginLintCmd.Flags().StringVarP(&binaryArtifactDir, "binary-artifacts", "", "./artifacts/plugins", "plugin binary artifact directory")
+	pluginLintCmd.Flags().StringVarP(&outputFormat, "output", "o", "table", "output format of the findings (table|json|yaml)")
+
+	return pluginLintCmd
+}
+
+func newPluginDocsCmd() *cobra.Command {
+	var gpo = &plugin.GeneratePluginDocsOptions{}
+
+	var pluginDocsCmd = &cobra.Command{
+		Use:   "docs",
+		Short: "Generate the documentation of plugins",
+		Long: `Generate the documentation of the command tree of the plugins of the plugin manifest.
+The documentation is generated by running the 'generate-docs' command of the plugin binaries
+built for the host os-arch and is written to the '<docs-dir>/<target>/<plugin>' directories.`,
+		SilenceUsage: true,
+		Example: `
+    # Generate the markdown documentation of the plugins available under the './artifacts/plugins' directory
+    tanzu builder plugin docs --binary-artifacts ./artifacts/plugins --docs-dir ./docs/plugins
+
+    # Generate the man pages of the plugins
+    tanzu builder plugin docs --binary-artifacts ./artifacts/plugins --docs-dir ./docs/man --format man`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return gpo.GeneratePluginDocs()
+		},
+	}
+
+	pluginDocsCmd.Flags().StringVarP(&gpo.BinaryArtifactDir, "binary-artifacts", "", "./artifacts/plugins", "plugin binary artifact directory")
+	pluginDocsCmd.Flags().StringVarP(&gpo.DocsDir, "docs-dir", "", "./docs/plugins", "directory in which to generate the documentation")
+	pluginDocsCmd.Flags().StringVarP(&gpo.Format, "format", "", plugin.DocsFormatMarkdown, "format of the documentation (markdown|man)")
+
+	return pluginDocsCmd
+}
+
+func newPluginBundleCmd() *cobra.Command {
+	var pbFlags = &pluginBundleFlags{}
+
+	var pluginBundleCmd = &cobra.Command{
+		Use:   "bundle",
+		Short: "Bundle plugins as a local central repository",
+		Long: `Bundle the plugins of the plugin manifest as a central repository containing the plugin images
+and a plugin inventory database referencing them, to test the installation of the plugins before publishing them.
+The bundle can be saved as a tar file to be uploaded to any repository using 'tanzu plugin upload-bundle'
+and/or be served by a local registry until the command is interrupted.`,
+		SilenceUsage: true,
+		Example: `
+    # Save the plugins available under the './artifacts/plugins' directory as a plugin bundle
+    tanzu builder plugin bundle --binary-artifacts ./artifacts/plugins --to-tar ./artifacts/plugin-bundle.tar.gz
+
+    # Serve the plugins available under the './artifacts/plugins' directory from a local registry on port 5001
+    tanzu builder plugin bundle --binary-artifacts ./artifacts/plugins --serve 5001`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if pbFlags.ToTar == "" && !cmd.Flags().Changed("serve") {
+				return errors.New("please specify the --to-tar and/or the --serve flags")
+			}
+
+			registryPort, shutdownServerFunc, err := registry.ServeLocalRegistry(pbFlags.Serve)
+			if err != nil {
+				return errors.Wrap(err, "error while starting local registry server")
+			}
+			defer shutdownServerFunc()
+			repository := fmt.Sprintf("localhost:%s", registryPort)
+
+			bpo := &plugin.BundlePluginOptions{
+				BinaryArtifactDir:   pbFlags.BinaryArtifactDir,
+				Vendor:              pbFlags.Vendor,
+				Publisher:           pbFlags.Publisher,
+				Repository:          repository,
+				ToTar:               pbFlags.ToTar,
+				ImageOperationsImpl: carvelhelpers.NewImageOperationsImpl(),
+			}
+			err = bpo.BundlePlugins()
+			if err != nil || !cmd.Flags().Changed("serve") {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), `
+The plugins are served from %[1]s until this command is interrupted.
+To install them, in another terminal, run:
+
+    export TANZU_CLI_PLUGIN_DISCOVERY_IMAGE_SIGNATURE_VERIFICATION_SKIP_LIST=%[1]s/%[2]s:%[3]s
+    tanzu plugin source update default --uri %[1]s/%[2]s:%[3]s
+    tanzu plugin search
+
+Use 'tanzu plugin source init' to restore the default discovery source afterwards.
+`, repository, helpers.PluginInventoryDBImageName, plugin.BundleInventoryImageTag)
+
+			interrupt := make(chan os.Signal, 1)
+			signal.Notify(interrupt, os.Interrupt, syscall.SIGTERM)
+			<-interrupt
+			return nil
+		},
+	}
+
+	pluginBundleCmd.Flags().StringVarP(&pbFlags.BinaryArtifactDir, "binary-artifacts", "", "./artifacts/plugins", "plugin binary artifact directory")
+	pluginBundleCmd.Flags().StringVarP(&pbFlags.Vendor, "vendor", "", "local", "name of the vendor of the plugins")
+	pluginBundleCmd.Flags().StringVarP(&pbFlags.Publisher, "publisher", "", "test", "name of the publisher of the plugins")
+	pluginBundleCmd.Flags().StringVarP(&pbFlags.ToTar, "to-tar", "", "", "file in which to save the plugin bundle")
+	pluginBundleCmd.Flags().StringVarP(&pbFlags.Serve, "serve", "", "", "port on which to serve the plugins from a local registry, a random port is used when empty")
+	pluginBundleCmd.Flags().Lookup("serve").NoOptDefVal = "0"
+
+	return pluginBundleCmd
+}
+
 func newPluginBuildPackageCmd() *cobra.Command {
 	var pbpFlags = &pluginBuildPackageFlags{}
 
@@ -128,6 +500,7 @@ func newPluginBuildPackageCmd() *cobra.Command {
 				PackageArtifactDir: pbpFlags.PackageArtifactDir,
 				LocalOCIRegistry:   pbpFlags.localOCIRepository,
 				CraneOptions:       crane.NewCraneWrapper(),
+				Compress:           pbpFlags.Compress,
 			}
 			return bppArgs.BuildPluginPackages()
 		},
@@ -136,6 +509,7 @@ func newPluginBuildPackageCmd() *cobra.Command {
 	pluginBuildPackageCmd.Flags().StringVarP(&pbpFlags.BinaryArtifactDir, "binary-artifacts", "", "./artifacts/plugins", "plugin binary artifact directory")
 	pluginBuildPackageCmd.Flags().StringVarP(&pbpFlags.PackageArtifactDir, "package-artifacts", "", "./artifacts/packages", "plugin package artifacts directory")
 	pluginBuildPackageCmd.Flags().StringVarP(&pbpFlags.localOCIRepository, "oci-registry", "", "", "local oci-registry to use for generating packages (optional)")
+	pluginBuildPackageCmd.Flags().BoolVarP(&pbpFlags.Compress, "compress", "", false, "also generate packages of the plugin binaries compressed using zstd")
 
 	return pluginBuildPackageCmd
 }
@@ -150,12 +524,15 @@ func newPluginPublishPackageCmd() *cobra.Command {
 		SilenceUsage: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			bppArgs := &plugin.PublishPluginPackageOptions{
-				PackageArtifactDir: pppFlags.PackageArtifactDir,
-				Publisher:          pppFlags.Publisher,
-				Vendor:             pppFlags.Vendor,
-				Repository:         pppFlags.Repository,
-				DryRun:             pppFlags.DryRun,
-				CraneOptions:       crane.NewCraneWrapper(),
+				PackageArtifactDir:  pppFlags.PackageArtifactDir,
+				Publisher:           pppFlags.Publisher,
+				Vendor:              pppFlags.Vendor,
+				Repository:          pppFlags.Repository,
+				DryRun:              pppFlags.DryRun,
+				CraneOptions:        crane.NewCraneWrapper(),
+				InventoryImageTag:   pppFlags.InventoryImageTag,
+				AllowOverwrite:      pppFlags.AllowOverwrite,
+				ImageOperationsImpl: carvelhelpers.NewImageOperationsImpl(),
 			}
 			return bppArgs.PublishPluginPackages()
 		},
@@ -165,7 +542,9 @@ func newPluginPublishPackageCmd() *cobra.Command {
 	pluginBuildPackageCmd.Flags().StringVarP(&pppFlags.Repository, "repository", "", "", "repository to publish plugins")
 	pluginBuildPackageCmd.Flags().StringVarP(&pppFlags.Vendor, "vendor", "", "", "name of the vendor")
 	pluginBuildPackageCmd.Flags().StringVarP(&pppFlags.Publisher, "publisher", "", "", "name of the publisher")
-	pluginBuildPackageCmd.Flags().BoolVarP(&pppFlags.DryRun, "dry-run", "", false, "show commands without publishing plugin packages")
+	pluginBuildPackageCmd.Flags().BoolVarP(&pppFlags.DryRun, "dry-run", "", false, "show commands and the changes to the plugin inventory without publishing plugin packages")
+	pluginBuildPackageCmd.Flags().StringVarP(&pppFlags.InventoryImageTag, "plugin-inventory-image-tag", "", "latest", "tag of the plugin inventory image to compare against with --dry-run")
+	pluginBuildPackageCmd.Flags().BoolVarP(&pppFlags.AllowOverwrite, "allow-overwrite", "", false, "allow --dry-run to succeed when existing plugin versions would be overwritten")
 
 	_ = pluginBuildPackageCmd.MarkFlagRequired("repository")
 	_ = pluginBuildPackageCmd.MarkFlagRequired("vendor")
@@ -16,8 +16,10 @@ import (
 
 	"github.com/vmware-tanzu/tanzu-cli/cmd/plugin/builder/crane"
 	"github.com/vmware-tanzu/tanzu-cli/cmd/plugin/builder/helpers"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/artifact"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/carvelhelpers"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/sbom"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/utils"
 )
 
@@ -26,6 +28,9 @@ type BuildPluginPackageOptions struct {
 	PackageArtifactDir string
 	LocalOCIRegistry   string
 	CraneOptions       crane.CraneWrapper
+	// Compress also generates packages of the plugin binaries compressed using zstd,
+	// which are smaller to download for the CLIs supporting them
+	Compress bool
 
 	pluginManifestFile string
 }
@@ -72,7 +77,7 @@ func (bpo *BuildPluginPackageOptions) BuildPluginPackages() error {
 
 	id := 0
 	for i := range pluginManifest.Plugins {
-		for _, osArch := range cli.AllOSArch {
+		for _, osArch := range helpers.GetAllOSArch() {
 			for _, version := range pluginManifest.Plugins[i].Versions {
 				wg.Add(1)
 				guard <- struct{}{}
@@ -138,5 +143,64 @@ func (bpo *BuildPluginPackageOptions) generatePluginPackage(pluginBinaryFilePath
 	}
 
 	log.Infof("%s Generated plugin package at %q", threadID, pluginTarFilePath)
+
+	if bpo.Compress {
+		err = bpo.generateCompressedPluginPackage(pluginBinaryFilePath, p, osArch, version, threadID)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Include the SBOMs of the plugin binary with the package so they can be published along with it
+	for _, format := range sbom.SupportedFormats {
+		sbomFilePath := filepath.Join(filepath.Dir(pluginBinaryFilePath), format.FileName())
+		if !utils.PathExists(sbomFilePath) {
+			continue
+		}
+		err = utils.CopyFile(sbomFilePath, filepath.Join(filepath.Dir(pluginTarFilePath), format.FileName()))
+		if err != nil {
+			return errors.Wrapf(err, "unable to copy the %s SBOM for plugin: %s, target: %s, os: %s, arch: %s, version: %s", format, p.Name, p.Target, osArch.OS(), osArch.Arch(), version)
+		}
+	}
+	return nil
+}
+
+// generateCompressedPluginPackage generates the package of the plugin binary compressed using zstd
+func (bpo *BuildPluginPackageOptions) generateCompressedPluginPackage(pluginBinaryFilePath string, p cli.Plugin, osArch cli.Arch, version, threadID string) error {
+	binary, err := os.ReadFile(pluginBinaryFilePath)
+	if err != nil {
+		return errors.Wrapf(err, "unable to read plugin binary %q", pluginBinaryFilePath)
+	}
+	compressed, err := artifact.CompressZstd(binary)
+	if err != nil {
+		return errors.Wrapf(err, "unable to compress plugin binary %q", pluginBinaryFilePath)
+	}
+
+	tmpDir, err := os.MkdirTemp("", "")
+	if err != nil {
+		return errors.Wrap(err, "unable to create temporary directory")
+	}
+	defer os.RemoveAll(tmpDir)
+
+	compressedBinaryFilePath := filepath.Join(tmpDir, filepath.Base(pluginBinaryFilePath)+".zst")
+	err = os.WriteFile(compressedBinaryFilePath, compressed, 0644)
+	if err != nil {
+		return errors.Wrapf(err, "unable to write compressed plugin binary %q", compressedBinaryFilePath)
+	}
+
+	pluginTarFilePath := filepath.Join(bpo.PackageArtifactDir, helpers.GetCompressedPluginArchiveRelativePath(p, osArch, version))
+	image := helpers.GetCompressedPluginImage(fmt.Sprintf("%s/plugins/%s/%s/%s:%s", bpo.LocalOCIRegistry, osArch.OS(), osArch.Arch(), p.Name, version))
+
+	err = carvelhelpers.NewImageOperationsImpl().PushImage(image, []string{compressedBinaryFilePath})
+	if err != nil {
+		return errors.Wrapf(err, "unable to push compressed package to temporary registry for plugin: %s, target: %s, os: %s, arch: %s, version: %s", p.Name, p.Target, osArch.OS(), osArch.Arch(), version)
+	}
+
+	err = bpo.CraneOptions.SaveImage(image, pluginTarFilePath)
+	if err != nil {
+		return errors.Wrapf(err, "unable to generate compressed package for plugin: %s, target: %s, os: %s, arch: %s, version: %s", p.Name, p.Target, osArch.OS(), osArch.Arch(), version)
+	}
+
+	log.Infof("%s Generated compressed plugin package at %q (%d bytes instead of %d)", threadID, pluginTarFilePath, len(compressed), len(binary))
 	return nil
 }
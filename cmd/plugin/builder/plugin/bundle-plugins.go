@@ -0,0 +1,229 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package plugin
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"github.com/verybluebot/tarinator-go"
+	"gopkg.in/yaml.v3"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
+
+	"github.com/vmware-tanzu/tanzu-cli/cmd/plugin/builder/helpers"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/airgapped"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/carvelhelpers"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/distribution"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/plugininventory"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/utils"
+)
+
+const (
+	// BundleInventoryImageTag is the tag of the plugin inventory image of a plugin bundle
+	BundleInventoryImageTag = "latest"
+
+	pluginInventoryImageTarFileName = "plugin-inventory-image.tar.gz"
+)
+
+// BundlePluginOptions defines options for bundling the built plugins as a local central repository
+type BundlePluginOptions struct {
+	BinaryArtifactDir string
+	Vendor            string
+	Publisher         string
+	// Repository is the repository to which the plugin images and the
+	// inventory image are published while the bundle is generated
+	Repository string
+	// ToTar is the file in which to save the plugin bundle, the
+	// bundle is not saved when empty
+	ToTar string
+
+	ImageOperationsImpl carvelhelpers.ImageOperationsImpl
+
+	pluginManifestFile string
+}
+
+// BundlePlugins publishes the plugin binaries of the binary artifact directory as plugin images
+// along with a plugin inventory database referencing them, which makes the repository usable as
+// a plugin discovery source. When requested, the images are also saved as a plugin bundle that
+// can be uploaded to any repository with 'tanzu plugin upload-bundle'.
+func (bpo *BundlePluginOptions) BundlePlugins() error {
+	if bpo.pluginManifestFile == "" {
+		bpo.pluginManifestFile = filepath.Join(bpo.BinaryArtifactDir, cli.PluginManifestFileName)
+	}
+
+	pluginManifest, err := helpers.ReadPluginManifest(bpo.pluginManifestFile)
+	if err != nil {
+		return err
+	}
+
+	tempDir, err := os.MkdirTemp("", "")
+	if err != nil {
+		return errors.Wrap(err, "unable to create temp directory")
+	}
+	defer os.RemoveAll(tempDir)
+
+	pluginBundleDir := filepath.Join(tempDir, airgapped.PluginBundleDirName)
+	err = os.MkdirAll(pluginBundleDir, 0755)
+	if err != nil {
+		return err
+	}
+
+	dbFile := filepath.Join(tempDir, plugininventory.SQliteDBFileName)
+	db := plugininventory.NewSQLiteInventory(dbFile, "")
+	err = db.CreateSchema()
+	if err != nil {
+		return errors.Wrap(err, "error while creating database")
+	}
+
+	var imagesToCopy []*airgapped.ImageCopyInfo
+	var pluginEntries []*plugininventory.PluginInventoryEntry
+	for i := range pluginManifest.Plugins {
+		entry, images, err := bpo.publishPlugin(&pluginManifest.Plugins[i], pluginBundleDir)
+		if err != nil {
+			return err
+		}
+		if entry == nil {
+			log.Warningf("no binary found for plugin %q with target %q, skipping it", pluginManifest.Plugins[i].Name, pluginManifest.Plugins[i].Target)
+			continue
+		}
+		err = db.InsertPlugin(entry)
+		if err != nil {
+			return errors.Wrapf(err, "error while inserting plugin '%s_%s'", entry.Name, entry.Target)
+		}
+		pluginEntries = append(pluginEntries, entry)
+		imagesToCopy = append(imagesToCopy, images...)
+	}
+	if len(pluginEntries) == 0 {
+		return errors.Errorf("no plugin binaries found under %q", bpo.BinaryArtifactDir)
+	}
+
+	inventoryImage := fmt.Sprintf("%s/%s:%s", bpo.Repository, helpers.PluginInventoryDBImageName, BundleInventoryImageTag)
+	log.Infof("publishing plugin inventory database at: %q", inventoryImage)
+	err = bpo.ImageOperationsImpl.PushImage(inventoryImage, []string{dbFile})
+	if err != nil {
+		return errors.Wrapf(err, "error while publishing database to the repository as image: %q", inventoryImage)
+	}
+
+	if bpo.ToTar == "" {
+		return nil
+	}
+	return bpo.savePluginBundle(inventoryImage, imagesToCopy, pluginEntries, pluginBundleDir)
+}
+
+// publishPlugin publishes the image of every binary of the plugin and returns the inventory
+// entry of the plugin along with the images to copy as part of the plugin bundle.
+// The returned entry is nil when no binary of the plugin was found.
+func (bpo *BundlePluginOptions) publishPlugin(p *cli.Plugin, pluginBundleDir string) (*plugininventory.PluginInventoryEntry, []*airgapped.ImageCopyInfo, error) {
+	var entry *plugininventory.PluginInventoryEntry
+	var imagesToCopy []*airgapped.ImageCopyInfo
+
+	for _, version := range p.Versions {
+		for _, osArch := range helpers.GetAllOSArch() {
+			pluginBinaryFilePath := filepath.Join(bpo.BinaryArtifactDir, osArch.OS(), osArch.Arch(), p.Target, p.Name, version, cli.MakeArtifactName(p.Name, osArch))
+			if !utils.PathExists(pluginBinaryFilePath) {
+				continue
+			}
+
+			digest, err := helpers.GetDigest(pluginBinaryFilePath)
+			if err != nil {
+				return nil, nil, errors.Wrapf(err, "unable to compute the digest of %q", pluginBinaryFilePath)
+			}
+
+			relativeImagePath := fmt.Sprintf("%s/%s/%s/%s/%s/%s", bpo.Vendor, bpo.Publisher, osArch.OS(), osArch.Arch(), p.Target, p.Name)
+			image := fmt.Sprintf("%s/%s:%s", bpo.Repository, relativeImagePath, version)
+			log.Infof("publishing plugin 'plugin:%s' 'target:%s' 'os:%s' 'arch:%s' 'version:%s' at %q", p.Name, p.Target, osArch.OS(), osArch.Arch(), version, image)
+			err = bpo.ImageOperationsImpl.PushImage(image, []string{pluginBinaryFilePath})
+			if err != nil {
+				return nil, nil, errors.Wrapf(err, "error while publishing plugin binary %q", pluginBinaryFilePath)
+			}
+
+			if entry == nil {
+				entry = &plugininventory.PluginInventoryEntry{
+					Name:        p.Name,
+					Target:      configtypes.Target(p.Target),
+					Description: p.Description,
+					Publisher:   bpo.Publisher,
+					Vendor:      bpo.Vendor,
+					Artifacts:   make(map[string]distribution.ArtifactList),
+				}
+			}
+			entry.Artifacts[version] = append(entry.Artifacts[version], distribution.Artifact{
+				OS:     osArch.OS(),
+				Arch:   osArch.Arch(),
+				Digest: digest,
+				Image:  fmt.Sprintf("%s:%s", relativeImagePath, version),
+			})
+
+			if bpo.ToTar == "" {
+				continue
+			}
+			tarFileName := fmt.Sprintf("%s-%s-%s_%s-%s.tar.gz", p.Name, p.Target, osArch.OS(), osArch.Arch(), version)
+			err = bpo.ImageOperationsImpl.CopyImageToTar(image, filepath.Join(pluginBundleDir, tarFileName))
+			if err != nil {
+				return nil, nil, errors.Wrapf(err, "error while saving image %q", image)
+			}
+			imagesToCopy = append(imagesToCopy, &airgapped.ImageCopyInfo{
+				SourceTarFilePath: tarFileName,
+				RelativeImagePath: relativeImagePath,
+			})
+		}
+	}
+	return entry, imagesToCopy, nil
+}
+
+// savePluginBundle saves the published images as a plugin bundle in the format
+// expected by 'tanzu plugin upload-bundle'
+func (bpo *BundlePluginOptions) savePluginBundle(inventoryImage string, imagesToCopy []*airgapped.ImageCopyInfo, pluginEntries []*plugininventory.PluginInventoryEntry, pluginBundleDir string) error {
+	err := bpo.ImageOperationsImpl.CopyImageToTar(inventoryImage, filepath.Join(pluginBundleDir, pluginInventoryImageTarFileName))
+	if err != nil {
+		return errors.Wrapf(err, "error while saving image %q", inventoryImage)
+	}
+	imagesToCopy = append(imagesToCopy, &airgapped.ImageCopyInfo{
+		SourceTarFilePath: pluginInventoryImageTarFileName,
+		RelativeImagePath: helpers.PluginInventoryDBImageName,
+	})
+
+	metadataDB := plugininventory.NewSQLiteInventoryMetadata(filepath.Join(pluginBundleDir, plugininventory.SQliteInventoryMetadataDBFileName))
+	err = metadataDB.CreateInventoryMetadataDBSchema()
+	if err != nil {
+		return errors.Wrap(err, "error while creating the inventory metadata database")
+	}
+	for _, pe := range pluginEntries {
+		for version := range pe.Artifacts {
+			err = metadataDB.InsertPluginIdentifier(&plugininventory.PluginIdentifier{Name: pe.Name, Target: pe.Target, Version: version})
+			if err != nil {
+				return errors.Wrap(err, "error while updating the inventory metadata database")
+			}
+		}
+	}
+
+	manifest := airgapped.PluginMigrationManifest{
+		RelativeInventoryImagePathWithTag: fmt.Sprintf("%s:%s", helpers.PluginInventoryDBImageName, BundleInventoryImageTag),
+		ImagesToCopy:                      imagesToCopy,
+		InventoryMetadataImage: &airgapped.ImagePublishInfo{
+			SourceFilePath:           plugininventory.SQliteInventoryMetadataDBFileName,
+			RelativeImagePathWithTag: fmt.Sprintf("%s-metadata:%s", helpers.PluginInventoryDBImageName, BundleInventoryImageTag),
+		},
+	}
+	bytes, err := yaml.Marshal(&manifest)
+	if err != nil {
+		return err
+	}
+	err = os.WriteFile(filepath.Join(pluginBundleDir, airgapped.PluginMigrationManifestFile), bytes, 0644)
+	if err != nil {
+		return err
+	}
+
+	log.Infof("saving plugin bundle at: %s", bpo.ToTar)
+	err = tarinator.Tarinate([]string{pluginBundleDir}, bpo.ToTar)
+	if err != nil {
+		return errors.Wrap(err, "error while creating tar file")
+	}
+	return nil
+}
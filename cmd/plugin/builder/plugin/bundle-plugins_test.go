@@ -0,0 +1,133 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package plugin
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/tj/assert"
+	"github.com/verybluebot/tarinator-go"
+	"gopkg.in/yaml.v3"
+
+	"github.com/vmware-tanzu/tanzu-cli/cmd/plugin/builder/helpers"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/airgapped"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/fakes"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/plugininventory"
+)
+
+const bundleTestManifest = `plugins:
+- name: foo
+  target: global
+  description: manage foo
+  versions:
+  - v0.0.1
+- name: bar
+  target: kubernetes
+  description: manage bar
+  versions:
+  - v0.0.1
+`
+
+func TestBundlePlugins(t *testing.T) {
+	assert := assert.New(t)
+
+	artifactsDir := t.TempDir()
+	assert.Nil(os.WriteFile(filepath.Join(artifactsDir, cli.PluginManifestFileName), []byte(bundleTestManifest), 0644))
+	for _, osArch := range []cli.Arch{cli.LinuxAMD64, cli.DarwinAMD64} {
+		binaryDir := filepath.Join(artifactsDir, osArch.OS(), osArch.Arch(), "global", "foo", "v0.0.1")
+		assert.Nil(os.MkdirAll(binaryDir, 0755))
+		assert.Nil(os.WriteFile(filepath.Join(binaryDir, cli.MakeArtifactName("foo", osArch)), []byte("foo binary"), 0755))
+	}
+
+	outputDir := t.TempDir()
+	inventoryDBFile := filepath.Join(outputDir, plugininventory.SQliteDBFileName)
+	fakeImageOperations := &fakes.ImageOperationsImpl{}
+	fakeImageOperations.PushImageCalls(func(image string, files []string) error {
+		if strings.Contains(image, helpers.PluginInventoryDBImageName) {
+			content, err := os.ReadFile(files[0])
+			assert.Nil(err)
+			assert.Nil(os.WriteFile(inventoryDBFile, content, 0644))
+		}
+		return nil
+	})
+	fakeImageOperations.CopyImageToTarCalls(func(image, tarFile string) error {
+		return os.WriteFile(tarFile, []byte(image), 0644)
+	})
+
+	bpo := &BundlePluginOptions{
+		BinaryArtifactDir:   artifactsDir,
+		Vendor:              "local",
+		Publisher:           "test",
+		Repository:          "localhost:5001",
+		ToTar:               filepath.Join(outputDir, "bundle.tar.gz"),
+		ImageOperationsImpl: fakeImageOperations,
+	}
+	assert.Nil(bpo.BundlePlugins())
+
+	// The two binaries of foo and the inventory database are published, bar has no binary
+	assert.Equal(3, fakeImageOperations.PushImageCallCount())
+	image, _ := fakeImageOperations.PushImageArgsForCall(0)
+	assert.Equal("localhost:5001/local/test/linux/amd64/global/foo:v0.0.1", image)
+	image, _ = fakeImageOperations.PushImageArgsForCall(2)
+	assert.Equal("localhost:5001/plugin-inventory:latest", image)
+
+	entries, err := plugininventory.NewSQLiteInventory(inventoryDBFile, "localhost:5001").GetAllPlugins()
+	assert.Nil(err)
+	assert.Equal(1, len(entries))
+	assert.Equal("foo", entries[0].Name)
+	assert.Equal("local", entries[0].Vendor)
+	assert.Equal("test", entries[0].Publisher)
+	assert.Equal(2, len(entries[0].Artifacts["v0.0.1"]))
+	digest, err := helpers.GetDigest(filepath.Join(artifactsDir, "linux", "amd64", "global", "foo", "v0.0.1", cli.MakeArtifactName("foo", cli.LinuxAMD64)))
+	assert.Nil(err)
+	for _, a := range entries[0].Artifacts["v0.0.1"] {
+		if a.OS == "linux" {
+			assert.Equal(digest, a.Digest)
+			assert.Equal("localhost:5001/local/test/linux/amd64/global/foo:v0.0.1", a.Image)
+		}
+	}
+
+	// The bundle can be uploaded with 'tanzu plugin upload-bundle'
+	extractDir := t.TempDir()
+	assert.Nil(tarinator.UnTarinate(extractDir, bpo.ToTar))
+	bundleDir := filepath.Join(extractDir, airgapped.PluginBundleDirName)
+	bytes, err := os.ReadFile(filepath.Join(bundleDir, airgapped.PluginMigrationManifestFile))
+	assert.Nil(err)
+	manifest := &airgapped.PluginMigrationManifest{}
+	assert.Nil(yaml.Unmarshal(bytes, manifest))
+	assert.Equal("plugin-inventory:latest", manifest.RelativeInventoryImagePathWithTag)
+	assert.Equal("plugin-inventory-metadata:latest", manifest.InventoryMetadataImage.RelativeImagePathWithTag)
+	assert.FileExists(filepath.Join(bundleDir, manifest.InventoryMetadataImage.SourceFilePath))
+	assert.Equal(3, len(manifest.ImagesToCopy))
+	for _, ic := range manifest.ImagesToCopy {
+		assert.FileExists(filepath.Join(bundleDir, ic.SourceTarFilePath))
+	}
+	assert.Equal("local/test/darwin/amd64/global/foo", manifest.ImagesToCopy[1].RelativeImagePath)
+	assert.Equal("plugin-inventory", manifest.ImagesToCopy[2].RelativeImagePath)
+}
+
+func TestBundlePluginsWithoutBinaries(t *testing.T) {
+	assert := assert.New(t)
+
+	artifactsDir := t.TempDir()
+	assert.Nil(os.WriteFile(filepath.Join(artifactsDir, cli.PluginManifestFileName), []byte(bundleTestManifest), 0644))
+
+	fakeImageOperations := &fakes.ImageOperationsImpl{}
+	bpo := &BundlePluginOptions{
+		BinaryArtifactDir:   artifactsDir,
+		Vendor:              "local",
+		Publisher:           "test",
+		Repository:          "localhost:5001",
+		ImageOperationsImpl: fakeImageOperations,
+	}
+	err := bpo.BundlePlugins()
+	assert.NotNil(err)
+	assert.Contains(err.Error(), "no plugin binaries found")
+	assert.Equal(0, fakeImageOperations.PushImageCallCount())
+	assert.Equal(0, fakeImageOperations.CopyImageToTarCallCount())
+}
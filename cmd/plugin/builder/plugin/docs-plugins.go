@@ -0,0 +1,119 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package plugin
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
+
+	"github.com/vmware-tanzu/tanzu-cli/cmd/plugin/builder/helpers"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
+)
+
+const (
+	// DocsFormatMarkdown generates markdown documentation
+	DocsFormatMarkdown = "markdown"
+	// DocsFormatMan generates man pages
+	DocsFormatMan = "man"
+
+	// rootDocFileName is the file documenting the 'tanzu' command itself which
+	// is generated along with the documentation of each plugin
+	rootDocFileName = "tanzu.md"
+)
+
+type GeneratePluginDocsOptions struct {
+	BinaryArtifactDir string
+	DocsDir           string
+	Format            string
+
+	pluginManifestFile string
+}
+
+// GeneratePluginDocs generates the documentation of the command tree of each plugin
+// of the binary artifact directory into the '<DocsDir>/<target>/<plugin>' directory.
+// The documentation is generated by the 'generate-docs' command that the plugin
+// runtime provides to every plugin, using the plugin binary built for the host os-arch.
+func (gpo *GeneratePluginDocsOptions) GeneratePluginDocs() error {
+	if gpo.Format != DocsFormatMarkdown && gpo.Format != DocsFormatMan {
+		return errors.Errorf("invalid docs format %q, must be %q or %q", gpo.Format, DocsFormatMarkdown, DocsFormatMan)
+	}
+	if gpo.pluginManifestFile == "" {
+		gpo.pluginManifestFile = filepath.Join(gpo.BinaryArtifactDir, cli.PluginManifestFileName)
+	}
+
+	pluginManifest, err := helpers.ReadPluginManifest(gpo.pluginManifestFile)
+	if err != nil {
+		return err
+	}
+
+	for i := range pluginManifest.Plugins {
+		p := &pluginManifest.Plugins[i]
+		if len(p.Versions) == 0 {
+			continue
+		}
+		// The documentation is the one of the latest version built
+		version := p.Versions[len(p.Versions)-1]
+		binaryPath := helpers.GetHostPluginBinaryPath(gpo.BinaryArtifactDir, p, version)
+		if binaryPath == "" {
+			return errors.Errorf("no binary of plugin %q version %q found for the host os-arch %q", p.Name, version, cli.BuildArch())
+		}
+
+		outputDir := filepath.Join(gpo.DocsDir, p.Target, p.Name)
+		err = gpo.generateDocs(binaryPath, outputDir)
+		if err != nil {
+			return errors.Wrapf(err, "unable to generate the docs of plugin %q for target %q", p.Name, p.Target)
+		}
+		log.Infof("Generated the docs of plugin %q for target %q in %q", p.Name, p.Target, outputDir)
+	}
+	return nil
+}
+
+func (gpo *GeneratePluginDocsOptions) generateDocs(binaryPath, outputDir string) error {
+	tmpDir, err := os.MkdirTemp("", "tanzu-builder-docs")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	output, err := exec.Command(binaryPath, "generate-docs", "--docs-dir", tmpDir).CombinedOutput()
+	if err != nil {
+		return errors.Wrapf(err, "running the 'generate-docs' command failed: %s", strings.TrimSpace(string(output)))
+	}
+
+	err = os.MkdirAll(outputDir, 0755)
+	if err != nil {
+		return err
+	}
+
+	files, err := os.ReadDir(tmpDir)
+	if err != nil {
+		return err
+	}
+	for _, f := range files {
+		if f.IsDir() || f.Name() == rootDocFileName || filepath.Ext(f.Name()) != ".md" {
+			continue
+		}
+		content, err := os.ReadFile(filepath.Join(tmpDir, f.Name()))
+		if err != nil {
+			return err
+		}
+
+		fileName := f.Name()
+		if gpo.Format == DocsFormatMan {
+			fileName = strings.ReplaceAll(strings.TrimSuffix(fileName, ".md"), "_", "-") + ".1"
+			content = markdownToMan(content)
+		}
+		err = os.WriteFile(filepath.Join(outputDir, fileName), content, 0644)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
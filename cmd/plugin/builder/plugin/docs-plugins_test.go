@@ -0,0 +1,122 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package plugin
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/tj/assert"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
+)
+
+const fooBarMarkdown = "## tanzu foo bar\n\nManage bar\n\n### Synopsis\n\nManage the bar resources\n\n```\ntanzu foo bar [flags]\n```\n\n### Options\n\n```\n  -h, --help   help for bar\n```\n\n### SEE ALSO\n\n* [tanzu foo](tanzu_foo.md)\t - Manage foo\n"
+
+// fakeDocsPluginScript simulates a plugin binary built with the plugin runtime
+const fakeDocsPluginScript = `#!/bin/sh
+if [ "$1" = "generate-docs" ] && [ "$2" = "--docs-dir" ]; then
+  printf '## tanzu\n\nThe main Tanzu CLI\n' > "$3/tanzu.md"
+  printf '## tanzu foo\n\nManage foo\n' > "$3/tanzu_foo.md"
+  printf '` + "%s" + `' > "$3/tanzu_foo_bar.md"
+  exit 0
+fi
+exit 1
+`
+
+func TestMarkdownToMan(t *testing.T) {
+	assert := assert.New(t)
+
+	expected := `.TH "TANZU-FOO-BAR" "1" "" "" "Tanzu CLI"
+.SH NAME
+tanzu\-foo\-bar \- Manage bar
+.PP
+.SH SYNOPSIS
+.PP
+Manage the bar resources
+.PP
+.PP
+.RS
+.nf
+tanzu foo bar [flags]
+.fi
+.RE
+.PP
+.SH OPTIONS
+.PP
+.PP
+.RS
+.nf
+  \-h, \-\-help   help for bar
+.fi
+.RE
+.PP
+.SH SEE ALSO
+.PP
+.TP
+\fBtanzu\-foo\fP(1)
+Manage foo
+`
+	assert.Equal(expected, string(markdownToMan([]byte(fooBarMarkdown))))
+}
+
+func TestGeneratePluginDocs(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the fake plugin binary is a shell script")
+	}
+
+	tests := []struct {
+		format        string
+		expectedFiles []string
+	}{
+		{
+			format:        DocsFormatMarkdown,
+			expectedFiles: []string{"tanzu_foo.md", "tanzu_foo_bar.md"},
+		},
+		{
+			format:        DocsFormatMan,
+			expectedFiles: []string{"tanzu-foo-bar.1", "tanzu-foo.1"},
+		},
+	}
+
+	for _, spec := range tests {
+		t.Run(spec.format, func(t *testing.T) {
+			assert := assert.New(t)
+
+			dir := t.TempDir()
+			manifest := "plugins:\n- name: foo\n  target: global\n  description: manage foo\n  versions:\n  - v0.0.1\n"
+			assert.Nil(os.WriteFile(filepath.Join(dir, cli.PluginManifestFileName), []byte(manifest), 0644))
+
+			osArch := cli.BuildArch()
+			binaryPath := filepath.Join(dir, osArch.OS(), osArch.Arch(), "global", "foo", "v0.0.1", cli.MakeArtifactName("foo", osArch))
+			assert.Nil(os.MkdirAll(filepath.Dir(binaryPath), 0755))
+			script := []byte(strings.Replace(fakeDocsPluginScript, "%s", strings.ReplaceAll(fooBarMarkdown, "\n", `\n`), 1))
+			assert.Nil(os.WriteFile(binaryPath, script, 0755))
+
+			docsDir := t.TempDir()
+			gpo := &GeneratePluginDocsOptions{BinaryArtifactDir: dir, DocsDir: docsDir, Format: spec.format}
+			assert.Nil(gpo.GeneratePluginDocs())
+
+			entries, err := os.ReadDir(filepath.Join(docsDir, "global", "foo"))
+			assert.Nil(err)
+			var files []string
+			for _, e := range entries {
+				files = append(files, e.Name())
+			}
+			assert.Equal(spec.expectedFiles, files)
+		})
+	}
+}
+
+func TestGeneratePluginDocsInvalidFormat(t *testing.T) {
+	assert := assert.New(t)
+
+	gpo := &GeneratePluginDocsOptions{Format: "html"}
+	err := gpo.GeneratePluginDocs()
+	assert.NotNil(err)
+	assert.Contains(err.Error(), `invalid docs format "html"`)
+}
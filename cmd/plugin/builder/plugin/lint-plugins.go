@@ -0,0 +1,201 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package plugin
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/Masterminds/semver"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
+	rtplugin "github.com/vmware-tanzu/tanzu-plugin-runtime/plugin"
+
+	"github.com/vmware-tanzu/tanzu-cli/cmd/plugin/builder/helpers"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
+)
+
+const (
+	// LintSeverityError is the severity of findings which must be fixed
+	LintSeverityError = "error"
+	// LintSeverityWarning is the severity of findings which should be fixed
+	LintSeverityWarning = "warning"
+
+	// maxDescriptionLength is the maximum length of a plugin description
+	// for it to be displayed properly by 'tanzu plugin list'
+	maxDescriptionLength = 100
+)
+
+var lintPluginNameRegexp = regexp.MustCompile(`^[a-z][a-z0-9-]*$`)
+
+// validCmdGroups are the command groups plugins can be part of
+var validCmdGroups = []rtplugin.CmdGroup{
+	rtplugin.RunCmdGroup,
+	rtplugin.ManageCmdGroup,
+	rtplugin.BuildCmdGroup,
+	rtplugin.ObserveCmdGroup,
+	rtplugin.SystemCmdGroup,
+	rtplugin.TargetCmdGroup,
+	rtplugin.VersionCmdGroup,
+	rtplugin.AdminCmdGroup,
+}
+
+// LintFinding is an issue found when linting a plugin.
+type LintFinding struct {
+	Plugin   string `json:"plugin" yaml:"plugin"`
+	Target   string `json:"target" yaml:"target"`
+	Version  string `json:"version" yaml:"version"`
+	Check    string `json:"check" yaml:"check"`
+	Severity string `json:"severity" yaml:"severity"`
+	Message  string `json:"message" yaml:"message"`
+}
+
+type LintPluginOptions struct {
+	BinaryArtifactDir string
+
+	pluginManifestFile string
+	findings           []LintFinding
+}
+
+// LintPlugins validates the plugins of the plugin manifest of the binary artifact
+// directory as well as the plugin binaries built for the host os-arch, and returns
+// the issues found.
+func (lpo *LintPluginOptions) LintPlugins() ([]LintFinding, error) {
+	if lpo.pluginManifestFile == "" {
+		lpo.pluginManifestFile = filepath.Join(lpo.BinaryArtifactDir, cli.PluginManifestFileName)
+	}
+
+	pluginManifest, err := helpers.ReadPluginManifest(lpo.pluginManifestFile)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Infof("Linting plugins from %q", lpo.BinaryArtifactDir)
+
+	lpo.findings = []LintFinding{}
+	for i := range pluginManifest.Plugins {
+		p := &pluginManifest.Plugins[i]
+		lpo.lintManifestEntry(p)
+		for _, version := range p.Versions {
+			lpo.lintBinary(p, version)
+		}
+	}
+	return lpo.findings, nil
+}
+
+func (lpo *LintPluginOptions) addFinding(p *cli.Plugin, version, check, severity, format string, args ...interface{}) {
+	lpo.findings = append(lpo.findings, LintFinding{
+		Plugin:   p.Name,
+		Target:   p.Target,
+		Version:  version,
+		Check:    check,
+		Severity: severity,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+// lintManifestEntry validates the plugin entry of the plugin manifest
+func (lpo *LintPluginOptions) lintManifestEntry(p *cli.Plugin) {
+	const check = "manifest"
+
+	if !lintPluginNameRegexp.MatchString(p.Name) {
+		lpo.addFinding(p, "", check, LintSeverityError, "invalid name %q, it must start with a lowercase letter and only contain lowercase letters, digits and dashes", p.Name)
+	}
+	if !configtypes.IsValidTarget(p.Target, true, false) {
+		lpo.addFinding(p, "", check, LintSeverityError, "invalid target %q", p.Target)
+	}
+	if p.Description == "" {
+		lpo.addFinding(p, "", check, LintSeverityError, "missing description")
+	} else if len(p.Description) > maxDescriptionLength {
+		lpo.addFinding(p, "", check, LintSeverityWarning, "description is longer than %d characters", maxDescriptionLength)
+	}
+	if len(p.Versions) == 0 {
+		lpo.addFinding(p, "", check, LintSeverityError, "no version specified")
+	}
+	for _, version := range p.Versions {
+		if _, err := semver.NewVersion(version); err != nil || !strings.HasPrefix(version, "v") {
+			lpo.addFinding(p, version, check, LintSeverityError, "invalid version %q, it must be a semantic version starting with 'v'", version)
+		}
+	}
+}
+
+// lintBinary runs the plugin binary built for the host os-arch to validate it
+func (lpo *LintPluginOptions) lintBinary(p *cli.Plugin, version string) {
+	binaryPath := helpers.GetHostPluginBinaryPath(lpo.BinaryArtifactDir, p, version)
+	if binaryPath == "" {
+		lpo.addFinding(p, version, "binary", LintSeverityWarning, "no binary found for the host os-arch %q, skipping the checks of the binary", cli.BuildArch())
+		return
+	}
+
+	output, err := exec.Command(binaryPath, "info").Output()
+	if err != nil {
+		lpo.addFinding(p, version, "info", LintSeverityError, "running the 'info' command failed: %v", err)
+	} else {
+		lpo.lintInfo(p, version, output)
+	}
+
+	output, err = exec.Command(binaryPath, "--help").CombinedOutput()
+	if err != nil {
+		lpo.addFinding(p, version, "help", LintSeverityError, "running with '--help' failed: %v: %s", err, strings.TrimSpace(string(output)))
+	}
+
+	// The 'lint' command of the plugin runtime checks the command tree against the Tanzu CLI style guide
+	output, err = exec.Command(binaryPath, "lint").Output()
+	if err != nil {
+		lpo.lintStyle(p, version, output)
+	}
+}
+
+// lintInfo validates the output of the 'info' command against the plugin manifest
+func (lpo *LintPluginOptions) lintInfo(p *cli.Plugin, version string, output []byte) {
+	const check = "info"
+
+	var desc rtplugin.PluginDescriptor
+	if err := json.Unmarshal(output, &desc); err != nil {
+		lpo.addFinding(p, version, check, LintSeverityError, "invalid output of the 'info' command: %v", err)
+		return
+	}
+	if desc.Name != p.Name {
+		lpo.addFinding(p, version, check, LintSeverityError, "the binary reports the name %q", desc.Name)
+	}
+	if string(desc.Target) != p.Target {
+		lpo.addFinding(p, version, check, LintSeverityError, "the binary reports the target %q", desc.Target)
+	}
+	if desc.Version != version {
+		lpo.addFinding(p, version, check, LintSeverityError, "the binary reports the version %q", desc.Version)
+	}
+	if desc.Description != p.Description {
+		lpo.addFinding(p, version, check, LintSeverityWarning, "the binary reports the description %q", desc.Description)
+	}
+	if !slices.Contains(validCmdGroups, desc.Group) {
+		lpo.addFinding(p, version, check, LintSeverityWarning, "unknown command group %q", desc.Group)
+	}
+}
+
+// lintStyle converts the table output by the 'lint' command of the plugin runtime into findings
+func (lpo *LintPluginOptions) lintStyle(p *cli.Plugin, version string, output []byte) {
+	const check = "style"
+
+	found := false
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || line == "---" || strings.HasPrefix(line, "COMMAND") {
+			continue
+		}
+		lpo.addFinding(p, version, check, LintSeverityWarning, "%s", strings.Join(strings.Fields(line), " "))
+		found = true
+	}
+	if !found {
+		lpo.addFinding(p, version, check, LintSeverityWarning, "the 'lint' command of the plugin failed")
+	}
+}
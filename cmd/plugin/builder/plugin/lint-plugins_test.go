@@ -0,0 +1,130 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package plugin
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/tj/assert"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
+)
+
+const lintTestManifest = `plugins:
+- name: foo
+  target: global
+  description: manage foo
+  versions:
+  - v0.0.1
+- name: Bar_
+  target: invalid
+  description: ""
+  versions:
+  - "1.0"
+`
+
+// fakePluginScript simulates a plugin binary built with the plugin runtime
+const fakePluginScript = `#!/bin/sh
+case "$1" in
+info)
+  echo '{"name":"foo","description":"manage foo","target":"global","version":"v0.0.1","group":"Unknown"}'
+  ;;
+lint)
+  echo '  COMMAND  LINT'
+  echo '  foo      unexpected flag fooflag, expected standard flag'
+  echo '---'
+  exit 1
+  ;;
+esac
+exit 0
+`
+
+func findingsFor(findings []LintFinding, pluginName, check string) []LintFinding {
+	var result []LintFinding
+	for _, f := range findings {
+		if f.Plugin == pluginName && f.Check == check {
+			result = append(result, f)
+		}
+	}
+	return result
+}
+
+func TestLintPluginsManifest(t *testing.T) {
+	assert := assert.New(t)
+
+	dir := t.TempDir()
+	assert.Nil(os.WriteFile(filepath.Join(dir, cli.PluginManifestFileName), []byte(lintTestManifest), 0644))
+
+	lpo := &LintPluginOptions{BinaryArtifactDir: dir}
+	findings, err := lpo.LintPlugins()
+	assert.Nil(err)
+
+	assert.Equal(0, len(findingsFor(findings, "foo", "manifest")))
+
+	barFindings := findingsFor(findings, "Bar_", "manifest")
+	assert.Equal(4, len(barFindings))
+	for _, f := range barFindings {
+		assert.Equal(LintSeverityError, f.Severity)
+	}
+	assert.Contains(barFindings[0].Message, `invalid name "Bar_"`)
+	assert.Contains(barFindings[1].Message, `invalid target "invalid"`)
+	assert.Contains(barFindings[2].Message, "missing description")
+	assert.Contains(barFindings[3].Message, `invalid version "1.0"`)
+
+	// No binaries were built
+	binaryFindings := findingsFor(findings, "foo", "binary")
+	assert.Equal(1, len(binaryFindings))
+	assert.Equal(LintSeverityWarning, binaryFindings[0].Severity)
+}
+
+func TestLintPluginsDescriptionLength(t *testing.T) {
+	assert := assert.New(t)
+
+	dir := t.TempDir()
+	manifest := fmt.Sprintf("plugins:\n- name: foo\n  target: global\n  description: %s\n  versions:\n  - v0.0.1\n", strings.Repeat("a", maxDescriptionLength+1))
+	assert.Nil(os.WriteFile(filepath.Join(dir, cli.PluginManifestFileName), []byte(manifest), 0644))
+
+	lpo := &LintPluginOptions{BinaryArtifactDir: dir}
+	findings, err := lpo.LintPlugins()
+	assert.Nil(err)
+
+	manifestFindings := findingsFor(findings, "foo", "manifest")
+	assert.Equal(1, len(manifestFindings))
+	assert.Equal(LintSeverityWarning, manifestFindings[0].Severity)
+}
+
+func TestLintPluginsBinary(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the fake plugin binary is a shell script")
+	}
+	assert := assert.New(t)
+
+	dir := t.TempDir()
+	assert.Nil(os.WriteFile(filepath.Join(dir, cli.PluginManifestFileName), []byte(lintTestManifest), 0644))
+
+	osArch := cli.BuildArch()
+	binaryPath := filepath.Join(dir, osArch.OS(), osArch.Arch(), "global", "foo", "v0.0.1", cli.MakeArtifactName("foo", osArch))
+	assert.Nil(os.MkdirAll(filepath.Dir(binaryPath), 0755))
+	assert.Nil(os.WriteFile(binaryPath, []byte(fakePluginScript), 0755))
+
+	lpo := &LintPluginOptions{BinaryArtifactDir: dir}
+	findings, err := lpo.LintPlugins()
+	assert.Nil(err)
+
+	assert.Equal(0, len(findingsFor(findings, "foo", "binary")))
+	assert.Equal(0, len(findingsFor(findings, "foo", "help")))
+
+	infoFindings := findingsFor(findings, "foo", "info")
+	assert.Equal(1, len(infoFindings))
+	assert.Equal(`unknown command group "Unknown"`, infoFindings[0].Message)
+
+	styleFindings := findingsFor(findings, "foo", "style")
+	assert.Equal(1, len(styleFindings))
+	assert.Equal("foo unexpected flag fooflag, expected standard flag", styleFindings[0].Message)
+}
@@ -0,0 +1,81 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package plugin
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// seeAlsoRegexp matches the entries of the 'SEE ALSO' section, e.g. '* [tanzu foo](tanzu_foo.md)	 - Manage foo'
+var seeAlsoRegexp = regexp.MustCompile(`^\* \[([^\]]+)\]\([^)]*\)\s*-\s*(.*)$`)
+
+// manPageName returns the name of the man page of a command path, e.g. 'tanzu-foo-bar' for 'tanzu foo bar'
+func manPageName(cmdPath string) string {
+	return strings.Join(strings.Fields(cmdPath), "-")
+}
+
+// escapeRoff escapes the text of a line so it is not interpreted as roff requests
+func escapeRoff(line string) string {
+	line = strings.ReplaceAll(line, `\`, `\e`)
+	line = strings.ReplaceAll(line, "-", `\-`)
+	if strings.HasPrefix(line, ".") || strings.HasPrefix(line, "'") {
+		line = `\&` + line
+	}
+	return line
+}
+
+// markdownToMan converts the markdown documentation of a command, as generated
+// by cobra, into a man page.  Only the markdown constructs used by cobra are
+// supported: the '##' title, '###' sections, code blocks, paragraphs and the
+// links of the 'SEE ALSO' section.
+func markdownToMan(md []byte) []byte {
+	var out bytes.Buffer
+	scanner := bufio.NewScanner(bytes.NewReader(md))
+
+	inCode := false
+	wantName := false
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		switch {
+		case strings.HasPrefix(line, "```"):
+			if inCode {
+				out.WriteString(".fi\n.RE\n")
+			} else {
+				out.WriteString(".PP\n.RS\n.nf\n")
+			}
+			inCode = !inCode
+		case inCode:
+			out.WriteString(escapeRoff(line) + "\n")
+		case strings.HasPrefix(line, "### "):
+			fmt.Fprintf(&out, ".SH %s\n", strings.ToUpper(strings.TrimPrefix(line, "### ")))
+		case strings.HasPrefix(line, "## "):
+			name := manPageName(strings.TrimPrefix(line, "## "))
+			fmt.Fprintf(&out, ".TH %q \"1\" \"\" \"\" \"Tanzu CLI\"\n.SH NAME\n", strings.ToUpper(name))
+			out.WriteString(escapeRoff(name))
+			// The short description of the command follows the title
+			wantName = true
+		case wantName && strings.TrimSpace(line) != "":
+			fmt.Fprintf(&out, ` \- %s`+"\n", escapeRoff(line))
+			wantName = false
+		case seeAlsoRegexp.MatchString(line):
+			match := seeAlsoRegexp.FindStringSubmatch(line)
+			fmt.Fprintf(&out, ".TP\n\\fB%s\\fP(1)\n%s\n", escapeRoff(manPageName(match[1])), escapeRoff(match[2]))
+		case strings.TrimSpace(line) == "":
+			if !wantName {
+				out.WriteString(".PP\n")
+			}
+		default:
+			out.WriteString(escapeRoff(line) + "\n")
+		}
+	}
+	if wantName {
+		out.WriteString("\n")
+	}
+	return out.Bytes()
+}
@@ -0,0 +1,164 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package plugin
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/component"
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
+
+	"github.com/vmware-tanzu/tanzu-cli/cmd/plugin/builder/helpers"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/plugininventory"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/utils"
+)
+
+const (
+	// InventoryChangeNewVersion is a plugin version not yet in the inventory
+	InventoryChangeNewVersion = "new version"
+	// InventoryChangeNewOSArch is an os-arch not yet in the inventory for an existing plugin version
+	InventoryChangeNewOSArch = "new os-arch"
+	// InventoryChangeOverwrite is an existing plugin version whose binary digest would change
+	InventoryChangeOverwrite = "overwritten digest"
+	// InventoryChangeUnchanged is an existing plugin version whose binary digest is identical
+	InventoryChangeUnchanged = "unchanged"
+)
+
+// InventoryChange describes how publishing a plugin binary affects the inventory database
+type InventoryChange struct {
+	Plugin         string
+	Target         string
+	Version        string
+	OS             string
+	Arch           string
+	Change         string
+	Image          string
+	Digest         string
+	ExistingDigest string
+}
+
+// diffWithInventory pulls the inventory database of the repository and returns the changes
+// that publishing the plugin packages, and then adding them to the inventory, would cause.
+func (ppo *PublishPluginPackageOptions) diffWithInventory(pluginManifest *cli.Manifest) ([]InventoryChange, error) {
+	db, cleanup, err := ppo.pullInventoryDB()
+	if err != nil {
+		return nil, err
+	}
+	defer cleanup()
+
+	var changes []InventoryChange
+	for i := range pluginManifest.Plugins {
+		p := pluginManifest.Plugins[i]
+
+		var existing *plugininventory.PluginInventoryEntry
+		if db != nil {
+			entries, err := db.GetPlugins(&plugininventory.PluginInventoryFilter{
+				Name:          p.Name,
+				Target:        configtypes.Target(p.Target),
+				Publisher:     ppo.Publisher,
+				Vendor:        ppo.Vendor,
+				IncludeHidden: true,
+			})
+			if err != nil {
+				return nil, errors.Wrapf(err, "unable to read plugin %q for target %q from the inventory", p.Name, p.Target)
+			}
+			if len(entries) > 0 {
+				existing = entries[0]
+			}
+		}
+
+		for _, osArch := range helpers.GetAllOSArch() {
+			for _, version := range p.Versions {
+				pluginTarFilePath := filepath.Join(ppo.PackageArtifactDir, helpers.GetPluginArchiveRelativePath(p, osArch, version))
+				if !utils.PathExists(pluginTarFilePath) {
+					continue
+				}
+				digest, err := ppo.CraneOptions.GetFileDigestFromTar(pluginTarFilePath, cli.MakeArtifactName(p.Name, osArch))
+				if err != nil {
+					return nil, errors.Wrapf(err, "unable to get the plugin binary digest from %q", pluginTarFilePath)
+				}
+
+				change := InventoryChange{
+					Plugin:  p.Name,
+					Target:  p.Target,
+					Version: version,
+					OS:      osArch.OS(),
+					Arch:    osArch.Arch(),
+					Image:   fmt.Sprintf("%s/%s/%s/%s/%s/%s:%s", ppo.Vendor, ppo.Publisher, osArch.OS(), osArch.Arch(), p.Target, p.Name, version),
+					Digest:  digest,
+					Change:  InventoryChangeNewVersion,
+				}
+				if existing != nil {
+					if artifacts, exists := existing.Artifacts[version]; exists {
+						change.Change = InventoryChangeNewOSArch
+						for _, a := range artifacts {
+							if a.OS != osArch.OS() || a.Arch != osArch.Arch() {
+								continue
+							}
+							change.ExistingDigest = a.Digest
+							change.Change = InventoryChangeOverwrite
+							if a.Digest == digest {
+								change.Change = InventoryChangeUnchanged
+							}
+						}
+					}
+				}
+				changes = append(changes, change)
+			}
+		}
+	}
+	return changes, nil
+}
+
+// pullInventoryDB pulls the inventory database of the repository.  A nil inventory
+// is returned if the repository does not have an inventory database yet.
+func (ppo *PublishPluginPackageOptions) pullInventoryDB() (plugininventory.PluginInventory, func(), error) {
+	dir, err := os.MkdirTemp("", "")
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "unable to create temporary directory")
+	}
+	cleanup := func() { os.RemoveAll(dir) }
+
+	pluginInventoryDBImage := fmt.Sprintf("%s/%s:%s", ppo.Repository, helpers.PluginInventoryDBImageName, ppo.InventoryImageTag)
+	log.Infof("pulling plugin inventory database from: %q", pluginInventoryDBImage)
+	err = ppo.ImageOperationsImpl.DownloadImageAndSaveFilesToDir(pluginInventoryDBImage, dir)
+	if err != nil {
+		log.Warningf("unable to pull the plugin inventory database, all plugins are considered new: %v", err)
+		return nil, cleanup, nil
+	}
+	return plugininventory.NewSQLiteInventory(filepath.Join(dir, plugininventory.SQliteDBFileName), ""), cleanup, nil
+}
+
+// renderInventoryChanges writes the inventory changes as a table
+func renderInventoryChanges(w io.Writer, changes []InventoryChange) {
+	output := component.NewOutputWriter(w, string(component.TableOutputType), "plugin", "target", "version", "os", "arch", "change", "image")
+	for _, c := range changes {
+		output.AddRow(c.Plugin, c.Target, c.Version, c.OS, c.Arch, c.Change, c.Image)
+	}
+	output.Render()
+}
+
+// checkInventoryOverwrites returns an error if any existing plugin version would be
+// overwritten with a different binary
+func checkInventoryOverwrites(changes []InventoryChange) error {
+	numOverwrites := 0
+	for _, c := range changes {
+		if c.Change != InventoryChangeOverwrite {
+			continue
+		}
+		numOverwrites++
+		log.Errorf("plugin 'name:%s' 'target:%s' 'os:%s' 'arch:%s' 'version:%s' already exists with digest %q but would be published with digest %q", c.Plugin, c.Target, c.OS, c.Arch, c.Version, c.ExistingDigest, c.Digest)
+	}
+	if numOverwrites > 0 {
+		return errors.Errorf("publishing would overwrite %d existing plugin binaries, use --allow-overwrite to allow it", numOverwrites)
+	}
+	return nil
+}
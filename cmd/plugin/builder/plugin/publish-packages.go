@@ -5,6 +5,7 @@ package plugin
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"sync"
@@ -15,7 +16,9 @@ import (
 
 	"github.com/vmware-tanzu/tanzu-cli/cmd/plugin/builder/crane"
 	"github.com/vmware-tanzu/tanzu-cli/cmd/plugin/builder/helpers"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/carvelhelpers"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/sbom"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/utils"
 )
 
@@ -27,7 +30,16 @@ type PublishPluginPackageOptions struct {
 	DryRun             bool
 	CraneOptions       crane.CraneWrapper
 
+	// InventoryImageTag is the tag of the inventory database image that a dry-run
+	// compares the plugin packages against
+	InventoryImageTag string
+	// AllowOverwrite allows a dry-run to succeed even if existing plugin versions
+	// would be published with a different binary
+	AllowOverwrite      bool
+	ImageOperationsImpl carvelhelpers.ImageOperationsImpl
+
 	pluginManifestFile string
+	outputWriter       io.Writer
 }
 
 func (ppo *PublishPluginPackageOptions) PublishPluginPackages() error {
@@ -45,6 +57,13 @@ func (ppo *PublishPluginPackageOptions) PublishPluginPackages() error {
 
 	log.Infof("using plugin package artifacts from %q", ppo.PackageArtifactDir)
 
+	if ppo.DryRun {
+		err = ppo.reportInventoryChanges(pluginManifest)
+		if err != nil {
+			return err
+		}
+	}
+
 	// Limit the number of concurrent operations we perform so we don't overwhelm the system.
 	maxConcurrent := helpers.GetMaxParallelism()
 	guard := make(chan struct{}, maxConcurrent)
@@ -67,7 +86,7 @@ func (ppo *PublishPluginPackageOptions) PublishPluginPackages() error {
 
 	id := 0
 	for i := range pluginManifest.Plugins {
-		for _, osArch := range cli.AllOSArch {
+		for _, osArch := range helpers.GetAllOSArch() {
 			for _, version := range pluginManifest.Plugins[i].Versions {
 				wg.Add(1)
 				guard <- struct{}{}
@@ -93,6 +112,25 @@ func (ppo *PublishPluginPackageOptions) PublishPluginPackages() error {
 	return nil
 }
 
+// reportInventoryChanges reports the changes that publishing the plugin packages would cause
+// to the inventory database of the repository, and fails if an existing plugin version would
+// be overwritten unless allowed
+func (ppo *PublishPluginPackageOptions) reportInventoryChanges(pluginManifest *cli.Manifest) error {
+	changes, err := ppo.diffWithInventory(pluginManifest)
+	if err != nil {
+		return err
+	}
+	if ppo.outputWriter == nil {
+		ppo.outputWriter = os.Stdout
+	}
+	renderInventoryChanges(ppo.outputWriter, changes)
+
+	if ppo.AllowOverwrite {
+		return nil
+	}
+	return checkInventoryOverwrites(changes)
+}
+
 func (ppo *PublishPluginPackageOptions) publishPluginPackage(pluginTarFilePath string, p cli.Plugin, osArch cli.Arch, version, threadID string) error {
 	if !utils.PathExists(pluginTarFilePath) {
 		return nil
@@ -110,5 +148,36 @@ func (ppo *PublishPluginPackageOptions) publishPluginPackage(pluginTarFilePath s
 		}
 		log.Infof("%s published plugin at '%s'", threadID, imageToPush)
 	}
+
+	// Publish the compressed plugin binary, if any, which is only used if recorded in the inventory
+	compressedTarFilePath := filepath.Join(ppo.PackageArtifactDir, helpers.GetCompressedPluginArchiveRelativePath(p, osArch, version))
+	if utils.PathExists(compressedTarFilePath) {
+		compressedImageToPush := helpers.GetCompressedPluginImage(imageToPush)
+		if ppo.DryRun {
+			log.Infof("%s command: 'crane push %s %s'", threadID, compressedTarFilePath, compressedImageToPush)
+		} else {
+			err := ppo.CraneOptions.PushImage(compressedTarFilePath, compressedImageToPush)
+			if err != nil {
+				return errors.Wrapf(err, "unable to publish compressed plugin (name:%s, target:%s, os:%s, arch:%s, version:%s)", p.Name, p.Target, osArch.OS(), osArch.Arch(), version)
+			}
+			log.Infof("%s published compressed plugin at '%s'", threadID, compressedImageToPush)
+		}
+	}
+
+	for _, format := range sbom.SupportedFormats {
+		sbomFilePath := filepath.Join(filepath.Dir(pluginTarFilePath), format.FileName())
+		if !utils.PathExists(sbomFilePath) {
+			continue
+		}
+		if ppo.DryRun {
+			log.Infof("%s attach %s SBOM %q to '%s'", threadID, format, sbomFilePath, imageToPush)
+			continue
+		}
+		err := ppo.CraneOptions.AttachSBOM(imageToPush, sbomFilePath, format)
+		if err != nil {
+			return errors.Wrapf(err, "unable to attach the %s SBOM to plugin (name:%s, target:%s, os:%s, arch:%s, version:%s)", format, p.Name, p.Target, osArch.OS(), osArch.Arch(), version)
+		}
+		log.Infof("%s attached %s SBOM to '%s'", threadID, format, imageToPush)
+	}
 	return nil
 }
@@ -0,0 +1,175 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package plugin
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/tj/assert"
+
+	"github.com/vmware-tanzu/tanzu-cli/cmd/plugin/builder/helpers"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/distribution"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/fakes"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/plugininventory"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/sbom"
+)
+
+// fakeCrane returns the digest of the plugin binary of each package from the 'digests' map
+type fakeCrane struct {
+	digests map[string]string
+}
+
+func (fc *fakeCrane) SaveImage(_, _ string) error {
+	return nil
+}
+
+func (fc *fakeCrane) PushImage(_, _ string) error {
+	return nil
+}
+
+func (fc *fakeCrane) AttachSBOM(_, _ string, _ sbom.Format) error {
+	return nil
+}
+
+func (fc *fakeCrane) GetFileDigestFromTar(pluginTarFilePath, _ string) (string, error) {
+	return fc.digests[filepath.Base(filepath.Dir(pluginTarFilePath))+"/"+filepath.Base(pluginTarFilePath)], nil
+}
+
+// setupPublishTest creates the packages of plugin 'foo' for the specified versions and os-arch
+// and returns the options to publish them using the specified binary digests
+func setupPublishTest(t *testing.T, versions []string, osArchs []cli.Arch, digests map[string]string) *PublishPluginPackageOptions {
+	dir := t.TempDir()
+	p := cli.Plugin{Name: "foo", Target: "global", Description: "manage foo", Versions: versions}
+	manifest := "plugins:\n- name: foo\n  target: global\n  description: manage foo\n  versions:\n"
+	for _, v := range versions {
+		manifest += "  - " + v + "\n"
+		for _, osArch := range osArchs {
+			tarPath := filepath.Join(dir, helpers.GetPluginArchiveRelativePath(p, osArch, v))
+			assert.Nil(t, os.MkdirAll(filepath.Dir(tarPath), 0755))
+			assert.Nil(t, os.WriteFile(tarPath, []byte("package"), 0644))
+		}
+	}
+	assert.Nil(t, os.WriteFile(filepath.Join(dir, cli.PluginManifestFileName), []byte(manifest), 0644))
+
+	return &PublishPluginPackageOptions{
+		PackageArtifactDir:  dir,
+		Publisher:           "fakepublisher",
+		Vendor:              "fakevendor",
+		Repository:          "test-repo.com",
+		InventoryImageTag:   "latest",
+		DryRun:              true,
+		CraneOptions:        &fakeCrane{digests: digests},
+		ImageOperationsImpl: &fakes.ImageOperationsImpl{},
+		outputWriter:        &bytes.Buffer{},
+	}
+}
+
+// pullDBWithFoo returns a stub creating an inventory database containing version v0.0.1 of
+// plugin 'foo' for linux_amd64 with the specified digest
+func pullDBWithFoo(t *testing.T, digest string) func(string, string) error {
+	return func(_, path string) error {
+		db := plugininventory.NewSQLiteInventory(filepath.Join(path, plugininventory.SQliteDBFileName), "")
+		assert.Nil(t, db.CreateSchema())
+		return db.InsertPlugin(&plugininventory.PluginInventoryEntry{
+			Name:        "foo",
+			Target:      "global",
+			Description: "manage foo",
+			Publisher:   "fakepublisher",
+			Vendor:      "fakevendor",
+			Artifacts: map[string]distribution.ArtifactList{
+				"v0.0.1": {{OS: "linux", Arch: "amd64", Digest: digest, Image: "fakevendor/fakepublisher/linux/amd64/global/foo:v0.0.1"}},
+			},
+		})
+	}
+}
+
+func changesByArtifact(changes []InventoryChange) map[string]string {
+	m := map[string]string{}
+	for _, c := range changes {
+		m[c.Version+"/"+c.OS+"_"+c.Arch] = c.Change
+	}
+	return m
+}
+
+func TestDiffWithInventory(t *testing.T) {
+	assert := assert.New(t)
+
+	osArchs := []cli.Arch{cli.LinuxAMD64, cli.DarwinAMD64}
+	digests := map[string]string{
+		"v0.0.1/foo-linux_amd64.tar":  "digest-1",
+		"v0.0.1/foo-darwin_amd64.tar": "digest-2",
+		"v0.0.2/foo-linux_amd64.tar":  "digest-3",
+		"v0.0.2/foo-darwin_amd64.tar": "digest-4",
+	}
+	ppo := setupPublishTest(t, []string{"v0.0.1", "v0.0.2"}, osArchs, digests)
+	ppo.ImageOperationsImpl.(*fakes.ImageOperationsImpl).DownloadImageAndSaveFilesToDirCalls(pullDBWithFoo(t, "digest-1"))
+
+	manifest, err := helpers.ReadPluginManifest(filepath.Join(ppo.PackageArtifactDir, cli.PluginManifestFileName))
+	assert.Nil(err)
+	changes, err := ppo.diffWithInventory(manifest)
+	assert.Nil(err)
+	assert.Equal(map[string]string{
+		"v0.0.1/linux_amd64":  InventoryChangeUnchanged,
+		"v0.0.1/darwin_amd64": InventoryChangeNewOSArch,
+		"v0.0.2/linux_amd64":  InventoryChangeNewVersion,
+		"v0.0.2/darwin_amd64": InventoryChangeNewVersion,
+	}, changesByArtifact(changes))
+}
+
+func TestDiffWithInventoryNoDatabase(t *testing.T) {
+	assert := assert.New(t)
+
+	ppo := setupPublishTest(t, []string{"v0.0.1"}, []cli.Arch{cli.LinuxAMD64}, map[string]string{"v0.0.1/foo-linux_amd64.tar": "digest-1"})
+	ppo.ImageOperationsImpl.(*fakes.ImageOperationsImpl).DownloadImageAndSaveFilesToDirReturns(errors.New("image not found"))
+
+	manifest, err := helpers.ReadPluginManifest(filepath.Join(ppo.PackageArtifactDir, cli.PluginManifestFileName))
+	assert.Nil(err)
+	changes, err := ppo.diffWithInventory(manifest)
+	assert.Nil(err)
+	assert.Equal(map[string]string{"v0.0.1/linux_amd64": InventoryChangeNewVersion}, changesByArtifact(changes))
+}
+
+func TestPublishPluginPackagesDryRunOverwrite(t *testing.T) {
+	tests := []struct {
+		name           string
+		allowOverwrite bool
+		expectedErr    string
+	}{
+		{
+			name:        "when an existing version would be overwritten",
+			expectedErr: "publishing would overwrite 1 existing plugin binaries, use --allow-overwrite to allow it",
+		},
+		{
+			name:           "when an existing version would be overwritten and overwriting is allowed",
+			allowOverwrite: true,
+		},
+	}
+
+	for _, spec := range tests {
+		t.Run(spec.name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			ppo := setupPublishTest(t, []string{"v0.0.1"}, []cli.Arch{cli.LinuxAMD64}, map[string]string{"v0.0.1/foo-linux_amd64.tar": "new-digest"})
+			ppo.ImageOperationsImpl.(*fakes.ImageOperationsImpl).DownloadImageAndSaveFilesToDirCalls(pullDBWithFoo(t, "old-digest"))
+			ppo.AllowOverwrite = spec.allowOverwrite
+
+			err := ppo.PublishPluginPackages()
+			if spec.expectedErr != "" {
+				assert.NotNil(err)
+				assert.Contains(err.Error(), spec.expectedErr)
+			} else {
+				assert.Nil(err)
+			}
+
+			output := ppo.outputWriter.(*bytes.Buffer).String()
+			assert.Contains(output, InventoryChangeOverwrite)
+			assert.Contains(output, "fakevendor/fakepublisher/linux/amd64/global/foo:v0.0.1")
+		})
+	}
+}
@@ -0,0 +1,101 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package plugin
+
+import (
+	"path/filepath"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
+
+	"github.com/vmware-tanzu/tanzu-cli/cmd/plugin/builder/helpers"
+	"github.com/vmware-tanzu/tanzu-cli/cmd/plugin/builder/signer"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/utils"
+)
+
+type SignPluginOptions struct {
+	BinaryArtifactDir string
+	Signers           []signer.Signer
+
+	pluginManifestFile string
+}
+
+// SignPlugins signs the plugin binaries of the binary artifact directory
+// using the signer configured for the OS of each binary.  Binaries of an OS
+// without a signer are left unchanged.
+func (spo *SignPluginOptions) SignPlugins() error {
+	if spo.pluginManifestFile == "" {
+		spo.pluginManifestFile = filepath.Join(spo.BinaryArtifactDir, cli.PluginManifestFileName)
+	}
+
+	pluginManifest, err := helpers.ReadPluginManifest(spo.pluginManifestFile)
+	if err != nil {
+		return err
+	}
+
+	signers := map[string]signer.Signer{}
+	for _, s := range spo.Signers {
+		signers[s.OS()] = s
+	}
+
+	log.Infof("Signing plugin binaries from %q", spo.BinaryArtifactDir)
+
+	// Limit the number of concurrent operations we perform so we don't overwhelm the system.
+	maxConcurrent := helpers.GetMaxParallelism()
+	guard := make(chan struct{}, maxConcurrent)
+	var wg sync.WaitGroup
+	fatalErrors := make(chan helpers.ErrInfo, helpers.GetNumberOfIndividualPluginBinariesFromManifest(pluginManifest))
+
+	signPlugin := func(s signer.Signer, pluginBinaryFilePath, threadID string) {
+		defer func() {
+			<-guard
+			wg.Done()
+		}()
+
+		log.Infof("%s signing %q", threadID, pluginBinaryFilePath)
+		err := s.Sign(pluginBinaryFilePath)
+		if err != nil {
+			fatalErrors <- helpers.ErrInfo{Err: err, ID: threadID, Path: pluginBinaryFilePath}
+		}
+	}
+
+	id := 0
+	for i := range pluginManifest.Plugins {
+		for _, osArch := range helpers.GetAllOSArch() {
+			s, exists := signers[osArch.OS()]
+			if !exists {
+				continue
+			}
+			for _, version := range pluginManifest.Plugins[i].Versions {
+				pluginBinaryFilePath := filepath.Join(spo.BinaryArtifactDir, osArch.OS(), osArch.Arch(),
+					pluginManifest.Plugins[i].Target, pluginManifest.Plugins[i].Name, version,
+					cli.MakeArtifactName(pluginManifest.Plugins[i].Name, osArch))
+				if !utils.PathExists(pluginBinaryFilePath) {
+					continue
+				}
+
+				wg.Add(1)
+				guard <- struct{}{}
+				go signPlugin(s, pluginBinaryFilePath, helpers.GetID(id))
+				id++
+			}
+		}
+	}
+
+	wg.Wait()
+	close(fatalErrors)
+
+	hasFailed := false
+	for err := range fatalErrors {
+		hasFailed = true
+		log.Errorf("%s - signing plugin binary %q failed - %v", err.ID, err.Path, err.Err)
+	}
+	if hasFailed {
+		return errors.New("signing of the plugin binaries failed")
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/vmware-tanzu/tanzu-cli/cmd/plugin/builder/publisher"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/carvelhelpers"
+)
+
+// newPublisherCmd creates a new command for publisher operations.
+func newPublisherCmd() *cobra.Command {
+	var publisherCmd = &cobra.Command{
+		Use:   "publisher",
+		Short: "Publisher Operations",
+	}
+
+	publisherCmd.AddCommand(
+		newPublisherRegisterCmd(),
+		newPublisherVerifyCmd(),
+	)
+
+	return publisherCmd
+}
+
+type publisherFlags struct {
+	Repository          string
+	AssociationImageTag string
+	ManifestFile        string
+	Publisher           string
+	Vendor              string
+}
+
+func (pf *publisherFlags) associationOptions() publisher.AssociationOptions {
+	return publisher.AssociationOptions{
+		Repository:          pf.Repository,
+		AssociationImageTag: pf.AssociationImageTag,
+		ManifestFile:        pf.ManifestFile,
+		Vendor:              pf.Vendor,
+		Publisher:           pf.Publisher,
+		ImageOperationsImpl: carvelhelpers.NewImageOperationsImpl(),
+	}
+}
+
+func addPublisherFlags(cmd *cobra.Command, pf *publisherFlags) {
+	cmd.Flags().StringVarP(&pf.Repository, "repository", "", "", "repository of the plugin association image")
+	cmd.Flags().StringVarP(&pf.AssociationImageTag, "plugin-association-image-tag", "", "latest", "tag of the plugin association image")
+	cmd.Flags().StringVarP(&pf.ManifestFile, "manifest", "", "", "manifest file specifying the plugins of the publisher")
+	cmd.Flags().StringVarP(&pf.Vendor, "vendor", "", "", "name of the vendor")
+	cmd.Flags().StringVarP(&pf.Publisher, "publisher", "", "", "name of the publisher")
+
+	_ = cmd.MarkFlagRequired("repository")
+	_ = cmd.MarkFlagRequired("vendor")
+	_ = cmd.MarkFlagRequired("publisher")
+	_ = cmd.MarkFlagRequired("manifest")
+}
+
+func newPublisherRegisterCmd() *cobra.Command {
+	var prFlags = &publisherFlags{}
+
+	var publisherRegisterCmd = &cobra.Command{
+		Use:          "register",
+		Short:        "Associate the plugins of the manifest with the publisher and publish the association to the remote repository",
+		SilenceUsage: true,
+		Example: `
+    # Associate the plugins of the manifest with the 'vmware/tkg' publisher
+    tanzu builder publisher register --repository gcr.io/repository/cli-plugins --vendor vmware --publisher tkg --manifest ./artifacts/packages/plugin_manifest.yaml`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			prOptions := publisher.PublisherRegisterOptions{AssociationOptions: prFlags.associationOptions()}
+			return prOptions.Register()
+		},
+	}
+	addPublisherFlags(publisherRegisterCmd, prFlags)
+
+	return publisherRegisterCmd
+}
+
+func newPublisherVerifyCmd() *cobra.Command {
+	var pvFlags = &publisherFlags{}
+
+	var publisherVerifyCmd = &cobra.Command{
+		Use:          "verify",
+		Short:        "Verify that the plugins of the manifest are associated with the publisher",
+		SilenceUsage: true,
+		Example: `
+    # Verify that the plugins of the manifest are associated with the 'vmware/tkg' publisher
+    tanzu builder publisher verify --repository gcr.io/repository/cli-plugins --vendor vmware --publisher tkg --manifest ./artifacts/packages/plugin_manifest.yaml`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			pvOptions := publisher.PublisherVerifyOptions{AssociationOptions: pvFlags.associationOptions()}
+			return pvOptions.Verify()
+		},
+	}
+	addPublisherFlags(publisherVerifyCmd, pvFlags)
+
+	return publisherVerifyCmd
+}
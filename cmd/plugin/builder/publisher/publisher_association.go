@@ -0,0 +1,199 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package publisher implements the registration and verification of the
+// association between publishers and the plugins they publish
+package publisher
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
+
+	"github.com/vmware-tanzu/tanzu-cli/cmd/plugin/builder/helpers"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/carvelhelpers"
+)
+
+var nameRegexp = regexp.MustCompile(`^[a-z][a-z0-9-]*$`)
+
+// PluginAssociation is the document associating each vendor/publisher
+// with the plugins it is allowed to publish
+type PluginAssociation struct {
+	Publishers []PublisherEntry `yaml:"publishers"`
+}
+
+// PublisherEntry lists the plugins associated with a vendor/publisher
+type PublisherEntry struct {
+	Vendor    string             `yaml:"vendor"`
+	Publisher string             `yaml:"publisher"`
+	Plugins   []AssociatedPlugin `yaml:"plugins"`
+}
+
+// AssociatedPlugin identifies a plugin associated with a publisher
+type AssociatedPlugin struct {
+	Name   string `yaml:"name"`
+	Target string `yaml:"target"`
+}
+
+// AssociationOptions defines the options shared by the publisher association operations
+type AssociationOptions struct {
+	Repository          string
+	AssociationImageTag string
+	Vendor              string
+	Publisher           string
+	ManifestFile        string
+
+	ImageOperationsImpl carvelhelpers.ImageOperationsImpl
+}
+
+func (ao *AssociationOptions) getPluginAssociationImagePath() string {
+	return fmt.Sprintf("%s/%s:%s", ao.Repository, helpers.PluginAssociationImageName, ao.AssociationImageTag)
+}
+
+// getManifestPlugins returns the plugins of the plugin manifest
+func (ao *AssociationOptions) getManifestPlugins() ([]AssociatedPlugin, error) {
+	pluginManifest, err := helpers.ReadPluginManifest(ao.ManifestFile)
+	if err != nil {
+		return nil, err
+	}
+	var plugins []AssociatedPlugin
+	for i := range pluginManifest.Plugins {
+		plugins = append(plugins, AssociatedPlugin{Name: pluginManifest.Plugins[i].Name, Target: pluginManifest.Plugins[i].Target})
+	}
+	return plugins, nil
+}
+
+// pullPluginAssociation pulls the plugin association document from the repository.
+// If 'allowMissing' is true, an empty document is returned if it cannot be pulled.
+func (ao *AssociationOptions) pullPluginAssociation(allowMissing bool) (*PluginAssociation, error) {
+	pluginAssociationImage := ao.getPluginAssociationImagePath()
+
+	dir, err := os.MkdirTemp("", "")
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to create temporary directory")
+	}
+	defer os.RemoveAll(dir)
+
+	log.Infof("pulling plugin association from: %q", pluginAssociationImage)
+	err = ao.ImageOperationsImpl.DownloadImageAndSaveFilesToDir(pluginAssociationImage, dir)
+	if err != nil {
+		if allowMissing {
+			log.Infof("no plugin association found, creating a new one")
+			return &PluginAssociation{}, nil
+		}
+		return nil, errors.Wrapf(err, "error while pulling plugin association from the image: %q", pluginAssociationImage)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, helpers.PluginAssociationFileName))
+	if err != nil {
+		return nil, err
+	}
+	pa := &PluginAssociation{}
+	err = yaml.Unmarshal(data, pa)
+	if err != nil {
+		return nil, errors.Wrap(err, "fail to read the plugin association")
+	}
+	return pa, nil
+}
+
+// pushPluginAssociation publishes the plugin association document to the repository
+func (ao *AssociationOptions) pushPluginAssociation(pa *PluginAssociation) error {
+	pluginAssociationImage := ao.getPluginAssociationImagePath()
+
+	data, err := yaml.Marshal(pa)
+	if err != nil {
+		return err
+	}
+	dir, err := os.MkdirTemp("", "")
+	if err != nil {
+		return errors.Wrap(err, "unable to create temporary directory")
+	}
+	defer os.RemoveAll(dir)
+
+	associationFile := filepath.Join(dir, helpers.PluginAssociationFileName)
+	err = os.WriteFile(associationFile, data, 0644)
+	if err != nil {
+		return err
+	}
+
+	log.Infof("publishing plugin association at: %q", pluginAssociationImage)
+	err = ao.ImageOperationsImpl.PushImage(pluginAssociationImage, []string{associationFile})
+	if err != nil {
+		return errors.Wrapf(err, "error while publishing plugin association to the repository as image: %q", pluginAssociationImage)
+	}
+	return nil
+}
+
+// Validate verifies that the vendor, publisher and plugin names and targets of the document
+// are valid and that no plugin is associated with more than one publisher
+func (pa *PluginAssociation) Validate() error {
+	owners := map[AssociatedPlugin]string{}
+	for _, pe := range pa.Publishers {
+		if err := validateVendorPublisher(pe.Vendor, pe.Publisher); err != nil {
+			return err
+		}
+		owner := pe.Vendor + "/" + pe.Publisher
+		for _, p := range pe.Plugins {
+			if !nameRegexp.MatchString(p.Name) {
+				return errors.Errorf("invalid plugin name %q associated with publisher %q", p.Name, owner)
+			}
+			if !configtypes.IsValidTarget(p.Target, true, false) {
+				return errors.Errorf("invalid target %q for plugin %q associated with publisher %q", p.Target, p.Name, owner)
+			}
+			if other, exists := owners[p]; exists && other != owner {
+				return errors.Errorf("plugin %q for target %q is associated with both publishers %q and %q", p.Name, p.Target, other, owner)
+			}
+			owners[p] = owner
+		}
+	}
+	return nil
+}
+
+// getPublisher returns the entry of the vendor/publisher or nil if it does not exist
+func (pa *PluginAssociation) getPublisher(vendor, publisher string) *PublisherEntry {
+	for i := range pa.Publishers {
+		if pa.Publishers[i].Vendor == vendor && pa.Publishers[i].Publisher == publisher {
+			return &pa.Publishers[i]
+		}
+	}
+	return nil
+}
+
+// getOwner returns the vendor/publisher associated with the plugin or an empty string
+func (pa *PluginAssociation) getOwner(p AssociatedPlugin) string {
+	for _, pe := range pa.Publishers {
+		for _, ap := range pe.Plugins {
+			if ap == p {
+				return pe.Vendor + "/" + pe.Publisher
+			}
+		}
+	}
+	return ""
+}
+
+func validateVendorPublisher(vendor, publisher string) error {
+	if !nameRegexp.MatchString(vendor) {
+		return errors.Errorf("invalid vendor name %q, it must start with a lowercase letter and contain only lowercase letters, digits and dashes", vendor)
+	}
+	if !nameRegexp.MatchString(publisher) {
+		return errors.Errorf("invalid publisher name %q, it must start with a lowercase letter and contain only lowercase letters, digits and dashes", publisher)
+	}
+	return nil
+}
+
+func sortPlugins(plugins []AssociatedPlugin) {
+	sort.Slice(plugins, func(i, j int) bool {
+		if plugins[i].Name != plugins[j].Name {
+			return plugins[i].Name < plugins[j].Name
+		}
+		return plugins[i].Target < plugins[j].Target
+	})
+}
@@ -0,0 +1,67 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package publisher
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
+)
+
+// PublisherRegisterOptions defines options for registering a publisher and its plugins
+type PublisherRegisterOptions struct {
+	AssociationOptions
+}
+
+// Register associates the plugins of the plugin manifest with the vendor/publisher by
+// downloading the plugin association document from the repository, updating it locally
+// and publishing it back to the repository.  Registering fails if any of the plugins
+// is already associated with another publisher.
+func (pro *PublisherRegisterOptions) Register() error {
+	err := validateVendorPublisher(pro.Vendor, pro.Publisher)
+	if err != nil {
+		return err
+	}
+	plugins, err := pro.getManifestPlugins()
+	if err != nil {
+		return err
+	}
+
+	pa, err := pro.pullPluginAssociation(true)
+	if err != nil {
+		return err
+	}
+
+	pe := pa.getPublisher(pro.Vendor, pro.Publisher)
+	if pe == nil {
+		pa.Publishers = append(pa.Publishers, PublisherEntry{Vendor: pro.Vendor, Publisher: pro.Publisher})
+		pe = &pa.Publishers[len(pa.Publishers)-1]
+		log.Infof("registering new publisher '%s/%s'", pro.Vendor, pro.Publisher)
+	}
+
+	owner := pro.Vendor + "/" + pro.Publisher
+	for _, p := range plugins {
+		switch pa.getOwner(p) {
+		case owner:
+			continue
+		case "":
+			pe.Plugins = append(pe.Plugins, p)
+			log.Infof("associating plugin 'name:%s' 'target:%s' with publisher %q", p.Name, p.Target, owner)
+		default:
+			return errors.Errorf("plugin %q for target %q is already associated with publisher %q", p.Name, p.Target, pa.getOwner(p))
+		}
+	}
+	sortPlugins(pe.Plugins)
+
+	err = pa.Validate()
+	if err != nil {
+		return errors.Wrap(err, "invalid plugin association")
+	}
+	err = pro.pushPluginAssociation(pa)
+	if err != nil {
+		return err
+	}
+	log.Infof("successfully registered publisher %q", owner)
+	return nil
+}
@@ -0,0 +1,156 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package publisher
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"gopkg.in/yaml.v3"
+
+	"github.com/vmware-tanzu/tanzu-cli/cmd/plugin/builder/helpers"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/fakes"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/utils"
+)
+
+func TestPublisherSuite(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Builder Publisher Suite")
+}
+
+// pullAssociationStub returns a stub saving the specified plugin association document
+func pullAssociationStub(pa *PluginAssociation) func(string, string) error {
+	return func(_, path string) error {
+		data, err := yaml.Marshal(pa)
+		Expect(err).ToNot(HaveOccurred())
+		return os.WriteFile(filepath.Join(path, helpers.PluginAssociationFileName), data, 0644)
+	}
+}
+
+// pushAssociationStub returns a stub storing the published plugin association document into 'pa'
+func pushAssociationStub(pa *PluginAssociation) func(string, []string) error {
+	return func(_ string, files []string) error {
+		Expect(files).To(HaveLen(1))
+		data, err := os.ReadFile(files[0])
+		Expect(err).ToNot(HaveOccurred())
+		return yaml.Unmarshal(data, pa)
+	}
+}
+
+func createTestManifestFile() (string, error) {
+	manifestBytes := `created: 2024-02-24T10:10:59.093382-08:00
+plugins:
+    - name: foo
+      target: global
+      description: Foo plugin
+      versions:
+        - v0.0.2
+    - name: bar
+      target: kubernetes
+      description: Bar plugin
+      versions:
+        - v0.0.2
+`
+	tempManifestFile := filepath.Join(os.TempDir(), "publisher_plugin_manifest.yaml")
+	return tempManifestFile, utils.SaveFile(tempManifestFile, []byte(manifestBytes))
+}
+
+var _ = Describe("Unit tests for publisher register", func() {
+	manifestFile, err := createTestManifestFile()
+	Expect(err).ToNot(HaveOccurred())
+
+	var fakeImgpkgWrapper *fakes.ImageOperationsImpl
+	var pro PublisherRegisterOptions
+	var pushed *PluginAssociation
+
+	BeforeEach(func() {
+		pushed = &PluginAssociation{}
+		fakeImgpkgWrapper = &fakes.ImageOperationsImpl{}
+		fakeImgpkgWrapper.PushImageCalls(pushAssociationStub(pushed))
+		pro = PublisherRegisterOptions{
+			AssociationOptions: AssociationOptions{
+				Repository:          "test-repo.com",
+				AssociationImageTag: "latest",
+				Vendor:              "fakevendor",
+				Publisher:           "fakepublisher",
+				ManifestFile:        manifestFile,
+				ImageOperationsImpl: fakeImgpkgWrapper,
+			},
+		}
+	})
+
+	var _ = Context("tests for the publisher register function", func() {
+
+		var _ = It("when the publisher name is invalid", func() {
+			pro.Publisher = "Fake_Publisher"
+
+			err := pro.Register()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(`invalid publisher name "Fake_Publisher"`))
+		})
+
+		var _ = It("when the plugin association does not exist yet", func() {
+			fakeImgpkgWrapper.DownloadImageAndSaveFilesToDirReturns(errors.New("image not found"))
+
+			err := pro.Register()
+			Expect(err).NotTo(HaveOccurred())
+
+			image, _ := fakeImgpkgWrapper.PushImageArgsForCall(0)
+			Expect(image).To(Equal("test-repo.com/plugin-association:latest"))
+			Expect(pushed).To(Equal(&PluginAssociation{
+				Publishers: []PublisherEntry{
+					{
+						Vendor:    "fakevendor",
+						Publisher: "fakepublisher",
+						Plugins:   []AssociatedPlugin{{Name: "bar", Target: "kubernetes"}, {Name: "foo", Target: "global"}},
+					},
+				},
+			}))
+		})
+
+		var _ = It("when the publisher already has plugins associated", func() {
+			fakeImgpkgWrapper.DownloadImageAndSaveFilesToDirCalls(pullAssociationStub(&PluginAssociation{
+				Publishers: []PublisherEntry{
+					{Vendor: "othervendor", Publisher: "otherpublisher", Plugins: []AssociatedPlugin{{Name: "baz", Target: "global"}}},
+					{Vendor: "fakevendor", Publisher: "fakepublisher", Plugins: []AssociatedPlugin{{Name: "foo", Target: "global"}}},
+				},
+			}))
+
+			err := pro.Register()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(pushed.Publishers).To(HaveLen(2))
+			Expect(pushed.Publishers[0].Plugins).To(Equal([]AssociatedPlugin{{Name: "baz", Target: "global"}}))
+			Expect(pushed.Publishers[1].Plugins).To(Equal([]AssociatedPlugin{{Name: "bar", Target: "kubernetes"}, {Name: "foo", Target: "global"}}))
+		})
+
+		var _ = It("when a plugin is already associated with another publisher", func() {
+			fakeImgpkgWrapper.DownloadImageAndSaveFilesToDirCalls(pullAssociationStub(&PluginAssociation{
+				Publishers: []PublisherEntry{
+					{Vendor: "othervendor", Publisher: "otherpublisher", Plugins: []AssociatedPlugin{{Name: "foo", Target: "global"}}},
+				},
+			}))
+
+			err := pro.Register()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(`plugin "foo" for target "global" is already associated with publisher "othervendor/otherpublisher"`))
+			Expect(fakeImgpkgWrapper.PushImageCallCount()).To(Equal(0))
+		})
+
+		var _ = It("when publishing the plugin association fails", func() {
+			fakeImgpkgWrapper.DownloadImageAndSaveFilesToDirReturns(errors.New("image not found"))
+			fakeImgpkgWrapper.PushImageCalls(nil)
+			fakeImgpkgWrapper.PushImageReturns(errors.New("unauthorized"))
+
+			err := pro.Register()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("error while publishing plugin association to the repository"))
+			Expect(err.Error()).To(ContainSubstring("unauthorized"))
+		})
+	})
+})
@@ -0,0 +1,52 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package publisher
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
+)
+
+// PublisherVerifyOptions defines options for verifying the association of plugins with a publisher
+type PublisherVerifyOptions struct {
+	AssociationOptions
+}
+
+// Verify validates the plugin association document of the repository and verifies that
+// all the plugins of the plugin manifest are associated with the vendor/publisher.
+func (pvo *PublisherVerifyOptions) Verify() error {
+	err := validateVendorPublisher(pvo.Vendor, pvo.Publisher)
+	if err != nil {
+		return err
+	}
+	plugins, err := pvo.getManifestPlugins()
+	if err != nil {
+		return err
+	}
+
+	pa, err := pvo.pullPluginAssociation(false)
+	if err != nil {
+		return err
+	}
+	err = pa.Validate()
+	if err != nil {
+		return errors.Wrap(err, "invalid plugin association")
+	}
+
+	owner := pvo.Vendor + "/" + pvo.Publisher
+	var unassociated []string
+	for _, p := range plugins {
+		if pa.getOwner(p) != owner {
+			unassociated = append(unassociated, p.Name+"_"+p.Target)
+		}
+	}
+	if len(unassociated) > 0 {
+		return errors.Errorf("plugins %s are not associated with publisher %q, use 'tanzu builder publisher register' to associate them", strings.Join(unassociated, ", "), owner)
+	}
+	log.Infof("all plugins are associated with publisher %q", owner)
+	return nil
+}
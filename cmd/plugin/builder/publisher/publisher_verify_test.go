@@ -0,0 +1,88 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package publisher
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/fakes"
+)
+
+var _ = Describe("Unit tests for publisher verify", func() {
+	manifestFile, err := createTestManifestFile()
+	Expect(err).ToNot(HaveOccurred())
+
+	var fakeImgpkgWrapper *fakes.ImageOperationsImpl
+	var pvo PublisherVerifyOptions
+
+	BeforeEach(func() {
+		fakeImgpkgWrapper = &fakes.ImageOperationsImpl{}
+		pvo = PublisherVerifyOptions{
+			AssociationOptions: AssociationOptions{
+				Repository:          "test-repo.com",
+				AssociationImageTag: "latest",
+				Vendor:              "fakevendor",
+				Publisher:           "fakepublisher",
+				ManifestFile:        manifestFile,
+				ImageOperationsImpl: fakeImgpkgWrapper,
+			},
+		}
+	})
+
+	var _ = Context("tests for the publisher verify function", func() {
+
+		var _ = It("when the plugin association cannot be pulled", func() {
+			fakeImgpkgWrapper.DownloadImageAndSaveFilesToDirReturns(errors.New("image not found"))
+
+			err := pvo.Verify()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("error while pulling plugin association from the image"))
+		})
+
+		var _ = It("when all the plugins are associated with the publisher", func() {
+			fakeImgpkgWrapper.DownloadImageAndSaveFilesToDirCalls(pullAssociationStub(&PluginAssociation{
+				Publishers: []PublisherEntry{
+					{
+						Vendor:    "fakevendor",
+						Publisher: "fakepublisher",
+						Plugins:   []AssociatedPlugin{{Name: "bar", Target: "kubernetes"}, {Name: "foo", Target: "global"}},
+					},
+				},
+			}))
+
+			err := pvo.Verify()
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		var _ = It("when some plugins are not associated with the publisher", func() {
+			fakeImgpkgWrapper.DownloadImageAndSaveFilesToDirCalls(pullAssociationStub(&PluginAssociation{
+				Publishers: []PublisherEntry{
+					{Vendor: "fakevendor", Publisher: "fakepublisher", Plugins: []AssociatedPlugin{{Name: "foo", Target: "global"}}},
+					{Vendor: "othervendor", Publisher: "otherpublisher", Plugins: []AssociatedPlugin{{Name: "bar", Target: "kubernetes"}}},
+				},
+			}))
+
+			err := pvo.Verify()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(`plugins bar_kubernetes are not associated with publisher "fakevendor/fakepublisher"`))
+		})
+
+		var _ = It("when the plugin association is invalid", func() {
+			fakeImgpkgWrapper.DownloadImageAndSaveFilesToDirCalls(pullAssociationStub(&PluginAssociation{
+				Publishers: []PublisherEntry{
+					{Vendor: "fakevendor", Publisher: "fakepublisher", Plugins: []AssociatedPlugin{{Name: "foo", Target: "global"}}},
+					{Vendor: "othervendor", Publisher: "otherpublisher", Plugins: []AssociatedPlugin{{Name: "foo", Target: "global"}}},
+				},
+			}))
+
+			err := pvo.Verify()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("invalid plugin association"))
+			Expect(err.Error()).To(ContainSubstring(`plugin "foo" for target "global" is associated with both publishers`))
+		})
+	})
+})
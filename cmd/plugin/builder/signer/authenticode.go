@@ -0,0 +1,55 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package signer
+
+import (
+	"os"
+	"runtime"
+
+	"github.com/pkg/errors"
+)
+
+// AuthenticodeSigner signs windows binaries with Authenticode.
+// It uses 'signtool' when running on windows and 'osslsigncode' otherwise.
+type AuthenticodeSigner struct {
+	// CertFile is the PKCS#12 file containing the signing certificate and its key
+	CertFile string
+	// CertPassword is the password of the CertFile
+	CertPassword string
+	// TimestampURL is the URL of the RFC 3161 timestamp server, if any
+	TimestampURL string
+}
+
+// OS returns the OS of the binaries signed with Authenticode
+func (s *AuthenticodeSigner) OS() string {
+	return "windows"
+}
+
+// Sign signs the windows binary in place
+func (s *AuthenticodeSigner) Sign(binaryPath string) error {
+	if runtime.GOOS == "windows" {
+		args := []string{"sign", "/f", s.CertFile, "/p", s.CertPassword, "/fd", "sha256"}
+		if s.TimestampURL != "" {
+			args = append(args, "/tr", s.TimestampURL, "/td", "sha256")
+		}
+		args = append(args, binaryPath)
+		if output, err := runCommand("signtool", args...); err != nil {
+			return errors.Wrapf(err, "signtool failed: %s", string(output))
+		}
+		return nil
+	}
+
+	// osslsigncode cannot sign a file in place
+	signedPath := binaryPath + ".signed"
+	args := []string{"sign", "-pkcs12", s.CertFile, "-pass", s.CertPassword, "-h", "sha256"}
+	if s.TimestampURL != "" {
+		args = append(args, "-ts", s.TimestampURL)
+	}
+	args = append(args, "-in", binaryPath, "-out", signedPath)
+	if output, err := runCommand("osslsigncode", args...); err != nil {
+		_ = os.Remove(signedPath)
+		return errors.Wrapf(err, "osslsigncode failed: %s", string(output))
+	}
+	return os.Rename(signedPath, binaryPath)
+}
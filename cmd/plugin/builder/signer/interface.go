@@ -0,0 +1,23 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package signer implements the signing of plugin binaries so that they
+// pass the gatekeeping of the operating system they are built for
+package signer
+
+import (
+	"os/exec"
+)
+
+// Signer defines a signing step for the plugin binaries of an OS
+type Signer interface {
+	// OS returns the OS, in `GOOS` format, of the binaries the signer applies to
+	OS() string
+	// Sign signs the binary in place
+	Sign(binaryPath string) error
+}
+
+// runCommand runs the command and returns its combined output
+var runCommand = func(name string, args ...string) ([]byte, error) {
+	return exec.Command(name, args...).CombinedOutput()
+}
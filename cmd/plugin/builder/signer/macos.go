@@ -0,0 +1,53 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package signer
+
+import (
+	"os"
+
+	"github.com/pkg/errors"
+)
+
+// MacOSSigner signs darwin binaries using 'codesign' and, if a notary profile
+// is specified, notarizes them using 'notarytool'.  Both tools are only
+// available on macOS.
+type MacOSSigner struct {
+	// Identity is the signing identity, as found in the keychain, given to codesign
+	Identity string
+	// NotaryProfile is the keychain profile holding the notarytool credentials.
+	// Notarization is skipped when it is empty.
+	NotaryProfile string
+}
+
+// OS returns the OS of the binaries signed with codesign
+func (s *MacOSSigner) OS() string {
+	return "darwin"
+}
+
+// Sign signs, and optionally notarizes, the darwin binary in place
+func (s *MacOSSigner) Sign(binaryPath string) error {
+	// The hardened runtime and a secure timestamp are required for notarization
+	output, err := runCommand("codesign", "--force", "--options", "runtime", "--timestamp", "--sign", s.Identity, binaryPath)
+	if err != nil {
+		return errors.Wrapf(err, "codesign failed: %s", string(output))
+	}
+
+	if s.NotaryProfile == "" {
+		return nil
+	}
+
+	// notarytool only accepts zip archives, disk images and packages
+	zipPath := binaryPath + ".zip"
+	defer os.Remove(zipPath)
+
+	output, err = runCommand("ditto", "-c", "-k", "--keepParent", binaryPath, zipPath)
+	if err != nil {
+		return errors.Wrapf(err, "unable to archive the binary for notarization: %s", string(output))
+	}
+	output, err = runCommand("xcrun", "notarytool", "submit", zipPath, "--keychain-profile", s.NotaryProfile, "--wait")
+	if err != nil {
+		return errors.Wrapf(err, "notarytool failed: %s", string(output))
+	}
+	return nil
+}
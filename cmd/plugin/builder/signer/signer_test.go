@@ -0,0 +1,155 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package signer
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/tj/assert"
+)
+
+// fakeRunCommand records the commands that are run instead of running them
+func fakeRunCommand(t *testing.T, commands *[]string, fail string) {
+	original := runCommand
+	t.Cleanup(func() { runCommand = original })
+
+	runCommand = func(name string, args ...string) ([]byte, error) {
+		*commands = append(*commands, strings.Join(append([]string{name}, args...), " "))
+		if name == fail {
+			return []byte("failure output"), errors.New("exit status 1")
+		}
+		// Simulate osslsigncode writing the signed binary
+		for i := range args {
+			if args[i] == "-out" {
+				return nil, os.WriteFile(args[i+1], []byte("signed"), 0644)
+			}
+		}
+		return nil, nil
+	}
+}
+
+func TestAuthenticodeSigner(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("osslsigncode is only used when not running on windows")
+	}
+	assert := assert.New(t)
+
+	binaryPath := filepath.Join(t.TempDir(), "tanzu-foo-windows_amd64.exe")
+	assert.Nil(os.WriteFile(binaryPath, []byte("unsigned"), 0644))
+
+	var commands []string
+	fakeRunCommand(t, &commands, "")
+
+	s := &AuthenticodeSigner{CertFile: "cert.p12", CertPassword: "secret", TimestampURL: "http://timestamp.example.com"}
+	assert.Equal("windows", s.OS())
+	assert.Nil(s.Sign(binaryPath))
+	assert.Equal([]string{
+		"osslsigncode sign -pkcs12 cert.p12 -pass secret -h sha256 -ts http://timestamp.example.com -in " + binaryPath + " -out " + binaryPath + ".signed",
+	}, commands)
+
+	b, err := os.ReadFile(binaryPath)
+	assert.Nil(err)
+	assert.Equal("signed", string(b))
+	_, err = os.Stat(binaryPath + ".signed")
+	assert.True(os.IsNotExist(err))
+}
+
+func TestAuthenticodeSignerFailure(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("osslsigncode is only used when not running on windows")
+	}
+	assert := assert.New(t)
+
+	binaryPath := filepath.Join(t.TempDir(), "tanzu-foo-windows_amd64.exe")
+	assert.Nil(os.WriteFile(binaryPath, []byte("unsigned"), 0644))
+
+	var commands []string
+	fakeRunCommand(t, &commands, "osslsigncode")
+
+	s := &AuthenticodeSigner{CertFile: "cert.p12"}
+	err := s.Sign(binaryPath)
+	assert.NotNil(err)
+	assert.Contains(err.Error(), "osslsigncode failed: failure output")
+
+	b, err := os.ReadFile(binaryPath)
+	assert.Nil(err)
+	assert.Equal("unsigned", string(b))
+}
+
+func TestMacOSSigner(t *testing.T) {
+	tests := []struct {
+		test          string
+		notaryProfile string
+		fail          string
+		expected      []string
+		expectedErr   string
+	}{
+		{
+			test: "sign only",
+			expected: []string{
+				"codesign --force --options runtime --timestamp --sign Developer ID Application: Foo %s",
+			},
+		},
+		{
+			test:          "sign and notarize",
+			notaryProfile: "foo-notary",
+			expected: []string{
+				"codesign --force --options runtime --timestamp --sign Developer ID Application: Foo %s",
+				"ditto -c -k --keepParent %s %s.zip",
+				"xcrun notarytool submit %s.zip --keychain-profile foo-notary --wait",
+			},
+		},
+		{
+			test:          "codesign failure",
+			notaryProfile: "foo-notary",
+			fail:          "codesign",
+			expected: []string{
+				"codesign --force --options runtime --timestamp --sign Developer ID Application: Foo %s",
+			},
+			expectedErr: "codesign failed: failure output",
+		},
+		{
+			test:          "notarization failure",
+			notaryProfile: "foo-notary",
+			fail:          "xcrun",
+			expected: []string{
+				"codesign --force --options runtime --timestamp --sign Developer ID Application: Foo %s",
+				"ditto -c -k --keepParent %s %s.zip",
+				"xcrun notarytool submit %s.zip --keychain-profile foo-notary --wait",
+			},
+			expectedErr: "notarytool failed: failure output",
+		},
+	}
+
+	for _, spec := range tests {
+		t.Run(spec.test, func(t *testing.T) {
+			assert := assert.New(t)
+			binaryPath := filepath.Join(t.TempDir(), "tanzu-foo-darwin_arm64")
+
+			var commands []string
+			fakeRunCommand(t, &commands, spec.fail)
+
+			s := &MacOSSigner{Identity: "Developer ID Application: Foo", NotaryProfile: spec.notaryProfile}
+			assert.Equal("darwin", s.OS())
+			err := s.Sign(binaryPath)
+			if spec.expectedErr != "" {
+				assert.NotNil(err)
+				assert.Contains(err.Error(), spec.expectedErr)
+			} else {
+				assert.Nil(err)
+			}
+
+			var expected []string
+			for _, c := range spec.expected {
+				expected = append(expected, strings.ReplaceAll(c, "%s", binaryPath))
+			}
+			assert.Equal(expected, commands)
+		})
+	}
+}
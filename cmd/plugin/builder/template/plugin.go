@@ -23,3 +23,9 @@ var PluginTest = Target{
 	Filepath: "cmd/plugin/{{ .PluginName }}/test/main.go",
 	Template: plugintemplates.MainTestGo,
 }
+
+// PluginScopeAssociation target
+var PluginScopeAssociation = Target{
+	Filepath: "cmd/plugin/plugin-scope-association.yaml",
+	Template: plugintemplates.PluginScopeAssociation,
+}
@@ -59,3 +59,8 @@ var GithubWorkflowBuild string
 //
 //go:embed gitlab-ci.yml.tmpl
 var GitlabCI string
+
+// PluginScopeAssociation contains the plugin-scope-association template
+//
+//go:embed plugin-scope-association.yaml.tmpl
+var PluginScopeAssociation string
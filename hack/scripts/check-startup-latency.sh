@@ -0,0 +1,48 @@
+#!/bin/bash
+
+# Copyright 2024 VMware, Inc. All Rights Reserved.
+# SPDX-License-Identifier: Apache-2.0
+
+#####################################################################
+# Script Name: check-startup-latency.sh
+# Description: This script measures the latency of 'tanzu --help', which
+#     covers the work done by every invocation of the CLI (loading the
+#     configuration and the catalog, building the plugin commands), and
+#     fails if the median latency exceeds the budget
+#####################################################################
+
+# Usage: check-startup-latency.sh <tanzu-binary> [budget-in-ms]
+
+# The budget can also be set through TANZU_CLI_STARTUP_BUDGET_MS
+# and the number of runs through TANZU_CLI_STARTUP_RUNS
+
+set -o errexit
+set -o nounset
+set -o pipefail
+
+TANZU_BIN=${1:?"Usage: $0 <tanzu-binary> [budget-in-ms]"}
+BUDGET_MS=${2:-${TANZU_CLI_STARTUP_BUDGET_MS:-300}}
+RUNS=${TANZU_CLI_STARTUP_RUNS:-11}
+
+export TANZU_CLI_CEIP_OPT_IN_PROMPT_ANSWER=${TANZU_CLI_CEIP_OPT_IN_PROMPT_ANSWER:-No}
+export TANZU_CLI_EULA_PROMPT_ANSWER=${TANZU_CLI_EULA_PROMPT_ANSWER:-Yes}
+
+# The first invocation may initialize the configuration and the catalog
+"${TANZU_BIN}" --help > /dev/null
+
+latencies=()
+for _ in $(seq "${RUNS}"); do
+    start=$(date +%s%N)
+    "${TANZU_BIN}" --help > /dev/null
+    end=$(date +%s%N)
+    latencies+=($(( (end - start) / 1000000 )))
+done
+
+median=$(printf "%s\n" "${latencies[@]}" | sort -n | sed -n "$(( (RUNS + 1) / 2 ))p")
+echo "'tanzu --help' latencies (ms): ${latencies[*]}"
+echo "Median latency: ${median}ms, budget: ${BUDGET_MS}ms"
+
+if [ "${median}" -gt "${BUDGET_MS}" ]; then
+    echo "ERROR: the startup latency of the CLI exceeds its budget"
+    exit 1
+fi
@@ -8,14 +8,19 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"strings"
 
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/layout"
 	"github.com/pkg/errors"
+	"github.com/sigstore/sigstore/pkg/signature"
 	"github.com/verybluebot/tarinator-go"
 	"gopkg.in/yaml.v3"
 
 	"github.com/vmware-tanzu/tanzu-cli/pkg/carvelhelpers"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/cosignhelper/sigverifier"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/download"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/essentials"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/plugininventory"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/utils"
@@ -34,7 +39,11 @@ type DownloadPluginBundleOptions struct {
 	Plugins              []string
 	RefreshConfigOnly    bool
 	DryRun               bool
-	ImageProcessor       carvelhelpers.ImageOperationsImpl
+	// Format is the format of the images of the plugin bundle, PluginBundleFormatImgpkg by default
+	Format PluginBundleFormat
+	// SigningKey is the reference of the key signing the plugin bundle, if any
+	SigningKey     string
+	ImageProcessor carvelhelpers.ImageOperationsImpl
 }
 
 // DownloadPluginBundle download the plugin bundle based on provided plugin inventory image
@@ -46,6 +55,16 @@ func (o *DownloadPluginBundleOptions) DownloadPluginBundle() error {
 		return err
 	}
 
+	// Load the signing key before downloading anything so that
+	// a wrong key or password does not waste a long download
+	var signer signature.Signer
+	if o.SigningKey != "" && !o.DryRun {
+		signer, err = loadPluginBundleSigner(o.SigningKey)
+		if err != nil {
+			return err
+		}
+	}
+
 	// Create temp download directory
 	tempBaseDir, err := os.MkdirTemp("", "")
 	if err != nil {
@@ -98,9 +117,21 @@ func (o *DownloadPluginBundleOptions) DownloadPluginBundle() error {
 		return errors.Wrap(err, "error while saving plugin migration manifest")
 	}
 
-	// Save entire plugin bundle as a single tar file which can be used with upload-bundle
+	// Save the checksums of the plugin bundle, signed if requested, so that
+	// upload-bundle can verify that nothing was altered after the download
+	err = savePluginBundleChecksums(tempPluginBundleDir, signer)
+	if err != nil {
+		return errors.Wrap(err, "error while saving plugin bundle checksums")
+	}
+
+	// Save entire plugin bundle as a single tar file which can be used with upload-bundle.
+	// An OCI image layout must be at the root of the tar file to be usable by other tools.
 	log.Infof("saving plugin bundle at: %s", o.ToTar)
-	err = tarinator.Tarinate([]string{tempPluginBundleDir}, o.ToTar)
+	if o.Format == PluginBundleFormatOCILayout {
+		err = tarDirContent(tempPluginBundleDir, o.ToTar)
+	} else {
+		err = tarinator.Tarinate([]string{tempPluginBundleDir}, o.ToTar)
+	}
 	if err != nil {
 		return errors.Wrap(err, "error while creating archive file")
 	}
@@ -299,46 +330,133 @@ func (o *DownloadPluginBundleOptions) saveAndGetImagesToCopy(pluginEntries []*pl
 }
 
 // downloadImagesAsTarFile downloads plugin inventory image and all plugin images
-// as tar file to the specified directory
+// as tar file to the specified directory.  The images are downloaded in parallel
+// within the limits of the download scheduler.
 func (o *DownloadPluginBundleOptions) downloadImagesAsTarFile(pluginEntries []*plugininventory.PluginInventoryEntry, downloadDir string) (string, []*ImageCopyInfo, error) {
 	allImages := []*ImageCopyInfo{}
+	imagesToDownload := []string{}
 
 	// Download plugin inventory database as tar file
 	pluginInventoryFileNameTar := "plugin-inventory-image.tar.gz"
-	log.Infof("downloading image %q", o.PluginInventoryImage)
-	err := o.ImageProcessor.CopyImageToTar(o.PluginInventoryImage, filepath.Join(downloadDir, pluginInventoryFileNameTar))
-	if err != nil {
-		return "", nil, err
-	}
-
 	relativeInventoryImagePathWithTag := GetImageRelativePath(o.PluginInventoryImage, path.Dir(o.PluginInventoryImage), true)
 
 	allImages = append(allImages, &ImageCopyInfo{
 		SourceTarFilePath: pluginInventoryFileNameTar,
 		RelativeImagePath: GetImageRelativePath(o.PluginInventoryImage, path.Dir(o.PluginInventoryImage), false),
 	})
+	imagesToDownload = append(imagesToDownload, o.PluginInventoryImage)
 
 	// Process all plugin entries and download the oci image as tar file
 	for _, pe := range pluginEntries {
 		for version, artifacts := range pe.Artifacts {
 			for _, a := range artifacts {
-				log.Infof("---------------------------")
-				log.Infof("downloading image %q", a.Image)
 				tarfileName := fmt.Sprintf("%s-%s-%s_%s-%s.tar.gz", pe.Name, pe.Target, a.OS, a.Arch, version)
-				err = o.ImageProcessor.CopyImageToTar(a.Image, filepath.Join(downloadDir, tarfileName))
-				if err != nil {
-					return "", nil, err
-				}
 				allImages = append(allImages, &ImageCopyInfo{
 					SourceTarFilePath: tarfileName,
 					RelativeImagePath: GetImageRelativePath(a.Image, path.Dir(o.PluginInventoryImage), false),
 				})
+				imagesToDownload = append(imagesToDownload, a.Image)
+
+				// The inventory database refers to the compressed plugin binary, if any,
+				// so it must also be part of the bundle
+				if a.CompressedImage == "" {
+					continue
+				}
+				tarfileName = fmt.Sprintf("%s-%s-%s_%s-%s-zstd.tar.gz", pe.Name, pe.Target, a.OS, a.Arch, version)
+				allImages = append(allImages, &ImageCopyInfo{
+					SourceTarFilePath: tarfileName,
+					RelativeImagePath: GetImageRelativePath(a.CompressedImage, path.Dir(o.PluginInventoryImage), false),
+				})
+				imagesToDownload = append(imagesToDownload, a.CompressedImage)
+			}
+		}
+	}
+
+	// With the oci-layout format, each image is first downloaded as a separate
+	// OCI image layout and then added to the OCI image layout of the plugin bundle
+	imagesDir := downloadDir
+	if o.Format == PluginBundleFormatOCILayout {
+		var err error
+		imagesDir, err = os.MkdirTemp("", "")
+		if err != nil {
+			return "", nil, errors.Wrap(err, "unable to create temp directory")
+		}
+		defer os.RemoveAll(imagesDir)
+	}
+
+	tasks := make([]func() error, len(allImages))
+	for i := range allImages {
+		tasks[i] = func() error {
+			log.Infof("downloading image %q", imagesToDownload[i])
+			var err error
+			if o.Format == PluginBundleFormatOCILayout {
+				err = o.ImageProcessor.DownloadImageToOCILayout(imagesToDownload[i], imageLayoutTarFile(imagesDir, i))
+			} else {
+				err = o.ImageProcessor.CopyImageToTar(imagesToDownload[i], filepath.Join(imagesDir, allImages[i].SourceTarFilePath))
 			}
+			if err != nil {
+				return err
+			}
+			o.recordImageDigest(imagesToDownload[i], allImages[i])
+			return nil
+		}
+	}
+	for _, err := range download.DefaultScheduler().Run(tasks...) {
+		if err != nil {
+			return "", nil, err
+		}
+	}
+
+	if o.Format == PluginBundleFormatOCILayout {
+		err := o.saveImagesToOCILayout(imagesToDownload, allImages, imagesDir, downloadDir)
+		if err != nil {
+			return "", nil, err
 		}
 	}
 	return relativeInventoryImagePathWithTag, allImages, nil
 }
 
+// imageLayoutTarFile returns the path of the uncompressed tar file of
+// the OCI image layout of the downloaded image at the specified index
+func imageLayoutTarFile(imagesDir string, index int) string {
+	return filepath.Join(imagesDir, fmt.Sprintf("image-%d.tar", index))
+}
+
+// saveImagesToOCILayout adds the downloaded images to the OCI image layout of the plugin bundle,
+// named after their path relative to the plugin inventory image
+func (o *DownloadPluginBundleOptions) saveImagesToOCILayout(images []string, imagesToCopy []*ImageCopyInfo, imagesDir, pluginBundleDir string) error {
+	layoutPath, err := layout.Write(pluginBundleDir, empty.Index)
+	if err != nil {
+		return errors.Wrap(err, "unable to initialize the OCI image layout")
+	}
+	for i, ic := range imagesToCopy {
+		refName := strings.TrimPrefix(GetImageRelativePath(images[i], path.Dir(o.PluginInventoryImage), true), "/")
+		err = addImageToOCILayout(layoutPath, imageLayoutTarFile(imagesDir, i), refName)
+		if err != nil {
+			return errors.Wrapf(err, "unable to add image %q to the OCI image layout", images[i])
+		}
+		ic.SourceTarFilePath = ""
+		ic.SourceOCILayoutRefName = refName
+	}
+	return nil
+}
+
+// recordImageDigest records the tag and digest of the downloaded image, which allow
+// to compare it with the image of the destination repository when uploading the plugin bundle
+func (o *DownloadPluginBundleOptions) recordImageDigest(image string, ic *ImageCopyInfo) {
+	tag := strings.TrimPrefix(GetImageRelativePath(image, path.Dir(o.PluginInventoryImage), true), ic.RelativeImagePath)
+	if !strings.HasPrefix(tag, ":") {
+		return
+	}
+	hashAlgorithm, hashHexVal, err := o.ImageProcessor.GetImageDigest(image)
+	if err != nil || hashHexVal == "" {
+		log.V(6).Infof("unable to get the digest of image %q: %v", image, err)
+		return
+	}
+	ic.Tag = strings.TrimPrefix(tag, ":")
+	ic.Digest = hashAlgorithm + ":" + hashHexVal
+}
+
 // downloadImagesAsTarFile downloads plugin inventory image and all plugin images
 // as tar file to the specified directory
 //
@@ -364,6 +482,12 @@ func (o *DownloadPluginBundleOptions) getListOfImages(pluginEntries []*plugininv
 // validateOptions validates the provided options and returns
 // error if contains invalid option
 func (o *DownloadPluginBundleOptions) validateOptions() error {
+	switch o.Format {
+	case "", PluginBundleFormatImgpkg, PluginBundleFormatOCILayout:
+	default:
+		return errors.Errorf("invalid plugin bundle format %q, it must be %q or %q", o.Format, PluginBundleFormatImgpkg, PluginBundleFormatOCILayout)
+	}
+
 	if !o.DryRun {
 		// Verify tar file to be used to save plugin bundle
 		err := o.verifyTarFile()
@@ -0,0 +1,136 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package airgapped
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/layout"
+	"github.com/pkg/errors"
+	"github.com/verybluebot/tarinator-go"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/utils"
+)
+
+const (
+	// ociLayoutFile is the file marking the root of an OCI image layout
+	ociLayoutFile = "oci-layout"
+	// ociRefNameAnnotation is the annotation of the OCI image layout index
+	// recording the reference of each image
+	ociRefNameAnnotation = "org.opencontainers.image.ref.name"
+)
+
+// isOCILayout returns true if the directory is the root of an OCI image layout
+func isOCILayout(dir string) bool {
+	return utils.PathExists(filepath.Join(dir, ociLayoutFile))
+}
+
+// addImageToOCILayout adds the image of the tar file of an OCI image layout, as created by
+// DownloadImageToOCILayout, to the OCI image layout of the plugin bundle with the specified reference name
+func addImageToOCILayout(layoutPath layout.Path, imageLayoutTar, refName string) error {
+	imageLayoutDir, err := os.MkdirTemp("", "")
+	if err != nil {
+		return errors.Wrap(err, "unable to create temp directory")
+	}
+	defer os.RemoveAll(imageLayoutDir)
+
+	err = tarinator.UnTarinate(imageLayoutDir, imageLayoutTar)
+	if err != nil {
+		return errors.Wrapf(err, "unable to extract %q", imageLayoutTar)
+	}
+	imageLayoutIndex, err := layout.ImageIndexFromPath(imageLayoutDir)
+	if err != nil {
+		return errors.Wrapf(err, "%q is not a valid OCI image layout", imageLayoutTar)
+	}
+	indexManifest, err := imageLayoutIndex.IndexManifest()
+	if err != nil {
+		return err
+	}
+	if len(indexManifest.Manifests) != 1 {
+		return errors.Errorf("the OCI image layout %q is required to contain only 1 image, but found %v", imageLayoutTar, len(indexManifest.Manifests))
+	}
+
+	desc := indexManifest.Manifests[0]
+	annotations := layout.WithAnnotations(map[string]string{ociRefNameAnnotation: refName})
+	if desc.MediaType.IsIndex() {
+		index, err := imageLayoutIndex.ImageIndex(desc.Digest)
+		if err != nil {
+			return err
+		}
+		return layoutPath.AppendIndex(index, annotations)
+	}
+	image, err := imageLayoutIndex.Image(desc.Digest)
+	if err != nil {
+		return err
+	}
+	return layoutPath.AppendImage(image, annotations)
+}
+
+// extractImageFromOCILayout saves the image with the specified reference name of the
+// OCI image layout of the plugin bundle as the tar file of a separate OCI image layout,
+// as expected by UploadImageFromOCILayout
+func extractImageFromOCILayout(pluginBundleDir, refName, destTarFile string) error {
+	bundleLayoutIndex, err := layout.ImageIndexFromPath(pluginBundleDir)
+	if err != nil {
+		return errors.Wrap(err, "the plugin bundle is not a valid OCI image layout")
+	}
+	indexManifest, err := bundleLayoutIndex.IndexManifest()
+	if err != nil {
+		return err
+	}
+
+	imageLayoutDir, err := os.MkdirTemp("", "")
+	if err != nil {
+		return errors.Wrap(err, "unable to create temp directory")
+	}
+	defer os.RemoveAll(imageLayoutDir)
+
+	for _, desc := range indexManifest.Manifests {
+		if desc.Annotations[ociRefNameAnnotation] != refName {
+			continue
+		}
+		imageLayoutPath, err := layout.Write(imageLayoutDir, empty.Index)
+		if err != nil {
+			return errors.Wrap(err, "unable to initialize the OCI image layout")
+		}
+		annotations := layout.WithAnnotations(desc.Annotations)
+		if desc.MediaType.IsIndex() {
+			index, err := bundleLayoutIndex.ImageIndex(desc.Digest)
+			if err != nil {
+				return err
+			}
+			err = imageLayoutPath.AppendIndex(index, annotations)
+			if err != nil {
+				return err
+			}
+		} else {
+			image, err := bundleLayoutIndex.Image(desc.Digest)
+			if err != nil {
+				return err
+			}
+			err = imageLayoutPath.AppendImage(image, annotations)
+			if err != nil {
+				return err
+			}
+		}
+		return tarDirContent(imageLayoutDir, destTarFile)
+	}
+	return errors.Errorf("image %q not found in the OCI image layout of the plugin bundle", refName)
+}
+
+// tarDirContent saves the content of the directory, without the directory itself,
+// as a tar file so that an OCI image layout is at the root of the tar file
+func tarDirContent(dir, destTarFile string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	paths := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		paths = append(paths, filepath.Join(dir, entry.Name()))
+	}
+	return tarinator.Tarinate(paths, destTarFile)
+}
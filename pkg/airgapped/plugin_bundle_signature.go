@@ -0,0 +1,178 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package airgapped
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/sigstore/cosign/v2/pkg/cosign"
+	sigs "github.com/sigstore/cosign/v2/pkg/signature"
+	"github.com/sigstore/sigstore/pkg/signature"
+	"gopkg.in/yaml.v3"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/utils"
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
+)
+
+// loadPluginBundleSigner loads the key signing a plugin bundle, which can be any key
+// reference supported by cosign, e.g. the path of a key generated by "cosign generate-key-pair".
+// The password of the key is read from the COSIGN_PASSWORD environment variable or from the terminal.
+func loadPluginBundleSigner(keyRef string) (signature.Signer, error) {
+	signer, err := sigs.SignerFromKeyRef(context.Background(), keyRef, getSigningKeyPassword)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to load the signing key %q", keyRef)
+	}
+	return signer, nil
+}
+
+func getSigningKeyPassword(_ bool) ([]byte, error) {
+	if password, ok := os.LookupEnv(constants.CosignPassword); ok {
+		return []byte(password), nil
+	}
+	return cosign.GetPassFromTerm(false)
+}
+
+// savePluginBundleChecksums saves the checksums of all the files of the plugin bundle
+// and, if a signer is provided, the signature of these checksums
+func savePluginBundleChecksums(pluginBundleDir string, signer signature.Signer) error {
+	checksums, err := computePluginBundleChecksums(pluginBundleDir)
+	if err != nil {
+		return err
+	}
+	payload, err := yaml.Marshal(&PluginBundleChecksums{Files: checksums})
+	if err != nil {
+		return err
+	}
+	err = os.WriteFile(filepath.Join(pluginBundleDir, PluginBundleChecksumsFile), payload, 0644)
+	if err != nil {
+		return err
+	}
+	if signer == nil {
+		return nil
+	}
+
+	sig, err := signer.SignMessage(bytes.NewReader(payload))
+	if err != nil {
+		return errors.Wrap(err, "unable to sign the plugin bundle")
+	}
+	return os.WriteFile(filepath.Join(pluginBundleDir, PluginBundleSignatureFile), []byte(base64.StdEncoding.EncodeToString(sig)), 0644)
+}
+
+// verifyPluginBundle verifies that the files of the plugin bundle match their checksums
+// and, if a key is provided, that the checksums were signed with the corresponding key
+func verifyPluginBundle(pluginBundleDir, keyRef string) error {
+	payload, err := os.ReadFile(filepath.Join(pluginBundleDir, PluginBundleChecksumsFile))
+	if err != nil {
+		if keyRef != "" {
+			return errors.New("the plugin bundle is not signed")
+		}
+		// Plugin bundles downloaded with older versions of the CLI have no checksums
+		log.Warningf("the plugin bundle has no checksums, its integrity cannot be verified")
+		return nil
+	}
+
+	signaturePath := filepath.Join(pluginBundleDir, PluginBundleSignatureFile)
+	if keyRef != "" {
+		if err := verifyPluginBundleSignature(payload, signaturePath, keyRef); err != nil {
+			return err
+		}
+		log.Infof("verified the signature of the plugin bundle")
+	} else if utils.PathExists(signaturePath) {
+		log.Warningf("the plugin bundle is signed but its signature was not verified, please provide the public key to verify it")
+	}
+
+	checksums := &PluginBundleChecksums{}
+	if err := yaml.Unmarshal(payload, checksums); err != nil {
+		return errors.Wrap(err, "error while parsing the plugin bundle checksums")
+	}
+	actualChecksums, err := computePluginBundleChecksums(pluginBundleDir)
+	if err != nil {
+		return err
+	}
+	for file, checksum := range checksums.Files {
+		if actualChecksums[file] != checksum {
+			return errors.Errorf("the file %q of the plugin bundle is missing or was altered", file)
+		}
+	}
+	for file := range actualChecksums {
+		if _, exists := checksums.Files[file]; !exists {
+			return errors.Errorf("the file %q was added to the plugin bundle", file)
+		}
+	}
+	return nil
+}
+
+func verifyPluginBundleSignature(payload []byte, signaturePath, keyRef string) error {
+	encodedSignature, err := os.ReadFile(signaturePath)
+	if err != nil {
+		return errors.New("the plugin bundle is not signed")
+	}
+	sig, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(encodedSignature)))
+	if err != nil {
+		return errors.Wrap(err, "invalid plugin bundle signature")
+	}
+	verifier, err := sigs.PublicKeyFromKeyRefWithHashAlgo(context.Background(), keyRef, crypto.SHA256)
+	if err != nil {
+		return errors.Wrapf(err, "unable to load the public key %q", keyRef)
+	}
+	if err := verifier.VerifySignature(bytes.NewReader(sig), bytes.NewReader(payload)); err != nil {
+		return errors.Wrap(err, "the signature of the plugin bundle does not match the public key")
+	}
+	return nil
+}
+
+// computePluginBundleChecksums returns the sha256 checksums of the files of the plugin bundle,
+// except the checksums and signature files, keyed by their slash-separated relative paths
+func computePluginBundleChecksums(pluginBundleDir string) (map[string]string, error) {
+	checksums := map[string]string{}
+	err := filepath.WalkDir(pluginBundleDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		relativePath, err := filepath.Rel(pluginBundleDir, path)
+		if err != nil {
+			return err
+		}
+		relativePath = filepath.ToSlash(relativePath)
+		if relativePath == PluginBundleChecksumsFile || relativePath == PluginBundleSignatureFile {
+			return nil
+		}
+		checksum, err := fileSHA256(path)
+		if err != nil {
+			return err
+		}
+		checksums[relativePath] = checksum
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "error while computing the plugin bundle checksums")
+	}
+	return checksums, nil
+}
+
+func fileSHA256(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
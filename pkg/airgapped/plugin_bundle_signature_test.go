@@ -0,0 +1,116 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package airgapped
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sigstore/cosign/v2/pkg/cosign"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
+)
+
+var _ = Describe("Unit tests for plugin bundle signatures", func() {
+	var (
+		tempDir         string
+		pluginBundleDir string
+		privateKeyPath  string
+		publicKeyPath   string
+	)
+
+	generateKeyPair := func(name string) (string, string) {
+		keys, err := cosign.GenerateKeyPair(func(bool) ([]byte, error) { return []byte("test-password"), nil })
+		Expect(err).NotTo(HaveOccurred())
+		privateKey := filepath.Join(tempDir, name+".key")
+		publicKey := filepath.Join(tempDir, name+".pub")
+		Expect(os.WriteFile(privateKey, keys.PrivateBytes, 0600)).To(Succeed())
+		Expect(os.WriteFile(publicKey, keys.PublicBytes, 0600)).To(Succeed())
+		return privateKey, publicKey
+	}
+
+	BeforeEach(func() {
+		var err error
+		tempDir, err = os.MkdirTemp("", "")
+		Expect(err).NotTo(HaveOccurred())
+		os.Setenv(constants.CosignPassword, "test-password")
+
+		pluginBundleDir = filepath.Join(tempDir, PluginBundleDirName)
+		Expect(os.MkdirAll(filepath.Join(pluginBundleDir, "plugins"), 0755)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(pluginBundleDir, PluginMigrationManifestFile), []byte("imagesToCopy: []\n"), 0644)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(pluginBundleDir, "plugins", "plugin.tar"), []byte("plugin"), 0644)).To(Succeed())
+
+		privateKeyPath, publicKeyPath = generateKeyPair("cosign")
+	})
+	AfterEach(func() {
+		os.Unsetenv(constants.CosignPassword)
+		os.RemoveAll(tempDir)
+	})
+
+	It("should verify an unsigned plugin bundle which was not altered", func() {
+		Expect(savePluginBundleChecksums(pluginBundleDir, nil)).To(Succeed())
+		Expect(verifyPluginBundle(pluginBundleDir, "")).To(Succeed())
+
+		err := verifyPluginBundle(pluginBundleDir, publicKeyPath)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("the plugin bundle is not signed"))
+	})
+
+	It("should verify the signature of a signed plugin bundle", func() {
+		signer, err := loadPluginBundleSigner(privateKeyPath)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(savePluginBundleChecksums(pluginBundleDir, signer)).To(Succeed())
+		Expect(verifyPluginBundle(pluginBundleDir, publicKeyPath)).To(Succeed())
+
+		_, otherPublicKeyPath := generateKeyPair("other")
+		err = verifyPluginBundle(pluginBundleDir, otherPublicKeyPath)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("the signature of the plugin bundle does not match the public key"))
+	})
+
+	It("should fail to load a signing key with a wrong password", func() {
+		os.Setenv(constants.CosignPassword, "wrong-password")
+		_, err := loadPluginBundleSigner(privateKeyPath)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("unable to load the signing key"))
+	})
+
+	It("should detect altered, missing and added files", func() {
+		signer, err := loadPluginBundleSigner(privateKeyPath)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(savePluginBundleChecksums(pluginBundleDir, signer)).To(Succeed())
+
+		pluginTar := filepath.Join(pluginBundleDir, "plugins", "plugin.tar")
+		Expect(os.WriteFile(pluginTar, []byte("altered"), 0644)).To(Succeed())
+		err = verifyPluginBundle(pluginBundleDir, publicKeyPath)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring(`the file "plugins/plugin.tar" of the plugin bundle is missing or was altered`))
+
+		Expect(os.Remove(pluginTar)).To(Succeed())
+		err = verifyPluginBundle(pluginBundleDir, publicKeyPath)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring(`the file "plugins/plugin.tar" of the plugin bundle is missing or was altered`))
+
+		Expect(os.WriteFile(pluginTar, []byte("plugin"), 0644)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(pluginBundleDir, "plugins", "extra.tar"), []byte("extra"), 0644)).To(Succeed())
+		err = verifyPluginBundle(pluginBundleDir, publicKeyPath)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring(`the file "plugins/extra.tar" was added to the plugin bundle`))
+	})
+
+	It("should detect altered checksums", func() {
+		signer, err := loadPluginBundleSigner(privateKeyPath)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(savePluginBundleChecksums(pluginBundleDir, signer)).To(Succeed())
+
+		// Someone updating both a file and its checksum cannot forge the signature
+		Expect(os.WriteFile(filepath.Join(pluginBundleDir, "plugins", "plugin.tar"), []byte("altered"), 0644)).To(Succeed())
+		Expect(savePluginBundleChecksums(pluginBundleDir, nil)).To(Succeed())
+		err = verifyPluginBundle(pluginBundleDir, publicKeyPath)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("the signature of the plugin bundle does not match the public key"))
+	})
+})
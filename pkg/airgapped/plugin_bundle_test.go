@@ -8,8 +8,12 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/layout"
+	"github.com/google/go-containerregistry/pkg/v1/random"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
@@ -734,6 +738,159 @@ imagesToCopy:
 			Expect(err).NotTo(HaveOccurred())
 		})
 	})
+
+	var _ = Context("Tests for comparing the plugin bundle with the destination repository", func() {
+		// getImageDigestStub fakes the digests of the source images, and of the destination images
+		// of the bar plugin which already exists and of the foo plugin which has a different digest
+		getImageDigestStub := func(image string) (string, string, error) {
+			switch {
+			case strings.HasPrefix(image, "fake.fakerepo.abc/"):
+				return "sha256", "source-digest", nil
+			case image == "fake.newfakerepo.abc/plugin/path/darwin/amd64/kubernetes/bar:v0.0.1":
+				return "sha256", "source-digest", nil
+			case strings.HasPrefix(image, "fake.newfakerepo.abc/plugin/path/") && strings.HasSuffix(image, "/global/foo:v0.0.2"):
+				return "sha256", "other-digest", nil
+			}
+			return "", "", errors.New("not found")
+		}
+
+		JustBeforeEach(func() {
+			fakeImageOperations.DownloadImageAndSaveFilesToDirCalls(downloadInventoryImageAndSaveFilesToDirStub)
+			fakeImageOperations.CopyImageToTarCalls(copyImageToTarStub)
+			fakeImageOperations.GetImageDigestCalls(getImageDigestStub)
+
+			err := dpbo.DownloadPluginBundle()
+			Expect(err).NotTo(HaveOccurred())
+
+			fakeImageOperations.DownloadImageAndSaveFilesToDirCalls(downloadInventoryMetadataImageWithExistingPlugins)
+			fakeImageOperations.CopyImageFromTarReturns(nil)
+		})
+		AfterEach(func() {
+			fakeImageOperations.GetImageDigestCalls(nil)
+		})
+
+		var _ = It("should compare the digests of the images", func() {
+			ic := &ImageCopyInfo{RelativeImagePath: "/path/darwin/amd64/kubernetes/bar", Tag: "v0.0.1", Digest: "sha256:source-digest"}
+			Expect(upbo.getImageStatus("fake.newfakerepo.abc/plugin/path/darwin/amd64/kubernetes/bar", ic)).To(Equal(imageStatusExisting))
+
+			ic = &ImageCopyInfo{RelativeImagePath: "/path/darwin/amd64/global/foo", Tag: "v0.0.2", Digest: "sha256:source-digest"}
+			Expect(upbo.getImageStatus("fake.newfakerepo.abc/plugin/path/darwin/amd64/global/foo", ic)).To(Equal(imageStatusOverwritten))
+
+			ic = &ImageCopyInfo{RelativeImagePath: "/path/darwin/amd64/global/telemetry", Tag: "v0.0.1", Digest: "sha256:source-digest"}
+			Expect(upbo.getImageStatus("fake.newfakerepo.abc/plugin/path/darwin/amd64/global/telemetry", ic)).To(Equal(imageStatusNew))
+
+			ic = &ImageCopyInfo{RelativeImagePath: "/path/darwin/amd64/global/telemetry"}
+			Expect(upbo.getImageStatus("fake.newfakerepo.abc/plugin/path/darwin/amd64/global/telemetry", ic)).To(Equal(imageStatusUnknown))
+		})
+
+		var _ = It("when dry run is requested, it should not upload anything", func() {
+			copyCount := fakeImageOperations.CopyImageFromTarCallCount()
+			pushCount := fakeImageOperations.PushImageCallCount()
+
+			upbo.DryRun = true
+			err := upbo.UploadPluginBundle()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeImageOperations.CopyImageFromTarCallCount()).To(Equal(copyCount))
+			Expect(fakeImageOperations.PushImageCallCount()).To(Equal(pushCount))
+		})
+
+		var _ = It("when skipping existing images, it should only upload the new and modified images", func() {
+			copyCount := fakeImageOperations.CopyImageFromTarCallCount()
+
+			upbo.SkipExisting = true
+			err := upbo.UploadPluginBundle()
+			Expect(err).NotTo(HaveOccurred())
+			// All the images of the plugin bundle but the bar plugin are uploaded
+			Expect(fakeImageOperations.CopyImageFromTarCallCount() - copyCount).To(Equal(4))
+			for i := copyCount; i < fakeImageOperations.CopyImageFromTarCallCount(); i++ {
+				_, repoImagePath := fakeImageOperations.CopyImageFromTarArgsForCall(i)
+				Expect(repoImagePath).NotTo(HaveSuffix("/bar"))
+			}
+		})
+
+		var _ = It("when not skipping existing images, it should upload all the images", func() {
+			copyCount := fakeImageOperations.CopyImageFromTarCallCount()
+
+			err := upbo.UploadPluginBundle()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeImageOperations.CopyImageFromTarCallCount() - copyCount).To(Equal(5))
+		})
+	})
+
+	var _ = Context("Tests for downloading and uploading plugin bundle in the oci-layout format", func() {
+		// downloadImageToOCILayoutStub fakes the image downloads and creates the tar file of
+		// an OCI image layout containing a random image
+		downloadImageToOCILayoutStub := func(_, tarfile string) error {
+			image, err := random.Image(256, 1)
+			Expect(err).ToNot(HaveOccurred())
+			layoutDir, err := os.MkdirTemp("", "")
+			Expect(err).ToNot(HaveOccurred())
+			defer os.RemoveAll(layoutDir)
+			layoutPath, err := layout.Write(layoutDir, empty.Index)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(layoutPath.AppendImage(image)).To(Succeed())
+			return tarDirContent(layoutDir, tarfile)
+		}
+
+		expectedRefNames := []string{
+			"plugin-inventory:latest",
+			"path/darwin/amd64/kubernetes/bar:v0.0.1",
+			"path/darwin/amd64/global/foo:v0.0.2",
+			"path/linux/amd64/global/foo:v0.0.2",
+			"path/darwin/amd64/global/telemetry:v0.0.1",
+		}
+
+		JustBeforeEach(func() {
+			fakeImageOperations.DownloadImageAndSaveFilesToDirCalls(downloadInventoryImageAndSaveFilesToDirStub)
+			fakeImageOperations.DownloadImageToOCILayoutCalls(downloadImageToOCILayoutStub)
+
+			dpbo.Format = PluginBundleFormatOCILayout
+			err := dpbo.DownloadPluginBundle()
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		var _ = It("should save the plugin bundle as an OCI image layout", func() {
+			bundleDir := filepath.Join(tempTestDir, "bundle")
+			Expect(tarinator.UnTarinate(bundleDir, dpbo.ToTar)).To(Succeed())
+			Expect(filepath.Join(bundleDir, ociLayoutFile)).To(BeAnExistingFile())
+			Expect(filepath.Join(bundleDir, PluginMigrationManifestFile)).To(BeAnExistingFile())
+
+			layoutIndex, err := layout.ImageIndexFromPath(bundleDir)
+			Expect(err).NotTo(HaveOccurred())
+			indexManifest, err := layoutIndex.IndexManifest()
+			Expect(err).NotTo(HaveOccurred())
+			refNames := []string{}
+			for _, desc := range indexManifest.Manifests {
+				refNames = append(refNames, desc.Annotations[ociRefNameAnnotation])
+			}
+			Expect(refNames).To(ConsistOf(expectedRefNames))
+		})
+
+		var _ = It("should upload the images of the OCI image layout", func() {
+			fakeImageOperations.DownloadImageAndSaveFilesToDirCalls(downloadInventoryMetadataImageWithNoExistingPlugins)
+			copyCount := fakeImageOperations.CopyImageFromTarCallCount()
+			uploadCount := fakeImageOperations.UploadImageFromOCILayoutCallCount()
+
+			err := upbo.UploadPluginBundle()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeImageOperations.CopyImageFromTarCallCount()).To(Equal(copyCount))
+
+			uploadedImages := []string{}
+			for i := uploadCount; i < fakeImageOperations.UploadImageFromOCILayoutCallCount(); i++ {
+				_, image := fakeImageOperations.UploadImageFromOCILayoutArgsForCall(i)
+				uploadedImages = append(uploadedImages, strings.TrimPrefix(image, "fake.newfakerepo.abc/plugin/"))
+			}
+			Expect(uploadedImages).To(ConsistOf(expectedRefNames))
+		})
+
+		var _ = It("when an invalid format is provided, it should return an error", func() {
+			dpbo.Format = "invalid"
+			dpbo.ToTar = filepath.Join(tempTestDir, "other_plugin_bundle.tar")
+			err := dpbo.DownloadPluginBundle()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(`invalid plugin bundle format "invalid"`))
+		})
+	})
 })
 
 // Create incorrect plugin bundle tar file with empty content
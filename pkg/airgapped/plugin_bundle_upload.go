@@ -23,10 +23,31 @@ import (
 type UploadPluginBundleOptions struct {
 	Tar             string
 	DestinationRepo string
+	// VerificationKey is the reference of the public key verifying the signature
+	// of the plugin bundle, if any
+	VerificationKey string
+	// DryRun only reports the differences between the plugin bundle and the
+	// destination repository without uploading anything
+	DryRun bool
+	// SkipExisting skips the upload of the images which already exist
+	// in the destination repository with the same digest
+	SkipExisting bool
 
 	ImageProcessor carvelhelpers.ImageOperationsImpl
 }
 
+// imageStatus describes how an image of the plugin bundle compares
+// with the same image of the destination repository
+type imageStatus string
+
+const (
+	imageStatusNew         imageStatus = "new"
+	imageStatusExisting    imageStatus = "existing"
+	imageStatusOverwritten imageStatus = "overwritten"
+	// imageStatusUnknown is the status of the images without digest in the plugin bundle
+	imageStatusUnknown imageStatus = "unknown"
+)
+
 // UploadPluginBundle uploads the given plugin bundle to the specified remote repository
 func (o *UploadPluginBundleOptions) UploadPluginBundle() error {
 	// create a temporary directory
@@ -43,8 +64,19 @@ func (o *UploadPluginBundleOptions) UploadPluginBundle() error {
 		return errors.Wrap(err, "unable to extract provided file")
 	}
 
-	// Read the plugin migration manifest file
+	// Plugin bundles in the oci-layout format are at the root of the tar file
 	pluginBundleDir := filepath.Join(tempDir, PluginBundleDirName)
+	if isOCILayout(tempDir) {
+		pluginBundleDir = tempDir
+	}
+
+	// Verify that the plugin bundle was not altered since it was downloaded
+	err = verifyPluginBundle(pluginBundleDir, o.VerificationKey)
+	if err != nil {
+		return errors.Wrap(err, "error while verifying the plugin bundle")
+	}
+
+	// Read the plugin migration manifest file
 	bytes, err := os.ReadFile(filepath.Join(pluginBundleDir, PluginMigrationManifestFile))
 	if err != nil {
 		return errors.Wrap(err, "error while reading plugin migration manifest")
@@ -55,16 +87,43 @@ func (o *UploadPluginBundleOptions) UploadPluginBundle() error {
 		return errors.Wrap(err, "error while parsing plugin migration manifest")
 	}
 
-	// Iterate through all the images and publish them to the remote repository
-	for _, ic := range manifest.ImagesToCopy {
-		imageTar := filepath.Join(pluginBundleDir, ic.SourceTarFilePath)
-		repoImagePath, err := utils.JoinURL(o.DestinationRepo, ic.RelativeImagePath)
+	// Compare the plugin bundle with the destination repository before publishing anything
+	repoImagePaths := make([]string, len(manifest.ImagesToCopy))
+	imageStatuses := make([]imageStatus, len(manifest.ImagesToCopy))
+	for i, ic := range manifest.ImagesToCopy {
+		repoImagePaths[i], err = utils.JoinURL(o.DestinationRepo, ic.RelativeImagePath)
 		if err != nil {
 			return errors.Wrap(err, "error while constructing the repo image path")
 		}
+		imageStatuses[i] = o.getImageStatus(repoImagePaths[i], ic)
+	}
+	reportImageStatuses(repoImagePaths, imageStatuses)
+
+	bundledPluginInventoryMetadataDBFilePath := filepath.Join(pluginBundleDir, manifest.InventoryMetadataImage.SourceFilePath)
+	pluginInventoryMetadataImageWithTag, err := utils.JoinURL(o.DestinationRepo, manifest.InventoryMetadataImage.RelativeImagePathWithTag)
+	if err != nil {
+		return errors.Wrap(err, "error while constructing the plugin inventory metadata image with tag")
+	}
+	existingPluginInventoryMetadataDBFilePath := o.downloadPluginInventoryMetadata(pluginInventoryMetadataImageWithTag, tempDir)
+	err = reportPluginInventoryMetadataDiff(pluginInventoryMetadataImageWithTag, bundledPluginInventoryMetadataDBFilePath, existingPluginInventoryMetadataDBFilePath)
+	if err != nil {
+		return errors.Wrap(err, "error while comparing the plugin inventory metadata database with the destination repository")
+	}
+
+	if o.DryRun {
+		log.Infof("dry run, skipping the upload of the plugin bundle to %q", o.DestinationRepo)
+		return nil
+	}
+
+	// Iterate through all the images and publish them to the remote repository
+	for i, ic := range manifest.ImagesToCopy {
 		log.Infof("---------------------------")
-		log.Infof("uploading image %q", repoImagePath)
-		err = o.ImageProcessor.CopyImageFromTar(imageTar, repoImagePath)
+		if o.SkipExisting && imageStatuses[i] == imageStatusExisting {
+			log.Infof("skipping image %q which already exists", repoImagePaths[i])
+			continue
+		}
+		log.Infof("uploading image %q", repoImagePaths[i])
+		err = o.uploadImage(pluginBundleDir, repoImagePaths[i], ic)
 		if err != nil {
 			return errors.Wrap(err, "error while uploading image")
 		}
@@ -74,12 +133,7 @@ func (o *UploadPluginBundleOptions) UploadPluginBundle() error {
 
 	// Publish plugin inventory metadata image after merging inventory metadata
 	log.Infof("publishing plugin inventory metadata image...")
-	bundledPluginInventoryMetadataDBFilePath := filepath.Join(pluginBundleDir, manifest.InventoryMetadataImage.SourceFilePath)
-	pluginInventoryMetadataImageWithTag, err := utils.JoinURL(o.DestinationRepo, manifest.InventoryMetadataImage.RelativeImagePathWithTag)
-	if err != nil {
-		return errors.Wrap(err, "error while constructing the plugin inventory metadata image with tag")
-	}
-	err = o.mergePluginInventoryMetadata(pluginInventoryMetadataImageWithTag, bundledPluginInventoryMetadataDBFilePath, tempDir)
+	err = mergePluginInventoryMetadata(pluginInventoryMetadataImageWithTag, bundledPluginInventoryMetadataDBFilePath, existingPluginInventoryMetadataDBFilePath)
 	if err != nil {
 		return errors.Wrap(err, "error while merging the plugin inventory metadata database before uploading metadata image")
 	}
@@ -101,21 +155,110 @@ func (o *UploadPluginBundleOptions) UploadPluginBundle() error {
 	return nil
 }
 
-// mergePluginInventoryMetadata merges the downloaded plugin inventory metadata with
-// existing plugin inventory metadata available on the remote repository
-func (o *UploadPluginBundleOptions) mergePluginInventoryMetadata(pluginInventoryMetadataImageWithTag, bundledPluginInventoryMetadataDBFilePath, tempDir string) error {
+// uploadImage publishes the image of the plugin bundle to the destination repository
+func (o *UploadPluginBundleOptions) uploadImage(pluginBundleDir, repoImagePath string, ic *ImageCopyInfo) error {
+	if ic.SourceOCILayoutRefName == "" {
+		return o.ImageProcessor.CopyImageFromTar(filepath.Join(pluginBundleDir, ic.SourceTarFilePath), repoImagePath)
+	}
+
+	imageLayoutDir, err := os.MkdirTemp("", "")
+	if err != nil {
+		return errors.Wrap(err, "unable to create temp directory")
+	}
+	defer os.RemoveAll(imageLayoutDir)
+
+	imageTar := filepath.Join(imageLayoutDir, "image.tar")
+	err = extractImageFromOCILayout(pluginBundleDir, ic.SourceOCILayoutRefName, imageTar)
+	if err != nil {
+		return err
+	}
+	repoImageWithTag, err := utils.JoinURL(o.DestinationRepo, ic.SourceOCILayoutRefName)
+	if err != nil {
+		return err
+	}
+	return o.ImageProcessor.UploadImageFromOCILayout(imageTar, repoImageWithTag)
+}
+
+// getImageStatus compares the digest of the image of the plugin bundle with the
+// digest of the same image of the destination repository, if any
+func (o *UploadPluginBundleOptions) getImageStatus(repoImagePath string, ic *ImageCopyInfo) imageStatus {
+	if ic.Digest == "" {
+		return imageStatusUnknown
+	}
+	hashAlgorithm, hashHexVal, err := o.ImageProcessor.GetImageDigest(repoImagePath + ":" + ic.Tag)
+	if err != nil || hashHexVal == "" {
+		return imageStatusNew
+	}
+	if hashAlgorithm+":"+hashHexVal == ic.Digest {
+		return imageStatusExisting
+	}
+	return imageStatusOverwritten
+}
+
+// reportImageStatuses logs how the images of the plugin bundle compare with the destination repository
+func reportImageStatuses(repoImagePaths []string, imageStatuses []imageStatus) {
+	counts := map[imageStatus]int{}
+	for i, status := range imageStatuses {
+		counts[status]++
+		switch status {
+		case imageStatusExisting:
+			log.Infof("image %q already exists", repoImagePaths[i])
+		case imageStatusOverwritten:
+			log.Infof("image %q exists with a different digest and will be overwritten", repoImagePaths[i])
+		case imageStatusNew:
+			log.Infof("image %q will be added", repoImagePaths[i])
+		default:
+			log.Infof("image %q cannot be compared with the destination repository and will be uploaded", repoImagePaths[i])
+		}
+	}
+	log.Infof("images: %d existing, %d new, %d to overwrite, %d unknown",
+		counts[imageStatusExisting], counts[imageStatusNew], counts[imageStatusOverwritten], counts[imageStatusUnknown])
+}
+
+// downloadPluginInventoryMetadata downloads the plugin inventory metadata database of the
+// destination repository, and returns its path or an empty string if it does not exist
+func (o *UploadPluginBundleOptions) downloadPluginInventoryMetadata(pluginInventoryMetadataImageWithTag, tempDir string) string {
 	tempPluginInventoryMetadataDir := filepath.Join(tempDir, "inventory-metadata")
 	err := o.ImageProcessor.DownloadImageAndSaveFilesToDir(pluginInventoryMetadataImageWithTag, tempPluginInventoryMetadataDir)
-	if err == nil {
-		downloadedPluginInventoryMetadataDBFilePath := filepath.Join(tempPluginInventoryMetadataDir, plugininventory.SQliteInventoryMetadataDBFileName)
-		pluginInventoryDB := plugininventory.NewSQLiteInventoryMetadata(bundledPluginInventoryMetadataDBFilePath)
-		err = pluginInventoryDB.MergeInventoryMetadataDatabase(downloadedPluginInventoryMetadataDBFilePath)
-		if err != nil {
-			return err
-		}
-		log.Infof("plugin inventory metadata image %q is present. Merging the plugin inventory metadata", pluginInventoryMetadataImageWithTag)
-	} else {
+	if err != nil {
+		return ""
+	}
+	existingPluginInventoryMetadataDBFilePath := filepath.Join(tempPluginInventoryMetadataDir, plugininventory.SQliteInventoryMetadataDBFileName)
+	if !utils.PathExists(existingPluginInventoryMetadataDBFilePath) {
+		return ""
+	}
+	return existingPluginInventoryMetadataDBFilePath
+}
+
+// reportPluginInventoryMetadataDiff logs how many plugins and plugin groups of the
+// plugin bundle already exist in the plugin inventory metadata of the destination repository
+func reportPluginInventoryMetadataDiff(pluginInventoryMetadataImageWithTag, bundledPluginInventoryMetadataDBFilePath, existingPluginInventoryMetadataDBFilePath string) error {
+	if existingPluginInventoryMetadataDBFilePath == "" {
+		log.Infof("plugin inventory metadata image %q is not present, all the plugins and plugin groups will be added", pluginInventoryMetadataImageWithTag)
+		return nil
+	}
+	pluginInventoryDB := plugininventory.NewSQLiteInventoryMetadata(bundledPluginInventoryMetadataDBFilePath)
+	diff, err := pluginInventoryDB.DiffInventoryMetadataDatabase(existingPluginInventoryMetadataDBFilePath)
+	if err != nil {
+		return err
+	}
+	log.Infof("plugins: %d existing, %d new", diff.ExistingPlugins, diff.NewPlugins)
+	log.Infof("plugin groups: %d existing, %d new", diff.ExistingPluginGroups, diff.NewPluginGroups)
+	return nil
+}
+
+// mergePluginInventoryMetadata merges the downloaded plugin inventory metadata with
+// existing plugin inventory metadata available on the remote repository
+func mergePluginInventoryMetadata(pluginInventoryMetadataImageWithTag, bundledPluginInventoryMetadataDBFilePath, existingPluginInventoryMetadataDBFilePath string) error {
+	if existingPluginInventoryMetadataDBFilePath == "" {
 		log.Infof("plugin inventory metadata image %q is not present. Skipping merging of the plugin inventory metadata", pluginInventoryMetadataImageWithTag)
+		return nil
+	}
+	pluginInventoryDB := plugininventory.NewSQLiteInventoryMetadata(bundledPluginInventoryMetadataDBFilePath)
+	err := pluginInventoryDB.MergeInventoryMetadataDatabase(existingPluginInventoryMetadataDBFilePath)
+	if err != nil {
+		return err
 	}
+	log.Infof("plugin inventory metadata image %q is present. Merging the plugin inventory metadata", pluginInventoryMetadataImageWithTag)
 	return nil
 }
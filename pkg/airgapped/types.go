@@ -6,6 +6,19 @@ package airgapped
 
 const PluginBundleDirName = "plugin_bundle"
 const PluginMigrationManifestFile = "plugin_migration_manifest.yaml"
+const PluginBundleChecksumsFile = "plugin_bundle_checksums.yaml"
+const PluginBundleSignatureFile = "plugin_bundle_checksums.yaml.sig"
+
+// PluginBundleFormat is the format of the images of a plugin bundle
+type PluginBundleFormat string
+
+const (
+	// PluginBundleFormatImgpkg saves each image as a separate imgpkg tar file
+	PluginBundleFormatImgpkg PluginBundleFormat = "imgpkg"
+	// PluginBundleFormatOCILayout saves all the images in a standard OCI image layout
+	// at the root of the plugin bundle, usable by other registry tools
+	PluginBundleFormatOCILayout PluginBundleFormat = "oci-layout"
+)
 
 // PluginMigrationManifest defines struct for plugin bundle manifest
 type PluginMigrationManifest struct {
@@ -18,6 +31,13 @@ type PluginMigrationManifest struct {
 type ImageCopyInfo struct {
 	SourceTarFilePath string `yaml:"sourceTarFilePath"`
 	RelativeImagePath string `yaml:"relativeImagePath"`
+	// SourceOCILayoutRefName is the reference name of the image in the OCI image layout
+	// of the plugin bundle, it replaces SourceTarFilePath for the oci-layout format
+	SourceOCILayoutRefName string `yaml:"sourceOCILayoutRefName,omitempty"`
+	// Tag and Digest identify the downloaded image, they are empty for the images of
+	// plugin bundles downloaded with older versions of the CLI or referenced by digest
+	Tag    string `yaml:"tag,omitempty"`
+	Digest string `yaml:"digest,omitempty"`
 }
 
 // ImagePublishInfo maps the relative image path and local relative file path
@@ -25,3 +45,9 @@ type ImagePublishInfo struct {
 	SourceFilePath           string `yaml:"sourceFilePath"`
 	RelativeImagePathWithTag string `yaml:"relativeImagePathWithTag"`
 }
+
+// PluginBundleChecksums defines struct for the checksums of the files of a plugin bundle
+type PluginBundleChecksums struct {
+	// Files maps the relative path of each file of the plugin bundle to its sha256 checksum
+	Files map[string]string `yaml:"files"`
+}
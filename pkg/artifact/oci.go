@@ -19,7 +19,9 @@ type fileMapGetterFn func(string) (map[string][]byte, error)
 
 // OCIArtifact defines OCI artifact image endpoint
 type OCIArtifact struct {
-	Image                string
+	Image string
+	// Compressed tells whether the plugin binary of the image is compressed using zstd
+	Compressed           bool
 	getFilesMapFromImage fileMapGetterFn
 }
 
@@ -31,6 +33,16 @@ func NewOCIArtifact(image string) Artifact {
 	}
 }
 
+// NewCompressedOCIArtifact creates OCI Artifact object for an image containing
+// a zstd-compressed plugin binary, which is decompressed when fetched
+func NewCompressedOCIArtifact(image string) Artifact {
+	return &OCIArtifact{
+		Image:                image,
+		Compressed:           true,
+		getFilesMapFromImage: carvelhelpers.GetFilesMapFromImage,
+	}
+}
+
 // Fetch an artifact.
 func (g *OCIArtifact) Fetch() ([]byte, error) {
 	filesMap, err := g.getFilesMapFromImage(g.Image)
@@ -55,6 +67,9 @@ func (g *OCIArtifact) Fetch() ([]byte, error) {
 		return nil, fmt.Errorf("oci artifact image for plugin is required to have only 1 file, but found %v", fileCount)
 	}
 
+	if g.Compressed {
+		return DecompressZstd(bytesData)
+	}
 	return bytesData, nil
 }
 
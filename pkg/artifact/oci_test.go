@@ -33,3 +33,33 @@ func TestOCIArtifactWhenMultipleFilesFound(t *testing.T) {
 		t.Fatalf("Did not receive the expected error message. Expected '%s', got '%s'", expectedErrorMessage, err.Error())
 	}
 }
+
+func TestCompressedOCIArtifact(t *testing.T) {
+	binary := []byte("#!/bin/bash\necho plugin\n")
+	compressed, err := CompressZstd(binary)
+	if err != nil {
+		t.Fatalf("Unexpected error compressing the binary: %v", err)
+	}
+
+	artifact := NewCompressedOCIArtifact("foo")
+	o, _ := artifact.(*OCIArtifact)
+	o.getFilesMapFromImage = func(s string) (map[string][]byte, error) {
+		return map[string][]byte{"tanzu-foo-linux_amd64.zst": compressed}, nil
+	}
+
+	data, err := o.Fetch()
+	if err != nil {
+		t.Fatalf("Unexpected error fetching the compressed artifact: %v", err)
+	}
+	if string(data) != string(binary) {
+		t.Fatalf("Expected the decompressed binary '%s', got '%s'", binary, data)
+	}
+
+	// A binary that is not compressed cannot be fetched as compressed
+	o.getFilesMapFromImage = func(s string) (map[string][]byte, error) {
+		return map[string][]byte{"tanzu-foo-linux_amd64": binary}, nil
+	}
+	if _, err = o.Fetch(); err == nil {
+		t.Fatalf("Expected an error fetching an uncompressed binary as compressed")
+	}
+}
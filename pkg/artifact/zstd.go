@@ -0,0 +1,35 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package artifact
+
+import (
+	"github.com/klauspost/compress/zstd"
+	"github.com/pkg/errors"
+)
+
+// CompressZstd compresses the plugin binary using zstd
+func CompressZstd(data []byte) ([]byte, error) {
+	encoder, err := zstd.NewWriter(nil, zstd.WithEncoderLevel(zstd.SpeedBestCompression))
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to create zstd encoder")
+	}
+	defer encoder.Close()
+
+	return encoder.EncodeAll(data, nil), nil
+}
+
+// DecompressZstd decompresses a plugin binary compressed using zstd
+func DecompressZstd(data []byte) ([]byte, error) {
+	decoder, err := zstd.NewReader(nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to create zstd decoder")
+	}
+	defer decoder.Close()
+
+	decompressed, err := decoder.DecodeAll(data, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to decompress plugin binary")
+	}
+	return decompressed, nil
+}
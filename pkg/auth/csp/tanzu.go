@@ -113,9 +113,9 @@ func getCSPMetadataFromCentralConfig() TanzuCSPMetadata {
 	// Get the tanzu CSP metadata based on user preference
 	useTanzuCSP, _ := strconv.ParseBool(os.Getenv(constants.UseTanzuCSP))
 	if useTanzuCSP {
-		_ = centralconfig.DefaultCentralConfigReader.GetCentralConfigEntry(centralConfigTanzuTCSPMetadata, &cspMetadata)
+		centralconfig.GetObject(centralConfigTanzuTCSPMetadata, &cspMetadata)
 	} else {
-		_ = centralconfig.DefaultCentralConfigReader.GetCentralConfigEntry(centralConfigTanzuDefaultCSPMetadata, &cspMetadata)
+		centralconfig.GetObject(centralConfigTanzuDefaultCSPMetadata, &cspMetadata)
 	}
 
 	return cspMetadata
@@ -124,8 +124,8 @@ func getCSPMetadataFromCentralConfig() TanzuCSPMetadata {
 // getCSPKnownIssuersEndpoints gets the CSP known issuer endpoints from central config as best effort,
 // If it fails to fetch data from central config, it returns the default values
 func getCSPKnownIssuersEndpoints() cspKnownIssuerEndpoints {
-	cspKnownIssuerEPs, err := getCSPKnownEndpointsFromCentralConfig()
-	if err == nil {
+	cspKnownIssuerEPs := cspKnownIssuerEndpoints{}
+	if centralconfig.GetObject(centralConfigTanzuKnownIssersEndpoints, &cspKnownIssuerEPs) {
 		return cspKnownIssuerEPs
 	}
 
@@ -140,27 +140,8 @@ func getCSPKnownIssuersEndpoints() cspKnownIssuerEndpoints {
 	return cspKnownIssuerEPs
 }
 
-// getCSPKnownEndpointsFromCentralConfig gets the CSP known endpoints from central config as best effort
-func getCSPKnownEndpointsFromCentralConfig() (cspKnownIssuerEndpoints, error) {
-	cspKnownIssuerEPs := cspKnownIssuerEndpoints{}
-
-	err := centralconfig.DefaultCentralConfigReader.GetCentralConfigEntry(centralConfigTanzuKnownIssersEndpoints, &cspKnownIssuerEPs)
-	if err != nil {
-		return cspKnownIssuerEPs, err
-	}
-
-	return cspKnownIssuerEPs, nil
-}
-
 // GetIssuerUpdateFlagFromCentralConfig gets the issuer update flag (used to update the CLI config file)
 // from Central config as best effort
 func GetIssuerUpdateFlagFromCentralConfig() bool {
-	updateIssuerInCLIConfig := false
-
-	err := centralconfig.DefaultCentralConfigReader.GetCentralConfigEntry(centralConfigCLIConfigCSPIssuerUpdateFlag, &updateIssuerInCLIConfig)
-	if err != nil {
-		return updateIssuerInCLIConfig
-	}
-
-	return updateIssuerInCLIConfig
+	return centralconfig.GetBool(centralConfigCLIConfigCSPIssuerUpdateFlag, false)
 }
@@ -107,11 +107,12 @@ func GetServerKubernetesVersion(kubeconfigPath, context string) (string, error)
 		return "", errors.Errorf("Error getting discovery client due to : %v", err)
 	}
 
-	if _, err := discoveryClient.ServerVersion(); err != nil {
+	serverVersion, err := discoveryClient.ServerVersion()
+	if err != nil {
 		return "", errors.Errorf("Failed to invoke API on cluster : %v", err)
 	}
 
-	return "", nil
+	return serverVersion.GitVersion, nil
 }
 
 func loadKubeconfigAndEnsureContext(kubeConfigPath, context string) ([]byte, error) {
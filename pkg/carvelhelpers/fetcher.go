@@ -5,13 +5,17 @@ package carvelhelpers
 
 import (
 	"os"
+	"path/filepath"
 	"strings"
+	"sync"
 
 	"github.com/pkg/errors"
 
 	ctlimg "github.com/vmware-tanzu/carvel-imgpkg/pkg/imgpkg/registry"
 
+	"github.com/vmware-tanzu/tanzu-cli/pkg/common"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/download"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/registry"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/utils"
 )
@@ -34,8 +38,42 @@ func GetImageDigest(imageWithTag string) (string, string, error) {
 	return NewImageOperationsImpl().GetImageDigest(imageWithTag)
 }
 
+// ListImageTags lists all the tags of the image repository
+func ListImageTags(imageName string) ([]string, error) {
+	return NewImageOperationsImpl().ListImageTags(imageName)
+}
+
+// DownloadImageToOCILayout downloads the image as a tar file of a standard OCI image layout
+func DownloadImageToOCILayout(imageWithTag, destTarFile string) error {
+	return NewImageOperationsImpl().DownloadImageToOCILayout(imageWithTag, destTarFile)
+}
+
+// UploadImageFromOCILayout publishes the image from the tar file of a standard OCI image layout
+func UploadImageFromOCILayout(sourceTarFile, destImageWithTag string) error {
+	return NewImageOperationsImpl().UploadImageFromOCILayout(sourceTarFile, destImageWithTag)
+}
+
+// registryCacheKey identifies the registry clients which can be shared
+// because they access the same registry host with the same options
+type registryCacheKey struct {
+	registryHost string
+	anon         bool
+	verifyCerts  bool
+	insecure     bool
+	caCertPaths  string
+}
+
+var (
+	registryCacheMutex sync.Mutex
+	// registryCache keeps the registry clients created by the process so that
+	// the authentication tokens they obtained are reused across operations
+	registryCache = map[registryCacheKey]registry.Registry{}
+)
+
 // newRegistry returns a new registry object by also taking
-// into account for any custom registry provided by the user
+// into account for any custom registry provided by the user.
+// The registry object is cached for the duration of the process so
+// that subsequent operations on the same registry do not re-authenticate.
 func newRegistry(registryHost string) (registry.Registry, error) {
 	registryOpts := &ctlimg.Opts{}
 
@@ -51,5 +89,26 @@ func newRegistry(registryHost string) (registry.Registry, error) {
 	registryOpts.CACertPaths = regCertOptions.CACertPaths
 	registryOpts.VerifyCerts = !(regCertOptions.SkipCertVerify)
 	registryOpts.Insecure = regCertOptions.Insecure
-	return registry.New(registryOpts)
+
+	key := registryCacheKey{
+		registryHost: registryHost,
+		anon:         registryOpts.Anon,
+		verifyCerts:  registryOpts.VerifyCerts,
+		insecure:     registryOpts.Insecure,
+		caCertPaths:  strings.Join(registryOpts.CACertPaths, ","),
+	}
+
+	registryCacheMutex.Lock()
+	defer registryCacheMutex.Unlock()
+	if reg, exists := registryCache[key]; exists {
+		return reg, nil
+	}
+	reg, err := registry.New(registryOpts,
+		registry.WithResumableDownloads(filepath.Join(common.DefaultCacheDir, common.DownloadsDirName)),
+		registry.WithDownloadScheduler(download.DefaultScheduler()))
+	if err != nil {
+		return nil, err
+	}
+	registryCache[key] = reg
+	return reg, nil
 }
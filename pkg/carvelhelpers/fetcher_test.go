@@ -0,0 +1,42 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package carvelhelpers
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
+)
+
+func Test_NewRegistry_Is_Cached_Per_Registry_Host(t *testing.T) {
+	assert := assert.New(t)
+
+	tanzuConfigFile, err := os.CreateTemp("", "config")
+	assert.NoError(err)
+	defer os.Remove(tanzuConfigFile.Name())
+	tanzuConfigNextGenFile, err := os.CreateTemp("", "config_ng")
+	assert.NoError(err)
+	defer os.Remove(tanzuConfigNextGenFile.Name())
+	t.Setenv("TANZU_CONFIG", tanzuConfigFile.Name())
+	t.Setenv("TANZU_CONFIG_NEXT_GEN", tanzuConfigNextGenFile.Name())
+
+	reg1, err := newRegistry("registry1.example.com")
+	assert.NoError(err)
+	reg2, err := newRegistry("registry1.example.com")
+	assert.NoError(err)
+	assert.Same(reg1, reg2)
+
+	reg3, err := newRegistry("registry2.example.com")
+	assert.NoError(err)
+	assert.NotSame(reg1, reg3)
+
+	// Changing the authentication of a registry requires a different registry object
+	t.Setenv(constants.AuthenticatedRegistry, "registry1.example.com")
+	reg4, err := newRegistry("registry1.example.com")
+	assert.NoError(err)
+	assert.NotSame(reg1, reg4)
+}
@@ -24,6 +24,7 @@ func NewImageOperationsImpl() ImageOperationsImpl {
 // CopyImageToTar downloads the image as tar file
 // This is equivalent to `imgpkg copy --image <image> --to-tar <tar-file-path>` command
 func (i *ImageOperationOptions) CopyImageToTar(sourceImageName, destTarFile string) error {
+	sourceImageName = registry.ResolveImageMirror(sourceImageName)
 	registryName, err := registry.GetRegistryName(sourceImageName)
 	if err != nil {
 		return err
@@ -49,9 +50,37 @@ func (i *ImageOperationOptions) CopyImageFromTar(sourceTarFile, destImageRepo st
 	return reg.CopyImageFromTar(sourceTarFile, destImageRepo)
 }
 
+// DownloadImageToOCILayout downloads the image as a tar file of a standard OCI image layout
+func (i *ImageOperationOptions) DownloadImageToOCILayout(imageWithTag, destTarFile string) error {
+	imageWithTag = registry.ResolveImageMirror(imageWithTag)
+	registryName, err := registry.GetRegistryName(imageWithTag)
+	if err != nil {
+		return err
+	}
+	reg, err := newRegistry(registryName)
+	if err != nil {
+		return errors.Wrapf(err, "unable to initialize registry")
+	}
+	return reg.DownloadImageToOCILayout(imageWithTag, destTarFile)
+}
+
+// UploadImageFromOCILayout publishes the image from the tar file of a standard OCI image layout
+func (i *ImageOperationOptions) UploadImageFromOCILayout(sourceTarFile, destImageWithTag string) error {
+	registryName, err := registry.GetRegistryName(destImageWithTag)
+	if err != nil {
+		return err
+	}
+	reg, err := newRegistry(registryName)
+	if err != nil {
+		return errors.Wrapf(err, "unable to initialize registry")
+	}
+	return reg.UploadImageFromOCILayout(sourceTarFile, destImageWithTag)
+}
+
 // DownloadImageAndSaveFilesToDir reads a plain OCI image and saves its
 // files to the specified location.
 func (i *ImageOperationOptions) DownloadImageAndSaveFilesToDir(imageWithTag, destinationDir string) error {
+	imageWithTag = registry.ResolveImageMirror(imageWithTag)
 	registryName, err := registry.GetRegistryName(imageWithTag)
 	if err != nil {
 		return err
@@ -71,6 +100,7 @@ func (i *ImageOperationOptions) DownloadImageAndSaveFilesToDir(imageWithTag, des
 // It takes os environment variables for custom repository and proxy
 // configuration into account while downloading image from repository
 func (i *ImageOperationOptions) GetFilesMapFromImage(imageWithTag string) (map[string][]byte, error) {
+	imageWithTag = registry.ResolveImageMirror(imageWithTag)
 	registryName, err := registry.GetRegistryName(imageWithTag)
 	if err != nil {
 		return nil, err
@@ -84,6 +114,7 @@ func (i *ImageOperationOptions) GetFilesMapFromImage(imageWithTag string) (map[s
 
 // GetImageDigest gets digest of the image
 func (i *ImageOperationOptions) GetImageDigest(imageWithTag string) (string, string, error) {
+	imageWithTag = registry.ResolveImageMirror(imageWithTag)
 	registryName, err := registry.GetRegistryName(imageWithTag)
 	if err != nil {
 		return "", "", err
@@ -101,6 +132,25 @@ func (i *ImageOperationOptions) GetImageDigest(imageWithTag string) (string, str
 	return hashAlgorithm, hashHexVal, nil
 }
 
+// ListImageTags lists all the tags of the image repository
+func (i *ImageOperationOptions) ListImageTags(imageName string) ([]string, error) {
+	imageName = registry.ResolveImageMirror(imageName)
+	registryName, err := registry.GetRegistryName(imageName)
+	if err != nil {
+		return nil, err
+	}
+	reg, err := newRegistry(registryName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to initialize registry")
+	}
+
+	tags, err := reg.ListImageTags(imageName)
+	if err != nil {
+		return nil, errors.Wrap(err, "error listing the image tags")
+	}
+	return tags, nil
+}
+
 // PushImage publishes the image to the specified location
 func (i *ImageOperationOptions) PushImage(imageWithTag string, filePaths []string) error {
 	registryName, err := registry.GetRegistryName(imageWithTag)
@@ -116,6 +166,7 @@ func (i *ImageOperationOptions) PushImage(imageWithTag string, filePaths []strin
 
 // ResolveImage invokes `imgpkg tag resolve -i <image>` command
 func (i *ImageOperationOptions) ResolveImage(imageWithTag string) error {
+	imageWithTag = registry.ResolveImageMirror(imageWithTag)
 	registryName, err := registry.GetRegistryName(imageWithTag)
 	if err != nil {
 		return err
@@ -6,6 +6,7 @@ package carvelhelpers
 //go:generate counterfeiter -o ../fakes/imageoperationsimpl.go --fake-name ImageOperationsImpl . ImageOperationsImpl
 
 // ImageOperationsImpl defines the helper functions for downloading, copying and processing oci images
+// Images are pulled from the registry mirror configured for their registry, if any
 type ImageOperationsImpl interface {
 	// CopyImageToTar downloads the image as tar file
 	// This is equivalent to `imgpkg copy --image <image> --to-tar <tar-file-path>` command
@@ -13,6 +14,12 @@ type ImageOperationsImpl interface {
 	// CopyImageFromTar publishes the image to destination repository from specified tar file
 	// This is equivalent to `imgpkg copy --tar <file> --to-repo <dest-repo>` command
 	CopyImageFromTar(sourceTarFile, destImageRepo string) error
+	// DownloadImageToOCILayout downloads the image as a tar file of a standard OCI image layout
+	// This is equivalent to `crane pull --format=oci <image> <dir>` followed by a tar of the directory
+	DownloadImageToOCILayout(imageWithTag, destTarFile string) error
+	// UploadImageFromOCILayout publishes the image from the tar file of a standard OCI image layout
+	// This is equivalent to untarring the file followed by `crane push <dir> <image>`
+	UploadImageFromOCILayout(sourceTarFile, destImageWithTag string) error
 	// DownloadImageAndSaveFilesToDir reads a plain OCI image and saves its
 	// files to the specified location.
 	DownloadImageAndSaveFilesToDir(imageWithTag, destinationDir string) error
@@ -22,6 +29,9 @@ type ImageOperationsImpl interface {
 	GetFilesMapFromImage(imageWithTag string) (map[string][]byte, error)
 	// GetImageDigest gets digest of the image
 	GetImageDigest(imageWithTag string) (string, string, error)
+	// ListImageTags lists all the tags of the image repository
+	// This is equivalent to `imgpkg tag list -i <image>` command
+	ListImageTags(imageName string) ([]string, error)
 	// PushImage publishes the image to the specified location
 	// This is equivalent to `imgpkg push -i <image> -f <filepath>`
 	PushImage(imageWithTag string, filePaths []string) error
@@ -5,18 +5,17 @@ package catalog
 
 import (
 	"fmt"
-	"io"
 	"os"
 	"path/filepath"
 
 	"github.com/pkg/errors"
-	"github.com/rogpeppe/go-internal/lockedfile"
 	"gopkg.in/yaml.v3"
 
 	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
 
 	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/common"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/profiling"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/utils"
 )
 
@@ -25,22 +24,27 @@ const (
 	catalogCacheFileName = "catalog.yaml"
 )
 
-var (
-	// PluginRoot is the plugin root where plugins are installed
-	pluginRoot = common.DefaultPluginRoot
-)
-
 // ContextCatalog denotes a local plugin catalog for a given context or
 // stand-alone.
 type ContextCatalog struct {
+	store         Store
 	sharedCatalog *Catalog
 	plugins       PluginAssociation
-	lockedFile    *lockedfile.File
+	lock          *utils.FileLock
+}
+
+// Store is the plugin catalog kept in a cache directory, which references the plugins
+// installed in a plugin root directory.  The zero value is the catalog of the CLI.
+type Store struct {
+	// CacheDir is the directory holding the catalog, the cache directory of the CLI if empty
+	CacheDir string
+	// PluginRoot is the directory in which the plugins are installed, the plugin root of the CLI if empty
+	PluginRoot string
 }
 
 // NewContextCatalog creates context-aware catalog for reading the catalog
 func NewContextCatalog(context string) (PluginCatalogReader, error) {
-	return newContextCatalog(context, false)
+	return Store{}.NewContextCatalog(context)
 }
 
 // NewContextCatalogUpdater creates context-aware catalog for reading/updating the catalog
@@ -49,12 +53,23 @@ func NewContextCatalog(context string) (PluginCatalogReader, error) {
 // After Unlock() is called, the ContextCatalog object can no longer be used,
 // and a new one must be obtained for any further operation on the catalog
 func NewContextCatalogUpdater(context string) (PluginCatalogUpdater, error) {
-	return newContextCatalog(context, true)
+	return Store{}.NewContextCatalogUpdater(context)
+}
+
+// NewContextCatalog creates context-aware catalog for reading the catalog of the store
+func (s Store) NewContextCatalog(context string) (PluginCatalogReader, error) {
+	return s.newContextCatalog(context, false)
+}
+
+// NewContextCatalogUpdater creates context-aware catalog for reading/updating the catalog
+// of the store.  As for NewContextCatalogUpdater, `Unlock` must be called once done.
+func (s Store) NewContextCatalogUpdater(context string) (PluginCatalogUpdater, error) {
+	return s.newContextCatalog(context, true)
 }
 
 // newContextCatalog creates a new context-aware catalog object
-func newContextCatalog(context string, lockCatalog bool) (*ContextCatalog, error) {
-	sc, lockedFile, err := getCatalogCache(lockCatalog)
+func (s Store) newContextCatalog(context string, lockCatalog bool) (*ContextCatalog, error) {
+	sc, lock, err := s.getCatalogCache(lockCatalog)
 	if err != nil {
 		return nil, err
 	}
@@ -72,15 +87,16 @@ func newContextCatalog(context string, lockCatalog bool) (*ContextCatalog, error
 	}
 
 	return &ContextCatalog{
+		store:         s,
 		sharedCatalog: sc,
 		plugins:       plugins,
-		lockedFile:    lockedFile,
+		lock:          lock,
 	}, nil
 }
 
 // Upsert inserts/updates the given plugin.
 func (c *ContextCatalog) Upsert(plugin *cli.PluginInfo) error {
-	if c.lockedFile == nil {
+	if c.lock == nil {
 		return errors.Errorf("cannot complete the upsert plugin operation for plugin %q. catalog is not locked", plugin.Name)
 	}
 
@@ -108,7 +124,7 @@ func (c *ContextCatalog) Upsert(plugin *cli.PluginInfo) error {
 		delete(c.plugins, PluginNameTarget(plugin.Name, configtypes.TargetGlobal))
 		delete(c.plugins, PluginNameTarget(plugin.Name, configtypes.TargetK8s))
 	}
-	return saveCatalogCache(c.sharedCatalog, c.lockedFile)
+	return c.store.saveCatalogCache(c.sharedCatalog, c.lock)
 }
 
 // Get looks up the descriptor of a plugin given its name.
@@ -142,28 +158,31 @@ func (c *ContextCatalog) List() []cli.PluginInfo {
 // Delete deletes the given plugin from the catalog, but it does not delete
 // the installation.
 func (c *ContextCatalog) Delete(plugin string) error {
-	if c.lockedFile == nil {
+	if c.lock == nil {
 		return errors.Errorf("cannot complete the delete plugin operation for plugin %q. catalog is not locked", plugin)
 	}
 	_, ok := c.plugins[plugin]
 	if ok {
 		delete(c.plugins, plugin)
 	}
-	return saveCatalogCache(c.sharedCatalog, c.lockedFile)
+	return c.store.saveCatalogCache(c.sharedCatalog, c.lock)
 }
 
 // Unlock unlocks the catalog for other process to read/write
 // After Unlock() is called, the ContextCatalog object can no longer be used,
 // and a new one must be obtained for any further operation on the catalog
 func (c *ContextCatalog) Unlock() {
-	if c.lockedFile != nil {
-		c.lockedFile.Close()
-		c.lockedFile = nil
+	if c.lock != nil {
+		c.lock.Unlock()
+		c.lock = nil
 	}
 }
 
 // getCatalogCacheDir returns the local directory in which tanzu state is stored.
-func getCatalogCacheDir() (path string) {
+func (s Store) getCatalogCacheDir() (path string) {
+	if s.CacheDir != "" {
+		return s.CacheDir
+	}
 	// NOTE: TEST_CUSTOM_CATALOG_CACHE_DIR is only for test purpose
 	customCacheDirForTest := os.Getenv("TEST_CUSTOM_CATALOG_CACHE_DIR")
 	if customCacheDirForTest != "" {
@@ -173,15 +192,16 @@ func getCatalogCacheDir() (path string) {
 }
 
 // newSharedCatalog creates an instance of the shared catalog file.
-func newSharedCatalog() (*Catalog, error) {
+func (s Store) newSharedCatalog() (*Catalog, error) {
 	c := &Catalog{
 		IndexByPath:       map[string]cli.PluginInfo{},
 		IndexByName:       map[string][]string{},
 		StandAlonePlugins: map[string]string{},
 		ServerPlugins:     map[string]PluginAssociation{},
+		ContextPlugins:    map[string][]string{},
 	}
 
-	err := ensureRoot()
+	err := s.ensureRoot()
 	if err != nil {
 		return nil, err
 	}
@@ -189,27 +209,39 @@ func newSharedCatalog() (*Catalog, error) {
 }
 
 // getCatalogCache retrieves the catalog from the local directory along with locking the catalog file
-// If `setWriteLock` is false, it will read the catalog file with ReadLock and release the lock at the same time
-// If `setWriteLock` is true, it will apply WriteLock to the catalog file, read the catalog file
-// and keep the WriteLock to the file along with returning `lockedFile` object. It is caller's
+// If `setWriteLock` is false, it will read the catalog file without locking, which is safe
+// because the catalog file is always replaced atomically
+// If `setWriteLock` is true, it will acquire the WriteLock of the catalog, read the catalog file
+// and keep the WriteLock along with returning the `lock` object. It is caller's
 // responsibility to unlock the WriteLock after the catalog update
-func getCatalogCache(setWriteLock bool) (*Catalog, *lockedfile.File, error) {
-	b, lockedFile, err := getCatalogCacheBytes(setWriteLock)
+func (s Store) getCatalogCache(setWriteLock bool) (*Catalog, *utils.FileLock, error) {
+	defer profiling.Track(profiling.PhaseCatalog)()
+
+	catalogCachePath := s.getCatalogCachePath()
+	var info os.FileInfo
+	if !setWriteLock {
+		var c *Catalog
+		if c, info = lookupParsedCatalog(catalogCachePath); c != nil {
+			return c, nil, nil
+		}
+	}
+
+	b, lock, err := getCatalogCacheBytes(catalogCachePath, setWriteLock)
 	if err != nil {
 		if os.IsNotExist(err) {
-			catalog, err := newSharedCatalog()
+			catalog, err := s.newSharedCatalog()
 			if err != nil {
-				return nil, lockedFile, err
+				return nil, lock, err
 			}
-			return catalog, lockedFile, nil
+			return catalog, lock, nil
 		}
-		return nil, lockedFile, err
+		return nil, lock, err
 	}
 
 	var c Catalog
 	err = yaml.Unmarshal(b, &c)
 	if err != nil {
-		return nil, lockedFile, errors.Wrap(err, "could not decode catalog file")
+		return nil, lock, errors.Wrap(err, "could not decode catalog file")
 	}
 
 	if c.IndexByPath == nil {
@@ -224,41 +256,40 @@ func getCatalogCache(setWriteLock bool) (*Catalog, *lockedfile.File, error) {
 	if c.ServerPlugins == nil {
 		c.ServerPlugins = map[string]PluginAssociation{}
 	}
+	if c.ContextPlugins == nil {
+		c.ContextPlugins = map[string][]string{}
+	}
+	if info != nil {
+		storeParsedCatalog(catalogCachePath, info, &c)
+	}
 
-	return &c, lockedFile, nil
+	return &c, lock, nil
 }
 
-func getCatalogCacheBytes(setWriteLock bool) ([]byte, *lockedfile.File, error) {
-	var lockedFile *lockedfile.File
+func getCatalogCacheBytes(catalogCachePath string, setWriteLock bool) ([]byte, *utils.FileLock, error) {
+	var lock *utils.FileLock
 	var err error
-	var b []byte
 
 	if setWriteLock {
-		if !utils.PathExists(getCatalogCachePath()) {
-			// Create directory path if missing before locking the file
-			_ = os.MkdirAll(getCatalogCacheDir(), 0755)
-		}
-		lockedFile, err = lockedfile.Edit(getCatalogCachePath())
+		lock, err = utils.LockFile(catalogCachePath)
 		if err != nil {
-			return nil, lockedFile, err
+			return nil, nil, err
 		}
-		b, err = io.ReadAll(lockedFile)
-	} else {
-		b, err = lockedfile.Read(getCatalogCachePath())
 	}
-	return b, lockedFile, err
+	b, err := utils.ReadFile(catalogCachePath)
+	return b, lock, err
 }
 
 // saveCatalogCache saves the catalog in the local directory.
-func saveCatalogCache(catalog *Catalog, lockedCatalogFile *lockedfile.File) error {
-	if lockedCatalogFile == nil {
+func (s Store) saveCatalogCache(catalog *Catalog, lock *utils.FileLock) error {
+	if lock == nil {
 		return errors.New("cannot save the catalog file. catalog is not locked")
 	}
 
-	catalogCachePath := getCatalogCachePath()
+	catalogCachePath := s.getCatalogCachePath()
 	_, err := os.Stat(catalogCachePath)
 	if os.IsNotExist(err) {
-		err = os.MkdirAll(getCatalogCacheDir(), 0755)
+		err = os.MkdirAll(s.getCatalogCacheDir(), 0755)
 		if err != nil {
 			return errors.Wrap(err, "could not make tanzu cache directory")
 		}
@@ -266,18 +297,14 @@ func saveCatalogCache(catalog *Catalog, lockedCatalogFile *lockedfile.File) erro
 		return errors.Wrap(err, "could not create catalog cache path")
 	}
 
+	catalog.PluginRoot = s.getPluginRoot()
 	out, err := yaml.Marshal(catalog)
 	if err != nil {
 		return errors.Wrap(err, "failed to encode catalog cache file")
 	}
 
-	if err := lockedCatalogFile.Truncate(0); err != nil {
-		return errors.Wrap(err, "failed to write catalog cache file. truncate failed")
-	}
-	if _, err := lockedCatalogFile.Seek(0, 0); err != nil {
-		return errors.Wrap(err, "failed to write catalog cache file. seek failed")
-	}
-	if _, err := lockedCatalogFile.Write(out); err != nil {
+	invalidateParsedCatalog()
+	if err := utils.WriteFileAtomic(catalogCachePath, out, 0644); err != nil {
 		return errors.Wrap(err, "failed to write catalog cache file")
 	}
 	return nil
@@ -285,30 +312,71 @@ func saveCatalogCache(catalog *Catalog, lockedCatalogFile *lockedfile.File) erro
 
 // CleanCatalogCache cleans the catalog cache
 func CleanCatalogCache() error {
-	if err := os.Remove(getCatalogCachePath()); err != nil && !errors.Is(err, os.ErrNotExist) {
+	return Store{}.CleanCatalogCache()
+}
+
+// CleanCatalogCache cleans the catalog cache of the store
+func (s Store) CleanCatalogCache() error {
+	invalidateParsedCatalog()
+	if err := os.Remove(s.getCatalogCachePath()); err != nil && !errors.Is(err, os.ErrNotExist) {
 		return err
 	}
 	return nil
 }
 
+// GetInstallationPaths returns the installation paths of all the plugins
+// installed, whether standalone or associated with a context
+func GetInstallationPaths() (map[string]bool, error) {
+	return Store{}.GetInstallationPaths()
+}
+
+// GetInstallationPaths returns the installation paths of all the plugins
+// of the catalog of the store
+func (s Store) GetInstallationPaths() (map[string]bool, error) {
+	c, _, err := s.getCatalogCache(false)
+	if err != nil {
+		return nil, err
+	}
+
+	paths := make(map[string]bool)
+	pluginAssociations := []PluginAssociation{c.StandAlonePlugins}
+	for _, spa := range c.ServerPlugins {
+		pluginAssociations = append(pluginAssociations, spa)
+	}
+	for _, pa := range pluginAssociations {
+		for _, path := range pa {
+			paths[path] = true
+		}
+	}
+	return paths, nil
+}
+
 // getCatalogCachePath gets the catalog cache path
-func getCatalogCachePath() string {
-	return filepath.Join(getCatalogCacheDir(), catalogCacheFileName)
+func (s Store) getCatalogCachePath() string {
+	return filepath.Join(s.getCatalogCacheDir(), catalogCacheFileName)
+}
+
+// getPluginRoot returns the directory in which the plugins of the catalog are installed
+func (s Store) getPluginRoot() string {
+	if s.PluginRoot != "" {
+		return s.PluginRoot
+	}
+	return common.DefaultPluginRoot
 }
 
 // Ensure the root directory exists.
-func ensureRoot() error {
-	_, err := os.Stat(testPath())
+func (s Store) ensureRoot() error {
+	_, err := os.Stat(s.testPath())
 	if os.IsNotExist(err) {
-		err := os.MkdirAll(testPath(), 0755)
+		err := os.MkdirAll(s.testPath(), 0755)
 		return errors.Wrap(err, "could not make root plugin directory")
 	}
 	return err
 }
 
 // Returns the test path relative to the plugin root
-func testPath() string {
-	return filepath.Join(pluginRoot, "test")
+func (s Store) testPath() string {
+	return filepath.Join(s.getPluginRoot(), "test")
 }
 
 // PluginNameTarget constructs a string to uniquely refer to a plugin associated
@@ -0,0 +1,73 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package catalog
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/common"
+)
+
+// setupBenchmarkCatalog creates a catalog with the number of installed plugins
+func setupBenchmarkCatalog(b *testing.B, count int) {
+	b.Helper()
+	origCacheDir, origPluginRoot := common.DefaultCacheDir, common.DefaultPluginRoot
+	common.DefaultCacheDir = b.TempDir()
+	common.DefaultPluginRoot = b.TempDir()
+	b.Cleanup(func() {
+		common.DefaultCacheDir, common.DefaultPluginRoot = origCacheDir, origPluginRoot
+	})
+
+	cc, err := NewContextCatalogUpdater("")
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer cc.Unlock()
+
+	installedAt := time.Now().UTC()
+	for i := 0; i < count; i++ {
+		name := fmt.Sprintf("plugin%03d", i)
+		err = cc.Upsert(&cli.PluginInfo{
+			Name:             name,
+			Target:           configtypes.TargetK8s,
+			Version:          "v1.0.0",
+			Description:      "Plugin " + name,
+			InstallationPath: "/path/to/plugin/" + name,
+			Digest:           fmt.Sprintf("%064d", i),
+			InstalledAt:      &installedAt,
+		})
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkNewContextCatalog measures the loading of the catalog,
+// which is done by every invocation of the CLI
+func BenchmarkNewContextCatalog(b *testing.B) {
+	setupBenchmarkCatalog(b, 100)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		cc, err := NewContextCatalog("")
+		if err != nil {
+			b.Fatal(err)
+		}
+		if len(cc.List()) != 100 {
+			b.Fatal("unexpected number of plugins in the catalog")
+		}
+	}
+}
+
+func BenchmarkMigratePluginInstallMetadataIfNeeded(b *testing.B) {
+	setupBenchmarkCatalog(b, 100)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		MigratePluginInstallMetadataIfNeeded()
+	}
+}
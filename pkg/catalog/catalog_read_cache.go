@@ -0,0 +1,92 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package catalog
+
+import (
+	"os"
+	"sync"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
+)
+
+// parsedCatalog holds the catalog last parsed for reading, which avoids parsing
+// the catalog file again when it has not changed, as the catalog is read several
+// times by each invocation of the CLI.
+// As the catalog file is always replaced atomically by renaming a new file, a change
+// of the catalog file is detected through the identity of the file, which changes
+// even if the new file has the same modification time and size.
+var parsedCatalog struct {
+	sync.Mutex
+	path    string
+	info    os.FileInfo
+	catalog *Catalog
+}
+
+// lookupParsedCatalog returns a copy of the parsed catalog if the catalog file at the path
+// has not changed since it was parsed, along with the information of the file
+// to use to store the catalog once parsed.
+func lookupParsedCatalog(path string) (*Catalog, os.FileInfo) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, nil
+	}
+
+	parsedCatalog.Lock()
+	defer parsedCatalog.Unlock()
+	if parsedCatalog.catalog != nil && parsedCatalog.path == path && os.SameFile(parsedCatalog.info, info) &&
+		parsedCatalog.info.ModTime().Equal(info.ModTime()) && parsedCatalog.info.Size() == info.Size() {
+		return copyCatalog(parsedCatalog.catalog), info
+	}
+	return nil, info
+}
+
+// storeParsedCatalog stores a copy of the catalog parsed from the file at the path described by info
+func storeParsedCatalog(path string, info os.FileInfo, c *Catalog) {
+	parsedCatalog.Lock()
+	defer parsedCatalog.Unlock()
+	parsedCatalog.path = path
+	parsedCatalog.info = info
+	parsedCatalog.catalog = copyCatalog(c)
+}
+
+// invalidateParsedCatalog forgets the parsed catalog when the catalog file is modified
+func invalidateParsedCatalog() {
+	parsedCatalog.Lock()
+	defer parsedCatalog.Unlock()
+	parsedCatalog.catalog = nil
+}
+
+// copyCatalog copies the indexes of the catalog so that the callers
+// can modify them without affecting the parsed catalog
+func copyCatalog(c *Catalog) *Catalog {
+	cp := &Catalog{
+		PluginInfos:       c.PluginInfos,
+		IndexByPath:       make(map[string]cli.PluginInfo, len(c.IndexByPath)),
+		IndexByName:       make(map[string][]string, len(c.IndexByName)),
+		StandAlonePlugins: make(PluginAssociation, len(c.StandAlonePlugins)),
+		ServerPlugins:     make(map[string]PluginAssociation, len(c.ServerPlugins)),
+		ContextPlugins:    make(map[string][]string, len(c.ContextPlugins)),
+		PluginRoot:        c.PluginRoot,
+	}
+	for path := range c.IndexByPath {
+		cp.IndexByPath[path] = c.IndexByPath[path]
+	}
+	for name, paths := range c.IndexByName {
+		cp.IndexByName[name] = append([]string(nil), paths...)
+	}
+	for key, path := range c.StandAlonePlugins {
+		cp.StandAlonePlugins[key] = path
+	}
+	for server, plugins := range c.ServerPlugins {
+		association := make(PluginAssociation, len(plugins))
+		for key, path := range plugins {
+			association[key] = path
+		}
+		cp.ServerPlugins[server] = association
+	}
+	for context, pluginKeys := range c.ContextPlugins {
+		cp.ContextPlugins[context] = append([]string(nil), pluginKeys...)
+	}
+	return cp
+}
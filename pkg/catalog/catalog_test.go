@@ -4,8 +4,11 @@
 package catalog
 
 import (
+	"bytes"
 	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -198,3 +201,145 @@ func Test_ContextCatalog_With_Context(t *testing.T) {
 	pd, exists = cc3.Get("fakeplugin1")
 	assert.False(exists)
 }
+
+func TestRelocatePluginRoot(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := os.MkdirTemp("", "test-catalog-relocate")
+	assert.Nil(err)
+	defer os.RemoveAll(dir)
+	common.DefaultCacheDir = dir
+	common.DefaultPluginRoot = filepath.Join(dir, "plugins")
+
+	cc, err := NewContextCatalogUpdater("")
+	assert.Nil(err)
+	assert.Nil(cc.Upsert(&cli.PluginInfo{
+		Name:             "fakeplugin1",
+		InstallationPath: "/old/root/fakeplugin1/v1.0.0",
+		Version:          "1.0.0",
+	}))
+	assert.Nil(cc.Upsert(&cli.PluginInfo{
+		Name:             "fakeplugin2",
+		InstallationPath: "/other/root/fakeplugin2/v2.0.0",
+		Version:          "2.0.0",
+	}))
+	cc.Unlock()
+
+	assert.Nil(RelocatePluginRoot("/old/root", "/new/root"))
+
+	cc2, err := NewContextCatalog("")
+	assert.Nil(err)
+	pd, exists := cc2.Get("fakeplugin1")
+	assert.True(exists)
+	assert.Equal(filepath.Join("/new/root", "fakeplugin1", "v1.0.0"), pd.InstallationPath)
+
+	// Plugins outside the old plugin root are not modified
+	pd, exists = cc2.Get("fakeplugin2")
+	assert.True(exists)
+	assert.Equal("/other/root/fakeplugin2/v2.0.0", pd.InstallationPath)
+}
+
+func TestMigratePluginInstallMetadataIfNeeded(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := os.MkdirTemp("", "test-catalog-migrate")
+	assert.Nil(err)
+	defer os.RemoveAll(dir)
+	common.DefaultCacheDir = dir
+	common.DefaultPluginRoot = filepath.Join(dir, "plugins")
+
+	digest := "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+	pluginPath := filepath.Join(common.DefaultPluginRoot, "fakeplugin1", "v1.0.0_"+digest+"_global")
+	assert.Nil(os.MkdirAll(filepath.Dir(pluginPath), 0755))
+	assert.Nil(os.WriteFile(pluginPath, []byte("binary"), 0755))
+	modTime := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	assert.Nil(os.Chtimes(pluginPath, modTime, modTime))
+
+	installedAt := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	cc, err := NewContextCatalogUpdater("")
+	assert.Nil(err)
+	assert.Nil(cc.Upsert(&cli.PluginInfo{
+		Name:             "fakeplugin1",
+		InstallationPath: pluginPath,
+		Version:          "v1.0.0",
+	}))
+	assert.Nil(cc.Upsert(&cli.PluginInfo{
+		Name:             "fakeplugin2",
+		InstallationPath: "/other/root/fakeplugin2/v2.0.0",
+		Version:          "v2.0.0",
+		Digest:           "recorded",
+		InstalledAt:      &installedAt,
+	}))
+	cc.Unlock()
+
+	MigratePluginInstallMetadataIfNeeded()
+
+	cc2, err := NewContextCatalog("")
+	assert.Nil(err)
+	pd, exists := cc2.Get("fakeplugin1")
+	assert.True(exists)
+	assert.Equal(digest, pd.Digest)
+	assert.NotNil(pd.InstalledAt)
+	assert.True(modTime.Equal(*pd.InstalledAt))
+
+	// Recorded metadata is not modified
+	pd, exists = cc2.Get("fakeplugin2")
+	assert.True(exists)
+	assert.Equal("recorded", pd.Digest)
+	assert.True(installedAt.Equal(*pd.InstalledAt))
+}
+
+func TestParsedCatalogIsReused(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := os.MkdirTemp("", "test-catalog-parsed")
+	assert.Nil(err)
+	defer os.RemoveAll(dir)
+	common.DefaultCacheDir = dir
+	common.DefaultPluginRoot = filepath.Join(dir, "plugins")
+
+	cc, err := NewContextCatalogUpdater("")
+	assert.Nil(err)
+	assert.Nil(cc.Upsert(&cli.PluginInfo{Name: "fakeplugin1", InstallationPath: "/path/fakeplugin1", Version: "v1.0.0"}))
+	cc.Unlock()
+
+	// The parsed catalog is reused while the catalog file does not change
+	c1, _, err := Store{}.getCatalogCache(false)
+	assert.Nil(err)
+	c2, _, err := Store{}.getCatalogCache(false)
+	assert.Nil(err)
+	assert.Equal(c1, c2)
+
+	// Modifying a read catalog does not affect the parsed catalog
+	delete(c1.StandAlonePlugins, PluginNameTarget("fakeplugin1", ""))
+	c3, _, err := Store{}.getCatalogCache(false)
+	assert.Nil(err)
+	assert.Len(c3.StandAlonePlugins, 1)
+
+	// A modification of the catalog is seen by the next read
+	cc, err = NewContextCatalogUpdater("")
+	assert.Nil(err)
+	assert.Nil(cc.Upsert(&cli.PluginInfo{Name: "fakeplugin2", InstallationPath: "/path/fakeplugin2", Version: "v1.0.0"}))
+	cc.Unlock()
+
+	reader, err := NewContextCatalog("")
+	assert.Nil(err)
+	assert.Len(reader.List(), 2)
+
+	// A replacement of the catalog file is seen by the next read even if
+	// the new file has the same modification time and size
+	catalogPath := Store{}.getCatalogCachePath()
+	stat, err := os.Stat(catalogPath)
+	assert.Nil(err)
+	b, err := os.ReadFile(catalogPath)
+	assert.Nil(err)
+	newCatalogPath := catalogPath + ".new"
+	assert.Nil(os.WriteFile(newCatalogPath, bytes.ReplaceAll(b, []byte("fakeplugin2"), []byte("fakeplugin3")), 0644))
+	assert.Nil(os.Chtimes(newCatalogPath, stat.ModTime(), stat.ModTime()))
+	assert.Nil(os.Rename(newCatalogPath, catalogPath))
+
+	reader, err = NewContextCatalog("")
+	assert.Nil(err)
+	_, exists := reader.Get(PluginNameTarget("fakeplugin3", ""))
+	assert.True(exists)
+}
@@ -48,6 +48,11 @@ type Catalog struct {
 	StandAlonePlugins PluginAssociation `json:"standAlonePlugins,omitempty" yaml:"standAlonePlugins,omitempty"`
 	// ServerPlugins links a server and a set of associated plugin installations.
 	ServerPlugins map[string]PluginAssociation `json:"serverPlugins,omitempty" yaml:"serverPlugins,omitempty"`
+	// ContextPlugins links a context and the plugins installed because the context recommended them,
+	// identified by their name and target, so that they can be uninstalled when the context is deleted.
+	ContextPlugins map[string][]string `json:"contextPlugins,omitempty" yaml:"contextPlugins,omitempty"`
+	// PluginRoot is the plugin root directory that was active when the catalog was last saved.
+	PluginRoot string `json:"pluginRoot,omitempty" yaml:"pluginRoot,omitempty"`
 }
 
 // CatalogList contains a list of Catalog
@@ -4,8 +4,15 @@
 package catalog
 
 import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
 	configlib "github.com/vmware-tanzu/tanzu-plugin-runtime/config"
 	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
 )
 
 // DeleteIncorrectPluginEntriesFromCatalog deletes the old plugin entries associated with
@@ -14,11 +21,12 @@ import (
 // where we allow plugins to be installed when target value is different even if target
 // values of “(empty), `global` and `kubernetes` can correspond to same root level command
 func DeleteIncorrectPluginEntriesFromCatalog() {
-	c, lockedFile, err := getCatalogCache(true)
+	s := Store{}
+	c, lock, err := s.getCatalogCache(true)
 	if err != nil {
 		return
 	}
-	defer lockedFile.Close()
+	defer lock.Unlock()
 
 	pluginAssociations := []PluginAssociation{c.StandAlonePlugins}
 	for _, spa := range c.ServerPlugins {
@@ -43,7 +51,7 @@ func DeleteIncorrectPluginEntriesFromCatalog() {
 		}
 	}
 
-	_ = saveCatalogCache(c, lockedFile)
+	_ = s.saveCatalogCache(c, lock)
 }
 
 // MigrateContextPluginsAsStandaloneIfNeeded updates the catalog cache to move all the
@@ -52,16 +60,28 @@ func DeleteIncorrectPluginEntriesFromCatalog() {
 // This is to ensure backwards compatibility when user migrates from pre v1.3 version of
 // the CLI, the context-scoped plugins are still gets shown as installed
 func MigrateContextPluginsAsStandaloneIfNeeded() {
+	Store{}.MigrateContextPluginsAsStandaloneIfNeeded()
+}
+
+// MigrateContextPluginsAsStandaloneIfNeeded migrates the context-scoped plugins of the
+// catalog of the store as done by MigrateContextPluginsAsStandaloneIfNeeded
+func (s Store) MigrateContextPluginsAsStandaloneIfNeeded() {
 	activeContexts, err := configlib.GetAllActiveContextsList()
 	if err != nil || len(activeContexts) == 0 {
 		return
 	}
 
-	c, lockedFile, err := getCatalogCache(true)
+	// Avoid locking and rewriting the catalog when no migration is needed
+	c, _, err := s.getCatalogCache(false)
+	if err != nil || !hasContextPlugins(c, activeContexts) {
+		return
+	}
+
+	c, lock, err := s.getCatalogCache(true)
 	if err != nil {
 		return
 	}
-	defer lockedFile.Close()
+	defer lock.Unlock()
 
 	for _, ac := range activeContexts {
 		for pluginKey, installPath := range c.ServerPlugins[ac] {
@@ -69,5 +89,131 @@ func MigrateContextPluginsAsStandaloneIfNeeded() {
 		}
 		delete(c.ServerPlugins, ac)
 	}
-	_ = saveCatalogCache(c, lockedFile)
+	_ = s.saveCatalogCache(c, lock)
+}
+
+// hasContextPlugins returns true if the catalog has plugins associated with any of the contexts
+func hasContextPlugins(c *Catalog, contexts []string) bool {
+	for _, ctx := range contexts {
+		if _, exists := c.ServerPlugins[ctx]; exists {
+			return true
+		}
+	}
+	return false
+}
+
+// pluginBinaryNameRegexp matches the name of the plugin binaries installed by the CLI,
+// which is <version>_<sha256 digest>_<target>
+var pluginBinaryNameRegexp = regexp.MustCompile(`^[^_]+_([a-f0-9]{64})_[^_]+$`)
+
+// MigratePluginInstallMetadataIfNeeded updates the catalog cache to record the
+// digest and installation time of the plugins installed by older versions of the
+// CLI, which did not record them. The digest is obtained from the name of the
+// installed binary and the installation time from its modification time.
+// The image the plugin was installed from cannot be recovered and is left empty.
+func MigratePluginInstallMetadataIfNeeded() {
+	Store{}.MigratePluginInstallMetadataIfNeeded()
+}
+
+// MigratePluginInstallMetadataIfNeeded records the missing installation metadata of the
+// plugins of the catalog of the store as done by MigratePluginInstallMetadataIfNeeded
+func (s Store) MigratePluginInstallMetadataIfNeeded() {
+	// Avoid locking the catalog when no migration is needed
+	c, _, err := s.getCatalogCache(false)
+	if err != nil || !migratePluginInstallMetadata(c) {
+		return
+	}
+
+	c, lock, err := s.getCatalogCache(true)
+	if err != nil {
+		return
+	}
+	defer lock.Unlock()
+
+	if migratePluginInstallMetadata(c) {
+		_ = s.saveCatalogCache(c, lock)
+	}
+}
+
+// migratePluginInstallMetadata records the missing installation metadata of the
+// plugins of the catalog and returns true if any plugin was updated
+func migratePluginInstallMetadata(c *Catalog) bool {
+	updated := false
+	for path, pluginInfo := range c.IndexByPath {
+		if pluginInfo.Digest != "" && pluginInfo.InstalledAt != nil {
+			continue
+		}
+		changed := false
+		if pluginInfo.Digest == "" {
+			name := strings.TrimSuffix(filepath.Base(pluginInfo.InstallationPath), ".exe")
+			if matches := pluginBinaryNameRegexp.FindStringSubmatch(name); matches != nil {
+				pluginInfo.Digest = matches[1]
+				changed = true
+			}
+		}
+		if pluginInfo.InstalledAt == nil {
+			if info, err := os.Stat(pluginInfo.InstallationPath); err == nil {
+				installedAt := info.ModTime().UTC()
+				pluginInfo.InstalledAt = &installedAt
+				changed = true
+			}
+		}
+		if changed {
+			c.IndexByPath[path] = pluginInfo
+			updated = true
+		}
+	}
+	return updated
+}
+
+// RelocatePluginRoot updates the catalog cache so that the plugins installed under the
+// old plugin root directory now point to the same plugins under the new plugin root directory.
+// This must be called after the plugin root directory has been moved.
+func RelocatePluginRoot(oldRoot, newRoot string) error {
+	s := Store{}
+	c, lock, err := s.getCatalogCache(true)
+	if err != nil {
+		return err
+	}
+	defer lock.Unlock()
+
+	relocate := func(path string) string {
+		rel, err := filepath.Rel(oldRoot, path)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			// Not installed under the old plugin root
+			return path
+		}
+		return filepath.Join(newRoot, rel)
+	}
+
+	indexByPath := make(map[string]cli.PluginInfo, len(c.IndexByPath))
+	for path, pluginInfo := range c.IndexByPath {
+		pluginInfo.InstallationPath = relocate(pluginInfo.InstallationPath)
+		indexByPath[relocate(path)] = pluginInfo
+	}
+	c.IndexByPath = indexByPath
+
+	for name, paths := range c.IndexByName {
+		for i := range paths {
+			paths[i] = relocate(paths[i])
+		}
+		c.IndexByName[name] = paths
+	}
+	for _, pluginInfo := range c.PluginInfos {
+		if pluginInfo != nil {
+			pluginInfo.InstallationPath = relocate(pluginInfo.InstallationPath)
+		}
+	}
+
+	pluginAssociations := []PluginAssociation{c.StandAlonePlugins}
+	for _, spa := range c.ServerPlugins {
+		pluginAssociations = append(pluginAssociations, spa)
+	}
+	for _, pa := range pluginAssociations {
+		for key, path := range pa {
+			pa[key] = relocate(path)
+		}
+	}
+
+	return s.saveCatalogCache(c, lock)
 }
@@ -0,0 +1,149 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package catalog
+
+import (
+	"slices"
+)
+
+// AddContextPlugins records that the plugins, identified by their name and target,
+// were installed because the context recommended them
+func AddContextPlugins(context string, pluginKeys []string) error {
+	return Store{}.AddContextPlugins(context, pluginKeys)
+}
+
+// AddContextPlugins records the plugins installed because the context recommended them
+// in the catalog of the store
+func (s Store) AddContextPlugins(context string, pluginKeys []string) error {
+	if len(pluginKeys) == 0 {
+		return nil
+	}
+	c, lock, err := s.getCatalogCache(true)
+	if err != nil {
+		return err
+	}
+	defer lock.Unlock()
+
+	for _, key := range pluginKeys {
+		if !slices.Contains(c.ContextPlugins[context], key) {
+			c.ContextPlugins[context] = append(c.ContextPlugins[context], key)
+		}
+	}
+	return s.saveCatalogCache(c, lock)
+}
+
+// IsContextPlugin returns true if the plugin, identified by its name and target,
+// was installed because a context recommended it
+func IsContextPlugin(pluginKey string) bool {
+	return Store{}.IsContextPlugin(pluginKey)
+}
+
+// IsContextPlugin returns true if the catalog of the store records the plugin as
+// installed because a context recommended it
+func (s Store) IsContextPlugin(pluginKey string) bool {
+	c, _, err := s.getCatalogCache(false)
+	if err != nil {
+		return false
+	}
+	for _, pluginKeys := range c.ContextPlugins {
+		if slices.Contains(pluginKeys, pluginKey) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetOrphanedContextPlugins returns the plugins, identified by their name and target,
+// installed because the context recommended them and not recorded for any other context
+func GetOrphanedContextPlugins(context string) ([]string, error) {
+	return Store{}.GetOrphanedContextPlugins(context)
+}
+
+// GetOrphanedContextPlugins returns the orphaned plugins of the context recorded
+// in the catalog of the store
+func (s Store) GetOrphanedContextPlugins(context string) ([]string, error) {
+	c, _, err := s.getCatalogCache(false)
+	if err != nil {
+		return nil, err
+	}
+	return orphanedContextPlugins(c, context), nil
+}
+
+// RemoveContextPlugins forgets the plugins recorded for the context and returns the
+// plugins, identified by their name and target, not recorded for any other context
+func RemoveContextPlugins(context string) ([]string, error) {
+	return Store{}.RemoveContextPlugins(context)
+}
+
+// RemoveContextPlugins forgets the plugins recorded for the context in the catalog
+// of the store and returns the ones not recorded for any other context
+func (s Store) RemoveContextPlugins(context string) ([]string, error) {
+	// Avoid locking and rewriting the catalog when nothing is recorded for the context
+	c, _, err := s.getCatalogCache(false)
+	if err != nil {
+		return nil, err
+	}
+	if _, exists := c.ContextPlugins[context]; !exists {
+		return nil, nil
+	}
+
+	c, lock, err := s.getCatalogCache(true)
+	if err != nil {
+		return nil, err
+	}
+	defer lock.Unlock()
+
+	orphaned := orphanedContextPlugins(c, context)
+	delete(c.ContextPlugins, context)
+	return orphaned, s.saveCatalogCache(c, lock)
+}
+
+// ForgetContextPlugins forgets that the plugins, identified by their name and target,
+// were installed because contexts recommended them
+func ForgetContextPlugins(pluginKeys []string) error {
+	return Store{}.ForgetContextPlugins(pluginKeys)
+}
+
+// ForgetContextPlugins forgets the plugins recorded for any context in the catalog of the store
+func (s Store) ForgetContextPlugins(pluginKeys []string) error {
+	// Avoid locking and rewriting the catalog when none of the plugins is recorded
+	if !slices.ContainsFunc(pluginKeys, s.IsContextPlugin) {
+		return nil
+	}
+
+	c, lock, err := s.getCatalogCache(true)
+	if err != nil {
+		return err
+	}
+	defer lock.Unlock()
+
+	for context, keys := range c.ContextPlugins {
+		keys = slices.DeleteFunc(keys, func(key string) bool {
+			return slices.Contains(pluginKeys, key)
+		})
+		if len(keys) == 0 {
+			delete(c.ContextPlugins, context)
+		} else {
+			c.ContextPlugins[context] = keys
+		}
+	}
+	return s.saveCatalogCache(c, lock)
+}
+
+func orphanedContextPlugins(c *Catalog, context string) []string {
+	var orphaned []string
+	for _, key := range c.ContextPlugins[context] {
+		recordedForOtherContext := false
+		for otherContext, pluginKeys := range c.ContextPlugins {
+			if otherContext != context && slices.Contains(pluginKeys, key) {
+				recordedForOtherContext = true
+				break
+			}
+		}
+		if !recordedForOtherContext {
+			orphaned = append(orphaned, key)
+		}
+	}
+	return orphaned
+}
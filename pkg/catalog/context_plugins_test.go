@@ -0,0 +1,66 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package catalog
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/common"
+)
+
+func TestContextPlugins(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := os.MkdirTemp("", "test-catalog-context-plugins")
+	assert.Nil(err)
+	defer os.RemoveAll(dir)
+	common.DefaultCacheDir = dir
+	common.DefaultPluginRoot = filepath.Join(dir, "plugins")
+
+	assert.Nil(AddContextPlugins("ctx1", []string{"cluster_kubernetes", "apps_kubernetes"}))
+	assert.Nil(AddContextPlugins("ctx2", []string{"apps_kubernetes"}))
+	// Recording a plugin twice has no effect
+	assert.Nil(AddContextPlugins("ctx2", []string{"apps_kubernetes"}))
+
+	assert.True(IsContextPlugin("cluster_kubernetes"))
+	assert.True(IsContextPlugin("apps_kubernetes"))
+	assert.False(IsContextPlugin("builder_global"))
+
+	// The plugins shared with another context are not orphaned
+	orphaned, err := GetOrphanedContextPlugins("ctx1")
+	assert.Nil(err)
+	assert.Equal([]string{"cluster_kubernetes"}, orphaned)
+
+	orphaned, err = RemoveContextPlugins("ctx1")
+	assert.Nil(err)
+	assert.Equal([]string{"cluster_kubernetes"}, orphaned)
+	assert.False(IsContextPlugin("cluster_kubernetes"))
+
+	// Once the other contexts are deleted, the shared plugins are orphaned
+	orphaned, err = RemoveContextPlugins("ctx2")
+	assert.Nil(err)
+	assert.Equal([]string{"apps_kubernetes"}, orphaned)
+	assert.False(IsContextPlugin("apps_kubernetes"))
+
+	// A plugin installed explicitly is forgotten for all the contexts
+	assert.Nil(AddContextPlugins("ctx3", []string{"cluster_kubernetes", "apps_kubernetes"}))
+	assert.Nil(AddContextPlugins("ctx4", []string{"cluster_kubernetes"}))
+	assert.Nil(ForgetContextPlugins([]string{"cluster_kubernetes"}))
+	assert.False(IsContextPlugin("cluster_kubernetes"))
+	orphaned, err = RemoveContextPlugins("ctx3")
+	assert.Nil(err)
+	assert.Equal([]string{"apps_kubernetes"}, orphaned)
+	orphaned, err = RemoveContextPlugins("ctx4")
+	assert.Nil(err)
+	assert.Empty(orphaned)
+
+	// Nothing is recorded for an unknown context
+	orphaned, err = RemoveContextPlugins("unknown")
+	assert.Nil(err)
+	assert.Empty(orphaned)
+}
@@ -0,0 +1,108 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fakes
+
+import (
+	"sync"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/catalog"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
+)
+
+type PluginSupplier struct {
+	GetInstalledPluginsStub        func() ([]*cli.PluginInfo, error)
+	getInstalledPluginsMutex       sync.RWMutex
+	getInstalledPluginsArgsForCall []struct {
+	}
+	getInstalledPluginsReturns struct {
+		result1 []*cli.PluginInfo
+		result2 error
+	}
+	getInstalledPluginsReturnsOnCall map[int]struct {
+		result1 []*cli.PluginInfo
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *PluginSupplier) GetInstalledPlugins() ([]*cli.PluginInfo, error) {
+	fake.getInstalledPluginsMutex.Lock()
+	ret, specificReturn := fake.getInstalledPluginsReturnsOnCall[len(fake.getInstalledPluginsArgsForCall)]
+	fake.getInstalledPluginsArgsForCall = append(fake.getInstalledPluginsArgsForCall, struct {
+	}{})
+	stub := fake.GetInstalledPluginsStub
+	fakeReturns := fake.getInstalledPluginsReturns
+	fake.recordInvocation("GetInstalledPlugins", []interface{}{})
+	fake.getInstalledPluginsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *PluginSupplier) GetInstalledPluginsCallCount() int {
+	fake.getInstalledPluginsMutex.RLock()
+	defer fake.getInstalledPluginsMutex.RUnlock()
+	return len(fake.getInstalledPluginsArgsForCall)
+}
+
+func (fake *PluginSupplier) GetInstalledPluginsCalls(stub func() ([]*cli.PluginInfo, error)) {
+	fake.getInstalledPluginsMutex.Lock()
+	defer fake.getInstalledPluginsMutex.Unlock()
+	fake.GetInstalledPluginsStub = stub
+}
+
+func (fake *PluginSupplier) GetInstalledPluginsReturns(result1 []*cli.PluginInfo, result2 error) {
+	fake.getInstalledPluginsMutex.Lock()
+	defer fake.getInstalledPluginsMutex.Unlock()
+	fake.GetInstalledPluginsStub = nil
+	fake.getInstalledPluginsReturns = struct {
+		result1 []*cli.PluginInfo
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *PluginSupplier) GetInstalledPluginsReturnsOnCall(i int, result1 []*cli.PluginInfo, result2 error) {
+	fake.getInstalledPluginsMutex.Lock()
+	defer fake.getInstalledPluginsMutex.Unlock()
+	fake.GetInstalledPluginsStub = nil
+	if fake.getInstalledPluginsReturnsOnCall == nil {
+		fake.getInstalledPluginsReturnsOnCall = make(map[int]struct {
+			result1 []*cli.PluginInfo
+			result2 error
+		})
+	}
+	fake.getInstalledPluginsReturnsOnCall[i] = struct {
+		result1 []*cli.PluginInfo
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *PluginSupplier) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.getInstalledPluginsMutex.RLock()
+	defer fake.getInstalledPluginsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *PluginSupplier) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ catalog.PluginSupplier = new(PluginSupplier)
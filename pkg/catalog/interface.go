@@ -8,12 +8,16 @@ import (
 	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
 )
 
+//go:generate counterfeiter -o ./fakes/plugin_supplier_fake.go --fake-name PluginSupplier . PluginSupplier
+
 // PluginSupplier is responsible for keeping an inventory of installed plugins
 type PluginSupplier interface {
 	// GetInstalledPlugins returns a list of installed plugins
 	GetInstalledPlugins() ([]*cli.PluginInfo, error)
 }
 
+//go:generate counterfeiter -o ./fakes/plugin_catalog_fake.go --fake-name PluginCatalog . PluginCatalogUpdater
+
 // PluginCatalogUpdater is the interface to read and update a collection of installed plugins.
 type PluginCatalogUpdater interface {
 	PluginCatalogReader
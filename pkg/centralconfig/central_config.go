@@ -5,8 +5,11 @@
 package centralconfig
 
 import (
+	"os"
 	"path/filepath"
 
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/config"
+
 	"github.com/vmware-tanzu/tanzu-cli/pkg/common"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
 )
@@ -61,5 +64,37 @@ func newCentralConfigReader(pluginDiscoveryName string) CentralConfig {
 //
 // Note: This function is currently private because the pre-initialized `DefaultCentralConfigReader` object should be used instead.
 func newDefaultCentralConfigReader() CentralConfig {
-	return newCentralConfigReader("default")
+	reader := newCentralConfigReader("default").(*centralConfigYamlReader)
+	// Only the default central configuration can be overridden locally
+	reader.withOverride = true
+	return reader
+}
+
+// getCentralConfigOverrideFilePath returns the path of the file whose values take precedence
+// over the values of the default central configuration.  This allows users or administrators
+// to adjust the central configuration without having to publish a new plugin inventory.
+// The path can be specified using the TANZU_CLI_CENTRAL_CONFIG_OVERRIDE_FILE environment variable.
+func getCentralConfigOverrideFilePath() string {
+	if overrideFile := os.Getenv(constants.ConfigVariableCentralConfigOverrideFile); overrideFile != "" {
+		return overrideFile
+	}
+	localDir, err := config.LocalDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(localDir, constants.CentralConfigOverrideFileName)
+}
+
+// GetDefaultCentralConfigContent returns all the entries of the default central configuration,
+// including the locally overridden ones.  It is mostly useful for debugging.  If there is no central configuration, an empty map is returned.
+func GetDefaultCentralConfigContent() (map[string]interface{}, error) {
+	reader := newDefaultCentralConfigReader().(*centralConfigYamlReader)
+	content, err := reader.parseConfigFile()
+	if err != nil {
+		return nil, err
+	}
+	if content == nil {
+		content = map[string]interface{}{}
+	}
+	return content, nil
 }
@@ -17,6 +17,12 @@ var (
 	// For testing, it can be overridden using the environment variable TANZU_CLI_PLUGIN_DB_CACHE_TTL_SECONDS.
 	DefaultInventoryRefreshTTLSeconds = 30 * 60 // 30 minutes
 
+	// DefaultCentralConfigRefreshTTLSeconds is the interval in seconds after which the central configuration
+	// of a discovery source is automatically refreshed.
+	// It can be overridden using the environment variable TANZU_CLI_CENTRAL_CONFIG_REFRESH_TTL_SECONDS,
+	// where 0 disables the automatic refresh.
+	DefaultCentralConfigRefreshTTLSeconds = 24 * 60 * 60 // 24 hours
+
 	defaultSaaSEndpoints = []string{
 		"https://(www.)?platform(.)*.tanzu.broadcom.com",
 		"https://api.tanzu(.)*.cloud.vmware.com",
@@ -29,18 +35,15 @@ var (
 
 func init() {
 	// initialize the value of `DefaultTanzuPlatformEndpoint` from default central configuration
-	endpoint, _ := DefaultCentralConfigReader.GetDefaultTanzuEndpoint()
-	if endpoint != "" {
+	if endpoint := GetString(KeyDefaultTanzuEndpoint, ""); endpoint != "" {
 		DefaultTanzuPlatformEndpoint = endpoint
 	}
 	// initialize the value of `DefaultPluginDBCacheRefreshThresholdSeconds` from default central configuration if specified there
-	secondsThreshold, err := DefaultCentralConfigReader.GetPluginDBCacheRefreshThresholdSeconds()
-	if err == nil && secondsThreshold > 0 {
+	if secondsThreshold := GetInt(KeyDefaultPluginDBCacheRefreshThresholdSeconds, 0); secondsThreshold > 0 {
 		DefaultPluginDBCacheRefreshThresholdSeconds = secondsThreshold
 	}
 	// initialize the value of `DefaultInventoryRefreshTTLSeconds` from default central configuration if specified there
-	secondsTTL, err := DefaultCentralConfigReader.GetInventoryRefreshTTLSeconds()
-	if err == nil && secondsTTL > 0 {
+	if secondsTTL := GetInt(KeyDefaultInventoryRefreshTTLSeconds, 0); secondsTTL > 0 {
 		DefaultInventoryRefreshTTLSeconds = secondsTTL
 	}
 }
@@ -12,4 +12,8 @@ const (
 	KeyTanzuPlatformSaaSEndpointsAsRegularExpression = "cli.core.tanzu_cli_platform_saas_endpoints_as_regular_expression"
 	KeyTanzuConfigEndpointUpdateVersion              = "cli.core.tanzu_cli_config_endpoint_update_version"
 	KeyTanzuConfigEndpointUpdateMapping              = "cli.core.tanzu_cli_config_endpoint_update_mapping"
+	KeyDefaultPluginDiscoveryImage                   = "cli.core.tanzu_cli_default_plugin_discovery_image"
+	KeyContextRecommendedPlugins                     = "cli.core.tanzu_cli_context_recommended_plugins"
+	KeyPluginVendorPolicy                            = "cli.core.tanzu_cli_plugin_vendor_policy"
+	KeyPluginInstallationPolicy                      = "cli.core.tanzu_cli_plugin_installation_policy"
 )
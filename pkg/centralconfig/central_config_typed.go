@@ -0,0 +1,94 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package centralconfig
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
+)
+
+// The functions of this file provide typed access to the entries of the default
+// central configuration.  They never fail: if a key is missing or if its value
+// cannot be converted to the requested type, the provided default value is returned.
+// This allows consumers to use the central configuration as a best-effort source
+// of configuration without having to deal with errors.
+
+// GetString returns the value of the given key from the default central configuration
+// as a string, or defaultValue if the key is missing or is not a scalar.
+func GetString(key, defaultValue string) string {
+	value := ""
+	if !getEntry(key, &value) {
+		return defaultValue
+	}
+	return value
+}
+
+// GetBool returns the value of the given key from the default central configuration
+// as a boolean, or defaultValue if the key is missing or is not a boolean.
+func GetBool(key string, defaultValue bool) bool {
+	value := ""
+	if !getEntry(key, &value) {
+		return defaultValue
+	}
+	b, err := strconv.ParseBool(strings.TrimSpace(value))
+	if err != nil {
+		log.V(7).Infof("central configuration key '%s' is not a boolean: %v", key, err)
+		return defaultValue
+	}
+	return b
+}
+
+// GetInt returns the value of the given key from the default central configuration
+// as an integer, or defaultValue if the key is missing or is not an integer.
+// Values published as strings (e.g. "86400") are also accepted.
+func GetInt(key string, defaultValue int) int {
+	value := ""
+	if !getEntry(key, &value) {
+		return defaultValue
+	}
+	i, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil {
+		log.V(7).Infof("central configuration key '%s' is not an integer: %v", key, err)
+		return defaultValue
+	}
+	return i
+}
+
+// GetStringSlice returns the value of the given key from the default central configuration
+// as a list of strings, or defaultValue if the key is missing or is not a list.
+func GetStringSlice(key string, defaultValue []string) []string {
+	var value []string
+	if !getEntry(key, &value) {
+		return defaultValue
+	}
+	return value
+}
+
+// GetObject unmarshals the value of the given key from the default central configuration
+// into out, which must be a non-nil pointer.  If the key is missing, out is not modified
+// so any value already set in it acts as the default. It returns true if the key was
+// found and properly unmarshalled.
+func GetObject(key string, out interface{}) bool {
+	return getEntry(key, out)
+}
+
+// getEntry reads the given key from the default central configuration into out.
+// Missing keys are expected and are therefore not logged as errors.
+func getEntry(key string, out interface{}) bool {
+	err := DefaultCentralConfigReader.GetCentralConfigEntry(key, out)
+	if err == nil {
+		return true
+	}
+
+	var keyNotFoundError *KeyNotFoundError
+	if errors.As(err, &keyNotFoundError) {
+		log.V(7).Infof("central configuration key '%s' not found, using default value", key)
+	} else {
+		log.V(7).Infof("unable to read central configuration key '%s', using default value: %v", key, err)
+	}
+	return false
+}
@@ -0,0 +1,79 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package centralconfig
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/common"
+)
+
+func TestTypedAccessors(t *testing.T) {
+	cfgContent := `
+string.key: a value
+bool.key: true
+bool.string.key: "false"
+int.key: 30
+int.string.key: "86400"
+invalid.int.key: thirty
+slice.key:
+  - first
+  - second
+object.key:
+  ucp: https://ucp.example.com
+  tmc: https://tmc.example.com
+`
+	dir, err := os.MkdirTemp("", "test-central-config")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	originalCacheDir := common.DefaultCacheDir
+	originalReader := DefaultCentralConfigReader
+	defer func() {
+		common.DefaultCacheDir = originalCacheDir
+		DefaultCentralConfigReader = originalReader
+	}()
+	common.DefaultCacheDir = dir
+	DefaultCentralConfigReader = newCentralConfigReader("default")
+
+	path := DefaultCentralConfigReader.(*centralConfigYamlReader).configFile
+	assert.Nil(t, os.MkdirAll(filepath.Dir(path), 0755))
+	assert.Nil(t, os.WriteFile(path, []byte(cfgContent), 0644))
+
+	assert.Equal(t, "a value", GetString("string.key", "default"))
+	assert.Equal(t, "default", GetString("missing.key", "default"))
+	assert.Equal(t, "default", GetString("slice.key", "default"))
+
+	assert.True(t, GetBool("bool.key", false))
+	assert.False(t, GetBool("bool.string.key", true))
+	assert.True(t, GetBool("string.key", true))
+	assert.True(t, GetBool("missing.key", true))
+
+	assert.Equal(t, 30, GetInt("int.key", 5))
+	assert.Equal(t, 86400, GetInt("int.string.key", 5))
+	assert.Equal(t, 5, GetInt("invalid.int.key", 5))
+	assert.Equal(t, 5, GetInt("missing.key", 5))
+
+	assert.Equal(t, []string{"first", "second"}, GetStringSlice("slice.key", nil))
+	assert.Equal(t, []string{"default"}, GetStringSlice("missing.key", []string{"default"}))
+	assert.Equal(t, []string{"default"}, GetStringSlice("object.key", []string{"default"}))
+
+	endpoints := ServiceEndpointMap{HubEndpoint: "https://hub.example.com"}
+	assert.True(t, GetObject("object.key", &endpoints))
+	assert.Equal(t, "https://ucp.example.com", endpoints.UCPEndpoint)
+	assert.Equal(t, "https://tmc.example.com", endpoints.TMCEndpoint)
+
+	endpoints = ServiceEndpointMap{HubEndpoint: "https://hub.example.com"}
+	assert.False(t, GetObject("missing.key", &endpoints))
+	assert.Equal(t, ServiceEndpointMap{HubEndpoint: "https://hub.example.com"}, endpoints)
+
+	// A missing central configuration file is not an error either
+	assert.Nil(t, os.Remove(path))
+	assert.Equal(t, "default", GetString("string.key", "default"))
+	assert.Equal(t, 5, GetInt("int.key", 5))
+}
@@ -17,3 +17,73 @@ type ServiceEndpointMap struct {
 	// HubEndpoint is the endpoint for the Tanzu Hub service.
 	HubEndpoint string `yaml:"hub"`
 }
+
+// ContextTypeToRecommendedPluginsMap maps a context type to the plugins recommended to every context of that type.
+type ContextTypeToRecommendedPluginsMap map[string]ContextRecommendedPlugins
+
+// ContextRecommendedPlugins represents the plugins and plugin groups recommended to every context of a
+// given type, in addition to the plugins recommended by the context itself.
+type ContextRecommendedPlugins struct {
+	// Plugins are the recommended plugins.
+	Plugins []ContextRecommendedPlugin `yaml:"plugins"`
+	// Groups are the recommended plugin groups, as vendor-publisher/name[:version], whose mandatory plugins are recommended.
+	Groups []string `yaml:"groups"`
+}
+
+// ContextRecommendedPlugin represents a plugin recommended to every context of a given type.
+type ContextRecommendedPlugin struct {
+	// Name is the name of the plugin.
+	Name string `yaml:"name"`
+	// Target is the target of the plugin, which defaults to the target associated with the context type.
+	Target string `yaml:"target"`
+	// Version is the version of the plugin, which defaults to its recommended version.
+	Version string `yaml:"version"`
+}
+
+// PluginVendorPolicy restricts the plugins which can be installed from the plugin repositories.
+// The entries are vendor-publisher/name patterns which can contain '*' wildcards, e.g.
+// "vmware-tkg/*" or "vmware-*"; an omitted publisher or name matches any publisher or name.
+type PluginVendorPolicy struct {
+	// Allow lists the only plugins which can be installed.  All plugins are allowed if it is empty.
+	Allow []string `yaml:"allow"`
+	// Deny lists the plugins which cannot be installed, even if they match the allow list.
+	Deny []string `yaml:"deny"`
+}
+
+// PluginInstallationPolicy restricts the plugin versions which can be installed, to prevent
+// installing versions which are too old or which are known to be defective.
+type PluginInstallationPolicy struct {
+	// Action is either "warn", to only print a warning, or "block", the default, to prevent
+	// installing the plugin versions which do not comply with the policy.
+	Action string `yaml:"action"`
+	// MaxVersionsBehind is the maximum number of more recent versions a plugin version can have.
+	// The age of a plugin version is measured in versions as the inventory does not record when
+	// a version was published.  There is no limit if it is 0.
+	MaxVersionsBehind int `yaml:"maxVersionsBehind"`
+	// MinimumVersions are the minimum versions of some plugins.
+	MinimumVersions []PluginMinimumVersion `yaml:"minimumVersions"`
+	// RevokedVersions are plugin versions which should no longer be installed, e.g. because of a CVE.
+	RevokedVersions []PluginRevokedVersions `yaml:"revokedVersions"`
+}
+
+// PluginMinimumVersion represents the minimum version of a plugin.
+type PluginMinimumVersion struct {
+	// Name is the name of the plugin.
+	Name string `yaml:"name"`
+	// Target is the target of the plugin; all the targets are concerned if it is empty.
+	Target string `yaml:"target"`
+	// Version is the minimum version of the plugin.
+	Version string `yaml:"version"`
+}
+
+// PluginRevokedVersions represents versions of a plugin which should no longer be installed.
+type PluginRevokedVersions struct {
+	// Name is the name of the plugin.
+	Name string `yaml:"name"`
+	// Target is the target of the plugin; all the targets are concerned if it is empty.
+	Target string `yaml:"target"`
+	// Versions are the revoked versions of the plugin.
+	Versions []string `yaml:"versions"`
+	// Reason explains why the versions are revoked, e.g. a CVE identifier.
+	Reason string `yaml:"reason"`
+}
@@ -5,9 +5,12 @@
 package centralconfig
 
 import (
+	"crypto/sha256"
 	"fmt"
 	"os"
 	"reflect"
+	"sync"
+	"time"
 
 	"gopkg.in/yaml.v3"
 )
@@ -21,9 +24,22 @@ func (e *KeyNotFoundError) Error() string {
 	return fmt.Sprintf("key '%s' not found in central config", e.Key)
 }
 
+// racyModTimeWindow is the time window after a modification of a yaml file
+// during which its modification time cannot be trusted to detect a further modification,
+// because file systems only store the modification time with a limited granularity.
+const racyModTimeWindow = 2 * time.Second
+
 type centralConfigYamlReader struct {
 	// configFile is the path to the central config file.
 	configFile string
+	// withOverride indicates if the values of the central config override file
+	// take precedence over the values of the central config file.
+	withOverride bool
+
+	// The parsed content of the files is cached so that reading multiple keys
+	// does not re-read and re-parse the files every time.
+	configCache   yamlFileCache
+	overrideCache yamlFileCache
 }
 
 // Make sure centralConfigYamlReader implements CentralConfig
@@ -32,24 +48,105 @@ var _ CentralConfig = &centralConfigYamlReader{}
 // parseConfigFile reads the central config file and returns the parsed yaml content.
 // If the file does not exist, it does not return an error because some central repositories
 // may choose not to have a central config file.
+// If enabled, the values of the override file take precedence over the ones of the central config file.
 func (c *centralConfigYamlReader) parseConfigFile() (map[string]interface{}, error) {
-	// Check if the central config file exists.
-	if _, err := os.Stat(c.configFile); os.IsNotExist(err) {
-		// The central config file is optional, don't return an error if it does not exist.
-		return nil, nil
+	content, err := c.configCache.parse(c.configFile)
+	if err != nil || !c.withOverride {
+		return content, err
 	}
 
-	bytes, err := os.ReadFile(c.configFile)
+	overrideFile := getCentralConfigOverrideFilePath()
+	overrides, err := c.overrideCache.parse(overrideFile)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("invalid central configuration override file '%s': %v", overrideFile, err)
+	}
+	if len(overrides) == 0 {
+		return content, nil
 	}
 
-	var content map[string]interface{}
-	err = yaml.Unmarshal(bytes, &content)
+	// Don't modify the cached content
+	merged := make(map[string]interface{}, len(content)+len(overrides))
+	for key, value := range content {
+		merged[key] = value
+	}
+	for key, value := range overrides {
+		merged[key] = value
+	}
+	return merged, nil
+}
+
+// yamlFileCache caches the parsed content of a yaml file.
+// The cache is invalidated when the file changes.
+type yamlFileCache struct {
+	mutex         sync.Mutex
+	cachedValues  map[string]interface{}
+	cachedModTime time.Time
+	cachedSize    int64
+	cachedDigest  [sha256.Size]byte
+	cachedAt      time.Time
+}
+
+// parse reads the specified yaml file and returns the parsed content.
+// If the file does not exist, it does not return an error.
+// The parsed content is cached and is only parsed again if the file has changed.
+func (f *yamlFileCache) parse(path string) (map[string]interface{}, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	// Check if the file exists.
+	info, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		// The file is optional, don't return an error if it does not exist.
+		f.invalidate()
+		return nil, nil
+	}
+
+	// If the file has not been modified since it was parsed, use the cached content without
+	// reading the file. A file modified just before it was parsed could be modified again
+	// without its modification time changing, so in that case the digest must be verified.
+	if err == nil && f.cachedValues != nil &&
+		info.ModTime().Equal(f.cachedModTime) && info.Size() == f.cachedSize &&
+		f.cachedAt.Sub(f.cachedModTime) > racyModTimeWindow {
+		return f.cachedValues, nil
+	}
+
+	bytes, err := os.ReadFile(path)
 	if err != nil {
+		f.invalidate()
 		return nil, err
 	}
-	return content, nil
+
+	digest := sha256.Sum256(bytes)
+	if f.cachedValues == nil || digest != f.cachedDigest {
+		var content map[string]interface{}
+		err = yaml.Unmarshal(bytes, &content)
+		if err != nil {
+			f.invalidate()
+			return nil, err
+		}
+		if content == nil {
+			// Cache an empty file as an empty map to distinguish it from no cache
+			content = map[string]interface{}{}
+		}
+		f.cachedValues = content
+		f.cachedDigest = digest
+	}
+
+	if info != nil {
+		f.cachedModTime = info.ModTime()
+		f.cachedSize = info.Size()
+	}
+	f.cachedAt = time.Now()
+	return f.cachedValues, nil
+}
+
+// invalidate clears the cached content
+func (f *yamlFileCache) invalidate() {
+	f.cachedValues = nil
+	f.cachedModTime = time.Time{}
+	f.cachedSize = 0
+	f.cachedDigest = [sha256.Size]byte{}
+	f.cachedAt = time.Time{}
 }
 
 func (c *centralConfigYamlReader) GetCentralConfigEntry(key string, out interface{}) error {
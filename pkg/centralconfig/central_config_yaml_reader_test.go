@@ -13,6 +13,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/vmware-tanzu/tanzu-cli/pkg/common"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
 )
 
 var (
@@ -331,3 +332,96 @@ func TestGetCentralConfigEntry(t *testing.T) {
 		})
 	}
 }
+
+func TestCentralConfigCache(t *testing.T) {
+	dir, err := os.MkdirTemp("", "test-central-config")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	common.DefaultCacheDir = dir
+	reader := newCentralConfigReader("my_discovery").(*centralConfigYamlReader)
+	assert.Nil(t, os.MkdirAll(filepath.Dir(reader.configFile), 0755))
+
+	// The file is parsed on first access
+	assert.Nil(t, os.WriteFile(reader.configFile, []byte("testKey: value1"), 0644))
+	value := ""
+	assert.Nil(t, reader.GetCentralConfigEntry("testKey", &value))
+	assert.Equal(t, "value1", value)
+
+	// A modification of the file is detected even if the size and modification time did not change
+	info, err := os.Stat(reader.configFile)
+	assert.Nil(t, err)
+	assert.Nil(t, os.WriteFile(reader.configFile, []byte("testKey: value2"), 0644))
+	assert.Nil(t, os.Chtimes(reader.configFile, info.ModTime(), info.ModTime()))
+	assert.Nil(t, reader.GetCentralConfigEntry("testKey", &value))
+	assert.Equal(t, "value2", value)
+
+	// Once the modification time is old enough, the cached content is used without reading the file
+	oldTime := time.Now().Add(-time.Hour)
+	assert.Nil(t, os.Chtimes(reader.configFile, oldTime, oldTime))
+	assert.Nil(t, reader.GetCentralConfigEntry("testKey", &value))
+	assert.Equal(t, "value2", value)
+	assert.True(t, reader.configCache.cachedAt.Sub(reader.configCache.cachedModTime) > racyModTimeWindow)
+
+	// Changing the file content without changing its size or modification time is not
+	// detected on purpose, proving that the cache is used
+	assert.Nil(t, os.WriteFile(reader.configFile, []byte("testKey: value3"), 0644))
+	assert.Nil(t, os.Chtimes(reader.configFile, oldTime, oldTime))
+	assert.Nil(t, reader.GetCentralConfigEntry("testKey", &value))
+	assert.Equal(t, "value2", value)
+
+	// A change of modification time invalidates the cache
+	assert.Nil(t, os.Chtimes(reader.configFile, time.Now(), time.Now()))
+	assert.Nil(t, reader.GetCentralConfigEntry("testKey", &value))
+	assert.Equal(t, "value3", value)
+
+	// Removing the file invalidates the cache
+	assert.Nil(t, os.Remove(reader.configFile))
+	err = reader.GetCentralConfigEntry("testKey", &value)
+	assert.NotNil(t, err)
+	assert.Nil(t, reader.configCache.cachedValues)
+}
+
+func TestCentralConfigOverride(t *testing.T) {
+	dir, err := os.MkdirTemp("", "test-central-config")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	common.DefaultCacheDir = dir
+	overrideFile := filepath.Join(dir, "override.yaml")
+	os.Setenv(constants.ConfigVariableCentralConfigOverrideFile, overrideFile)
+	defer os.Unsetenv(constants.ConfigVariableCentralConfigOverrideFile)
+
+	reader := newDefaultCentralConfigReader().(*centralConfigYamlReader)
+	assert.Nil(t, os.MkdirAll(filepath.Dir(reader.configFile), 0755))
+	assert.Nil(t, os.WriteFile(reader.configFile, []byte("key1: central1\nkey2: central2"), 0644))
+
+	// Without an override file, the central config values are used
+	value := ""
+	assert.Nil(t, reader.GetCentralConfigEntry("key1", &value))
+	assert.Equal(t, "central1", value)
+
+	// The values of the override file take precedence
+	assert.Nil(t, os.WriteFile(overrideFile, []byte("key1: override1\nkey3: override3"), 0644))
+	assert.Nil(t, reader.GetCentralConfigEntry("key1", &value))
+	assert.Equal(t, "override1", value)
+	assert.Nil(t, reader.GetCentralConfigEntry("key2", &value))
+	assert.Equal(t, "central2", value)
+	assert.Nil(t, reader.GetCentralConfigEntry("key3", &value))
+	assert.Equal(t, "override3", value)
+
+	// The override file is used even without a central config file
+	assert.Nil(t, os.Remove(reader.configFile))
+	assert.Nil(t, reader.GetCentralConfigEntry("key1", &value))
+	assert.Equal(t, "override1", value)
+	assert.NotNil(t, reader.GetCentralConfigEntry("key2", &value))
+
+	// An invalid override file is reported
+	assert.Nil(t, os.WriteFile(overrideFile, []byte("key1: [invalid"), 0644))
+	err = reader.GetCentralConfigEntry("key1", &value)
+	assert.ErrorContains(t, err, "invalid central configuration override file")
+
+	// Non-default central configurations cannot be overridden
+	reader = newCentralConfigReader("my_discovery").(*centralConfigYamlReader)
+	assert.False(t, reader.withOverride)
+}
@@ -14,7 +14,11 @@ var (
 	MinOSArch = []Arch{LinuxAMD64, DarwinAMD64, WinAMD64}
 
 	// AllOSArch defines all OS/ARCH combinations for which plugins can be built
-	AllOSArch = []Arch{LinuxAMD64, DarwinAMD64, WinAMD64, LinuxARM64, DarwinARM64, WinARM64}
+	AllOSArch = []Arch{LinuxAMD64, DarwinAMD64, WinAMD64, LinuxARM64, DarwinARM64, WinARM64, LinuxPPC64LE}
+
+	// UniversalOSArch defines the OS/ARCH combinations of universal binaries, which combine
+	// the binaries of multiple architectures of the same OS into a single file
+	UniversalOSArch = []Arch{DarwinUniversal}
 
 	// GOOS is the current go os.  Defaults to runtime.GOOS but could be overridden.
 	// The CLI code should always this variable instead of runtime.GOOS.
@@ -46,6 +50,11 @@ func (a Arch) IsWindows() bool {
 	return false
 }
 
+// IsUniversal tells if an arch is a universal binary combining multiple architectures.
+func (a Arch) IsUniversal() bool {
+	return a.Arch() == UniversalArch
+}
+
 // OS returns os-name based on the arch
 func (a Arch) OS() string {
 	ele := strings.Split(a.String(), "_")
@@ -76,6 +85,8 @@ const (
 	LinuxAMD64 Arch = "linux_amd64"
 	// LinuxARM64 arch.
 	LinuxARM64 Arch = "linux_arm64"
+	// LinuxPPC64LE arch.
+	LinuxPPC64LE Arch = "linux_ppc64le"
 	// DarwinAMD64 arch.
 	DarwinAMD64 Arch = "darwin_amd64"
 	// DarwinARM64 arch.
@@ -86,4 +97,9 @@ const (
 	WinAMD64 Arch = "windows_amd64"
 	// WinARM64 arch.
 	WinARM64 Arch = "windows_arm64"
+	// DarwinUniversal arch combining the darwin amd64 and arm64 binaries.
+	DarwinUniversal Arch = "darwin_universal"
+
+	// UniversalArch is the architecture name used for universal binaries.
+	UniversalArch = "universal"
 )
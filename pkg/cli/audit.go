@@ -0,0 +1,140 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
+)
+
+// AuditLogSyslog is the value of the audit log configuration which sends
+// the audit records to the system logger instead of a file
+const AuditLogSyslog = "syslog"
+
+// redactedValue replaces the values of the arguments which may hold secrets
+const redactedValue = "REDACTED"
+
+// sensitiveFlagNames are the fragments of flag names whose values are redacted
+// from the audit log
+var sensitiveFlagNames = []string{"password", "passwd", "token", "secret", "api-key", "apikey", "credential", "private-key"}
+
+// AuditRecord describes one invocation of a plugin
+type AuditRecord struct {
+	Time       time.Time `json:"time"`
+	Plugin     string    `json:"plugin"`
+	Version    string    `json:"version"`
+	Target     string    `json:"target,omitempty"`
+	Args       []string  `json:"args"`
+	ExitCode   int       `json:"exitCode"`
+	DurationMs int64     `json:"durationMs"`
+	Error      string    `json:"error,omitempty"`
+}
+
+// auditPluginInvocation records the invocation of the plugin in the audit log configured by
+// the TANZU_CLI_AUDIT_LOG variable, if any.  Failing to record the invocation does not fail
+// the command, but a warning is printed as the audit log is then incomplete.
+func auditPluginInvocation(p *PluginInfo, args []string, start time.Time, runErr error) {
+	destination := os.Getenv(constants.ConfigVariableAuditLog)
+	if destination == "" {
+		return
+	}
+
+	record := &AuditRecord{
+		Time:       start.UTC(),
+		Plugin:     p.Name,
+		Version:    p.Version,
+		Target:     string(p.Target),
+		Args:       RedactArgs(args),
+		DurationMs: time.Since(start).Milliseconds(),
+	}
+	if runErr != nil {
+		var exitErr *exec.ExitError
+		if errors.As(runErr, &exitErr) {
+			record.ExitCode = exitErr.ExitCode()
+		} else {
+			// The plugin could not be started
+			record.ExitCode = -1
+			record.Error = runErr.Error()
+		}
+	}
+
+	if err := writeAuditRecord(destination, record); err != nil {
+		log.Warningf("unable to record the invocation of plugin '%s' in the audit log: %v", p.Name, err)
+	}
+}
+
+func writeAuditRecord(destination string, record *AuditRecord) error {
+	b, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	if destination == AuditLogSyslog {
+		return writeAuditRecordToSyslog(string(b))
+	}
+
+	if err := os.MkdirAll(filepath.Dir(destination), 0o700); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(destination, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	// Writing the whole line at once keeps the records of concurrent invocations from interleaving
+	_, err = f.Write(append(b, '\n'))
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// RedactArgs returns a copy of the arguments where the values of the flags
+// whose name suggests they hold a secret, e.g. --password, are redacted
+func RedactArgs(args []string) []string {
+	redacted := make([]string, len(args))
+	redactNext := false
+	for i, arg := range args {
+		if redactNext && !strings.HasPrefix(arg, "-") {
+			redacted[i] = redactedValue
+			redactNext = false
+			continue
+		}
+		redactNext = false
+		redacted[i] = arg
+
+		if !strings.HasPrefix(arg, "-") || arg == "--" {
+			continue
+		}
+		name, _, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		if !isSensitiveFlag(name) {
+			continue
+		}
+		if hasValue {
+			redacted[i] = fmt.Sprintf("%s=%s", arg[:strings.Index(arg, "=")], redactedValue)
+		} else {
+			redactNext = true
+		}
+	}
+	return redacted
+}
+
+func isSensitiveFlag(name string) bool {
+	name = strings.ToLower(name)
+	for _, sensitive := range sensitiveFlagNames {
+		if strings.Contains(name, sensitive) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,18 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !windows
+
+package cli
+
+import "log/syslog"
+
+// writeAuditRecordToSyslog sends the audit record to the system logger
+func writeAuditRecordToSyslog(record string) error {
+	w, err := syslog.New(syslog.LOG_INFO|syslog.LOG_USER, "tanzu")
+	if err != nil {
+		return err
+	}
+	defer w.Close()
+	return w.Info(record)
+}
@@ -0,0 +1,106 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/plugin"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
+)
+
+func TestRedactArgs(t *testing.T) {
+	tests := []struct {
+		args     []string
+		expected []string
+	}{
+		{
+			args:     []string{"list", "-o", "json"},
+			expected: []string{"list", "-o", "json"},
+		},
+		{
+			args:     []string{"login", "--password", "s3cr3t", "--username", "me"},
+			expected: []string{"login", "--password", "REDACTED", "--username", "me"},
+		},
+		{
+			args:     []string{"login", "--api-token=s3cr3t", "--endpoint=https://example.com"},
+			expected: []string{"login", "--api-token=REDACTED", "--endpoint=https://example.com"},
+		},
+		{
+			args:     []string{"create", "--Client-Secret", "s3cr3t", "name"},
+			expected: []string{"create", "--Client-Secret", "REDACTED", "name"},
+		},
+		{
+			// A boolean flag followed by another flag does not redact it
+			args:     []string{"login", "--stdin-password", "--endpoint", "https://example.com"},
+			expected: []string{"login", "--stdin-password", "--endpoint", "https://example.com"},
+		},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, RedactArgs(tt.args))
+	}
+}
+
+func TestAuditPluginInvocation(t *testing.T) {
+	assert := assert.New(t)
+
+	dir := t.TempDir()
+	path, err := setupFakePlugin(dir, "fakefoo", "exit 3")
+	assert.Nil(err)
+
+	auditLog := filepath.Join(dir, "audit", "audit.jsonl")
+	t.Setenv(constants.ConfigVariableAuditLog, auditLog)
+
+	pi := &PluginInfo{
+		Name:             "fakefoo",
+		Description:      "Fake foo",
+		Version:          "v1.2.3",
+		Target:           configtypes.TargetK8s,
+		Group:            plugin.SystemCmdGroup,
+		InstallationPath: path,
+	}
+	cmd := GetCmdForPlugin(pi)
+	cmd.SetArgs([]string{"login", "--password=s3cr3t"})
+	assert.NotNil(cmd.Execute())
+
+	pi.InstallationPath = filepath.Join(dir, "missing")
+	cmd = GetCmdForPlugin(pi)
+	cmd.SetArgs([]string{"list"})
+	assert.NotNil(cmd.Execute())
+
+	f, err := os.Open(auditLog)
+	assert.Nil(err)
+	defer f.Close()
+
+	var records []AuditRecord
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var record AuditRecord
+		assert.Nil(json.Unmarshal(scanner.Bytes(), &record))
+		records = append(records, record)
+	}
+	assert.Len(records, 2)
+
+	assert.Equal("fakefoo", records[0].Plugin)
+	assert.Equal("v1.2.3", records[0].Version)
+	assert.Equal(string(configtypes.TargetK8s), records[0].Target)
+	assert.Equal([]string{"login", "--password=REDACTED"}, records[0].Args)
+	assert.Equal(3, records[0].ExitCode)
+	assert.Empty(records[0].Error)
+	assert.False(records[0].Time.IsZero())
+
+	// The plugin could not be started
+	assert.Equal([]string{"list"}, records[1].Args)
+	assert.Equal(-1, records[1].ExitCode)
+	assert.Contains(records[1].Error, "does not exist")
+}
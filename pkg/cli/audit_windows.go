@@ -0,0 +1,13 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build windows
+
+package cli
+
+import "errors"
+
+// writeAuditRecordToSyslog fails as there is no system logger on Windows
+func writeAuditRecordToSyslog(_ string) error {
+	return errors.New("the syslog audit log is not supported on Windows, please use a file instead")
+}
@@ -52,6 +52,9 @@ type Plugin struct {
 
 	// Versions available for plugin.
 	Versions []string `json:"versions" yaml:"versions"`
+
+	// PluginRuntimeVersion is the version of the Tanzu Plugin Runtime the plugin was built with.
+	PluginRuntimeVersion string `json:"pluginRuntimeVersion,omitempty" yaml:"pluginRuntimeVersion,omitempty"`
 }
 
 // PluginGroupManifest is used to parse metadata about Plugin Groups
@@ -10,6 +10,7 @@ import (
 	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
@@ -18,6 +19,9 @@ import (
 	"github.com/vmware-tanzu/tanzu-plugin-runtime/plugin"
 
 	"github.com/vmware-tanzu/tanzu-cli/pkg/common"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/plugindefaults"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/profiling"
 )
 
 // CommandMapProcessor process the plugin's command map to
@@ -183,14 +187,31 @@ func getCmdForPluginEx(p *PluginInfo, cmdName string, mapEntry *plugin.CommandMa
 		Use:   cmdName,
 		Short: description,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			runtimeWarning, err := VerifyPluginRuntimeVersion(p.Name, p.PluginRuntimeVersion)
+			if err != nil {
+				return err
+			}
+
+			warnShadowingPluginBinaries(p)
+
+			args = applyPluginDefaults(p.Name, args)
 			if len(srcHierarchy) > 0 {
 				args = append(srcHierarchy, args...)
 			}
 
 			runner := NewRunner(p.Name, p.InstallationPath, args)
 			ctx := context.Background()
-			setupPluginEnv(srcHierarchy, dstHierarchy)
-			return runner.Run(ctx)
+			setupPluginEnv(p.Name, srcHierarchy, dstHierarchy)
+			start := time.Now()
+			stopExecTracking := profiling.Track(profiling.PhasePluginExec)
+			err = runner.Run(ctx)
+			stopExecTracking()
+			auditPluginInvocation(p, args, start, err)
+			if err != nil && runtimeWarning != "" {
+				// Help understand failures which may be due to the version of the plugin runtime
+				log.Warning(runtimeWarning)
+			}
+			return err
 		},
 		DisableFlagParsing: true,
 		Annotations: map[string]string{
@@ -202,6 +223,11 @@ func getCmdForPluginEx(p *PluginInfo, cmdName string, mapEntry *plugin.CommandMa
 			// of the command within the plugin so that it can determine how
 			// to invoke this command directly from the plugin binary.
 			common.AnnotationForCmdSrcPath: strings.Join(srcHierarchy, " "),
+			// The root help uses the below annotations to group the plugin
+			// commands and to indicate which plugin provides each command.
+			common.AnnotationForPluginName:    p.Name,
+			common.AnnotationForPluginVersion: p.Version,
+			common.AnnotationForPluginTarget:  string(p.Target),
 		},
 		Hidden:  hidden,
 		Aliases: aliases,
@@ -221,7 +247,7 @@ func getCmdForPluginEx(p *PluginInfo, cmdName string, mapEntry *plugin.CommandMa
 
 		runner := NewRunner(p.Name, p.InstallationPath, completion)
 		ctx := context.Background()
-		setupPluginEnv(srcHierarchy, dstHierarchy)
+		setupPluginEnv(p.Name, srcHierarchy, dstHierarchy)
 		output, errOutput, err := runner.RunOutput(ctx)
 		if err != nil {
 			return nil, cobra.ShellCompDirectiveError
@@ -267,7 +293,7 @@ func getCmdForPluginEx(p *PluginInfo, cmdName string, mapEntry *plugin.CommandMa
 		// Pass this new command in to our plugin to have it handle help output
 		runner := NewRunner(p.Name, p.InstallationPath, helpArgs)
 		ctx := context.Background()
-		setupPluginEnv(srcHierarchy, dstHierarchy)
+		setupPluginEnv(p.Name, srcHierarchy, dstHierarchy)
 		err := runner.Run(ctx)
 		if err != nil {
 			log.Errorf("Help output for '%s' is not available.", c.Name())
@@ -276,6 +302,42 @@ func getCmdForPluginEx(p *PluginInfo, cmdName string, mapEntry *plugin.CommandMa
 	return cmd
 }
 
+// applyPluginDefaults sets the default environment variables configured for the plugin,
+// unless they are already set, and returns the arguments with the default flags configured
+// for the plugin inserted before the flags specified by the user, which take precedence.
+func applyPluginDefaults(pluginName string, args []string) []string {
+	defaults, err := plugindefaults.GetPluginDefaults(pluginName)
+	if err != nil {
+		log.V(6).Warningf("unable to read the defaults of plugin %q: %v", pluginName, err)
+		return args
+	}
+
+	for key, val := range defaults.Env {
+		if _, exists := os.LookupEnv(key); !exists {
+			os.Setenv(key, val)
+		}
+	}
+	if len(defaults.Flags) == 0 {
+		return args
+	}
+	return insertPluginDefaultFlags(args, defaults.Flags)
+}
+
+// insertPluginDefaultFlags inserts the default flags after the command path and the
+// positional arguments, before the first flag or "--" specified by the user.
+// The default flags must not come before a subcommand: a boolean flag would be taken
+// as having the subcommand as its value, and the plugin command would then reject the
+// flags that only the subcommand defines.
+func insertPluginDefaultFlags(args, defaultFlags []string) []string {
+	i := slices.IndexFunc(args, func(arg string) bool {
+		return strings.HasPrefix(arg, "-")
+	})
+	if i == -1 {
+		i = len(args)
+	}
+	return slices.Concat(args[:i], defaultFlags, args[i:])
+}
+
 // getHelpArguments extracts the command line to pass along to help calls.
 // The help function is only ever called for help commands in the format of
 // "tanzu help cmd", so we can assume anything two after "help" should get
@@ -300,12 +362,16 @@ func getHelpArguments() []string {
 
 // setupPluginEnv prepares some extra environment variables
 // that communicate certain information to plugins.
-func setupPluginEnv(srcHierarchy, dstHierarchy []string) {
+func setupPluginEnv(pluginName string, srcHierarchy, dstHierarchy []string) {
 	env := make(map[string]string, 10)
 
 	// The location of the tanzu binary
 	env["TANZU_BIN"] = os.Args[0]
 
+	// The namespace of the data store reserved to the plugin, which is used
+	// by default by the 'tanzu config datastore' commands run by the plugin
+	env[constants.DataStoreNamespace] = pluginName
+
 	// Information provided when command invocation is via a mapped command
 	numParts := len(dstHierarchy)
 	if numParts > 0 {
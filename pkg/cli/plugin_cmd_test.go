@@ -10,9 +10,11 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/vmware-tanzu/tanzu-cli/pkg/common"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/plugindefaults"
 	"github.com/vmware-tanzu/tanzu-plugin-runtime/plugin"
 )
 
@@ -51,11 +53,12 @@ func TestGetCmdForPlugin(t *testing.T) {
 	assert.Equal(pi.Aliases, cmd.Aliases)
 
 	annotations := cmd.Annotations
-	assert.Equal(5, len(annotations))
+	assert.Equal(8, len(annotations))
 	assert.Equal(string(pi.Group), annotations["group"])
 	assert.Equal(pi.Scope, annotations["scope"])
 	assert.Equal(common.CommandTypePlugin, annotations["type"])
 	assert.Equal(pi.InstallationPath, annotations["pluginInstallationPath"])
+	assert.Equal(pi.Name, annotations[common.AnnotationForPluginName])
 	// No remapping in this test
 	assert.Equal("", annotations[common.AnnotationForCmdSrcPath])
 }
@@ -103,7 +106,7 @@ func TestGetCmdForRemappedPlugin(t *testing.T) {
 	assert.False(cmd.Hidden)
 
 	annotations := cmd.Annotations
-	assert.Equal(5, len(annotations))
+	assert.Equal(8, len(annotations))
 	assert.Equal(string(pi.Group), annotations["group"])
 	assert.Equal(pi.Scope, annotations["scope"])
 	assert.Equal(common.CommandTypePlugin, annotations["type"])
@@ -119,7 +122,7 @@ func TestEnvForPlugin(t *testing.T) {
 	assert.Nil(err)
 	defer os.RemoveAll(dir)
 
-	path, err := setupFakePlugin(dir, "fakefoo", "echo $TANZU_BIN")
+	path, err := setupFakePlugin(dir, "fakefoo", "echo $TANZU_BIN $TANZU_CLI_DATA_STORE_NAMESPACE")
 	assert.Nil(err)
 
 	pi := &PluginInfo{
@@ -153,7 +156,130 @@ func TestEnvForPlugin(t *testing.T) {
 	w.Close()
 
 	got := <-c
-	assert.Equal(binaryPath+"\n", string(got))
+	assert.Equal(binaryPath+" fakefoo\n", string(got))
+}
+
+func TestPluginDefaultsForPlugin(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := os.MkdirTemp("", "tanzu-cli-getcmd")
+	assert.Nil(err)
+	defer os.RemoveAll(dir)
+
+	path, err := setupFakePlugin(dir, "fakefoo", `echo "$FAKE_FOO_ENV $FAKE_FOO_USER_ENV $@"`)
+	assert.Nil(err)
+
+	t.Setenv("TEST_CUSTOM_PLUGIN_DEFAULTS_FILE", filepath.Join(dir, "plugin-defaults.yaml"))
+	assert.Nil(plugindefaults.SetPluginDefaultFlags("fakefoo", []string{"--kubeconfig=/default/kubeconfig"}))
+	assert.Nil(plugindefaults.SetPluginDefaultEnv("fakefoo", map[string]string{
+		"FAKE_FOO_ENV":      "default",
+		"FAKE_FOO_USER_ENV": "default",
+	}))
+	// Variables already set in the environment take precedence
+	t.Setenv("FAKE_FOO_USER_ENV", "user")
+
+	pi := &PluginInfo{
+		Name:             "fakefoo",
+		Description:      "Fake foo",
+		Group:            plugin.SystemCmdGroup,
+		InstallationPath: path,
+	}
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Error(err)
+	}
+	c := make(chan []byte)
+	go readOutput(t, r, c)
+
+	stdout := os.Stdout
+	defer func() {
+		os.Stdout = stdout
+		os.Unsetenv("FAKE_FOO_ENV")
+	}()
+	os.Stdout = w
+
+	cmd := GetCmdForPlugin(pi)
+	cmd.SetArgs([]string{"list", "--kubeconfig=/user/kubeconfig"})
+	err = cmd.Execute()
+	assert.Nil(err)
+	w.Close()
+
+	got := <-c
+	// The default flags are passed before the flags of the user, which take precedence
+	assert.Equal("default user list --kubeconfig=/default/kubeconfig --kubeconfig=/user/kubeconfig\n", string(got))
+}
+
+func TestInsertPluginDefaultFlags(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		expected []string
+	}{
+		{
+			name:     "no arguments",
+			args:     nil,
+			expected: []string{"--dry-run", "--kubeconfig=/default"},
+		},
+		{
+			name:     "after the command path",
+			args:     []string{"cluster", "list"},
+			expected: []string{"cluster", "list", "--dry-run", "--kubeconfig=/default"},
+		},
+		{
+			name:     "after the positional arguments and before the flags of the user",
+			args:     []string{"get", "mycluster", "-o", "yaml", "--kubeconfig=/user"},
+			expected: []string{"get", "mycluster", "--dry-run", "--kubeconfig=/default", "-o", "yaml", "--kubeconfig=/user"},
+		},
+		{
+			name:     "before the arguments after --",
+			args:     []string{"exec", "--", "ls", "--all"},
+			expected: []string{"exec", "--dry-run", "--kubeconfig=/default", "--", "ls", "--all"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, insertPluginDefaultFlags(tt.args, []string{"--dry-run", "--kubeconfig=/default"}))
+		})
+	}
+}
+
+// TestPluginDefaultFlagsOfSubcommand verifies that a plugin built with cobra accepts
+// default flags only defined by a subcommand, including boolean flags
+func TestPluginDefaultFlagsOfSubcommand(t *testing.T) {
+	assert := assert.New(t)
+
+	var dryRun bool
+	var ran string
+	pluginCmd := &cobra.Command{
+		Use: "cluster",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ran = "cluster"
+			return nil
+		},
+	}
+	listCmd := &cobra.Command{
+		Use: "list",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ran = "list"
+			return nil
+		},
+	}
+	listCmd.Flags().BoolVar(&dryRun, "dry-run", false, "")
+	pluginCmd.AddCommand(listCmd)
+	pluginCmd.SetOut(io.Discard)
+	pluginCmd.SetErr(io.Discard)
+
+	pluginCmd.SetArgs(insertPluginDefaultFlags([]string{"list"}, []string{"--dry-run"}))
+	assert.Nil(pluginCmd.Execute())
+	assert.Equal("list", ran)
+	assert.True(dryRun)
+
+	// The flags of the user take precedence
+	pluginCmd.SetArgs(insertPluginDefaultFlags([]string{"list", "--dry-run=false"}, []string{"--dry-run"}))
+	assert.Nil(pluginCmd.Execute())
+	assert.Equal("list", ran)
+	assert.False(dryRun)
 }
 
 func TestGetTestCmdForPlugin(t *testing.T) {
@@ -4,6 +4,8 @@
 package cli
 
 import (
+	"time"
+
 	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
 	"github.com/vmware-tanzu/tanzu-plugin-runtime/plugin"
 )
@@ -25,6 +27,9 @@ type PluginInfo struct {
 	// Digest is the SHA256 hash of the plugin binary.
 	Digest string `json:"digest" yaml:"digest"`
 
+	// PluginRuntimeVersion is the version of the Tanzu Plugin Runtime the plugin was built with.
+	PluginRuntimeVersion string `json:"pluginRuntimeVersion,omitempty" yaml:"pluginRuntimeVersion,omitempty"`
+
 	// Command group for the plugin.
 	Group plugin.CmdGroup `json:"group" yaml:"group"`
 
@@ -56,6 +61,13 @@ type PluginInfo struct {
 	// this plugin is discovered.
 	Discovery string `json:"discovery" yaml:"discovery"`
 
+	// Image is the OCI image from which the plugin binary was installed.
+	// It is empty for plugins installed from a local source.
+	Image string `json:"image,omitempty" yaml:"image,omitempty"`
+
+	// InstalledAt is the time at which the plugin binary was installed.
+	InstalledAt *time.Time `json:"installedAt,omitempty" yaml:"installedAt,omitempty"`
+
 	// Scope is the scope of the plugin. Stand-Alone or Context
 	Scope string `json:"scope" yaml:"scope"`
 
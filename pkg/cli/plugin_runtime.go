@@ -0,0 +1,59 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"fmt"
+	"runtime/debug"
+
+	"golang.org/x/mod/semver"
+
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/plugin"
+)
+
+// SupportedPluginRuntimeVersion is the version of the Tanzu Plugin Runtime the CLI
+// is built with. Following the compatibility policy of the CLI, plugins built with
+// any older version, or with any patch version of the same minor version, are supported.
+var SupportedPluginRuntimeVersion = getPluginRuntimeVersion()
+
+// getPluginRuntimeVersion returns the version of the Tanzu Plugin Runtime module
+// the CLI is built with, or an empty string if it cannot be determined
+func getPluginRuntimeVersion() string {
+	buildInfo, ok := debug.ReadBuildInfo()
+	if !ok {
+		return ""
+	}
+	for _, dep := range buildInfo.Deps {
+		if dep.Path != plugin.PluginRuntimeModulePath {
+			continue
+		}
+		if dep.Replace != nil && semver.IsValid(dep.Replace.Version) {
+			return dep.Replace.Version
+		}
+		return dep.Version
+	}
+	return ""
+}
+
+// VerifyPluginRuntimeVersion verifies that a plugin built with the specified version of the
+// Tanzu Plugin Runtime is compatible with the CLI.
+// An error is returned if the plugin uses a newer major version of the Tanzu Plugin Runtime,
+// as the CLI cannot be expected to run it.  If the plugin uses a newer minor version, it is
+// supported but the features introduced by that version may not work, which is described by
+// the returned warning.  Unknown versions are considered compatible.
+func VerifyPluginRuntimeVersion(pluginName, pluginRuntimeVersion string) (warning string, err error) {
+	if !semver.IsValid(pluginRuntimeVersion) || !semver.IsValid(SupportedPluginRuntimeVersion) {
+		return "", nil
+	}
+
+	if semver.Compare(semver.Major(pluginRuntimeVersion), semver.Major(SupportedPluginRuntimeVersion)) > 0 {
+		return "", fmt.Errorf("plugin '%s' was built with Tanzu Plugin Runtime %s which is not supported by this version of the Tanzu CLI (supports up to %s.x). Please upgrade the Tanzu CLI to use this plugin",
+			pluginName, pluginRuntimeVersion, semver.MajorMinor(SupportedPluginRuntimeVersion))
+	}
+	if semver.Compare(semver.MajorMinor(pluginRuntimeVersion), semver.MajorMinor(SupportedPluginRuntimeVersion)) > 0 {
+		return fmt.Sprintf("plugin '%s' was built with Tanzu Plugin Runtime %s which is newer than the %s.x supported by this version of the Tanzu CLI; some of its features may not work. Please upgrade the Tanzu CLI if the plugin fails",
+			pluginName, pluginRuntimeVersion, semver.MajorMinor(SupportedPluginRuntimeVersion)), nil
+	}
+	return "", nil
+}
@@ -0,0 +1,74 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerifyPluginRuntimeVersion(t *testing.T) {
+	origVersion := SupportedPluginRuntimeVersion
+	defer func() { SupportedPluginRuntimeVersion = origVersion }()
+
+	tests := []struct {
+		name                 string
+		supportedVersion     string
+		pluginRuntimeVersion string
+		expectWarning        bool
+		expectedErr          string
+	}{
+		{
+			name:                 "unknown plugin runtime version",
+			supportedVersion:     "v1.4.7",
+			pluginRuntimeVersion: "",
+		},
+		{
+			name:                 "unknown supported plugin runtime version",
+			supportedVersion:     "",
+			pluginRuntimeVersion: "v2.0.0",
+		},
+		{
+			name:                 "older plugin runtime version",
+			supportedVersion:     "v1.4.7",
+			pluginRuntimeVersion: "v0.28.0",
+		},
+		{
+			name:                 "newer patch version of the plugin runtime",
+			supportedVersion:     "v1.4.7",
+			pluginRuntimeVersion: "v1.4.9",
+		},
+		{
+			name:                 "newer minor version of the plugin runtime",
+			supportedVersion:     "v1.4.7",
+			pluginRuntimeVersion: "v1.5.0",
+			expectWarning:        true,
+		},
+		{
+			name:                 "newer major version of the plugin runtime",
+			supportedVersion:     "v1.4.7",
+			pluginRuntimeVersion: "v2.0.0",
+			expectedErr:          "plugin 'foo' was built with Tanzu Plugin Runtime v2.0.0 which is not supported by this version of the Tanzu CLI (supports up to v1.4.x). Please upgrade the Tanzu CLI to use this plugin",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SupportedPluginRuntimeVersion = tt.supportedVersion
+
+			warning, err := VerifyPluginRuntimeVersion("foo", tt.pluginRuntimeVersion)
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			if tt.expectWarning {
+				assert.Contains(t, warning, "newer than the v1.4.x supported by this version of the Tanzu CLI")
+			} else {
+				assert.Empty(t, warning)
+			}
+		})
+	}
+}
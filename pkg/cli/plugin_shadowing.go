@@ -0,0 +1,60 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/common"
+)
+
+// FindShadowingPluginBinaries returns the binaries of the plugin left by older installations
+// of the CLI, which are not managed by the plugin catalog: the tanzu-plugin-<name> binary
+// found on the PATH and the binaries of the plugin in the plugin root directory used by
+// older versions of the CLI, if it is not the current one.  Running them by mistake, or
+// running an older CLI which uses them, gives the impression that the plugin was not upgraded.
+func FindShadowingPluginBinaries(p *PluginInfo) []string {
+	var binaries []string
+	if path, err := exec.LookPath(BinFromPluginName(p.Name)); err == nil {
+		binaries = append(binaries, path)
+	}
+
+	legacyRoot := common.LegacyPluginRootLocation()
+	if filepath.Clean(legacyRoot) == filepath.Clean(common.DefaultPluginRoot) ||
+		isPathInDir(p.InstallationPath, legacyRoot) {
+		// The plugins of the legacy plugin root are still managed by the catalog
+		return binaries
+	}
+	entries, err := os.ReadDir(filepath.Join(legacyRoot, p.Name))
+	if err != nil {
+		return binaries
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			binaries = append(binaries, filepath.Join(legacyRoot, p.Name, entry.Name()))
+		}
+	}
+	return binaries
+}
+
+// warnShadowingPluginBinaries prints a warning about the binaries of the plugin
+// left by older installations of the CLI along with how to remove them
+func warnShadowingPluginBinaries(p *PluginInfo) {
+	binaries := FindShadowingPluginBinaries(p)
+	if len(binaries) == 0 {
+		return
+	}
+	log.Warningf("Found binaries of the '%s' plugin from a previous installation of the CLI which are not used by this CLI: %s. "+
+		"Remove them to avoid running an outdated version of the plugin by mistake.", p.Name, strings.Join(binaries, ", "))
+}
+
+func isPathInDir(path, dir string) bool {
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
@@ -0,0 +1,50 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/adrg/xdg"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/common"
+)
+
+func TestFindShadowingPluginBinaries(t *testing.T) {
+	assert := assert.New(t)
+
+	originalDataHome, originalPluginRoot := xdg.DataHome, common.DefaultPluginRoot
+	defer func() {
+		xdg.DataHome, common.DefaultPluginRoot = originalDataHome, originalPluginRoot
+	}()
+	xdg.DataHome = t.TempDir()
+	common.DefaultPluginRoot = filepath.Join(t.TempDir(), "plugins")
+
+	pathDir := t.TempDir()
+	t.Setenv("PATH", pathDir)
+
+	p := &PluginInfo{Name: "foo", InstallationPath: filepath.Join(common.DefaultPluginRoot, "foo", "v1.0.0_abc_global")}
+	assert.Empty(FindShadowingPluginBinaries(p))
+
+	// A legacy binary on the PATH
+	pathBinary := filepath.Join(pathDir, BinFromPluginName("foo"))
+	if BuildArch().IsWindows() {
+		pathBinary += ".exe"
+	}
+	assert.Nil(os.WriteFile(pathBinary, []byte("binary"), 0o755))
+
+	// A binary in the legacy plugin root
+	legacyBinary := filepath.Join(common.LegacyPluginRootLocation(), "foo", "v0.9.0_def_global")
+	assert.Nil(os.MkdirAll(filepath.Dir(legacyBinary), 0o755))
+	assert.Nil(os.WriteFile(legacyBinary, []byte("binary"), 0o755))
+
+	assert.Equal([]string{pathBinary, legacyBinary}, FindShadowingPluginBinaries(p))
+
+	// The legacy plugin root is ignored while the catalog still uses it
+	p.InstallationPath = legacyBinary
+	assert.Equal([]string{pathBinary}, FindShadowingPluginBinaries(p))
+}
@@ -4,13 +4,18 @@
 package cli
 
 import (
+	"fmt"
 	"io"
-	"os"
+	"sort"
 	"text/template"
 
+	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 
 	"github.com/vmware-tanzu/tanzu-plugin-runtime/component"
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/common"
 )
 
 // CmdMap is the map of command groups to plugins
@@ -27,35 +32,43 @@ func NewMainUsage() *MainUsage {
 // UsageFunc generates a usage func for cobra.
 func (u *MainUsage) UsageFunc() func(*cobra.Command) error {
 	return func(c *cobra.Command) error {
-		return u.GenerateDescriptor(c, os.Stdout)
+		return u.GenerateDescriptor(c, c.OutOrStdout())
 	}
 }
 
-// GenerateDescriptor generates a descriptor
+// GenerateDescriptor generates a descriptor.
+// For the root command, the commands provided by plugins are listed
+// separately from the core commands and are grouped by the target and
+// scope of the plugin providing them.
 func (u *MainUsage) GenerateDescriptor(c *cobra.Command, w io.Writer) error {
 	cmdMap := CmdMap{}
+	pluginCmdMap := CmdMap{}
 	for _, cmd := range c.Commands() {
 		if cmd.Hidden || cmd.Deprecated != "" {
 			continue
 		}
+		if !c.HasParent() && cmd.Annotations["type"] == common.CommandTypePlugin {
+			group := pluginCommandGroup(cmd)
+			pluginCmdMap[group] = append(pluginCmdMap[group], cmd)
+			continue
+		}
 		group := cmd.Annotations["group"]
 		if group == "" {
 			continue
 		}
-		g, ok := cmdMap[group]
-		if !ok {
-			g = []*cobra.Command{}
-		}
-		g = append(g, cmd)
-		cmdMap[group] = g
+		cmdMap[group] = append(cmdMap[group], cmd)
 	}
+	cmdMap.sort()
+	pluginCmdMap.sort()
 
 	d := struct {
 		*cobra.Command
-		CmdMap CmdMap
+		CmdMap       CmdMap
+		PluginCmdMap CmdMap
 	}{
 		c,
 		cmdMap,
+		pluginCmdMap,
 	}
 
 	t := template.Must(template.New("usage").Funcs(TemplateFuncs).Parse(u.Template()))
@@ -66,6 +79,41 @@ func (u *MainUsage) GenerateDescriptor(c *cobra.Command, w io.Writer) error {
 	return nil
 }
 
+// sort orders the commands of each group by name.
+func (m CmdMap) sort() {
+	for _, cmds := range m {
+		sort.SliceStable(cmds, func(i, j int) bool {
+			return cmds[i].Name() < cmds[j].Name()
+		})
+	}
+}
+
+// pluginCommandGroup returns the name of the group under which a plugin
+// command is listed in the root help.
+func pluginCommandGroup(cmd *cobra.Command) string {
+	target := cmd.Annotations[common.AnnotationForPluginTarget]
+	if target == "" || target == string(configtypes.TargetUnknown) {
+		target = "unspecified target"
+	}
+	if cmd.Annotations["scope"] == common.PluginScopeContext {
+		return fmt.Sprintf("%s (context-scoped)", target)
+	}
+	return target
+}
+
+// pluginOrigin returns an indication of the plugin, and its version,
+// providing the command.
+func pluginOrigin(cmd *cobra.Command) string {
+	name := cmd.Annotations[common.AnnotationForPluginName]
+	if name == "" {
+		return ""
+	}
+	if version := cmd.Annotations[common.AnnotationForPluginVersion]; version != "" {
+		name = fmt.Sprintf("%s %s", name, version)
+	}
+	return color.New(color.Faint).Sprintf("[%s]", name)
+}
+
 // Template returns the template for the root cmd help as well
 // as for the two target commands ('kubernetes' and 'mission-control').
 // Those three commands use this template because they use command groups
@@ -83,7 +131,12 @@ func (u *MainUsage) Template() string {
 {{ bold "Available command groups:" }}
 {{ range $group, $cmds := .CmdMap}}
   {{ bold $group }}{{ range $cmd := $cmds }}
-    {{rpad $cmd.Name 24}}{{$cmd.Short}} {{end}}{{end}}{{end}}
+    {{rpad $cmd.Name 24}}{{$cmd.Short}} {{end}}{{end}}{{end}}{{if gt (len .PluginCmdMap) 0}}
+
+{{ bold "Available plugin commands:" }}
+{{ range $group, $cmds := .PluginCmdMap}}
+  {{ bold $group }}{{ range $cmd := $cmds }}
+    {{rpad $cmd.Name 24}}{{$cmd.Short}} {{pluginOrigin $cmd}}{{end}}{{end}}{{end}}
 
 {{ bold "Flags:" }}
 {{.LocalFlags.FlagUsages  | trimTrailingWhitespaces}}
@@ -98,7 +151,7 @@ var SubCmdUsageFunc = func(c *cobra.Command) error {
 	if err != nil {
 		return err
 	}
-	return t.Execute(os.Stdout, c)
+	return t.Execute(c.OutOrStdout(), c)
 }
 
 // SubCmdTemplate is the template for all core sub-commands.
@@ -134,4 +187,5 @@ var TemplateFuncs = template.FuncMap{
 	"underline":               component.Underline,
 	"trimTrailingWhitespaces": component.TrimRightSpace,
 	"beginsWith":              component.BeginsWith,
+	"pluginOrigin":            pluginOrigin,
 }
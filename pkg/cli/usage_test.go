@@ -4,11 +4,15 @@
 package cli
 
 import (
+	"bytes"
+	"strings"
 	"testing"
 
 	"github.com/logrusorgru/aurora"
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/require"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/common"
 )
 
 func TestGenerateDescriptor(t *testing.T) {
@@ -45,3 +49,81 @@ func TestSubcommandUsage(t *testing.T) {
 	err := SubCmdUsageFunc(c)
 	require.NoError(t, err)
 }
+
+func TestGenerateDescriptorPluginCommands(t *testing.T) {
+	m := MainUsage{}
+
+	c := &cobra.Command{
+		Use:   "tanzu",
+		Short: "Tanzu CLI",
+	}
+	coreCmd := &cobra.Command{
+		Use:         "version",
+		Short:       "Version information",
+		Annotations: map[string]string{"group": "System"},
+		Run:         func(cmd *cobra.Command, args []string) {},
+	}
+	newPluginCmd := func(name, target, scope string) *cobra.Command {
+		return &cobra.Command{
+			Use:   name,
+			Short: name + " commands",
+			Annotations: map[string]string{
+				"group":                           "Run",
+				"type":                            common.CommandTypePlugin,
+				"scope":                           scope,
+				common.AnnotationForPluginName:    name + "-plugin",
+				common.AnnotationForPluginVersion: "v1.2.3",
+				common.AnnotationForPluginTarget:  target,
+			},
+			Run: func(cmd *cobra.Command, args []string) {},
+		}
+	}
+	c.AddCommand(
+		coreCmd,
+		newPluginCmd("zeta", "global", ""),
+		newPluginCmd("alpha", "global", ""),
+		newPluginCmd("cluster", "kubernetes", common.PluginScopeContext),
+	)
+
+	var out bytes.Buffer
+	err := m.GenerateDescriptor(c, &out)
+	require.NoError(t, err)
+
+	help := out.String()
+	require.Contains(t, help, "Available command groups:")
+	require.Contains(t, help, "Available plugin commands:")
+	require.Contains(t, help, "global")
+	require.Contains(t, help, "kubernetes (context-scoped)")
+	require.Contains(t, help, "[cluster-plugin v1.2.3]")
+
+	// Plugin commands are not listed among the core command groups
+	require.NotContains(t, help, "Run")
+
+	// Commands of a group are sorted by name
+	require.Less(t, strings.Index(help, "alpha"), strings.Index(help, "zeta"))
+	require.Less(t, strings.Index(help, "Available command groups:"), strings.Index(help, "Available plugin commands:"))
+}
+
+func TestGenerateDescriptorSubCommandKeepsGroups(t *testing.T) {
+	m := MainUsage{}
+
+	root := &cobra.Command{Use: "tanzu"}
+	target := &cobra.Command{Use: "mission-control"}
+	root.AddCommand(target)
+	target.AddCommand(&cobra.Command{
+		Use:   "cluster",
+		Short: "cluster commands",
+		Annotations: map[string]string{
+			"group":                        "Run",
+			"type":                         common.CommandTypePlugin,
+			common.AnnotationForPluginName: "cluster",
+		},
+		Run: func(cmd *cobra.Command, args []string) {},
+	})
+
+	var out bytes.Buffer
+	err := m.GenerateDescriptor(target, &out)
+	require.NoError(t, err)
+	require.Contains(t, out.String(), "Available command groups:\n\n  Run\n    cluster                 cluster commands \n\nFlags:")
+	require.NotContains(t, out.String(), "Available plugin commands:")
+}
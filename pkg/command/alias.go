@@ -0,0 +1,277 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kballard/go-shellquote"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/component"
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/plugin"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/commandalias"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/utils"
+)
+
+func newAliasCmd() *cobra.Command {
+	var aliasCmd = &cobra.Command{
+		Use:   "alias",
+		Short: "Manage the aliases of commands",
+		Long: "Manage the aliases of commands, which shorten the invocation of nested commands. " +
+			"For example, after 'tanzu alias add pc plugin clean', 'tanzu pc' runs 'tanzu plugin clean'. " +
+			"An alias is only expanded when it is the first argument, possibly after global flags, and does not match a command of the CLI.",
+		Annotations: map[string]string{
+			"group": string(plugin.SystemCmdGroup),
+		},
+	}
+	aliasCmd.SetUsageFunc(cli.SubCmdUsageFunc)
+
+	aliasCmd.AddCommand(
+		newAddAliasCmd(),
+		newListAliasCmd(),
+		newDeleteAliasCmd(),
+	)
+
+	return aliasCmd
+}
+
+func newAddAliasCmd() *cobra.Command {
+	var addCmd = &cobra.Command{
+		Use:   "add ALIAS COMMAND...",
+		Short: "Add an alias for a command",
+		Long: "Add an alias for a command, replacing any existing alias of the same name. " +
+			"The command can include flags, which must then follow '--' or be quoted. " +
+			"A command given as a single quoted argument is split into arguments as done by a shell.",
+		Example: `
+    # Use "tanzu pc" to remove all the installed plugins
+    tanzu alias add pc plugin clean
+
+    # Use "tanzu kcl" to list the clusters in JSON
+    tanzu alias add kcl "kubernetes cluster list -o json"
+
+    # Arguments containing spaces are kept as they are
+    tanzu alias add kcla -- kubernetes cluster list --label "team=a b"`,
+		Args:              cobra.MinimumNArgs(2),
+		ValidArgsFunction: completeAliasAdd,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := args[0]
+			if existingCmd := findRootSubCommandByName(cmd.Root(), name); existingCmd != nil {
+				return errors.Errorf("the alias %q cannot be used as it is the name of the '%s' command", name, existingCmd.CommandPath())
+			}
+
+			command, err := getAliasCommandFromArgs(args[1:])
+			if err != nil {
+				return err
+			}
+			if err := commandalias.SetAlias(name, command); err != nil {
+				return err
+			}
+			log.Successf("Alias %q added for '%s'", name, shellquote.Join(command...))
+			return nil
+		},
+	}
+	return addCmd
+}
+
+func newListAliasCmd() *cobra.Command {
+	var outputFormat string
+
+	var listCmd = &cobra.Command{
+		Use:               "list",
+		Short:             "List the aliases of commands",
+		Args:              cobra.NoArgs,
+		ValidArgsFunction: noMoreCompletions,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			aliases, err := commandalias.GetAliases()
+			if err != nil {
+				return err
+			}
+			names, err := commandalias.GetAliasNames()
+			if err != nil {
+				return err
+			}
+
+			output := component.NewOutputWriterWithOptions(cmd.OutOrStdout(), outputFormat, []component.OutputWriterOption{}, "Alias", "Command")
+			for _, name := range names {
+				output.AddRow(name, shellquote.Join(aliases[name]...))
+			}
+			output.Render()
+			return nil
+		},
+	}
+	listCmd.Flags().StringVarP(&outputFormat, "output", "o", "", "Output format (yaml|json|table)")
+	utils.PanicOnErr(listCmd.RegisterFlagCompletionFunc("output", completionGetOutputFormats))
+
+	return listCmd
+}
+
+func newDeleteAliasCmd() *cobra.Command {
+	var deleteCmd = &cobra.Command{
+		Use:               "delete ALIAS",
+		Short:             "Delete an alias",
+		Aliases:           []string{"remove", "rm"},
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeAliasNames,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := commandalias.DeleteAlias(args[0]); err != nil {
+				return err
+			}
+			log.Successf("Alias %q deleted", args[0])
+			return nil
+		},
+	}
+	return deleteCmd
+}
+
+// getAliasCommandFromArgs returns the arguments of the command of an alias.  A command
+// specified as a single argument, e.g. "plugin clean -y", is split as done by a shell.
+func getAliasCommandFromArgs(args []string) ([]string, error) {
+	if len(args) != 1 {
+		return args, nil
+	}
+	command, err := shellquote.Split(args[0])
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid command %q", args[0])
+	}
+	return command, nil
+}
+
+// findRootSubCommandByName returns the command of the root command which has the
+// specified name or alias, or nil if there is none
+func findRootSubCommandByName(rootCmd *cobra.Command, name string) *cobra.Command {
+	for _, subCmd := range rootCmd.Commands() {
+		if matchOnCommandNameAndAliases(subCmd, name) {
+			return subCmd
+		}
+	}
+	return nil
+}
+
+// expandCommandAlias replaces the alias found as the first argument of the CLI,
+// not including the program name and the global flags preceding it, by the
+// arguments of its command.  The shell completion request of the arguments is
+// also expanded.  Aliases never take precedence over the commands of the CLI,
+// including plugins, and are not expanded recursively.
+func expandCommandAlias(rootCmd *cobra.Command, args []string) []string {
+	aliasIdx := 0
+	completing := len(args) > 0 && (args[0] == cobra.ShellCompRequestCmd || args[0] == cobra.ShellCompNoDescRequestCmd)
+	if completing {
+		aliasIdx = 1
+	}
+	aliasIdx = skipGlobalFlags(rootCmd, args, aliasIdx)
+	// When completing the alias itself, there is nothing to expand
+	if completing && aliasIdx >= len(args)-1 {
+		return args
+	}
+	if len(args) <= aliasIdx || findRootSubCommandByName(rootCmd, args[aliasIdx]) != nil {
+		return args
+	}
+
+	command, exists, err := commandalias.GetAlias(args[aliasIdx])
+	if err != nil {
+		log.V(6).Warningf("unable to read the command aliases: %v", err)
+		return args
+	}
+	if !exists {
+		return args
+	}
+
+	expanded := append([]string{}, args[:aliasIdx]...)
+	expanded = append(expanded, command...)
+	return append(expanded, args[aliasIdx+1:]...)
+}
+
+// skipGlobalFlags returns the index of the first argument, starting at the
+// specified index, which is neither a global flag of the CLI nor its value
+func skipGlobalFlags(rootCmd *cobra.Command, args []string, idx int) int {
+	for idx < len(args) && strings.HasPrefix(args[idx], "-") && args[idx] != "--" {
+		name, _, hasValue := strings.Cut(strings.TrimLeft(args[idx], "-"), "=")
+		var flag *pflag.Flag
+		if strings.HasPrefix(args[idx], "--") {
+			flag = rootCmd.PersistentFlags().Lookup(name)
+		} else if len(name) == 1 {
+			flag = rootCmd.PersistentFlags().ShorthandLookup(name)
+		}
+		if flag == nil {
+			// Not a global flag, so the next argument cannot be an alias
+			return idx
+		}
+		idx++
+		if !hasValue && flag.NoOptDefVal == "" {
+			// The value of the flag is the next argument
+			idx++
+		}
+	}
+	return idx
+}
+
+// ====================================
+// Shell completion functions
+// ====================================
+
+// completeCommandAliases completes the aliases as arguments of the root command,
+// along with the commands completed by Cobra
+func completeCommandAliases(cmd *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	aliases, err := commandalias.GetAliases()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	names, _ := commandalias.GetAliasNames()
+
+	var comps []string
+	for _, name := range names {
+		if findRootSubCommandByName(cmd.Root(), name) == nil {
+			comps = append(comps, fmt.Sprintf("%s\tAlias for '%s'", name, shellquote.Join(aliases[name]...)))
+		}
+	}
+	return comps, cobra.ShellCompDirectiveNoFileComp
+}
+
+func completeAliasAdd(cmd *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+	if len(args) == 0 {
+		return cobra.AppendActiveHelp(nil, "Please specify the name of the alias"), cobra.ShellCompDirectiveNoFileComp
+	}
+
+	// Complete the sub-commands of the command being aliased
+	targetCmd, remaining, err := cmd.Root().Find(args[1:])
+	if err != nil || len(remaining) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	var comps []string
+	for _, subCmd := range targetCmd.Commands() {
+		if subCmd.IsAvailableCommand() {
+			comps = append(comps, fmt.Sprintf("%s\t%s", subCmd.Name(), subCmd.Short))
+		}
+	}
+	return comps, cobra.ShellCompDirectiveNoFileComp
+}
+
+func completeAliasNames(_ *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return activeHelpNoMoreArgs(nil), cobra.ShellCompDirectiveNoFileComp
+	}
+
+	aliases, err := commandalias.GetAliases()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	names, _ := commandalias.GetAliasNames()
+
+	var comps []string
+	for _, name := range names {
+		comps = append(comps, fmt.Sprintf("%s\t%s", name, shellquote.Join(aliases[name]...)))
+	}
+	return comps, cobra.ShellCompDirectiveNoFileComp
+}
@@ -0,0 +1,161 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package command
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/commandalias"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
+)
+
+func newAliasTestRootCmd() *cobra.Command {
+	rootCmd := &cobra.Command{Use: "tanzu"}
+	pluginCmd := &cobra.Command{Use: "plugin", Aliases: []string{"plugins"}}
+	pluginCmd.AddCommand(&cobra.Command{Use: "clean", Short: "Clean the plugins", Run: func(*cobra.Command, []string) {}})
+	rootCmd.PersistentFlags().Bool("assume-yes", false, "")
+	rootCmd.PersistentFlags().StringP("output", "o", "", "")
+	rootCmd.AddCommand(pluginCmd, newAliasCmd())
+	return rootCmd
+}
+
+func setupAliasTestConfig(t *testing.T) {
+	t.Setenv("TEST_CUSTOM_COMMAND_ALIASES_FILE", filepath.Join(t.TempDir(), constants.CommandAliasesFileName))
+}
+
+func TestAliasCmd(t *testing.T) {
+	setupAliasTestConfig(t)
+
+	runCmd := func(args ...string) (string, error) {
+		var out bytes.Buffer
+		rootCmd := newAliasTestRootCmd()
+		rootCmd.SetArgs(append([]string{"alias"}, args...))
+		rootCmd.SetOut(&out)
+		err := rootCmd.Execute()
+		return out.String(), err
+	}
+
+	_, err := runCmd("add", "pc", "plugin", "clean")
+	assert.Nil(t, err)
+	_, err = runCmd("add", "pcy", "plugin clean -y")
+	assert.Nil(t, err)
+	_, err = runCmd("add", "kl", "--", "kubernetes", "cluster", "list", "--label", "team=a b")
+	assert.Nil(t, err)
+	_, err = runCmd("add", "kq", `kubernetes cluster list --label "team=a b"`)
+	assert.Nil(t, err)
+	_, err = runCmd("add", "bad", `plugin "clean`)
+	assert.ErrorContains(t, err, "invalid command")
+	_, err = runCmd("add", "plugins", "plugin", "list")
+	assert.ErrorContains(t, err, "is the name of the 'tanzu plugin' command")
+	_, err = runCmd("add", "pl")
+	assert.NotNil(t, err)
+
+	command, exists, err := commandalias.GetAlias("pcy")
+	assert.Nil(t, err)
+	assert.True(t, exists)
+	assert.Equal(t, []string{"plugin", "clean", "-y"}, command)
+
+	// The arguments containing spaces are kept as they are
+	for _, alias := range []string{"kl", "kq"} {
+		command, _, err = commandalias.GetAlias(alias)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"kubernetes", "cluster", "list", "--label", "team=a b"}, command)
+	}
+
+	out, err := runCmd("list", "-o", "json")
+	assert.Nil(t, err)
+	assert.Contains(t, out, `"alias": "pc"`)
+	assert.Contains(t, out, `"command": "plugin clean"`)
+	assert.Contains(t, out, `"command": "kubernetes cluster list --label 'team=a b'"`)
+
+	_, err = runCmd("delete", "pcy")
+	assert.Nil(t, err)
+	_, err = runCmd("delete", "pcy")
+	assert.ErrorContains(t, err, "does not exist")
+}
+
+func TestExpandCommandAlias(t *testing.T) {
+	setupAliasTestConfig(t)
+	assert.Nil(t, commandalias.SetAlias("pc", []string{"plugin", "clean"}))
+	// An alias never masks a command
+	assert.Nil(t, commandalias.SetAlias("plugins", []string{"alias", "list"}))
+
+	assert.Nil(t, commandalias.SetAlias("kl", []string{"kubernetes", "cluster", "list", "--label", "team=a b"}))
+
+	rootCmd := newAliasTestRootCmd()
+	tests := []struct {
+		args     []string
+		expected []string
+	}{
+		{
+			args:     []string{},
+			expected: []string{},
+		},
+		{
+			args:     []string{"pc", "-y"},
+			expected: []string{"plugin", "clean", "-y"},
+		},
+		{
+			args:     []string{"plugins", "clean"},
+			expected: []string{"plugins", "clean"},
+		},
+		{
+			args:     []string{"unknown", "pc"},
+			expected: []string{"unknown", "pc"},
+		},
+		{
+			// Global flags can precede the alias
+			args:     []string{"--assume-yes", "pc"},
+			expected: []string{"--assume-yes", "plugin", "clean"},
+		},
+		{
+			args:     []string{"-o", "json", "--assume-yes=true", "pc", "-y"},
+			expected: []string{"-o", "json", "--assume-yes=true", "plugin", "clean", "-y"},
+		},
+		{
+			args:     []string{"--unknown", "pc"},
+			expected: []string{"--unknown", "pc"},
+		},
+		{
+			args:     []string{"__complete", "--assume-yes", "pc", ""},
+			expected: []string{"__complete", "--assume-yes", "plugin", "clean", ""},
+		},
+		{
+			args:     []string{"__complete", "pc", ""},
+			expected: []string{"__complete", "plugin", "clean", ""},
+		},
+		{
+			args:     []string{"kl", "-o", "json"},
+			expected: []string{"kubernetes", "cluster", "list", "--label", "team=a b", "-o", "json"},
+		},
+		{
+			// The alias itself is being completed
+			args:     []string{"__complete", "pc"},
+			expected: []string{"__complete", "pc"},
+		},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, expandCommandAlias(rootCmd, tt.args))
+	}
+}
+
+func TestCompleteCommandAliases(t *testing.T) {
+	setupAliasTestConfig(t)
+	assert.Nil(t, commandalias.SetAlias("pc", []string{"plugin", "clean"}))
+	assert.Nil(t, commandalias.SetAlias("plugins", []string{"alias", "list"}))
+
+	rootCmd := newAliasTestRootCmd()
+	comps, directive := completeCommandAliases(rootCmd, []string{}, "")
+	assert.Equal(t, []string{"pc\tAlias for 'plugin clean'"}, comps)
+	assert.Equal(t, cobra.ShellCompDirectiveNoFileComp, directive)
+
+	comps, _ = completeAliasAdd(rootCmd, []string{"pc", "plugin"}, "")
+	assert.Equal(t, []string{"clean\tClean the plugins"}, comps)
+}
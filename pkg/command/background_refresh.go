@@ -0,0 +1,170 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package command
+
+import (
+	"os"
+	"os/exec"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/config"
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/datastore"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/discovery"
+)
+
+const (
+	// refreshCacheCmdName is the name of the hidden command run by the background refresh process
+	refreshCacheCmdName = "__refresh-cache"
+	// refreshCompletionsFlag is the flag of the hidden refresh command selecting the cached
+	// completions to refresh instead of the plugin inventory and the central configuration
+	refreshCompletionsFlag = "completions"
+	// refreshCentralConfigFlag is the flag of the hidden refresh command limiting
+	// the refresh to the stale central configurations
+	refreshCentralConfigFlag = "central-config"
+
+	// dataStoreLastBackgroundCacheRefreshKey is the data store key of the time the last background refresh was started
+	dataStoreLastBackgroundCacheRefreshKey = "lastBackgroundCacheRefresh"
+
+	// defaultBackgroundCacheRefreshIntervalSeconds is the default minimum delay between two background refreshes
+	defaultBackgroundCacheRefreshIntervalSeconds = 60 * 60
+
+	// dataStoreLastCentralConfigRefreshKey is the data store key of the time the last
+	// refresh of the stale central configurations was started
+	dataStoreLastCentralConfigRefreshKey = "lastCentralConfigRefresh"
+
+	// centralConfigRefreshRetryInterval is the minimum delay between two refreshes of the
+	// stale central configurations, which prevents starting a process for every command
+	// when the refresh keeps failing, e.g., when offline
+	centralConfigRefreshRetryInterval = 10 * time.Minute
+)
+
+// startDetachedProcess starts the CLI with the specified arguments in a process
+// which keeps running once the current process exits
+var startDetachedProcess = func(args ...string) error {
+	executable, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	cmd := exec.Command(executable, args...)
+	cmd.SysProcAttr = detachedProcessAttributes()
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	return cmd.Process.Release()
+}
+
+// isBackgroundCacheRefreshEnabled returns true if the user enabled the background refresh
+func isBackgroundCacheRefreshEnabled() bool {
+	enabled, _ := strconv.ParseBool(os.Getenv(constants.ConfigVariableBackgroundCacheRefresh))
+	return enabled
+}
+
+// getBackgroundCacheRefreshIntervalInSeconds returns the minimum delay between two background refreshes
+func getBackgroundCacheRefreshIntervalInSeconds() int {
+	if interval, err := strconv.Atoi(os.Getenv(constants.ConfigVariableBackgroundCacheRefreshIntervalSeconds)); err == nil && interval >= 0 {
+		return interval
+	}
+	return defaultBackgroundCacheRefreshIntervalSeconds
+}
+
+// startBackgroundCacheRefreshIfDue starts a background process refreshing the plugin
+// inventory and the central configuration, unless one was started recently
+func startBackgroundCacheRefreshIfDue() {
+	var lastRefresh time.Time
+	if err := datastore.GetDataStoreValue(dataStoreLastBackgroundCacheRefreshKey, &lastRefresh); err == nil &&
+		time.Since(lastRefresh) < time.Duration(getBackgroundCacheRefreshIntervalInSeconds())*time.Second {
+		return
+	}
+
+	// Record the time before starting the process so that the commands
+	// running concurrently don't also start a refresh
+	_ = datastore.SetDataStoreValue(dataStoreLastBackgroundCacheRefreshKey, time.Now())
+	if err := startDetachedProcess(refreshCacheCmdName); err != nil {
+		log.V(7).Error(err, "unable to start the background refresh of the cache")
+	}
+}
+
+// startCentralConfigRefreshIfStale starts a background process refreshing the central
+// configuration of the discovery sources once its TTL has expired, unless one was started
+// recently.  The refreshed central configuration is used by the following commands.
+func startCentralConfigRefreshIfStale() {
+	sources, err := config.GetCLIDiscoverySources()
+	if err != nil || !discovery.HasStaleCentralConfig(sources) {
+		return
+	}
+
+	var lastRefresh time.Time
+	if err := datastore.GetDataStoreValue(dataStoreLastCentralConfigRefreshKey, &lastRefresh); err == nil &&
+		time.Since(lastRefresh) < centralConfigRefreshRetryInterval {
+		return
+	}
+
+	_ = datastore.SetDataStoreValue(dataStoreLastCentralConfigRefreshKey, time.Now())
+	if err := startDetachedProcess(refreshCacheCmdName, "--"+refreshCentralConfigFlag); err != nil {
+		log.V(7).Error(err, "unable to start the refresh of the central configuration")
+	}
+}
+
+// newRefreshCacheCmd creates the hidden command run by the background refresh process
+func newRefreshCacheCmd() *cobra.Command {
+	var completionsKey string
+	var centralConfigOnly bool
+	refreshCacheCmd := &cobra.Command{
+		Use:    refreshCacheCmdName,
+		Short:  "Refresh the plugin inventory and the central configuration",
+		Hidden: true,
+		Args:   cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if completionsKey != "" {
+				return refreshCompletionCache(completionsKey)
+			}
+			if centralConfigOnly {
+				return refreshStaleCentralConfigs()
+			}
+			return refreshCache()
+		},
+	}
+	refreshCacheCmd.Flags().StringVar(&completionsKey, refreshCompletionsFlag, "", "refresh the cached completions of the specified key instead")
+	refreshCacheCmd.Flags().BoolVar(&centralConfigOnly, refreshCentralConfigFlag, false, "only refresh the stale central configurations")
+	return refreshCacheCmd
+}
+
+// refreshCache refreshes the plugin inventory of the discovery sources if it has changed
+// and their central configuration if it is stale
+func refreshCache() error {
+	sources, err := config.GetCLIDiscoverySources()
+	if err != nil {
+		return errors.Wrap(err, "unable to read the discovery sources")
+	}
+
+	var errorList []error
+	for _, source := range sources {
+		// Forcing the refresh only downloads the plugin inventory if its digest has changed
+		if err := discovery.RefreshDiscoveryDatabaseForSource(source, discovery.WithForceRefresh()); err != nil {
+			errorList = append(errorList, err)
+		}
+	}
+	if err := discovery.RefreshStaleCentralConfigs(sources); err != nil {
+		errorList = append(errorList, err)
+	}
+	return kerrors.NewAggregate(errorList)
+}
+
+// refreshStaleCentralConfigs refreshes the central configuration of the discovery
+// sources once its TTL has expired
+func refreshStaleCentralConfigs() error {
+	sources, err := config.GetCLIDiscoverySources()
+	if err != nil {
+		return errors.Wrap(err, "unable to read the discovery sources")
+	}
+	return discovery.RefreshStaleCentralConfigs(sources)
+}
@@ -0,0 +1,14 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !windows
+
+package command
+
+import "syscall"
+
+// detachedProcessAttributes starts the process in a new session so that
+// it is not terminated along with the terminal of the current process
+func detachedProcessAttributes() *syscall.SysProcAttr {
+	return &syscall.SysProcAttr{Setsid: true}
+}
@@ -0,0 +1,105 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package command
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	configlib "github.com/vmware-tanzu/tanzu-plugin-runtime/config"
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/common"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
+)
+
+func TestStartBackgroundCacheRefreshIfDue(t *testing.T) {
+	env := setupTestCLIEnvironment(t)
+	defer tearDownTestCLIEnvironment(env)
+
+	origStartDetachedProcess := startDetachedProcess
+	defer func() { startDetachedProcess = origStartDetachedProcess }()
+	var started [][]string
+	startDetachedProcess = func(args ...string) error {
+		started = append(started, args)
+		return nil
+	}
+
+	assert := assert.New(t)
+
+	// The first refresh is started
+	startBackgroundCacheRefreshIfDue()
+	assert.Equal([][]string{{refreshCacheCmdName}}, started)
+
+	// Another refresh is not started before the interval has passed
+	startBackgroundCacheRefreshIfDue()
+	assert.Len(started, 1)
+
+	// Another refresh is started once the interval has passed
+	os.Setenv(constants.ConfigVariableBackgroundCacheRefreshIntervalSeconds, "0")
+	defer os.Unsetenv(constants.ConfigVariableBackgroundCacheRefreshIntervalSeconds)
+	startBackgroundCacheRefreshIfDue()
+	assert.Len(started, 2)
+}
+
+func TestStartCentralConfigRefreshIfStale(t *testing.T) {
+	env := setupTestCLIEnvironment(t)
+	defer tearDownTestCLIEnvironment(env)
+
+	originalCacheDir := common.DefaultCacheDir
+	common.DefaultCacheDir = t.TempDir()
+	defer func() { common.DefaultCacheDir = originalCacheDir }()
+
+	origStartDetachedProcess := startDetachedProcess
+	defer func() { startDetachedProcess = origStartDetachedProcess }()
+	var started [][]string
+	startDetachedProcess = func(args ...string) error {
+		started = append(started, args)
+		return nil
+	}
+
+	assert := assert.New(t)
+	assert.Nil(configlib.SetCLIDiscoverySource(configtypes.PluginDiscovery{
+		OCI: &configtypes.OCIDiscovery{
+			Name:  "default",
+			Image: constants.TanzuCLIDefaultCentralPluginDiscoveryImage,
+		}}))
+
+	// No refresh is started while the central configuration is fresh
+	centralConfigFile := filepath.Join(common.DefaultCacheDir, common.PluginInventoryDirName, "default", constants.CentralConfigFileName)
+	assert.Nil(os.MkdirAll(filepath.Dir(centralConfigFile), 0755))
+	assert.Nil(os.WriteFile(centralConfigFile, []byte("testKey: testValue"), 0644))
+	startCentralConfigRefreshIfStale()
+	assert.Empty(started)
+
+	// A refresh is started once the central configuration is stale
+	oldTime := time.Now().Add(-48 * time.Hour)
+	assert.Nil(os.Chtimes(centralConfigFile, oldTime, oldTime))
+	startCentralConfigRefreshIfStale()
+	assert.Equal([][]string{{refreshCacheCmdName, "--" + refreshCentralConfigFlag}}, started)
+
+	// Another refresh is not started right away, even if the previous one failed
+	startCentralConfigRefreshIfStale()
+	assert.Len(started, 1)
+}
+
+func TestBackgroundCacheRefreshSettings(t *testing.T) {
+	assert := assert.New(t)
+	defer os.Unsetenv(constants.ConfigVariableBackgroundCacheRefresh)
+	defer os.Unsetenv(constants.ConfigVariableBackgroundCacheRefreshIntervalSeconds)
+
+	assert.False(isBackgroundCacheRefreshEnabled())
+	os.Setenv(constants.ConfigVariableBackgroundCacheRefresh, "true")
+	assert.True(isBackgroundCacheRefreshEnabled())
+
+	assert.Equal(defaultBackgroundCacheRefreshIntervalSeconds, getBackgroundCacheRefreshIntervalInSeconds())
+	os.Setenv(constants.ConfigVariableBackgroundCacheRefreshIntervalSeconds, "120")
+	assert.Equal(120, getBackgroundCacheRefreshIntervalInSeconds())
+	os.Setenv(constants.ConfigVariableBackgroundCacheRefreshIntervalSeconds, "invalid")
+	assert.Equal(defaultBackgroundCacheRefreshIntervalSeconds, getBackgroundCacheRefreshIntervalInSeconds())
+}
@@ -0,0 +1,16 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package command
+
+import "syscall"
+
+// detachedProcess is the DETACHED_PROCESS process creation flag, which
+// starts the process without attaching it to the console of the current process
+const detachedProcess = 0x00000008
+
+// detachedProcessAttributes starts the process detached from the console and
+// in a new process group so that it is not terminated along with the current process
+func detachedProcessAttributes() *syscall.SysProcAttr {
+	return &syscall.SysProcAttr{CreationFlags: detachedProcess | syscall.CREATE_NEW_PROCESS_GROUP}
+}
@@ -35,6 +35,9 @@ for how to perform this for your given shell.
 Note for bash users: make sure the bash-completions package has been installed.`
 
 	completionExamples = dedent.Dedent(`
+		# Install completion for the current shell for all new sessions:
+		  tanzu completion install
+
 		# Bash instructions:
 
 		  ## Load only for current session:
@@ -97,12 +100,7 @@ func newCompletionCmd() *cobra.Command {
 		Long:                  fmt.Sprintf(completionLongDesc, strings.Join(completionShells, ", ")),
 		Example:               completionExamples,
 		DisableFlagsInUseLine: true,
-		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
-			if len(args) == 0 {
-				return completionShells, cobra.ShellCompDirectiveNoFileComp
-			}
-			return activeHelpNoMoreArgs(nil), cobra.ShellCompDirectiveNoFileComp
-		},
+		ValidArgsFunction:     completeCompletionShells,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runCompletion(os.Stdout, cmd, args)
 		},
@@ -112,6 +110,11 @@ func newCompletionCmd() *cobra.Command {
 	}
 	completionCmd.SetUsageFunc(cli.SubCmdUsageFunc)
 
+	completionCmd.AddCommand(
+		newCompletionInstallCmd(),
+		newCompletionUninstallCmd(),
+	)
+
 	return completionCmd
 }
 
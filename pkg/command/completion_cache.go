@@ -0,0 +1,139 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package command
+
+import (
+	"os"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	configlib "github.com/vmware-tanzu/tanzu-plugin-runtime/config"
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/common"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/datastore"
+)
+
+const (
+	// completionCacheKeyPrefix is the prefix of the data store keys holding cached completions
+	completionCacheKeyPrefix = "completionCache."
+
+	// completionCacheTTL is the duration after which cached completions are no longer used
+	completionCacheTTL = 1 * time.Hour
+
+	// completionCacheRefreshAge is the age after which cached completions are refreshed
+	completionCacheRefreshAge = 5 * time.Minute
+
+	// groupsCompletionCacheKey is the cache key of the completions of the plugin groups
+	groupsCompletionCacheKey = "groups"
+	// pluginsCompletionCacheKey is the cache key of the completions of the plugins, it is
+	// followed by ".<target>" for the completions of the plugins of a specific target
+	pluginsCompletionCacheKey = "plugins"
+	// qualifiedPluginsCompletionCacheKey is the cache key of the completions of the plugins
+	// where the plugins available for more than one target are qualified with their target
+	qualifiedPluginsCompletionCacheKey = "qualifiedPlugins"
+)
+
+// completionCacheEntry is the content stored in the data store for cached completions
+type completionCacheEntry struct {
+	// Sources identifies the discovery sources used to compute the completions
+	Sources     string    `yaml:"sources"`
+	RefreshedAt time.Time `yaml:"refreshedAt"`
+	Completions []string  `yaml:"completions"`
+	// RefreshStartedAt is the time a background process was last started to refresh the completions
+	RefreshStartedAt time.Time `yaml:"refreshStartedAt,omitempty"`
+}
+
+// getCachedCompletions returns the completions cached in the data store under the specified key.
+// Computing some completions requires reading the plugin inventories which can make completion
+// sluggish, so such completions are cached for a short time.
+// When there are no usable cached completions, they are computed using the generate function
+// and cached.  When the cached completions are stale, they are returned right away and a
+// background process, which outlives the completion request, is started to refresh them.
+func getCachedCompletions(key string, generate func() []string) []string {
+	cacheKey := completionCacheKeyPrefix + key
+	sources := discoverySourcesFingerprint()
+
+	var entry completionCacheEntry
+	if err := datastore.GetDataStoreValue(cacheKey, &entry); err != nil || entry.Sources != sources || len(entry.Completions) == 0 {
+		return refreshCachedCompletions(cacheKey, sources, generate)
+	}
+
+	if time.Since(entry.RefreshedAt) >= completionCacheRefreshAge && time.Since(entry.RefreshStartedAt) >= completionCacheRefreshAge {
+		// Record the refresh first so that the next completion requests don't also start one
+		entry.RefreshStartedAt = time.Now()
+		if ttl := completionCacheTTL - time.Since(entry.RefreshedAt); ttl > 0 {
+			_ = datastore.SetDataStoreValueWithTTL(cacheKey, entry, ttl)
+		}
+		if err := startDetachedProcess(refreshCacheCmdName, "--"+refreshCompletionsFlag, key); err != nil {
+			log.V(7).Error(err, "unable to start the background refresh of the completions")
+		}
+	}
+	return entry.Completions
+}
+
+// refreshCompletionCache refreshes the cached completions of the specified key
+func refreshCompletionCache(key string) error {
+	generate, err := cachedCompletionsGenerator(key)
+	if err != nil {
+		return err
+	}
+	refreshCachedCompletions(completionCacheKeyPrefix+key, discoverySourcesFingerprint(), generate)
+	return nil
+}
+
+// cachedCompletionsGenerator returns the function computing the cached completions of the specified key
+func cachedCompletionsGenerator(key string) (func() []string, error) {
+	switch {
+	case key == groupsCompletionCacheKey:
+		return completionAllGroupNames, nil
+	case key == qualifiedPluginsCompletionCacheKey:
+		return completionQualifiedPluginsFromCentralRepo, nil
+	case key == pluginsCompletionCacheKey:
+		return func() []string { return completionAllPluginsFromCentralRepo(configtypes.TargetUnknown) }, nil
+	case strings.HasPrefix(key, pluginsCompletionCacheKey+"."):
+		target := configtypes.StringToTarget(strings.TrimPrefix(key, pluginsCompletionCacheKey+"."))
+		if target != configtypes.TargetUnknown {
+			return func() []string { return completionAllPluginsFromCentralRepo(target) }, nil
+		}
+	}
+	return nil, errors.Errorf("unknown completion cache key %q", key)
+}
+
+// refreshCachedCompletions computes the completions and stores them in the data store.
+// Empty completions are not cached since they usually mean the plugin inventories
+// have not been downloaded yet.
+func refreshCachedCompletions(key, sources string, generate func() []string) []string {
+	comps := generate()
+	if len(comps) > 0 {
+		_ = datastore.SetDataStoreValueWithTTL(key, completionCacheEntry{
+			Sources:     sources,
+			RefreshedAt: time.Now(),
+			Completions: comps,
+		}, completionCacheTTL)
+	}
+	return comps
+}
+
+// discoverySourcesFingerprint returns a string identifying the configured discovery sources
+// and the location of their cached inventories so that cached completions are not used once
+// the discovery sources are modified
+func discoverySourcesFingerprint() string {
+	sources, _ := configlib.GetCLIDiscoverySources()
+
+	ids := []string{common.DefaultCacheDir}
+	for _, source := range sources {
+		if source.OCI != nil {
+			ids = append(ids, source.OCI.Name+"="+source.OCI.Image)
+		}
+	}
+	if testSources := os.Getenv(constants.ConfigVariableAdditionalDiscoveryForTesting); testSources != "" {
+		ids = append(ids, testSources)
+	}
+	return strings.Join(ids, ",")
+}
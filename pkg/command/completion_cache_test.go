@@ -0,0 +1,95 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package command
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/common"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/datastore"
+)
+
+func TestGetCachedCompletions(t *testing.T) {
+	assert := assert.New(t)
+
+	tmpDir, err := os.MkdirTemp("", "completion_cache_test")
+	assert.Nil(err)
+	defer os.RemoveAll(tmpDir)
+
+	os.Setenv("TEST_CUSTOM_DATA_STORE_FILE", filepath.Join(tmpDir, ".data-store.yaml"))
+	defer os.Unsetenv("TEST_CUSTOM_DATA_STORE_FILE")
+
+	origCacheDir := common.DefaultCacheDir
+	common.DefaultCacheDir = tmpDir
+	defer func() { common.DefaultCacheDir = origCacheDir }()
+
+	calls := 0
+	generate := func(comps ...string) func() []string {
+		return func() []string {
+			calls++
+			return comps
+		}
+	}
+
+	// Empty completions are not cached
+	assert.Empty(getCachedCompletions("test", generate()))
+	assert.Empty(getCachedCompletions("test", generate()))
+	assert.Equal(2, calls)
+
+	// Completions are computed once and then cached
+	calls = 0
+	assert.Equal([]string{"a", "b"}, getCachedCompletions("test", generate("a", "b")))
+	assert.Equal([]string{"a", "b"}, getCachedCompletions("test", generate("c")))
+	assert.Equal(1, calls)
+
+	// Stale completions are returned and refreshed by a background process
+	origStartDetachedProcess := startDetachedProcess
+	defer func() { startDetachedProcess = origStartDetachedProcess }()
+	var started [][]string
+	startDetachedProcess = func(args ...string) error {
+		started = append(started, args)
+		return nil
+	}
+
+	calls = 0
+	staleEntry := completionCacheEntry{
+		Sources:     discoverySourcesFingerprint(),
+		RefreshedAt: time.Now().Add(-2 * completionCacheRefreshAge),
+		Completions: []string{"stale"},
+	}
+	assert.Nil(datastore.SetDataStoreValueWithTTL(completionCacheKeyPrefix+"test", staleEntry, completionCacheTTL))
+	assert.Equal([]string{"stale"}, getCachedCompletions("test", generate("fresh")))
+	assert.Equal(0, calls)
+	assert.Equal([][]string{{refreshCacheCmdName, "--" + refreshCompletionsFlag, "test"}}, started)
+
+	// A single background refresh is started
+	assert.Equal([]string{"stale"}, getCachedCompletions("test", generate("fresh")))
+	assert.Len(started, 1)
+
+	// Completions are computed again when the discovery sources change
+	calls = 0
+	common.DefaultCacheDir = filepath.Join(tmpDir, "other")
+	assert.Equal([]string{"c"}, getCachedCompletions("test", generate("c")))
+	assert.Equal(1, calls)
+}
+
+func TestCachedCompletionsGenerator(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, key := range []string{groupsCompletionCacheKey, pluginsCompletionCacheKey, pluginsCompletionCacheKey + ".kubernetes", qualifiedPluginsCompletionCacheKey} {
+		generate, err := cachedCompletionsGenerator(key)
+		assert.Nil(err, key)
+		assert.NotNil(generate, key)
+	}
+
+	_, err := cachedCompletionsGenerator(pluginsCompletionCacheKey + ".invalid")
+	assert.NotNil(err)
+	_, err = cachedCompletionsGenerator("unknown")
+	assert.NotNil(err)
+}
@@ -0,0 +1,279 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package command
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/adrg/xdg"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/utils"
+)
+
+const (
+	// Markers delimiting the block added to the shell startup files
+	completionBlockStart = "# >>> tanzu shell completion >>>"
+	completionBlockEnd   = "# <<< tanzu shell completion <<<"
+)
+
+// completionInstallation describes where the completion script of a shell is installed
+type completionInstallation struct {
+	shell string
+	// scriptFile is the file in which the completion script is written.
+	// It is empty for shells whose startup file generates the completion
+	// script each time the shell starts.
+	scriptFile string
+	// rcFile is the shell startup file that must load the completion script.
+	// It is empty for shells which automatically load completion scripts
+	// from the directory of the scriptFile.
+	rcFile string
+	// rcBlock is the content added to the rcFile to load the completion script
+	rcBlock string
+}
+
+func newCompletionInstallCmd() *cobra.Command {
+	var installCmd = &cobra.Command{
+		Use:   fmt.Sprintf("install [%v]", strings.Join(completionShells, "|")),
+		Short: "Install shell completion for the current user",
+		Long: "Install shell completion for the current user. The completion script is written to the location " +
+			"from which the shell loads completions and, if needed, the shell startup file is modified to load it. " +
+			"If no shell is specified, the current shell is detected. Running the command again updates the installation. " +
+			"For zsh, the completion system must be initialized with 'autoload -U compinit; compinit' before the " +
+			"completion is loaded, which is already the case with frameworks such as Oh My Zsh.",
+		Example: `
+    # Install shell completion for the current shell
+    tanzu completion install
+
+    # Install shell completion for zsh
+    tanzu completion install zsh`,
+		Args:              cobra.MaximumNArgs(1),
+		ValidArgsFunction: completeCompletionShells,
+		RunE:              installShellCompletion,
+	}
+	return installCmd
+}
+
+// installShellCompletion installs the shell completion for the shell specified as
+// argument or, if no shell is specified, for the current shell
+func installShellCompletion(cmd *cobra.Command, args []string) error {
+	installation, err := getCompletionInstallation(args)
+	if err != nil {
+		return err
+	}
+
+	var script bytes.Buffer
+	if installation.scriptFile != "" {
+		if err := runCompletion(&script, cmd, []string{installation.shell}); err != nil {
+			return err
+		}
+	}
+	if err := installation.install(script.Bytes()); err != nil {
+		return err
+	}
+
+	if installation.scriptFile != "" {
+		log.Successf("shell completion for %s installed in %s", installation.shell, installation.scriptFile)
+	} else {
+		log.Successf("shell completion for %s installed", installation.shell)
+	}
+	if installation.rcFile != "" {
+		log.Infof("%s has been configured to load the completion script", installation.rcFile)
+	}
+	log.Info("Start a new shell for the completion to take effect")
+	return nil
+}
+
+func newCompletionUninstallCmd() *cobra.Command {
+	var uninstallCmd = &cobra.Command{
+		Use:   fmt.Sprintf("uninstall [%v]", strings.Join(completionShells, "|")),
+		Short: "Uninstall shell completion for the current user",
+		Long: "Uninstall the shell completion installed by 'tanzu completion install'. " +
+			"If no shell is specified, the current shell is detected.",
+		Example: `
+    # Uninstall shell completion for the current shell
+    tanzu completion uninstall
+
+    # Uninstall shell completion for zsh
+    tanzu completion uninstall zsh`,
+		Args:              cobra.MaximumNArgs(1),
+		ValidArgsFunction: completeCompletionShells,
+		RunE: func(_ *cobra.Command, args []string) error {
+			installation, err := getCompletionInstallation(args)
+			if err != nil {
+				return err
+			}
+			if err := installation.uninstall(); err != nil {
+				return err
+			}
+			log.Successf("shell completion for %s uninstalled", installation.shell)
+			return nil
+		},
+	}
+	return uninstallCmd
+}
+
+func completeCompletionShells(_ *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+	if len(args) == 0 {
+		return completionShells, cobra.ShellCompDirectiveNoFileComp
+	}
+	return activeHelpNoMoreArgs(nil), cobra.ShellCompDirectiveNoFileComp
+}
+
+// getCompletionInstallation returns the installation details of the shell specified
+// as argument or, if no shell is specified, of the current shell.
+func getCompletionInstallation(args []string) (*completionInstallation, error) {
+	var shell string
+	if len(args) > 0 {
+		shell = strings.ToLower(args[0])
+	} else {
+		shell = detectShell()
+		if shell == "" {
+			return nil, fmt.Errorf("unable to detect the current shell, please specify one of: %v", strings.Join(completionShells, ", "))
+		}
+	}
+
+	switch shell {
+	case "bash":
+		// The bash-completion package automatically loads completion scripts from this directory
+		return &completionInstallation{
+			shell:      shell,
+			scriptFile: filepath.Join(xdg.DataHome, "bash-completion", "completions", "tanzu"),
+		}, nil
+	case "zsh":
+		// The completion system is initialized by the user, often through a framework,
+		// and must not be initialized a second time
+		return &completionInstallation{
+			shell:   shell,
+			rcFile:  filepath.Join(zshConfigDir(), ".zshrc"),
+			rcBlock: "source <(tanzu completion zsh)",
+		}, nil
+	case "fish":
+		// fish automatically loads completion scripts from this directory
+		return &completionInstallation{
+			shell:      shell,
+			scriptFile: filepath.Join(xdg.ConfigHome, "fish", "completions", "tanzu.fish"),
+		}, nil
+	case "powershell", "pwsh":
+		scriptFile := filepath.Join(xdg.DataHome, "tanzu-cli", "completion", "powershell", "tanzu.ps1")
+		return &completionInstallation{
+			shell:      "powershell",
+			scriptFile: scriptFile,
+			rcFile:     powershellProfile(),
+			rcBlock:    fmt.Sprintf(". '%s'", scriptFile),
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported shell '%s', please specify one of: %v", shell, strings.Join(completionShells, ", "))
+	}
+}
+
+// detectShell returns the name of the shell of the user or an empty string if it cannot be detected
+func detectShell() string {
+	if shell := os.Getenv("SHELL"); shell != "" {
+		return strings.TrimSuffix(filepath.Base(shell), ".exe")
+	}
+	if runtime.GOOS == "windows" {
+		return "powershell"
+	}
+	return ""
+}
+
+// zshConfigDir returns the directory holding the .zshrc file
+func zshConfigDir() string {
+	if dir := os.Getenv("ZDOTDIR"); dir != "" {
+		return dir
+	}
+	return xdg.Home
+}
+
+// powershellProfile returns the location of the PowerShell profile of the current user
+func powershellProfile() string {
+	if runtime.GOOS == "windows" {
+		return filepath.Join(xdg.Home, "Documents", "PowerShell", "Microsoft.PowerShell_profile.ps1")
+	}
+	return filepath.Join(xdg.ConfigHome, "powershell", "Microsoft.PowerShell_profile.ps1")
+}
+
+// install writes the completion script and configures the shell startup file to load it.
+// It can safely be run multiple times.
+func (ci *completionInstallation) install(script []byte) error {
+	if ci.scriptFile != "" {
+		if err := os.MkdirAll(filepath.Dir(ci.scriptFile), 0o755); err != nil {
+			return errors.Wrapf(err, "unable to create the directory for the completion script")
+		}
+		if err := utils.WriteFileAtomic(ci.scriptFile, script, 0o644); err != nil {
+			return errors.Wrapf(err, "unable to write the completion script %s", ci.scriptFile)
+		}
+	}
+
+	if ci.rcFile == "" {
+		return nil
+	}
+	content, err := os.ReadFile(ci.rcFile)
+	if err != nil && !os.IsNotExist(err) {
+		return errors.Wrapf(err, "unable to read %s", ci.rcFile)
+	}
+	newContent := removeCompletionBlock(string(content))
+	if newContent != "" && !strings.HasSuffix(newContent, "\n") {
+		newContent += "\n"
+	}
+	newContent += completionBlockStart + "\n" + ci.rcBlock + "\n" + completionBlockEnd + "\n"
+
+	if err := os.MkdirAll(filepath.Dir(ci.rcFile), 0o755); err != nil {
+		return errors.Wrapf(err, "unable to create the directory for %s", ci.rcFile)
+	}
+	// The startup file is not replaced atomically in case it is a symbolic link
+	return errors.Wrapf(os.WriteFile(ci.rcFile, []byte(newContent), 0o644), "unable to update %s", ci.rcFile)
+}
+
+// uninstall removes the completion script and the configuration added to the shell startup file.
+// It is not an error to uninstall a completion that is not installed.
+func (ci *completionInstallation) uninstall() error {
+	if ci.scriptFile != "" {
+		if err := os.Remove(ci.scriptFile); err != nil && !os.IsNotExist(err) {
+			return errors.Wrapf(err, "unable to remove the completion script %s", ci.scriptFile)
+		}
+	}
+
+	if ci.rcFile == "" {
+		return nil
+	}
+	content, err := os.ReadFile(ci.rcFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return errors.Wrapf(err, "unable to read %s", ci.rcFile)
+	}
+	newContent := removeCompletionBlock(string(content))
+	if newContent == string(content) {
+		return nil
+	}
+	return errors.Wrapf(os.WriteFile(ci.rcFile, []byte(newContent), 0o644), "unable to update %s", ci.rcFile)
+}
+
+// removeCompletionBlock returns the content without the block added by 'tanzu completion install'
+func removeCompletionBlock(content string) string {
+	start := strings.Index(content, completionBlockStart)
+	if start == -1 {
+		return content
+	}
+	end := strings.Index(content[start:], completionBlockEnd)
+	if end == -1 {
+		return content
+	}
+	end += start + len(completionBlockEnd)
+	if end < len(content) && content[end] == '\n' {
+		end++
+	}
+	return content[:start] + content[end:]
+}
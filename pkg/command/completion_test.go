@@ -6,9 +6,11 @@ package command
 import (
 	"bytes"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
+	"github.com/adrg/xdg"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -141,7 +143,9 @@ func TestCompletionCompletion(t *testing.T) {
 			test: "completion of supported shells as the arg to the completion command",
 			args: []string{"__complete", "completion", ""},
 			// ":4" is the value of the ShellCompDirectiveNoFileComp
-			expected: strings.Join(completionShells, "\n") + "\n:4\n",
+			expected: "install\tInstall shell completion for the current user\n" +
+				"uninstall\tUninstall shell completion for the current user\n" +
+				strings.Join(completionShells, "\n") + "\n:4\n",
 		},
 		{
 			test: "no completion after the first arg for the completion command",
@@ -149,6 +153,18 @@ func TestCompletionCompletion(t *testing.T) {
 			// ":4" is the value of the ShellCompDirectiveNoFileComp
 			expected: "_activeHelp_ " + compNoMoreArgsMsg + "\n:4\n",
 		},
+		{
+			test: "completion of supported shells as the arg to the completion install command",
+			args: []string{"__complete", "completion", "install", ""},
+			// ":4" is the value of the ShellCompDirectiveNoFileComp
+			expected: strings.Join(completionShells, "\n") + "\n:4\n",
+		},
+		{
+			test: "no completion after the first arg for the completion uninstall command",
+			args: []string{"__complete", "completion", "uninstall", "zsh", ""},
+			// ":4" is the value of the ShellCompDirectiveNoFileComp
+			expected: "_activeHelp_ " + compNoMoreArgsMsg + "\n:4\n",
+		},
 	}
 
 	for _, spec := range tests {
@@ -171,3 +187,126 @@ func TestCompletionCompletion(t *testing.T) {
 
 	os.Unsetenv("TANZU_ACTIVE_HELP")
 }
+
+func TestCompletionInstall(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "completion_install_test")
+	assert.Nil(t, err)
+	defer os.RemoveAll(tmpDir)
+
+	origHome, origDataHome, origConfigHome := xdg.Home, xdg.DataHome, xdg.ConfigHome
+	xdg.Home = tmpDir
+	xdg.DataHome = filepath.Join(tmpDir, ".local", "share")
+	xdg.ConfigHome = filepath.Join(tmpDir, ".config")
+	defer func() {
+		xdg.Home, xdg.DataHome, xdg.ConfigHome = origHome, origDataHome, origConfigHome
+	}()
+
+	origShell := os.Getenv("SHELL")
+	defer os.Setenv("SHELL", origShell)
+	os.Unsetenv("ZDOTDIR")
+
+	tests := []struct {
+		test       string
+		shell      string
+		args       []string
+		scriptFile string
+		rcFile     string
+		rcContent  string
+		rcBlock    string
+	}{
+		{
+			test:       "bash detected from the SHELL variable",
+			shell:      "/bin/bash",
+			scriptFile: filepath.Join(tmpDir, ".local", "share", "bash-completion", "completions", "tanzu"),
+		},
+		{
+			test:       "fish specified as argument",
+			shell:      "/bin/bash",
+			args:       []string{"fish"},
+			scriptFile: filepath.Join(tmpDir, ".config", "fish", "completions", "tanzu.fish"),
+		},
+		{
+			test:      "zsh detected from the SHELL variable",
+			shell:     "/usr/bin/zsh",
+			rcFile:    filepath.Join(tmpDir, ".zshrc"),
+			rcContent: "export EDITOR=vi",
+			rcBlock:   "source <(tanzu completion zsh)",
+		},
+		{
+			test:       "powershell specified as argument",
+			args:       []string{"pwsh"},
+			scriptFile: filepath.Join(tmpDir, ".local", "share", "tanzu-cli", "completion", "powershell", "tanzu.ps1"),
+			rcFile:     powershellProfile(),
+		},
+	}
+
+	for _, spec := range tests {
+		t.Run(spec.test, func(t *testing.T) {
+			assert := assert.New(t)
+
+			os.Setenv("SHELL", spec.shell)
+			if spec.rcContent != "" {
+				assert.Nil(os.WriteFile(spec.rcFile, []byte(spec.rcContent), 0o600))
+			}
+
+			// Installing twice must give the same result
+			for i := 0; i < 2; i++ {
+				rootCmd, err := NewRootCmdForTest()
+				assert.Nil(err)
+				rootCmd.SetArgs(append([]string{"completion", "install"}, spec.args...))
+				assert.Nil(rootCmd.Execute())
+
+				if spec.scriptFile != "" {
+					assert.FileExists(spec.scriptFile)
+				}
+				if spec.rcFile != "" {
+					b, err := os.ReadFile(spec.rcFile)
+					assert.Nil(err)
+					assert.Equal(1, strings.Count(string(b), completionBlockStart))
+					assert.True(strings.HasPrefix(string(b), spec.rcContent))
+					assert.Contains(string(b), spec.rcBlock)
+					// The zsh completion system is initialized by the user
+					assert.NotContains(string(b), "compinit")
+				}
+			}
+
+			rootCmd, err := NewRootCmdForTest()
+			assert.Nil(err)
+			rootCmd.SetArgs(append([]string{"completion", "uninstall"}, spec.args...))
+			assert.Nil(rootCmd.Execute())
+
+			if spec.scriptFile != "" {
+				assert.NoFileExists(spec.scriptFile)
+			}
+			if spec.rcFile != "" {
+				b, err := os.ReadFile(spec.rcFile)
+				assert.Nil(err)
+				assert.NotContains(string(b), completionBlockStart)
+				if spec.rcContent != "" {
+					assert.Equal(spec.rcContent+"\n", string(b))
+				}
+			}
+		})
+	}
+}
+
+func TestCompletionInstallUnknownShell(t *testing.T) {
+	origShell := os.Getenv("SHELL")
+	defer os.Setenv("SHELL", origShell)
+
+	os.Setenv("SHELL", "/bin/tcsh")
+	_, err := getCompletionInstallation(nil)
+	assert.ErrorContains(t, err, "unsupported shell 'tcsh'")
+
+	_, err = getCompletionInstallation([]string{"cmd"})
+	assert.ErrorContains(t, err, "unsupported shell 'cmd'")
+}
+
+func TestRemoveCompletionBlock(t *testing.T) {
+	content := "line1\n" + completionBlockStart + "\nsource file\n" + completionBlockEnd + "\nline2\n"
+	assert.Equal(t, "line1\nline2\n", removeCompletionBlock(content))
+
+	// An incomplete block is left untouched
+	content = "line1\n" + completionBlockStart + "\nsource file\n"
+	assert.Equal(t, content, removeCompletionBlock(content))
+}
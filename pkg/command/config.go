@@ -7,7 +7,9 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/pkg/errors"
@@ -18,7 +20,11 @@ import (
 	"github.com/vmware-tanzu/tanzu-plugin-runtime/plugin"
 
 	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/common"
+	cliconfig "github.com/vmware-tanzu/tanzu-cli/pkg/config"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/pluginsupplier"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/recommendedversion"
 	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
 )
 
@@ -26,8 +32,26 @@ import (
 const (
 	ConfigLiteralFeatures = "features"
 	ConfigLiteralEnv      = "env"
+	ConfigLiteralCLI      = "cli"
 )
 
+// Options used with the "cli" config literal
+const (
+	// configCLIOptionChannel selects the release channel
+	configCLIOptionChannel = "channel"
+	// configCLIOptionPluginRoot sets the directory where plugins are installed
+	configCLIOptionPluginRoot = "plugin-root"
+	// configCLIOptionCacheDir sets the cache directory of the CLI
+	configCLIOptionCacheDir = "cache-dir"
+	// configCLIOptionAuditLog sets the audit log of the plugin invocations
+	configCLIOptionAuditLog = "audit-log"
+	// configCLIOptionPluginRetainVersions sets the number of versions of each plugin kept on disk
+	configCLIOptionPluginRetainVersions = "plugin-retain-versions"
+)
+
+// configCLIOptionsExpected lists the supported "cli.<option>" paths for error messages
+const configCLIOptionsExpected = "'cli.channel', 'cli.plugin-root', 'cli.cache-dir', 'cli.audit-log' or 'cli.plugin-retain-versions'"
+
 var unattended bool
 
 func newConfigCmd() *cobra.Command {
@@ -46,6 +70,10 @@ func newConfigCmd() *cobra.Command {
 		newUnsetConfigCmd(),
 		newEULACmd(),
 		newCertCmd(),
+		newCentralConfigCmd(),
+		newFeatureCmd(),
+		newDataStoreCmd(),
+		newPluginDefaultsCmd(),
 	)
 	return configCmd
 }
@@ -107,7 +135,7 @@ func newSetConfigCmd() *cobra.Command {
 	return &cobra.Command{
 		Use:               "set PATH <value>",
 		Short:             "Set config values at the given PATH",
-		Long:              "Set config values at the given PATH. Supported PATH values: [features.global.<feature>, features.<plugin>.<feature>, env.<variable>]",
+		Long:              "Set config values at the given PATH. Supported PATH values: [features.global.<feature>, features.<plugin>.<feature>, env.<variable>, cli.channel, cli.plugin-root, cli.cache-dir, cli.audit-log, cli.plugin-retain-versions]",
 		ValidArgsFunction: completeSetConfig,
 		Example: `
     # Sets a custom CA cert for a proxy that requires it
@@ -115,7 +143,17 @@ func newSetConfigCmd() *cobra.Command {
     # Enables a specific plugin feature
     tanzu config set features.management-cluster.custom_nameservers true
     # Enables a general CLI feature
-    tanzu config set features.global.abcd true`,
+    tanzu config set features.global.abcd true
+    # Receives recommendations for the beta releases of the CLI
+    tanzu config set cli.channel beta
+    # Installs plugins in a different directory, moving the plugins already installed
+    tanzu config set cli.plugin-root /data/tanzu/plugins
+    # Stores the cache of the CLI in a different directory, moving the existing cache
+    tanzu config set cli.cache-dir /data/tanzu/cache
+    # Records every plugin invocation in an audit log file, or use "syslog" for the system logger
+    tanzu config set cli.audit-log /var/log/tanzu/audit.jsonl
+    # Keeps the binaries of the last 3 versions of each plugin, to switch between them without downloading them
+    tanzu config set cli.plugin-retain-versions 3`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if len(args) < 2 {
 				return errors.Errorf("both PATH and <value> are required")
@@ -139,7 +177,7 @@ func setConfiguration(pathParam, value string) error {
 	// parse the param
 	paramArray := strings.Split(pathParam, ".")
 	if len(paramArray) < 2 {
-		return errors.New("unable to parse config path parameter into parts [" + pathParam + "]  (was expecting 'features.<plugin>.<feature>' or 'env.<env_variable>')")
+		return errors.New("unable to parse config path parameter into parts [" + pathParam + "]  (was expecting 'features.<plugin>.<feature>', 'env.<env_variable>' or 'cli.channel')")
 	}
 
 	configLiteral := paramArray[0]
@@ -158,9 +196,95 @@ func setConfiguration(pathParam, value string) error {
 			return errors.New("unable to parse config path parameter into two parts [" + strings.Join(paramArray, ".") + "]  (was expecting 'env.<variable>'")
 		}
 		return configlib.SetEnv(paramArray[1], value)
+	case ConfigLiteralCLI:
+		return setCLIOption(paramArray, value)
 	default:
-		return errors.New("unsupported config path parameter [" + configLiteral + "] (was expecting 'features.<plugin>.<feature>' or 'env.<env_variable>')")
+		return errors.New("unsupported config path parameter [" + configLiteral + "] (was expecting 'features.<plugin>.<feature>', 'env.<env_variable>' or 'cli.channel')")
+	}
+}
+
+// setCLIOption sets the CLI option specified by the "cli.<option>" path.
+// The options are stored as CLI environment variables so that they can
+// also be overridden from the shell.
+func setCLIOption(paramArray []string, value string) error {
+	if len(paramArray) != 2 {
+		return errors.New("unsupported config path parameter [" + strings.Join(paramArray, ".") + "] (was expecting " + configCLIOptionsExpected + ")")
+	}
+
+	switch paramArray[1] {
+	case configCLIOptionChannel:
+		if !recommendedversion.IsSupportedChannel(value) {
+			return errors.Errorf("invalid channel %q provided, only %s are accepted", value, strings.Join(recommendedversion.SupportedChannels, ", "))
+		}
+		return configlib.SetEnv(constants.ConfigVariableReleaseChannel, value)
+	case configCLIOptionPluginRoot:
+		return setCLIDirectory(constants.ConfigVariablePluginRoot, value, &common.DefaultPluginRoot, cliconfig.MovePluginRoot)
+	case configCLIOptionCacheDir:
+		return setCLIDirectory(constants.ConfigVariableCacheDir, value, &common.DefaultCacheDir, cliconfig.MoveCacheDir)
+	case configCLIOptionAuditLog:
+		return setAuditLog(value)
+	case configCLIOptionPluginRetainVersions:
+		if n, err := strconv.Atoi(value); err != nil || n < 0 {
+			return errors.Errorf("invalid number of versions %q provided, only non-negative integers are accepted, 0 keeping all the versions", value)
+		}
+		return configlib.SetEnv(constants.ConfigVariablePluginRetainVersions, value)
+	default:
+		return errors.New("unsupported config path parameter [" + strings.Join(paramArray, ".") + "] (was expecting " + configCLIOptionsExpected + ")")
+	}
+}
+
+// setCLIDirectory moves the content of the directory held by the currentDir variable
+// to the specified directory and stores the new directory in the envVar CLI environment variable
+func setCLIDirectory(envVar, dir string, currentDir *string, move func(oldDir, newDir string) error) error {
+	if dir == "" {
+		return errors.New("the directory cannot be empty")
+	}
+	newDir, err := filepath.Abs(dir)
+	if err != nil {
+		return errors.Wrapf(err, "invalid directory %q", dir)
+	}
+	if err := relocateCLIDirectory(currentDir, newDir, move); err != nil {
+		return err
 	}
+	return configlib.SetEnv(envVar, newDir)
+}
+
+// unsetCLIDirectory moves the content of the directory held by the currentDir variable
+// back to its default location and removes the envVar CLI environment variable
+func unsetCLIDirectory(envVar string, currentDir *string, defaultDir func() string, move func(oldDir, newDir string) error) error {
+	// The variable was set in the environment when the CLI started
+	os.Unsetenv(envVar)
+	if err := relocateCLIDirectory(currentDir, defaultDir(), move); err != nil {
+		return err
+	}
+	return configlib.DeleteEnv(envVar)
+}
+
+func relocateCLIDirectory(currentDir *string, newDir string, move func(oldDir, newDir string) error) error {
+	oldDir := *currentDir
+	// Set the new directory first so that it is the one recorded by the catalog
+	*currentDir = newDir
+	if err := move(oldDir, newDir); err != nil {
+		*currentDir = oldDir
+		return errors.Wrapf(err, "unable to move %q to %q", oldDir, newDir)
+	}
+	return nil
+}
+
+// setAuditLog stores the audit log of the plugin invocations, which is either
+// the syslog or a file whose path is made absolute
+func setAuditLog(value string) error {
+	if value == "" {
+		return errors.New("the audit log cannot be empty, please specify a file or 'syslog'")
+	}
+	if value != cli.AuditLogSyslog {
+		file, err := filepath.Abs(value)
+		if err != nil {
+			return errors.Wrapf(err, "invalid audit log file %q", value)
+		}
+		value = file
+	}
+	return configlib.SetEnv(constants.ConfigVariableAuditLog, value)
 }
 
 func newInitConfigCmd() *cobra.Command {
@@ -195,7 +319,7 @@ func newUnsetConfigCmd() *cobra.Command {
 	return &cobra.Command{
 		Use:               "unset PATH",
 		Short:             "Unset config values at the given PATH",
-		Long:              "Unset config values at the given PATH. Supported PATH values: [features.global.<feature>, features.<plugin>.<feature>, env.<variable>]",
+		Long:              "Unset config values at the given PATH. Supported PATH values: [features.global.<feature>, features.<plugin>.<feature>, env.<variable>, cli.channel, cli.plugin-root, cli.cache-dir, cli.audit-log, cli.plugin-retain-versions]",
 		ValidArgsFunction: completeUnsetConfig,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if len(args) < 1 {
@@ -215,7 +339,7 @@ func unsetConfiguration(pathParam string) error {
 	// parse the param
 	paramArray := strings.Split(pathParam, ".")
 	if len(paramArray) < 2 {
-		return errors.New("unable to parse config path parameter into parts [" + pathParam + "]  (was expecting 'features.<plugin>.<feature>' or 'env.<env_variable>')")
+		return errors.New("unable to parse config path parameter into parts [" + pathParam + "]  (was expecting 'features.<plugin>.<feature>', 'env.<env_variable>' or 'cli.channel')")
 	}
 
 	configLiteral := paramArray[0]
@@ -225,8 +349,10 @@ func unsetConfiguration(pathParam string) error {
 		return unsetFeatures(paramArray)
 	case ConfigLiteralEnv:
 		return unsetEnvs(paramArray)
+	case ConfigLiteralCLI:
+		return unsetCLIOption(paramArray)
 	default:
-		return errors.New("unsupported config path parameter [" + configLiteral + "] (was expecting 'features.<plugin>.<feature>' or 'env.<env_variable>')")
+		return errors.New("unsupported config path parameter [" + configLiteral + "] (was expecting 'features.<plugin>.<feature>', 'env.<env_variable>' or 'cli.channel')")
 	}
 }
 
@@ -249,6 +375,27 @@ func unsetEnvs(paramArray []string) error {
 	return configlib.DeleteEnv(envVariable)
 }
 
+func unsetCLIOption(paramArray []string) error {
+	if len(paramArray) != 2 {
+		return errors.New("unsupported config path parameter [" + strings.Join(paramArray, ".") + "] (was expecting " + configCLIOptionsExpected + ")")
+	}
+
+	switch paramArray[1] {
+	case configCLIOptionChannel:
+		return configlib.DeleteEnv(constants.ConfigVariableReleaseChannel)
+	case configCLIOptionPluginRoot:
+		return unsetCLIDirectory(constants.ConfigVariablePluginRoot, &common.DefaultPluginRoot, common.PluginRootLocation, cliconfig.MovePluginRoot)
+	case configCLIOptionCacheDir:
+		return unsetCLIDirectory(constants.ConfigVariableCacheDir, &common.DefaultCacheDir, common.CacheDirLocation, cliconfig.MoveCacheDir)
+	case configCLIOptionAuditLog:
+		return configlib.DeleteEnv(constants.ConfigVariableAuditLog)
+	case configCLIOptionPluginRetainVersions:
+		return configlib.DeleteEnv(constants.ConfigVariablePluginRetainVersions)
+	default:
+		return errors.New("unsupported config path parameter [" + strings.Join(paramArray, ".") + "] (was expecting " + configCLIOptionsExpected + ")")
+	}
+}
+
 // ====================================
 // Shell completion functions
 // ====================================
@@ -259,6 +406,14 @@ func completeSetConfig(_ *cobra.Command, args []string, _ string) ([]string, cob
 	}
 
 	if len(args) == 1 {
+		switch args[0] {
+		case ConfigLiteralCLI + "." + configCLIOptionChannel:
+			return recommendedversion.SupportedChannels, cobra.ShellCompDirectiveNoFileComp
+		case ConfigLiteralCLI + "." + configCLIOptionPluginRoot, ConfigLiteralCLI + "." + configCLIOptionCacheDir:
+			return nil, cobra.ShellCompDirectiveFilterDirs
+		case ConfigLiteralCLI + "." + configCLIOptionAuditLog:
+			return []string{cli.AuditLogSyslog}, cobra.ShellCompDirectiveDefault
+		}
 		return cobra.AppendActiveHelp(nil, "You must provide a value as a second argument"),
 			cobra.ShellCompDirectiveNoFileComp
 	}
@@ -0,0 +1,99 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package command
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	configlib "github.com/vmware-tanzu/tanzu-plugin-runtime/config"
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/centralconfig"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/discovery"
+)
+
+func newCentralConfigCmd() *cobra.Command {
+	var centralConfigCmd = &cobra.Command{
+		Use:   "central",
+		Short: "Manage the central configuration",
+		Long: "Manage the central configuration. The central configuration is published along with the plugin inventory " +
+			"of the discovery source and is automatically refreshed by the CLI. Its values can be overridden locally " +
+			"in the central_config_override.yaml file of the configuration directory.",
+	}
+	centralConfigCmd.SetUsageFunc(cli.SubCmdUsageFunc)
+
+	centralConfigCmd.AddCommand(
+		newRefreshCentralConfigCmd(),
+	)
+
+	return centralConfigCmd
+}
+
+func newRefreshCentralConfigCmd() *cobra.Command {
+	var show bool
+
+	var refreshCmd = &cobra.Command{
+		Use:   "refresh",
+		Short: "Refresh the central configuration",
+		Long: "Refresh the central configuration from the discovery sources without waiting for " +
+			"the automatic refresh.",
+		Example: `
+    # Refresh the central configuration
+    tanzu config central refresh
+
+    # Refresh the central configuration and print its content
+    tanzu config central refresh --show`,
+		Args:              cobra.NoArgs,
+		ValidArgsFunction: noMoreCompletions,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			sources, err := configlib.GetCLIDiscoverySources()
+			if err != nil {
+				return errors.Wrap(err, "unable to read the discovery sources")
+			}
+
+			for _, source := range sources {
+				if source.OCI == nil {
+					continue
+				}
+				log.Infof("Refreshing the central configuration of discovery source %q", source.OCI.Name)
+				if err := discovery.RefreshCentralConfigForSource(source); err != nil {
+					return errors.Wrapf(err, "unable to refresh the central configuration of discovery source %q", source.OCI.Name)
+				}
+			}
+			log.Success("the central configuration has been refreshed")
+
+			if show {
+				return printCentralConfig(cmd)
+			}
+			return nil
+		},
+	}
+	refreshCmd.Flags().BoolVar(&show, "show", false, "print the effective central configuration after the refresh")
+
+	return refreshCmd
+}
+
+// printCentralConfig prints the effective central configuration in yaml format
+func printCentralConfig(cmd *cobra.Command) error {
+	content, err := centralconfig.GetDefaultCentralConfigContent()
+	if err != nil {
+		return errors.Wrap(err, "unable to read the central configuration")
+	}
+	if len(content) == 0 {
+		log.Info("The central configuration is empty")
+		return nil
+	}
+
+	bytes, err := yaml.Marshal(content)
+	if err != nil {
+		return err
+	}
+	fmt.Fprint(cmd.OutOrStdout(), string(bytes))
+	return nil
+}
@@ -0,0 +1,233 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package command
+
+import (
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/component"
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/datastore"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/utils"
+)
+
+type dataStoreFlags struct {
+	namespace string
+	output    string
+	ttl       time.Duration
+}
+
+// getNamespace returns the namespace specified with the --namespace flag or, when the
+// command is run by a plugin, the namespace of that plugin
+func (dsFlags *dataStoreFlags) getNamespace() string {
+	if dsFlags.namespace != "" {
+		return dsFlags.namespace
+	}
+	return os.Getenv(constants.DataStoreNamespace)
+}
+
+// newDataStoreCmd creates the hidden commands used to inspect and modify the data store
+// of the CLI.  These commands are meant for debugging and support purposes.
+func newDataStoreCmd() *cobra.Command {
+	var dataStoreCmd = &cobra.Command{
+		Use:   "datastore",
+		Short: "Inspect and modify the data store of the CLI",
+		Long: "Inspect and modify the data store of the CLI. The data store holds the data the CLI needs " +
+			"to remember between executions, such as the last time a new version was recommended. " +
+			"These commands are meant for debugging purposes and for plugins, which use them to persist " +
+			"their own state: when run by a plugin, the commands use the namespace of that plugin by default.",
+		Hidden: true,
+	}
+	dataStoreCmd.SetUsageFunc(cli.SubCmdUsageFunc)
+
+	dataStoreCmd.AddCommand(
+		newGetDataStoreCmd(),
+		newSetDataStoreCmd(),
+		newDeleteDataStoreCmd(),
+	)
+
+	return dataStoreCmd
+}
+
+func newGetDataStoreCmd() *cobra.Command {
+	dsFlags := &dataStoreFlags{}
+
+	var getCmd = &cobra.Command{
+		Use:   "get [KEY]",
+		Short: "Get the value of a key of the data store",
+		Long:  "Get the value of a key of the data store or, if no key is specified, the entire content of the data store",
+		Example: `
+    # Get the entire content of the data store
+    tanzu config datastore get
+
+    # Get the last time a new CLI version was recommended in json format
+    tanzu config datastore get lastVersionCheck -o json
+
+    # Get the content of a namespace of the data store
+    tanzu config datastore get --namespace my-plugin`,
+		Args:              cobra.MaximumNArgs(1),
+		ValidArgsFunction: completeDataStoreKeys(dsFlags),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			namespace := dsFlags.getNamespace()
+			var value interface{}
+			var err error
+			switch {
+			case len(args) == 0 && namespace == "":
+				value, err = datastore.GetDataStoreContent()
+			case len(args) == 0:
+				value, err = datastore.GetNamespacedDataStoreContent(namespace)
+			case namespace == "":
+				err = datastore.GetDataStoreValue(args[0], &value)
+			default:
+				err = datastore.GetNamespacedDataStoreValue(namespace, args[0], &value)
+			}
+			if err != nil {
+				return err
+			}
+
+			component.NewObjectWriter(cmd.OutOrStdout(), dsFlags.output, value).Render()
+			return nil
+		},
+	}
+	getCmd.Flags().StringVarP(&dsFlags.namespace, "namespace", "n", "", "namespace of the data store to use")
+	utils.PanicOnErr(getCmd.RegisterFlagCompletionFunc("namespace", completeDataStoreNamespaces))
+	getCmd.Flags().StringVarP(&dsFlags.output, "output", "o", string(component.YAMLOutputType), "output format: yaml|json")
+	utils.PanicOnErr(getCmd.RegisterFlagCompletionFunc("output", cobra.FixedCompletions([]string{compYAMLOutput, compJSONOutput}, cobra.ShellCompDirectiveNoFileComp)))
+
+	return getCmd
+}
+
+func newSetDataStoreCmd() *cobra.Command {
+	dsFlags := &dataStoreFlags{}
+
+	var setCmd = &cobra.Command{
+		Use:   "set KEY VALUE",
+		Short: "Set the value of a key of the data store",
+		Long:  "Set the value of a key of the data store. The value is parsed as yaml which allows to set structured values.",
+		Example: `
+    # Set a timestamp value
+    tanzu config datastore set lastVersionCheck 2024-01-01T00:00:00Z
+
+    # Set a value which expires after one hour
+    tanzu config datastore set myKey myValue --ttl 1h`,
+		Args: cobra.ExactArgs(2),
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			if len(args) == 0 {
+				return completeDataStoreKeys(dsFlags)(cmd, args, toComplete)
+			}
+			if len(args) == 1 {
+				return cobra.AppendActiveHelp(nil, "You must provide a value for the key"), cobra.ShellCompDirectiveNoFileComp
+			}
+			return activeHelpNoMoreArgs(nil), cobra.ShellCompDirectiveNoFileComp
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			key := args[0]
+			var value interface{}
+			if err := yaml.Unmarshal([]byte(args[1]), &value); err != nil {
+				return errors.Wrapf(err, "invalid value %q", args[1])
+			}
+
+			namespace := dsFlags.getNamespace()
+			var err error
+			switch {
+			case namespace != "" && dsFlags.ttl != 0:
+				return errors.New("the --ttl flag cannot be used with a namespace")
+			case namespace != "":
+				err = datastore.SetNamespacedDataStoreValue(namespace, key, value)
+			case dsFlags.ttl != 0:
+				err = datastore.SetDataStoreValueWithTTL(key, value, dsFlags.ttl)
+			default:
+				err = datastore.SetDataStoreValue(key, value)
+			}
+			if err != nil {
+				return err
+			}
+			log.Successf("Key %q set in the data store", key)
+			return nil
+		},
+	}
+	setCmd.Flags().StringVarP(&dsFlags.namespace, "namespace", "n", "", "namespace of the data store to use")
+	utils.PanicOnErr(setCmd.RegisterFlagCompletionFunc("namespace", completeDataStoreNamespaces))
+	setCmd.Flags().DurationVar(&dsFlags.ttl, "ttl", 0, "duration after which the key expires (e.g. 30m, 24h)")
+	utils.PanicOnErr(setCmd.RegisterFlagCompletionFunc("ttl", cobra.NoFileCompletions))
+
+	return setCmd
+}
+
+func newDeleteDataStoreCmd() *cobra.Command {
+	dsFlags := &dataStoreFlags{}
+
+	var deleteCmd = &cobra.Command{
+		Use:   "delete KEY",
+		Short: "Delete a key from the data store",
+		Example: `
+    # Reset the last time a new CLI version was recommended
+    tanzu config datastore delete lastVersionCheck`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeDataStoreKeys(dsFlags),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var err error
+			if namespace := dsFlags.getNamespace(); namespace != "" {
+				err = datastore.DeleteNamespacedDataStoreValue(namespace, args[0])
+			} else {
+				err = datastore.DeleteDataStoreValue(args[0])
+			}
+			if err != nil {
+				return err
+			}
+			log.Successf("Key %q deleted from the data store", args[0])
+			return nil
+		},
+	}
+	deleteCmd.Flags().StringVarP(&dsFlags.namespace, "namespace", "n", "", "namespace of the data store to use")
+	utils.PanicOnErr(deleteCmd.RegisterFlagCompletionFunc("namespace", completeDataStoreNamespaces))
+
+	return deleteCmd
+}
+
+// completeDataStoreKeys returns a completion function for the keys of the data store
+// or of the namespace specified by the --namespace flag
+func completeDataStoreKeys(dsFlags *dataStoreFlags) func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return func(_ *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return activeHelpNoMoreArgs(nil), cobra.ShellCompDirectiveNoFileComp
+		}
+
+		var content map[string]interface{}
+		if namespace := dsFlags.getNamespace(); namespace != "" {
+			content, _ = datastore.GetNamespacedDataStoreContent(namespace)
+		} else {
+			content, _ = datastore.GetDataStoreContent()
+		}
+		return sortedKeys(content), cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+func completeDataStoreNamespaces(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+	namespaces, _ := datastore.GetDataStoreNamespaces()
+	return namespaces, cobra.ShellCompDirectiveNoFileComp
+}
+
+// sortedKeys returns the sorted keys of the data store content that can be used as completions
+func sortedKeys(content map[string]interface{}) []string {
+	var keys []string
+	for key := range content {
+		// Don't suggest keys that are not usable from the shell
+		if key != "" && !strings.ContainsAny(key, " \t\n") {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
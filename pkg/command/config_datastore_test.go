@@ -0,0 +1,160 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package command
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/datastore"
+)
+
+func TestDataStoreCmd(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "data_store_test")
+	assert.Nil(t, err)
+	defer os.RemoveAll(tmpDir)
+
+	os.Setenv("TEST_CUSTOM_DATA_STORE_FILE", filepath.Join(tmpDir, ".data-store.yaml"))
+	defer os.Unsetenv("TEST_CUSTOM_DATA_STORE_FILE")
+	// Tests executing plugins set the namespace of the plugin
+	os.Unsetenv(constants.DataStoreNamespace)
+
+	runCmd := func(args ...string) (string, error) {
+		var out bytes.Buffer
+		cmd := newDataStoreCmd()
+		cmd.SetArgs(args)
+		cmd.SetOut(&out)
+		err := cmd.Execute()
+		return out.String(), err
+	}
+
+	// Set values
+	_, err = runCmd("set", "stringKey", "value1")
+	assert.Nil(t, err)
+	_, err = runCmd("set", "listKey", "[a, b]")
+	assert.Nil(t, err)
+	_, err = runCmd("set", "nsKey", "value2", "--namespace", "my-plugin")
+	assert.Nil(t, err)
+	_, err = runCmd("set", "nsKey", "value2", "--namespace", "my-plugin", "--ttl", "1h")
+	assert.ErrorContains(t, err, "cannot be used with")
+
+	var listValue []string
+	assert.Nil(t, datastore.GetDataStoreValue("listKey", &listValue))
+	assert.Equal(t, []string{"a", "b"}, listValue)
+
+	// Get values
+	out, err := runCmd("get", "stringKey")
+	assert.Nil(t, err)
+	assert.Contains(t, out, "value1")
+
+	out, err = runCmd("get", "listKey", "-o", "json")
+	assert.Nil(t, err)
+	assert.Contains(t, out, `"a"`)
+
+	out, err = runCmd("get", "nsKey", "--namespace", "my-plugin")
+	assert.Nil(t, err)
+	assert.Contains(t, out, "value2")
+
+	out, err = runCmd("get")
+	assert.Nil(t, err)
+	assert.Contains(t, out, "stringKey: value1")
+	assert.Contains(t, out, "my-plugin")
+
+	_, err = runCmd("get", "missingKey")
+	assert.NotNil(t, err)
+
+	// Delete values
+	_, err = runCmd("delete", "stringKey")
+	assert.Nil(t, err)
+	_, err = runCmd("get", "stringKey")
+	assert.NotNil(t, err)
+	_, err = runCmd("delete", "nsKey", "--namespace", "my-plugin")
+	assert.Nil(t, err)
+	_, err = runCmd("delete", "nsKey", "--namespace", "my-plugin")
+	assert.NotNil(t, err)
+
+	// The reserved keys of the data store cannot be modified
+	_, err = runCmd("set", "namespaces", "value")
+	assert.ErrorContains(t, err, "reserved")
+
+	// When run by a plugin, the namespace of the plugin is used by default
+	os.Setenv(constants.DataStoreNamespace, "my-plugin")
+	defer os.Unsetenv(constants.DataStoreNamespace)
+	_, err = runCmd("set", "pluginKey", "value3")
+	assert.Nil(t, err)
+	var value string
+	assert.Nil(t, datastore.GetNamespacedDataStoreValue("my-plugin", "pluginKey", &value))
+	assert.Equal(t, "value3", value)
+	assert.NotNil(t, datastore.GetDataStoreValue("pluginKey", &value))
+	out, err = runCmd("get", "pluginKey")
+	assert.Nil(t, err)
+	assert.Contains(t, out, "value3")
+}
+
+func TestCompletionDataStore(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "data_store_test")
+	assert.Nil(t, err)
+	defer os.RemoveAll(tmpDir)
+
+	os.Setenv("TEST_CUSTOM_DATA_STORE_FILE", filepath.Join(tmpDir, ".data-store.yaml"))
+	defer os.Unsetenv("TEST_CUSTOM_DATA_STORE_FILE")
+
+	assert.Nil(t, datastore.SetDataStoreValue("key2", "value"))
+	assert.Nil(t, datastore.SetDataStoreValue("key1", "value"))
+	assert.Nil(t, datastore.SetNamespacedDataStoreValue("my-plugin", "nsKey", "value"))
+
+	// This is global logic and needs not be tested for each
+	// command.  Let's deactivate it.
+	os.Setenv("TANZU_ACTIVE_HELP", "no_short_help")
+	defer os.Unsetenv("TANZU_ACTIVE_HELP")
+
+	tests := []struct {
+		test     string
+		args     []string
+		expected string
+	}{
+		{
+			test: "completion of the keys for the get command",
+			args: []string{"__complete", "config", "datastore", "get", ""},
+			// ":4" is the value of the ShellCompDirectiveNoFileComp
+			expected: "key1\nkey2\nnamespaces\n:4\n",
+		},
+		{
+			test:     "completion of the keys of a namespace",
+			args:     []string{"__complete", "config", "datastore", "delete", "--namespace", "my-plugin", ""},
+			expected: "nsKey\n:4\n",
+		},
+		{
+			test:     "completion of the namespaces",
+			args:     []string{"__complete", "config", "datastore", "set", "--namespace", ""},
+			expected: "my-plugin\n:4\n",
+		},
+		{
+			test:     "no more completions after the key",
+			args:     []string{"__complete", "config", "datastore", "get", "key1", ""},
+			expected: "_activeHelp_ " + compNoMoreArgsMsg + "\n:4\n",
+		},
+	}
+
+	for _, spec := range tests {
+		t.Run(spec.test, func(t *testing.T) {
+			rootCmd, err := NewRootCmdForTest()
+			assert.Nil(t, err)
+
+			var out bytes.Buffer
+			rootCmd.SetOut(&out)
+			rootCmd.SetArgs(spec.args)
+
+			err = rootCmd.Execute()
+			assert.Nil(t, err)
+
+			assert.Equal(t, spec.expected, out.String())
+		})
+	}
+}
@@ -0,0 +1,219 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package command
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/component"
+	configlib "github.com/vmware-tanzu/tanzu-plugin-runtime/config"
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/utils"
+)
+
+// featureFlagGlobalPlugin is the plugin name used for the feature flags of the CLI itself
+const featureFlagGlobalPlugin = "global"
+
+// featureFlag describes a feature flag known by the CLI or present in the configuration
+type featureFlag struct {
+	// name is the full name of the feature flag: features.<plugin>.<feature>
+	name         string
+	value        string
+	defaultValue string
+	description  string
+}
+
+func newFeatureCmd() *cobra.Command {
+	var featureCmd = &cobra.Command{
+		Use:   "feature",
+		Short: "Manage the feature flags of the CLI and its plugins",
+		Long: "Manage the feature flags of the CLI and its plugins. A feature flag can be specified " +
+			"as features.<plugin>.<feature>, <plugin>.<feature> or, for the feature flags of the CLI itself, <feature>.",
+	}
+	featureCmd.SetUsageFunc(cli.SubCmdUsageFunc)
+
+	featureCmd.AddCommand(
+		newListFeatureCmd(),
+		newEnableFeatureCmd(),
+		newDisableFeatureCmd(),
+	)
+
+	return featureCmd
+}
+
+func newListFeatureCmd() *cobra.Command {
+	var outputFormat string
+
+	var listCmd = &cobra.Command{
+		Use:               "list",
+		Short:             "List the feature flags",
+		Long:              "List the feature flags known by the CLI as well as the ones present in the configuration, along with their current and default values",
+		Args:              cobra.NoArgs,
+		ValidArgsFunction: noMoreCompletions,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			flags, err := getFeatureFlags()
+			if err != nil {
+				return err
+			}
+
+			output := component.NewOutputWriterWithOptions(cmd.OutOrStdout(), outputFormat, []component.OutputWriterOption{}, "Name", "Value", "Default", "Description")
+			for _, f := range flags {
+				output.AddRow(f.name, f.value, f.defaultValue, f.description)
+			}
+			output.Render()
+			return nil
+		},
+	}
+	listCmd.Flags().StringVarP(&outputFormat, "output", "o", "", "Output format (yaml|json|table)")
+	utils.PanicOnErr(listCmd.RegisterFlagCompletionFunc("output", completionGetOutputFormats))
+
+	return listCmd
+}
+
+func newEnableFeatureCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "enable FEATURE",
+		Short: "Enable a feature flag",
+		Example: `
+    # Enable a feature flag of the CLI
+    tanzu config feature enable plugin-discovery-for-tanzu-context
+
+    # Enable a feature flag of a plugin
+    tanzu config feature enable features.management-cluster.custom_nameservers`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeFeatureFlags,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return setFeatureFlag(args[0], true)
+		},
+	}
+}
+
+func newDisableFeatureCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "disable FEATURE",
+		Short: "Disable a feature flag",
+		Example: `
+    # Disable a feature flag of the CLI
+    tanzu config feature disable plugin-discovery-for-tanzu-context
+
+    # Disable a feature flag of a plugin
+    tanzu config feature disable features.management-cluster.custom_nameservers`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeFeatureFlags,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return setFeatureFlag(args[0], false)
+		},
+	}
+}
+
+func setFeatureFlag(name string, value bool) error {
+	plugin, feature, err := parseFeatureFlagName(name)
+	if err != nil {
+		return err
+	}
+	if err := configlib.SetFeature(plugin, feature, strconv.FormatBool(value)); err != nil {
+		return err
+	}
+
+	if value {
+		log.Successf("Feature flag %q enabled", featureFlagName(plugin, feature))
+	} else {
+		log.Successf("Feature flag %q disabled", featureFlagName(plugin, feature))
+	}
+	return nil
+}
+
+// getFeatureFlags returns the feature flags known by the CLI and the ones
+// present in the configuration, sorted by name
+func getFeatureFlags() ([]featureFlag, error) {
+	flags := make(map[string]*featureFlag)
+	for name, description := range constants.KnownCliFeatureFlags {
+		flags[name] = &featureFlag{
+			name:         name,
+			defaultValue: strconv.FormatBool(constants.DefaultCliFeatureFlags[name]),
+			description:  description,
+		}
+	}
+
+	configuredFlags, err := configlib.GetAllFeatureFlags()
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to read the feature flags from the configuration")
+	}
+	for plugin, features := range configuredFlags {
+		for feature, value := range features {
+			name := featureFlagName(plugin, feature)
+			if _, exists := flags[name]; !exists {
+				flags[name] = &featureFlag{name: name}
+			}
+			flags[name].value = value
+		}
+	}
+
+	result := make([]featureFlag, 0, len(flags))
+	for _, f := range flags {
+		result = append(result, *f)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].name < result[j].name
+	})
+	return result, nil
+}
+
+// parseFeatureFlagName returns the plugin and feature of a feature flag specified as
+// features.<plugin>.<feature>, <plugin>.<feature> or <feature> for the feature flags of the CLI itself
+func parseFeatureFlagName(name string) (plugin, feature string, err error) {
+	parts := strings.Split(strings.TrimPrefix(name, "features."), ".")
+	switch {
+	case len(parts) == 1 && parts[0] != "":
+		return featureFlagGlobalPlugin, parts[0], nil
+	case len(parts) == 2 && parts[0] != "" && parts[1] != "":
+		return parts[0], parts[1], nil
+	default:
+		return "", "", errors.Errorf("invalid feature flag %q (was expecting 'features.<plugin>.<feature>', '<plugin>.<feature>' or '<feature>')", name)
+	}
+}
+
+func featureFlagName(plugin, feature string) string {
+	return "features." + plugin + "." + feature
+}
+
+// ====================================
+// Shell completion functions
+// ====================================
+
+func completeFeatureFlags(_ *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return activeHelpNoMoreArgs(nil), cobra.ShellCompDirectiveNoFileComp
+	}
+
+	flags, err := getFeatureFlags()
+	if err != nil {
+		return cobra.AppendActiveHelp(nil, err.Error()), cobra.ShellCompDirectiveNoFileComp
+	}
+
+	var comps []string
+	for _, f := range flags {
+		desc := f.description
+		if f.value != "" {
+			desc = "Value: " + f.value
+			if f.description != "" {
+				desc += ", " + f.description
+			}
+		}
+		if desc == "" {
+			comps = append(comps, f.name)
+		} else {
+			comps = append(comps, f.name+"\t"+desc)
+		}
+	}
+	return comps, cobra.ShellCompDirectiveNoFileComp
+}
@@ -0,0 +1,100 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package command
+
+import (
+	"bytes"
+	"os"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	configlib "github.com/vmware-tanzu/tanzu-plugin-runtime/config"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
+)
+
+func TestParseFeatureFlagName(t *testing.T) {
+	tests := []struct {
+		name    string
+		plugin  string
+		feature string
+		errStr  string
+	}{
+		{name: "features.global.foo", plugin: "global", feature: "foo"},
+		{name: "features.plugin1.foo", plugin: "plugin1", feature: "foo"},
+		{name: "plugin1.foo", plugin: "plugin1", feature: "foo"},
+		{name: "foo", plugin: "global", feature: "foo"},
+		{name: "", errStr: "invalid feature flag"},
+		{name: "features.plugin1.", errStr: "invalid feature flag"},
+		{name: "a.b.c", errStr: "invalid feature flag"},
+	}
+	for _, spec := range tests {
+		t.Run(spec.name, func(t *testing.T) {
+			plugin, feature, err := parseFeatureFlagName(spec.name)
+			if spec.errStr != "" {
+				assert.ErrorContains(t, err, spec.errStr)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, spec.plugin, plugin)
+			assert.Equal(t, spec.feature, feature)
+		})
+	}
+}
+
+func TestFeatureCmd(t *testing.T) {
+	configFile, err := os.CreateTemp("", "config")
+	assert.Nil(t, err)
+	defer os.RemoveAll(configFile.Name())
+	os.Setenv("TANZU_CONFIG", configFile.Name())
+	defer os.Unsetenv("TANZU_CONFIG")
+	configFileNG, err := os.CreateTemp("", "config_ng")
+	assert.Nil(t, err)
+	defer os.RemoveAll(configFileNG.Name())
+	os.Setenv("TANZU_CONFIG_NEXT_GEN", configFileNG.Name())
+	defer os.Unsetenv("TANZU_CONFIG_NEXT_GEN")
+	os.Setenv(constants.CEIPOptInUserPromptAnswer, "No")
+	defer os.Unsetenv(constants.CEIPOptInUserPromptAnswer)
+	os.Setenv(constants.EULAPromptAnswer, "Yes")
+	defer os.Unsetenv(constants.EULAPromptAnswer)
+
+	// Enable and disable feature flags
+	assert.Nil(t, setFeatureFlag(constants.FeaturePluginDiscoveryForTanzuContext, true))
+	assert.True(t, configlib.IsFeatureActivated(constants.FeaturePluginDiscoveryForTanzuContext))
+	assert.Nil(t, setFeatureFlag("plugin-discovery-for-tanzu-context", false))
+	assert.False(t, configlib.IsFeatureActivated(constants.FeaturePluginDiscoveryForTanzuContext))
+	assert.Nil(t, setFeatureFlag("plugin1.feat1", true))
+	enabled, err := configlib.IsFeatureEnabled("plugin1", "feat1")
+	assert.Nil(t, err)
+	assert.True(t, enabled)
+	assert.NotNil(t, setFeatureFlag("a.b.c", true))
+
+	// The known feature flags are listed even if they are not in the configuration
+	flags, err := getFeatureFlags()
+	assert.Nil(t, err)
+	byName := make(map[string]featureFlag)
+	for _, f := range flags {
+		byName[f.name] = f
+	}
+	for name, description := range constants.KnownCliFeatureFlags {
+		assert.Contains(t, byName, name)
+		assert.Equal(t, description, byName[name].description)
+		assert.Equal(t, strconv.FormatBool(constants.DefaultCliFeatureFlags[name]), byName[name].defaultValue)
+	}
+	assert.Equal(t, "false", byName[constants.FeaturePluginDiscoveryForTanzuContext].value)
+	assert.Equal(t, "true", byName[constants.FeatureAMD64Fallback].defaultValue)
+	assert.Equal(t, "true", byName["features.plugin1.feat1"].value)
+
+	// The list command prints the feature flags
+	rootCmd, err := NewRootCmdForTest()
+	assert.Nil(t, err)
+	var out bytes.Buffer
+	rootCmd.SetOut(&out)
+	rootCmd.SetArgs([]string{"config", "feature", "list", "-o", "json"})
+	assert.Nil(t, rootCmd.Execute())
+	assert.Contains(t, out.String(), "features.plugin1.feat1")
+	assert.Contains(t, out.String(), constants.FeaturePluginOverrideOnActiveContextType)
+}
@@ -0,0 +1,265 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package command
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/component"
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/plugindefaults"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/pluginsupplier"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/utils"
+)
+
+func newPluginDefaultsCmd() *cobra.Command {
+	var pluginDefaultsCmd = &cobra.Command{
+		Use:   "plugin-defaults",
+		Short: "Manage the default flags and environment variables of plugins",
+		Long: "Manage the default flags and environment variables of plugins. The CLI passes the default flags " +
+			"to a plugin after the command path, before the flags specified on the command line, which therefore take " +
+			"precedence, and sets the default environment variables which are not already set.",
+	}
+	pluginDefaultsCmd.SetUsageFunc(cli.SubCmdUsageFunc)
+
+	pluginDefaultsCmd.AddCommand(
+		newListPluginDefaultsCmd(),
+		newSetPluginDefaultsCmd(),
+		newUnsetPluginDefaultsCmd(),
+	)
+
+	return pluginDefaultsCmd
+}
+
+func newListPluginDefaultsCmd() *cobra.Command {
+	var outputFormat string
+
+	var listCmd = &cobra.Command{
+		Use:               "list",
+		Short:             "List the default flags and environment variables of plugins",
+		Args:              cobra.NoArgs,
+		ValidArgsFunction: noMoreCompletions,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			allDefaults, err := plugindefaults.GetAllPluginDefaults()
+			if err != nil {
+				return err
+			}
+			names, err := plugindefaults.GetPluginNames()
+			if err != nil {
+				return err
+			}
+
+			output := component.NewOutputWriterWithOptions(cmd.OutOrStdout(), outputFormat, []component.OutputWriterOption{}, "Plugin", "Flags", "Env")
+			for _, name := range names {
+				defaults := allDefaults[name]
+				output.AddRow(name, strings.Join(defaults.Flags, " "), formatPluginDefaultEnv(defaults.Env))
+			}
+			output.Render()
+			return nil
+		},
+	}
+	listCmd.Flags().StringVarP(&outputFormat, "output", "o", "", "Output format (yaml|json|table)")
+	utils.PanicOnErr(listCmd.RegisterFlagCompletionFunc("output", completionGetOutputFormats))
+
+	return listCmd
+}
+
+func newSetPluginDefaultsCmd() *cobra.Command {
+	var envVars []string
+
+	var setCmd = &cobra.Command{
+		Use:   "set " + pluginNameCaps + " [--env KEY=VALUE]... [-- FLAGS...]",
+		Short: "Set the default flags and environment variables of a plugin",
+		Long: "Set the default flags and environment variables of a plugin. The flags specified after '--' replace " +
+			"the default flags of the plugin, while the environment variables are added to the existing ones.",
+		Example: `
+    # Always pass a specific kubeconfig file to the cluster plugin
+    tanzu config plugin-defaults set cluster -- --kubeconfig=/home/me/.kube/prod-config
+
+    # Set an environment variable whenever the package plugin is invoked
+    tanzu config plugin-defaults set package --env KAPP_FQDN=kapp.example.com`,
+		Args:              cobra.MinimumNArgs(1),
+		ValidArgsFunction: completePluginNamesForDefaults,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			pluginName := args[0]
+
+			flags, err := getPluginDefaultFlagsFromArgs(cmd, args)
+			if err != nil {
+				return err
+			}
+			env, err := parsePluginDefaultEnv(envVars)
+			if err != nil {
+				return err
+			}
+			if flags == nil && len(env) == 0 {
+				return errors.New("no default flags or environment variables were specified")
+			}
+
+			if flags != nil {
+				if err := plugindefaults.SetPluginDefaultFlags(pluginName, flags); err != nil {
+					return err
+				}
+			}
+			if len(env) > 0 {
+				if err := plugindefaults.SetPluginDefaultEnv(pluginName, env); err != nil {
+					return err
+				}
+			}
+			log.Successf("Defaults of plugin %q updated", pluginName)
+			return nil
+		},
+	}
+	setCmd.Flags().StringArrayVar(&envVars, "env", nil, "default environment variable of the plugin, as KEY=VALUE (can be repeated)")
+	utils.PanicOnErr(setCmd.RegisterFlagCompletionFunc("env", cobra.NoFileCompletions))
+	// The flags of the plugin are unknown to this command when they are not specified after '--'
+	setCmd.SetFlagErrorFunc(func(_ *cobra.Command, err error) error {
+		if strings.HasPrefix(err.Error(), "unknown flag") || strings.HasPrefix(err.Error(), "unknown shorthand flag") {
+			return errors.Errorf("%v: the default flags of the plugin must be specified after '--'", err)
+		}
+		return err
+	})
+
+	return setCmd
+}
+
+func newUnsetPluginDefaultsCmd() *cobra.Command {
+	var envKeys []string
+	var unsetFlags bool
+
+	var unsetCmd = &cobra.Command{
+		Use:   "unset " + pluginNameCaps,
+		Short: "Unset the default flags and environment variables of a plugin",
+		Long: "Unset the default flags and environment variables of a plugin. If neither the --flags nor the --env " +
+			"flag is specified, all the defaults of the plugin are removed.",
+		Example: `
+    # Stop passing default flags to the cluster plugin
+    tanzu config plugin-defaults unset cluster --flags
+
+    # Remove a default environment variable of the package plugin
+    tanzu config plugin-defaults unset package --env KAPP_FQDN
+
+    # Remove all the defaults of the cluster plugin
+    tanzu config plugin-defaults unset cluster`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completePluginsWithDefaults,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			pluginName := args[0]
+
+			var err error
+			switch {
+			case !unsetFlags && len(envKeys) == 0:
+				err = plugindefaults.DeletePluginDefaults(pluginName)
+			case unsetFlags:
+				err = plugindefaults.DeletePluginDefaultFlags(pluginName)
+			}
+			if err == nil && len(envKeys) > 0 {
+				err = plugindefaults.DeletePluginDefaultEnv(pluginName, envKeys)
+			}
+			if err != nil {
+				return err
+			}
+			log.Successf("Defaults of plugin %q updated", pluginName)
+			return nil
+		},
+	}
+	unsetCmd.Flags().BoolVar(&unsetFlags, "flags", false, "unset the default flags of the plugin")
+	unsetCmd.Flags().StringArrayVar(&envKeys, "env", nil, "default environment variable of the plugin to unset (can be repeated)")
+	utils.PanicOnErr(unsetCmd.RegisterFlagCompletionFunc("env", completePluginDefaultEnvKeys))
+
+	return unsetCmd
+}
+
+// getPluginDefaultFlagsFromArgs returns the flags specified after '--', or nil if there are none
+func getPluginDefaultFlagsFromArgs(cmd *cobra.Command, args []string) ([]string, error) {
+	dashIdx := cmd.ArgsLenAtDash()
+	if dashIdx == -1 {
+		if len(args) > 1 {
+			return nil, errors.Errorf("the default flags of the plugin must be specified after '--', e.g. '-- %s'", strings.Join(args[1:], " "))
+		}
+		return nil, nil
+	}
+	if dashIdx != 1 {
+		return nil, errors.New("only the plugin name can be specified before '--'")
+	}
+	flags := args[dashIdx:]
+	if len(flags) == 0 {
+		return nil, nil
+	}
+	return flags, nil
+}
+
+// parsePluginDefaultEnv parses the KEY=VALUE environment variables
+func parsePluginDefaultEnv(envVars []string) (map[string]string, error) {
+	env := make(map[string]string, len(envVars))
+	for _, envVar := range envVars {
+		key, value, found := strings.Cut(envVar, "=")
+		if !found || key == "" {
+			return nil, errors.Errorf("invalid environment variable %q, expected KEY=VALUE", envVar)
+		}
+		env[key] = value
+	}
+	return env, nil
+}
+
+// formatPluginDefaultEnv formats the environment variables as a sorted list of KEY=VALUE
+func formatPluginDefaultEnv(env map[string]string) string {
+	vars := make([]string, 0, len(env))
+	for key, value := range env {
+		vars = append(vars, fmt.Sprintf("%s=%s", key, value))
+	}
+	sort.Strings(vars)
+	return strings.Join(vars, " ")
+}
+
+// completePluginNamesForDefaults completes the names of the installed plugins
+func completePluginNamesForDefaults(cmd *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		if cmd.ArgsLenAtDash() == -1 {
+			return []string{"--"}, cobra.ShellCompDirectiveNoFileComp
+		}
+		// The default flags of the plugin cannot be completed
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	installedPlugins, err := pluginsupplier.GetInstalledPlugins()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	var comps []string
+	for i := range installedPlugins {
+		comps = append(comps, fmt.Sprintf("%s\t%s", installedPlugins[i].Name, installedPlugins[i].Description))
+	}
+	sort.Strings(comps)
+	return comps, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completePluginsWithDefaults completes the names of the plugins which have defaults
+func completePluginsWithDefaults(_ *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return activeHelpNoMoreArgs(nil), cobra.ShellCompDirectiveNoFileComp
+	}
+	names, _ := plugindefaults.GetPluginNames()
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completePluginDefaultEnvKeys completes the default environment variables of the plugin
+func completePluginDefaultEnvKeys(_ *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+	if len(args) == 0 {
+		return cobra.AppendActiveHelp(nil, "You must first specify the plugin name"), cobra.ShellCompDirectiveNoFileComp
+	}
+	defaults, _ := plugindefaults.GetPluginDefaults(args[0])
+	var keys []string
+	for key := range defaults.Env {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys, cobra.ShellCompDirectiveNoFileComp
+}
@@ -0,0 +1,65 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package command
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/plugindefaults"
+)
+
+func TestPluginDefaultsCmd(t *testing.T) {
+	t.Setenv("TEST_CUSTOM_PLUGIN_DEFAULTS_FILE", filepath.Join(t.TempDir(), "plugin-defaults.yaml"))
+
+	runCmd := func(args ...string) (string, error) {
+		var out bytes.Buffer
+		cmd := newPluginDefaultsCmd()
+		cmd.SetArgs(args)
+		cmd.SetOut(&out)
+		err := cmd.Execute()
+		return out.String(), err
+	}
+
+	// Set defaults
+	_, err := runCmd("set", "cluster", "--env", "FOO=foo", "--", "--kubeconfig=/tmp/kubeconfig", "-v", "6")
+	assert.Nil(t, err)
+	_, err = runCmd("set", "cluster", "--env", "BAR=bar=baz")
+	assert.Nil(t, err)
+	_, err = runCmd("set", "package", "--env", "BAZ")
+	assert.ErrorContains(t, err, "expected KEY=VALUE")
+	_, err = runCmd("set", "package", "--kubeconfig=/tmp/kubeconfig")
+	assert.ErrorContains(t, err, "must be specified after '--'")
+	_, err = runCmd("set", "package")
+	assert.ErrorContains(t, err, "no default flags or environment variables were specified")
+
+	defaults, err := plugindefaults.GetPluginDefaults("cluster")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"--kubeconfig=/tmp/kubeconfig", "-v", "6"}, defaults.Flags)
+	assert.Equal(t, map[string]string{"FOO": "foo", "BAR": "bar=baz"}, defaults.Env)
+
+	// List defaults
+	out, err := runCmd("list", "-o", "yaml")
+	assert.Nil(t, err)
+	assert.Contains(t, out, "cluster")
+	assert.Contains(t, out, "--kubeconfig=/tmp/kubeconfig -v 6")
+	assert.Contains(t, out, "BAR=bar=baz FOO=foo")
+
+	// Unset defaults
+	_, err = runCmd("unset", "cluster", "--flags", "--env", "FOO")
+	assert.Nil(t, err)
+	defaults, err = plugindefaults.GetPluginDefaults("cluster")
+	assert.Nil(t, err)
+	assert.Empty(t, defaults.Flags)
+	assert.Equal(t, map[string]string{"BAR": "bar=baz"}, defaults.Env)
+
+	_, err = runCmd("unset", "cluster")
+	assert.Nil(t, err)
+	names, err := plugindefaults.GetPluginNames()
+	assert.Nil(t, err)
+	assert.Empty(t, names)
+}
@@ -6,12 +6,16 @@ package command
 import (
 	"bytes"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 
 	configlib "github.com/vmware-tanzu/tanzu-plugin-runtime/config"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/common"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
 )
 
 // Test_config_MalformedPathArg validates functionality when an invalid argument is provided.
@@ -57,7 +61,131 @@ func TestConfigIncorrectConfigLiteral(t *testing.T) {
 	value := "b"
 	err := setConfiguration("fake.any-plugin.foo", value)
 	assert.NotNil(t, err)
-	assert.Contains(t, err.Error(), "unsupported config path parameter [fake] (was expecting 'features.<plugin>.<feature>' or 'env.<env_variable>')")
+	assert.Contains(t, err.Error(), "unsupported config path parameter [fake] (was expecting 'features.<plugin>.<feature>', 'env.<env_variable>' or 'cli.channel')")
+}
+
+// TestConfigSetUnsetCLIChannel validates set and unset functionality for the cli.channel path argument.
+func TestConfigSetUnsetCLIChannel(t *testing.T) {
+	err := setConfiguration("cli.channel", "beta")
+	assert.Nil(t, err)
+	value, err := configlib.GetEnv(constants.ConfigVariableReleaseChannel)
+	assert.Nil(t, err)
+	assert.Equal(t, "beta", value)
+
+	err = setConfiguration("cli.channel", "unknown")
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "invalid channel \"unknown\" provided")
+
+	err = setConfiguration("cli.other", "beta")
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "was expecting 'cli.channel'")
+
+	err = unsetConfiguration("cli.channel")
+	assert.Nil(t, err)
+	_, err = configlib.GetEnv(constants.ConfigVariableReleaseChannel)
+	assert.NotNil(t, err)
+}
+
+// TestConfigSetUnsetCLIAuditLog validates set and unset functionality for the cli.audit-log path argument.
+func TestConfigSetUnsetCLIAuditLog(t *testing.T) {
+	err := setConfiguration("cli.audit-log", "syslog")
+	assert.Nil(t, err)
+	value, err := configlib.GetEnv(constants.ConfigVariableAuditLog)
+	assert.Nil(t, err)
+	assert.Equal(t, "syslog", value)
+
+	// A relative file is made absolute
+	err = setConfiguration("cli.audit-log", "audit.jsonl")
+	assert.Nil(t, err)
+	value, err = configlib.GetEnv(constants.ConfigVariableAuditLog)
+	assert.Nil(t, err)
+	assert.True(t, filepath.IsAbs(value))
+	assert.Equal(t, "audit.jsonl", filepath.Base(value))
+
+	err = setConfiguration("cli.audit-log", "")
+	assert.NotNil(t, err)
+
+	err = unsetConfiguration("cli.audit-log")
+	assert.Nil(t, err)
+	_, err = configlib.GetEnv(constants.ConfigVariableAuditLog)
+	assert.NotNil(t, err)
+}
+
+// TestConfigSetUnsetCLIPluginRetainVersions validates set and unset functionality for the cli.plugin-retain-versions path argument.
+func TestConfigSetUnsetCLIPluginRetainVersions(t *testing.T) {
+	err := setConfiguration("cli.plugin-retain-versions", "3")
+	assert.Nil(t, err)
+	value, err := configlib.GetEnv(constants.ConfigVariablePluginRetainVersions)
+	assert.Nil(t, err)
+	assert.Equal(t, "3", value)
+
+	for _, invalid := range []string{"", "-1", "two"} {
+		err = setConfiguration("cli.plugin-retain-versions", invalid)
+		assert.NotNil(t, err)
+		assert.Contains(t, err.Error(), "only non-negative integers are accepted")
+	}
+
+	err = unsetConfiguration("cli.plugin-retain-versions")
+	assert.Nil(t, err)
+	_, err = configlib.GetEnv(constants.ConfigVariablePluginRetainVersions)
+	assert.NotNil(t, err)
+}
+
+// TestConfigSetUnsetCLIDirectories validates that setting and unsetting the cli.plugin-root and
+// cli.cache-dir paths moves the existing directories.
+func TestConfigSetUnsetCLIDirectories(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	originalPluginRoot := common.DefaultPluginRoot
+	originalCacheDir := common.DefaultCacheDir
+	defer func() {
+		common.DefaultPluginRoot = originalPluginRoot
+		common.DefaultCacheDir = originalCacheDir
+	}()
+	// Make sure the default locations used when unsetting are in the test directory
+	os.Setenv(constants.ConfigVariableDataHome, filepath.Join(tmpDir, "data"))
+	defer os.Unsetenv(constants.ConfigVariableDataHome)
+	os.Setenv(constants.ConfigVariableCacheHome, filepath.Join(tmpDir, "cache"))
+	defer os.Unsetenv(constants.ConfigVariableCacheHome)
+
+	common.DefaultPluginRoot = common.PluginRootLocation()
+	common.DefaultCacheDir = common.CacheDirLocation()
+	assert.Nil(t, os.MkdirAll(common.DefaultPluginRoot, 0755))
+	assert.Nil(t, os.WriteFile(filepath.Join(common.DefaultPluginRoot, "plugin"), []byte("plugin"), 0600))
+	assert.Nil(t, os.MkdirAll(common.DefaultCacheDir, 0755))
+	assert.Nil(t, os.WriteFile(filepath.Join(common.DefaultCacheDir, "cache"), []byte("cache"), 0600))
+
+	// Set the directories
+	newPluginRoot := filepath.Join(tmpDir, "new-plugins")
+	assert.Nil(t, setConfiguration("cli.plugin-root", newPluginRoot))
+	assert.Equal(t, newPluginRoot, common.DefaultPluginRoot)
+	assert.FileExists(t, filepath.Join(newPluginRoot, "plugin"))
+	value, err := configlib.GetEnv(constants.ConfigVariablePluginRoot)
+	assert.Nil(t, err)
+	assert.Equal(t, newPluginRoot, value)
+
+	newCacheDir := filepath.Join(tmpDir, "new-cache")
+	assert.Nil(t, setConfiguration("cli.cache-dir", newCacheDir))
+	assert.Equal(t, newCacheDir, common.DefaultCacheDir)
+	assert.FileExists(t, filepath.Join(newCacheDir, "cache"))
+	value, err = configlib.GetEnv(constants.ConfigVariableCacheDir)
+	assert.Nil(t, err)
+	assert.Equal(t, newCacheDir, value)
+
+	assert.NotNil(t, setConfiguration("cli.cache-dir", ""))
+
+	// Unset the directories
+	assert.Nil(t, unsetConfiguration("cli.plugin-root"))
+	assert.Equal(t, filepath.Join(tmpDir, "data", "tanzu-cli"), common.DefaultPluginRoot)
+	assert.FileExists(t, filepath.Join(common.DefaultPluginRoot, "plugin"))
+	_, err = configlib.GetEnv(constants.ConfigVariablePluginRoot)
+	assert.NotNil(t, err)
+
+	assert.Nil(t, unsetConfiguration("cli.cache-dir"))
+	assert.Equal(t, filepath.Join(tmpDir, "cache", "tanzu"), common.DefaultCacheDir)
+	assert.FileExists(t, filepath.Join(common.DefaultCacheDir, "cache"))
+	_, err = configlib.GetEnv(constants.ConfigVariableCacheDir)
+	assert.NotNil(t, err)
 }
 
 // TestConfigEnv validates functionality when normal env path argument is provided.
@@ -98,19 +226,19 @@ func TestConfigFeatureFlags(t *testing.T) {
 			name:   "should not set invalid feature flag key 2",
 			key:    "features",
 			value:  "false",
-			errStr: "unable to parse config path parameter into parts [features]  (was expecting 'features.<plugin>.<feature>' or 'env.<env_variable>')",
+			errStr: "unable to parse config path parameter into parts [features]  (was expecting 'features.<plugin>.<feature>', 'env.<env_variable>' or 'cli.channel')",
 		},
 		{
 			name:   "should not set invalid feature flag key 3",
 			key:    "feature",
 			value:  "false",
-			errStr: "unable to parse config path parameter into parts [feature]  (was expecting 'features.<plugin>.<feature>' or 'env.<env_variable>')",
+			errStr: "unable to parse config path parameter into parts [feature]  (was expecting 'features.<plugin>.<feature>', 'env.<env_variable>' or 'cli.channel')",
 		},
 		{
 			name:   "should not set invalid key anv value",
 			key:    "",
 			value:  "",
-			errStr: "unable to parse config path parameter into parts []  (was expecting 'features.<plugin>.<feature>' or 'env.<env_variable>')",
+			errStr: "unable to parse config path parameter into parts []  (was expecting 'features.<plugin>.<feature>', 'env.<env_variable>' or 'cli.channel')",
 		},
 	}
 	for _, spec := range tests {
@@ -161,6 +289,15 @@ func TestCompletionConfig(t *testing.T) {
 			expected: "_activeHelp_ " + compNoMoreArgsMsg + "\n:4\n",
 		},
 		// ======================
+		// tanzu config central refresh
+		// ======================
+		{
+			test: "no completion for the config central refresh command",
+			args: []string{"__complete", "config", "central", "refresh", ""},
+			// ":4" is the value of the ShellCompDirectiveNoFileComp
+			expected: "_activeHelp_ " + compNoMoreArgsMsg + "\n:4\n",
+		},
+		// ======================
 		// tanzu config set
 		// ======================
 		{
@@ -180,6 +317,18 @@ func TestCompletionConfig(t *testing.T) {
 			// ":4" is the value of the ShellCompDirectiveNoFileComp
 			expected: "_activeHelp_ You must provide a value as a second argument\n:4\n",
 		},
+		{
+			test: "completion of the channels for the cli.channel path of the config set command",
+			args: []string{"__complete", "config", "set", "cli.channel", ""},
+			// ":4" is the value of the ShellCompDirectiveNoFileComp
+			expected: "stable\nbeta\nnightly\n:4\n",
+		},
+		{
+			test: "completion of the syslog for the cli.audit-log path of the config set command",
+			args: []string{"__complete", "config", "set", "cli.audit-log", ""},
+			// ":0" is the value of the ShellCompDirectiveDefault
+			expected: "syslog\n:0\n",
+		},
 		{
 			test: "no completion after the second arg for the config set command",
 			args: []string{"__complete", "config", "set", "env.VAR", "val", ""},
@@ -206,6 +355,21 @@ func TestCompletionConfig(t *testing.T) {
 			expected: "_activeHelp_ " + compNoMoreArgsMsg + "\n:4\n",
 		},
 		// ======================
+		// tanzu config feature
+		// ======================
+		{
+			test: "no completion for the config feature list command",
+			args: []string{"__complete", "config", "feature", "list", ""},
+			// ":4" is the value of the ShellCompDirectiveNoFileComp
+			expected: "_activeHelp_ " + compNoMoreArgsMsg + "\n:4\n",
+		},
+		{
+			test: "no completion after the first arg for the config feature disable command",
+			args: []string{"__complete", "config", "feature", "disable", "feat1", ""},
+			// ":4" is the value of the ShellCompDirectiveNoFileComp
+			expected: "_activeHelp_ " + compNoMoreArgsMsg + "\n:4\n",
+		},
+		// ======================
 		// tanzu config init
 		// ======================
 		{
@@ -0,0 +1,35 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package command
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/component"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/internal/tty"
+)
+
+// assumeYes is set by the global --assume-yes flag to answer yes to all
+// confirmation prompts, as if --yes was specified for every command
+var assumeYes bool
+
+const assumeYesFlag = "assume-yes"
+
+// askForConfirmation prompts the user
+var askForConfirmation = component.AskForConfirmation
+
+// confirmDestructiveAction asks the user to confirm the action described by the message, unless
+// the user already confirmed it with the --yes flag of the command (yes) or the global --assume-yes
+// flag.  As the user cannot be prompted when the CLI is not used interactively, e.g. in a script,
+// the action is then refused unless it was confirmed with one of these flags.
+func confirmDestructiveAction(message string, yes bool) error {
+	if yes || assumeYes {
+		return nil
+	}
+	if !tty.CanPrompt() {
+		return errors.Errorf("%s\nconfirmation is required but the CLI is not running interactively, please use the '--yes' flag to confirm", message)
+	}
+	return askForConfirmation(message)
+}
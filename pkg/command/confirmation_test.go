@@ -0,0 +1,80 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package command
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/internal/tty"
+)
+
+func TestConfirmDestructiveAction(t *testing.T) {
+	origIsInteractive := tty.CanPrompt
+	origAskForConfirmation := askForConfirmation
+	origAssumeYes := assumeYes
+	defer func() {
+		tty.CanPrompt = origIsInteractive
+		askForConfirmation = origAskForConfirmation
+		assumeYes = origAssumeYes
+	}()
+
+	tests := []struct {
+		name        string
+		yes         bool
+		assumeYes   bool
+		interactive bool
+		answer      error
+		expectAsked bool
+		expectedErr string
+	}{
+		{
+			name: "confirmed with --yes",
+			yes:  true,
+		},
+		{
+			name:      "confirmed with --assume-yes",
+			assumeYes: true,
+		},
+		{
+			name:        "confirmed by the user",
+			interactive: true,
+			expectAsked: true,
+		},
+		{
+			name:        "refused by the user",
+			interactive: true,
+			answer:      errors.New("aborted"),
+			expectAsked: true,
+			expectedErr: "aborted",
+		},
+		{
+			name:        "not interactive",
+			expectedErr: "please use the '--yes' flag to confirm",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			asked := false
+			tty.CanPrompt = func() bool { return tt.interactive }
+			askForConfirmation = func(message string) error {
+				asked = true
+				assert.Equal(t, "Delete everything?", message)
+				return tt.answer
+			}
+			assumeYes = tt.assumeYes
+
+			err := confirmDestructiveAction("Delete everything?", tt.yes)
+			if tt.expectedErr != "" {
+				assert.ErrorContains(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expectAsked, asked)
+		})
+	}
+}
@@ -58,6 +58,8 @@ var (
 
 	projectStr, projectIDStr, spaceStr, clustergroupStr string
 	contextTypeStr                                      string
+
+	keepContextPlugins bool
 )
 
 const (
@@ -300,19 +302,25 @@ func syncContextPlugins(cmd *cobra.Command, contextType configtypes.ContextType,
 	}
 
 	if len(pluginsNeedToBeInstalled) == 0 {
+		recordContextPlugins(ctxName, plugins, nil)
 		log.Success("All recommended plugins are already installed and up-to-date.")
 		return nil
 	}
 
 	errList := make([]error, 0)
+	var installedPlugins []discovery.Discovered
 	log.Infof("Installing the following plugins recommended by context '%s':", ctxName)
 	displayToBeInstalledPluginsAsTable(plugins, cmd.ErrOrStderr())
+	pluginmanager.PrefetchPlugins(pluginsNeedToBeInstalled)
 	for i := range pluginsNeedToBeInstalled {
 		err = pluginmanager.InstallStandalonePlugin(pluginsNeedToBeInstalled[i].Name, pluginsNeedToBeInstalled[i].RecommendedVersion, pluginsNeedToBeInstalled[i].Target)
 		if err != nil {
 			errList = append(errList, err)
+		} else if pluginsNeedToBeInstalled[i].Status == common.PluginStatusNotInstalled {
+			installedPlugins = append(installedPlugins, pluginsNeedToBeInstalled[i])
 		}
 	}
+	recordContextPlugins(ctxName, plugins, installedPlugins)
 	err = kerrors.NewAggregate(errList)
 	if err == nil {
 		log.Success("Successfully installed all recommended plugins.")
@@ -321,6 +329,14 @@ func syncContextPlugins(cmd *cobra.Command, contextType configtypes.ContextType,
 	return err
 }
 
+// recordContextPlugins records the plugins installed for the context, so that they
+// are uninstalled when the context is deleted
+func recordContextPlugins(ctxName string, recommended, installed []discovery.Discovered) {
+	if err := pluginmanager.OnContextPluginsSynced(ctxName, recommended, installed); err != nil {
+		log.V(6).Warningf("unable to record the plugins installed for context %q: %v", ctxName, err)
+	}
+}
+
 // displayToBeInstalledPluginsAsTable takes a list of plugins and displays the plugin info as a table
 func displayToBeInstalledPluginsAsTable(plugins []discovery.Discovered, writer io.Writer) {
 	outputPlugins := component.NewOutputWriterWithOptions(writer, outputFormat, []component.OutputWriterOption{}, "Name", "Target", "Current", "Installing")
@@ -1549,6 +1565,7 @@ func newDeleteCtxCmd() *cobra.Command {
 		RunE:              deleteCtx,
 	}
 	deleteCtxCmd.Flags().BoolVarP(&unattended, "yes", "y", false, "delete the context entry without confirmation")
+	deleteCtxCmd.Flags().BoolVar(&keepContextPlugins, "keep-plugins", false, "keep the plugins installed for the context as standalone plugins")
 	return deleteCtxCmd
 }
 
@@ -1569,12 +1586,14 @@ func deleteCtx(_ *cobra.Command, args []string) error {
 		return err
 	}
 
-	if !unattended {
-		isAborted := component.AskForConfirmation("Deleting the context entry from the config will remove it from the list of tracked contexts. " +
-			"You will need to use `tanzu context create` to re-create this context. Are you sure you want to continue?")
-		if isAborted != nil {
-			return nil
-		}
+	message := "Deleting the context entry from the config will remove it from the list of tracked contexts. " +
+		"You will need to use `tanzu context create` to re-create this context."
+	if pluginsToUninstall := getContextPluginsToUninstall(name); len(pluginsToUninstall) > 0 {
+		message += fmt.Sprintf(" The plugins installed for this context (%s) will also be uninstalled, "+
+			"use --keep-plugins to keep them.", strings.Join(pluginsToUninstall, ", "))
+	}
+	if err := confirmDestructiveAction(message+" Are you sure you want to continue?", unattended); err != nil {
+		return err
 	}
 
 	err = config.RemoveContext(name)
@@ -1583,10 +1602,36 @@ func deleteCtx(_ *cobra.Command, args []string) error {
 	}
 
 	deleteKubeconfigContext(ctx)
+	deleteContextPlugins(name)
 	log.Successf("Successfully deleted context %q", name)
 	return nil
 }
 
+// getContextPluginsToUninstall returns the names of the plugins uninstalled along with the context
+func getContextPluginsToUninstall(ctxName string) []string {
+	if keepContextPlugins {
+		return nil
+	}
+	plugins, err := pluginmanager.GetContextPluginsToUninstall(ctxName)
+	if err != nil {
+		log.V(6).Warningf("unable to get the plugins installed for context %q: %v", ctxName, err)
+		return nil
+	}
+	names := make([]string, 0, len(plugins))
+	for i := range plugins {
+		names = append(names, fmt.Sprintf("%s:%s", plugins[i].Name, plugins[i].Target))
+	}
+	return names
+}
+
+// deleteContextPlugins uninstalls the plugins installed for the deleted context which
+// are not needed by any other context, unless they are to be kept as standalone plugins
+func deleteContextPlugins(ctxName string) {
+	if _, err := pluginmanager.OnContextDeleted(ctxName, keepContextPlugins); err != nil {
+		log.Warningf("Failed to uninstall the plugins installed for context %q: %v", ctxName, err)
+	}
+}
+
 func deleteKubeconfigContext(ctx *configtypes.Context) {
 	// Note: currently cleaning up the kubeconfig for tanzu context types only.
 	// (Since the kubernetes context type can have kube context provided by the user, it may not be
@@ -5,14 +5,20 @@ package command
 
 import (
 	"fmt"
+	"net/http"
 	"sort"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 
 	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/config"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/cosignhelper/sigverifier"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/discovery"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/pluginmanager"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/registry"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/utils"
 
 	"github.com/spf13/cobra"
@@ -25,7 +31,8 @@ import (
 )
 
 var (
-	uri string
+	uri             string
+	signaturePolicy string
 )
 
 func newDiscoverySourceCmd() *cobra.Command {
@@ -41,6 +48,7 @@ func newDiscoverySourceCmd() *cobra.Command {
 		newUpdateDiscoverySourceCmd(),
 		newDeleteDiscoverySourceCmd(),
 		newInitDiscoverySourceCmd(),
+		newResetDiscoverySourceCmd(),
 	)
 
 	return discoverySourceCmd
@@ -52,20 +60,42 @@ func newListDiscoverySourceCmd() *cobra.Command {
 		Short:             "List available discovery sources",
 		ValidArgsFunction: noMoreCompletions,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			output := component.NewOutputWriterWithOptions(cmd.OutOrStdout(), outputFormat, []component.OutputWriterOption{}, "name", "image")
+			output := component.NewOutputWriterWithOptions(cmd.OutOrStdout(), outputFormat, []component.OutputWriterOption{},
+				"name", "image", "type", "priority", "lastRefresh", "dbAge", "signature", "status")
+			var unhealthySources []*discovery.SourceHealth
+			// The discovery sources are searched in the order they are listed,
+			// so a plugin found in several of them comes from the first one
+			priority := 0
+			addSource := func(name, displayName, image string) {
+				priority++
+				status := discovery.GetSourceStatus(name, image)
+				output.AddRow(displayName, image, "oci", priority,
+					formatDiscoverySourceTime(status.LastRefresh),
+					formatDiscoverySourceAge(status.InventoryUpdated),
+					getDiscoverySourceSignature(status),
+					getDiscoverySourceStatus(status.Health))
+				if status.Health != nil {
+					unhealthySources = append(unhealthySources, status.Health)
+				}
+			}
+
 			discoverySources, err := configlib.GetCLIDiscoverySources()
 			for _, ds := range discoverySources {
 				if ds.OCI != nil {
-					output.AddRow(ds.OCI.Name, ds.OCI.Image)
+					addSource(ds.OCI.Name, ds.OCI.Name, ds.OCI.Image)
 				}
 			}
 			testPluginSources := pluginmanager.GetAdditionalTestPluginDiscoveries()
 			for _, ds := range testPluginSources {
 				if ds.OCI != nil {
-					output.AddRow(ds.OCI.Name+" (test only)", ds.OCI.Image)
+					addSource(ds.OCI.Name, ds.OCI.Name+" (test only)", ds.OCI.Image)
 				}
 			}
 			output.Render()
+
+			for _, health := range unhealthySources {
+				log.Warningf("discovery source %q failed with: %s", health.Name, health.Reason)
+			}
 			return err
 		},
 	}
@@ -81,12 +111,13 @@ func newUpdateDiscoverySourceCmd() *cobra.Command {
 		Use:   "update SOURCE_NAME --uri <URI>",
 		Short: "Update a discovery source configuration",
 		Long:  "Update a discovery source configuration and refresh the plugin inventory local cache",
-		// We already include the only flag in the use text,
-		// we therefore don't show '[flags]' in the usage text.
-		DisableFlagsInUseLine: true,
 		Example: `
     # Update the discovery source for an air-gapped scenario. The URI must be an OCI image.
-    tanzu plugin source update default --uri registry.example.com/tanzu/plugin-inventory:latest`,
+    tanzu plugin source update default --uri registry.example.com/tanzu/plugin-inventory:latest
+
+    # Only warn if the signature of an internal discovery source cannot be verified.
+    # The signature policy of a discovery source is kept when its URI is updated.
+    tanzu plugin source update dev --uri registry.example.com/dev/plugin-inventory:latest --signature-policy warn`,
 		Args:              cobra.ExactArgs(1),
 		ValidArgsFunction: completeUpdateDiscoverySource,
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -102,6 +133,18 @@ func newUpdateDiscoverySourceCmd() *cobra.Command {
 				return err
 			}
 
+			// The signature policy of the discovery source is kept unless a new one is specified
+			policy := discovery.GetSourceSignaturePolicy(discoveryName)
+			policyChanged := false
+			if cmd.Flags().Changed("signature-policy") {
+				newPolicy, err := sigverifier.ParseSignaturePolicy(signaturePolicy)
+				if err != nil {
+					return err
+				}
+				policyChanged = newPolicy != policy
+				policy = newPolicy
+			}
+
 			// Check the discovery source *before* we save it in the configuration
 			// file. This way, if the discovery source is invalid, we don't save it.
 			// NOTE: We cannot first save and then revert the change if the discovery
@@ -109,7 +152,13 @@ func newUpdateDiscoverySourceCmd() *cobra.Command {
 			// will fail with a call to log.Fatal(), which will exit the program before
 			// we can revert the change; this happens when the discovery source is
 			// not properly signed.
-			err = checkDiscoverySource(newDiscoverySource)
+			// When the signature policy changes, the plugin inventory is downloaded
+			// again to verify its signature as per the new policy.
+			options := []discovery.DiscoveryOptions{discovery.WithSignaturePolicy(policy)}
+			if policyChanged {
+				options = append(options, discovery.WithForceInvalidation())
+			}
+			err = checkDiscoverySource(newDiscoverySource, options...)
 			if err != nil {
 				return err
 			}
@@ -118,6 +167,9 @@ func newUpdateDiscoverySourceCmd() *cobra.Command {
 			if err != nil {
 				return err
 			}
+			if err := discovery.SetSourceSignaturePolicy(discoveryName, policy); err != nil {
+				return errors.Wrapf(err, "failed to save the signature policy of discovery %q", discoveryName)
+			}
 
 			log.Successf("updated discovery source %s", discoveryName)
 			return nil
@@ -129,18 +181,26 @@ func newUpdateDiscoverySourceCmd() *cobra.Command {
 	utils.PanicOnErr(updateDiscoverySourceCmd.RegisterFlagCompletionFunc("uri", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 		return cobra.AppendActiveHelp(nil, "Please enter the uri of the OCI image for plugin discovery"), cobra.ShellCompDirectiveNoFileComp
 	}))
+	updateDiscoverySourceCmd.Flags().StringVar(&signaturePolicy, "signature-policy", "", "How the signature of the discovery source is enforced (require|warn|none), unchanged if not specified")
+	utils.PanicOnErr(updateDiscoverySourceCmd.RegisterFlagCompletionFunc("signature-policy", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{
+			string(sigverifier.SignaturePolicyRequire) + "\tFail if the signature cannot be verified",
+			string(sigverifier.SignaturePolicyWarn) + "\tOnly warn if the signature cannot be verified",
+			string(sigverifier.SignaturePolicyNone) + "\tDo not verify the signature",
+		}, cobra.ShellCompDirectiveNoFileComp
+	}))
 
 	return updateDiscoverySourceCmd
 }
 
 func newDeleteDiscoverySourceCmd() *cobra.Command {
+	var yes bool
+
 	var deleteDiscoverySourceCmd = &cobra.Command{
-		Use:   "delete SOURCE_NAME",
-		Short: "Delete a discovery source",
-		// There are no flags
-		DisableFlagsInUseLine: true,
-		Args:                  cobra.ExactArgs(1),
-		Hidden:                true,
+		Use:    "delete SOURCE_NAME",
+		Short:  "Delete a discovery source",
+		Args:   cobra.ExactArgs(1),
+		Hidden: true,
 		Example: `
     # Delete a discovery source
     tanzu plugin discovery delete default`,
@@ -153,14 +213,21 @@ func newDeleteDiscoverySourceCmd() *cobra.Command {
 				return fmt.Errorf("discovery %q does not exist", discoveryName)
 			}
 
+			if err := confirmDestructiveAction(fmt.Sprintf("Discovery source %q will be deleted. Are you sure?", discoveryName), yes); err != nil {
+				return err
+			}
+
 			err = configlib.DeleteCLIDiscoverySource(discoveryName)
 			if err != nil {
 				return err
 			}
+			_ = discovery.DeleteSourceSignaturePolicy(discoveryName)
 			log.Successf("deleted discovery source %s", discoveryName)
 			return nil
 		},
 	}
+	deleteDiscoverySourceCmd.Flags().BoolVarP(&yes, "yes", "y", false, "delete the discovery source without asking for confirmation")
+
 	return deleteDiscoverySourceCmd
 }
 
@@ -197,12 +264,68 @@ func newInitDiscoverySourceCmd() *cobra.Command {
 	return initDiscoverySourceCmd
 }
 
+func newResetDiscoverySourceCmd() *cobra.Command {
+	var resetDiscoverySourceCmd = &cobra.Command{
+		Use:   "reset SOURCE_NAME",
+		Short: "Reset a discovery source to its default value",
+		Long:  "Reset a discovery source to its default value, clear its plugin inventory local cache and refresh it",
+		// There are no flags
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.ExactArgs(1),
+		Example: `
+    # Reset the default discovery source, e.g. after pointing it to a test repository
+    tanzu plugin source reset default`,
+		ValidArgsFunction: completeResetDiscoverySource,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			discoveryName := args[0]
+			if discoveryName != config.DefaultStandaloneDiscoveryName {
+				return fmt.Errorf("discovery %q has no default value, only discovery %q can be reset", discoveryName, config.DefaultStandaloneDiscoveryName)
+			}
+
+			// Clear the cache of the current image of the discovery source,
+			// which may be broken or unhealthy
+			if discoverySource, _ := configlib.GetCLIDiscoverySource(discoveryName); discoverySource != nil && discoverySource.OCI != nil {
+				if err := discovery.ResetSourceCache(discoveryName, discoverySource.OCI.Image); err != nil {
+					return errors.Wrapf(err, "failed to clear the plugin inventory cache of discovery %q", discoveryName)
+				}
+			}
+
+			// Unlike "plugin source update", the discovery source is saved even if it cannot
+			// be refreshed, e.g. without network access, as its default value is trusted.
+			// Its default value requires a verified signature.
+			err := config.PopulateDefaultCentralDiscovery(true)
+			if err != nil {
+				return err
+			}
+			if err := discovery.DeleteSourceSignaturePolicy(discoveryName); err != nil {
+				return errors.Wrapf(err, "failed to reset the signature policy of discovery %q", discoveryName)
+			}
+			if discoverySource, err := configlib.GetCLIDiscoverySource(discoveryName); err == nil {
+				if err := checkDiscoverySource(*discoverySource); err != nil {
+					log.Warningf("unable to refresh the plugin inventory of discovery %q: %v", discoveryName, err)
+				}
+			}
+
+			log.Successf("reset discovery source %s", discoveryName)
+			return nil
+		},
+	}
+	return resetDiscoverySourceCmd
+}
+
 func createDiscoverySource(dsName, uri string) (configtypes.PluginDiscovery, error) {
 	pluginDiscoverySource := configtypes.PluginDiscovery{}
 
 	if dsName == "" {
 		return pluginDiscoverySource, errors.New("discovery source name cannot be empty")
 	}
+	if uri == "" {
+		return pluginDiscoverySource, errors.New("discovery source URI cannot be empty")
+	}
+	// Validate the image reference before contacting the registry
+	if _, err := registry.GetRegistryName(uri); err != nil {
+		return pluginDiscoverySource, errors.Wrapf(err, "invalid discovery source URI %q, it must be an OCI image", uri)
+	}
 
 	pluginDiscoverySource = configtypes.PluginDiscovery{
 		OCI: &configtypes.OCIDiscovery{
@@ -212,15 +335,79 @@ func createDiscoverySource(dsName, uri string) (configtypes.PluginDiscovery, err
 	return pluginDiscoverySource, nil
 }
 
+// getDiscoverySourceStatus returns the status of a discovery source given its health
+func getDiscoverySourceStatus(health *discovery.SourceHealth) string {
+	if health == nil {
+		return "ok"
+	}
+	status := "unavailable"
+	if health.RateLimited {
+		status = "rate limited"
+	}
+	return fmt.Sprintf("%s, retrying in %s", status, time.Until(health.RetryAfter).Round(time.Second))
+}
+
+// getDiscoverySourceSignature returns the outcome of the signature verification
+// of the cached plugin inventory of a discovery source
+func getDiscoverySourceSignature(status *discovery.SourceStatus) string {
+	if status.Signature == "" {
+		return "unknown"
+	}
+	return string(status.Signature)
+}
+
+// formatDiscoverySourceTime formats a time of a discovery source as a duration
+// in a table and as a timestamp otherwise; the zero time means never
+func formatDiscoverySourceTime(t time.Time) string {
+	if t.IsZero() {
+		return "never"
+	}
+	if isTableOutputFormat() {
+		return formatDiscoverySourceAge(t) + " ago"
+	}
+	return t.Format(time.RFC3339)
+}
+
+// formatDiscoverySourceAge formats the time elapsed since a time of a discovery source
+func formatDiscoverySourceAge(t time.Time) string {
+	if t.IsZero() {
+		return "n/a"
+	}
+	return time.Since(t).Round(time.Second).String()
+}
+
 // checkDiscoverySource attempts to access the content of the discovery to
 // confirm it is valid; this implies refreshing the DB.
-func checkDiscoverySource(source configtypes.PluginDiscovery) error {
+
+func checkDiscoverySource(source configtypes.PluginDiscovery, options ...discovery.DiscoveryOptions) error {
 	// If the URI has changed, the cache will be refreshed automatically.  However, if the URI has not changed,
 	// normally the TTL would be respected and the cache would not be refreshed.  However, we choose to pass
 	// the WithForceRefresh() option to ensure we refresh the DB no matter if the TTL has expired or not.
 	// This provides a way for the user to force a refresh of the DB by running "tanzu plugin source init/update"
 	// without waiting for the TTL to expire.
-	return discovery.RefreshDiscoveryDatabaseForSource(source, discovery.WithForceRefresh())
+	err := discovery.RefreshDiscoveryDatabaseForSource(source, append(options, discovery.WithForceRefresh())...)
+	if err != nil && source.OCI != nil {
+		return explainDiscoverySourceError(source.OCI.Image, err)
+	}
+	return err
+}
+
+// explainDiscoverySourceError adds to the error of a discovery source how to
+// fix it when it is caused by the authentication or the TLS configuration
+// of its registry, which are the most common problems of a new discovery source
+func explainDiscoverySourceError(image string, err error) error {
+	registryName, _ := registry.GetRegistryName(image)
+	msg := err.Error()
+	switch {
+	case strings.Contains(msg, "UNAUTHORIZED") || strings.Contains(msg, "DENIED") ||
+		strings.Contains(msg, strconv.Itoa(http.StatusUnauthorized)+" "+http.StatusText(http.StatusUnauthorized)) ||
+		strings.Contains(msg, strconv.Itoa(http.StatusForbidden)+" "+http.StatusText(http.StatusForbidden)):
+		return errors.Wrapf(err, "unable to authenticate to the registry %q, please log in to it, e.g. with 'docker login %s'", registryName, registryName)
+	case strings.Contains(msg, "x509:") || strings.Contains(msg, "tls:") ||
+		strings.Contains(msg, "server gave HTTP response to HTTPS client"):
+		return errors.Wrapf(err, "unable to establish a secure connection to the registry %q, please configure its certificate with 'tanzu config cert add --host %s'", registryName, registryName)
+	}
+	return err
 }
 
 // ====================================
@@ -244,6 +431,14 @@ func completeDiscoverySources(_ *cobra.Command, args []string, _ string) ([]stri
 	return comps, cobra.ShellCompDirectiveNoFileComp
 }
 
+func completeResetDiscoverySource(_ *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return activeHelpNoMoreArgs(nil), cobra.ShellCompDirectiveNoFileComp
+	}
+	// Only the default discovery source has a default value
+	return []string{fmt.Sprintf("%s\t%s", config.DefaultStandaloneDiscoveryName, config.GetDefaultCentralDiscoveryImage())}, cobra.ShellCompDirectiveNoFileComp
+}
+
 func completeUpdateDiscoverySource(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 	if len(args) > 0 && uri == "" {
 		// The --uri flag is required, so completion will be provided for it
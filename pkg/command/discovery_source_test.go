@@ -5,6 +5,7 @@ package command
 
 import (
 	"bytes"
+	"errors"
 	"io"
 	"os"
 	"path/filepath"
@@ -17,6 +18,7 @@ import (
 	"github.com/vmware-tanzu/tanzu-cli/pkg/common"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/config"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/discovery"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/plugininventory"
 	configlib "github.com/vmware-tanzu/tanzu-plugin-runtime/config"
 	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
@@ -31,7 +33,17 @@ func Test_createDiscoverySource(t *testing.T) {
 	assert.NotNil(err)
 	assert.Equal(err.Error(), "discovery source name cannot be empty")
 
-	// With an invalid image, no error are thrown, as there is no verification in createDiscoverySource()
+	// When discovery source URI is empty
+	_, err = createDiscoverySource("fake-oci-discovery-name", "")
+	assert.NotNil(err)
+	assert.Equal(err.Error(), "discovery source URI cannot be empty")
+
+	// When discovery source URI is not an image reference
+	_, err = createDiscoverySource("fake-oci-discovery-name", "test.registry.com/Test Image:v1.0.0")
+	assert.NotNil(err)
+	assert.Contains(err.Error(), `invalid discovery source URI "test.registry.com/Test Image:v1.0.0", it must be an OCI image`)
+
+	// With an image which does not exist, no error are thrown, as the image is not accessed by createDiscoverySource()
 	pd, err := createDiscoverySource("fake-oci-discovery-name", "test.registry.com/test-image:v1.0.0")
 	assert.Nil(err)
 	assert.NotNil(pd.OCI)
@@ -419,7 +431,7 @@ func Test_deleteDiscoverySource(t *testing.T) {
 		},
 		{
 			test:            "delete success",
-			args:            []string{"plugin", "source", "delete", "default"},
+			args:            []string{"plugin", "source", "delete", "default", "--yes"},
 			expectedFailure: false,
 			expected:        "deleted discovery source",
 		},
@@ -484,6 +496,103 @@ func Test_deleteDiscoverySource(t *testing.T) {
 	os.Unsetenv(constants.EULAPromptAnswer)
 }
 
+func Test_resetDiscoverySource(t *testing.T) {
+	tests := []struct {
+		test            string
+		args            []string
+		expected        string
+		expectedFailure bool
+	}{
+		{
+			test:            "reset missing arg error",
+			args:            []string{"plugin", "source", "reset"},
+			expectedFailure: true,
+			expected:        "accepts 1 arg(s), received 0",
+		},
+		{
+			test:            "reset source without default value",
+			args:            []string{"plugin", "source", "reset", "invalid"},
+			expectedFailure: true,
+			expected:        `discovery "invalid" has no default value, only discovery "default" can be reset`,
+		},
+		{
+			test:            "reset success",
+			args:            []string{"plugin", "source", "reset", "default"},
+			expectedFailure: false,
+			expected:        "reset discovery source default",
+		},
+	}
+
+	configFile, _ := os.CreateTemp("", "config")
+	os.Setenv(configlib.EnvConfigKey, configFile.Name())
+	defer os.RemoveAll(configFile.Name())
+
+	configFileNG, _ := os.CreateTemp("", "config_ng")
+	os.Setenv(configlib.EnvConfigNextGenKey, configFileNG.Name())
+	defer os.RemoveAll(configFileNG.Name())
+
+	os.Setenv(constants.CEIPOptInUserPromptAnswer, "No")
+	os.Setenv(constants.EULAPromptAnswer, "Yes")
+
+	dir, err := os.MkdirTemp("", "test-source")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	common.DefaultCacheDir = dir
+	os.Setenv("TEST_CUSTOM_DATA_STORE_FILE", filepath.Join(dir, "data-store.yaml"))
+	defer os.Unsetenv("TEST_CUSTOM_DATA_STORE_FILE")
+
+	for _, spec := range tests {
+		t.Run(spec.test, func(t *testing.T) {
+			assert := assert.New(t)
+
+			// Break the default discovery source before each test
+			err := configlib.SetCLIDiscoverySource(configtypes.PluginDiscovery{
+				OCI: &configtypes.OCIDiscovery{
+					Name:  config.DefaultStandaloneDiscoveryName,
+					Image: "test/uri",
+				}})
+			assert.Nil(err)
+			staleFile := filepath.Join(dir, common.PluginInventoryDirName, config.DefaultStandaloneDiscoveryName, "digest.stale")
+			assert.Nil(os.MkdirAll(filepath.Dir(staleFile), 0755))
+			assert.Nil(os.WriteFile(staleFile, []byte("test/uri"), 0600))
+
+			rootCmd, err := NewRootCmdForTest()
+			assert.Nil(err)
+			rootCmd.SetArgs(spec.args)
+			b := bytes.NewBufferString("")
+			rootCmd.SetOut(b)
+			rootCmd.SetErr(b)
+			log.SetStdout(b)
+			log.SetStderr(b)
+
+			err = rootCmd.Execute()
+			assert.Equal(err != nil, spec.expectedFailure)
+
+			if spec.expectedFailure {
+				// Check we got the correct error
+				assert.Contains(err.Error(), spec.expected)
+				return
+			}
+			got, err := io.ReadAll(b)
+			assert.Nil(err)
+			assert.Contains(string(got), spec.expected)
+
+			// Check that the default discovery source was restored
+			ds, err := configlib.GetCLIDiscoverySource(config.DefaultStandaloneDiscoveryName)
+			assert.Nil(err)
+			assert.Equal(constants.TanzuCLIDefaultCentralPluginDiscoveryImage, ds.OCI.Image)
+
+			// Check that the cache of the broken discovery source was cleared
+			_, err = os.Stat(staleFile)
+			assert.True(os.IsNotExist(err))
+		})
+	}
+	os.Unsetenv(configlib.EnvConfigKey)
+	os.Unsetenv(configlib.EnvConfigNextGenKey)
+	os.Unsetenv(constants.CEIPOptInUserPromptAnswer)
+	os.Unsetenv(constants.EULAPromptAnswer)
+}
+
 func TestCompletionPluginSource(t *testing.T) {
 	// This is global logic and needs not be tested for each
 	// command.  Let's deactivate it.
@@ -567,6 +676,22 @@ func TestCompletionPluginSource(t *testing.T) {
 			// ":4" is the value of the ShellCompDirectiveNoFileComp
 			expected: "_activeHelp_ " + compNoMoreArgsMsg + "\n:4\n",
 		},
+		// =========================
+		// tanzu plugin source reset
+		// =========================
+		{
+			test: "completion for the source reset command",
+			args: []string{"__complete", "plugin", "source", "reset", ""},
+			// ":4" is the value of the ShellCompDirectiveNoFileComp
+			expected: "default\t" + constants.TanzuCLIDefaultCentralPluginDiscoveryImage + "\n" +
+				":4\n",
+		},
+		{
+			test: "no completion after the first arg of the source reset command",
+			args: []string{"__complete", "plugin", "source", "reset", "default", ""},
+			// ":4" is the value of the ShellCompDirectiveNoFileComp
+			expected: "_activeHelp_ " + compNoMoreArgsMsg + "\n:4\n",
+		},
 	}
 
 	// Setup a plugin source and a set of installed plugins
@@ -594,3 +719,62 @@ func TestCompletionPluginSource(t *testing.T) {
 
 	os.Unsetenv("TANZU_ACTIVE_HELP")
 }
+
+func Test_getDiscoverySourceStatus(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("ok", getDiscoverySourceStatus(nil))
+	assert.Equal("unavailable, retrying in 5m0s", getDiscoverySourceStatus(&discovery.SourceHealth{
+		RetryAfter: time.Now().Add(5*time.Minute + 200*time.Millisecond),
+	}))
+	assert.Equal("rate limited, retrying in 15m0s", getDiscoverySourceStatus(&discovery.SourceHealth{
+		RateLimited: true,
+		RetryAfter:  time.Now().Add(15*time.Minute + 200*time.Millisecond),
+	}))
+}
+
+func Test_formatDiscoverySourceTime(t *testing.T) {
+	assert := assert.New(t)
+	defer func() { outputFormat = "" }()
+
+	assert.Equal("never", formatDiscoverySourceTime(time.Time{}))
+	assert.Equal("n/a", formatDiscoverySourceAge(time.Time{}))
+
+	lastRefresh := time.Now().Add(-time.Hour)
+	outputFormat = ""
+	assert.Equal("1h0m0s ago", formatDiscoverySourceTime(lastRefresh))
+	assert.Equal("1h0m0s", formatDiscoverySourceAge(lastRefresh))
+	outputFormat = "json"
+	assert.Equal(lastRefresh.Format(time.RFC3339), formatDiscoverySourceTime(lastRefresh))
+}
+
+func Test_explainDiscoverySourceError(t *testing.T) {
+	tests := []struct {
+		test     string
+		err      error
+		expected string
+	}{
+		{
+			test:     "authentication error",
+			err:      errors.New("GET https://test.registry.com/v2/test-image/manifests/latest: UNAUTHORIZED: authentication required"),
+			expected: `unable to authenticate to the registry "test.registry.com", please log in to it, e.g. with 'docker login test.registry.com': GET https://test.registry.com/v2/test-image/manifests/latest: UNAUTHORIZED: authentication required`,
+		},
+		{
+			test:     "TLS error",
+			err:      errors.New("Get \"https://test.registry.com/v2/\": tls: failed to verify certificate: x509: certificate signed by unknown authority"),
+			expected: `unable to establish a secure connection to the registry "test.registry.com", please configure its certificate with 'tanzu config cert add --host test.registry.com': Get "https://test.registry.com/v2/": tls: failed to verify certificate: x509: certificate signed by unknown authority`,
+		},
+		{
+			test:     "other error",
+			err:      errors.New("dial tcp: lookup test.registry.com: no such host"),
+			expected: "dial tcp: lookup test.registry.com: no such host",
+		},
+	}
+
+	for _, spec := range tests {
+		t.Run(spec.test, func(t *testing.T) {
+			err := explainDiscoverySourceError("test.registry.com/test-image:latest", spec.err)
+			assert.EqualError(t, err, spec.expected)
+		})
+	}
+}
@@ -0,0 +1,36 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package command
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+)
+
+const noHintsFlagDesc = "do not print the hints following the output of the command"
+
+// noHints disables the printing of hints
+var noHints bool
+
+// addNoHintsFlag adds the --no-hints flag to a command printing hints
+func addNoHintsFlag(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&noHints, "no-hints", false, noHintsFlagDesc)
+}
+
+// printHints prints advisory messages, such as the suggestion of a related command.
+// Hints are not part of the output of a command and should therefore be printed to
+// stderr, so that programs consuming the output of the command are not affected.
+// Hints are only printed along with the table output format and can be disabled
+// using the --no-hints flag.
+func printHints(writer io.Writer, hints ...string) {
+	if noHints || !isTableOutputFormat() || len(hints) == 0 {
+		return
+	}
+	fmt.Fprintln(writer)
+	for _, hint := range hints {
+		fmt.Fprintln(writer, hint)
+	}
+}
@@ -4,32 +4,294 @@
 package command
 
 import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
 
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/component"
+	configlib "github.com/vmware-tanzu/tanzu-plugin-runtime/config"
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
 	"github.com/vmware-tanzu/tanzu-plugin-runtime/plugin"
 
 	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
-	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/config"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/internal/tty"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/plugininventory"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/pluginmanager"
 )
 
+const (
+	// initAnswerNone is the answer skipping the installation of a plugin group or of the shell completion
+	initAnswerNone = "none"
+	initAnswerYes  = "Yes"
+	initAnswerNo   = "No"
+)
+
+// initAnswers are the answers to the questions of 'tanzu init', read from the
+// answers file when the command is not run interactively, e.g.:
+//
+//	acceptEula: true
+//	centralRepository: registry.example.com/tanzu/plugin-inventory:latest
+//	pluginGroup: vmware-tkg/default:v2.1.0
+//	shellCompletion: zsh
+type initAnswers struct {
+	// AcceptEULA accepts the EULA, which is required to use the CLI
+	AcceptEULA bool `yaml:"acceptEula"`
+	// CentralRepository is the image of the plugin inventory of the central repository
+	// to use, the default central repository being used if empty
+	CentralRepository string `yaml:"centralRepository"`
+	// PluginGroup is the plugin group whose plugins are installed, if not empty or "none"
+	PluginGroup string `yaml:"pluginGroup"`
+	// ShellCompletion is the shell for which the completion is installed, if not empty or "none"
+	ShellCompletion string `yaml:"shellCompletion"`
+}
+
+// promptForInitAnswer prompts the user
+var promptForInitAnswer = component.Prompt
+
 func newInitCmd() *cobra.Command {
+	var nonInteractive bool
+	var answersFile string
+	var profileFile string
+
 	var initCmd = &cobra.Command{
-		Use:    "init",
-		Hidden: true,
-		Short:  "Initialize the CLI",
+		Use:   "init",
+		Short: "Initialize the CLI",
+		Long: "Walk through the setup of the CLI: accepting the EULA, choosing the central repository of the plugins, " +
+			"installing the plugins of a plugin group and installing the shell completion. The setup can be " +
+			"provided through an answers file for machines provisioned without user interaction. " +
+			"Alternatively, a bootstrap profile declaring the sources, feature flags, plugin groups, pinned plugins " +
+			"and contexts of the CLI can be applied, skipping what is already set up.",
+		Example: `
+    # Set up the CLI interactively
+    tanzu init
+
+    # Set up the CLI non-interactively, e.g., when provisioning a machine
+    tanzu init --non-interactive --answers-file answers.yaml
+
+    # Where answers.yaml contains:
+    acceptEula: true
+    centralRepository: registry.example.com/tanzu/plugin-inventory:latest
+    pluginGroup: vmware-tkg/default:v2.1.0
+    shellCompletion: bash
+
+    # Apply a machine bootstrap profile, e.g., when building a golden image
+    tanzu init --profile profile.yaml`,
 		Annotations: map[string]string{
 			"group": string(plugin.SystemCmdGroup),
 		},
 		SilenceErrors:     true,
+		Args:              cobra.NoArgs,
 		ValidArgsFunction: noMoreCompletions,
-		RunE: func(_ *cobra.Command, _ []string) error {
-			// Currently nothing to initialize.
-			// We are keeping this command as it may become useful
-			// again in the future.
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if profileFile != "" {
+				profile, err := readInitProfile(profileFile)
+				if err != nil {
+					return err
+				}
+				if err := applyInitProfile(profile); err != nil {
+					return err
+				}
+				log.Successf("successfully applied the profile %s", profileFile)
+				return nil
+			}
+
+			if !nonInteractive && answersFile != "" {
+				return errors.New("the --answers-file flag can only be used with the --non-interactive flag")
+			}
+
+			answers := &initAnswers{}
+			if nonInteractive {
+				if answersFile != "" {
+					var err error
+					if answers, err = readInitAnswers(answersFile); err != nil {
+						return err
+					}
+				}
+			} else if !tty.CanPrompt() {
+				return errors.New("the CLI is not running interactively, please use the '--non-interactive' flag along with an answers file")
+			}
+
+			if err := initEULA(answers, nonInteractive); err != nil {
+				return err
+			}
+			if err := initCentralRepository(answers, nonInteractive); err != nil {
+				return err
+			}
+			if err := initPluginGroup(cmd, answers, nonInteractive); err != nil {
+				return err
+			}
+			if err := initShellCompletion(cmd, answers, nonInteractive); err != nil {
+				return err
+			}
+
 			log.Success("successfully initialized CLI")
 			return nil
 		},
 	}
+	initCmd.Flags().BoolVar(&nonInteractive, "non-interactive", false, "do not prompt, using the answers file or else the default answers")
+	initCmd.Flags().StringVar(&answersFile, "answers-file", "", "YAML file providing the answers when running non-interactively")
+	initCmd.Flags().StringVar(&profileFile, "profile", "", "YAML bootstrap profile to apply, without prompting")
+	initCmd.MarkFlagsMutuallyExclusive("profile", "answers-file")
+	initCmd.MarkFlagsMutuallyExclusive("profile", "non-interactive")
 	initCmd.SetUsageFunc(cli.SubCmdUsageFunc)
 	return initCmd
 }
+
+// readInitAnswers reads and validates the answers file of 'tanzu init'
+func readInitAnswers(file string) (*initAnswers, error) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to read the answers file %q", file)
+	}
+	answers := &initAnswers{}
+	decoder := yaml.NewDecoder(strings.NewReader(string(content)))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(answers); err != nil {
+		return nil, errors.Wrapf(err, "invalid answers file %q", file)
+	}
+
+	if answers.PluginGroup != "" && answers.PluginGroup != initAnswerNone && plugininventory.PluginGroupIdentifierFromID(answers.PluginGroup) == nil {
+		return nil, errors.Errorf("invalid plugin group %q in the answers file, the format is vendor-publisher/name[:version]", answers.PluginGroup)
+	}
+	shell := strings.ToLower(answers.ShellCompletion)
+	if shell != "" && shell != initAnswerNone && !slices.Contains(completionShells, shell) {
+		return nil, errors.Errorf("unsupported shell %q in the answers file, please specify one of: %v, %s", answers.ShellCompletion, strings.Join(completionShells, ", "), initAnswerNone)
+	}
+	return answers, nil
+}
+
+// initEULA prompts the user to accept the EULA, or accepts it based on the answers
+func initEULA(answers *initAnswers, nonInteractive bool) error {
+	if nonInteractive {
+		if answers.AcceptEULA {
+			if err := config.UpdateEULAAcceptance(configlib.EULAStatusAccepted); err != nil {
+				return err
+			}
+		}
+	} else if err := config.ConfigureEULA(false); err != nil {
+		return err
+	}
+
+	if status, _ := configlib.GetEULAStatus(); status != configlib.EULAStatusAccepted {
+		if nonInteractive {
+			return errors.New("the EULA must be accepted to use the CLI, please set 'acceptEula: true' in the answers file")
+		}
+		return errors.New("the EULA must be accepted to use the CLI, please use 'tanzu config eula show' to review it")
+	}
+	return nil
+}
+
+// initCentralRepository configures the central repository, asking the user for the image of its
+// plugin inventory, and installs the essential plugins from it
+func initCentralRepository(answers *initAnswers, nonInteractive bool) error {
+	defaultImage := config.GetDefaultCentralDiscoveryImage()
+	image := answers.CentralRepository
+	if !nonInteractive {
+		if current, _ := configlib.GetCLIDiscoverySource(config.DefaultStandaloneDiscoveryName); current != nil && current.OCI != nil {
+			defaultImage = current.OCI.Image
+		}
+		err := promptForInitAnswer(&component.PromptConfig{
+			Message: "Image of the plugin inventory of the central repository",
+			Default: defaultImage,
+		}, &image)
+		if err != nil {
+			return errors.Wrap(err, "prompt failed")
+		}
+	}
+	image = strings.TrimSpace(image)
+
+	if image == "" || image == config.GetDefaultCentralDiscoveryImage() {
+		if err := config.PopulateDefaultCentralDiscovery(true); err != nil {
+			return err
+		}
+		if discoverySource, err := configlib.GetCLIDiscoverySource(config.DefaultStandaloneDiscoveryName); err == nil {
+			if err := checkDiscoverySource(*discoverySource); err != nil {
+				log.Warningf("unable to refresh the plugin inventory of the central repository: %v", err)
+			}
+		}
+	} else {
+		discoverySource, err := createDiscoverySource(config.DefaultStandaloneDiscoveryName, image)
+		if err != nil {
+			return err
+		}
+		// As for "plugin source update", the source is only saved if it is valid
+		if err := checkDiscoverySource(discoverySource); err != nil {
+			return err
+		}
+		if err := configlib.SetCLIDiscoverySource(discoverySource); err != nil {
+			return err
+		}
+	}
+	log.Successf("using the central repository %s", image)
+
+	// The essential plugins are not installed before this command runs,
+	// as they must come from the central repository chosen by the user
+	installEssentialPlugins()
+	return nil
+}
+
+// initPluginGroup installs the plugins of the plugin group chosen by the user
+func initPluginGroup(cmd *cobra.Command, answers *initAnswers, nonInteractive bool) error {
+	groupID := answers.PluginGroup
+	if !nonInteractive {
+		groups, err := pluginmanager.DiscoverPluginGroups()
+		if err != nil {
+			log.Warningf("unable to discover the plugin groups: %v", err)
+			return nil
+		}
+		options := []string{initAnswerNone}
+		for _, pg := range groups {
+			options = append(options, plugininventory.PluginGroupToID(pg))
+		}
+		err = promptForInitAnswer(&component.PromptConfig{
+			Message: "Plugin group to install",
+			Options: options,
+			Default: initAnswerNone,
+		}, &groupID)
+		if err != nil {
+			return errors.Wrap(err, "prompt failed")
+		}
+	}
+	if groupID == "" || groupID == initAnswerNone {
+		return nil
+	}
+
+	// Install all the plugins of the group as 'tanzu plugin install --group' does
+	defer func(previous string) { group = previous }(group)
+	group = groupID
+	return installPluginsForPluginGroup(cmd, nil)
+}
+
+// initShellCompletion installs the shell completion if the user wants it
+func initShellCompletion(cmd *cobra.Command, answers *initAnswers, nonInteractive bool) error {
+	shell := strings.ToLower(answers.ShellCompletion)
+	if !nonInteractive {
+		shell = detectShell()
+		if shell == "" || !slices.Contains(completionShells, shell) {
+			log.Infof("Shell completion can be installed later using 'tanzu completion install SHELL'")
+			return nil
+		}
+		answer := initAnswerYes
+		err := promptForInitAnswer(&component.PromptConfig{
+			Message: fmt.Sprintf("Install shell completion for %s", shell),
+			Options: []string{initAnswerYes, initAnswerNo},
+			Default: initAnswerYes,
+		}, &answer)
+		if err != nil {
+			return errors.Wrap(err, "prompt failed")
+		}
+		if answer != initAnswerYes {
+			return nil
+		}
+	}
+	if shell == "" || shell == initAnswerNone {
+		return nil
+	}
+	return installShellCompletion(cmd, []string{shell})
+}
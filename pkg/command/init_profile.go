@@ -0,0 +1,316 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package command
+
+import (
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+
+	configlib "github.com/vmware-tanzu/tanzu-plugin-runtime/config"
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
+
+	kubecfg "github.com/vmware-tanzu/tanzu-cli/pkg/auth/utils/kubeconfig"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/common"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/config"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/plugininventory"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/pluginmanager"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/pluginsupplier"
+)
+
+// initProfile is a machine bootstrap profile applied by 'tanzu init --profile'.
+// It declares the state of the CLI to reach, so that applying it again on a
+// machine that is already set up does not change anything, e.g.:
+//
+//	acceptEula: true
+//	sources:
+//	  - name: default
+//	    image: registry.example.com/tanzu/plugin-inventory:latest
+//	features:
+//	  global:
+//	    context-target-v2: true
+//	pluginGroups:
+//	  - vmware-tkg/default:v2.1.0
+//	plugins:
+//	  - name: package
+//	    target: kubernetes
+//	    version: v0.32.1
+//	contexts:
+//	  - name: dev
+//	    kubeconfig: /home/user/.kube/config
+//	    kubecontext: dev-admin
+//	    current: true
+type initProfile struct {
+	// AcceptEULA accepts the EULA, which is required to use the CLI
+	AcceptEULA bool `yaml:"acceptEula"`
+	// Sources are the plugin discovery sources to configure
+	Sources []initProfileSource `yaml:"sources"`
+	// Features are the feature flags to set, by plugin, "global" being used for the CLI itself
+	Features map[string]map[string]bool `yaml:"features"`
+	// PluginGroups are the plugin groups whose plugins are installed
+	PluginGroups []string `yaml:"pluginGroups"`
+	// Plugins are the plugins to install, pinned to a specific version
+	Plugins []initProfilePlugin `yaml:"plugins"`
+	// Contexts are the kubernetes contexts to create from kubeconfig files
+	Contexts []initProfileContext `yaml:"contexts"`
+}
+
+// initProfileSource is a plugin discovery source of a bootstrap profile
+type initProfileSource struct {
+	Name  string `yaml:"name"`
+	Image string `yaml:"image"`
+}
+
+// initProfilePlugin is a plugin version of a bootstrap profile
+type initProfilePlugin struct {
+	Name    string `yaml:"name"`
+	Target  string `yaml:"target"`
+	Version string `yaml:"version"`
+}
+
+// initProfileContext is a kubernetes context of a bootstrap profile
+type initProfileContext struct {
+	Name string `yaml:"name"`
+	// Kubeconfig is the path to the kubeconfig file, the default kubeconfig file being used if empty
+	Kubeconfig  string `yaml:"kubeconfig"`
+	Kubecontext string `yaml:"kubecontext"`
+	// Current makes the context the active kubernetes context
+	Current bool `yaml:"current"`
+}
+
+// readInitProfile reads and validates a bootstrap profile
+func readInitProfile(file string) (*initProfile, error) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to read the profile %q", file)
+	}
+	profile := &initProfile{}
+	decoder := yaml.NewDecoder(strings.NewReader(string(content)))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(profile); err != nil {
+		return nil, errors.Wrapf(err, "invalid profile %q", file)
+	}
+	if err := profile.validate(); err != nil {
+		return nil, errors.Wrapf(err, "invalid profile %q", file)
+	}
+	return profile, nil
+}
+
+// validate verifies the whole profile before anything is applied,
+// so that an invalid profile leaves the machine untouched
+func (p *initProfile) validate() error {
+	sourceNames := map[string]bool{}
+	for _, source := range p.Sources {
+		if source.Name == "" || source.Image == "" {
+			return errors.New("a source requires a name and an image")
+		}
+		if sourceNames[source.Name] {
+			return errors.Errorf("source %q is specified more than once", source.Name)
+		}
+		sourceNames[source.Name] = true
+	}
+	for plugin, features := range p.Features {
+		if plugin == "" {
+			return errors.New("feature flags require a plugin name, or 'global' for the CLI")
+		}
+		if _, found := features[""]; found {
+			return errors.Errorf("a feature flag of %q has no name", plugin)
+		}
+	}
+	for _, groupID := range p.PluginGroups {
+		if plugininventory.PluginGroupIdentifierFromID(groupID) == nil {
+			return errors.Errorf("invalid plugin group %q, the format is vendor-publisher/name[:version]", groupID)
+		}
+	}
+	for _, plugin := range p.Plugins {
+		if plugin.Name == "" || plugin.Version == "" {
+			return errors.New("a plugin requires a name and a version")
+		}
+		if !configtypes.IsValidTarget(plugin.Target, true, true) {
+			return errors.Errorf("invalid target %q for plugin %q, please specify one of '%s'", plugin.Target, plugin.Name, common.TargetList)
+		}
+	}
+	contextNames := map[string]bool{}
+	hasCurrent := false
+	for _, ctx := range p.Contexts {
+		if ctx.Name == "" || ctx.Kubecontext == "" {
+			return errors.New("a context requires a name and a kubecontext")
+		}
+		if contextNames[ctx.Name] {
+			return errors.Errorf("context %q is specified more than once", ctx.Name)
+		}
+		contextNames[ctx.Name] = true
+		if ctx.Current {
+			if hasCurrent {
+				return errors.New("only one context can be the current context")
+			}
+			hasCurrent = true
+		}
+	}
+	return nil
+}
+
+// applyInitProfile applies a bootstrap profile, skipping what is already set up
+func applyInitProfile(profile *initProfile) error {
+	if err := applyProfileEULA(profile); err != nil {
+		return err
+	}
+	if err := applyProfileSources(profile); err != nil {
+		return err
+	}
+	if err := applyProfileFeatures(profile); err != nil {
+		return err
+	}
+	if err := applyProfilePluginGroups(profile); err != nil {
+		return err
+	}
+	if err := applyProfilePlugins(profile); err != nil {
+		return err
+	}
+	return applyProfileContexts(profile)
+}
+
+func applyProfileEULA(profile *initProfile) error {
+	if status, _ := configlib.GetEULAStatus(); status == configlib.EULAStatusAccepted {
+		return nil
+	}
+	if !profile.AcceptEULA {
+		return errors.New("the EULA must be accepted to use the CLI, please set 'acceptEula: true' in the profile")
+	}
+	return config.UpdateEULAAcceptance(configlib.EULAStatusAccepted)
+}
+
+func applyProfileSources(profile *initProfile) error {
+	for _, source := range profile.Sources {
+		if current, _ := configlib.GetCLIDiscoverySource(source.Name); current != nil && current.OCI != nil && current.OCI.Image == source.Image {
+			log.Infof("discovery source %s is already configured", source.Name)
+			continue
+		}
+		discoverySource, err := createDiscoverySource(source.Name, source.Image)
+		if err != nil {
+			return err
+		}
+		// As for "plugin source update", the source is only saved if it is valid
+		if err := checkDiscoverySource(discoverySource); err != nil {
+			return err
+		}
+		if err := configlib.SetCLIDiscoverySource(discoverySource); err != nil {
+			return err
+		}
+		log.Successf("configured discovery source %s", source.Name)
+	}
+
+	// The essential plugins are not installed before this command runs,
+	// as they must come from the sources of the profile
+	installEssentialPlugins()
+	return nil
+}
+
+func applyProfileFeatures(profile *initProfile) error {
+	currentFeatures, _ := configlib.GetAllFeatureFlags()
+	for plugin, features := range profile.Features {
+		for feature, activated := range features {
+			value := strconv.FormatBool(activated)
+			if currentFeatures[plugin][feature] == value {
+				continue
+			}
+			if err := configlib.SetFeature(plugin, feature, value); err != nil {
+				return err
+			}
+			log.Successf("set feature flag features.%s.%s to %s", plugin, feature, value)
+		}
+	}
+	return nil
+}
+
+func applyProfilePluginGroups(profile *initProfile) error {
+	for _, groupID := range profile.PluginGroups {
+		pg, err := pluginmanager.GetPluginGroup(groupID)
+		if err != nil {
+			return err
+		}
+		groupIDAndVersion := plugininventory.PluginGroupToID(pg) + ":" + pg.RecommendedVersion
+		if isPluginGroupInstalled(pg) {
+			log.Infof("the plugins of group '%s' are already installed", groupIDAndVersion)
+			continue
+		}
+		if _, err := pluginmanager.InstallPluginsFromGivenPluginGroup(cli.AllPlugins, groupIDAndVersion, pg); err != nil {
+			return err
+		}
+		log.Successf("successfully installed all plugins from group '%s'", groupIDAndVersion)
+	}
+	return nil
+}
+
+// isPluginGroupInstalled checks if the mandatory plugins of the recommended
+// version of the plugin group are installed
+func isPluginGroupInstalled(pg *plugininventory.PluginGroup) bool {
+	for _, plugin := range pg.Versions[pg.RecommendedVersion] {
+		if plugin.Mandatory && !pluginsupplier.IsPluginInstalled(plugin.Name, plugin.Target, plugin.Version) {
+			return false
+		}
+	}
+	return true
+}
+
+func applyProfilePlugins(profile *initProfile) error {
+	for _, plugin := range profile.Plugins {
+		target := configtypes.StringToTarget(strings.ToLower(plugin.Target))
+		if pluginsupplier.IsPluginInstalled(plugin.Name, target, plugin.Version) {
+			log.Infof("plugin '%s:%s' is already installed", plugin.Name, plugin.Version)
+			continue
+		}
+		if err := pluginmanager.InstallStandalonePlugin(plugin.Name, plugin.Version, target); err != nil {
+			return err
+		}
+		log.Successf("successfully installed '%s' plugin", plugin.Name)
+	}
+	return nil
+}
+
+func applyProfileContexts(profile *initProfile) error {
+	for _, ctx := range profile.Contexts {
+		kubeconfig := ctx.Kubeconfig
+		if kubeconfig == "" {
+			kubeconfig = kubecfg.GetDefaultKubeConfigFile()
+		}
+
+		current, _ := configlib.GetContext(ctx.Name)
+		if current != nil && current.ContextType != configtypes.ContextTypeK8s {
+			return errors.Errorf("context %q already exists and is not a kubernetes context", ctx.Name)
+		}
+		if current == nil || current.ClusterOpts == nil || current.ClusterOpts.Path != kubeconfig || current.ClusterOpts.Context != ctx.Kubecontext {
+			// The cluster is not contacted, as it is often not reachable when provisioning the machine
+			context := &configtypes.Context{
+				Name:        ctx.Name,
+				ContextType: configtypes.ContextTypeK8s,
+				ClusterOpts: &configtypes.ClusterServer{
+					Path:                kubeconfig,
+					Context:             ctx.Kubecontext,
+					IsManagementCluster: true,
+				},
+			}
+			if err := configlib.SetContext(context, false); err != nil {
+				return err
+			}
+			log.Successf("configured context %s", ctx.Name)
+		}
+
+		if ctx.Current {
+			active, _ := configlib.GetActiveContext(configtypes.ContextTypeK8s)
+			if active == nil || active.Name != ctx.Name {
+				if err := configlib.SetActiveContext(ctx.Name); err != nil {
+					return err
+				}
+				log.Successf("activated context %s", ctx.Name)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,156 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package command
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	configlib "github.com/vmware-tanzu/tanzu-plugin-runtime/config"
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/config"
+)
+
+func TestReadInitProfile(t *testing.T) {
+	tests := []struct {
+		test     string
+		content  string
+		expected *initProfile
+		errStr   string
+	}{
+		{
+			test: "complete profile",
+			content: `acceptEula: true
+sources:
+  - name: default
+    image: registry.example.com/tanzu/plugin-inventory:latest
+features:
+  global:
+    context-target-v2: true
+pluginGroups:
+  - vmware-tkg/default:v2.1.0
+plugins:
+  - name: package
+    target: kubernetes
+    version: v0.32.1
+contexts:
+  - name: dev
+    kubeconfig: /tmp/kubeconfig
+    kubecontext: dev-admin
+    current: true`,
+			expected: &initProfile{
+				AcceptEULA:   true,
+				Sources:      []initProfileSource{{Name: "default", Image: "registry.example.com/tanzu/plugin-inventory:latest"}},
+				Features:     map[string]map[string]bool{"global": {"context-target-v2": true}},
+				PluginGroups: []string{"vmware-tkg/default:v2.1.0"},
+				Plugins:      []initProfilePlugin{{Name: "package", Target: "kubernetes", Version: "v0.32.1"}},
+				Contexts:     []initProfileContext{{Name: "dev", Kubeconfig: "/tmp/kubeconfig", Kubecontext: "dev-admin", Current: true}},
+			},
+		},
+		{
+			test:    "unknown field",
+			content: "plugin-groups: []",
+			errStr:  "field plugin-groups not found",
+		},
+		{
+			test:    "duplicate source",
+			content: "sources:\n  - name: default\n    image: a/b:c\n  - name: default\n    image: d/e:f",
+			errStr:  `source "default" is specified more than once`,
+		},
+		{
+			test:    "invalid plugin group",
+			content: "pluginGroups: [default]",
+			errStr:  `invalid plugin group "default"`,
+		},
+		{
+			test:    "plugin without version",
+			content: "plugins:\n  - name: package",
+			errStr:  "a plugin requires a name and a version",
+		},
+		{
+			test:    "invalid plugin target",
+			content: "plugins:\n  - name: package\n    target: cloud\n    version: v1.0.0",
+			errStr:  `invalid target "cloud" for plugin "package"`,
+		},
+		{
+			test:    "two current contexts",
+			content: "contexts:\n  - {name: a, kubecontext: a, current: true}\n  - {name: b, kubecontext: b, current: true}",
+			errStr:  "only one context can be the current context",
+		},
+	}
+
+	for _, spec := range tests {
+		t.Run(spec.test, func(t *testing.T) {
+			assert := assert.New(t)
+
+			profileFile := filepath.Join(t.TempDir(), "profile.yaml")
+			assert.Nil(os.WriteFile(profileFile, []byte(spec.content), 0o600))
+
+			profile, err := readInitProfile(profileFile)
+			if spec.errStr != "" {
+				assert.NotNil(err)
+				assert.Contains(err.Error(), spec.errStr)
+			} else {
+				assert.Nil(err)
+				assert.Equal(spec.expected, profile)
+			}
+		})
+	}
+}
+
+func TestApplyInitProfileIsIdempotent(t *testing.T) {
+	assert := assert.New(t)
+
+	dir := t.TempDir()
+	t.Setenv("TANZU_CONFIG", filepath.Join(dir, "config.yaml"))
+	t.Setenv("TANZU_CONFIG_NEXT_GEN", filepath.Join(dir, "config-ng.yaml"))
+
+	profile := &initProfile{
+		AcceptEULA: true,
+		Features:   map[string]map[string]bool{"global": {"some-feature": true}},
+		Contexts: []initProfileContext{
+			{Name: "dev", Kubeconfig: "/tmp/kubeconfig", Kubecontext: "dev-admin", Current: true},
+			{Name: "prod", Kubeconfig: "/tmp/kubeconfig", Kubecontext: "prod-admin"},
+		},
+	}
+
+	// Applying the profile twice must lead to the same configuration
+	for i := 0; i < 2; i++ {
+		assert.Nil(applyProfileEULA(profile))
+		assert.Nil(applyProfileFeatures(profile))
+		assert.Nil(applyProfileContexts(profile))
+
+		status, err := configlib.GetEULAStatus()
+		assert.Nil(err)
+		assert.Equal(configlib.EULAStatusAccepted, status)
+
+		enabled, err := configlib.IsFeatureEnabled("global", "some-feature")
+		assert.Nil(err)
+		assert.True(enabled)
+
+		contexts, err := configlib.GetContextsByType(configtypes.ContextTypeK8s)
+		assert.Nil(err)
+		assert.Len(contexts, 2)
+
+		active, err := configlib.GetActiveContext(configtypes.ContextTypeK8s)
+		assert.Nil(err)
+		assert.Equal("dev", active.Name)
+		assert.Equal("dev-admin", active.ClusterOpts.Context)
+	}
+
+	// A context of the profile which changed is updated
+	profile.Contexts[1].Kubecontext = "prod-viewer"
+	assert.Nil(applyProfileContexts(profile))
+	ctx, err := configlib.GetContext("prod")
+	assert.Nil(err)
+	assert.Equal("prod-viewer", ctx.ClusterOpts.Context)
+
+	// The EULA must be accepted by the profile if it was not already
+	assert.Nil(config.UpdateEULAAcceptance(configlib.EULAStatusShown))
+	assert.ErrorContains(applyProfileEULA(&initProfile{}), "the EULA must be accepted to use the CLI")
+}
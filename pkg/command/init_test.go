@@ -5,6 +5,7 @@ package command
 import (
 	"bytes"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -48,3 +49,116 @@ func TestCompletionInit(t *testing.T) {
 
 	os.Unsetenv("TANZU_ACTIVE_HELP")
 }
+
+func TestReadInitAnswers(t *testing.T) {
+	tests := []struct {
+		test     string
+		content  string
+		expected *initAnswers
+		errStr   string
+	}{
+		{
+			test: "all the answers",
+			content: `acceptEula: true
+centralRepository: registry.example.com/tanzu/plugin-inventory:latest
+pluginGroup: vmware-tkg/default:v2.1.0
+shellCompletion: zsh`,
+			expected: &initAnswers{
+				AcceptEULA:        true,
+				CentralRepository: "registry.example.com/tanzu/plugin-inventory:latest",
+				PluginGroup:       "vmware-tkg/default:v2.1.0",
+				ShellCompletion:   "zsh",
+			},
+		},
+		{
+			test:     "no plugin group and no shell completion",
+			content:  "acceptEula: true\npluginGroup: none\nshellCompletion: none",
+			expected: &initAnswers{AcceptEULA: true, PluginGroup: "none", ShellCompletion: "none"},
+		},
+		{
+			test:    "unknown question",
+			content: "acceptEULA: true",
+			errStr:  "field acceptEULA not found",
+		},
+		{
+			test:    "invalid plugin group",
+			content: "pluginGroup: default",
+			errStr:  `invalid plugin group "default" in the answers file`,
+		},
+		{
+			test:    "unsupported shell",
+			content: "shellCompletion: tcsh",
+			errStr:  `unsupported shell "tcsh" in the answers file`,
+		},
+	}
+
+	for _, spec := range tests {
+		t.Run(spec.test, func(t *testing.T) {
+			assert := assert.New(t)
+
+			answersFile := filepath.Join(t.TempDir(), "answers.yaml")
+			assert.Nil(os.WriteFile(answersFile, []byte(spec.content), 0o600))
+
+			answers, err := readInitAnswers(answersFile)
+			if spec.errStr != "" {
+				assert.NotNil(err)
+				assert.Contains(err.Error(), spec.errStr)
+			} else {
+				assert.Nil(err)
+				assert.Equal(spec.expected, answers)
+			}
+		})
+	}
+}
+
+func TestInitNonInteractive(t *testing.T) {
+	tests := []struct {
+		test    string
+		args    []string
+		answers string
+		errStr  string
+	}{
+		{
+			test:   "answers file without non-interactive",
+			args:   []string{"init", "--answers-file", "answers.yaml"},
+			errStr: "the --answers-file flag can only be used with the --non-interactive flag",
+		},
+		{
+			test:   "missing answers file",
+			args:   []string{"init", "--non-interactive", "--answers-file", "does-not-exist.yaml"},
+			errStr: `unable to read the answers file "does-not-exist.yaml"`,
+		},
+		{
+			test:    "EULA not accepted",
+			args:    []string{"init", "--non-interactive"},
+			answers: "acceptEula: false",
+			errStr:  "the EULA must be accepted to use the CLI, please set 'acceptEula: true' in the answers file",
+		},
+	}
+
+	for _, spec := range tests {
+		t.Run(spec.test, func(t *testing.T) {
+			assert := assert.New(t)
+
+			dir := t.TempDir()
+			t.Setenv("TANZU_CONFIG", filepath.Join(dir, "config.yaml"))
+			t.Setenv("TANZU_CONFIG_NEXT_GEN", filepath.Join(dir, "config-ng.yaml"))
+			t.Setenv("TANZU_CLI_CEIP_OPT_IN_PROMPT_ANSWER", "No")
+
+			args := spec.args
+			if spec.answers != "" {
+				answersFile := filepath.Join(dir, "answers.yaml")
+				assert.Nil(os.WriteFile(answersFile, []byte(spec.answers), 0o600))
+				args = append(args, "--answers-file", answersFile)
+			}
+
+			rootCmd, err := NewRootCmdForTest()
+			assert.Nil(err)
+			rootCmd.SetArgs(args)
+
+			err = rootCmd.Execute()
+			assert.NotNil(err)
+			assert.Contains(err.Error(), spec.errStr)
+		})
+	}
+}
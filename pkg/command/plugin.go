@@ -6,7 +6,9 @@ package command
 import (
 	"fmt"
 	"io"
+	"os"
 	"path/filepath"
+	"slices"
 	"sort"
 	"strings"
 
@@ -20,7 +22,9 @@ import (
 
 	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/common"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/discovery"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/localgroups"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/plugininventory"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/pluginmanager"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/pluginsupplier"
@@ -30,12 +34,17 @@ import (
 )
 
 var (
-	local        string
-	version      string
-	forceDelete  bool
-	outputFormat string
-	targetStr    string
-	group        string
+	local          string
+	version        string
+	forceDelete    bool
+	outputFormat   string
+	targetStr      string
+	group          string
+	pluginOS       string
+	pluginArch     string
+	showSBOM       bool
+	showAdvisories bool
+	ignorePolicy   bool
 )
 
 const (
@@ -46,7 +55,8 @@ const (
 )
 
 var (
-	targetFlagDesc = fmt.Sprintf("target of the plugin (%s)", common.TargetList)
+	targetFlagDesc                   = fmt.Sprintf("target of the plugin (%s)", common.TargetList)
+	ignoreInstallationPolicyFlagDesc = "install plugin versions not allowed by the installation policy, printing a warning instead"
 )
 
 func newPluginCmd() *cobra.Command {
@@ -69,6 +79,11 @@ func newPluginCmd() *cobra.Command {
 		newDeletePluginCmd(),
 		newCleanPluginCmd(),
 		newSyncPluginCmd(),
+		newRecommendedPluginCmd(),
+		newWhichPluginCmd(),
+		newUsagePluginCmd(),
+		newVerifyPluginCmd(),
+		newOutdatedPluginCmd(),
 		newDiscoverySourceCmd(),
 		newSearchPluginCmd(),
 		newPluginGroupCmd(),
@@ -101,7 +116,8 @@ func newListPluginCmd() *cobra.Command {
 				log.Warningf(errorWhileGettingContextPlugins, err.Error())
 			}
 
-			displayInstalledPlugins(installedPlugins, discoveredServerPlugins, cmd.OutOrStdout())
+			displayInstalledPlugins(installedPlugins, discoveredServerPlugins, cmd.OutOrStdout(), cmd.ErrOrStderr())
+			warnAboutInstalledPluginsAdvisories(installedPlugins)
 
 			return kerrors.NewAggregate(errorList)
 		},
@@ -110,6 +126,7 @@ func newListPluginCmd() *cobra.Command {
 	utils.PanicOnErr(listCmd.RegisterFlagCompletionFunc("output", completionGetOutputFormats))
 	listCmd.Flags().BoolVar(&showAllColumns, "wide", false, "display additional columns for plugins")
 	utils.PanicOnErr(listCmd.Flags().MarkHidden("wide"))
+	addNoHintsFlag(listCmd)
 
 	return listCmd
 }
@@ -121,6 +138,27 @@ func newDescribePluginCmd() *cobra.Command {
 		Long:              "Displays detailed information for a plugin",
 		ValidArgsFunction: completeInstalledPlugins,
 		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			if len(args) != 1 {
+				return fmt.Errorf("must provide one plugin name as a positional argument")
+			}
+			pluginName, target, err := parsePluginNameAndTarget(args[0])
+			if err != nil {
+				return err
+			}
+
+			if showSBOM {
+				content, _, err := pluginmanager.GetPluginSBOM(pluginName, target)
+				if err != nil {
+					return err
+				}
+				fmt.Fprintln(cmd.OutOrStdout(), string(content))
+				return nil
+			}
+
+			if showAdvisories {
+				return displayPluginAdvisories(pluginName, target, cmd.OutOrStdout(), cmd.ErrOrStderr())
+			}
+
 			if outputFormat == "" {
 				outputFormat = string(component.ListTableOutputType)
 				fmt.Fprintln(cmd.OutOrStdout())
@@ -128,16 +166,7 @@ func newDescribePluginCmd() *cobra.Command {
 			}
 			output := component.NewOutputWriterWithOptions(cmd.OutOrStdout(), outputFormat, []component.OutputWriterOption{}, "name", "version", "status", "target", "description", "installationPath")
 
-			if len(args) != 1 {
-				return fmt.Errorf("must provide one plugin name as a positional argument")
-			}
-			pluginName := args[0]
-
-			if !configtypes.IsValidTarget(targetStr, true, true) {
-				return errors.New(invalidTargetMsg)
-			}
-
-			pd, err := pluginmanager.DescribePlugin(pluginName, getTarget())
+			pd, err := pluginmanager.DescribePlugin(pluginName, target)
 			if err != nil {
 				return err
 			}
@@ -153,6 +182,11 @@ func newDescribePluginCmd() *cobra.Command {
 	describeCmd.Flags().StringVarP(&targetStr, "target", "t", "", targetFlagDesc)
 	utils.PanicOnErr(describeCmd.RegisterFlagCompletionFunc("target", completeTargetsForInstalledPlugins))
 
+	describeCmd.Flags().BoolVarP(&showSBOM, "sbom", "", false, "display the SBOM of the plugin binary as published with the plugin")
+	describeCmd.MarkFlagsMutuallyExclusive("sbom", "output")
+	describeCmd.Flags().BoolVarP(&showAdvisories, "advisories", "", false, "display the security advisories affecting the versions of the plugin")
+	describeCmd.MarkFlagsMutuallyExclusive("sbom", "advisories")
+
 	return describeCmd
 }
 
@@ -174,8 +208,12 @@ func newInstallPluginCmd() *cobra.Command { //nolint:funlen
     # Install all plugins from the latest patch of the v1.2 version of the vmware-tkg/default plugin group
     tanzu plugin install --group vmware-tkg/default:v1.2
 
+    # Install all plugins of the "team" plugin group defined locally using "tanzu plugin group local set"
+    tanzu plugin install --group local:team
+
     # Install the latest version of plugin "myPlugin"
-    # If the plugin exists for more than one target, an error will be thrown
+    # If the plugin exists for more than one target, you will be asked to choose the target
+    # when running in a terminal; otherwise an error will be thrown
     tanzu plugin install myPlugin
 
     # Install the latest version of plugin "myPlugin" for target kubernetes
@@ -188,7 +226,11 @@ func newInstallPluginCmd() *cobra.Command { //nolint:funlen
     tanzu plugin install myPlugin --version v1.0
 
     # Install latest minor and patch version of v1 of plugin "myPlugin"
-    tanzu plugin install myPlugin --version v1`,
+    tanzu plugin install myPlugin --version v1
+
+    # Install the latest version of plugin "myPlugin" satisfying a semver range
+    tanzu plugin install myPlugin --version "~1.2"
+    tanzu plugin install myPlugin --version ">=1.0.0 <2.0.0"`,
 		Args:              cobra.MaximumNArgs(1),
 		ValidArgsFunction: completeAllPluginsToInstall,
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -199,6 +241,13 @@ func newInstallPluginCmd() *cobra.Command { //nolint:funlen
 				return errors.New(invalidTargetMsg)
 			}
 
+			restoreArch, err := overridePluginArch(pluginOS, pluginArch)
+			if err != nil {
+				return err
+			}
+			defer restoreArch()
+			defer pluginmanager.SetIgnoreInstallationPolicy(ignorePolicy)()
+
 			if group != "" {
 				return installPluginsForPluginGroup(cmd, args)
 			}
@@ -241,6 +290,7 @@ func newInstallPluginCmd() *cobra.Command { //nolint:funlen
 			if err != nil {
 				return err
 			}
+			forgetContextPlugin(pluginName, getTarget())
 			log.Successf("successfully installed '%s' plugin", pluginName)
 			return nil
 		},
@@ -266,6 +316,15 @@ func newInstallPluginCmd() *cobra.Command { //nolint:funlen
 	installPluginCmd.Flags().StringVarP(&targetStr, "target", "t", "", targetFlagDesc)
 	utils.PanicOnErr(installPluginCmd.RegisterFlagCompletionFunc("target", completeTargetsForAllPlugins))
 
+	// The --os and --arch flags allow to install plugins for a different platform than the one of
+	// the host.  They are only meant for testing or preparing plugins for other machines, so are hidden.
+	installPluginCmd.Flags().StringVar(&pluginOS, "os", "", "operating system of the plugins to install (defaults to the one of the host)")
+	utils.PanicOnErr(installPluginCmd.Flags().MarkHidden("os"))
+	installPluginCmd.Flags().StringVar(&pluginArch, "arch", "", "architecture of the plugins to install (defaults to the one of the host)")
+	utils.PanicOnErr(installPluginCmd.Flags().MarkHidden("arch"))
+
+	installPluginCmd.Flags().BoolVar(&ignorePolicy, "ignore-installation-policy", false, ignoreInstallationPolicyFlagDesc)
+
 	installPluginCmd.MarkFlagsMutuallyExclusive("group", "local")
 	installPluginCmd.MarkFlagsMutuallyExclusive("group", "local-source")
 	installPluginCmd.MarkFlagsMutuallyExclusive("group", "version")
@@ -274,6 +333,44 @@ func newInstallPluginCmd() *cobra.Command { //nolint:funlen
 	return installPluginCmd
 }
 
+// overridePluginArch overrides the OS and architecture of the plugins to install with the specified
+// values or, if they are not specified, with the values of the TANZU_CLI_PLUGIN_OS and TANZU_CLI_PLUGIN_ARCH
+// variables.  The returned function restores the original OS and architecture.
+func overridePluginArch(osName, archName string) (func(), error) {
+	origArch := cli.BuildArch()
+	restore := func() { cli.SetArch(origArch) }
+
+	if osName == "" {
+		osName = os.Getenv(constants.ConfigVariablePluginOS)
+	}
+	if archName == "" {
+		archName = os.Getenv(constants.ConfigVariablePluginArch)
+	}
+	if osName == "" && archName == "" {
+		return restore, nil
+	}
+	if osName == "" {
+		osName = origArch.OS()
+	}
+	if archName == "" {
+		archName = origArch.Arch()
+	}
+
+	arch := cli.Arch(fmt.Sprintf("%s_%s", osName, archName))
+	if !slices.Contains(cli.AllOSArch, arch) {
+		supported := make([]string, len(cli.AllOSArch))
+		for i := range cli.AllOSArch {
+			supported[i] = cli.AllOSArch[i].String()
+		}
+		return nil, fmt.Errorf("unsupported os/arch combination '%s', must be one of: %s", arch, strings.Join(supported, ", "))
+	}
+	if arch != origArch {
+		log.Warningf("installing plugins for %s instead of %s", arch, origArch)
+	}
+	cli.SetArch(arch)
+	return restore, nil
+}
+
 func installPluginsForPluginGroup(cmd *cobra.Command, args []string) error {
 	var pluginName string
 	// We are installing from a group
@@ -284,6 +381,18 @@ func installPluginsForPluginGroup(cmd *cobra.Command, args []string) error {
 		pluginName = args[0]
 	}
 
+	if groupName, isLocal := localgroups.ParseLocalGroupID(group); isLocal {
+		if err := pluginmanager.InstallPluginsFromLocalGroup(pluginName, groupName); err != nil {
+			return err
+		}
+		if pluginName == cli.AllPlugins {
+			log.Successf("successfully installed all plugins from group '%s'", group)
+		} else {
+			log.Successf("successfully installed '%s' from group '%s'", pluginName, group)
+		}
+		return nil
+	}
+
 	if pluginName == cli.AllPlugins {
 		pg, err := pluginmanager.GetPluginGroup(group)
 		if err != nil {
@@ -292,17 +401,23 @@ func installPluginsForPluginGroup(cmd *cobra.Command, args []string) error {
 		groupIDAndVersion := fmt.Sprintf("%s-%s/%s:%s", pg.Vendor, pg.Publisher, pg.Name, pg.RecommendedVersion)
 		log.Infof("The following plugins will be installed from plugin group '%s'", groupIDAndVersion)
 		// list plugins if we are installing all plugins from the plugin group
-		displayGroupContentAsTable(pg, pg.RecommendedVersion, "", false, false, cmd.ErrOrStderr())
+		displayGroupContentAsTable(pg, pg.RecommendedVersion, "", false, false, cmd.ErrOrStderr(), cmd.ErrOrStderr())
 		groupWithVersion, err := pluginmanager.InstallPluginsFromGivenPluginGroup(pluginName, groupIDAndVersion, pg)
 		if err != nil {
 			return err
 		}
+		for _, plugin := range pg.Versions[pg.RecommendedVersion] {
+			if plugin.Mandatory {
+				forgetContextPlugin(plugin.Name, plugin.Target)
+			}
+		}
 		log.Successf("successfully installed all plugins from group '%s'", groupWithVersion)
 	} else {
 		groupWithVersion, err := pluginmanager.InstallPluginsFromGroup(pluginName, group)
 		if err != nil {
 			return err
 		}
+		forgetContextPlugin(pluginName, configtypes.TargetUnknown)
 		log.Successf("successfully installed '%s' from group '%s'", pluginName, groupWithVersion)
 	}
 	return nil
@@ -312,35 +427,62 @@ func newUpgradePluginCmd() *cobra.Command {
 	var upgradeCmd = &cobra.Command{
 		Use:               "upgrade " + pluginNameCaps,
 		Short:             "Upgrade a plugin",
-		Long:              "Installs the latest version available for the specified plugin",
-		ValidArgsFunction: completeAllPluginsToInstall,
+		Long:              "Installs the latest version available for the specified plugin, or the version specified using the `--version` flag. The plugin is never downgraded, and pre-release versions are considered if the installed version is a pre-release",
+		ValidArgsFunction: completeUpgradePlugin,
+		Example: `
+    # Upgrade plugin "myPlugin" to its latest version
+    tanzu plugin upgrade myPlugin
+
+    # Upgrade plugin "myPlugin" of target kubernetes to its latest version
+    tanzu plugin upgrade myPlugin@k8s
+
+    # Upgrade plugin "myPlugin" to the latest patch version of v1.2
+    tanzu plugin upgrade myPlugin --version v1.2
+
+    # Upgrade plugin "myPlugin" to the latest version satisfying a semver range
+    tanzu plugin upgrade myPlugin --version ">=1.0.0 <2.0.0"`,
 		RunE: func(cmd *cobra.Command, args []string) (err error) {
 			if len(args) != 1 {
 				return fmt.Errorf("must provide plugin name as positional argument")
 			}
-			pluginName := args[0]
-
-			if !configtypes.IsValidTarget(targetStr, true, true) {
-				return errors.New(invalidTargetMsg)
+			pluginName, target, err := parsePluginNameAndTarget(args[0])
+			if err != nil {
+				return err
 			}
 
+			defer pluginmanager.SetIgnoreInstallationPolicy(ignorePolicy)()
+
 			// With the Central Repository feature we can simply request to install
-			// the recommendedVersion.
-			err = pluginmanager.UpgradePlugin(pluginName, cli.VersionLatest, getTarget())
+			// the recommendedVersion, unless a specific version was requested.
+			err = pluginmanager.UpgradePlugin(pluginName, version, target)
 			if err != nil {
 				return err
 			}
+			forgetContextPlugin(pluginName, target)
 			log.Successf("successfully upgraded plugin '%s'", pluginName)
 			return nil
 		},
 	}
 
+	upgradeCmd.Flags().StringVarP(&version, "version", "v", cli.VersionLatest, "version of the plugin to upgrade to")
+	utils.PanicOnErr(upgradeCmd.RegisterFlagCompletionFunc("version", completePluginVersions))
+
 	upgradeCmd.Flags().StringVarP(&targetStr, "target", "t", "", targetFlagDesc)
 	utils.PanicOnErr(upgradeCmd.RegisterFlagCompletionFunc("target", completeTargetsForAllPlugins))
 
+	upgradeCmd.Flags().BoolVar(&ignorePolicy, "ignore-installation-policy", false, ignoreInstallationPolicyFlagDesc)
+
 	return upgradeCmd
 }
 
+// forgetContextPlugin records that the user explicitly installed the plugin, so that it
+// is no longer uninstalled along with the contexts which installed it
+func forgetContextPlugin(pluginName string, target configtypes.Target) {
+	if err := pluginmanager.OnPluginInstalledByUser(pluginName, target); err != nil {
+		log.V(6).Warningf("unable to record the installation of plugin %q: %v", pluginName, err)
+	}
+}
+
 func newDeletePluginCmd() *cobra.Command {
 	var deleteCmd = &cobra.Command{
 		Use:               "uninstall " + pluginNameCaps,
@@ -348,17 +490,24 @@ func newDeletePluginCmd() *cobra.Command {
 		Short:             "Uninstall a plugin",
 		Long:              "Uninstall the specified plugin or specify 'all' to uninstall all plugins of a target",
 		ValidArgsFunction: completeDeletePlugin,
+		Example: `
+    # Uninstall plugin "myPlugin"
+    tanzu plugin uninstall myPlugin
+
+    # Uninstall plugin "myPlugin" of target mission-control
+    tanzu plugin uninstall myPlugin@tmc
+
+    # Uninstall all plugins of target kubernetes
+    tanzu plugin uninstall all --target k8s`,
 		RunE: func(cmd *cobra.Command, args []string) (err error) {
 			if len(args) != 1 {
 				return fmt.Errorf("must provide one plugin name as a positional argument")
 			}
-			pluginName := args[0]
-
-			if !configtypes.IsValidTarget(targetStr, true, true) {
-				return errors.New(invalidTargetMsg)
+			pluginName, target, err := parsePluginNameAndTarget(args[0])
+			if err != nil {
+				return err
 			}
 
-			target := getTarget()
 			if pluginName == cli.AllPlugins {
 				if target == configtypes.TargetUnknown {
 					return fmt.Errorf("the '%s' argument can only be used with the '--target' flag", cli.AllPlugins)
@@ -368,7 +517,10 @@ func newDeletePluginCmd() *cobra.Command {
 			deletePluginOptions := pluginmanager.DeletePluginOptions{
 				PluginName:  pluginName,
 				Target:      target,
-				ForceDelete: forceDelete,
+				ForceDelete: forceDelete || assumeYes,
+				Confirm: func(message string) error {
+					return confirmDestructiveAction(message, false)
+				},
 			}
 
 			err = pluginmanager.DeletePlugin(deletePluginOptions)
@@ -394,12 +546,35 @@ func newDeletePluginCmd() *cobra.Command {
 }
 
 func newCleanPluginCmd() *cobra.Command {
+	var yes, oldVersions bool
+
 	var cleanCmd = &cobra.Command{
-		Use:               "clean",
-		Short:             "Clean the plugins",
-		Long:              "Remove all installed plugins from the system",
+		Use:   "clean",
+		Short: "Clean the plugins",
+		Long: "Remove all installed plugins from the system. With --old-versions, only remove the binaries " +
+			"of the plugin versions which are not installed, except for the most recent ones kept by the " +
+			"'cli.plugin-retain-versions' configuration",
+		Example: `
+    # Remove all installed plugins
+    tanzu plugin clean
+
+    # Remove the binaries of the plugin versions which are not installed
+    tanzu plugin clean --old-versions`,
 		ValidArgsFunction: noMoreCompletions,
 		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			if oldVersions {
+				freed, err := pluginmanager.CleanOldPluginVersions()
+				if err != nil {
+					return err
+				}
+				log.Successf("successfully removed the older plugin versions, freeing %s", formatDiskSize(freed))
+				return nil
+			}
+
+			if err := confirmDestructiveAction("All plugins will be removed from the system. Are you sure?", yes); err != nil {
+				return err
+			}
+
 			err = pluginmanager.Clean()
 			if err != nil {
 				return err
@@ -408,6 +583,9 @@ func newCleanPluginCmd() *cobra.Command {
 			return nil
 		},
 	}
+	cleanCmd.Flags().BoolVarP(&yes, "yes", "y", false, "remove all the plugins without asking for confirmation")
+	cleanCmd.Flags().BoolVarP(&oldVersions, "old-versions", "", false, "only remove the binaries of the plugin versions which are not installed")
+
 	return cleanCmd
 }
 
@@ -481,7 +659,7 @@ func (d pluginListInfoSorter) Less(i, j int) bool {
 	return d[i].target < d[j].target
 }
 
-func displayInstalledPlugins(installedPlugins []cli.PluginInfo, recommendedContextPlugins []discovery.Discovered, writer io.Writer) {
+func displayInstalledPlugins(installedPlugins []cli.PluginInfo, recommendedContextPlugins []discovery.Discovered, writer, hintWriter io.Writer) {
 	pluginSyncRequired := false
 
 	getRecommendedPluginVersion := func(installedPlugin cli.PluginInfo) string {
@@ -554,10 +732,58 @@ func displayInstalledPlugins(installedPlugins []cli.PluginInfo, recommendedConte
 
 	outputPluginWriter.Render()
 
-	if pluginSyncRequired && isTableOutputFormat() {
+	if pluginSyncRequired {
 		// Print a warning to the user that some context plugins are not installed or outdated and plugin sync is required to install them
-		fmt.Println("")
-		fmt.Printf("Note: As shown above, some recommended plugins have not been installed or are outdated. To install them please run %s.\n", "'tanzu plugin sync'")
+		printHints(hintWriter, fmt.Sprintf("Note: As shown above, some recommended plugins have not been installed or are outdated. To install them please run %s.", "'tanzu plugin sync'"))
+	}
+}
+
+// displayPluginAdvisories displays the security advisories affecting the versions of
+// the plugin, the most recent version first
+func displayPluginAdvisories(pluginName string, target configtypes.Target, writer, hintWriter io.Writer) error {
+	advisories, err := pluginmanager.GetPluginAdvisories(pluginName, target)
+	if err != nil {
+		return err
+	}
+	versions := make([]string, 0, len(advisories))
+	for v := range advisories {
+		versions = append(versions, v)
+	}
+	_ = utils.SortVersions(versions)
+
+	if outputFormat == "" {
+		outputFormat = string(component.TableOutputType)
+	}
+	output := component.NewOutputWriterWithOptions(writer, outputFormat, []component.OutputWriterOption{}, "version", "id", "severity", "fixedVersion", "summary", "url")
+	for i := len(versions) - 1; i >= 0; i-- {
+		for _, a := range advisories[versions[i]] {
+			output.AddRow(a.Version, a.ID, a.Severity, a.FixedVersion, a.Summary, a.URL)
+		}
+	}
+	output.Render()
+
+	if len(versions) == 0 {
+		printHints(hintWriter, fmt.Sprintf("Note: No security advisories were found for plugin '%s' in the plugin inventory cache.", pluginName))
+	} else if pd, err := pluginmanager.DescribePlugin(pluginName, target); err == nil && len(advisories[pd.Version]) > 0 {
+		printHints(hintWriter, fmt.Sprintf("Note: The installed version %s of plugin '%s' is affected by the advisories shown above. To upgrade it please run 'tanzu plugin upgrade %s --target %s'.", pd.Version, pd.Name, pd.Name, pd.Target))
+	}
+	return nil
+}
+
+// warnAboutInstalledPluginsAdvisories prints a warning for each installed plugin whose
+// version is affected by security advisories
+func warnAboutInstalledPluginsAdvisories(installedPlugins []cli.PluginInfo) {
+	advisories, err := pluginmanager.GetInstalledPluginsAdvisories(installedPlugins)
+	if err != nil {
+		log.V(7).Error(err, "error reading the advisories of the installed plugins")
+		return
+	}
+	for i := range installedPlugins {
+		key := fmt.Sprintf("%s:%s", installedPlugins[i].Name, installedPlugins[i].Target)
+		if len(advisories[key]) > 0 {
+			log.Warningf("the installed version %s of plugin '%s' for target '%s' is affected by security advisories: %s",
+				installedPlugins[i].Version, installedPlugins[i].Name, installedPlugins[i].Target, pluginmanager.FormatAdvisories(advisories[key]))
+		}
 	}
 }
 
@@ -590,20 +816,23 @@ func completeInstalledPlugins(cmd *cobra.Command, args []string, _ string) ([]st
 		return nil, cobra.ShellCompDirectiveNoFileComp
 	}
 
-	var comps []string
+	var plugins []pluginCompletionEntry
 	target := getTarget()
 	// Complete all plugin names as long as the target matches and let the shell filter
 	for i := range installedPlugins {
 		if target == configtypes.TargetUnknown || target == installedPlugins[i].Target {
-			// Make sure the name of the plugin is part of the description so that
-			// zsh does not lump many plugins that have the same description
-			comps = append(comps, fmt.Sprintf("%[1]s\tTarget: %[2]s for %[1]s", installedPlugins[i].Name, installedPlugins[i].Target))
+			plugins = append(plugins, pluginCompletionEntry{
+				name:   installedPlugins[i].Name,
+				target: installedPlugins[i].Target,
+				// Make sure the name of the plugin is part of the description so that
+				// zsh does not lump many plugins that have the same description
+				description: fmt.Sprintf("Target: %s for %s", installedPlugins[i].Target, installedPlugins[i].Name),
+			})
 		}
 	}
 
-	comps = completionMergeSimilarPlugins(comps)
-
-	return comps, cobra.ShellCompDirectiveNoFileComp
+	// Plugins installed for more than one target are completed with their target, e.g., cluster@tmc
+	return completionQualifyAmbiguousPlugins(plugins), cobra.ShellCompDirectiveNoFileComp
 }
 
 func completeAllPluginsToInstall(cmd *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
@@ -624,6 +853,16 @@ func completeAllPluginsToInstall(cmd *cobra.Command, args []string, _ string) ([
 	return completionAllPlugins(), cobra.ShellCompDirectiveNoFileComp
 }
 
+func completeUpgradePlugin(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 || getTarget() != configtypes.TargetUnknown {
+		// Once the target is known, the plugin names are unique
+		return completeAllPluginsToInstall(cmd, args, toComplete)
+	}
+
+	// Plugins available for more than one target are completed with their target, e.g., cluster@tmc
+	return getCachedCompletions(qualifiedPluginsCompletionCacheKey, completionQualifiedPluginsFromCentralRepo), cobra.ShellCompDirectiveNoFileComp
+}
+
 func completePluginVersions(_ *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
 	if len(args) == 0 {
 		// We can't complete the version if we don't have a plugin name
@@ -631,15 +870,16 @@ func completePluginVersions(_ *cobra.Command, args []string, _ string) ([]string
 		return comps, cobra.ShellCompDirectiveNoFileComp
 	}
 
-	criteria := &discovery.PluginDiscoveryCriteria{
-		Name:   args[0],
-		Target: configtypes.StringToTarget(targetStr),
+	pluginName, target, err := parsePluginNameAndTarget(args[0])
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	criteria := plugininventory.PluginCriteria{
+		Name:   pluginName,
+		Target: target,
 	}
 
-	plugins, err := pluginmanager.DiscoverStandalonePlugins(
-		discovery.WithPluginDiscoveryCriteria(criteria),
-		discovery.WithUseLocalCacheOnly())
-
+	plugins, err := pluginmanager.GetCachedPlugins(criteria)
 	if err != nil {
 		return nil, cobra.ShellCompDirectiveNoFileComp
 	}
@@ -663,11 +903,15 @@ func completePluginVersions(_ *cobra.Command, args []string, _ string) ([]string
 		return comps, cobra.ShellCompDirectiveNoFileComp
 	}
 
-	// The versions are already sorted, but in ascending order.
+	// The versions are sorted, but in ascending order.
 	// Since more recent versions are more likely to be of interest
 	// lets reverse the order and then tell the shell to respect
 	// that order using cobra.ShellCompDirectiveKeepOrder
-	versions := plugins[0].SupportedVersions
+	criteria.Target = plugins[0].Target
+	versions, err := pluginmanager.GetCachedPluginVersions(criteria)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
 	comps := make([]string, len(versions))
 	for i := range versions {
 		comps[len(versions)-1-i] = versions[i]
@@ -744,25 +988,33 @@ func completionAllPluginsFromLocal() []string {
 }
 
 func completionAllPluginsFromGroup() []string {
-	groupIdentifier := plugininventory.PluginGroupIdentifierFromID(group)
-	if groupIdentifier == nil {
-		return nil
-	}
+	var groups []*plugininventory.PluginGroup
+	if groupName, isLocal := localgroups.ParseLocalGroupID(group); isLocal {
+		pg, err := pluginmanager.GetLocalPluginGroup(groupName)
+		if err != nil {
+			return nil
+		}
+		groups = append(groups, pg)
+	} else {
+		groupIdentifier := plugininventory.PluginGroupIdentifierFromID(group)
+		if groupIdentifier == nil {
+			return nil
+		}
 
-	if groupIdentifier.Version == "" {
-		groupIdentifier.Version = cli.VersionLatest
-	}
+		if groupIdentifier.Version == "" {
+			groupIdentifier.Version = cli.VersionLatest
+		}
 
-	groups, err := pluginmanager.DiscoverPluginGroups(
-		discovery.WithGroupDiscoveryCriteria(&discovery.GroupDiscoveryCriteria{
+		var err error
+		groups, err = pluginmanager.GetCachedPluginGroups(plugininventory.GroupCriteria{
 			Vendor:    groupIdentifier.Vendor,
 			Publisher: groupIdentifier.Publisher,
 			Name:      groupIdentifier.Name,
 			Version:   groupIdentifier.Version,
-		}),
-		discovery.WithUseLocalCacheOnly())
-	if err != nil || len(groups) == 0 {
-		return nil
+		})
+		if err != nil || len(groups) == 0 {
+			return nil
+		}
 	}
 
 	var comps []string
@@ -791,10 +1043,56 @@ func completionAllPlugins() []string {
 		return completionAllPluginsFromGroup()
 	}
 
-	// Show plugins found in the central repos
+	// Show plugins found in the central repos.
+	// Reading the plugin inventories can be slow, so the completions are cached.
+	target := configtypes.StringToTarget(targetStr)
+	cacheKey := pluginsCompletionCacheKey
+	if target != configtypes.TargetUnknown {
+		cacheKey += "." + string(target)
+	}
+	return getCachedCompletions(cacheKey, func() []string {
+		return completionAllPluginsFromCentralRepo(target)
+	})
+}
+
+// completionAllPluginsFromCentralRepo returns the completions for the plugins of the
+// central repositories, limited to the specified target unless it is unknown
+func completionAllPluginsFromCentralRepo(target configtypes.Target) []string {
+	allPlugins := discoverPluginsForCompletion(target)
+
+	var comps []string
+	for i := range allPlugins {
+		comps = append(comps, fmt.Sprintf("%s\t%s", allPlugins[i].Name, allPlugins[i].Description))
+	}
+
+	comps = completionMergeSimilarPlugins(comps)
+
+	return comps
+}
+
+// completionQualifiedPluginsFromCentralRepo returns the completions for the plugins of the
+// central repositories where the plugins available for more than one target are qualified
+// with their target
+func completionQualifiedPluginsFromCentralRepo() []string {
+	allPlugins := discoverPluginsForCompletion(configtypes.TargetUnknown)
+
+	plugins := make([]pluginCompletionEntry, 0, len(allPlugins))
+	for i := range allPlugins {
+		plugins = append(plugins, pluginCompletionEntry{
+			name:        allPlugins[i].Name,
+			target:      allPlugins[i].Target,
+			description: allPlugins[i].Description,
+		})
+	}
+	return completionQualifyAmbiguousPlugins(plugins)
+}
+
+// discoverPluginsForCompletion returns the plugins of the central repositories,
+// limited to the specified target unless it is unknown
+func discoverPluginsForCompletion(target configtypes.Target) []discovery.Discovered {
 	allPlugins, err := pluginmanager.DiscoverStandalonePlugins(
 		discovery.WithPluginDiscoveryCriteria(&discovery.PluginDiscoveryCriteria{
-			Target: configtypes.StringToTarget(targetStr),
+			Target: target,
 		}),
 		discovery.WithUseLocalCacheOnly())
 
@@ -807,22 +1105,14 @@ func completionAllPlugins() []string {
 		// Try the call again but allow it to download the plugin DB.
 		allPlugins, err = pluginmanager.DiscoverStandalonePlugins(
 			discovery.WithPluginDiscoveryCriteria(&discovery.PluginDiscoveryCriteria{
-				Target: configtypes.StringToTarget(targetStr),
+				Target: target,
 			}))
 
 		if err != nil {
 			return nil
 		}
 	}
-
-	var comps []string
-	for i := range allPlugins {
-		comps = append(comps, fmt.Sprintf("%s\t%s", allPlugins[i].Name, allPlugins[i].Description))
-	}
-
-	comps = completionMergeSimilarPlugins(comps)
-
-	return comps
+	return allPlugins
 }
 
 // completionMergeSimilarPlugins A plugin completion is made up as the plugin name as
@@ -866,8 +1156,8 @@ func completionMergeSimilarPlugins(completions []string) []string {
 
 func compCheckIfTargetFlagNeededForInstalled(cmd *cobra.Command, name string) bool {
 	targetFlag := cmd.Flags().Lookup("target")
-	if targetFlag.Changed {
-		// The target flag is already on the command-line
+	if targetFlag.Changed || strings.Contains(name, pluginTargetSeparator) {
+		// The target flag is already on the command-line or the target qualifies the plugin name
 		return false
 	}
 
@@ -892,18 +1182,15 @@ func compCheckIfTargetFlagNeededForInstalled(cmd *cobra.Command, name string) bo
 
 func compCheckIfTargetFlagNeededForAllPlugins(cmd *cobra.Command, pluginName string) bool {
 	targetFlag := cmd.Flags().Lookup("target")
-	if targetFlag.Changed {
-		// The target flag is already on the command-line
+	if targetFlag.Changed || strings.Contains(pluginName, pluginTargetSeparator) {
+		// The target flag is already on the command-line or the target qualifies the plugin name
 		return false
 	}
 
 	// Check if the pluginName applies to more than one installed plugin
-	plugins, err := pluginmanager.DiscoverStandalonePlugins(
-		discovery.WithPluginDiscoveryCriteria(&discovery.PluginDiscoveryCriteria{
-			Name: pluginName,
-		}),
-		discovery.WithUseLocalCacheOnly())
-
+	plugins, err := pluginmanager.GetCachedPlugins(plugininventory.PluginCriteria{
+		Name: pluginName,
+	})
 	if err != nil {
 		return false
 	}
@@ -950,11 +1237,9 @@ func completeTargetsForAllPlugins(_ *cobra.Command, args []string, _ string) ([]
 	if len(args) == 1 {
 		// Only suggest targets that match the specified plugin
 		pluginName := args[0]
-		plugins, err := pluginmanager.DiscoverStandalonePlugins(
-			discovery.WithPluginDiscoveryCriteria(&discovery.PluginDiscoveryCriteria{
-				Name: pluginName,
-			}),
-			discovery.WithUseLocalCacheOnly())
+		plugins, err := pluginmanager.GetCachedPlugins(plugininventory.PluginCriteria{
+			Name: pluginName,
+		})
 
 		// If we found no plugins with the correct name, just complete all targets
 		if err != nil || len(plugins) == 0 {
@@ -28,6 +28,8 @@ type downloadPluginBundleOptions struct {
 	plugins                 []string
 	refreshConfigOnly       bool
 	dryRun                  bool
+	signingKey              string
+	format                  string
 }
 
 var (
@@ -54,7 +56,13 @@ to an internet-restricted environment. Please also see the "upload-bundle" comma
     tanzu plugin download-bundle --plugin cluster:v1.0.0 --to-tar /tmp/plugin_bundle_cluster.tar.gz
 
     # Download a plugin bundle with the entire plugin repository from a custom discovery source
-    tanzu plugin download-bundle --image custom.registry.vmware.com/tkg/tanzu-plugins/plugin-inventory:latest --to-tar /tmp/plugin_bundle_complete.tar.gz`,
+    tanzu plugin download-bundle --image custom.registry.vmware.com/tkg/tanzu-plugins/plugin-inventory:latest --to-tar /tmp/plugin_bundle_complete.tar.gz
+
+    # Download a plugin bundle signed with a key generated by "cosign generate-key-pair"
+    tanzu plugin download-bundle --group vmware-tkg/default:v1.0.0 --to-tar /tmp/plugin_bundle_vmware_tkg_default_v1.0.0.tar.gz --signing-key cosign.key
+
+    # Download a plugin bundle as a standard OCI image layout usable by other registry tools
+    tanzu plugin download-bundle --group vmware-tkg/default:v1.0.0 --to-tar /tmp/plugin_bundle_vmware_tkg_default_v1.0.0.tar --format oci-layout`,
 		ValidArgsFunction: completeDownloadBundle,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if !dpbo.dryRun && dpbo.tarFile == "" {
@@ -67,6 +75,8 @@ to an internet-restricted environment. Please also see the "upload-bundle" comma
 				Plugins:              dpbo.plugins,
 				RefreshConfigOnly:    dpbo.refreshConfigOnly,
 				DryRun:               dpbo.dryRun,
+				SigningKey:           dpbo.signingKey,
+				Format:               airgapped.PluginBundleFormat(dpbo.format),
 				ImageProcessor:       carvelhelpers.NewImageOperationsImpl(),
 			}
 			return options.DownloadPluginBundle()
@@ -89,6 +99,14 @@ to an internet-restricted environment. Please also see the "upload-bundle" comma
 
 	f.BoolVarP(&dpbo.refreshConfigOnly, "refresh-configuration-only", "", false, "only refresh the central configuration data")
 
+	// Shell completion for this flag is the default behavior of doing file completion
+	f.StringVarP(&dpbo.signingKey, "signing-key", "", "", "cosign private key used to sign the plugin bundle (its password is read from the COSIGN_PASSWORD environment variable or the terminal)")
+
+	f.StringVarP(&dpbo.format, "format", "", string(airgapped.PluginBundleFormatImgpkg), "format of the images of the plugin bundle, either 'imgpkg' or 'oci-layout' for a standard OCI image layout")
+	utils.PanicOnErr(downloadBundleCmd.RegisterFlagCompletionFunc("format", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{string(airgapped.PluginBundleFormatImgpkg), string(airgapped.PluginBundleFormatOCILayout)}, cobra.ShellCompDirectiveNoFileComp
+	}))
+
 	f.BoolVarP(&dpbo.dryRun, "dry-run", "", false, "perform a dry run by listing the images to download without actually downloading them")
 	_ = downloadBundleCmd.Flags().MarkHidden("dry-run")
 
@@ -107,6 +125,9 @@ to an internet-restricted environment. Please also see the "upload-bundle" comma
 type uploadPluginBundleOptions struct {
 	sourceTar       string
 	destinationRepo string
+	verificationKey string
+	dryRun          bool
+	skipExisting    bool
 }
 
 var upbo uploadPluginBundleOptions
@@ -116,16 +137,28 @@ func newUploadBundlePluginCmd() *cobra.Command {
 		Use:   "upload-bundle",
 		Short: "Upload plugin bundle to a repository",
 		Long: `Upload a plugin bundle to an alternate container registry for use in an internet-restricted
-environment. The plugin bundle is obtained using the "download-bundle" command.`,
+environment. The plugin bundle is obtained using the "download-bundle" command, in any of its formats.`,
 		Example: `
     # Upload the plugin bundle to the remote repository
     tanzu plugin upload-bundle --tar /tmp/plugin_bundle_vmware_tkg_default_v1.0.0.tar.gz --to-repo custom.registry.company.com/tanzu-plugins/
-    tanzu plugin upload-bundle --tar /tmp/plugin_bundle_complete.tar.gz --to-repo custom.registry.company.com/tanzu-plugins/`,
+    tanzu plugin upload-bundle --tar /tmp/plugin_bundle_complete.tar.gz --to-repo custom.registry.company.com/tanzu-plugins/
+
+    # Verify the signature of the plugin bundle before uploading it
+    tanzu plugin upload-bundle --tar /tmp/plugin_bundle_vmware_tkg_default_v1.0.0.tar.gz --to-repo custom.registry.company.com/tanzu-plugins/ --verification-key cosign.pub
+
+    # Report the images and plugins already present in the repository without uploading anything
+    tanzu plugin upload-bundle --tar /tmp/plugin_bundle_complete.tar.gz --to-repo custom.registry.company.com/tanzu-plugins/ --dry-run
+
+    # Only upload the images which are not already present in the repository with the same digest
+    tanzu plugin upload-bundle --tar /tmp/plugin_bundle_complete.tar.gz --to-repo custom.registry.company.com/tanzu-plugins/ --skip-existing`,
 		ValidArgsFunction: completeUploadBundle,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			options := airgapped.UploadPluginBundleOptions{
 				Tar:             upbo.sourceTar,
 				DestinationRepo: upbo.destinationRepo,
+				VerificationKey: upbo.verificationKey,
+				DryRun:          upbo.dryRun,
+				SkipExisting:    upbo.skipExisting,
 				ImageProcessor:  carvelhelpers.NewImageOperationsImpl(),
 			}
 			return options.UploadPluginBundle()
@@ -141,6 +174,12 @@ environment. The plugin bundle is obtained using the "download-bundle" command.`
 		return cobra.AppendActiveHelp(nil, "Please enter the URI of the destination repository for publishing plugins"), cobra.ShellCompDirectiveNoFileComp
 	}))
 
+	// Shell completion for this flag is the default behavior of doing file completion
+	f.StringVarP(&upbo.verificationKey, "verification-key", "", "", "cosign public key used to verify the signature of the plugin bundle")
+
+	f.BoolVarP(&upbo.dryRun, "dry-run", "", false, "report the images and plugins which already exist in the destination repository without uploading anything")
+	f.BoolVarP(&upbo.skipExisting, "skip-existing", "", false, "skip the upload of the images which already exist in the destination repository with the same digest")
+
 	_ = uploadBundleCmd.MarkFlagRequired("tar")
 	_ = uploadBundleCmd.MarkFlagRequired("to-repo")
 
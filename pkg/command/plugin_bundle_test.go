@@ -72,6 +72,14 @@ func TestCompletionPluginBundle(t *testing.T) {
 			// ":0" is the value of the ShellCompDirectiveDefault
 			expected: ":0\n",
 		},
+		{
+			test: "completion for the --format flag value of the download-bundle command",
+			args: []string{"__complete", "plugin", "download-bundle", "--format", ""},
+			// ":4" is the value of the ShellCompDirectiveNoFileComp
+			expected: "imgpkg\n" +
+				"oci-layout\n" +
+				":4\n",
+		},
 		{
 			test: "completion for the --group flag value for the group name part of the download-bundle command",
 			args: []string{"__complete", "plugin", "download-bundle", "--group", ""},
@@ -6,6 +6,7 @@ package command
 import (
 	"fmt"
 	"io"
+	"slices"
 	"sort"
 	"strings"
 
@@ -18,6 +19,7 @@ import (
 
 	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/discovery"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/localgroups"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/plugininventory"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/pluginmanager"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/utils"
@@ -26,6 +28,7 @@ import (
 var (
 	groupID          string
 	showNonMandatory bool
+	showVersions     bool
 )
 
 const groupSearchShowDetailsMsg = "Note: To view all plugin group versions available, use 'tanzu plugin group search --show-details'."
@@ -41,16 +44,32 @@ func newPluginGroupCmd() *cobra.Command {
 	pluginGroupCmd.AddCommand(
 		newSearchCmd(),
 		newGetCmd(),
+		newLocalPluginGroupCmd(),
 	)
 
 	return pluginGroupCmd
 }
 
 func newSearchCmd() *cobra.Command {
+	var vendor, publisher string
+
 	var searchCmd = &cobra.Command{
-		Use:               "search",
-		Short:             "Search for available plugin-groups",
-		Long:              "Search from the list of available plugin-groups.  A plugin-group provides a list of plugin name/version combinations which can be installed in one step.",
+		Use:   "search",
+		Short: "Search for available plugin-groups",
+		Long: "Search from the list of available plugin-groups.  A plugin-group provides a list of plugin name/version combinations which can be installed in one step.  " +
+			"The values used to limit the search can contain the '*' wildcard which matches any sequence of characters.",
+		Example: `
+    # Search for all available plugin-groups
+    tanzu plugin group search
+
+    # Search for the plugin-groups of a specific publisher
+    tanzu plugin group search --vendor vmware --publisher tkg
+
+    # Search for the plugin-groups using wildcards
+    tanzu plugin group search --name 'vmware-*/default'
+
+    # List every published version of the plugin-groups of a specific publisher
+    tanzu plugin group search --vendor vmware --publisher tkg --show-versions`,
 		Args:              cobra.MaximumNArgs(0),
 		ValidArgsFunction: noMoreCompletions,
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -66,6 +85,11 @@ func newSearchCmd() *cobra.Command {
 					Publisher: groupIdentifier.Publisher,
 					Name:      groupIdentifier.Name,
 				}
+			} else if vendor != "" || publisher != "" {
+				criteria = &discovery.GroupDiscoveryCriteria{
+					Vendor:    vendor,
+					Publisher: publisher,
+				}
 			}
 			groups, err := pluginmanager.DiscoverPluginGroups(discovery.WithGroupDiscoveryCriteria(criteria))
 			if err != nil {
@@ -73,10 +97,13 @@ func newSearchCmd() *cobra.Command {
 			}
 
 			sort.Sort(plugininventory.PluginGroupSorter(groups))
-			if !showDetails {
-				displayGroupsFound(groups, cmd.OutOrStdout())
-			} else {
+			switch {
+			case showVersions:
+				displayGroupVersions(groups, cmd.OutOrStdout())
+			case showDetails:
 				displayGroupDetails(groups, cmd.OutOrStdout())
+			default:
+				displayGroupsFound(groups, cmd.OutOrStdout(), cmd.ErrOrStderr())
 			}
 			return nil
 		},
@@ -85,19 +112,38 @@ func newSearchCmd() *cobra.Command {
 	f := searchCmd.Flags()
 	f.StringVarP(&groupID, "name", "n", "", "limit the search to the plugin-group with the specified name")
 	utils.PanicOnErr(searchCmd.RegisterFlagCompletionFunc("name", completeGroupNames))
+	f.StringVar(&vendor, "vendor", "", "limit the search to the plugin-groups of the specified vendor")
+	utils.PanicOnErr(searchCmd.RegisterFlagCompletionFunc("vendor", completeGroupVendors))
+	f.StringVar(&publisher, "publisher", "", "limit the search to the plugin-groups of the specified publisher")
+	utils.PanicOnErr(searchCmd.RegisterFlagCompletionFunc("publisher", completeGroupPublishers))
+	searchCmd.MarkFlagsMutuallyExclusive("name", "vendor")
+	searchCmd.MarkFlagsMutuallyExclusive("name", "publisher")
 
 	f.BoolVar(&showDetails, "show-details", false, "show the details of the specified group, including all available versions")
+	f.BoolVar(&showVersions, "show-versions", false, "list every available version of the plugin-groups, one per row")
+	searchCmd.MarkFlagsMutuallyExclusive("show-details", "show-versions")
 	f.StringVarP(&outputFormat, "output", "o", "", "output format (yaml|json|table)")
 	utils.PanicOnErr(searchCmd.RegisterFlagCompletionFunc("output", completionGetOutputFormats))
+	addNoHintsFlag(searchCmd)
 
 	return searchCmd
 }
 
 func newGetCmd() *cobra.Command {
+	var groupVersion string
+
 	var getCmd = &cobra.Command{
-		Use:               "get GROUP_NAME",
-		Short:             "Get the content of the specified plugin-group",
-		Long:              "Get the content of the specified plugin-group.  A plugin-group provides a list of plugin name/version combinations which can be installed in one step.  This command allows to see the list of plugins included in the specified group.",
+		Use:   "get GROUP_NAME",
+		Short: "Get the content of the specified plugin-group",
+		Long: "Get the content of the specified plugin-group.  A plugin-group provides a list of plugin name/version combinations which can be installed in one step.  This command allows to see the list of plugins included in the specified group.  " +
+			"The version of the plugin-group can be specified either with the --version flag or as part of the group name using the GROUP_NAME:VERSION format.",
+		Example: `
+    # Get the content of the latest version of a plugin-group
+    tanzu plugin group get vmware-tkg/default
+
+    # Get the content of a specific version of a plugin-group
+    tanzu plugin group get vmware-tkg/default --version v2.1.0
+    tanzu plugin group get vmware-tkg/default:v2.1.0`,
 		Args:              cobra.ExactArgs(1),
 		ValidArgsFunction: completeGroupGet,
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -110,6 +156,16 @@ func newGetCmd() *cobra.Command {
 				return errors.Errorf("incorrect plugin-group %q specified", gID)
 			}
 
+			if cmd.Flags().Changed("version") {
+				if groupIdentifier.Version != "" {
+					return errors.Errorf("the version of plugin-group %q is specified both as part of the group name and with the '--version' flag", gID)
+				}
+				if groupVersion != cli.VersionLatest {
+					groupIdentifier.Version = groupVersion
+					gID = fmt.Sprintf("%s:%s", gID, groupVersion)
+				}
+			}
+
 			if groupIdentifier.Version == "" {
 				groupIdentifier.Version = cli.VersionLatest
 			} else {
@@ -127,7 +183,7 @@ func newGetCmd() *cobra.Command {
 				return err
 			}
 			if len(groups) == 0 {
-				return errors.Errorf("plugin-group %q cannot be found", gID)
+				return groupNotFoundError(gID, groupIdentifier)
 			}
 
 			if len(groups) > 1 {
@@ -135,7 +191,7 @@ func newGetCmd() *cobra.Command {
 			}
 
 			if isTableOutputFormat() {
-				displayGroupContentAsTable(groups[0], specifiedVersion, outputFormat, true, showNonMandatory, cmd.OutOrStdout())
+				displayGroupContentAsTable(groups[0], specifiedVersion, outputFormat, true, showNonMandatory, cmd.OutOrStdout(), cmd.ErrOrStderr())
 			} else {
 				displayGroupContentAsList(groups[0], cmd.OutOrStdout())
 			}
@@ -144,15 +200,51 @@ func newGetCmd() *cobra.Command {
 	}
 
 	f := getCmd.Flags()
+	f.StringVarP(&groupVersion, "version", "v", cli.VersionLatest, "version of the plugin-group")
+	utils.PanicOnErr(getCmd.RegisterFlagCompletionFunc("version", completeGroupGetVersions))
+
 	f.StringVarP(&outputFormat, "output", "o", "", "output format (yaml|json|table)")
 	utils.PanicOnErr(getCmd.RegisterFlagCompletionFunc("output", completionGetOutputFormats))
 
 	f.BoolVarP(&showNonMandatory, "all", "", false, "include the contextual plugins")
+	addNoHintsFlag(getCmd)
 
 	return getCmd
 }
 
-func displayGroupsFound(groups []*plugininventory.PluginGroup, writer io.Writer) {
+// groupNotFoundError returns the error to use when the specified plugin-group cannot be found.
+// If the group exists but not the requested version, the error lists the available versions.
+func groupNotFoundError(gID string, groupIdentifier *plugininventory.PluginGroupIdentifier) error {
+	notFoundErr := errors.Errorf("plugin-group %q cannot be found", gID)
+	if groupIdentifier.Version == cli.VersionLatest {
+		return notFoundErr
+	}
+
+	criteria := &discovery.GroupDiscoveryCriteria{
+		Vendor:    groupIdentifier.Vendor,
+		Publisher: groupIdentifier.Publisher,
+		Name:      groupIdentifier.Name,
+	}
+	groups, err := pluginmanager.DiscoverPluginGroups(discovery.WithGroupDiscoveryCriteria(criteria))
+	if err != nil || len(groups) == 0 {
+		return notFoundErr
+	}
+	return errors.Errorf("%s. Available versions: %s", notFoundErr.Error(), strings.Join(sortedGroupVersions(groups[0]), ", "))
+}
+
+// sortedGroupVersions returns the versions of the plugin-group with the most recent versions first
+func sortedGroupVersions(group *plugininventory.PluginGroup) []string {
+	var versions []string
+	for v := range group.Versions {
+		versions = append(versions, v)
+	}
+	// Sort in ascending order
+	_ = utils.SortVersions(versions)
+	slices.Reverse(versions)
+	return versions
+}
+
+func displayGroupsFound(groups []*plugininventory.PluginGroup, writer, hintWriter io.Writer) {
 	output := component.NewOutputWriterWithOptions(writer, outputFormat, []component.OutputWriterOption{}, "group", "description", "latest")
 
 	for _, pg := range groups {
@@ -161,10 +253,21 @@ func displayGroupsFound(groups []*plugininventory.PluginGroup, writer io.Writer)
 	}
 	output.Render()
 
-	if isTableOutputFormat() {
-		fmt.Fprintln(writer)
-		fmt.Fprintln(writer, groupSearchShowDetailsMsg)
+	printHints(hintWriter, groupSearchShowDetailsMsg)
+}
+
+// displayGroupVersions lists every version of the plugin-groups as a flat list,
+// with the most recent versions of each group first
+func displayGroupVersions(groups []*plugininventory.PluginGroup, writer io.Writer) {
+	output := component.NewOutputWriterWithOptions(writer, outputFormat, []component.OutputWriterOption{}, "group", "version", "latest")
+
+	for _, pg := range groups {
+		id := plugininventory.PluginGroupToID(pg)
+		for _, version := range sortedGroupVersions(pg) {
+			output.AddRow(id, version, version == pg.RecommendedVersion)
+		}
 	}
+	output.Render()
 }
 
 func displayGroupDetails(groups []*plugininventory.PluginGroup, writer io.Writer) {
@@ -221,7 +324,7 @@ func displayGroupDetails(groups []*plugininventory.PluginGroup, writer io.Writer
 	component.NewObjectWriter(writer, outputFormat, details).Render()
 }
 
-func displayGroupContentAsTable(group *plugininventory.PluginGroup, specifiedVersion, outputFormat string, showPreText, showNonMandatory bool, writer io.Writer) {
+func displayGroupContentAsTable(group *plugininventory.PluginGroup, specifiedVersion, outputFormat string, showPreText, showNonMandatory bool, writer, hintWriter io.Writer) {
 	cyanBold := color.New(color.FgCyan).Add(color.Bold)
 	cyanBoldItalic := color.New(color.FgCyan).Add(color.Bold, color.Italic)
 	outputStandalone := component.NewOutputWriterWithOptions(writer, outputFormat, []component.OutputWriterOption{}, "Name", "Target", "Version")
@@ -241,8 +344,7 @@ func displayGroupContentAsTable(group *plugininventory.PluginGroup, specifiedVer
 	outputStandalone.Render()
 
 	if showNonMandatory {
-		fmt.Fprintln(writer)
-		fmt.Fprintf(writer, "Note: The standalone plugins in this plugin group are installed when the 'tanzu plugin install --group %s%s' command is invoked.\n", gID, specifiedVersion)
+		printHints(hintWriter, fmt.Sprintf("Note: The standalone plugins in this plugin group are installed when the 'tanzu plugin install --group %s%s' command is invoked.", gID, specifiedVersion))
 
 		fmt.Fprintln(writer)
 		outputContext := component.NewOutputWriterWithOptions(writer, outputFormat, []component.OutputWriterOption{}, "Name", "Target", "Version")
@@ -254,8 +356,7 @@ func displayGroupContentAsTable(group *plugininventory.PluginGroup, specifiedVer
 		}
 		outputContext.Render()
 
-		fmt.Fprintln(writer)
-		fmt.Fprintln(writer, "Note: The contextual plugins in this plugin group are automatically installed, and only available for use, when a Tanzu context which supports them is created or activated/used.")
+		printHints(hintWriter, "Note: The contextual plugins in this plugin group are automatically installed, and only available for use, when a Tanzu context which supports them is created or activated/used.")
 	}
 }
 
@@ -282,18 +383,21 @@ func completeGroupGet(cmd *cobra.Command, args []string, toComplete string) ([]s
 }
 
 func completeGroupNames(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
-	// We need to complete a group name
-	groups, err := pluginmanager.DiscoverPluginGroups(discovery.WithUseLocalCacheOnly())
-	if err != nil {
-		return nil, cobra.ShellCompDirectiveNoFileComp
-	}
+	// Reading the plugin inventories can be slow, so the completions are cached.
+	return getCachedCompletions(groupsCompletionCacheKey, completionAllGroupNames), cobra.ShellCompDirectiveNoFileComp
+}
 
+// completionAllGroupNames returns the completions for the plugin groups of the central repositories
+func completionAllGroupNames() []string {
+	// We need to complete a group name
+	groups, _ := pluginmanager.GetCachedPluginGroups(plugininventory.GroupCriteria{})
 	if len(groups) == 0 {
 		// If no plugin group was returned it probably means the cache is empty.
 		// Try the call again but allow it to download the plugin DB.
+		var err error
 		groups, err = pluginmanager.DiscoverPluginGroups()
 		if err != nil {
-			return nil, cobra.ShellCompDirectiveNoFileComp
+			return nil
 		}
 	}
 
@@ -305,11 +409,41 @@ func completeGroupNames(_ *cobra.Command, _ []string, _ string) ([]string, cobra
 	// Sort to allow for testing
 	sort.Strings(comps)
 
-	return comps, cobra.ShellCompDirectiveNoFileComp
+	return comps
+}
+
+func completeGroupVendors(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+	return completionGroupIdentifierParts(func(pg *plugininventory.PluginGroupIdentifier) string {
+		return pg.Vendor
+	}), cobra.ShellCompDirectiveNoFileComp
+}
+
+func completeGroupPublishers(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+	return completionGroupIdentifierParts(func(pg *plugininventory.PluginGroupIdentifier) string {
+		return pg.Publisher
+	}), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completionGroupIdentifierParts returns the sorted unique values of the part of the
+// group identifiers selected by the getPart function, based on the cached group completions.
+func completionGroupIdentifierParts(getPart func(*plugininventory.PluginGroupIdentifier) string) []string {
+	var comps []string
+	for _, comp := range getCachedCompletions(groupsCompletionCacheKey, completionAllGroupNames) {
+		gID, _, _ := strings.Cut(comp, "\t")
+		if pg := plugininventory.PluginGroupIdentifierFromID(gID); pg != nil {
+			comps = append(comps, getPart(pg))
+		}
+	}
+	sort.Strings(comps)
+	return slices.Compact(comps)
 }
 
 func completeGroupsAndVersion(cmd *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 	var comps []string
+	if strings.HasPrefix(toComplete, localgroups.LocalGroupPrefix) {
+		// Local plugin-groups are not versioned
+		return completionLocalGroupIDs(), cobra.ShellCompDirectiveNoFileComp
+	}
 	if idx := strings.Index(toComplete, ":"); idx != -1 {
 		// The gID is already specified before the :
 		// so now we should complete the gID version
@@ -321,6 +455,7 @@ func completeGroupsAndVersion(cmd *cobra.Command, _ []string, toComplete string)
 	// Don't add a space after the group name so the uer can add a : if
 	// they want to specify a version.
 	comps, _ = completeGroupNames(nil, nil, "")
+	comps = append(comps, completionLocalGroupIDs()...)
 	return comps, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveNoSpace
 }
 
@@ -331,15 +466,11 @@ func completeGroupVersions(_ *cobra.Command, gID string) ([]string, cobra.ShellC
 		return comps, cobra.ShellCompDirectiveNoFileComp
 	}
 
-	criteria := &discovery.GroupDiscoveryCriteria{
+	groups, err := pluginmanager.GetCachedPluginGroups(plugininventory.GroupCriteria{
 		Vendor:    groupIdentifier.Vendor,
 		Publisher: groupIdentifier.Publisher,
 		Name:      groupIdentifier.Name,
-	}
-
-	groups, err := pluginmanager.DiscoverPluginGroups(
-		discovery.WithGroupDiscoveryCriteria(criteria),
-		discovery.WithUseLocalCacheOnly())
+	})
 	if err != nil {
 		return nil, cobra.ShellCompDirectiveError
 	}
@@ -353,18 +484,30 @@ func completeGroupVersions(_ *cobra.Command, gID string) ([]string, cobra.ShellC
 	// useful, we return the list of versions in reverse order
 	// and tell the shell to preserve that order using
 	// cobra.ShellCompDirectiveKeepOrder
-	var versions []string
-	for v := range groups[0].Versions {
-		versions = append(versions, v)
-	}
-	// Sort in ascending order
-	_ = utils.SortVersions(versions)
-
-	// Create the completions in reverse order
+	versions := sortedGroupVersions(groups[0])
 	comps := make([]string, len(versions))
 	for i := range versions {
-		comps[len(versions)-1-i] = fmt.Sprintf("%s:%s", gID, versions[i])
+		comps[i] = fmt.Sprintf("%s:%s", gID, versions[i])
 	}
 
 	return comps, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveKeepOrder
 }
+
+func completeGroupGetVersions(cmd *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+	if len(args) == 0 {
+		// We can't complete the version if we don't have a group name
+		comps := cobra.AppendActiveHelp(nil, "You must first specify a plugin-group name to be able to complete its version")
+		return comps, cobra.ShellCompDirectiveNoFileComp
+	}
+	if strings.Contains(args[0], ":") {
+		comps := cobra.AppendActiveHelp(nil, "The version is already specified as part of the plugin-group name")
+		return comps, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	// Only keep the version part of the completions
+	comps, directive := completeGroupVersions(cmd, args[0])
+	for i := range comps {
+		comps[i] = strings.TrimPrefix(comps[i], args[0]+":")
+	}
+	return comps, directive
+}
@@ -0,0 +1,208 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package command
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/component"
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/common"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/localgroups"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/utils"
+)
+
+const localGroupNameCaps = "GROUP_NAME"
+
+func newLocalPluginGroupCmd() *cobra.Command {
+	var localGroupCmd = &cobra.Command{
+		Use:   "local",
+		Short: "Manage the plugin-groups defined locally",
+		Long: "Manage the plugin-groups defined locally. A local plugin-group is a named list of plugins, defined " +
+			"without publishing a plugin-group to a plugin repository, whose plugins are installed using " +
+			"'tanzu plugin install --group " + localgroups.LocalGroupPrefix + localGroupNameCaps + "'.",
+	}
+	localGroupCmd.SetUsageFunc(cli.SubCmdUsageFunc)
+
+	localGroupCmd.AddCommand(
+		newListLocalPluginGroupCmd(),
+		newSetLocalPluginGroupCmd(),
+		newDeleteLocalPluginGroupCmd(),
+	)
+
+	return localGroupCmd
+}
+
+func newListLocalPluginGroupCmd() *cobra.Command {
+	var outputFormat string
+
+	var listCmd = &cobra.Command{
+		Use:               "list",
+		Short:             "List the plugin-groups defined locally",
+		Args:              cobra.NoArgs,
+		ValidArgsFunction: noMoreCompletions,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			groups, err := localgroups.GetAllLocalGroups()
+			if err != nil {
+				return err
+			}
+			names, err := localgroups.GetLocalGroupNames()
+			if err != nil {
+				return err
+			}
+
+			output := component.NewOutputWriterWithOptions(cmd.OutOrStdout(), outputFormat, []component.OutputWriterOption{}, "Group", "Description", "Plugins")
+			for _, name := range names {
+				group := groups[name]
+				output.AddRow(localgroups.LocalGroupPrefix+name, group.Description, formatLocalGroupPlugins(group.Plugins))
+			}
+			output.Render()
+			return nil
+		},
+	}
+	listCmd.Flags().StringVarP(&outputFormat, "output", "o", "", "Output format (yaml|json|table)")
+	utils.PanicOnErr(listCmd.RegisterFlagCompletionFunc("output", completionGetOutputFormats))
+
+	return listCmd
+}
+
+func newSetLocalPluginGroupCmd() *cobra.Command {
+	var description string
+
+	var setCmd = &cobra.Command{
+		Use:   "set " + localGroupNameCaps + " PLUGIN...",
+		Short: "Define a local plugin-group",
+		Long: "Define a local plugin-group, replacing any local plugin-group of the same name. Each plugin is " +
+			"specified as name[@target][:version]. The target can be omitted if the plugin is only available " +
+			"for a single target and the latest version of the plugin is installed if the version is omitted.",
+		Example: `
+    # Define the "team" local plugin-group
+    tanzu plugin group local set team cluster@k8s:v1.2.0 package --description "Plugins used by the team"
+
+    # Install all the plugins of the "team" local plugin-group
+    tanzu plugin install --group local:team`,
+		Args:              cobra.MinimumNArgs(2),
+		ValidArgsFunction: completeLocalGroupSet,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := strings.TrimPrefix(args[0], localgroups.LocalGroupPrefix)
+			plugins, err := parseLocalGroupPlugins(args[1:])
+			if err != nil {
+				return err
+			}
+			if err := localgroups.SetLocalGroup(name, &localgroups.LocalGroup{Description: description, Plugins: plugins}); err != nil {
+				return err
+			}
+			log.Successf("Local plugin-group '%s%s' defined", localgroups.LocalGroupPrefix, name)
+			return nil
+		},
+	}
+	setCmd.Flags().StringVar(&description, "description", "", "description of the local plugin-group")
+	utils.PanicOnErr(setCmd.RegisterFlagCompletionFunc("description", cobra.NoFileCompletions))
+
+	return setCmd
+}
+
+func newDeleteLocalPluginGroupCmd() *cobra.Command {
+	var deleteCmd = &cobra.Command{
+		Use:               "delete " + localGroupNameCaps,
+		Short:             "Delete a local plugin-group",
+		Long:              "Delete a local plugin-group. The plugins installed from the plugin-group remain installed.",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeLocalGroupNames,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := strings.TrimPrefix(args[0], localgroups.LocalGroupPrefix)
+			if err := localgroups.DeleteLocalGroup(name); err != nil {
+				return err
+			}
+			log.Successf("Local plugin-group '%s%s' deleted", localgroups.LocalGroupPrefix, name)
+			return nil
+		},
+	}
+
+	return deleteCmd
+}
+
+// parseLocalGroupPlugins parses the plugins of a local plugin-group specified as name[@target][:version]
+func parseLocalGroupPlugins(pluginIDs []string) ([]localgroups.LocalGroupPlugin, error) {
+	plugins := make([]localgroups.LocalGroupPlugin, 0, len(pluginIDs))
+	for _, pluginID := range pluginIDs {
+		name, target, version := utils.ParsePluginID(pluginID)
+		if name == "" {
+			return nil, errors.Errorf("invalid plugin %q, expected name[@target][:version]", pluginID)
+		}
+		if target != "" {
+			if !configtypes.IsValidTarget(strings.ToLower(target), true, false) {
+				return nil, errors.Errorf("invalid target for plugin %q. Please specify a target from '%s'", pluginID, common.TargetList)
+			}
+			target = string(configtypes.StringToTarget(strings.ToLower(target)))
+		}
+		plugins = append(plugins, localgroups.LocalGroupPlugin{Name: name, Target: target, Version: version})
+	}
+	return plugins, nil
+}
+
+// formatLocalGroupPlugins formats the plugins of a local plugin-group as name[@target][:version]
+func formatLocalGroupPlugins(plugins []localgroups.LocalGroupPlugin) string {
+	ids := make([]string, 0, len(plugins))
+	for _, p := range plugins {
+		id := p.Name
+		if p.Target != "" {
+			id += "@" + p.Target
+		}
+		if p.Version != "" {
+			id += ":" + p.Version
+		}
+		ids = append(ids, id)
+	}
+	return strings.Join(ids, ", ")
+}
+
+// ====================================
+// Shell completion functions
+// ====================================
+
+// completionLocalGroupIDs returns the completions for the local plugin-groups, prefixed by "local:"
+func completionLocalGroupIDs() []string {
+	groups, err := localgroups.GetAllLocalGroups()
+	if err != nil {
+		return nil
+	}
+
+	var comps []string
+	for name := range groups {
+		comps = append(comps, fmt.Sprintf("%s%s\t%s", localgroups.LocalGroupPrefix, name, groups[name].Description))
+	}
+	sort.Strings(comps)
+	return comps
+}
+
+func completeLocalGroupNames(_ *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return activeHelpNoMoreArgs(nil), cobra.ShellCompDirectiveNoFileComp
+	}
+
+	names, err := localgroups.GetLocalGroupNames()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
+
+func completeLocalGroupSet(_ *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+	if len(args) == 0 {
+		// Offer the existing local plugin-groups, which can be redefined
+		names, _ := localgroups.GetLocalGroupNames()
+		return cobra.AppendActiveHelp(names, "Please specify the name of the local plugin-group"), cobra.ShellCompDirectiveNoFileComp
+	}
+	// The plugins are completed using the names of the plugins of the central repositories
+	return completionAllPlugins(), cobra.ShellCompDirectiveNoFileComp
+}
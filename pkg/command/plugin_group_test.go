@@ -31,6 +31,47 @@ func TestPluginGroupSearch(t *testing.T) {
 			expectedFailure: false,
 			expected:        "GROUP DESCRIPTION LATEST vmware-tap/default Plugins for TAP v3.3.3 " + groupSearchShowDetailsMsg,
 		},
+		{
+			test:            "search for group with --name and wildcards",
+			args:            []string{"plugin", "group", "search", "--name", "*-t*/def*"},
+			expectedFailure: false,
+			expected:        "GROUP DESCRIPTION LATEST vmware-tap/default Plugins for TAP v3.3.3 vmware-tkg/default Plugins for TKG v2.2.2 " + groupSearchShowDetailsMsg,
+		},
+		{
+			test:            "search for groups with --vendor",
+			args:            []string{"plugin", "group", "search", "--vendor", "vmware"},
+			expectedFailure: false,
+			expected:        "GROUP DESCRIPTION LATEST vmware-tap/default Plugins for TAP v3.3.3 vmware-tkg/default Plugins for TKG v2.2.2 " + groupSearchShowDetailsMsg,
+		},
+		{
+			test:            "search for groups with --vendor and --publisher",
+			args:            []string{"plugin", "group", "search", "--vendor", "vmware", "--publisher", "tkg"},
+			expectedFailure: false,
+			expected:        "GROUP DESCRIPTION LATEST vmware-tkg/default Plugins for TKG v2.2.2 " + groupSearchShowDetailsMsg,
+		},
+		{
+			test:            "search for groups with --publisher and wildcards",
+			args:            []string{"plugin", "group", "search", "--publisher", "ta*"},
+			expectedFailure: false,
+			expected:        "GROUP DESCRIPTION LATEST vmware-tap/default Plugins for TAP v3.3.3 " + groupSearchShowDetailsMsg,
+		},
+		{
+			test:            "search for groups with a --vendor that does not match",
+			args:            []string{"plugin", "group", "search", "--vendor", "other*"},
+			expectedFailure: false,
+			expected:        "GROUP DESCRIPTION LATEST " + groupSearchShowDetailsMsg,
+		},
+		{
+			test:            "search for all groups with --no-hints",
+			args:            []string{"plugin", "group", "search", "--no-hints"},
+			expectedFailure: false,
+			expected:        "GROUP DESCRIPTION LATEST vmware-tap/default Plugins for TAP v3.3.3 vmware-tkg/default Plugins for TKG v2.2.2",
+		},
+		{
+			test:            "search for group with --name and --vendor",
+			args:            []string{"plugin", "group", "search", "--name", "vmware-tap/default", "--vendor", "vmware"},
+			expectedFailure: true,
+		},
 		{
 			test:            "search for invalid group with --name",
 			args:            []string{"plugin", "group", "search", "--name", "invalid"},
@@ -49,6 +90,23 @@ func TestPluginGroupSearch(t *testing.T) {
 			expectedFailure: false,
 			expected:        "name: vmware-tap/default description: Plugins for TAP latest: v3.3.3 versions: - v3.3.3",
 		},
+		{
+			test:            "search for group with --show-versions",
+			args:            []string{"plugin", "group", "search", "--show-versions"},
+			expectedFailure: false,
+			expected:        "GROUP VERSION LATEST vmware-tap/default v3.3.3 true vmware-tkg/default v2.2.2 true vmware-tkg/default v2.2.2-beta false vmware-tkg/default v1.1.1 false",
+		},
+		{
+			test:            "search for group with --show-versions and --publisher",
+			args:            []string{"plugin", "group", "search", "--show-versions", "--publisher", "tkg", "-o", "json"},
+			expectedFailure: false,
+			expected:        "[ { \"group\": \"vmware-tkg/default\", \"latest\": true, \"version\": \"v2.2.2\" }, { \"group\": \"vmware-tkg/default\", \"latest\": false, \"version\": \"v2.2.2-beta\" }, { \"group\": \"vmware-tkg/default\", \"latest\": false, \"version\": \"v1.1.1\" } ]",
+		},
+		{
+			test:            "search for group with --show-versions and --show-details",
+			args:            []string{"plugin", "group", "search", "--show-versions", "--show-details"},
+			expectedFailure: true,
+		},
 		{
 			test:            "search for all groups with json",
 			args:            []string{"plugin", "group", "search", "-o", "json"},
@@ -92,6 +150,8 @@ func TestPluginGroupSearch(t *testing.T) {
 
 			var out bytes.Buffer
 			rootCmd.SetOut(&out)
+			// The hints are printed to stderr
+			rootCmd.SetErr(&out)
 			rootCmd.SetArgs(spec.args)
 
 			err = rootCmd.Execute()
@@ -147,6 +207,12 @@ func TestPluginGroupGet(t *testing.T) {
 			expectedFailure: false,
 			expected:        "Plugins in Group: vmware-tkg/default:v1.1.1 Standalone Plugins NAME TARGET VERSION isolated-cluster global v1.2.3 login global v1.2.0 management-cluster kubernetes v0.1.0 package kubernetes v0.2.0 secret kubernetes v0.3.0 Note: The standalone plugins in this plugin group are installed when the 'tanzu plugin install --group vmware-tkg/default:v1.1.1' command is invoked. Contextual Plugins NAME TARGET VERSION cluster kubernetes v1.1.1 Note: The contextual plugins in this plugin group are automatically installed, and only available for use, when a Tanzu context which supports them is created or activated/used.",
 		},
+		{
+			test:            "get a plugin group with --all and --no-hints",
+			args:            []string{"plugin", "group", "get", "vmware-tkg/default", "--all", "--no-hints"},
+			expectedFailure: false,
+			expected:        "Plugins in Group: vmware-tkg/default:v2.2.2 Standalone Plugins NAME TARGET VERSION isolated-cluster global v1.3 Contextual Plugins NAME TARGET VERSION",
+		},
 		{
 			test:            "get a plugin group in json with --all with no context-scoped",
 			args:            []string{"plugin", "group", "get", "vmware-tkg/default", "-o", "json", "--all"},
@@ -175,7 +241,37 @@ func TestPluginGroupGet(t *testing.T) {
 			test:            "get a plugin group with an invalid version",
 			args:            []string{"plugin", "group", "get", "vmware-tkg/default:v0.888.0"},
 			expectedFailure: true,
-			expected:        "plugin-group \"vmware-tkg/default:v0.888.0\" cannot be found",
+			expected:        "plugin-group \"vmware-tkg/default:v0.888.0\" cannot be found. Available versions: v2.2.2, v2.2.2-beta, v1.1.1",
+		},
+		{
+			test:            "get a plugin group with --version",
+			args:            []string{"plugin", "group", "get", "vmware-tkg/default", "--version", "v1.1.1"},
+			expectedFailure: false,
+			expected:        "Plugins in Group: vmware-tkg/default:v1.1.1 NAME TARGET VERSION isolated-cluster global v1.2.3 login global v1.2.0 management-cluster kubernetes v0.1.0 package kubernetes v0.2.0 secret kubernetes v0.3.0",
+		},
+		{
+			test:            "get a plugin group with --version latest",
+			args:            []string{"plugin", "group", "get", "vmware-tkg/default", "--version", "latest"},
+			expectedFailure: false,
+			expected:        "Plugins in Group: vmware-tkg/default:v2.2.2 NAME TARGET VERSION isolated-cluster global v1.3",
+		},
+		{
+			test:            "get a plugin group with an invalid --version",
+			args:            []string{"plugin", "group", "get", "vmware-tkg/default", "--version", "v0.888.0"},
+			expectedFailure: true,
+			expected:        "plugin-group \"vmware-tkg/default:v0.888.0\" cannot be found. Available versions: v2.2.2, v2.2.2-beta, v1.1.1",
+		},
+		{
+			test:            "get a missing plugin group with a version",
+			args:            []string{"plugin", "group", "get", "vmware-tkg/invalid", "--version", "v1.1.1"},
+			expectedFailure: true,
+			expected:        "plugin-group \"vmware-tkg/invalid:v1.1.1\" cannot be found",
+		},
+		{
+			test:            "get a plugin group with a version specified twice",
+			args:            []string{"plugin", "group", "get", "vmware-tkg/default:v1.1.1", "--version", "v1.1.1"},
+			expectedFailure: true,
+			expected:        "the version of plugin-group \"vmware-tkg/default:v1.1.1\" is specified both as part of the group name and with the '--version' flag",
 		},
 	}
 
@@ -196,6 +292,8 @@ func TestPluginGroupGet(t *testing.T) {
 
 			var out bytes.Buffer
 			rootCmd.SetOut(&out)
+			// The hints are printed to stderr
+			rootCmd.SetErr(&out)
 			rootCmd.SetArgs(spec.args)
 
 			err = rootCmd.Execute()
@@ -247,6 +345,18 @@ func TestCompletionPluginGroup(t *testing.T) {
 				"vmware-tkg/default\tPlugins for TKG\n" +
 				":4\n",
 		},
+		{
+			test: "completion for the --vendor flag value of the group search command",
+			args: []string{"__complete", "plugin", "group", "search", "--vendor", ""},
+			// ":4" is the value of the ShellCompDirectiveNoFileComp
+			expected: "vmware\n:4\n",
+		},
+		{
+			test: "completion for the --publisher flag value of the group search command",
+			args: []string{"__complete", "plugin", "group", "search", "--publisher", ""},
+			// ":4" is the value of the ShellCompDirectiveNoFileComp
+			expected: "tap\ntkg\n:4\n",
+		},
 		// ============================
 		// tanzu plugin group get
 		// ============================
@@ -287,6 +397,29 @@ func TestCompletionPluginGroup(t *testing.T) {
 			// ":4" is the value of the ShellCompDirectiveNoFileComp
 			expected: "_activeHelp_ " + compNoMoreArgsMsg + "\n:4\n",
 		},
+		{
+			test: "completion for the --version flag value for the group get command",
+			args: []string{"__complete", "plugin", "group", "get", "vmware-tkg/default", "--version", ""},
+			// ":36" is the value of the ShellCompDirectiveNoFileComp | ShellCompDirectiveKeepOrder
+			expected: "v2.2.2\n" +
+				"v2.2.2-beta\n" +
+				"v1.1.1\n" +
+				":36\n",
+		},
+		{
+			test: "completion for the --version flag value without a group for the group get command",
+			args: []string{"__complete", "plugin", "group", "get", "--version", ""},
+			// ":4" is the value of the ShellCompDirectiveNoFileComp
+			expected: "_activeHelp_ You must first specify a plugin-group name to be able to complete its version\n" +
+				":4\n",
+		},
+		{
+			test: "completion for the --version flag value when the group includes a version for the group get command",
+			args: []string{"__complete", "plugin", "group", "get", "vmware-tkg/default:v1.1.1", "--version", ""},
+			// ":4" is the value of the ShellCompDirectiveNoFileComp
+			expected: "_activeHelp_ The version is already specified as part of the plugin-group name\n" +
+				":4\n",
+		},
 		{
 			test: "completion for the --output flag value for the group get command",
 			args: []string{"__complete", "plugin", "group", "get", "--output", ""},
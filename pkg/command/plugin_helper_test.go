@@ -274,6 +274,9 @@ func setupPluginSourceForTesting(t *testing.T) func() {
 	assert.Nil(t, err)
 	common.DefaultCacheDir = dir
 
+	// Use a temporary data store since completions are cached in it
+	os.Setenv("TEST_CUSTOM_DATA_STORE_FILE", filepath.Join(dir, ".data-store.yaml"))
+
 	// Set an invalid central repo to make sure we only use the cached DB
 	// and don't actually go to the internet when doing completion
 	err = configlib.SetCLIDiscoverySource(configtypes.PluginDiscovery{
@@ -295,6 +298,7 @@ func setupPluginSourceForTesting(t *testing.T) func() {
 		os.Unsetenv("TANZU_CONFIG")
 		os.Unsetenv("TANZU_CONFIG_NEXT_GEN")
 		os.Unsetenv("TEST_CUSTOM_CATALOG_CACHE_DIR")
+		os.Unsetenv("TEST_CUSTOM_DATA_STORE_FILE")
 		os.Unsetenv("TANZU_CLI_CEIP_OPT_IN_PROMPT_ANSWER")
 		os.Unsetenv("TANZU_CLI_EULA_PROMPT_ANSWER")
 	}
@@ -0,0 +1,89 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package command
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/common"
+)
+
+// pluginTargetSeparator separates the name of a plugin from its target when
+// a plugin argument is qualified with the target, e.g., "cluster@tmc"
+const pluginTargetSeparator = "@"
+
+// pluginCompletionEntry is a plugin offered as a completion
+type pluginCompletionEntry struct {
+	name        string
+	target      configtypes.Target
+	description string
+}
+
+// parsePluginNameAndTarget returns the name and the target of the plugin specified as
+// argument.  The argument can be qualified with the target of the plugin, e.g., "cluster@k8s",
+// in which case the target specified by the --target flag, if any, must be the same.
+func parsePluginNameAndTarget(arg string) (string, configtypes.Target, error) {
+	if !configtypes.IsValidTarget(targetStr, true, true) {
+		return "", configtypes.TargetUnknown, errors.New(invalidTargetMsg)
+	}
+
+	name, qualifier, qualified := strings.Cut(arg, pluginTargetSeparator)
+	if !qualified {
+		return arg, getTarget(), nil
+	}
+
+	qualifier = strings.ToLower(qualifier)
+	if name == "" || !configtypes.IsValidTarget(qualifier, true, false) {
+		return "", configtypes.TargetUnknown, fmt.Errorf("invalid plugin '%s'. The target of the plugin must be specified as %s%s<target> where the target is one of '%s'", arg, pluginNameCaps, pluginTargetSeparator, common.TargetList)
+	}
+
+	target := configtypes.StringToTarget(qualifier)
+	if flagTarget := getTarget(); flagTarget != configtypes.TargetUnknown && flagTarget != target {
+		return "", configtypes.TargetUnknown, fmt.Errorf("the target '%s' of plugin '%s' does not match the target '%s' specified by the `--target` flag", target, arg, flagTarget)
+	}
+	return name, target, nil
+}
+
+// qualifyPluginName returns the name of the plugin qualified with the short name of its target
+func qualifyPluginName(name string, target configtypes.Target) string {
+	shortTarget, _, _ := strings.Cut(compTargetToCompString(target), "\t")
+	return name + pluginTargetSeparator + shortTarget
+}
+
+// completionQualifyAmbiguousPlugins returns the completions of the specified plugins.  Unlike
+// completionMergeSimilarPlugins, which merges the plugins having the same name, the name of a
+// plugin which exists for more than one target is qualified with the target, e.g., "cluster@k8s"
+// and "cluster@tmc", so that any completion chosen uniquely identifies a plugin.
+func completionQualifyAmbiguousPlugins(plugins []pluginCompletionEntry) []string {
+	targetsOfName := make(map[string]map[configtypes.Target]bool)
+	for i := range plugins {
+		if targetsOfName[plugins[i].name] == nil {
+			targetsOfName[plugins[i].name] = make(map[configtypes.Target]bool)
+		}
+		targetsOfName[plugins[i].name][plugins[i].target] = true
+	}
+
+	var comps []string
+	added := make(map[string]bool)
+	for i := range plugins {
+		name := plugins[i].name
+		if len(targetsOfName[name]) > 1 {
+			name = qualifyPluginName(name, plugins[i].target)
+		}
+		if added[name] {
+			continue
+		}
+		added[name] = true
+		comps = append(comps, fmt.Sprintf("%s\t%s", name, plugins[i].description))
+	}
+
+	sort.Strings(comps)
+	return comps
+}
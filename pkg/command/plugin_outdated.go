@@ -0,0 +1,337 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package command
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/component"
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/pluginmanager"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/utils"
+)
+
+const (
+	outdatedReportFormatJUnit = "junit"
+	outdatedReportFormatSARIF = "sarif"
+
+	// Completion strings for the values of the --format flag
+	compJUnitFormat = outdatedReportFormatJUnit + "\tJUnit XML report"
+	compSARIFFormat = outdatedReportFormatSARIF + "\tSARIF 2.1.0 report"
+
+	outdatedReportName     = "tanzu plugin outdated"
+	outdatedRuleID         = "outdated-plugin"
+	advisoryRuleID         = "plugin-security-advisory"
+	latestVersionCheckName = "latest version"
+	advisoriesCheckName    = "security advisories"
+)
+
+func newOutdatedPluginCmd() *cobra.Command {
+	var output string
+	var format string
+
+	var outdatedCmd = &cobra.Command{
+		Use:   "outdated",
+		Short: "List the installed plugins which are outdated or affected by security advisories",
+		Long: "List the installed plugins for which a more recent version is available in the plugin sources, " +
+			"or whose installed version is affected by security advisories. The --format flag produces a JUnit " +
+			"or SARIF report covering every installed plugin, which CI pipelines can use to fail when an image " +
+			"contains outdated or vulnerable plugin versions.",
+		Example: `
+    # List the installed plugins which are outdated or affected by security advisories
+    tanzu plugin outdated
+
+    # Produce a JUnit report of the installed plugins for a CI pipeline
+    tanzu plugin outdated --format junit > plugins-report.xml
+
+    # Produce a SARIF report of the installed plugins for a code scanning tool
+    tanzu plugin outdated --format sarif > plugins-report.sarif`,
+		Args:              cobra.NoArgs,
+		ValidArgsFunction: noMoreCompletions,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			infos, err := pluginmanager.GetOutdatedPluginsInfo()
+			if err != nil {
+				return err
+			}
+
+			switch format {
+			case "":
+				displayOutdatedPlugins(infos, output, cmd.OutOrStdout())
+				return nil
+			case outdatedReportFormatJUnit:
+				return writeOutdatedJUnitReport(infos, cmd.OutOrStdout())
+			case outdatedReportFormatSARIF:
+				return writeOutdatedSARIFReport(infos, cmd.OutOrStdout())
+			default:
+				return errors.Errorf("invalid report format %q, the supported formats are %s and %s", format, outdatedReportFormatJUnit, outdatedReportFormatSARIF)
+			}
+		},
+	}
+	outdatedCmd.Flags().StringVarP(&output, "output", "o", "", "Output format (yaml|json|table)")
+	utils.PanicOnErr(outdatedCmd.RegisterFlagCompletionFunc("output", completionGetOutputFormats))
+	outdatedCmd.Flags().StringVar(&format, "format", "", "report format covering every installed plugin, for CI pipelines (junit|sarif)")
+	utils.PanicOnErr(outdatedCmd.RegisterFlagCompletionFunc("format", func(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+		return []string{compJUnitFormat, compSARIFFormat}, cobra.ShellCompDirectiveNoFileComp
+	}))
+	outdatedCmd.MarkFlagsMutuallyExclusive("output", "format")
+
+	return outdatedCmd
+}
+
+// displayOutdatedPlugins displays the installed plugins which are outdated or affected by security advisories
+func displayOutdatedPlugins(infos []pluginmanager.OutdatedPluginInfo, output string, writer io.Writer) {
+	outputWriter := component.NewOutputWriterWithOptions(writer, output, []component.OutputWriterOption{},
+		"Name", "Target", "Installed", "Latest", "Advisories")
+	outputWriter.MarkDynamicKeys("Advisories")
+	count := 0
+	for i := range infos {
+		if infos[i].Outdated || len(infos[i].Advisories) > 0 {
+			outputWriter.AddRow(infos[i].Name, infos[i].Target, infos[i].InstalledVersion, infos[i].LatestVersion, pluginmanager.FormatAdvisories(infos[i].Advisories))
+			count++
+		}
+	}
+	if count == 0 && (output == "" || output == string(component.TableOutputType)) {
+		log.Info("All the installed plugins are up to date")
+		return
+	}
+	outputWriter.Render()
+}
+
+// ====================================
+// JUnit report
+// ====================================
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	ClassName string        `xml:"classname,attr"`
+	Name      string        `xml:"name,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	Skipped   *junitSkipped `xml:"skipped,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+type junitSkipped struct {
+	Message string `xml:"message,attr"`
+}
+
+// writeOutdatedJUnitReport writes a JUnit XML report with, for every installed plugin, a test
+// case verifying that the latest version is installed and a test case verifying that the
+// installed version is not affected by security advisories
+func writeOutdatedJUnitReport(infos []pluginmanager.OutdatedPluginInfo, writer io.Writer) error {
+	suite := junitTestSuite{Name: "plugins"}
+	for i := range infos {
+		className := fmt.Sprintf("%s.%s", infos[i].Target, infos[i].Name)
+
+		versionCase := junitTestCase{ClassName: className, Name: latestVersionCheckName}
+		switch {
+		case infos[i].LatestVersion == "":
+			versionCase.Skipped = &junitSkipped{Message: "the plugin is not found in the plugin sources"}
+		case infos[i].Outdated:
+			versionCase.Failure = &junitFailure{
+				Message: fmt.Sprintf("version %s is installed but version %s is available", infos[i].InstalledVersion, infos[i].LatestVersion),
+				Type:    outdatedRuleID,
+				Text:    fmt.Sprintf("Run 'tanzu plugin upgrade %s --target %s' to install version %s.", infos[i].Name, infos[i].Target, infos[i].LatestVersion),
+			}
+		}
+
+		advisoriesCase := junitTestCase{ClassName: className, Name: advisoriesCheckName}
+		if len(infos[i].Advisories) > 0 {
+			var details []string
+			for _, a := range infos[i].Advisories {
+				details = append(details, strings.TrimSpace(fmt.Sprintf("%s: %s %s", a.ID, a.Summary, a.URL)))
+			}
+			advisoriesCase.Failure = &junitFailure{
+				Message: fmt.Sprintf("version %s is affected by %s", infos[i].InstalledVersion, pluginmanager.FormatAdvisories(infos[i].Advisories)),
+				Type:    advisoryRuleID,
+				Text:    strings.Join(details, "\n"),
+			}
+		}
+
+		for _, tc := range []junitTestCase{versionCase, advisoriesCase} {
+			suite.Tests++
+			if tc.Failure != nil {
+				suite.Failures++
+			}
+			if tc.Skipped != nil {
+				suite.Skipped++
+			}
+			suite.TestCases = append(suite.TestCases, tc)
+		}
+	}
+
+	report := junitTestSuites{
+		Name:     outdatedReportName,
+		Tests:    suite.Tests,
+		Failures: suite.Failures,
+		Skipped:  suite.Skipped,
+		Suites:   []junitTestSuite{suite},
+	}
+	content, err := xml.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "unable to generate the JUnit report")
+	}
+	_, err = fmt.Fprintf(writer, "%s%s\n", xml.Header, content)
+	return err
+}
+
+// ====================================
+// SARIF report
+// ====================================
+
+type sarifReport struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID     string            `json:"ruleId"`
+	Level      string            `json:"level"`
+	Message    sarifMessage      `json:"message"`
+	Locations  []sarifLocation   `json:"locations,omitempty"`
+	Properties map[string]string `json:"properties,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+// sarifAdvisoryLevel returns the SARIF level of a security advisory based on its severity
+func sarifAdvisoryLevel(severity string) string {
+	switch strings.ToLower(severity) {
+	case "critical", "high":
+		return "error"
+	case "low":
+		return "note"
+	default:
+		return "warning"
+	}
+}
+
+// writeOutdatedSARIFReport writes a SARIF 2.1.0 report with a result for every outdated
+// installed plugin and for every security advisory affecting an installed plugin
+func writeOutdatedSARIFReport(infos []pluginmanager.OutdatedPluginInfo, writer io.Writer) error {
+	run := sarifRun{
+		Tool: sarifTool{Driver: sarifDriver{
+			Name:           outdatedReportName,
+			InformationURI: "https://github.com/vmware-tanzu/tanzu-cli",
+			Rules: []sarifRule{
+				{ID: outdatedRuleID, ShortDescription: sarifMessage{Text: "A more recent version of the plugin is available"}},
+				{ID: advisoryRuleID, ShortDescription: sarifMessage{Text: "The installed version of the plugin is affected by a security advisory"}},
+			},
+		}},
+		Results: []sarifResult{},
+	}
+
+	for i := range infos {
+		var locations []sarifLocation
+		if infos[i].InstallationPath != "" {
+			locations = []sarifLocation{{PhysicalLocation: sarifPhysicalLocation{
+				ArtifactLocation: sarifArtifactLocation{URI: filepath.ToSlash(infos[i].InstallationPath)},
+			}}}
+		}
+		properties := map[string]string{
+			"plugin":  infos[i].Name,
+			"target":  infos[i].Target,
+			"version": infos[i].InstalledVersion,
+		}
+
+		if infos[i].Outdated {
+			run.Results = append(run.Results, sarifResult{
+				RuleID:     outdatedRuleID,
+				Level:      "warning",
+				Message:    sarifMessage{Text: fmt.Sprintf("Version %s of plugin '%s' for target '%s' is installed but version %s is available", infos[i].InstalledVersion, infos[i].Name, infos[i].Target, infos[i].LatestVersion)},
+				Locations:  locations,
+				Properties: properties,
+			})
+		}
+		for _, a := range infos[i].Advisories {
+			advisoryProperties := map[string]string{"advisory": a.ID, "severity": a.Severity, "fixedVersion": a.FixedVersion, "url": a.URL}
+			for k, v := range properties {
+				advisoryProperties[k] = v
+			}
+			run.Results = append(run.Results, sarifResult{
+				RuleID:     advisoryRuleID,
+				Level:      sarifAdvisoryLevel(a.Severity),
+				Message:    sarifMessage{Text: strings.TrimSpace(fmt.Sprintf("Version %s of plugin '%s' for target '%s' is affected by %s. %s", infos[i].InstalledVersion, infos[i].Name, infos[i].Target, a.ID, a.Summary))},
+				Locations:  locations,
+				Properties: advisoryProperties,
+			})
+		}
+	}
+
+	report := sarifReport{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs:    []sarifRun{run},
+	}
+	content, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "unable to generate the SARIF report")
+	}
+	_, err = fmt.Fprintf(writer, "%s\n", content)
+	return err
+}
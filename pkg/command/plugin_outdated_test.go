@@ -0,0 +1,82 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package command
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/plugininventory"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/pluginmanager"
+)
+
+var outdatedPluginsForTesting = []pluginmanager.OutdatedPluginInfo{
+	{Name: "cluster", Target: "kubernetes", InstalledVersion: "v1.0.0", LatestVersion: "v1.1.0", InstallationPath: "/plugins/cluster", Outdated: true},
+	{
+		Name: "package", Target: "kubernetes", InstalledVersion: "v2.0.0", LatestVersion: "v2.0.0", InstallationPath: "/plugins/package",
+		Advisories: []*plugininventory.PluginAdvisory{{ID: "CVE-2024-12345", Severity: "high", Summary: "Credentials are logged", FixedVersion: "v2.0.1"}},
+	},
+	{Name: "secret", Target: "kubernetes", InstalledVersion: "v1.0.0", LatestVersion: "v1.0.0"},
+	{Name: "local", Target: "global", InstalledVersion: "v0.1.0"},
+}
+
+func TestDisplayOutdatedPlugins(t *testing.T) {
+	var out bytes.Buffer
+	displayOutdatedPlugins(outdatedPluginsForTesting, "json", &out)
+	assert.JSONEq(t, `[
+		{"name":"cluster","target":"kubernetes","installed":"v1.0.0","latest":"v1.1.0","advisories":""},
+		{"name":"package","target":"kubernetes","installed":"v2.0.0","latest":"v2.0.0","advisories":"CVE-2024-12345 (high, fixed in v2.0.1)"}
+	]`, out.String())
+}
+
+func TestWriteOutdatedJUnitReport(t *testing.T) {
+	assert := assert.New(t)
+
+	var out bytes.Buffer
+	assert.Nil(writeOutdatedJUnitReport(outdatedPluginsForTesting, &out))
+
+	report := junitTestSuites{}
+	assert.Nil(xml.Unmarshal(out.Bytes(), &report))
+	assert.Equal(8, report.Tests)
+	assert.Equal(2, report.Failures)
+	assert.Equal(1, report.Skipped)
+	assert.Len(report.Suites, 1)
+
+	testCases := report.Suites[0].TestCases
+	assert.Equal("kubernetes.cluster", testCases[0].ClassName)
+	assert.Equal(latestVersionCheckName, testCases[0].Name)
+	assert.Equal("version v1.0.0 is installed but version v1.1.0 is available", testCases[0].Failure.Message)
+	assert.Nil(testCases[1].Failure)
+	assert.Nil(testCases[2].Failure)
+	assert.Equal(advisoriesCheckName, testCases[3].Name)
+	assert.Equal("version v2.0.0 is affected by CVE-2024-12345 (high, fixed in v2.0.1)", testCases[3].Failure.Message)
+	assert.Equal(advisoryRuleID, testCases[3].Failure.Type)
+	assert.Equal("the plugin is not found in the plugin sources", testCases[6].Skipped.Message)
+}
+
+func TestWriteOutdatedSARIFReport(t *testing.T) {
+	assert := assert.New(t)
+
+	var out bytes.Buffer
+	assert.Nil(writeOutdatedSARIFReport(outdatedPluginsForTesting, &out))
+
+	report := sarifReport{}
+	assert.Nil(json.Unmarshal(out.Bytes(), &report))
+	assert.Equal("2.1.0", report.Version)
+	assert.Len(report.Runs, 1)
+
+	results := report.Runs[0].Results
+	assert.Len(results, 2)
+	assert.Equal(outdatedRuleID, results[0].RuleID)
+	assert.Equal("warning", results[0].Level)
+	assert.Equal("/plugins/cluster", results[0].Locations[0].PhysicalLocation.ArtifactLocation.URI)
+	assert.Equal(advisoryRuleID, results[1].RuleID)
+	assert.Equal("error", results[1].Level)
+	assert.Equal("CVE-2024-12345", results[1].Properties["advisory"])
+	assert.Equal("package", results[1].Properties["plugin"])
+}
@@ -0,0 +1,141 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package command
+
+import (
+	"io"
+	"sort"
+
+	"github.com/spf13/cobra"
+
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/component"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/common"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/discovery"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/pluginmanager"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/pluginsupplier"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/utils"
+)
+
+// recommendedPluginInfo describes a plugin recommended by an active context
+type recommendedPluginInfo struct {
+	context     string
+	name        string
+	target      string
+	recommended string
+	installed   string
+	status      string
+}
+
+func newRecommendedPluginCmd() *cobra.Command {
+	var output string
+
+	var recommendedCmd = &cobra.Command{
+		Use:   "recommended",
+		Short: "List the plugins recommended by the active contexts",
+		Long: "List the plugins recommended by the active contexts along with their installation status. " +
+			"Unlike 'tanzu plugin sync', this command does not install anything.",
+		Example: `
+    # List the plugins recommended by the active contexts
+    tanzu plugin recommended
+
+    # List the plugins recommended by the active contexts in json format
+    tanzu plugin recommended -o json`,
+		Args:              cobra.NoArgs,
+		ValidArgsFunction: noMoreCompletions,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			recommendedPlugins, err := pluginmanager.DiscoverServerPlugins()
+			if err != nil {
+				return err
+			}
+			installedPlugins, err := pluginsupplier.GetInstalledPlugins()
+			if err != nil {
+				return err
+			}
+
+			displayRecommendedPlugins(getRecommendedPluginsInfo(recommendedPlugins, installedPlugins), output, cmd.OutOrStdout())
+			return nil
+		},
+	}
+	recommendedCmd.Flags().StringVarP(&output, "output", "o", "", "Output format (yaml|json|table)")
+	utils.PanicOnErr(recommendedCmd.RegisterFlagCompletionFunc("output", completionGetOutputFormats))
+
+	return recommendedCmd
+}
+
+// getInstalledAndMissingContextPlugins splits the context-scoped recommended plugins into the ones
+// that are installed, with their installed version set, and the ones that are missing.
+// Recommended plugins which are not scoped to a context are ignored.
+func getInstalledAndMissingContextPlugins(recommendedPlugins []discovery.Discovered, installedPlugins []cli.PluginInfo) (installed, missing []discovery.Discovered) {
+	for i := range recommendedPlugins {
+		if recommendedPlugins[i].Scope != common.PluginScopeContext {
+			continue
+		}
+		p := recommendedPlugins[i]
+		for j := range installedPlugins {
+			if installedPlugins[j].Name == p.Name && installedPlugins[j].Target == p.Target {
+				p.InstalledVersion = installedPlugins[j].Version
+				break
+			}
+		}
+		if p.InstalledVersion == "" {
+			missing = append(missing, p)
+		} else {
+			installed = append(installed, p)
+		}
+	}
+	return installed, missing
+}
+
+// getRecommendedPluginsInfo returns the recommended plugins along with their installation status:
+// installed, not installed, or update available if the installed version is older than the recommended one.
+func getRecommendedPluginsInfo(recommendedPlugins []discovery.Discovered, installedPlugins []cli.PluginInfo) []recommendedPluginInfo {
+	installed, missing := getInstalledAndMissingContextPlugins(recommendedPlugins, installedPlugins)
+
+	plugins := make([]recommendedPluginInfo, 0, len(installed)+len(missing))
+	for i := range installed {
+		status := common.PluginStatusInstalled
+		if utils.IsNewVersion(installed[i].RecommendedVersion, installed[i].InstalledVersion) {
+			status = common.PluginStatusUpdateAvailable
+		}
+		plugins = append(plugins, recommendedPluginInfo{
+			context:     installed[i].ContextName,
+			name:        installed[i].Name,
+			target:      string(installed[i].Target),
+			recommended: installed[i].RecommendedVersion,
+			installed:   installed[i].InstalledVersion,
+			status:      status,
+		})
+	}
+	for i := range missing {
+		plugins = append(plugins, recommendedPluginInfo{
+			context:     missing[i].ContextName,
+			name:        missing[i].Name,
+			target:      string(missing[i].Target),
+			recommended: missing[i].RecommendedVersion,
+			status:      common.PluginStatusNotInstalled,
+		})
+	}
+
+	sort.Slice(plugins, func(i, j int) bool {
+		if plugins[i].context != plugins[j].context {
+			return plugins[i].context < plugins[j].context
+		}
+		if plugins[i].name != plugins[j].name {
+			return plugins[i].name < plugins[j].name
+		}
+		return plugins[i].target < plugins[j].target
+	})
+	return plugins
+}
+
+func displayRecommendedPlugins(plugins []recommendedPluginInfo, output string, writer io.Writer) {
+	outputWriter := component.NewOutputWriterWithOptions(writer, output, []component.OutputWriterOption{},
+		"Context", "Name", "Target", "Recommended", "Installed", "Status")
+	for i := range plugins {
+		outputWriter.AddRow(plugins[i].context, plugins[i].name, plugins[i].target, plugins[i].recommended, plugins[i].installed, plugins[i].status)
+	}
+	outputWriter.Render()
+}
@@ -0,0 +1,50 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package command
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/common"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/discovery"
+)
+
+func TestGetRecommendedPluginsInfo(t *testing.T) {
+	assert := assert.New(t)
+
+	recommended := []discovery.Discovered{
+		{Name: "cluster", Target: configtypes.TargetTMC, RecommendedVersion: "v1.0.0", ContextName: "tmc-ctx", Scope: common.PluginScopeContext},
+		{Name: "cluster", Target: configtypes.TargetK8s, RecommendedVersion: "v1.1.0", ContextName: "k8s-ctx", Scope: common.PluginScopeContext},
+		{Name: "package", Target: configtypes.TargetK8s, RecommendedVersion: "v2.0.0", ContextName: "k8s-ctx", Scope: common.PluginScopeContext},
+		{Name: "secret", Target: configtypes.TargetK8s, RecommendedVersion: "v3.0.0", ContextName: "k8s-ctx", Scope: common.PluginScopeContext},
+		{Name: "apps", Target: configtypes.TargetK8s, RecommendedVersion: "v1.0.0", ContextName: "k8s-ctx", Scope: common.PluginScopeContext},
+		{Name: "standalone", Target: configtypes.TargetK8s, RecommendedVersion: "v1.0.0", Scope: common.PluginScopeStandalone},
+	}
+	installed := []cli.PluginInfo{
+		{Name: "cluster", Target: configtypes.TargetK8s, Version: "v1.1.0"},
+		{Name: "package", Target: configtypes.TargetK8s, Version: "v1.9.0"},
+		{Name: "secret", Target: configtypes.TargetTMC, Version: "v3.0.0"},
+		{Name: "apps", Target: configtypes.TargetK8s, Version: "v1.2.0"},
+		{Name: "standalone", Target: configtypes.TargetK8s, Version: "v0.9.0"},
+	}
+
+	plugins := getRecommendedPluginsInfo(recommended, installed)
+	assert.Equal([]recommendedPluginInfo{
+		{context: "k8s-ctx", name: "apps", target: "kubernetes", recommended: "v1.0.0", installed: "v1.2.0", status: common.PluginStatusInstalled},
+		{context: "k8s-ctx", name: "cluster", target: "kubernetes", recommended: "v1.1.0", installed: "v1.1.0", status: common.PluginStatusInstalled},
+		{context: "k8s-ctx", name: "package", target: "kubernetes", recommended: "v2.0.0", installed: "v1.9.0", status: common.PluginStatusUpdateAvailable},
+		{context: "k8s-ctx", name: "secret", target: "kubernetes", recommended: "v3.0.0", status: common.PluginStatusNotInstalled},
+		{context: "tmc-ctx", name: "cluster", target: "mission-control", recommended: "v1.0.0", status: common.PluginStatusNotInstalled},
+	}, plugins)
+
+	var out bytes.Buffer
+	displayRecommendedPlugins(plugins[1:2], "json", &out)
+	assert.JSONEq(`[{"context":"k8s-ctx","name":"cluster","target":"kubernetes","recommended":"v1.1.0","installed":"v1.1.0","status":"installed"}]`, out.String())
+}
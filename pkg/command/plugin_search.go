@@ -8,24 +8,34 @@ import (
 	"io"
 	"path/filepath"
 	"sort"
+	"strings"
 
+	"github.com/Masterminds/semver"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
 
+	tkgauth "github.com/vmware-tanzu/tanzu-cli/pkg/auth/tkg"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/common"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/discovery"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/pluginmanager"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/utils"
 	"github.com/vmware-tanzu/tanzu-plugin-runtime/component"
+	configlib "github.com/vmware-tanzu/tanzu-plugin-runtime/config"
 	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
 )
 
 var (
-	showDetails bool
-	pluginName  string
+	showDetails            bool
+	pluginName             string
+	installedCompatibility bool
 )
 
+// getServerKubernetesVersion returns the Kubernetes version of the cluster of a kubeconfig context
+var getServerKubernetesVersion = tkgauth.GetServerKubernetesVersion
+
 const searchLongDesc = `Search provides the ability to search for plugins that can be installed.
 The command lists all plugins currently available for installation.
 The search command also provides flags to limit the scope of the search.
@@ -68,10 +78,15 @@ func newSearchPluginCmd() *cobra.Command {
 			}
 			sort.Sort(discovery.DiscoveredSorter(allPlugins))
 
+			var ctxVersions []contextKubernetesVersion
+			if installedCompatibility {
+				ctxVersions = getActiveContextsKubernetesVersions()
+			}
+
 			if !showDetails {
-				displayPluginsFound(allPlugins, cmd.OutOrStdout())
+				displayPluginsFound(allPlugins, ctxVersions, cmd.OutOrStdout())
 			} else {
-				displayPluginDetails(allPlugins, cmd.OutOrStdout())
+				displayPluginDetails(allPlugins, ctxVersions, cmd.OutOrStdout())
 			}
 
 			return kerrors.NewAggregate(errorList)
@@ -88,6 +103,8 @@ func newSearchPluginCmd() *cobra.Command {
 	f.StringVarP(&outputFormat, "output", "o", "", "output format (yaml|json|table)")
 	utils.PanicOnErr(searchCmd.RegisterFlagCompletionFunc("output", completionGetOutputFormats))
 
+	f.BoolVar(&installedCompatibility, "installed-compatibility", false, "flag the plugin versions known to be incompatible with the Kubernetes version of the clusters of the active contexts")
+
 	f.StringVarP(&local, "local", "", "", "path to local plugin source")
 	msg := fmt.Sprintf("this was done in the %q release, it will be removed following the deprecation policy (6 months). Use the %q flag instead.\n", "v1.0.0", "--local-source")
 	utils.PanicOnErr(f.MarkDeprecated("local", msg))
@@ -109,25 +126,40 @@ func newSearchPluginCmd() *cobra.Command {
 	searchCmd.MarkFlagsMutuallyExclusive("local-source", "name")
 	searchCmd.MarkFlagsMutuallyExclusive("local-source", "target")
 	searchCmd.MarkFlagsMutuallyExclusive("local-source", "show-details")
+	searchCmd.MarkFlagsMutuallyExclusive("local", "installed-compatibility")
+	searchCmd.MarkFlagsMutuallyExclusive("local-source", "installed-compatibility")
 
 	return searchCmd
 }
 
-func displayPluginsFound(plugins []discovery.Discovered, writer io.Writer) {
-	outputWriter := component.NewOutputWriterWithOptions(writer, outputFormat, []component.OutputWriterOption{}, "Name", "Description", "Target", "Latest")
+func displayPluginsFound(plugins []discovery.Discovered, ctxVersions []contextKubernetesVersion, writer io.Writer) {
+	columns := []string{"Name", "Description", "Target", "Latest"}
+	if installedCompatibility {
+		columns = append(columns, "Compatibility")
+	}
+	outputWriter := component.NewOutputWriterWithOptions(writer, outputFormat, []component.OutputWriterOption{}, columns...)
 
 	for i := range plugins {
-		outputWriter.AddRow(
+		row := []interface{}{
 			plugins[i].Name,
 			plugins[i].Description,
 			string(plugins[i].Target),
-			plugins[i].RecommendedVersion)
+			plugins[i].RecommendedVersion,
+		}
+		if installedCompatibility {
+			compatibility := "compatible"
+			if reason := getIncompatibility(&plugins[i], plugins[i].RecommendedVersion, ctxVersions); reason != "" {
+				compatibility = "incompatible with " + reason
+			}
+			row = append(row, compatibility)
+		}
+		outputWriter.AddRow(row...)
 	}
 
 	outputWriter.Render()
 }
 
-func displayPluginDetails(plugins []discovery.Discovered, writer io.Writer) {
+func displayPluginDetails(plugins []discovery.Discovered, ctxVersions []contextKubernetesVersion, writer io.Writer) {
 	// Create a specific object format so it gets printed properly in yaml or json
 	type detailedObject struct {
 		Name        string
@@ -135,6 +167,12 @@ func displayPluginDetails(plugins []discovery.Discovered, writer io.Writer) {
 		Target      string
 		Latest      string
 		Versions    []string
+		// MinCLIVersions is the minimum CLI version required by each version
+		// of the plugin that has such a requirement
+		MinCLIVersions map[string]string `json:"MinCLIVersions,omitempty" yaml:"minCLIVersions,omitempty"`
+		// Incompatible describes, for each version of the plugin known to be incompatible
+		// with the active contexts, the contexts it is incompatible with
+		Incompatible map[string]string `json:"Incompatible,omitempty" yaml:"incompatible,omitempty"`
 	}
 
 	// For the table format, we will use individual yaml output for each plugin
@@ -144,11 +182,13 @@ func displayPluginDetails(plugins []discovery.Discovered, writer io.Writer) {
 				fmt.Println()
 			}
 			details := detailedObject{
-				Name:        plugins[i].Name,
-				Description: plugins[i].Description,
-				Target:      string(plugins[i].Target),
-				Latest:      plugins[i].RecommendedVersion,
-				Versions:    plugins[i].SupportedVersions,
+				Name:           plugins[i].Name,
+				Description:    plugins[i].Description,
+				Target:         string(plugins[i].Target),
+				Latest:         plugins[i].RecommendedVersion,
+				Versions:       plugins[i].SupportedVersions,
+				MinCLIVersions: getMinCLIVersions(&plugins[i]),
+				Incompatible:   getIncompatibleVersions(&plugins[i], ctxVersions),
 			}
 			component.NewObjectWriter(writer, string(component.YAMLOutputType), details).Render()
 		}
@@ -161,12 +201,119 @@ func displayPluginDetails(plugins []discovery.Discovered, writer io.Writer) {
 	var details []detailedObject
 	for i := range plugins {
 		details = append(details, detailedObject{
-			Name:        plugins[i].Name,
-			Description: plugins[i].Description,
-			Target:      string(plugins[i].Target),
-			Latest:      plugins[i].RecommendedVersion,
-			Versions:    plugins[i].SupportedVersions,
+			Name:           plugins[i].Name,
+			Description:    plugins[i].Description,
+			Target:         string(plugins[i].Target),
+			Latest:         plugins[i].RecommendedVersion,
+			Versions:       plugins[i].SupportedVersions,
+			MinCLIVersions: getMinCLIVersions(&plugins[i]),
+			Incompatible:   getIncompatibleVersions(&plugins[i], ctxVersions),
 		})
 	}
 	component.NewObjectWriter(writer, outputFormat, details).Render()
 }
+
+// getMinCLIVersions returns the minimum CLI version required by each version of the
+// plugin for the current platform, or nil if none of the versions has such a requirement
+func getMinCLIVersions(p *discovery.Discovered) map[string]string {
+	if p.Distribution == nil {
+		return nil
+	}
+	var minCLIVersions map[string]string
+	for _, version := range p.SupportedVersions {
+		artifact, err := p.Distribution.DescribeArtifact(version, cli.GOOS, cli.GOARCH)
+		if err != nil || artifact.MinCLIVersion == "" {
+			continue
+		}
+		if minCLIVersions == nil {
+			minCLIVersions = map[string]string{}
+		}
+		minCLIVersions[version] = artifact.MinCLIVersion
+	}
+	return minCLIVersions
+}
+
+// contextKubernetesVersion is the Kubernetes version of the cluster of an active context
+type contextKubernetesVersion struct {
+	contextName string
+	version     *semver.Version
+}
+
+// getActiveContextsKubernetesVersions returns the Kubernetes versions of the clusters
+// of the active contexts that have a kubeconfig and whose cluster can be reached
+func getActiveContextsKubernetesVersions() []contextKubernetesVersion {
+	activeContexts, err := configlib.GetAllActiveContextsList()
+	if err != nil {
+		log.Warningf("unable to get the active contexts: %v", err)
+		return nil
+	}
+
+	var ctxVersions []contextKubernetesVersion
+	for _, ctxName := range activeContexts {
+		ctx, err := configlib.GetContext(ctxName)
+		if err != nil || ctx.ClusterOpts == nil || ctx.ClusterOpts.Path == "" {
+			continue
+		}
+		gitVersion, err := getServerKubernetesVersion(ctx.ClusterOpts.Path, ctx.ClusterOpts.Context)
+		if err != nil {
+			log.Warningf("unable to get the Kubernetes version of the cluster of context %q: %v", ctxName, err)
+			continue
+		}
+		version, err := semver.NewVersion(gitVersion)
+		if err != nil {
+			log.Warningf("unable to parse the Kubernetes version %q of the cluster of context %q: %v", gitVersion, ctxName, err)
+			continue
+		}
+		// Ignore the pre-release and build metadata of the distribution, e.g. v1.27.3+vmware.1,
+		// as they would prevent the version from satisfying the constraints
+		version, _ = semver.NewVersion(fmt.Sprintf("%d.%d.%d", version.Major(), version.Minor(), version.Patch()))
+		ctxVersions = append(ctxVersions, contextKubernetesVersion{contextName: ctxName, version: version})
+	}
+	if len(ctxVersions) == 0 {
+		log.Warningf("no Kubernetes cluster of the active contexts could be reached, the compatibility of the plugins cannot be verified")
+	}
+	return ctxVersions
+}
+
+// getIncompatibility returns a description of the active contexts whose Kubernetes version is
+// not supported by the specified version of the plugin for the current platform, or an empty
+// string if that version is not known to be incompatible with any of them
+func getIncompatibility(p *discovery.Discovered, version string, ctxVersions []contextKubernetesVersion) string {
+	if len(ctxVersions) == 0 || p.Distribution == nil {
+		return ""
+	}
+	artifact, err := p.Distribution.DescribeArtifact(version, cli.GOOS, cli.GOARCH)
+	if err != nil || artifact.KubernetesVersions == "" {
+		return ""
+	}
+	constraint, err := semver.NewConstraint(artifact.KubernetesVersions)
+	if err != nil {
+		log.V(6).Warningf("invalid Kubernetes versions constraint %q for plugin %q version %q: %v", artifact.KubernetesVersions, p.Name, version, err)
+		return ""
+	}
+
+	var incompatibleContexts []string
+	for _, cv := range ctxVersions {
+		if !constraint.Check(cv.version) {
+			incompatibleContexts = append(incompatibleContexts, fmt.Sprintf("context %q (Kubernetes v%s)", cv.contextName, cv.version))
+		}
+	}
+	return strings.Join(incompatibleContexts, ", ")
+}
+
+// getIncompatibleVersions returns the description of the incompatibility of each version of the
+// plugin known to be incompatible with the active contexts, or nil if there is no such version
+func getIncompatibleVersions(p *discovery.Discovered, ctxVersions []contextKubernetesVersion) map[string]string {
+	var incompatible map[string]string
+	for _, version := range p.SupportedVersions {
+		reason := getIncompatibility(p, version, ctxVersions)
+		if reason == "" {
+			continue
+		}
+		if incompatible == nil {
+			incompatible = map[string]string{}
+		}
+		incompatible[version] = reason
+	}
+	return incompatible
+}
@@ -10,7 +10,12 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/Masterminds/semver"
 	"github.com/stretchr/testify/assert"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/discovery"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/distribution"
 )
 
 func TestPluginSearch(t *testing.T) {
@@ -44,6 +49,12 @@ func TestPluginSearch(t *testing.T) {
 			expectedFailure: true,
 			expected:        "if any flags in the group [local show-details] are set none of the others can be",
 		},
+		{
+			test:            "no --local and --installed-compatibility together",
+			args:            []string{"plugin", "search", "--local", "./", "--installed-compatibility"},
+			expectedFailure: true,
+			expected:        "if any flags in the group [local installed-compatibility] are set none of the others can be",
+		},
 	}
 
 	assert := assert.New(t)
@@ -93,6 +104,36 @@ func TestPluginSearch(t *testing.T) {
 	}
 }
 
+func TestPluginSearchIncompatibility(t *testing.T) {
+	assert := assert.New(t)
+
+	plugin := &discovery.Discovered{
+		Name:              "foo",
+		SupportedVersions: []string{"v1.0.0", "v2.0.0", "v3.0.0"},
+		Distribution: distribution.Artifacts{
+			"v1.0.0": distribution.ArtifactList{{OS: cli.GOOS, Arch: cli.GOARCH, KubernetesVersions: "<1.26.0"}},
+			"v2.0.0": distribution.ArtifactList{{OS: cli.GOOS, Arch: cli.GOARCH, KubernetesVersions: ">=1.26.0, <1.29.0"}},
+			"v3.0.0": distribution.ArtifactList{{OS: cli.GOOS, Arch: cli.GOARCH}},
+		},
+	}
+	ctxVersions := []contextKubernetesVersion{
+		{contextName: "old", version: semver.MustParse("1.25.4")},
+		{contextName: "new", version: semver.MustParse("1.28.1")},
+	}
+
+	assert.Equal(`context "new" (Kubernetes v1.28.1)`, getIncompatibility(plugin, "v1.0.0", ctxVersions))
+	assert.Equal(`context "old" (Kubernetes v1.25.4)`, getIncompatibility(plugin, "v2.0.0", ctxVersions))
+	// A plugin version without constraint is not known to be incompatible
+	assert.Equal("", getIncompatibility(plugin, "v3.0.0", ctxVersions))
+	// Nothing is incompatible when no cluster could be reached
+	assert.Equal("", getIncompatibility(plugin, "v1.0.0", nil))
+
+	incompatible := getIncompatibleVersions(plugin, ctxVersions)
+	assert.Equal(2, len(incompatible))
+	assert.NotContains(incompatible, "v3.0.0")
+	assert.Nil(getIncompatibleVersions(plugin, nil))
+}
+
 func TestCompletionPluginSearch(t *testing.T) {
 	// This is global logic and needs not be tested for each
 	// command.  Let's deactivate it.
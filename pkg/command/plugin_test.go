@@ -17,6 +17,7 @@ import (
 	"github.com/vmware-tanzu/tanzu-cli/pkg/catalog"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/common"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
 	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
 	"github.com/vmware-tanzu/tanzu-plugin-runtime/plugin"
 )
@@ -89,6 +90,24 @@ func TestPluginList(t *testing.T) {
 			expectedFailure: false,
 			expected:        `[ { "description": "some foo description", "installationpath": "%v", "name": "foo", "status": "installed", "target": "kubernetes", "version": "v0.1.0" } ]`,
 		},
+		{
+			test:            "plugin describe SBOM of a plugin that is not installed",
+			plugins:         []string{"foo"},
+			versions:        []string{"v0.1.0"},
+			targets:         []configtypes.Target{configtypes.TargetK8s},
+			args:            []string{"plugin", "describe", "bar", "--sbom"},
+			expectedFailure: true,
+			expected:        "unable to find plugin 'bar'",
+		},
+		{
+			test:            "plugin describe SBOM with an output format",
+			plugins:         []string{"foo"},
+			versions:        []string{"v0.1.0"},
+			targets:         []configtypes.Target{configtypes.TargetK8s},
+			args:            []string{"plugin", "describe", "foo", "--sbom", "-o", "json"},
+			expectedFailure: true,
+			expected:        "if any flags in the group [sbom output] are set none of the others can be",
+		},
 	}
 
 	for _, spec := range tests {
@@ -213,6 +232,24 @@ func TestDeletePlugin(t *testing.T) {
 			args:             []string{"plugin", "delete", "foo", "--target", string(configtypes.TargetK8s), "-y"},
 			expectedFailure:  false,
 		},
+		{
+			test:             "delete an installed plugin present for multiple targets qualified with its target",
+			plugins:          []string{"foo", "foo"},
+			remainingPlugins: []bool{false, true},
+			versions:         []string{"v0.1.0", "v0.2.0"},
+			targets:          []configtypes.Target{configtypes.TargetTMC, configtypes.TargetK8s},
+			args:             []string{"plugin", "delete", "foo@tmc", "-y"},
+			expectedFailure:  false,
+		},
+		{
+			test:             "delete a plugin qualified with a target not matching --target",
+			plugins:          []string{"foo", "foo"},
+			versions:         []string{"v0.1.0", "v0.2.0"},
+			targets:          []configtypes.Target{configtypes.TargetTMC, configtypes.TargetK8s},
+			args:             []string{"plugin", "delete", "foo@tmc", "--target", "k8s", "-y"},
+			expectedFailure:  true,
+			expectedErrorMsg: "the target 'mission-control' of plugin 'foo@tmc' does not match the target 'kubernetes' specified by the `--target` flag",
+		},
 		{
 			test:             "delete all installed plugins without using --target",
 			plugins:          []string{"foo", "bar"},
@@ -368,6 +405,12 @@ func TestInstallPlugin(t *testing.T) {
 			expectedFailure:  true,
 			expectedErrorMsg: "if any flags in the group [group version] are set none of the others can be",
 		},
+		{
+			test:             "invalid --os and --arch",
+			args:             []string{"plugin", "install", "--os", "plan9", "--arch", "arm", "myplugin"},
+			expectedFailure:  true,
+			expectedErrorMsg: "unsupported os/arch combination 'plan9_arm'",
+		},
 	}
 
 	assert := assert.New(t)
@@ -406,6 +449,48 @@ func TestInstallPlugin(t *testing.T) {
 	}
 }
 
+func TestOverridePluginArch(t *testing.T) {
+	assert := assert.New(t)
+
+	realArch := cli.BuildArch()
+	defer cli.SetArch(realArch)
+
+	// No override
+	restore, err := overridePluginArch("", "")
+	assert.Nil(err)
+	assert.Equal(realArch, cli.BuildArch())
+	restore()
+
+	// Override both the OS and the architecture using the flags
+	restore, err = overridePluginArch("windows", "arm64")
+	assert.Nil(err)
+	assert.Equal(cli.WinARM64, cli.BuildArch())
+	restore()
+	assert.Equal(realArch, cli.BuildArch())
+
+	// Override only the OS using the variables
+	os.Setenv(constants.ConfigVariablePluginOS, "darwin")
+	os.Setenv(constants.ConfigVariablePluginArch, "")
+	defer os.Unsetenv(constants.ConfigVariablePluginOS)
+	defer os.Unsetenv(constants.ConfigVariablePluginArch)
+	restore, err = overridePluginArch("", "")
+	assert.Nil(err)
+	assert.Equal(cli.Arch("darwin_"+realArch.Arch()), cli.BuildArch())
+	restore()
+
+	// The flags take precedence over the variables
+	os.Setenv(constants.ConfigVariablePluginArch, "arm64")
+	restore, err = overridePluginArch("linux", "amd64")
+	assert.Nil(err)
+	assert.Equal(cli.LinuxAMD64, cli.BuildArch())
+	restore()
+
+	// Invalid combination
+	_, err = overridePluginArch("linux", "invalid")
+	assert.ErrorContains(err, "unsupported os/arch combination 'linux_invalid'")
+	assert.Equal(realArch, cli.BuildArch())
+}
+
 func TestUpgradePlugin(t *testing.T) {
 	tests := []struct {
 		test             string
@@ -419,6 +504,12 @@ func TestUpgradePlugin(t *testing.T) {
 			expectedFailure:  true,
 			expectedErrorMsg: invalidTargetMsg,
 		},
+		{
+			test:             "invalid target qualifying the plugin name",
+			args:             []string{"plugin", "upgrade", "myplugin@invalid"},
+			expectedFailure:  true,
+			expectedErrorMsg: "invalid plugin 'myplugin@invalid'",
+		},
 	}
 
 	assert := assert.New(t)
@@ -504,6 +595,21 @@ func TestCompletionPlugin(t *testing.T) {
 			expected: "_activeHelp_ " + compNoMoreArgsMsg + "\n:4\n",
 		},
 		// =====================
+		// tanzu plugin recommended
+		// =====================
+		{
+			test: "no completions for the plugin recommended command",
+			args: []string{"__complete", "plugin", "recommended", ""},
+			// ":4" is the value of the ShellCompDirectiveNoFileComp
+			expected: "_activeHelp_ " + compNoMoreArgsMsg + "\n:4\n",
+		},
+		{
+			test: "completion for the --output flag value of the plugin recommended command",
+			args: []string{"__complete", "plugin", "recommended", "--output", ""},
+			// ":4" is the value of the ShellCompDirectiveNoFileComp
+			expected: expectedOutForOutputFlag + ":4\n",
+		},
+		// =====================
 		// tanzu plugin install
 		// =====================
 		{
@@ -706,11 +812,13 @@ func TestCompletionPlugin(t *testing.T) {
 			test: "completion for the plugin upgrade command",
 			args: []string{"__complete", "plugin", "upgrade", ""},
 			// ":4" is the value of the ShellCompDirectiveNoFileComp
-			expected: "cluster\tMultiple entries for plugin cluster. You will need to use the --target flag.\n" +
+			expected: "cluster@k8s\tPlugin cluster/kubernetes description\n" +
+				"cluster@tmc\tPlugin cluster/mission-control description\n" +
 				"feature\tPlugin feature/kubernetes description\n" +
 				"isolated-cluster\tPlugin isolated-cluster/global description\n" +
 				"login\tPlugin login/global description\n" +
-				"management-cluster\tMultiple entries for plugin management-cluster. You will need to use the --target flag.\n" +
+				"management-cluster@k8s\tPlugin management-cluster/kubernetes description\n" +
+				"management-cluster@tmc\tPlugin management-cluster/mission-control description\n" +
 				"package\tPlugin package/kubernetes description\n" +
 				"secret\tPlugin secret/kubernetes description\n" +
 				":4\n",
@@ -733,6 +841,12 @@ func TestCompletionPlugin(t *testing.T) {
 			expected: "--target\n" +
 				":4\n",
 		},
+		{
+			test: "no more completions for the plugin upgrade command when the specified plugin name is qualified with its target",
+			args: []string{"__complete", "plugin", "upgrade", "cluster@k8s", ""},
+			// ":4" is the value of the ShellCompDirectiveNoFileComp
+			expected: "_activeHelp_ " + compNoMoreArgsMsg + "\n:4\n",
+		},
 		{
 			test: "no more completions for the plugin upgrade command when the specified plugin name is not unique and --target is specified",
 			args: []string{"__complete", "plugin", "upgrade", "cluster", "--target", "k8s", ""},
@@ -774,6 +888,33 @@ func TestCompletionPlugin(t *testing.T) {
 			// ":4" is the value of the ShellCompDirectiveNoFileComp
 			expected: "_activeHelp_ " + compNoMoreArgsMsg + "\n:4\n",
 		},
+		{
+			test: "no completion for the --version flag value for the plugin upgrade command with no plugin name",
+			args: []string{"__complete", "plugin", "upgrade", "--version", ""},
+			// ":4" is the value of the ShellCompDirectiveNoFileComp
+			expected: "_activeHelp_ You must first specify a plugin name to be able to complete its version\n" +
+				":4\n",
+		},
+		{
+			test: "completion for the --version flag value for the plugin upgrade command with a plugin name and --target",
+			args: []string{"__complete", "plugin", "upgrade", "management-cluster", "--target", "tmc", "--version", ""},
+			// ":36" is the value of the ShellCompDirectiveNoFileComp | ShellCompDirectiveKeepOrder
+			expected: "v0.2.0\n" +
+				"v0.0.3\n" +
+				"v0.0.2\n" +
+				"v0.0.1\n" +
+				":36\n",
+		},
+		{
+			test: "completion for the --version flag value for the plugin upgrade command with a plugin name qualified with its target",
+			args: []string{"__complete", "plugin", "upgrade", "management-cluster@tmc", "--version", ""},
+			// ":36" is the value of the ShellCompDirectiveNoFileComp | ShellCompDirectiveKeepOrder
+			expected: "v0.2.0\n" +
+				"v0.0.3\n" +
+				"v0.0.2\n" +
+				"v0.0.1\n" +
+				":36\n",
+		},
 		// =====================
 		// tanzu plugin uninstall
 		// =====================
@@ -782,9 +923,11 @@ func TestCompletionPlugin(t *testing.T) {
 			args: []string{"__complete", "plugin", "uninstall", ""},
 			// ":36" is the value of the ShellCompDirectiveNoFileComp | ShellCompDirectiveKeepOrder
 			expected: "all\tAll plugins for a target. You will need to use the --target flag.\n" +
-				"cluster\tMultiple entries for plugin cluster. You will need to use the --target flag.\n" +
+				"cluster@k8s\tTarget: kubernetes for cluster\n" +
+				"cluster@tmc\tTarget: mission-control for cluster\n" +
 				"feature\tTarget: kubernetes for feature\n" +
-				"management-cluster\tMultiple entries for plugin management-cluster. You will need to use the --target flag.\n" +
+				"management-cluster@k8s\tTarget: kubernetes for management-cluster\n" +
+				"management-cluster@tmc\tTarget: mission-control for management-cluster\n" +
 				"secret\tTarget: kubernetes for secret\n" +
 				":36\n",
 		},
@@ -806,6 +949,12 @@ func TestCompletionPlugin(t *testing.T) {
 			expected: "--target\n" +
 				":4\n",
 		},
+		{
+			test: "no more completions for the plugin uninstall command when the specified plugin name is qualified with its target",
+			args: []string{"__complete", "plugin", "uninstall", "cluster@tmc", ""},
+			// ":4" is the value of the ShellCompDirectiveNoFileComp
+			expected: "_activeHelp_ " + compNoMoreArgsMsg + "\n:4\n",
+		},
 		{
 			test: "no more completions for the plugin uninstall command when the specified plugin name is not unique and --target is specified",
 			args: []string{"__complete", "plugin", "uninstall", "cluster", "--target", "k8s", ""},
@@ -876,9 +1025,11 @@ func TestCompletionPlugin(t *testing.T) {
 			test: "completion for the plugin describe command",
 			args: []string{"__complete", "plugin", "describe", ""},
 			// ":4" is the value of the ShellCompDirectiveNoFileComp
-			expected: "cluster\tMultiple entries for plugin cluster. You will need to use the --target flag.\n" +
+			expected: "cluster@k8s\tTarget: kubernetes for cluster\n" +
+				"cluster@tmc\tTarget: mission-control for cluster\n" +
 				"feature\tTarget: kubernetes for feature\n" +
-				"management-cluster\tMultiple entries for plugin management-cluster. You will need to use the --target flag.\n" +
+				"management-cluster@k8s\tTarget: kubernetes for management-cluster\n" +
+				"management-cluster@tmc\tTarget: mission-control for management-cluster\n" +
 				"secret\tTarget: kubernetes for secret\n" +
 				":4\n",
 		},
@@ -978,4 +1129,5 @@ func resetPluginCommandFlags() {
 	groupID = ""
 	showDetails = false
 	pluginName = ""
+	installedCompatibility = false
 }
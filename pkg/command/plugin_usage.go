@@ -0,0 +1,71 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package command
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/component"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/pluginmanager"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/utils"
+)
+
+func newUsagePluginCmd() *cobra.Command {
+	var usageCmd = &cobra.Command{
+		Use:   "usage",
+		Short: "Show the disk usage of the plugins",
+		Long: "Show the disk space used by the binaries of each plugin, including the binaries of the versions " +
+			"which are not installed but are kept to switch back to them without downloading them again. " +
+			"The number of versions kept can be limited with 'tanzu config set cli.plugin-retain-versions <n>' " +
+			"and the binaries of these versions removed with 'tanzu plugin clean --old-versions'.",
+		ValidArgsFunction: noMoreCompletions,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			usages, err := pluginmanager.GetPluginDiskUsage()
+			if err != nil {
+				return err
+			}
+			displayPluginDiskUsage(usages, cmd.OutOrStdout())
+			return nil
+		},
+	}
+	usageCmd.Flags().StringVarP(&outputFormat, "output", "o", "", "Output format (yaml|json|table)")
+	utils.PanicOnErr(usageCmd.RegisterFlagCompletionFunc("output", completionGetOutputFormats))
+
+	return usageCmd
+}
+
+func displayPluginDiskUsage(usages []pluginmanager.PluginDiskUsage, writer io.Writer) {
+	outputWriter := component.NewOutputWriterWithOptions(writer, outputFormat, []component.OutputWriterOption{},
+		"Name", "Target", "Installed", "Versions", "Size")
+	var total int64
+	for i := range usages {
+		outputWriter.AddRow(usages[i].Name, string(usages[i].Target), strings.Join(usages[i].InstalledVersions, ", "),
+			strings.Join(usages[i].Versions, ", "), formatDiskSize(usages[i].Size))
+		total += usages[i].Size
+	}
+	outputWriter.Render()
+
+	if isTableOutputFormat() && len(usages) > 0 {
+		fmt.Fprintf(writer, "\nTotal: %s\n", formatDiskSize(total))
+	}
+}
+
+// formatDiskSize formats a size in bytes using binary units
+func formatDiskSize(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+	div, exp := int64(unit), 0
+	for n := size / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(size)/float64(div), "KMGTPE"[exp])
+}
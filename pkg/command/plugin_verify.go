@@ -0,0 +1,81 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package command
+
+import (
+	"io"
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/component"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/pluginmanager"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/utils"
+)
+
+func newVerifyPluginCmd() *cobra.Command {
+	var output string
+	var checkPluginSources bool
+
+	var verifyCmd = &cobra.Command{
+		Use:   "verify",
+		Short: "Verify the binaries of the installed plugins",
+		Long: "Verify that the binaries of the installed plugins have not been modified since their installation " +
+			"by comparing their digest with the digest recorded when they were installed.",
+		Example: `
+    # Verify the binaries of the installed plugins
+    tanzu plugin verify
+
+    # Also verify that the binaries match the ones published in the plugin sources
+    tanzu plugin verify --plugin-sources`,
+		Args:              cobra.NoArgs,
+		ValidArgsFunction: noMoreCompletions,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			results, err := pluginmanager.VerifyInstalledPlugins(checkPluginSources)
+			if err != nil {
+				return err
+			}
+
+			displayPluginVerificationResults(results, output, cmd.OutOrStdout())
+			return getPluginVerificationError(results)
+		},
+	}
+	verifyCmd.Flags().StringVarP(&output, "output", "o", "", "Output format (yaml|json|table)")
+	utils.PanicOnErr(verifyCmd.RegisterFlagCompletionFunc("output", completionGetOutputFormats))
+	verifyCmd.Flags().BoolVar(&checkPluginSources, "plugin-sources", false, "also verify that the binaries match the ones published in the plugin sources")
+
+	return verifyCmd
+}
+
+// getPluginVerificationError returns an error if the binary of any installed plugin was tampered with
+func getPluginVerificationError(results []pluginmanager.PluginVerificationResult) error {
+	tampered := 0
+	for i := range results {
+		if results[i].Status == pluginmanager.PluginVerificationStatusTampered {
+			tampered++
+		}
+	}
+	if tampered > 0 {
+		return errors.Errorf("the binaries of %d installed plugin(s) have been modified since their installation", tampered)
+	}
+	return nil
+}
+
+func displayPluginVerificationResults(results []pluginmanager.PluginVerificationResult, output string, writer io.Writer) {
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Name != results[j].Name {
+			return results[i].Name < results[j].Name
+		}
+		return results[i].Target < results[j].Target
+	})
+
+	outputWriter := component.NewOutputWriterWithOptions(writer, output, []component.OutputWriterOption{},
+		"Name", "Target", "Version", "Status", "Details")
+	for i := range results {
+		outputWriter.AddRow(results[i].Name, results[i].Target, results[i].Version, results[i].Status, results[i].Details)
+	}
+	outputWriter.Render()
+}
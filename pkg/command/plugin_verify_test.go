@@ -0,0 +1,35 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package command
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/pluginmanager"
+)
+
+func TestPluginVerificationResults(t *testing.T) {
+	assert := assert.New(t)
+
+	results := []pluginmanager.PluginVerificationResult{
+		{Name: "secret", Target: "kubernetes", Version: "v1.0.0", Status: pluginmanager.PluginVerificationStatusOK},
+		{Name: "cluster", Target: "kubernetes", Version: "v2.0.0", Status: pluginmanager.PluginVerificationStatusStale, Details: "stale"},
+	}
+	assert.Nil(getPluginVerificationError(results))
+
+	var out bytes.Buffer
+	displayPluginVerificationResults(results, "json", &out)
+	assert.JSONEq(`[
+		{"name":"cluster","target":"kubernetes","version":"v2.0.0","status":"stale","details":"stale"},
+		{"name":"secret","target":"kubernetes","version":"v1.0.0","status":"ok","details":""}
+	]`, out.String())
+
+	results = append(results, pluginmanager.PluginVerificationResult{Name: "package", Status: pluginmanager.PluginVerificationStatusTampered})
+	err := getPluginVerificationError(results)
+	assert.NotNil(err)
+	assert.Contains(err.Error(), "the binaries of 1 installed plugin(s) have been modified")
+}
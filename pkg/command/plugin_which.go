@@ -0,0 +1,183 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package command
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/component"
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/discovery"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/pluginmanager"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/pluginsupplier"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/utils"
+)
+
+const (
+	pluginWhichStatusActive   = "active"
+	pluginWhichStatusShadowed = "shadowed"
+	// pluginWhichStatusUnmanaged is the status of the plugin binaries left by older
+	// installations of the CLI, which are not managed by the plugin catalog
+	pluginWhichStatusUnmanaged = "unmanaged"
+)
+
+// pluginWhichInfo describes an installed plugin which provides a top-level command
+type pluginWhichInfo struct {
+	name    string
+	target  string
+	version string
+	path    string
+	context string
+	status  string
+}
+
+func newWhichPluginCmd() *cobra.Command {
+	var whichCmd = &cobra.Command{
+		Use:   "which COMMAND",
+		Short: "Show which plugin handles a command",
+		Long: "Show which installed plugin binary handles a top-level command of the CLI, along with its version, " +
+			"target and the active context recommending it. Other installed plugins providing the same command " +
+			"are listed as shadowed, and binaries of the plugin left by older installations of the CLI as unmanaged.",
+		Example: `
+    # Show which plugin handles 'tanzu cluster'
+    tanzu plugin which cluster
+
+    # Show which plugin handles 'tanzu cluster' in json format
+    tanzu plugin which cluster -o json`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeWhichPlugin,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			installedPlugins, err := pluginsupplier.GetInstalledPlugins()
+			if err != nil {
+				return err
+			}
+			// The contexts recommending the plugins are only informative
+			recommendedPlugins, err := pluginmanager.DiscoverServerPlugins()
+			if err != nil {
+				log.V(6).Warningf("unable to discover the plugins recommended by the active contexts: %v", err)
+			}
+
+			handlingCmd := findRootSubCommandByName(cmd.Root(), args[0])
+			plugins := getPluginWhichInfo(args[0], handlingCmd, installedPlugins, recommendedPlugins)
+			if handlingCmd == nil && len(plugins) == 0 {
+				return errors.Errorf("no command %q found", args[0])
+			}
+			if handlingCmd != nil && !isPluginCommand(handlingCmd) && isTableOutputFormat() {
+				log.Infof("'%s' is a command of the CLI itself", handlingCmd.CommandPath())
+			}
+
+			displayPluginWhichInfo(plugins, cmd.OutOrStdout(), cmd.ErrOrStderr())
+			return nil
+		},
+	}
+	whichCmd.Flags().StringVarP(&outputFormat, "output", "o", "", "Output format (yaml|json|table)")
+	utils.PanicOnErr(whichCmd.RegisterFlagCompletionFunc("output", completionGetOutputFormats))
+	addNoHintsFlag(whichCmd)
+
+	return whichCmd
+}
+
+// getPluginWhichInfo returns the installed plugins providing the top-level command:
+// the plugin handling the command, if any, followed by the plugins it shadows.
+func getPluginWhichInfo(name string, handlingCmd *cobra.Command, installedPlugins []cli.PluginInfo, recommendedPlugins []discovery.Discovered) []pluginWhichInfo {
+	handlingPath := ""
+	if handlingCmd != nil && isPluginCommand(handlingCmd) {
+		handlingPath = handlingCmd.Annotations["pluginInstallationPath"]
+	}
+
+	var plugins []pluginWhichInfo
+	for i := range installedPlugins {
+		p := &installedPlugins[i]
+		isHandling := handlingPath != "" && p.InstallationPath == handlingPath
+		providesCmd := isPluginRootCmdTargeted(p) && (p.Name == name || slices.Contains(p.Aliases, name))
+		if !isHandling && !providesCmd {
+			continue
+		}
+
+		info := pluginWhichInfo{
+			name:    p.Name,
+			target:  string(p.Target),
+			version: p.Version,
+			path:    p.InstallationPath,
+			context: getRecommendingContexts(p, recommendedPlugins),
+			status:  pluginWhichStatusShadowed,
+		}
+		if isHandling {
+			info.status = pluginWhichStatusActive
+		}
+		plugins = append(plugins, info)
+
+		if isHandling {
+			for _, binary := range cli.FindShadowingPluginBinaries(p) {
+				plugins = append(plugins, pluginWhichInfo{name: p.Name, path: binary, status: pluginWhichStatusUnmanaged})
+			}
+		}
+	}
+
+	sort.SliceStable(plugins, func(i, j int) bool {
+		return plugins[i].status == pluginWhichStatusActive && plugins[j].status != pluginWhichStatusActive
+	})
+	return plugins
+}
+
+// getRecommendingContexts returns the comma-separated names of the active contexts recommending the plugin
+func getRecommendingContexts(p *cli.PluginInfo, recommendedPlugins []discovery.Discovered) string {
+	var contexts []string
+	for i := range recommendedPlugins {
+		if recommendedPlugins[i].Name == p.Name && recommendedPlugins[i].Target == p.Target &&
+			recommendedPlugins[i].ContextName != "" && !slices.Contains(contexts, recommendedPlugins[i].ContextName) {
+			contexts = append(contexts, recommendedPlugins[i].ContextName)
+		}
+	}
+	return strings.Join(contexts, ", ")
+}
+
+func displayPluginWhichInfo(plugins []pluginWhichInfo, writer, hintWriter io.Writer) {
+	outputWriter := component.NewOutputWriterWithOptions(writer, outputFormat, []component.OutputWriterOption{},
+		"Name", "Target", "Version", "Status", "Context", "Path")
+	outputWriter.MarkDynamicKeys("Context")
+	statuses := make(map[string]bool)
+	for i := range plugins {
+		outputWriter.AddRow(plugins[i].name, plugins[i].target, plugins[i].version, plugins[i].status, plugins[i].context, plugins[i].path)
+		statuses[plugins[i].status] = true
+	}
+	outputWriter.Render()
+
+	var hints []string
+	if statuses[pluginWhichStatusShadowed] {
+		hints = append(hints, "Note: the shadowed plugins cannot be invoked using this command. Uninstall them with 'tanzu plugin delete' if they are not needed.")
+	}
+	if statuses[pluginWhichStatusUnmanaged] {
+		hints = append(hints, "Note: the unmanaged binaries were left by older installations of the CLI, are not used by this CLI, and can be removed.")
+	}
+	printHints(hintWriter, hints...)
+}
+
+// ====================================
+// Shell completion functions
+// ====================================
+
+// completeWhichPlugin completes the top-level commands of the CLI
+func completeWhichPlugin(cmd *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return activeHelpNoMoreArgs(nil), cobra.ShellCompDirectiveNoFileComp
+	}
+
+	var comps []string
+	for _, subCmd := range cmd.Root().Commands() {
+		if subCmd.IsAvailableCommand() {
+			comps = append(comps, fmt.Sprintf("%s\t%s", subCmd.Name(), subCmd.Short))
+		}
+	}
+	return comps, cobra.ShellCompDirectiveNoFileComp
+}
@@ -0,0 +1,67 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package command
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/common"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/discovery"
+)
+
+func TestGetPluginWhichInfo(t *testing.T) {
+	assert := assert.New(t)
+
+	installed := []cli.PluginInfo{
+		{Name: "cluster", Target: configtypes.TargetK8s, Version: "v1.0.0", InstallationPath: "/plugins/cluster_k8s"},
+		{Name: "cluster", Target: configtypes.TargetGlobal, Version: "v2.0.0", InstallationPath: "/plugins/cluster_global"},
+		{Name: "cluster", Target: configtypes.TargetTMC, Version: "v3.0.0", InstallationPath: "/plugins/cluster_tmc"},
+		{Name: "clusters", Target: configtypes.TargetK8s, Version: "v4.0.0", InstallationPath: "/plugins/clusters", Aliases: []string{"cl"}},
+	}
+	recommended := []discovery.Discovered{
+		{Name: "cluster", Target: configtypes.TargetGlobal, ContextName: "ctx1"},
+		{Name: "cluster", Target: configtypes.TargetGlobal, ContextName: "ctx2"},
+	}
+	handlingCmd := &cobra.Command{
+		Use: "cluster",
+		Annotations: map[string]string{
+			"type":                   common.CommandTypePlugin,
+			"pluginInstallationPath": "/plugins/cluster_global",
+		},
+	}
+
+	// The plugin of the mission-control target is not invoked at the root level
+	plugins := getPluginWhichInfo("cluster", handlingCmd, installed, recommended)
+	assert.Equal([]pluginWhichInfo{
+		{name: "cluster", target: "global", version: "v2.0.0", path: "/plugins/cluster_global", context: "ctx1, ctx2", status: pluginWhichStatusActive},
+		{name: "cluster", target: "kubernetes", version: "v1.0.0", path: "/plugins/cluster_k8s", status: pluginWhichStatusShadowed},
+	}, plugins)
+
+	// Plugins are also found by alias and can be shadowed by a core command
+	plugins = getPluginWhichInfo("cl", &cobra.Command{Use: "cl"}, installed, nil)
+	assert.Equal([]pluginWhichInfo{
+		{name: "clusters", target: "kubernetes", version: "v4.0.0", path: "/plugins/clusters", status: pluginWhichStatusShadowed},
+	}, plugins)
+
+	assert.Empty(getPluginWhichInfo("unknown", nil, installed, recommended))
+
+	var out, hints bytes.Buffer
+	displayPluginWhichInfo(plugins, &out, &hints)
+	assert.NotContains(out.String(), "shadowed plugins cannot be invoked")
+	assert.Contains(hints.String(), "shadowed plugins cannot be invoked")
+
+	// Hints are not printed when using --no-hints
+	noHints = true
+	defer func() { noHints = false }()
+	hints.Reset()
+	displayPluginWhichInfo(plugins, &out, &hints)
+	assert.Empty(hints.String())
+}
@@ -0,0 +1,27 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package command
+
+import "strings"
+
+// profileFlag enables the profiling of the command.  It is only recognized right after
+// "tanzu", e.g. "tanzu --profile plugin list", so that it does not conflict with flags of
+// the plugins which are named the same.  "--profile=DIR" also writes pprof profiles in DIR.
+const profileFlag = "--profile"
+
+// extractProfileFlag removes the profile flag from the arguments of the CLI, not including
+// the program name, and returns whether profiling was requested and in which directory the
+// pprof profiles should be written, if any
+func extractProfileFlag(args []string) (remaining []string, profile bool, dir string) {
+	if len(args) == 0 {
+		return args, false, ""
+	}
+	if args[0] == profileFlag {
+		return args[1:], true, ""
+	}
+	if value, found := strings.CutPrefix(args[0], profileFlag+"="); found {
+		return args[1:], true, value
+	}
+	return args, false, ""
+}
@@ -0,0 +1,51 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package command
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtractProfileFlag(t *testing.T) {
+	tests := []struct {
+		args              []string
+		expectedArgs      []string
+		expectedProfile   bool
+		expectedDirectory string
+	}{
+		{
+			args:         []string{},
+			expectedArgs: []string{},
+		},
+		{
+			args:         []string{"plugin", "list"},
+			expectedArgs: []string{"plugin", "list"},
+		},
+		{
+			args:            []string{"--profile", "plugin", "list"},
+			expectedArgs:    []string{"plugin", "list"},
+			expectedProfile: true,
+		},
+		{
+			args:              []string{"--profile=/tmp/profile", "cluster", "list"},
+			expectedArgs:      []string{"cluster", "list"},
+			expectedProfile:   true,
+			expectedDirectory: "/tmp/profile",
+		},
+		{
+			// The flag of a plugin is not mistaken for the profile flag
+			args:         []string{"cluster", "list", "--profile", "prod"},
+			expectedArgs: []string{"cluster", "list", "--profile", "prod"},
+		},
+	}
+
+	for _, tt := range tests {
+		args, profile, dir := extractProfileFlag(tt.args)
+		assert.Equal(t, tt.expectedArgs, args)
+		assert.Equal(t, tt.expectedProfile, profile)
+		assert.Equal(t, tt.expectedDirectory, dir)
+	}
+}
@@ -10,6 +10,8 @@ import (
 	"os"
 	"os/exec"
 	"os/signal"
+	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
@@ -34,6 +36,7 @@ import (
 	"github.com/vmware-tanzu/tanzu-cli/pkg/lastversion"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/pluginmanager"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/pluginsupplier"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/profiling"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/recommendedversion"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/telemetry"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/utils"
@@ -89,11 +92,18 @@ func createRootCmd() (*cobra.Command, error) { //nolint: gocyclo
 	uFunc := cli.NewMainUsage().UsageFunc()
 	rootCmd.SetUsageFunc(uFunc)
 
+	stopConfigLoadTracking := profiling.Track(profiling.PhaseConfigLoad)
 	// Configure defined environment variables found in the config file
 	cliconfig.ConfigureEnvVariables()
+	common.ApplyDirectoryOverrides()
+
+	// Move the directories of older CLI versions before anything reads them
+	cliconfig.MigrateLegacyDirectories()
+	stopConfigLoadTracking()
 
 	rootCmd.AddCommand(
 		newVersionCmd(),
+		newUpdateCmd(),
 		newPluginCmd(),
 		newLoginCmd(),
 		newInitCmd(),
@@ -109,23 +119,23 @@ func createRootCmd() (*cobra.Command, error) { //nolint: gocyclo
 		//       If we decide to fold this functionality into existing 'tanzu telemetry' plugin
 		newCEIPParticipationCmd(),
 		newGenAllDocsCmd(),
+		newRefreshCacheCmd(),
+		newAliasCmd(),
 	)
 	if _, err := ensureCLIInstanceID(); err != nil {
 		return nil, errors.Wrap(err, "failed to ensure CLI ID")
 	}
 
-	// Setup the commands for the plugins under the k8s and tmc targets
-	if err := setupTargetPlugins(); err != nil {
-		return nil, err
-	}
-
 	plugins, err := pluginsupplier.GetInstalledPlugins()
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("unable to find installed plugins: %w", err)
 	}
 
 	convertInvokedAs(plugins)
 
+	// Setup the commands for the plugins under the k8s and tmc targets
+	setupTargetPlugins(plugins)
+
 	telemetry.Client().SetInstalledPlugins(plugins)
 	if err = config.CopyLegacyConfigDir(); err != nil {
 		return nil, fmt.Errorf("failed to copy legacy configuration directory to new location: %w", err)
@@ -133,6 +143,8 @@ func createRootCmd() (*cobra.Command, error) { //nolint: gocyclo
 
 	var maskedPluginsWithPluginOverlap []string
 	var maskedPluginsWithCoreCmdOverlap []string
+	// invocations through their target command group of the plugins which are masked at the root level
+	var maskedPluginsPrefixedInvocations []string
 
 	for i := range plugins {
 		// Only add plugins that should be available as root level command
@@ -169,6 +181,9 @@ func createRootCmd() (*cobra.Command, error) { //nolint: gocyclo
 					maskedPluginsWithPluginOverlap = append(maskedPluginsWithPluginOverlap, plugins[i].Name)
 				} else {
 					maskedPluginsWithCoreCmdOverlap = append(maskedPluginsWithCoreCmdOverlap, plugins[i].Name)
+					if invocation := targetPrefixedInvocation(&plugins[i]); invocation != "" {
+						maskedPluginsPrefixedInvocations = append(maskedPluginsPrefixedInvocations, invocation)
+					}
 				}
 			}
 		}
@@ -189,14 +204,15 @@ func createRootCmd() (*cobra.Command, error) { //nolint: gocyclo
 
 	remapCommandTree(rootCmd, plugins)
 	updateTargetCommandGroupVisibility()
-	updateConfigWithTanzuCSPIssuer(csp.GetIssuerUpdateFlagFromCentralConfig, datastore.GetDataStoreValue)
-	updateConfigWithTanzuPlatformEndpointChanges()
 
 	if len(maskedPluginsWithPluginOverlap) > 0 {
 		catalog.DeleteIncorrectPluginEntriesFromCatalog()
 	}
 	if len(maskedPluginsWithCoreCmdOverlap) > 0 {
 		fmt.Fprintf(os.Stderr, "Warning, masking commands for plugins %q because a core command with that name already exists. \n", strings.Join(maskedPluginsWithCoreCmdOverlap, ", "))
+		if len(maskedPluginsPrefixedInvocations) > 0 {
+			fmt.Fprintf(os.Stderr, "The masked plugins can still be invoked using their target prefix: %s. \n", strings.Join(maskedPluginsPrefixedInvocations, ", "))
+		}
 	}
 	duplicateAliasWarning(rootCmd)
 
@@ -274,6 +290,14 @@ func buildReplacementMap(plugins []cli.PluginInfo) map[string]*cobra.Command {
 // updateTargetCommandGroupVisibility hides commands associated with target
 // command group if latter did not acquire any child commands
 func updateTargetCommandGroupVisibility() {
+	// The kubernetes command group is deprecated unless the user chose to
+	// invoke the plugins of the kubernetes target using the target prefix
+	if config.IsFeatureActivated(constants.FeatureTargetPrefixedCommands) {
+		k8sCmd.Deprecated = ""
+	} else {
+		k8sCmd.Deprecated = k8sCmdDeprecatedMsg
+	}
+
 	for _, targetCmd := range []*cobra.Command{k8sCmd, tmcCmd, opsCmd, tpeCmd} {
 		if len(targetCmd.Commands()) == 0 {
 			targetCmd.Hidden = true
@@ -305,31 +329,76 @@ func findPluginsRequiringActiveContextCheck(rootCmd *cobra.Command, plugins []cl
 }
 
 // setupTargetPlugins sets up the commands for the plugins under the k8s and tmc targets
-func setupTargetPlugins() error {
-	mapTargetToCmd := map[configtypes.Target]*cobra.Command{
+func setupTargetPlugins(plugins []cli.PluginInfo) {
+	mapTargetToCmd := targetCommands()
+
+	// Insert the plugin commands under the appropriate target command
+	for i := range plugins {
+		if targetCmd, exists := mapTargetToCmd[plugins[i].Target]; exists {
+			cmd := cli.GetUnmappedCmdForPlugin(&plugins[i])
+			if cmd != nil {
+				targetCmd.AddCommand(cmd)
+			}
+		}
+	}
+}
+
+// targetCommands returns the command groups under which the plugins of each target are inserted
+func targetCommands() map[configtypes.Target]*cobra.Command {
+	return map[configtypes.Target]*cobra.Command{
 		configtypes.TargetK8s:        k8sCmd,
 		configtypes.TargetTMC:        tmcCmd,
 		configtypes.TargetOperations: opsCmd,
 	}
+}
 
-	plugins, err := pluginsupplier.GetInstalledPlugins()
-	if err != nil {
-		return fmt.Errorf("unable to find installed plugins: %w", err)
+// targetPrefixedInvocation returns how to invoke the plugin through the command group of its target,
+// or an empty string if target-prefixed commands are not activated or the target has no command group
+func targetPrefixedInvocation(pluginInfo *cli.PluginInfo) string {
+	if !config.IsFeatureActivated(constants.FeatureTargetPrefixedCommands) {
+		return ""
+	}
+	targetCmd, exists := targetCommands()[pluginInfo.Target]
+	if !exists {
+		return ""
 	}
+	return fmt.Sprintf("'tanzu %s %s'", targetCmd.Name(), pluginInfo.Name)
+}
 
-	convertInvokedAs(plugins)
+// unknownRootCommandRegex matches the error returned by cobra for an unknown sub-command of the root command
+var unknownRootCommandRegex = regexp.MustCompile(`^unknown command "([^"]+)" for "tanzu"`)
 
-	// Insert the plugin commands under the appropriate target command
+// explainUnknownCommand enriches the error returned for an unknown command of the root command
+// when a plugin with that name is installed for a target that is not available at the root level.
+// For example, a plugin "cluster" installed only for the mission-control target must be
+// invoked as "tanzu mission-control cluster".
+func explainUnknownCommand(err error, plugins []cli.PluginInfo) error {
+	if err == nil {
+		return nil
+	}
+	matches := unknownRootCommandRegex.FindStringSubmatch(err.Error())
+	if matches == nil {
+		return err
+	}
+
+	mapTargetToCmd := targetCommands()
+	var invocations []string
 	for i := range plugins {
+		if plugins[i].Name != matches[1] || isPluginRootCmdTargeted(&plugins[i]) {
+			continue
+		}
 		if targetCmd, exists := mapTargetToCmd[plugins[i].Target]; exists {
-			cmd := cli.GetUnmappedCmdForPlugin(&plugins[i])
-			if cmd != nil {
-				targetCmd.AddCommand(cmd)
+			invocation := fmt.Sprintf("'tanzu %s %s'", targetCmd.Name(), plugins[i].Name)
+			if !slices.Contains(invocations, invocation) {
+				invocations = append(invocations, invocation)
 			}
 		}
 	}
-
-	return nil
+	if len(invocations) == 0 {
+		return err
+	}
+	return fmt.Errorf("%w\nThe '%s' plugin is installed for a target that is not invoked from the root level. Use %s instead",
+		err, matches[1], strings.Join(invocations, " or "))
 }
 
 // updateConfigWithTanzuCSPIssuer updates the "tanzu" and "mission-control" CLI contexts issuers with TCSP if the
@@ -403,6 +472,8 @@ func newRootCmd() *cobra.Command {
 		SilenceErrors: true,
 		// silencing usage for now as we are getting double usage from plugins on errors
 		SilenceUsage: true,
+		// Complete the aliases of commands along with the commands themselves
+		ValidArgsFunction: completeCommandAliases,
 		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
 			// Sets the verbosity of the logger if TANZU_CLI_LOG_LEVEL is set
 			setLoggerVerbosity()
@@ -424,6 +495,14 @@ func newRootCmd() *cobra.Command {
 				lastversion.SetLastExecutedCLIVersion()
 			}
 
+			// Apply the context updates requested by the central configuration.
+			// This is not done when creating the root command so that commands which
+			// don't use the contexts, like shell completion, don't read the central configuration.
+			if !shouldSkipContextUpdates(cmd) {
+				updateConfigWithTanzuCSPIssuer(csp.GetIssuerUpdateFlagFromCentralConfig, datastore.GetDataStoreValue)
+				updateConfigWithTanzuPlatformEndpointChanges()
+			}
+
 			// Ensure mutual exclusion in current contexts just in case if any plugins with old
 			// plugin-runtime sets k8s context as current when tanzu context is already set as current
 			if err := utils.EnsureMutualExclusiveCurrentContexts(); err != nil {
@@ -463,8 +542,21 @@ func newRootCmd() *cobra.Command {
 			return nil
 		},
 		PersistentPostRunE: func(cmd *cobra.Command, args []string) error {
+			if !shouldSkipCentralConfigRefresh(cmd) {
+				if isBackgroundCacheRefreshEnabled() {
+					// The refresh is done by a background process so that neither this command
+					// nor the next ones wait for it
+					startBackgroundCacheRefreshIfDue()
+				} else {
+					// The refresh is done by a background process so as not to delay this
+					// command.  The next commands use the refreshed central configuration.
+					startCentralConfigRefreshIfStale()
+				}
+			}
+
 			if !shouldSkipVersionCheck(cmd) {
 				recommendedversion.CheckRecommendedCLIVersion(cmd)
+				recommendedversion.CheckPluginUpdates(cmd)
 			}
 
 			// Ensure mutual exclusion in current contexts just in case if any plugins with old
@@ -472,6 +564,7 @@ func newRootCmd() *cobra.Command {
 			return utils.EnsureMutualExclusiveCurrentContexts()
 		},
 	}
+	rootCmd.PersistentFlags().BoolVar(&assumeYes, assumeYesFlag, false, "answer yes to all confirmation prompts, as with the --yes flag of each command")
 	return rootCmd
 }
 
@@ -522,14 +615,17 @@ func handleCommandGroupHelp(cmd *cobra.Command, args []string) {
 	cmd.HelpFunc()(cmd, args)
 }
 
+const k8sCmdDeprecatedMsg = `you should invoke its sub-commands directly without the "kubernetes" prefix.`
+
 var k8sCmd = &cobra.Command{
 	Use:     "kubernetes",
 	Short:   "Commands that interact with a Kubernetes endpoint",
 	Aliases: []string{"k8s"},
 	// We are moving away from the 'kubernetes' target.
 	// All commands under this target are accessible as sub-commands of the root command.
-	// For backwards compatibility, we are keeping the target but are hiding it.
-	Deprecated: `you should invoke its sub-commands directly without the "kubernetes" prefix.`,
+	// For backwards compatibility, we are keeping the target but are hiding it,
+	// unless the target-prefixed-commands feature is activated.
+	Deprecated: k8sCmdDeprecatedMsg,
 	Annotations: map[string]string{
 		"group": string(plugin.TargetCmdGroup),
 	},
@@ -685,8 +781,12 @@ func ensureCLIInstanceID() (string, error) {
 }
 
 // isSkipCommand returns true if the command is part of the skip list by checking the prefix of
-// the command's command path matches with one of the item in the skip command list
-func isSkipCommand(skipCommandList []string, commandPath string) bool {
+// the command's command path matches with one of the item in the skip command list.
+// The skip lists use "tanzu" as the name of the root command, which can be renamed
+// when the CLI is embedded (see WithCommandName), so the command path is made relative
+// to the root command before being compared.
+func isSkipCommand(skipCommandList []string, cmd *cobra.Command) bool {
+	commandPath := "tanzu" + strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name())
 	skipCommand := false
 	for _, cmdPath := range skipCommandList {
 		if strings.HasPrefix(commandPath, cmdPath) {
@@ -703,6 +803,8 @@ func shouldSkipTelemetryCollection(cmd *cobra.Command) bool {
 		// The shell completion setup is not interactive, so it should not trigger a prompt
 		"tanzu __complete",
 		"tanzu completion",
+		// The background refresh of the cache is not a user command
+		"tanzu __refresh-cache",
 		// Common first command to run,
 		"tanzu version",
 		// Can be used to set the prompt on every shell command
@@ -710,7 +812,7 @@ func shouldSkipTelemetryCollection(cmd *cobra.Command) bool {
 		// should skip telemetry for "telemetry" plugin
 		"tanzu telemetry",
 	}
-	return isSkipCommand(skipTelemetryCollectionCommands, cmd.CommandPath())
+	return isSkipCommand(skipTelemetryCollectionCommands, cmd)
 }
 
 // shouldSkipPrompts checks if the prompts should be skipped for the command
@@ -720,6 +822,8 @@ func shouldSkipPrompts(cmd *cobra.Command) bool {
 		// The shell completion setup is not interactive, so it should not trigger a prompt
 		"tanzu __complete",
 		"tanzu completion",
+		// The background refresh process has no terminal to prompt the user
+		"tanzu __refresh-cache",
 		// Common first command to run,
 		"tanzu version",
 		// It would be a chicken and egg issue if user tries to set CEIP configuration
@@ -738,8 +842,10 @@ func shouldSkipPrompts(cmd *cobra.Command) bool {
 		// get to see the prompts and the kubectl command execution just gets stuck, and it
 		// is very hard for users to figure out what is going wrong
 		"tanzu context get-token",
+		// This command prompts for the EULA itself as part of the CLI setup
+		"tanzu init",
 	}
-	return isSkipCommand(skipCommands, cmd.CommandPath())
+	return isSkipCommand(skipCommands, cmd)
 }
 
 func shouldSkipEssentialPlugins(cmd *cobra.Command) bool {
@@ -749,6 +855,8 @@ func shouldSkipEssentialPlugins(cmd *cobra.Command) bool {
 		// and break shell completion
 		"tanzu __complete",
 		"tanzu completion",
+		// The background refresh process only refreshes the cache
+		"tanzu __refresh-cache",
 		// Common first command to run
 		"tanzu version",
 
@@ -772,9 +880,12 @@ func shouldSkipEssentialPlugins(cmd *cobra.Command) bool {
 		// Avoid trying to install essential plugins when the user initializes or updates the plugin
 		// source information since the essential plugins installation would use the old plugin source
 		"tanzu plugin source",
+		// This command installs the essential plugins itself once the user has chosen
+		// the central repository
+		"tanzu init",
 	}
 
-	return isSkipCommand(skipCommandsForEssentials, cmd.CommandPath())
+	return isSkipCommand(skipCommandsForEssentials, cmd)
 }
 
 // shouldSkipVersionCheck checks if the CLI recommended version check should be skipped
@@ -785,6 +896,8 @@ func shouldSkipVersionCheck(cmd *cobra.Command) bool {
 		// extra printouts to the user for recommending a new version of the CLI
 		"tanzu __complete",
 		"tanzu completion",
+		// The output of the background refresh process is not seen by the user
+		"tanzu __refresh-cache",
 		// Common first command to run, let's not recommend a new version of the CLI
 		"tanzu version",
 		// Can be used to set the prompt on every shell command
@@ -795,8 +908,52 @@ func shouldSkipVersionCheck(cmd *cobra.Command) bool {
 		// This command is being invoked by the kubectl exec binary where the user doesn't
 		// get to see the output so, we should avoid printing the new version availability
 		"tanzu pinniped-auth",
+		// The CLI has just been updated, so the recommendation no longer applies
+		"tanzu update",
 	}
-	return isSkipCommand(skipVersionCheckCommands, cmd.CommandPath())
+	return isSkipCommand(skipVersionCheckCommands, cmd)
+}
+
+// shouldSkipCentralConfigRefresh checks if the automatic refresh of the central configuration
+// should be skipped for the specified command
+func shouldSkipCentralConfigRefresh(cmd *cobra.Command) bool {
+	skipCentralConfigRefreshCommands := []string{
+		// The shell completion logic must be fast and should not access the network
+		"tanzu __complete",
+		"tanzu completion",
+		// The background refresh process refreshes the central configuration itself
+		"tanzu __refresh-cache",
+		// Common first command to run, let's not perform extra tasks
+		"tanzu version",
+		// Can be used to set the prompt on every shell command
+		"tanzu context current",
+		// This command is being invoked by the kubectl exec binary where the user doesn't
+		// get to see the output, so we should avoid delaying it
+		"tanzu context get-token",
+		"tanzu pinniped-auth",
+		// The central configuration has just been refreshed explicitly
+		"tanzu config central refresh",
+		// Refreshing the central configuration uses the plugin sources
+		// which are being modified by these commands
+		"tanzu plugin source",
+		"tanzu plugin clean",
+	}
+	return isSkipCommand(skipCentralConfigRefreshCommands, cmd)
+}
+
+// shouldSkipContextUpdates checks if the updates of the contexts requested by the
+// central configuration should be skipped for the specified command
+func shouldSkipContextUpdates(cmd *cobra.Command) bool {
+	skipContextUpdatesCommands := []string{
+		// The shell completion logic must be fast and does not use the contexts
+		"tanzu __complete",
+		"tanzu completion",
+		// The background refresh process does not use the contexts
+		"tanzu __refresh-cache",
+		// Common first command to run, let's not perform extra tasks
+		"tanzu version",
+	}
+	return isSkipCommand(skipContextUpdatesCommands, cmd)
 }
 
 // shouldSkipGlobalInit checks if the initialization of a new CLI version should be skipped
@@ -807,6 +964,8 @@ func shouldSkipGlobalInit(cmd *cobra.Command) bool {
 		// the global initialization of the CLI
 		"tanzu __complete",
 		"tanzu completion",
+		// The background refresh process is not interactive
+		"tanzu __refresh-cache",
 		// Common first command to run, let's not perform extra tasks
 		"tanzu version",
 		// Can be used to set the prompt on every shell command
@@ -815,7 +974,7 @@ func shouldSkipGlobalInit(cmd *cobra.Command) bool {
 		// so it should not trigger the global initialization of the CLI
 		"tanzu context get-token",
 	}
-	return isSkipCommand(skipGlobalInitCommands, cmd.CommandPath())
+	return isSkipCommand(skipGlobalInitCommands, cmd)
 }
 
 var globalRootCmd *cobra.Command
@@ -835,11 +994,30 @@ func NewRootCmd() (*cobra.Command, error) {
 
 // Execute executes the CLI.
 func Execute() error {
+	if args, profile, profileDir := extractProfileFlag(os.Args[1:]); profile {
+		// The flag is removed so that neither the commands nor the plugins see it
+		os.Args = append(os.Args[:1], args...)
+		if err := profiling.Enable(profileDir); err != nil {
+			log.Warningf("unable to profile the command: %v", err)
+		}
+	}
+
 	rootCmd, err := NewRootCmd()
 	if err != nil {
 		return err
 	}
+	// The settings of the root command created by NewRootCmdWithOptions only apply while the CLI runs
+	defer globalRootCmdSettings.apply()()
+
+	// Aliases are expanded once the root command knows all the commands, including plugins
+	os.Args = append(os.Args[:1], expandCommandAlias(rootCmd, os.Args[1:])...)
+
 	executionErr := rootCmd.Execute()
+	if executionErr != nil {
+		if plugins, err := pluginsupplier.GetInstalledPlugins(); err == nil {
+			executionErr = explainUnknownCommand(executionErr, plugins)
+		}
+	}
 	exitCode := 0
 	if executionErr != nil {
 		exitCode = 1
@@ -858,6 +1036,10 @@ func Execute() error {
 	} else if sendErr := telemetry.Client().SendMetrics(context.Background(), 0); sendErr != nil {
 		telemetry.LogError(sendErr, "")
 	}
+
+	if err := profiling.Stop(os.Stderr); err != nil {
+		log.Warningf("unable to complete the profile of the command: %v", err)
+	}
 	return executionErr
 }
 
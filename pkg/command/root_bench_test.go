@@ -0,0 +1,30 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package command
+
+import (
+	"io"
+	"testing"
+)
+
+// BenchmarkRootCmdHelp measures the creation of the root command and the
+// display of its help, which is the work done by every invocation of 'tanzu --help'
+func BenchmarkRootCmdHelp(b *testing.B) {
+	env := setupTestCLIEnvironment(b)
+	defer tearDownTestCLIEnvironment(env)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		rootCmd, err := NewRootCmdForTest()
+		if err != nil {
+			b.Fatal(err)
+		}
+		rootCmd.SetOut(io.Discard)
+		rootCmd.SetErr(io.Discard)
+		rootCmd.SetArgs([]string{"--help"})
+		if err := rootCmd.Execute(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
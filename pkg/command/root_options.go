@@ -0,0 +1,188 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package command
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	configlib "github.com/vmware-tanzu/tanzu-plugin-runtime/config"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/discovery"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/utils"
+)
+
+// rootCmdOptions holds the options of the root command
+type rootCmdOptions struct {
+	name             string
+	short            string
+	configDir        string
+	in               io.Reader
+	out              io.Writer
+	errOut           io.Writer
+	discoveryFactory discovery.DiscoveryFactory
+}
+
+// RootCmdOption configures the root command created by NewRootCmdWithOptions
+type RootCmdOption func(o *rootCmdOptions)
+
+// WithCommandName sets the name of the root command, e.g. the name of a branded CLI
+func WithCommandName(name string) RootCmdOption {
+	return func(o *rootCmdOptions) {
+		o.name = name
+	}
+}
+
+// WithShortDescription sets the short description of the root command
+func WithShortDescription(short string) RootCmdOption {
+	return func(o *rootCmdOptions) {
+		o.short = short
+	}
+}
+
+// WithConfigDir sets the directory holding the configuration files of the CLI.
+// The directory is also used by the plugins invoked by the CLI.
+func WithConfigDir(dir string) RootCmdOption {
+	return func(o *rootCmdOptions) {
+		o.configDir = dir
+	}
+}
+
+// WithIOStreams sets the input, output and error streams of the CLI
+func WithIOStreams(in io.Reader, out, errOut io.Writer) RootCmdOption {
+	return func(o *rootCmdOptions) {
+		o.in = in
+		o.out = out
+		o.errOut = errOut
+	}
+}
+
+// WithDiscoveryFactory sets the factory used to create the Discovery of the
+// plugin discovery sources, which allows providing custom discovery sources
+func WithDiscoveryFactory(factory discovery.DiscoveryFactory) RootCmdOption {
+	return func(o *rootCmdOptions) {
+		o.discoveryFactory = factory
+	}
+}
+
+// NewRootCmdWithOptions creates the root command of the CLI configured with the
+// provided options, allowing a program to embed the CLI and its plugin architecture.
+// The created command replaces the one returned by NewRootCmd and used by Execute.
+// The configuration directory and the IO streams are only applied to the process,
+// which the configuration files and the logger require, while the command is created
+// and while Execute runs; the previous settings of the program are restored afterwards.
+func NewRootCmdWithOptions(opts ...RootCmdOption) (*cobra.Command, error) {
+	o := &rootCmdOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	settings, err := o.processSettings()
+	if err != nil {
+		return nil, err
+	}
+
+	globalRootCmdLock.Lock()
+	defer globalRootCmdLock.Unlock()
+
+	// The configuration and the discoveries must be set up before creating the
+	// root command, as it reads the configuration and the installed plugins
+	if o.discoveryFactory != nil {
+		discovery.SetDiscoveryFactory(o.discoveryFactory)
+	}
+	restore := settings.apply()
+	rootCmd, err := createRootCmd()
+	restore()
+	if err != nil {
+		return nil, err
+	}
+
+	if o.name != "" {
+		rootCmd.Use = o.name
+	}
+	if o.short != "" {
+		rootCmd.Short = o.short
+	}
+	if o.in != nil {
+		rootCmd.SetIn(o.in)
+	}
+	if o.out != nil {
+		rootCmd.SetOut(o.out)
+	}
+	if o.errOut != nil {
+		rootCmd.SetErr(o.errOut)
+	}
+
+	globalRootCmd = rootCmd
+	globalRootCmdSettings = settings
+	return rootCmd, nil
+}
+
+// processSettings are the settings of the root command which apply to the whole process
+type processSettings struct {
+	// env are the environment variables pointing the configuration files to the
+	// configuration directory, which the plugins inherit
+	env    map[string]string
+	out    io.Writer
+	errOut io.Writer
+}
+
+// globalRootCmdSettings are the process settings of the root command created by
+// NewRootCmdWithOptions, applied by Execute while the CLI runs
+var globalRootCmdSettings *processSettings
+
+// processSettings returns the process settings of the options
+func (o *rootCmdOptions) processSettings() (*processSettings, error) {
+	settings := &processSettings{out: o.out, errOut: o.errOut}
+	if o.configDir == "" {
+		return settings, nil
+	}
+
+	absDir, err := filepath.Abs(o.configDir)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid configuration directory %q", o.configDir)
+	}
+	if err := os.MkdirAll(absDir, 0755); err != nil {
+		return nil, errors.Wrapf(err, "unable to create the configuration directory %q", o.configDir)
+	}
+	settings.env = map[string]string{
+		configlib.EnvConfigKey:         filepath.Join(absDir, configlib.ConfigName),
+		configlib.EnvConfigNextGenKey:  filepath.Join(absDir, configlib.CfgNextGenName),
+		configlib.EnvConfigMetadataKey: filepath.Join(absDir, configlib.CfgMetadataName),
+	}
+	return settings, nil
+}
+
+// apply applies the settings to the process and returns a function restoring the previous settings
+func (s *processSettings) apply() (restore func()) {
+	if s == nil {
+		return func() {}
+	}
+
+	previousEnv := map[string]*string{}
+	for envVar, value := range s.env {
+		if previous, found := os.LookupEnv(envVar); found {
+			previousEnv[envVar] = &previous
+		} else {
+			previousEnv[envVar] = nil
+		}
+		_ = os.Setenv(envVar, value)
+	}
+	restoreLogWriters := utils.SetLogWriters(s.out, s.errOut)
+
+	return func() {
+		restoreLogWriters()
+		for envVar, previous := range previousEnv {
+			if previous == nil {
+				_ = os.Unsetenv(envVar)
+			} else {
+				_ = os.Setenv(envVar, *previous)
+			}
+		}
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package command
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/discovery"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/utils"
+)
+
+func TestNewRootCmdWithOptions(t *testing.T) {
+	env := setupTestCLIEnvironment(t)
+	defer tearDownTestCLIEnvironment(env)
+	defer func() {
+		globalRootCmd = nil
+		globalRootCmdSettings = nil
+		discovery.SetDiscoveryFactory(nil)
+	}()
+
+	assert := assert.New(t)
+
+	configDir := filepath.Join(t.TempDir(), "config")
+	factoryCalled := false
+	factory := func(pd configtypes.PluginDiscovery, options ...discovery.DiscoveryOptions) (discovery.Discovery, error) {
+		factoryCalled = true
+		return discovery.DefaultDiscoveryFactory(pd, options...)
+	}
+	var out, errOut bytes.Buffer
+
+	// The program embedding the CLI has its own configuration and log writers
+	programConfig := os.Getenv("TANZU_CONFIG")
+	var programOut bytes.Buffer
+	restoreLogWriters := utils.SetLogWriters(&programOut, nil)
+	defer restoreLogWriters()
+
+	rootCmd, err := NewRootCmdWithOptions(
+		WithCommandName("mycli"),
+		WithShortDescription("My branded CLI"),
+		WithConfigDir(configDir),
+		WithIOStreams(&bytes.Buffer{}, &out, &errOut),
+		WithDiscoveryFactory(factory),
+	)
+	assert.Nil(err)
+	assert.Equal("mycli", rootCmd.Use)
+	assert.Equal("My branded CLI", rootCmd.Short)
+
+	// The created command is the one used by Execute
+	cmd, err := NewRootCmd()
+	assert.Nil(err)
+	assert.Same(rootCmd, cmd)
+
+	// The configuration files are in the configuration directory while the CLI runs
+	restore := globalRootCmdSettings.apply()
+	assert.Equal(filepath.Join(configDir, "config.yaml"), os.Getenv("TANZU_CONFIG"))
+	assert.Equal(filepath.Join(configDir, "config-ng.yaml"), os.Getenv("TANZU_CONFIG_NEXT_GEN"))
+	assert.Equal(filepath.Join(configDir, ".config-metadata.yaml"), os.Getenv("TANZU_CONFIG_METADATA"))
+	log.Outputf("cli output")
+	restore()
+
+	// The settings of the program are restored
+	assert.Equal(programConfig, os.Getenv("TANZU_CONFIG"))
+	log.Outputf("program output")
+	assert.Contains(out.String(), "cli output")
+	assert.Contains(programOut.String(), "program output")
+	assert.NotContains(programOut.String(), "cli output")
+
+	// The discoveries are created using the factory
+	_, err = discovery.CreateDiscoveryFromV1alpha1(configtypes.PluginDiscovery{
+		Local: &configtypes.LocalDiscovery{Name: "local", Path: t.TempDir()},
+	})
+	assert.Nil(err)
+	assert.True(factoryCalled)
+
+	// The output is written to the provided stream
+	rootCmd.SetArgs([]string{"--help"})
+	assert.Nil(rootCmd.Execute())
+	assert.Contains(out.String(), "mycli")
+}
+
+func TestSkipCommandsWithRenamedRootCommand(t *testing.T) {
+	rootCmd := &cobra.Command{Use: "mycli"}
+	completeCmd := &cobra.Command{Use: "__complete"}
+	refreshCmd := &cobra.Command{Use: "__refresh-cache"}
+	pluginCmd := &cobra.Command{Use: "plugin"}
+	listCmd := &cobra.Command{Use: "list"}
+	pluginCmd.AddCommand(listCmd)
+	rootCmd.AddCommand(completeCmd, refreshCmd, pluginCmd)
+
+	// The skip lists apply whatever the name of the root command
+	for _, cmd := range []*cobra.Command{completeCmd, refreshCmd} {
+		assert.True(t, shouldSkipPrompts(cmd), cmd.CommandPath())
+		assert.True(t, shouldSkipGlobalInit(cmd), cmd.CommandPath())
+		assert.True(t, shouldSkipEssentialPlugins(cmd), cmd.CommandPath())
+		assert.True(t, shouldSkipVersionCheck(cmd), cmd.CommandPath())
+		assert.True(t, shouldSkipTelemetryCollection(cmd), cmd.CommandPath())
+	}
+	assert.False(t, shouldSkipPrompts(listCmd))
+	assert.False(t, shouldSkipEssentialPlugins(listCmd))
+}
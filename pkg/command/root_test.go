@@ -6,14 +6,17 @@ package command
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/vmware-tanzu/tanzu-plugin-runtime/config"
@@ -107,7 +110,7 @@ type testCLIEnvironment struct {
 }
 
 // helper to set up a clean environment to create a root CLI command
-func setupTestCLIEnvironment(t *testing.T) testCLIEnvironment {
+func setupTestCLIEnvironment(t testing.TB) testCLIEnvironment {
 	dir, err := os.MkdirTemp("", "tanzu-cli-root-cmd")
 	assert.Nil(t, err)
 	os.Setenv("TEST_CUSTOM_CATALOG_CACHE_DIR", dir)
@@ -141,6 +144,8 @@ func setupTestCLIEnvironment(t *testing.T) testCLIEnvironment {
 	helpFlag = k8sCmd.Flags().Lookup("help")
 	if helpFlag != nil {
 		_ = helpFlag.Value.Set("false")
+		// A changed flag prevents the completion of the plugins of the target
+		helpFlag.Changed = false
 	}
 	opsCmd.ResetCommands()
 	opsCmd.Hidden = false
@@ -195,6 +200,33 @@ func TestSubcommandNonexistent(t *testing.T) {
 	assert.NotNil(err)
 }
 
+func TestExplainUnknownCommand(t *testing.T) {
+	assert := assert.New(t)
+
+	plugins := []cli.PluginInfo{
+		{Name: "cluster", Target: configtypes.TargetK8s},
+		{Name: "cluster", Target: configtypes.TargetTMC},
+		{Name: "policy", Target: configtypes.TargetTMC},
+		{Name: "policy", Target: configtypes.TargetTMC, Scope: "Context"},
+		{Name: "policy", Target: configtypes.TargetOperations},
+	}
+
+	// Errors other than an unknown command are not modified
+	otherErr := errors.New("some error")
+	assert.Equal(otherErr, explainUnknownCommand(otherErr, plugins))
+	assert.Nil(explainUnknownCommand(nil, plugins))
+
+	// Unknown commands that don't match a plugin of a target are not modified
+	unknownErr := errors.New(`unknown command "foo" for "tanzu"`)
+	assert.Equal(unknownErr, explainUnknownCommand(unknownErr, plugins))
+
+	// Unknown commands matching plugins of a target explain how to invoke them
+	unknownErr = errors.New(`unknown command "policy" for "tanzu"`)
+	err := explainUnknownCommand(unknownErr, plugins)
+	assert.ErrorIs(err, unknownErr)
+	assert.Contains(err.Error(), "Use 'tanzu mission-control policy' or 'tanzu operations policy' instead")
+}
+
 func TestSubcommands(t *testing.T) {
 	tests := []struct {
 		test              string
@@ -450,54 +482,55 @@ func TestTargetCommands(t *testing.T) {
 		{
 			test:       "top run for all empty targets",
 			args:       []string{},
-			unexpected: []string{"Target", "kubernetes", "mission-control", "operations"},
+			// The target commands are indented, unlike the targets heading the plugin commands
+			unexpected: []string{"Target", "    kubernetes ", "mission-control", "operations"},
 		},
 		{
 			test:                   "top run for only ops empty",
 			args:                   []string{},
 			installedPluginTargets: []configtypes.Target{configtypes.TargetTMC},
 			expected:               []string{"Target", "mission-control"},
-			unexpected:             []string{"kubernetes", "operations"},
+			unexpected:             []string{"    kubernetes ", "operations"},
 		},
 		{
 			test:                   "top run for only tmc empty",
 			args:                   []string{},
 			installedPluginTargets: []configtypes.Target{configtypes.TargetK8s, configtypes.TargetOperations},
 			expected:               []string{"Target", "operations"},
-			unexpected:             []string{"mission-control", "kubernetes"},
+			unexpected:             []string{"mission-control", "    kubernetes "},
 		},
 		{
 			test:                   "top run for no empty targets",
 			args:                   []string{},
 			installedPluginTargets: []configtypes.Target{configtypes.TargetK8s, configtypes.TargetTMC, configtypes.TargetOperations},
 			expected:               []string{"Target", "operations", "mission-control"},
-			unexpected:             []string{"kubernetes"},
+			unexpected:             []string{"    kubernetes "},
 		},
 		{
 			test:       "top help for all empty targets",
 			args:       []string{"-h"},
-			unexpected: []string{"Target", "kubernetes", "mission-control", "operations"},
+			unexpected: []string{"Target", "    kubernetes ", "mission-control", "operations"},
 		},
 		{
 			test:                   "top help for only ops empty",
 			args:                   []string{"-h"},
 			installedPluginTargets: []configtypes.Target{configtypes.TargetTMC},
 			expected:               []string{"Target", "mission-control"},
-			unexpected:             []string{"kubernetes", "operations"},
+			unexpected:             []string{"    kubernetes ", "operations"},
 		},
 		{
 			test:                   "top help for only tmc empty",
 			args:                   []string{"-h"},
 			installedPluginTargets: []configtypes.Target{configtypes.TargetK8s, configtypes.TargetOperations},
 			expected:               []string{"Target", "operations"},
-			unexpected:             []string{"mission-control", "kubernetes"},
+			unexpected:             []string{"mission-control", "    kubernetes "},
 		},
 		{
 			test:                   "top help for no empty targets",
 			args:                   []string{"-h"},
 			installedPluginTargets: []configtypes.Target{configtypes.TargetK8s, configtypes.TargetTMC, configtypes.TargetOperations},
 			expected:               []string{"Target", "operations", "mission-control"},
-			unexpected:             []string{"kubernetes"},
+			unexpected:             []string{"    kubernetes "},
 		},
 		// ========================
 		// Tests for the k8s target
@@ -819,6 +852,141 @@ func TestTargetCommands(t *testing.T) {
 	}
 }
 
+func TestTargetPrefixedCommands(t *testing.T) {
+	tests := []struct {
+		test            string
+		featureActive   bool
+		args            []string
+		expected        []string
+		unexpected      []string
+		expectedFailure bool
+	}{
+		{
+			test:       "top help without the feature",
+			args:       []string{"-h"},
+			unexpected: []string{"    kubernetes "},
+		},
+		{
+			test:          "top help with the feature",
+			featureActive: true,
+			args:          []string{"-h"},
+			expected:      []string{"Target", "    kubernetes "},
+		},
+		{
+			test:     "k8s target without the feature",
+			args:     []string{"k8s"},
+			expected: []string{"Command \"kubernetes\" is deprecated", "dummy"},
+		},
+		{
+			test:          "k8s target with the feature",
+			featureActive: true,
+			args:          []string{"k8s"},
+			expected:      []string{"Commands that interact with a Kubernetes endpoint", "dummy"},
+			unexpected:    []string{"is deprecated"},
+		},
+		{
+			test:       "completion of target commands without the feature",
+			args:       []string{"__complete", ""},
+			unexpected: []string{"kubernetes"},
+		},
+		{
+			test:          "completion of target commands with the feature",
+			featureActive: true,
+			args:          []string{"__complete", ""},
+			expected:      []string{"kubernetes\tCommands that interact with a Kubernetes endpoint"},
+		},
+		{
+			test:          "completion of the plugins of the target with the feature",
+			featureActive: true,
+			args:          []string{"__complete", "kubernetes", ""},
+			expected:      []string{"dummy\tdummy"},
+		},
+	}
+
+	for _, spec := range tests {
+		env := setupTestCLIEnvironment(t)
+		defer tearDownTestCLIEnvironment(env)
+
+		t.Run(spec.test, func(t *testing.T) {
+			assert := assert.New(t)
+
+			if spec.featureActive {
+				assert.Nil(config.SetFeature("global", "target-prefixed-commands", "true"))
+			}
+
+			pi := &cli.PluginInfo{
+				Name:             "dummy",
+				Description:      "dummy",
+				Group:            plugin.SystemCmdGroup,
+				Version:          "v0.1.0",
+				Aliases:          []string{},
+				InstallationPath: filepath.Join(env.cacheDir, fmt.Sprintf("%s_%s", "dummy", string(configtypes.TargetK8s))),
+				Target:           configtypes.TargetK8s,
+			}
+			err := setupFakePlugin(env.cacheDir, pi.Name, pi.Version, pi.Group, 0, pi.Target, 0, pi.Hidden, pi.Aliases)
+			assert.Nil(err)
+
+			cc, err := catalog.NewContextCatalogUpdater("")
+			assert.Nil(err)
+			err = cc.Upsert(pi)
+			cc.Unlock()
+			assert.Nil(err)
+
+			r, w, err := os.Pipe()
+			if err != nil {
+				t.Error(err)
+			}
+			c := make(chan []byte)
+			go readOutput(t, r, c)
+
+			stdout := os.Stdout
+			stderr := os.Stderr
+			defer func() {
+				os.Stdout = stdout
+				os.Stderr = stderr
+			}()
+			os.Stdout = w
+			os.Stderr = w
+
+			rootCmd, err := NewRootCmdForTest()
+			assert.Nil(err)
+			rootCmd.SetArgs(spec.args)
+
+			err = rootCmd.Execute()
+
+			w.Close()
+			got := <-c
+
+			assert.Equal(spec.expectedFailure, err != nil)
+			for _, expected := range spec.expected {
+				assert.Contains(string(got), expected)
+			}
+			for _, unexpected := range spec.unexpected {
+				assert.NotContains(string(got), unexpected)
+			}
+		})
+	}
+}
+
+func TestTargetPrefixedInvocation(t *testing.T) {
+	assert := assert.New(t)
+
+	env := setupTestCLIEnvironment(t)
+	defer tearDownTestCLIEnvironment(env)
+
+	k8sPlugin := &cli.PluginInfo{Name: "dummy", Target: configtypes.TargetK8s}
+	tmcPlugin := &cli.PluginInfo{Name: "dummy", Target: configtypes.TargetTMC}
+	globalPlugin := &cli.PluginInfo{Name: "dummy", Target: configtypes.TargetGlobal}
+
+	// No hint unless the plugins can be invoked using the target prefix
+	assert.Empty(targetPrefixedInvocation(k8sPlugin))
+
+	assert.Nil(config.SetFeature("global", "target-prefixed-commands", "true"))
+	assert.Equal("'tanzu kubernetes dummy'", targetPrefixedInvocation(k8sPlugin))
+	assert.Equal("'tanzu mission-control dummy'", targetPrefixedInvocation(tmcPlugin))
+	assert.Empty(targetPrefixedInvocation(globalPlugin))
+}
+
 func TestGlobalInit(t *testing.T) {
 	tests := []struct {
 		test         string
@@ -1029,12 +1197,17 @@ func TestCompletionShortHelpInActiveHelp(t *testing.T) {
 				"group\tManage plugin-groups\n" +
 				"install\tInstall a plugin\n" +
 				"list\tList installed plugins\n" +
+				"outdated\tList the installed plugins which are outdated or affected by security advisories\n" +
+				"recommended\tList the plugins recommended by the active contexts\n" +
 				"search\tSearch for available plugins\n" +
 				"source\tManage plugin discovery sources\n" +
 				"sync\tInstalls all plugins recommended by the active contexts\n" +
 				"uninstall\tUninstall a plugin\n" +
 				"upgrade\tUpgrade a plugin\n" +
 				"upload-bundle\tUpload plugin bundle to a repository\n" +
+				"usage\tShow the disk usage of the plugins\n" +
+				"verify\tVerify the binaries of the installed plugins\n" +
+				"which\tShow which plugin handles a command\n" +
 				"_activeHelp_ Command help: Manage CLI plugins\n" +
 				":4\n",
 		},
@@ -2108,7 +2281,7 @@ func TestCommandRemapping(t *testing.T) {
 			// The mapped command should not be hidden
 			expected: []string{"show-context"},
 			// The plugin command should be hidden
-			unexpected: []string{"dummy2"},
+			unexpected: []string{"    dummy2 "},
 		},
 		{
 			test:     "when nothing under platform-engineering command group",
@@ -2345,3 +2518,28 @@ func createFakeContextWithAuthData(ctxName, issuer, refreshToken, tokenType stri
 		},
 	}
 }
+
+func TestShouldSkipContextUpdates(t *testing.T) {
+	tests := []struct {
+		args     []string
+		expected bool
+	}{
+		{args: []string{"__complete"}, expected: true},
+		{args: []string{"completion"}, expected: true},
+		{args: []string{"version"}, expected: true},
+		{args: []string{"context"}, expected: false},
+		{args: []string{"plugin", "list"}, expected: false},
+	}
+	for _, test := range tests {
+		t.Run(strings.Join(test.args, " "), func(t *testing.T) {
+			rootCmd := &cobra.Command{Use: "tanzu"}
+			cmd := rootCmd
+			for _, arg := range test.args {
+				subCmd := &cobra.Command{Use: arg}
+				cmd.AddCommand(subCmd)
+				cmd = subCmd
+			}
+			assert.Equal(t, test.expected, shouldSkipContextUpdates(cmd))
+		})
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package command
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/plugin"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/buildinfo"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/recommendedversion"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/selfupdate"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/utils"
+)
+
+type updateFlags struct {
+	version string
+	yes     bool
+}
+
+func newUpdateCmd() *cobra.Command {
+	uf := &updateFlags{}
+
+	var updateCmd = &cobra.Command{
+		Use:   "update",
+		Short: "Update the Tanzu CLI to the recommended version",
+		Long: "Download the recommended version of the Tanzu CLI for the current platform, " +
+			"verify its signature and digest and replace the current executable with it",
+		Example: `
+    # Update the CLI to the recommended version
+    tanzu update
+
+    # Update the CLI to a specific version without asking for confirmation
+    tanzu update --version v1.5.1 --yes`,
+		Annotations: map[string]string{
+			"group": string(plugin.SystemCmdGroup),
+		},
+		ValidArgsFunction: noMoreCompletions,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runUpdate(cmd, uf)
+		},
+	}
+
+	updateCmd.Flags().StringVarP(&uf.version, "version", "v", "", "version of the CLI to update to instead of the recommended version")
+	utils.PanicOnErr(updateCmd.RegisterFlagCompletionFunc("version", cobra.NoFileCompletions))
+	updateCmd.Flags().BoolVarP(&uf.yes, "yes", "y", false, "update the CLI without asking for confirmation")
+
+	updateCmd.SetUsageFunc(cli.SubCmdUsageFunc)
+	return updateCmd
+}
+
+func runUpdate(cmd *cobra.Command, uf *updateFlags) error {
+	targetVersion := uf.version
+	if targetVersion == "" {
+		var err error
+		targetVersion, err = recommendedversion.GetRecommendedCLIVersion()
+		if err != nil {
+			return errors.Wrap(err, "unable to determine the recommended version of the Tanzu CLI")
+		}
+		if targetVersion == "" {
+			log.Infof("The Tanzu CLI is already at the recommended version %s", buildinfo.Version)
+			return nil
+		}
+	}
+
+	executable, err := os.Executable()
+	if err != nil {
+		return errors.Wrap(err, "unable to find the Tanzu CLI executable")
+	}
+
+	if err := confirmDestructiveAction(fmt.Sprintf("The Tanzu CLI will be updated from version %s to version %s. Are you sure you want to continue?",
+		buildinfo.Version, targetVersion), uf.yes); err != nil {
+		return err
+	}
+
+	return selfupdate.UpdateCLI(&selfupdate.UpdateOptions{
+		Version:        targetVersion,
+		ExecutablePath: executable,
+		Out:            cmd.ErrOrStderr(),
+	})
+}
@@ -0,0 +1,84 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package command
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/internal/tty"
+)
+
+func TestUpdateCancelled(t *testing.T) {
+	origIsInteractive := tty.CanPrompt
+	origAskForConfirmation := askForConfirmation
+	defer func() {
+		tty.CanPrompt = origIsInteractive
+		askForConfirmation = origAskForConfirmation
+	}()
+	tty.CanPrompt = func() bool { return true }
+	askForConfirmation = func(message string) error {
+		assert.Contains(t, message, "to version v9.9.9")
+		return errors.New("aborted")
+	}
+
+	// Declining the confirmation must be reported to the user
+	err := runUpdate(&cobra.Command{}, &updateFlags{version: "v9.9.9"})
+	assert.EqualError(t, err, "aborted")
+
+	// The user cannot be prompted when not running interactively
+	tty.CanPrompt = func() bool { return false }
+	err = runUpdate(&cobra.Command{}, &updateFlags{version: "v9.9.9"})
+	assert.ErrorContains(t, err, "please use the '--yes' flag to confirm")
+}
+
+func TestCompletionUpdate(t *testing.T) {
+	// This is global logic and needs not be tested for each
+	// command.  Let's deactivate it.
+	os.Setenv("TANZU_ACTIVE_HELP", "no_short_help")
+
+	tests := []struct {
+		test     string
+		args     []string
+		expected string
+	}{
+		{
+			test: "no completion for the update command",
+			args: []string{"__complete", "update", ""},
+			// ":4" is the value of the ShellCompDirectiveNoFileComp
+			expected: "_activeHelp_ " + compNoMoreArgsMsg + "\n:4\n",
+		},
+		{
+			test: "no completion for the --version flag value",
+			args: []string{"__complete", "update", "--version", ""},
+			// ":4" is the value of the ShellCompDirectiveNoFileComp
+			expected: ":4\n",
+		},
+	}
+
+	for _, spec := range tests {
+		t.Run(spec.test, func(t *testing.T) {
+			assert := assert.New(t)
+
+			rootCmd, err := NewRootCmdForTest()
+			assert.Nil(err)
+
+			var out bytes.Buffer
+			rootCmd.SetOut(&out)
+			rootCmd.SetArgs(spec.args)
+
+			err = rootCmd.Execute()
+			assert.Nil(err)
+
+			assert.Equal(spec.expected, out.String())
+		})
+	}
+
+	os.Unsetenv("TANZU_ACTIVE_HELP")
+}
@@ -12,6 +12,7 @@ import (
 
 	"github.com/vmware-tanzu/tanzu-cli/pkg/buildinfo"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/fips"
 )
 
 func newVersionCmd() *cobra.Command {
@@ -24,8 +25,8 @@ func newVersionCmd() *cobra.Command {
 		ValidArgsFunction: noMoreCompletions,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			fmt.Printf(
-				"version: %s\nbuildDate: %s\nsha: %s\narch: %s\n",
-				buildinfo.Version, buildinfo.Date, buildinfo.SHA, cli.GOARCH)
+				"version: %s\nbuildDate: %s\nsha: %s\narch: %s\nfips: %t\n",
+				buildinfo.Version, buildinfo.Date, buildinfo.SHA, cli.GOARCH, fips.Enabled())
 			return nil
 		},
 	}
@@ -5,6 +5,7 @@ package command
 
 import (
 	"bytes"
+	"fmt"
 	"io"
 	"os"
 	"testing"
@@ -13,6 +14,7 @@ import (
 
 	"github.com/vmware-tanzu/tanzu-cli/pkg/buildinfo"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/fips"
 )
 
 func readOutput(t *testing.T, r io.Reader, c chan<- []byte) {
@@ -60,7 +62,7 @@ func TestVersion(t *testing.T) {
 	w.Close()
 
 	got := <-c
-	expected := "version: 1.2.3\nbuildDate: today\nsha: cafecafe\narch: amd64\n"
+	expected := fmt.Sprintf("version: 1.2.3\nbuildDate: today\nsha: cafecafe\narch: amd64\nfips: %t\n", fips.Enabled())
 	assert.Equal(expected, string(got))
 }
 
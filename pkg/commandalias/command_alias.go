@@ -0,0 +1,157 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package commandalias manages the aliases defined by the user for CLI commands,
+// e.g. "pc" for "plugin clean", which the CLI expands before running the command.
+package commandalias
+
+import (
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/common"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/utils"
+)
+
+type commandAliasesContent struct {
+	// Aliases are the arguments of the command of every alias, by alias name.
+	// The arguments are stored separately so that the arguments containing
+	// spaces are kept as they are.
+	Aliases map[string][]string `yaml:"aliases,omitempty"`
+}
+
+// GetAliases returns the arguments of the command of every alias, by alias name
+func GetAliases() (map[string][]string, error) {
+	content, err := readContent()
+	if err != nil {
+		return nil, err
+	}
+	if content.Aliases == nil {
+		return map[string][]string{}, nil
+	}
+	return content.Aliases, nil
+}
+
+// GetAliasNames returns the sorted names of the aliases
+func GetAliasNames() ([]string, error) {
+	aliases, err := GetAliases()
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(aliases))
+	for name := range aliases {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// GetAlias returns the arguments of the command of the alias, or false if the alias does not exist
+func GetAlias(name string) ([]string, bool, error) {
+	aliases, err := GetAliases()
+	if err != nil {
+		return nil, false, err
+	}
+	command, exists := aliases[name]
+	return command, exists, nil
+}
+
+// SetAlias creates or replaces the alias for the command, which is the list
+// of arguments of the CLI, e.g. ["plugin", "clean"]
+func SetAlias(name string, command []string) error {
+	if err := ValidateAliasName(name); err != nil {
+		return err
+	}
+	if len(command) == 0 || strings.TrimSpace(command[0]) == "" {
+		return errors.New("the command of the alias cannot be empty")
+	}
+	return updateContent(func(content *commandAliasesContent) error {
+		if content.Aliases == nil {
+			content.Aliases = make(map[string][]string)
+		}
+		content.Aliases[name] = command
+		return nil
+	})
+}
+
+// DeleteAlias removes the alias
+func DeleteAlias(name string) error {
+	return updateContent(func(content *commandAliasesContent) error {
+		if _, exists := content.Aliases[name]; !exists {
+			return errors.Errorf("alias %q does not exist", name)
+		}
+		delete(content.Aliases, name)
+		return nil
+	})
+}
+
+// ValidateAliasName verifies that the name can be used as an alias
+func ValidateAliasName(name string) error {
+	if name == "" {
+		return errors.New("the alias name cannot be empty")
+	}
+	if strings.HasPrefix(name, "-") || strings.HasPrefix(name, "__") {
+		return errors.Errorf("invalid alias name %q, it cannot start with '-' or '__'", name)
+	}
+	if strings.ContainsFunc(name, func(r rune) bool { return r == ' ' || r == '\t' || r == '\n' }) {
+		return errors.Errorf("invalid alias name %q, it cannot contain spaces", name)
+	}
+	if strings.Contains(name, "=") {
+		return errors.Errorf("invalid alias name %q, it cannot contain '='", name)
+	}
+	return nil
+}
+
+// updateContent applies the update to the aliases while holding the lock of the aliases file
+func updateContent(update func(*commandAliasesContent) error) error {
+	path := getCommandAliasesPath()
+	lock, err := utils.LockFile(path)
+	if err != nil {
+		return err
+	}
+	defer lock.Unlock()
+
+	content, err := readContent()
+	if err != nil {
+		return err
+	}
+	if err := update(content); err != nil {
+		return err
+	}
+
+	b, err := yaml.Marshal(content)
+	if err != nil {
+		return errors.Wrap(err, "could not encode the command aliases")
+	}
+	return utils.WriteFileAtomic(path, b, 0600)
+}
+
+func readContent() (*commandAliasesContent, error) {
+	content := &commandAliasesContent{}
+	b, err := utils.ReadFile(getCommandAliasesPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return content, nil
+		}
+		return nil, err
+	}
+	if err := yaml.Unmarshal(b, content); err != nil {
+		return nil, errors.Wrap(err, "could not decode the command aliases file")
+	}
+	return content, nil
+}
+
+func getCommandAliasesPath() string {
+	// NOTE: TEST_CUSTOM_COMMAND_ALIASES_FILE is only for test purpose
+	customFile := os.Getenv("TEST_CUSTOM_COMMAND_ALIASES_FILE")
+	if customFile != "" {
+		return customFile
+	}
+
+	return common.CLIConfigFilePath(constants.CommandAliasesFileName)
+}
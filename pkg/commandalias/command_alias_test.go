@@ -0,0 +1,70 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package commandalias
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/utils"
+)
+
+func TestCommandAliases(t *testing.T) {
+	assert := assert.New(t)
+
+	aliasesFile := filepath.Join(t.TempDir(), constants.CommandAliasesFileName)
+	t.Setenv("TEST_CUSTOM_COMMAND_ALIASES_FILE", aliasesFile)
+
+	// No aliases when the file does not exist
+	aliases, err := GetAliases()
+	assert.Nil(err)
+	assert.Empty(aliases)
+
+	assert.Nil(SetAlias("pc", []string{"plugin", "clean"}))
+	assert.Nil(SetAlias("kcl", []string{"kubernetes", "cluster", "list", "--label", "team=a b"}))
+
+	// The aliases are stored in their own file
+	b, err := utils.ReadFile(aliasesFile)
+	assert.Nil(err)
+	assert.Contains(string(b), "kcl:")
+
+	command, exists, err := GetAlias("kcl")
+	assert.Nil(err)
+	assert.True(exists)
+	assert.Equal([]string{"kubernetes", "cluster", "list", "--label", "team=a b"}, command)
+
+	_, exists, err = GetAlias("missing")
+	assert.Nil(err)
+	assert.False(exists)
+
+	names, err := GetAliasNames()
+	assert.Nil(err)
+	assert.Equal([]string{"kcl", "pc"}, names)
+
+	// Replacing an alias
+	assert.Nil(SetAlias("pc", []string{"plugin", "clean", "--yes"}))
+	aliases, err = GetAliases()
+	assert.Nil(err)
+	assert.Equal(map[string][]string{
+		"pc":  {"plugin", "clean", "--yes"},
+		"kcl": {"kubernetes", "cluster", "list", "--label", "team=a b"},
+	}, aliases)
+
+	assert.Nil(DeleteAlias("pc"))
+	assert.ErrorContains(DeleteAlias("pc"), "does not exist")
+	names, err = GetAliasNames()
+	assert.Nil(err)
+	assert.Equal([]string{"kcl"}, names)
+
+	// Invalid aliases
+	assert.ErrorContains(SetAlias("", []string{"plugin", "list"}), "cannot be empty")
+	assert.ErrorContains(SetAlias("p l", []string{"plugin", "list"}), "cannot contain spaces")
+	assert.ErrorContains(SetAlias("--pl", []string{"plugin", "list"}), "cannot start with")
+	assert.ErrorContains(SetAlias("__complete", []string{"plugin", "list"}), "cannot start with")
+	assert.ErrorContains(SetAlias("p=l", []string{"plugin", "list"}), "cannot contain '='")
+	assert.ErrorContains(SetAlias("pl", nil), "command of the alias cannot be empty")
+}
@@ -40,5 +40,8 @@ const CommandTypePlugin = "plugin"
 
 // Command Annotations
 const (
-	AnnotationForCmdSrcPath = "cmdSrcPath"
+	AnnotationForCmdSrcPath    = "cmdSrcPath"
+	AnnotationForPluginName    = "pluginName"
+	AnnotationForPluginVersion = "pluginVersion"
+	AnnotationForPluginTarget  = "pluginTarget"
 )
@@ -4,25 +4,19 @@
 // Package common defines generic constants and structs
 package common
 
-import (
-	"path/filepath"
-
-	"github.com/adrg/xdg"
-)
-
 var (
 	// DefaultPluginRoot is the default plugin root.
-	DefaultPluginRoot = filepath.Join(xdg.DataHome, "tanzu-cli")
+	DefaultPluginRoot = PluginRootLocation()
 
 	// DefaultCacheDir is the default cache directory
-	DefaultCacheDir = filepath.Join(xdg.Home, ".cache", "tanzu")
+	DefaultCacheDir = CacheDirLocation()
 
 	// DefaultLocalPluginDistroDir is the default Local plugin distribution root directory
 	// This directory will be used for local discovery and local distribute of plugins
-	DefaultLocalPluginDistroDir = filepath.Join(xdg.Home, ".config", "tanzu-plugins")
+	DefaultLocalPluginDistroDir = localPluginDistroDirLocation()
 
 	// DefaultCLITelemetryDir is the default telemetry directory
-	DefaultCLITelemetryDir = filepath.Join(xdg.Home, ".config", "tanzu-cli-telemetry")
+	DefaultCLITelemetryDir = cliTelemetryDirLocation()
 )
 
 const (
@@ -30,4 +24,9 @@ const (
 	// the inventory of the discovery will be downloaded and stored.
 	// It should be used as a sub-directory of the cache directory (DefaultCacheDir).
 	PluginInventoryDirName = "plugin_inventory"
+
+	// DownloadsDirName is the name of the directory where the partial downloads of
+	// images are persisted so that they can be resumed.
+	// It should be used as a sub-directory of the cache directory (DefaultCacheDir).
+	DownloadsDirName = "downloads"
 )
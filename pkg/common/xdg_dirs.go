@@ -0,0 +1,256 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/adrg/xdg"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
+)
+
+// The CLI stores its files under the XDG base directories:
+//   - plugins are installed under $XDG_DATA_HOME (e.g., ~/.local/share)
+//   - the cache is stored under $XDG_CACHE_HOME (e.g., ~/.cache)
+//   - the local plugin distributions and telemetry data are stored under $XDG_CONFIG_HOME (e.g., ~/.config)
+// When the XDG_CACHE_HOME and XDG_CONFIG_HOME variables are not set, the ~/.cache and ~/.config
+// directories are used on every OS, as was always done by the CLI.
+// Each base directory can be overridden for the CLI only using the TANZU_CLI_<TYPE>_HOME variables.
+// The plugin root and cache directories can also be set directly using the TANZU_CLI_PLUGIN_ROOT
+// and TANZU_CLI_CACHE_DIR variables.
+// Note that the configuration file of the CLI is managed by the plugin runtime and stays in ~/.config/tanzu,
+// while the other files of the CLI in that directory follow the configuration base directory (see CLIConfigFilePath).
+
+// LegacyDirectory associates a directory of the CLI with the location used for it
+// by older versions of the CLI
+type LegacyDirectory struct {
+	// Dir points to the variable holding the location of the directory
+	Dir *string
+	// LegacyDir is the location used by older versions of the CLI
+	LegacyDir string
+}
+
+// LegacyDirectories returns the directories of the CLI whose location has changed
+// compared to older versions of the CLI.  A directory is not included if its variable
+// no longer holds its default location, for example because it was modified by a test.
+func LegacyDirectories() []LegacyDirectory {
+	candidates := []struct {
+		dir        *string
+		defaultDir string
+		legacyDir  string
+	}{
+		{&DefaultCacheDir, CacheDirLocation(), filepath.Join(xdg.Home, ".cache", "tanzu")},
+		{&DefaultLocalPluginDistroDir, localPluginDistroDirLocation(), filepath.Join(xdg.Home, ".config", "tanzu-plugins")},
+		{&DefaultCLITelemetryDir, cliTelemetryDirLocation(), filepath.Join(xdg.Home, ".config", "tanzu-cli-telemetry")},
+		// The plugin root must be last as moving it requires the catalog which is in the cache directory
+		{&DefaultPluginRoot, PluginRootLocation(), LegacyPluginRootLocation()},
+	}
+
+	var legacyDirs []LegacyDirectory
+	for _, c := range candidates {
+		if *c.dir == c.defaultDir && filepath.Clean(c.defaultDir) != filepath.Clean(c.legacyDir) {
+			legacyDirs = append(legacyDirs, LegacyDirectory{Dir: c.dir, LegacyDir: c.legacyDir})
+		}
+	}
+	return legacyDirs
+}
+
+// PluginRootLocation returns the directory where plugins should be installed
+// based on the current environment variables
+func PluginRootLocation() string {
+	if dir := absDirFromEnv(constants.ConfigVariablePluginRoot); dir != "" {
+		return dir
+	}
+	return filepath.Join(cliDataHome(), "tanzu-cli")
+}
+
+// LegacyPluginRootLocation returns the directory where older versions of the CLI installed plugins
+func LegacyPluginRootLocation() string {
+	return filepath.Join(xdg.DataHome, "tanzu-cli")
+}
+
+// CacheDirLocation returns the cache directory of the CLI based on the current environment variables
+func CacheDirLocation() string {
+	if dir := absDirFromEnv(constants.ConfigVariableCacheDir); dir != "" {
+		return dir
+	}
+	return filepath.Join(cliCacheHome(), "tanzu")
+}
+
+// localPluginDistroDirLocation returns the local plugin distribution root directory
+// based on the current environment variables
+func localPluginDistroDirLocation() string {
+	return filepath.Join(cliConfigHome(), "tanzu-plugins")
+}
+
+// cliTelemetryDirLocation returns the telemetry directory based on the current environment variables
+func cliTelemetryDirLocation() string {
+	return filepath.Join(cliConfigHome(), "tanzu-cli-telemetry")
+}
+
+// cliConfigFileNames are the files of the CLI stored in the "tanzu" sub-directory of the
+// configuration base directory, which older versions of the CLI stored in ~/.config/tanzu
+var cliConfigFileNames = []string{
+	constants.DataStoreFileName,
+	constants.LocalPluginGroupsFileName,
+	constants.PluginDefaultsFileName,
+	constants.CommandAliasesFileName,
+}
+
+// LegacyFile associates a file of the CLI with the location used for it
+// by older versions of the CLI
+type LegacyFile struct {
+	// Path is the current location of the file
+	Path string
+	// LegacyPath is the location used by older versions of the CLI
+	LegacyPath string
+}
+
+// LegacyConfigFiles returns the files of the CLI stored in ~/.config/tanzu by an older version
+// of the CLI which have not yet been moved to the relocated configuration base directory
+func LegacyConfigFiles() []LegacyFile {
+	var legacyFiles []LegacyFile
+	for _, fileName := range cliConfigFileNames {
+		path, legacyPath := cliConfigFileLocations(fileName)
+		if isLegacyFileInUse(path, legacyPath) {
+			legacyFiles = append(legacyFiles, LegacyFile{Path: path, LegacyPath: legacyPath})
+		}
+	}
+	return legacyFiles
+}
+
+// CLIConfigFilePath returns the path of the specified file of the CLI in the "tanzu" sub-directory
+// of the configuration base directory, e.g., ~/.config/tanzu/<fileName>.  When the base directory
+// is relocated using XDG_CONFIG_HOME or TANZU_CLI_CONFIG_HOME, a file stored in ~/.config/tanzu by
+// an older version of the CLI keeps being used from there until it is moved to the new location,
+// which is done by MigrateLegacyDirectories of the config package.
+func CLIConfigFilePath(fileName string) string {
+	path, legacyPath := cliConfigFileLocations(fileName)
+	if isLegacyFileInUse(path, legacyPath) {
+		return legacyPath
+	}
+	return path
+}
+
+// cliConfigFileLocations returns the current and legacy locations of the file of the CLI
+func cliConfigFileLocations(fileName string) (path, legacyPath string) {
+	return filepath.Join(cliConfigHome(), "tanzu", fileName), filepath.Join(xdg.Home, ".config", "tanzu", fileName)
+}
+
+// isLegacyFileInUse returns true if the file only exists at its legacy location
+func isLegacyFileInUse(path, legacyPath string) bool {
+	return filepath.Clean(path) != filepath.Clean(legacyPath) && !fileExists(path) && fileExists(legacyPath)
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// SystemPluginDirs returns the read-only directories where an administrator can provision plugin
+// binaries to be shared by all the users of a machine.  The directories are specified by the
+// TANZU_CLI_SYSTEM_PLUGIN_DIRS variable and default to the "tanzu-cli/plugins" sub-directory of
+// each $XDG_DATA_DIRS directory (e.g., /usr/local/share/tanzu-cli/plugins).
+// Setting TANZU_CLI_SYSTEM_PLUGIN_DIRS to an empty value disables the system plugin directories.
+func SystemPluginDirs() []string {
+	if dirs, isSet := os.LookupEnv(constants.ConfigVariableSystemPluginDirs); isSet {
+		var result []string
+		for _, dir := range filepath.SplitList(dirs) {
+			if filepath.IsAbs(dir) {
+				result = append(result, filepath.Clean(dir))
+			}
+		}
+		return result
+	}
+
+	result := make([]string, 0, len(xdg.DataDirs))
+	for _, dir := range xdg.DataDirs {
+		result = append(result, filepath.Join(dir, "tanzu-cli", "plugins"))
+	}
+	return result
+}
+
+// cliDirectory associates a directory variable of the CLI with the function computing
+// its location and with the variable that can explicitly set it, if any
+type cliDirectory struct {
+	dir         *string
+	location    func() string
+	overrideVar string
+}
+
+func cliDirectories() []cliDirectory {
+	return []cliDirectory{
+		{&DefaultPluginRoot, PluginRootLocation, constants.ConfigVariablePluginRoot},
+		{&DefaultCacheDir, CacheDirLocation, constants.ConfigVariableCacheDir},
+		{&DefaultLocalPluginDistroDir, localPluginDistroDirLocation, ""},
+		{&DefaultCLITelemetryDir, cliTelemetryDirLocation, ""},
+	}
+}
+
+// computedDirectories holds the location each directory variable was last set to by this
+// package, which allows detecting the variables modified by other means, such as by tests
+var computedDirectories = map[*string]string{}
+
+func init() {
+	for _, d := range cliDirectories() {
+		computedDirectories[d.dir] = *d.dir
+	}
+}
+
+// ApplyDirectoryOverrides recomputes the directories of the CLI based on the current environment
+// variables.  This is needed for variables which are set after the initialization of this package,
+// such as the variables of the "env" section of the configuration file, e.g., TANZU_CLI_CONFIG_HOME.
+// A directory whose variable was modified since it was computed, for example by a test, is left
+// unchanged unless it is explicitly set by the TANZU_CLI_PLUGIN_ROOT or TANZU_CLI_CACHE_DIR variable.
+func ApplyDirectoryOverrides() {
+	for _, d := range cliDirectories() {
+		if *d.dir != computedDirectories[d.dir] && absDirFromEnv(d.overrideVar) == "" {
+			continue
+		}
+		*d.dir = d.location()
+		computedDirectories[d.dir] = *d.dir
+	}
+}
+
+// cliConfigHome returns the base directory for the configuration files of the CLI
+func cliConfigHome() string {
+	return baseDir(constants.ConfigVariableConfigHome, "XDG_CONFIG_HOME", filepath.Join(xdg.Home, ".config"))
+}
+
+// cliCacheHome returns the base directory for the cache of the CLI
+func cliCacheHome() string {
+	return baseDir(constants.ConfigVariableCacheHome, "XDG_CACHE_HOME", filepath.Join(xdg.Home, ".cache"))
+}
+
+// cliDataHome returns the base directory for the data files of the CLI.
+// The xdg library already honors XDG_DATA_HOME.
+func cliDataHome() string {
+	return baseDir(constants.ConfigVariableDataHome, "", xdg.DataHome)
+}
+
+// baseDir returns the directory specified by the CLI override variable, or by the XDG variable,
+// or the default directory, in that order.  As required by the XDG specification, relative paths
+// are ignored.
+func baseDir(overrideVar, xdgVar, defaultDir string) string {
+	for _, envVar := range []string{overrideVar, xdgVar} {
+		if dir := absDirFromEnv(envVar); dir != "" {
+			return dir
+		}
+	}
+	return defaultDir
+}
+
+// absDirFromEnv returns the directory specified by the environment variable
+// or an empty string if the variable is not set or is not an absolute path
+func absDirFromEnv(envVar string) string {
+	if envVar == "" {
+		return ""
+	}
+	if dir := os.Getenv(envVar); dir != "" && filepath.IsAbs(dir) {
+		return filepath.Clean(dir)
+	}
+	return ""
+}
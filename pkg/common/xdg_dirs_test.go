@@ -0,0 +1,84 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/adrg/xdg"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
+)
+
+func TestApplyDirectoryOverrides(t *testing.T) {
+	originalDirs := map[*string]string{}
+	for _, d := range cliDirectories() {
+		originalDirs[d.dir] = *d.dir
+	}
+	defer func() {
+		for dir, value := range originalDirs {
+			*dir = value
+			computedDirectories[dir] = value
+		}
+	}()
+
+	tmpDir := t.TempDir()
+	t.Setenv(constants.ConfigVariableConfigHome, filepath.Join(tmpDir, "config"))
+	t.Setenv(constants.ConfigVariableCacheHome, filepath.Join(tmpDir, "cache"))
+	t.Setenv(constants.ConfigVariableDataHome, filepath.Join(tmpDir, "data"))
+	t.Setenv(constants.ConfigVariablePluginRoot, "")
+	t.Setenv(constants.ConfigVariableCacheDir, "")
+
+	// A directory modified by other means is left unchanged
+	DefaultCLITelemetryDir = filepath.Join(tmpDir, "custom")
+
+	ApplyDirectoryOverrides()
+
+	assert.Equal(t, filepath.Join(tmpDir, "data", "tanzu-cli"), DefaultPluginRoot)
+	assert.Equal(t, filepath.Join(tmpDir, "cache", "tanzu"), DefaultCacheDir)
+	assert.Equal(t, filepath.Join(tmpDir, "config", "tanzu-plugins"), DefaultLocalPluginDistroDir)
+	assert.Equal(t, filepath.Join(tmpDir, "custom"), DefaultCLITelemetryDir)
+
+	// An explicitly set directory always applies
+	DefaultPluginRoot = filepath.Join(tmpDir, "modified")
+	t.Setenv(constants.ConfigVariablePluginRoot, filepath.Join(tmpDir, "plugins"))
+
+	ApplyDirectoryOverrides()
+
+	assert.Equal(t, filepath.Join(tmpDir, "plugins"), DefaultPluginRoot)
+}
+
+func TestCLIConfigFilePath(t *testing.T) {
+	originalHome := xdg.Home
+	defer func() { xdg.Home = originalHome }()
+	xdg.Home = t.TempDir()
+	configHome := filepath.Join(t.TempDir(), "config")
+
+	// The file stays in ~/.config/tanzu by default
+	t.Setenv("XDG_CONFIG_HOME", "")
+	t.Setenv(constants.ConfigVariableConfigHome, "")
+	assert.Equal(t, filepath.Join(xdg.Home, ".config", "tanzu", constants.DataStoreFileName), CLIConfigFilePath(constants.DataStoreFileName))
+	assert.Empty(t, LegacyConfigFiles())
+
+	// A file of an older CLI keeps being used from its legacy location, without being moved
+	legacyFile := filepath.Join(xdg.Home, ".config", "tanzu", constants.DataStoreFileName)
+	assert.Nil(t, os.MkdirAll(filepath.Dir(legacyFile), 0755))
+	assert.Nil(t, os.WriteFile(legacyFile, []byte("content"), 0600))
+	t.Setenv(constants.ConfigVariableConfigHome, configHome)
+
+	newFile := filepath.Join(configHome, "tanzu", constants.DataStoreFileName)
+	assert.Equal(t, legacyFile, CLIConfigFilePath(constants.DataStoreFileName))
+	assert.FileExists(t, legacyFile)
+	assert.NoFileExists(t, newFile)
+	assert.Equal(t, []LegacyFile{{Path: newFile, LegacyPath: legacyFile}}, LegacyConfigFiles())
+
+	// Once the file exists at the new location, the new location is used
+	assert.Nil(t, os.MkdirAll(filepath.Dir(newFile), 0755))
+	assert.Nil(t, os.Rename(legacyFile, newFile))
+	assert.Equal(t, newFile, CLIConfigFilePath(constants.DataStoreFileName))
+	assert.Empty(t, LegacyConfigFiles())
+}
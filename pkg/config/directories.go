@@ -0,0 +1,156 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/adrg/xdg"
+	"github.com/pkg/errors"
+
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/catalog"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/common"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/utils"
+)
+
+// MigrateLegacyDirectories moves the directories and the files used by older versions of
+// the CLI to their current location, which can differ when the XDG base directory variables
+// or their TANZU_CLI_<TYPE>_HOME overrides are set.
+// A directory or a file is only moved if it does not already exist at its current location.
+// If a directory or a file cannot be moved, its legacy location continues to be used.
+// Note that the installed plugins are not moved when the plugin root directory is changed
+// through the TANZU_CLI_PLUGIN_ROOT variable, as this is only done explicitly by
+// 'tanzu config set cli.plugin-root'; the installed plugins keep being used from their
+// previous location as the catalog references them using their full path.
+func MigrateLegacyDirectories() {
+	var legacyDirs []common.LegacyDirectory
+	for _, dir := range common.LegacyDirectories() {
+		if canMoveDirectory(dir.LegacyDir, *dir.Dir) {
+			legacyDirs = append(legacyDirs, dir)
+		}
+	}
+	// Every command calls this function, so don't lock anything once the directories have been moved
+	if len(legacyDirs) == 0 && len(common.LegacyConfigFiles()) == 0 {
+		return
+	}
+
+	// Prevent concurrent CLI processes from moving the same directories
+	lock, err := utils.LockFile(migrationLockPath())
+	if err != nil {
+		// The files which are not moved keep being used from their legacy location
+		log.Warningf("Unable to move the directories of the previous version of the CLI, their previous location will be used: %v", err)
+		for _, dir := range legacyDirs {
+			*dir.Dir = dir.LegacyDir
+		}
+		return
+	}
+	defer lock.Unlock()
+
+	for _, dir := range legacyDirs {
+		current, legacy := *dir.Dir, dir.LegacyDir
+		// Another CLI process may have moved the directory while this one was waiting for the lock
+		if !canMoveDirectory(legacy, current) {
+			continue
+		}
+
+		log.V(6).Infof("Moving %q to %q", legacy, current)
+		if err := moveDirectory(legacy, current); err != nil {
+			log.Warningf("Unable to move %q to %q, the previous location will be used: %v", legacy, current, err)
+			*dir.Dir = legacy
+			continue
+		}
+
+		if dir.Dir == &common.DefaultPluginRoot {
+			// The catalog references the installed plugins using their full path
+			if err := catalog.RelocatePluginRoot(legacy, current); err != nil {
+				log.Warningf("Unable to update the plugin catalog after moving %q to %q: %v", legacy, current, err)
+			}
+		}
+	}
+
+	// Another CLI process may have moved the files while this one was waiting for the lock
+	for _, file := range common.LegacyConfigFiles() {
+		log.V(6).Infof("Moving %q to %q", file.LegacyPath, file.Path)
+		if err := moveLegacyFile(file.LegacyPath, file.Path); err != nil {
+			log.V(6).Warningf("Unable to move %q to %q, the previous location will be used: %v", file.LegacyPath, file.Path, err)
+		}
+	}
+}
+
+// moveLegacyFile moves the file used by an older version of the CLI to its current location.
+// The file is replaced atomically so that it is never seen partially written at either location.
+func moveLegacyFile(legacyPath, path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	if err := os.Rename(legacyPath, path); err == nil {
+		return nil
+	}
+
+	// The new location may be on another file system
+	b, err := os.ReadFile(legacyPath)
+	if err != nil {
+		return err
+	}
+	info, err := os.Stat(legacyPath)
+	if err != nil {
+		return err
+	}
+	if err := utils.WriteFileAtomic(path, b, info.Mode().Perm()); err != nil {
+		return err
+	}
+	return os.Remove(legacyPath)
+}
+
+// migrationLockPath returns the file whose lock serializes the migration of the legacy directories.
+// It is in the configuration directory of the plugin runtime, which is never relocated.
+func migrationLockPath() string {
+	return filepath.Join(xdg.Home, ".config", "tanzu", ".directories-migration")
+}
+
+// MovePluginRoot moves the installed plugins from the old plugin root directory to the new one
+// and updates the catalog accordingly.  Nothing is done if the new directory already contains files.
+func MovePluginRoot(oldRoot, newRoot string) error {
+	if !canMoveDirectory(oldRoot, newRoot) {
+		return nil
+	}
+
+	log.V(6).Infof("Moving the installed plugins from %q to %q", oldRoot, newRoot)
+	if err := moveDirectory(oldRoot, newRoot); err != nil {
+		return err
+	}
+	return errors.Wrap(catalog.RelocatePluginRoot(oldRoot, newRoot), "unable to update the plugin catalog")
+}
+
+// MoveCacheDir moves the content of the old cache directory to the new one.
+// Nothing is done if the new directory already contains files.
+func MoveCacheDir(oldDir, newDir string) error {
+	if !canMoveDirectory(oldDir, newDir) {
+		return nil
+	}
+
+	log.V(6).Infof("Moving the cache from %q to %q", oldDir, newDir)
+	return moveDirectory(oldDir, newDir)
+}
+
+// canMoveDirectory returns true if the source directory exists and is different
+// from the destination directory which must not exist or be empty
+func canMoveDirectory(src, dst string) bool {
+	if filepath.Clean(src) == filepath.Clean(dst) || !utils.PathExists(src) {
+		return false
+	}
+	entries, err := os.ReadDir(dst)
+	return os.IsNotExist(err) || (err == nil && len(entries) == 0)
+}
+
+// moveDirectory moves the source directory to the destination directory, copying
+// it if the destination directory is on a different file system
+func moveDirectory(src, dst string) error {
+	// Remove the destination directory in case it exists but is empty
+	_ = os.Remove(dst)
+	return utils.MoveDir(src, dst)
+}
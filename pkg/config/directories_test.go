@@ -0,0 +1,189 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/adrg/xdg"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/catalog"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/common"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
+)
+
+var _ = Describe("migration of legacy directories", func() {
+	var (
+		tmpDir           string
+		originalHome     string
+		originalCacheDir string
+		legacyCacheDir   string
+		newCacheDir      string
+	)
+
+	BeforeEach(func() {
+		var err error
+		tmpDir, err = os.MkdirTemp("", "xdg_migration_test")
+		Expect(err).To(BeNil())
+
+		originalHome = xdg.Home
+		originalCacheDir = common.DefaultCacheDir
+		xdg.Home = tmpDir
+
+		legacyCacheDir = filepath.Join(tmpDir, ".cache", "tanzu")
+		newCacheDir = filepath.Join(tmpDir, "xdg-cache", "tanzu")
+		os.Setenv(constants.ConfigVariableCacheHome, filepath.Join(tmpDir, "xdg-cache"))
+		common.DefaultCacheDir = newCacheDir
+
+		Expect(os.MkdirAll(legacyCacheDir, 0755)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(legacyCacheDir, "catalog.yaml"), []byte("{}"), 0600)).To(Succeed())
+	})
+	AfterEach(func() {
+		xdg.Home = originalHome
+		common.DefaultCacheDir = originalCacheDir
+		os.Unsetenv(constants.ConfigVariableCacheHome)
+		os.RemoveAll(tmpDir)
+	})
+
+	It("moves the legacy directory to its new location", func() {
+		MigrateLegacyDirectories()
+
+		Expect(common.DefaultCacheDir).To(Equal(newCacheDir))
+		Expect(filepath.Join(newCacheDir, "catalog.yaml")).To(BeAnExistingFile())
+		Expect(legacyCacheDir).ToNot(BeADirectory())
+	})
+
+	It("does not move the legacy directory if the new location already exists", func() {
+		Expect(os.MkdirAll(newCacheDir, 0755)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(newCacheDir, "other.yaml"), []byte("{}"), 0600)).To(Succeed())
+
+		MigrateLegacyDirectories()
+
+		Expect(filepath.Join(legacyCacheDir, "catalog.yaml")).To(BeAnExistingFile())
+		Expect(filepath.Join(newCacheDir, "catalog.yaml")).ToNot(BeAnExistingFile())
+	})
+
+	It("moves the legacy directory once when migrated concurrently", func() {
+		var wg sync.WaitGroup
+		for i := 0; i < 5; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				MigrateLegacyDirectories()
+			}()
+		}
+		wg.Wait()
+
+		Expect(common.DefaultCacheDir).To(Equal(newCacheDir))
+		Expect(filepath.Join(newCacheDir, "catalog.yaml")).To(BeAnExistingFile())
+		Expect(legacyCacheDir).ToNot(BeADirectory())
+	})
+
+	It("moves the legacy files to the relocated configuration directory", func() {
+		os.Setenv(constants.ConfigVariableConfigHome, filepath.Join(tmpDir, "xdg-config"))
+		defer os.Unsetenv(constants.ConfigVariableConfigHome)
+		legacyFile := filepath.Join(tmpDir, ".config", "tanzu", constants.PluginDefaultsFileName)
+		newFile := filepath.Join(tmpDir, "xdg-config", "tanzu", constants.PluginDefaultsFileName)
+		Expect(os.MkdirAll(filepath.Dir(legacyFile), 0755)).To(Succeed())
+		Expect(os.WriteFile(legacyFile, []byte("defaults"), 0600)).To(Succeed())
+
+		// Getting the path of the file does not move it
+		Expect(common.CLIConfigFilePath(constants.PluginDefaultsFileName)).To(Equal(legacyFile))
+		Expect(legacyFile).To(BeAnExistingFile())
+
+		MigrateLegacyDirectories()
+
+		Expect(legacyFile).ToNot(BeAnExistingFile())
+		Expect(newFile).To(BeAnExistingFile())
+		Expect(common.CLIConfigFilePath(constants.PluginDefaultsFileName)).To(Equal(newFile))
+	})
+
+	It("does not move a directory whose location was modified", func() {
+		common.DefaultCacheDir = filepath.Join(tmpDir, "custom")
+
+		MigrateLegacyDirectories()
+
+		Expect(filepath.Join(legacyCacheDir, "catalog.yaml")).To(BeAnExistingFile())
+		Expect(common.DefaultCacheDir).To(Equal(filepath.Join(tmpDir, "custom")))
+	})
+})
+
+var _ = Describe("relocation of the plugin root", func() {
+	var (
+		tmpDir             string
+		originalCacheDir   string
+		originalPluginRoot string
+	)
+
+	BeforeEach(func() {
+		var err error
+		tmpDir, err = os.MkdirTemp("", "plugin_root_test")
+		Expect(err).To(BeNil())
+
+		originalCacheDir = common.DefaultCacheDir
+		originalPluginRoot = common.DefaultPluginRoot
+		common.DefaultCacheDir = filepath.Join(tmpDir, "cache")
+		common.DefaultPluginRoot = filepath.Join(tmpDir, "old-root")
+
+		c, err := catalog.NewContextCatalogUpdater("")
+		Expect(err).To(BeNil())
+		Expect(c.Upsert(&cli.PluginInfo{
+			Name:             "fakeplugin",
+			InstallationPath: filepath.Join(tmpDir, "old-root", "fakeplugin", "v1.0.0"),
+			Version:          "v1.0.0",
+		})).To(Succeed())
+		c.Unlock()
+		Expect(os.MkdirAll(filepath.Join(tmpDir, "old-root", "fakeplugin"), 0755)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(tmpDir, "old-root", "fakeplugin", "v1.0.0"), []byte("binary"), 0600)).To(Succeed())
+	})
+	AfterEach(func() {
+		common.DefaultCacheDir = originalCacheDir
+		common.DefaultPluginRoot = originalPluginRoot
+		os.RemoveAll(tmpDir)
+	})
+
+	It("does not move the plugins at startup when the plugin root has changed", func() {
+		newRoot := filepath.Join(tmpDir, "new-root")
+		common.DefaultPluginRoot = newRoot
+		MigrateLegacyDirectories()
+
+		Expect(filepath.Join(tmpDir, "old-root", "fakeplugin", "v1.0.0")).To(BeAnExistingFile())
+		Expect(newRoot).ToNot(BeADirectory())
+
+		c, err := catalog.NewContextCatalog("")
+		Expect(err).To(BeNil())
+		plugin, found := c.Get("fakeplugin")
+		Expect(found).To(BeTrue())
+		Expect(plugin.InstallationPath).To(Equal(filepath.Join(tmpDir, "old-root", "fakeplugin", "v1.0.0")))
+	})
+
+	It("moves the plugins and updates the catalog when explicitly requested", func() {
+		newRoot := filepath.Join(tmpDir, "new-root")
+		common.DefaultPluginRoot = newRoot
+		Expect(MovePluginRoot(filepath.Join(tmpDir, "old-root"), newRoot)).To(Succeed())
+
+		Expect(filepath.Join(newRoot, "fakeplugin", "v1.0.0")).To(BeAnExistingFile())
+
+		c, err := catalog.NewContextCatalog("")
+		Expect(err).To(BeNil())
+		plugin, found := c.Get("fakeplugin")
+		Expect(found).To(BeTrue())
+		Expect(plugin.InstallationPath).To(Equal(filepath.Join(newRoot, "fakeplugin", "v1.0.0")))
+	})
+
+	It("does not move the plugins to a plugin root which is not empty", func() {
+		newRoot := filepath.Join(tmpDir, "new-root")
+		Expect(os.MkdirAll(newRoot, 0755)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(newRoot, "other"), []byte("other"), 0600)).To(Succeed())
+		common.DefaultPluginRoot = newRoot
+
+		Expect(MovePluginRoot(filepath.Join(tmpDir, "old-root"), newRoot)).To(Succeed())
+		Expect(filepath.Join(tmpDir, "old-root", "fakeplugin", "v1.0.0")).To(BeAnExistingFile())
+	})
+})
@@ -7,9 +7,21 @@ import (
 	configlib "github.com/vmware-tanzu/tanzu-plugin-runtime/config"
 	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
 
+	"github.com/vmware-tanzu/tanzu-cli/pkg/centralconfig"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
 )
 
+// GetDefaultCentralDiscoveryImage returns the image of the default central discovery.
+// The central configuration can override the compiled-in image, e.g. through its local
+// override file, to point the CLI to a mirror of the central repository.
+func GetDefaultCentralDiscoveryImage() string {
+	// An empty value is not a valid image and is ignored
+	if image := centralconfig.GetString(centralconfig.KeyDefaultPluginDiscoveryImage, ""); image != "" {
+		return image
+	}
+	return constants.TanzuCLIDefaultCentralPluginDiscoveryImage
+}
+
 func PopulateDefaultCentralDiscovery(force bool) error {
 	discoverySources, _ := configlib.GetCLIDiscoverySources()
 
@@ -22,7 +34,7 @@ func PopulateDefaultCentralDiscovery(force bool) error {
 		defaultDiscovery := configtypes.PluginDiscovery{
 			OCI: &configtypes.OCIDiscovery{
 				Name:  DefaultStandaloneDiscoveryName,
-				Image: constants.TanzuCLIDefaultCentralPluginDiscoveryImage,
+				Image: GetDefaultCentralDiscoveryImage(),
 			},
 		}
 		return configlib.SetCLIDiscoverySource(defaultDiscovery)
@@ -131,6 +131,31 @@ var _ = Describe("Populate default central discovery", func() {
 			Expect(discoverySources[0].OCI.Image).To(Equal(constants.TanzuCLIDefaultCentralPluginDiscoveryImage))
 		})
 	})
+	Context("when the central configuration overrides the default discovery image", func() {
+		var overrideFile *os.File
+		BeforeEach(func() {
+			overrideFile, err = os.CreateTemp("", "central_config_override")
+			Expect(err).To(BeNil())
+			_, err = overrideFile.WriteString("cli.core.tanzu_cli_default_plugin_discovery_image: registry.example.com/tanzu/plugin-inventory:latest\n")
+			Expect(err).To(BeNil())
+			os.Setenv(constants.ConfigVariableCentralConfigOverrideFile, overrideFile.Name())
+		})
+		AfterEach(func() {
+			os.Unsetenv(constants.ConfigVariableCentralConfigOverrideFile)
+			os.RemoveAll(overrideFile.Name())
+		})
+		It("should create the default central discovery with the overridden image", func() {
+			err = PopulateDefaultCentralDiscovery(true)
+			Expect(err).To(BeNil())
+
+			discoverySources, err := configlib.GetCLIDiscoverySources()
+			Expect(err).To(BeNil())
+			Expect(len(discoverySources)).To(Equal(1))
+			Expect(discoverySources[0].OCI).ToNot(BeNil())
+			Expect(discoverySources[0].OCI.Name).To(Equal(DefaultStandaloneDiscoveryName))
+			Expect(discoverySources[0].OCI.Image).To(Equal("registry.example.com/tanzu/plugin-inventory:latest"))
+		})
+	})
 	Context("when a the default central discovery was deleted by the user", func() {
 		BeforeEach(func() {
 			err = PopulateDefaultCentralDiscovery(false)
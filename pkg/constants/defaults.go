@@ -36,4 +36,21 @@ const (
 
 	// CentralConfigFileName is the name of the central config file
 	CentralConfigFileName = "central_config.yaml"
+
+	// CentralConfigOverrideFileName is the name of the file, stored in the local configuration directory,
+	// whose values take precedence over the values of the central config file
+	CentralConfigOverrideFileName = "central_config_override.yaml"
+
+	// DataStoreFileName is the name of the data store file of the CLI.
+	// It is a hidden file and should not be directly accessed by the user.
+	DataStoreFileName = ".data-store.yaml"
+
+	// LocalPluginGroupsFileName is the name of the file storing the local plugin groups
+	LocalPluginGroupsFileName = "local-plugin-groups.yaml"
+
+	// PluginDefaultsFileName is the name of the file storing the plugin defaults
+	PluginDefaultsFileName = "plugin-defaults.yaml"
+
+	// CommandAliasesFileName is the name of the file storing the command aliases
+	CommandAliasesFileName = "command-aliases.yaml"
 )
@@ -8,6 +8,12 @@ const (
 	ProxyCACert = "PROXY_CA_CERT"
 )
 
+// environment variable for the password of the cosign key signing a plugin bundle,
+// which is the same as for the cosign CLI
+const (
+	CosignPassword = "COSIGN_PASSWORD"
+)
+
 const (
 	AllowedRegistries                                 = "ALLOWED_REGISTRY"
 	ConfigVariableAdditionalDiscoveryForTesting       = "TANZU_CLI_ADDITIONAL_PLUGIN_DISCOVERY_IMAGES_TEST_ONLY"
@@ -20,6 +26,16 @@ const (
 	SuppressSkipSignatureVerificationWarning          = "TANZU_CLI_SUPPRESS_SKIP_SIGNATURE_VERIFICATION_WARNING"
 	CEIPOptInUserPromptAnswer                         = "TANZU_CLI_CEIP_OPT_IN_PROMPT_ANSWER"
 	EULAPromptAnswer                                  = "TANZU_CLI_EULA_PROMPT_ANSWER"
+
+	// DataStoreNamespace is set by the CLI to the name of the plugin it executes.  It is the
+	// namespace of the data store used by the 'tanzu config datastore' commands when the
+	// --namespace flag is not specified, which gives each plugin its own data store keys.
+	DataStoreNamespace = "TANZU_CLI_DATA_STORE_NAMESPACE"
+
+	// PublicKeyPathForCLIBinarySignature is the path of the public key used to verify the
+	// signature of the CLI binaries downloaded by 'tanzu update'
+	PublicKeyPathForCLIBinarySignature = "TANZU_CLI_BINARY_SIGNATURE_PUBLIC_KEY_PATH"
+
 	// Environment variable to indicate that the CLI is running in E2E test environment
 	E2ETestEnvironment                = "TANZU_CLI_E2E_TEST_ENVIRONMENT"
 	ShowTelemetryConsoleLogs          = "TANZU_CLI_SHOW_TELEMETRY_CONSOLE_LOGS"
@@ -50,9 +66,60 @@ const (
 	// ConfigVariablePluginDBCacheRefreshThresholdSeconds Change the default value of db cache refresh threshold
 	ConfigVariablePluginDBCacheRefreshThresholdSeconds = "TANZU_CLI_PLUGIN_DB_CACHE_REFRESH_THRESHOLD_SECONDS"
 
+	// ConfigVariableCentralConfigRefreshTTLSeconds Change the default value of the central configuration refresh TTL.
+	// A value of 0 disables the automatic refresh of the central configuration.
+	ConfigVariableCentralConfigRefreshTTLSeconds = "TANZU_CLI_CENTRAL_CONFIG_REFRESH_TTL_SECONDS"
+
+	// ConfigVariableBackgroundCacheRefresh enables, when set to "true", the refresh of the plugin inventory
+	// and of the central configuration by a background process started once a command has completed,
+	// so that the following commands use fresh data without waiting for its download
+	ConfigVariableBackgroundCacheRefresh = "TANZU_CLI_BACKGROUND_CACHE_REFRESH"
+
+	// ConfigVariableBackgroundCacheRefreshIntervalSeconds Change the default value of the minimum delay between two background refreshes
+	ConfigVariableBackgroundCacheRefreshIntervalSeconds = "TANZU_CLI_BACKGROUND_CACHE_REFRESH_INTERVAL_SECONDS"
+
+	// ConfigVariableDiscoverySourceCooldownSeconds Change the default value of the duration during which a discovery
+	// source that failed is not contacted; its cached plugin inventory is used instead.  0 deactivates the cooldown.
+	ConfigVariableDiscoverySourceCooldownSeconds = "TANZU_CLI_DISCOVERY_SOURCE_COOLDOWN_SECONDS"
+
+	// ConfigVariableMaxParallelDownloads Change the default value of the maximum number of downloads running in parallel
+	ConfigVariableMaxParallelDownloads = "TANZU_CLI_MAX_PARALLEL_DOWNLOADS"
+
+	// ConfigVariableDownloadBandwidthLimitKBps limits, in kilobytes per second, the bandwidth used by all the downloads
+	ConfigVariableDownloadBandwidthLimitKBps = "TANZU_CLI_DOWNLOAD_BANDWIDTH_LIMIT_KBPS"
+
+	// ConfigVariableCentralConfigOverrideFile specifies the path of the file whose values take
+	// precedence over the values of the central configuration
+	ConfigVariableCentralConfigOverrideFile = "TANZU_CLI_CENTRAL_CONFIG_OVERRIDE_FILE"
+
 	// ConfigVariableRecommendVersionDelayDays Change the default value of the delay between printing a recommended version message
 	ConfigVariableRecommendVersionDelayDays = "TANZU_CLI_RECOMMEND_VERSION_DELAY_DAYS"
 
+	// ConfigVariablePluginUpdateCheckDelayDays Change the default value of the delay between printing a notice about plugin updates
+	ConfigVariablePluginUpdateCheckDelayDays = "TANZU_CLI_PLUGIN_UPDATE_CHECK_DELAY_DAYS"
+
+	// ConfigVariableReleaseChannel selects the release channel (stable, beta or nightly) from which
+	// CLI versions are recommended, each channel also including the versions of the more stable ones.
+	// It is set by `tanzu config set cli.channel <channel>`
+	ConfigVariableReleaseChannel = "TANZU_CLI_RELEASE_CHANNEL"
+
+	// ConfigVariablePluginOS and ConfigVariablePluginArch override the OS and architecture of the
+	// plugin binaries installed by `tanzu plugin install`, which can then be different than the
+	// ones of the host.  This is meant for testing and for preparing plugins for other machines.
+	ConfigVariablePluginOS   = "TANZU_CLI_PLUGIN_OS"
+	ConfigVariablePluginArch = "TANZU_CLI_PLUGIN_ARCH"
+
+	// ConfigVariableIncludePreReleasePlugins controls whether pre-release plugin versions are considered
+	// when resolving the latest version of a plugin or plugin-group.  It can be set to "true" or "false"
+	// or to a comma-separated list of the plugin names or plugin-group IDs for which pre-release
+	// versions are considered.  When not set, pre-release versions are only considered if the CLI
+	// itself is a pre-release.
+	ConfigVariableIncludePreReleasePlugins = "TANZU_CLI_INCLUDE_PRERELEASE_PLUGINS"
+
+	// ConfigVariableSuppressVersionRecommendation permanently suppresses the recommended version message when set to "true".
+	// When set to "false", the message is printed even when the CLI is not used interactively or runs in CI.
+	ConfigVariableSuppressVersionRecommendation = "TANZU_CLI_SUPPRESS_VERSION_RECOMMENDATION"
+
 	// CSPLoginOrgID overrides the CSP default OrgID to which the user logs into, using CLI interactive login flow
 	// Note: More information regarding the CSP organizations can be found at
 	// https://docs.vmware.com/en/VMware-Cloud-services/services/Using-VMware-Cloud-Services/GUID-CF9E9318-B811-48CF-8499-9419997DC1F8.html
@@ -94,10 +161,19 @@ const (
 	// context using tanzu login or tanzu context create command
 	SkipTAPScopesValidationOnTanzuContext = "TANZU_CLI_SKIP_TAP_SCOPES_VALIDATION_ON_TANZU_CONTEXT"
 
+	// SkipMinCLIVersionCheck allows installing plugins which require a newer version of the Tanzu CLI,
+	// with a warning instead of an error
+	SkipMinCLIVersionCheck = "TANZU_CLI_SKIP_MIN_CLI_VERSION_CHECK"
+
 	// AuthenticatedRegistry provides a comma separated list of registry hosts that requires authentication
 	// to pull images. Tanzu CLI will use default docker auth to communicate to these registries
 	AuthenticatedRegistry = "TANZU_CLI_AUTHENTICATED_REGISTRY"
 
+	// RegistryMirrors provides a comma separated list of <registry>=<mirror> mappings, e.g.
+	// "projects.registry.vmware.com=harbor.example.com/vmware-proxy". Images of the registry
+	// (or of the repository path prefix) are pulled from the mirror instead.
+	RegistryMirrors = "TANZU_CLI_REGISTRY_MIRRORS"
+
 	// UseStableKubeContextNameForTanzuContext uses the stable kube context name associated with tanzu context.
 	// CLI would not change the context name when the TAP resource pointed by the CLI context is changed.
 	UseStableKubeContextNameForTanzuContext = "TANZU_CLI_USE_STABLE_KUBE_CONTEXT_NAME"
@@ -118,4 +194,45 @@ const (
 	// TPUCPEndpoint specifies UCP endpoint for the Tanzu Platform
 	// This will be used as part of `tanzu login`
 	TPUCPEndpoint = "TANZU_CLI_UCP_ENDPOINT"
+
+	// ConfigVariableConfigHome overrides XDG_CONFIG_HOME for the CLI only. It is the base directory
+	// of the local plugin distribution and telemetry directories.
+	ConfigVariableConfigHome = "TANZU_CLI_CONFIG_HOME"
+	// ConfigVariableCacheHome overrides XDG_CACHE_HOME for the CLI only. It is the base directory
+	// of the cache directory of the CLI.
+	ConfigVariableCacheHome = "TANZU_CLI_CACHE_HOME"
+	// ConfigVariableDataHome overrides XDG_DATA_HOME for the CLI only. It is the base directory
+	// of the directory where plugins are installed.
+	ConfigVariableDataHome = "TANZU_CLI_DATA_HOME"
+
+	// ConfigVariablePluginRoot specifies the directory where plugins are installed.
+	// It is set by `tanzu config set cli.plugin-root <dir>`
+	ConfigVariablePluginRoot = "TANZU_CLI_PLUGIN_ROOT"
+	// ConfigVariableCacheDir specifies the cache directory of the CLI.
+	// It is set by `tanzu config set cli.cache-dir <dir>`
+	ConfigVariableCacheDir = "TANZU_CLI_CACHE_DIR"
+	// ConfigVariableSystemPluginDirs specifies the read-only directories, separated by the OS path list
+	// separator, where an administrator provisions plugin binaries to be shared by all users
+	ConfigVariableSystemPluginDirs = "TANZU_CLI_SYSTEM_PLUGIN_DIRS"
+	// ConfigVariableAuditLog enables the audit log of the plugin invocations. It is either the path of
+	// the file where the records are appended as JSON lines, or "syslog" to send them to the system logger.
+	// It is set by `tanzu config set cli.audit-log <file|syslog>`
+	ConfigVariableAuditLog = "TANZU_CLI_AUDIT_LOG"
+	// ConfigVariablePluginRetainVersions specifies the number of versions of each installed plugin
+	// whose binaries are kept on disk, so that switching back to them does not download them again.
+	// All the versions are kept when not set. It is set by `tanzu config set cli.plugin-retain-versions <n>`
+	ConfigVariablePluginRetainVersions = "TANZU_CLI_PLUGIN_RETAIN_VERSIONS"
+
+	// PluginVendorAllowList provides a comma separated list of vendor-publisher/name patterns, which can
+	// contain '*' wildcards, of the plugins which can be installed from the plugin repositories.
+	// When set, it replaces the allow list of the central configuration.
+	PluginVendorAllowList = "TANZU_CLI_PLUGIN_VENDOR_ALLOW_LIST"
+	// PluginVendorDenyList provides a comma separated list of vendor-publisher/name patterns, which can
+	// contain '*' wildcards, of the plugins which cannot be installed from the plugin repositories.
+	// When set, it replaces the deny list of the central configuration.
+	PluginVendorDenyList = "TANZU_CLI_PLUGIN_VENDOR_DENY_LIST"
+
+	// IgnorePluginInstallationPolicy allows installing plugin versions which do not comply with the
+	// plugin installation policy of the central configuration, with a warning instead of an error
+	IgnorePluginInstallationPolicy = "TANZU_CLI_IGNORE_PLUGIN_INSTALLATION_POLICY"
 )
@@ -18,8 +18,30 @@ const (
 	// overrides an existing CLI command group should be conditional on the active context type or not.
 	// When false, the mapping will be unconditionally applied.
 	FeaturePluginOverrideOnActiveContextType = "features.global.plugin-override-on-active-context-type"
+
+	// FeatureTargetPrefixedCommands determines whether the plugins of the kubernetes target are also
+	// surfaced under the `tanzu kubernetes` (or `tanzu k8s`) command group.  This allows to invoke
+	// a kubernetes plugin whose name collides with another command at the root level.
+	// This is disabled by default.
+	FeatureTargetPrefixedCommands = "features.global.target-prefixed-commands"
+
+	// FeatureAMD64Fallback determines whether the AMD64 binary of a plugin is installed on Darwin and
+	// Windows ARM64 machines when the plugin is not available for ARM64.  The AMD64 binary then runs
+	// using emulation.  This is enabled by default.
+	FeatureAMD64Fallback = "features.global.amd64-fallback"
 )
 
+// KnownCliFeatureFlags describes the global feature flags used by the CLI.
+// It is used by the `tanzu config feature` commands to list the available feature flags.
+// Any new global feature flag should be added here with a short description.
+var KnownCliFeatureFlags = map[string]string{
+	FeatureContextCommand:                    "Deprecated: only used to detect if a CLI older than v1.3.0 was last executed",
+	FeaturePluginDiscoveryForTanzuContext:    "Enable the discovery of context-scoped plugins for Tanzu contexts",
+	FeaturePluginOverrideOnActiveContextType: "Only apply the command mapping of plugins based on the type of the active context",
+	FeatureTargetPrefixedCommands:            "Surface the plugins of the kubernetes target under the 'tanzu kubernetes' command group",
+	FeatureAMD64Fallback:                     "Install the AMD64 binary of plugins not available for ARM64 on Darwin and Windows ARM64 machines",
+}
+
 // DefaultCliFeatureFlags is used to populate an initially empty config file with default values for feature flags.
 // The keys MUST be in the format "features.global.<feature>" or initialization will fail
 //
@@ -32,5 +54,7 @@ const (
 // mainstreaming the feature (with a default true value) under the flag name "features.global.foo-bar", as there will be
 // no conflict with previous installs (that have a false value for the entry "features.global.foo-bar-beta").
 var (
-	DefaultCliFeatureFlags = map[string]bool{}
+	DefaultCliFeatureFlags = map[string]bool{
+		FeatureAMD64Fallback: true,
+	}
 )
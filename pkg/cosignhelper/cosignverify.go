@@ -5,10 +5,12 @@
 package cosignhelper
 
 import (
+	"bytes"
 	"context"
 	"crypto"
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/base64"
 	"fmt"
 	"net/http"
 	"os"
@@ -59,8 +61,6 @@ func NewCosignVerifier(publicKeyPath string, registryOpts *RegistryOptions) Cosi
 
 // Verify verifies the signature on the images
 func (vo *CosignVerifyOptions) Verify(ctx context.Context, images []string) error {
-	var pubKeys []signature.Verifier
-	var err error
 	httpTrans, err := vo.newHTTPTransport()
 	if err != nil {
 		return errors.Wrapf(err, "creating registry HTTP transport")
@@ -70,33 +70,11 @@ func (vo *CosignVerifyOptions) Verify(ctx context.Context, images []string) erro
 	// Using Rekor Default URL and Rekor public Keys (downloaded from online by default) not be feasible for air-gapped environment
 	ignoreTlog := true
 
-	switch {
-	// If PublicKeyPath is provided(custom public key) use it, else use the embedded public key
-	case vo.PublicKeyPath != "":
-		pubKey, err := sigs.PublicKeyFromKeyRefWithHashAlgo(ctx, vo.PublicKeyPath, crypto.SHA256)
-		if err != nil {
-			return fmt.Errorf("loading custom public key: %w", err)
-		}
-		pubKeys = append(pubKeys, pubKey)
-		pkcs11Key, ok := pubKey.(*pkcs11key.Key)
-		if ok {
-			defer pkcs11Key.Close()
-		}
-
-	default:
-		for _, raw := range [][]byte{tanzuCLIPluginDBImageSignPublicKeyOfficialV2, tanzuCLIPluginDBImageSignPublicKeyOfficial} {
-			// PEM encoded file.
-			key, err := cryptoutils.UnmarshalPEMToPublicKey(raw)
-			if err != nil {
-				return fmt.Errorf("failed unmarshalling PEM encoded default public key: %w", err)
-			}
-			pubKey, err := signature.LoadVerifier(key, crypto.SHA256)
-			if err != nil {
-				return fmt.Errorf("loading default public key: %w", err)
-			}
-			pubKeys = append(pubKeys, pubKey)
-		}
+	pubKeys, closeKeys, err := loadPublicKeys(ctx, vo.PublicKeyPath)
+	if err != nil {
+		return err
 	}
+	defer closeKeys()
 
 	var nameOpts []name.Option
 	if vo.RegistryOpts.AllowInsecure {
@@ -143,6 +121,67 @@ func (vo *CosignVerifyOptions) Verify(ctx context.Context, images []string) erro
 	return nil
 }
 
+// VerifyBlobSignature verifies the base64 encoded signature of the blob, as produced by
+// "cosign sign-blob".  If publicKeyPath is empty, the CLI embedded public keys are used.
+func VerifyBlobSignature(ctx context.Context, publicKeyPath string, blob, sig []byte) error {
+	rawSig, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(sig)))
+	if err != nil {
+		return errors.Wrap(err, "decoding the signature")
+	}
+
+	pubKeys, closeKeys, err := loadPublicKeys(ctx, publicKeyPath)
+	if err != nil {
+		return err
+	}
+	defer closeKeys()
+
+	var arrErr []error
+	for _, verifier := range pubKeys {
+		err = verifier.VerifySignature(bytes.NewReader(rawSig), bytes.NewReader(blob))
+		if err == nil {
+			return nil
+		}
+		arrErr = append(arrErr, fmt.Errorf("failed validating the signature: %w", err))
+	}
+	return kerrors.NewAggregate(arrErr)
+}
+
+// loadPublicKeys returns the verifiers of the custom public key if publicKeyPath is
+// provided, or of the embedded public keys otherwise.  The returned function must be
+// called to release the keys once the verification is done.
+func loadPublicKeys(ctx context.Context, publicKeyPath string) ([]signature.Verifier, func(), error) {
+	var pubKeys []signature.Verifier
+	closeKeys := func() {}
+
+	switch {
+	// If PublicKeyPath is provided(custom public key) use it, else use the embedded public key
+	case publicKeyPath != "":
+		pubKey, err := sigs.PublicKeyFromKeyRefWithHashAlgo(ctx, publicKeyPath, crypto.SHA256)
+		if err != nil {
+			return nil, nil, fmt.Errorf("loading custom public key: %w", err)
+		}
+		pubKeys = append(pubKeys, pubKey)
+		if pkcs11Key, ok := pubKey.(*pkcs11key.Key); ok {
+			closeKeys = pkcs11Key.Close
+		}
+
+	default:
+		for _, raw := range [][]byte{tanzuCLIPluginDBImageSignPublicKeyOfficialV2, tanzuCLIPluginDBImageSignPublicKeyOfficial} {
+			// PEM encoded file.
+			key, err := cryptoutils.UnmarshalPEMToPublicKey(raw)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed unmarshalling PEM encoded default public key: %w", err)
+			}
+			pubKey, err := signature.LoadVerifier(key, crypto.SHA256)
+			if err != nil {
+				return nil, nil, fmt.Errorf("loading default public key: %w", err)
+			}
+			pubKeys = append(pubKeys, pubKey)
+		}
+	}
+	return pubKeys, closeKeys, nil
+}
+
 func (vo *CosignVerifyOptions) newHTTPTransport() (*http.Transport, error) {
 	var pool *x509.CertPool
 
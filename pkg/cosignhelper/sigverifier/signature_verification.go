@@ -19,13 +19,64 @@ import (
 	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
 )
 
+// SignaturePolicy defines how the signature of a plugin discovery image is enforced
+type SignaturePolicy string
+
+const (
+	// SignaturePolicyRequire fails if the signature of the discovery image cannot be verified
+	SignaturePolicyRequire SignaturePolicy = "require"
+	// SignaturePolicyWarn only warns if the signature of the discovery image cannot be verified
+	SignaturePolicyWarn SignaturePolicy = "warn"
+	// SignaturePolicyNone does not verify the signature of the discovery image
+	SignaturePolicyNone SignaturePolicy = "none"
+)
+
+// SignatureStatus is the outcome of the signature verification of a plugin discovery image
+type SignatureStatus string
+
+const (
+	// SignatureStatusVerified means the signature of the discovery image was verified
+	SignatureStatusVerified SignatureStatus = "verified"
+	// SignatureStatusSkipped means the signature of the discovery image was not verified
+	// as per its signature policy or the signature verification skip list
+	SignatureStatusSkipped SignatureStatus = "skipped"
+	// SignatureStatusUnverified means the signature of the discovery image could not be
+	// verified but the discovery image was used anyway as per its "warn" signature policy
+	SignatureStatusUnverified SignatureStatus = "unverified"
+)
+
+// VerifyInventoryImageSignature verifies the signature of the plugin discovery image,
+// which is required
 func VerifyInventoryImageSignature(image string) error {
+	_, err := CheckInventoryImageSignature(image, SignaturePolicyRequire)
+	return err
+}
+
+// CheckInventoryImageSignature verifies the signature of the plugin discovery image
+// as per the signature policy of its discovery source and returns the outcome of the verification
+func CheckInventoryImageSignature(image string, policy SignaturePolicy) (SignatureStatus, error) {
+	if policy == SignaturePolicyNone {
+		// Print the message directly to stderr without using the log library
+		// to make sure the user sees the warning even if the logs are disabled
+		if skip, _ := strconv.ParseBool(os.Getenv(constants.SuppressSkipSignatureVerificationWarning)); !skip {
+			msg := fmt.Sprintf("Skipping the plugins discovery image signature verification for %q as per its %q signature policy", image, policy)
+			fmt.Fprintf(os.Stderr, "%s%s\n", log.GetLogTypeIndicator(log.LogTypeWARN), msg)
+		}
+		return SignatureStatusSkipped, nil
+	}
+
 	cosignVerifier, err := getCosignVerifier(image)
 	if err != nil {
-		return errors.Wrapf(err, "failed to initialize the cosign verifier")
+		return "", errors.Wrapf(err, "failed to initialize the cosign verifier")
 	}
 
 	if sigVerifyErr := verifyInventoryImageSignature(image, cosignVerifier); sigVerifyErr != nil {
+		if policy == SignaturePolicyWarn {
+			msg := fmt.Sprintf("Unable to verify the plugins discovery image signature of %q, continuing as per its %q signature policy: %v", image, policy, sigVerifyErr)
+			fmt.Fprintf(os.Stderr, "%s%s\n", log.GetLogTypeIndicator(log.LogTypeWARN), msg)
+			return SignatureStatusUnverified, nil
+		}
+
 		// Print the message directly to stderr without using the log library
 		// to make sure the user sees the error message even if the logs are disabled
 		msg := fmt.Sprintf("Unable to verify the plugins discovery image signature: %v", sigVerifyErr)
@@ -44,10 +95,27 @@ func VerifyInventoryImageSignature(image string) error {
 		// from an untrusted source.
 		os.Exit(1)
 	}
+	if IsSignatureVerificationSkipped(image) {
+		return SignatureStatusSkipped, nil
+	}
+	return SignatureStatusVerified, nil
+}
+
+// VerifyCLIBinarySignature verifies the cosign signature of the checksums file published
+// along with the CLI binaries.  The embedded public keys are used unless a custom public
+// key is provided through the TANZU_CLI_BINARY_SIGNATURE_PUBLIC_KEY_PATH variable.
+func VerifyCLIBinarySignature(checksums, signature []byte) error {
+	customPublicKeyPath := os.Getenv(constants.PublicKeyPathForCLIBinarySignature)
+	if err := cosignhelper.VerifyBlobSignature(context.Background(), customPublicKeyPath, checksums, signature); err != nil {
+		return errors.Wrap(err, "unable to verify the signature of the Tanzu CLI binary checksums")
+	}
 	return nil
 }
 
 func getCosignVerifier(image string) (cosignhelper.Cosignhelper, error) {
+	// The signature is verified from the registry mirror if one is configured
+	image = registry.ResolveImageMirror(image)
+
 	// Get the custom public key path and prepare cosign verifier, if empty, cosign verifier would use embedded public key for verification
 	customPublicKeyPath := os.Getenv(constants.PublicKeyPathForPluginDiscoveryImageSignature)
 
@@ -78,8 +146,7 @@ func getCosignVerifierRegistryOptions(image string) (*cosignhelper.RegistryOptio
 }
 
 func verifyInventoryImageSignature(image string, verifier cosignhelper.Cosignhelper) error {
-	signatureVerificationSkipSet := getPluginDiscoveryImagesSkippedForSignatureVerification()
-	if _, exists := signatureVerificationSkipSet[strings.TrimSpace(image)]; exists {
+	if IsSignatureVerificationSkipped(image) {
 		// log warning message iff user had not chosen to skip warning message for signature verification
 		if skip, _ := strconv.ParseBool(os.Getenv(constants.SuppressSkipSignatureVerificationWarning)); !skip {
 			log.Warningf("Skipping the plugins discovery image signature verification for %q\n ", image)
@@ -87,7 +154,7 @@ func verifyInventoryImageSignature(image string, verifier cosignhelper.Cosignhel
 		return nil
 	}
 
-	err := verifier.Verify(context.Background(), []string{image})
+	err := verifier.Verify(context.Background(), []string{registry.ResolveImageMirror(image)})
 	if err != nil {
 		return err
 	}
@@ -105,3 +172,22 @@ func getPluginDiscoveryImagesSkippedForSignatureVerification() map[string]struct
 	}
 	return discoveryImages
 }
+
+// IsSignatureVerificationSkipped returns true if the discovery image is in the
+// TANZU_CLI_PLUGIN_DISCOVERY_IMAGE_SIGNATURE_VERIFICATION_SKIP_LIST variable
+func IsSignatureVerificationSkipped(image string) bool {
+	_, skipped := getPluginDiscoveryImagesSkippedForSignatureVerification()[strings.TrimSpace(image)]
+	return skipped
+}
+
+// ParseSignaturePolicy returns the signature policy with the specified name,
+// or the default "require" policy if the name is empty
+func ParseSignaturePolicy(name string) (SignaturePolicy, error) {
+	switch p := SignaturePolicy(strings.ToLower(strings.TrimSpace(name))); p {
+	case "":
+		return SignaturePolicyRequire, nil
+	case SignaturePolicyRequire, SignaturePolicyWarn, SignaturePolicyNone:
+		return p, nil
+	}
+	return "", errors.Errorf("invalid signature policy %q, it must be one of %q, %q or %q", name, SignaturePolicyRequire, SignaturePolicyWarn, SignaturePolicyNone)
+}
@@ -4,13 +4,19 @@
 package sigverifier
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
 	"encoding/base64"
 	"fmt"
 	"os"
+	"path/filepath"
 	"testing"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
 
 	"github.com/vmware-tanzu/tanzu-cli/pkg/configpaths"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
@@ -158,4 +164,67 @@ var _ = Describe("Unit tests for discovery image signature verification", func()
 			})
 		})
 	})
+
+	Describe("Signature policy of the discovery images", func() {
+		It("should require the signature when no policy is specified", func() {
+			Expect(ParseSignaturePolicy("")).To(Equal(SignaturePolicyRequire))
+		})
+		It("should parse the valid policies", func() {
+			Expect(ParseSignaturePolicy("none")).To(Equal(SignaturePolicyNone))
+			Expect(ParseSignaturePolicy(" Warn")).To(Equal(SignaturePolicyWarn))
+			Expect(ParseSignaturePolicy("require")).To(Equal(SignaturePolicyRequire))
+		})
+		It("should fail for an invalid policy", func() {
+			_, err := ParseSignaturePolicy("invalid")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(`invalid signature policy "invalid"`))
+		})
+		It("should not verify the signature of an image with the 'none' policy", func() {
+			status, err := CheckInventoryImageSignature("test-image:latest", SignaturePolicyNone)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(status).To(Equal(SignatureStatusSkipped))
+		})
+		It("should report the images of the skip list", func() {
+			os.Setenv(constants.PluginDiscoveryImageSignatureVerificationSkipList, "dev-image:latest, test-image:latest")
+			defer os.Unsetenv(constants.PluginDiscoveryImageSignatureVerificationSkipList)
+			Expect(IsSignatureVerificationSkipped(" test-image:latest")).To(BeTrue())
+			Expect(IsSignatureVerificationSkipped("prod-image:latest")).To(BeFalse())
+		})
+	})
+	Describe("Verify the signature of the CLI binary checksums", func() {
+		var (
+			checksums []byte
+			signature []byte
+		)
+		BeforeEach(func() {
+			privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+			Expect(err).ToNot(HaveOccurred())
+			publicKeyPEM, err := cryptoutils.MarshalPublicKeyToPEM(privateKey.Public())
+			Expect(err).ToNot(HaveOccurred())
+			keyPath := filepath.Join(GinkgoT().TempDir(), "cosign.pub")
+			Expect(os.WriteFile(keyPath, publicKeyPEM, 0o644)).To(Succeed())
+			os.Setenv(constants.PublicKeyPathForCLIBinarySignature, keyPath)
+
+			checksums = []byte("0123  tanzu-cli-linux_amd64\n")
+			digest := sha256.Sum256(checksums)
+			rawSignature, err := ecdsa.SignASN1(rand.Reader, privateKey, digest[:])
+			Expect(err).ToNot(HaveOccurred())
+			signature = []byte(base64.StdEncoding.EncodeToString(rawSignature))
+		})
+		AfterEach(func() {
+			os.Unsetenv(constants.PublicKeyPathForCLIBinarySignature)
+		})
+		It("should succeed when the checksums are signed with the key", func() {
+			Expect(VerifyCLIBinarySignature(checksums, signature)).To(Succeed())
+		})
+		It("should fail when the checksums were modified", func() {
+			err := VerifyCLIBinarySignature([]byte("4567  tanzu-cli-linux_amd64\n"), signature)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("unable to verify the signature"))
+		})
+		It("should fail when the checksums are not signed with the embedded keys", func() {
+			os.Unsetenv(constants.PublicKeyPathForCLIBinarySignature)
+			Expect(VerifyCLIBinarySignature(checksums, signature)).ToNot(Succeed())
+		})
+	})
 })
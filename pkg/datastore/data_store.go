@@ -7,25 +7,22 @@ package datastore
 
 import (
 	"fmt"
-	"io"
 	"os"
-	"path/filepath"
 	"reflect"
+	"time"
 
-	"github.com/adrg/xdg"
 	"github.com/pkg/errors"
-	"github.com/rogpeppe/go-internal/lockedfile"
 	"gopkg.in/yaml.v3"
 
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/common"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/utils"
 )
 
-// dataStoreFileName is the name of the data store yaml file
-// that is stored in the .config/tanzu directory.
-// It is a hidden file and should not be directly accessed by the user.
-const dataStoreFileName = ".data-store.yaml"
-
-var lockFile *lockedfile.File
+// dataStoreLock is the write lock of the data store.  It is set while the lock is held.
+var dataStoreLock *utils.FileLock
 
 type dataStoreContent map[string]interface{}
 
@@ -46,7 +43,7 @@ func GetDataStoreValue(key string, out interface{}) error {
 	}
 
 	res, ok := content[key]
-	if !ok {
+	if !ok || isExpired(content, key) {
 		return fmt.Errorf("key %s not found in the data store", key)
 	}
 
@@ -57,8 +54,29 @@ func GetDataStoreValue(key string, out interface{}) error {
 	return err
 }
 
+// GetDataStoreContent returns all the keys and values of the data store.
+// Keys that have expired are not included.  It is mostly useful for debugging.
+func GetDataStoreContent() (map[string]interface{}, error) {
+	content, err := getDataStoreContent(false)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]interface{}, len(content))
+	for key, value := range content {
+		if !isExpired(content, key) {
+			result[key] = value
+		}
+	}
+	return result, nil
+}
+
 // SetDataStoreValue sets the value of the key in the data store.
 func SetDataStoreValue(key string, value interface{}) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+
 	content, err := getDataStoreContent(true)
 	if err != nil {
 		return err
@@ -68,12 +86,17 @@ func SetDataStoreValue(key string, value interface{}) error {
 		content = make(dataStoreContent)
 	}
 	content[key] = value
+	setExpiration(content, key, time.Time{})
 
 	return saveAndClose(content)
 }
 
 // DeleteDataStoreValue deletes the key and value from the data store.
 func DeleteDataStoreValue(key string) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+
 	content, err := getDataStoreContent(true)
 	if err != nil {
 		return err
@@ -86,16 +109,27 @@ func DeleteDataStoreValue(key string) error {
 	}
 
 	delete(content, key)
+	setExpiration(content, key, time.Time{})
 
 	return saveAndClose(content)
 }
 
-// getDataStore retrieves the data store from the config directory along with locking the file.
-// If `setWriteLock` is false, it will read the data store file with a ReadLock and release the
-// lock at the same time.
-// If `setWriteLock` is true, it will apply a WriteLock to the data store file, read the file
-// and keep the WriteLock on the file.  The function saveAndClose() should be called to save
+// validateKey returns an error for the keys under which the data store keeps its own
+// data, such as the namespaces, which must not be modified as regular keys
+func validateKey(key string) error {
+	if key == namespacesKey || key == expirationsKey {
+		return errors.Errorf("key %s is reserved by the data store", key)
+	}
+	return nil
+}
+
+// getDataStore retrieves the data store from the config directory.
+// If `setWriteLock` is false, it will read the data store file without locking, which is safe
+// because the data store file is always replaced atomically.
+// If `setWriteLock` is true, it will acquire the write lock of the data store, read the file
+// and keep the lock.  The function saveAndClose() should be called to save
 // any changes and release the lock.
+// If the data store file cannot be parsed, the content of the backup file is used instead.
 func getDataStoreContent(setWriteLock bool) (dataStoreContent, error) {
 	var content dataStoreContent
 
@@ -109,33 +143,43 @@ func getDataStoreContent(setWriteLock bool) (dataStoreContent, error) {
 
 	err = yaml.Unmarshal(b, &content)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode data store file")
+		backupContent, backupErr := getBackupContent()
+		if backupErr != nil {
+			return nil, errors.Wrap(err, "could not decode data store file")
+		}
+		// The next write will replace the corrupted data store with the recovered content
+		log.V(6).Warningf("the data store file is corrupted, using the content of its backup: %v", err)
+		return backupContent, nil
+	}
+
+	return content, nil
+}
+
+// getBackupContent reads the content of the backup of the data store
+func getBackupContent() (dataStoreContent, error) {
+	b, err := utils.ReadFile(getDataStoreBackupPath())
+	if err != nil {
+		return nil, err
 	}
 
+	var content dataStoreContent
+	if err := yaml.Unmarshal(b, &content); err != nil {
+		return nil, err
+	}
 	return content, nil
 }
 
 func getDataStoreBytes(setWriteLock bool) ([]byte, error) {
-	var err error
-	var b []byte
-
 	dsPath := getDataStorePath()
 	if setWriteLock {
-		dsDir := filepath.Dir(dsPath)
-		if !utils.PathExists(dsDir) {
-			// Create directory path if missing before locking the file
-			_ = os.MkdirAll(dsDir, 0755)
-		}
-		lockFile, err = lockedfile.Edit(dsPath)
+		var err error
+		dataStoreLock, err = utils.LockFile(dsPath)
 		if err != nil {
+			dataStoreLock = nil
 			return nil, err
 		}
-
-		b, err = io.ReadAll(lockFile)
-	} else {
-		b, err = lockedfile.Read(dsPath)
 	}
-	return b, err
+	return utils.ReadFile(dsPath)
 }
 
 // getDataStorePath gets the data store file path
@@ -146,34 +190,44 @@ func getDataStorePath() string {
 		return customDSFile
 	}
 
-	return filepath.Join(xdg.Home, ".config", "tanzu", dataStoreFileName)
+	return common.CLIConfigFilePath(constants.DataStoreFileName)
+}
+
+// getDataStoreBackupPath gets the path of the backup of the data store file
+func getDataStoreBackupPath() string {
+	return getDataStorePath() + ".bak"
 }
 
-// saveFile saves the data store file in the .config directory.
+// saveAndClose saves the data store file in the .config directory and releases the write lock.
+// To avoid losing the data store content if the CLI is interrupted, the file is replaced atomically.
+// The previous content is kept as a backup in case the data store file becomes corrupted.
 func saveAndClose(content dataStoreContent) error {
-	if lockFile == nil {
+	if dataStoreLock == nil {
 		return errors.New("cannot save the data store file as it is not locked")
 	}
-	defer lockFile.Close()
+	defer func() {
+		dataStoreLock.Unlock()
+		dataStoreLock = nil
+	}()
 
-	dsPath := getDataStorePath()
-	_, err := os.Stat(dsPath)
-	if err != nil {
-		return errors.Wrap(err, "could not stat the data store file")
-	}
+	// Take the opportunity to clean up any expired keys
+	purgeExpiredKeys(content)
 
 	out, err := yaml.Marshal(content)
 	if err != nil {
 		return errors.Wrap(err, "failed to encode the data store file")
 	}
 
-	if err := lockFile.Truncate(0); err != nil {
-		return errors.Wrap(err, "failed to truncate the data store file")
-	}
-	if _, err := lockFile.Seek(0, 0); err != nil {
-		return errors.Wrap(err, "failed to reset the data store file")
+	// Keep a backup of the current content if it is valid
+	dsPath := getDataStorePath()
+	if previous, err := utils.ReadFile(dsPath); err == nil {
+		var previousContent dataStoreContent
+		if yaml.Unmarshal(previous, &previousContent) == nil {
+			_ = utils.WriteFileAtomic(getDataStoreBackupPath(), previous, 0o600)
+		}
 	}
-	if _, err := lockFile.Write(out); err != nil {
+
+	if err := utils.WriteFileAtomic(dsPath, out, 0o600); err != nil {
 		return errors.Wrap(err, "failed to write the data store file")
 	}
 	return nil
@@ -10,7 +10,11 @@ import (
 	"testing"
 	"time"
 
+	"github.com/adrg/xdg"
 	"github.com/stretchr/testify/assert"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/utils"
 )
 
 var (
@@ -612,7 +616,99 @@ func TestDeleteDataStoreValue(t *testing.T) {
 }
 
 func TestGetDataStorePath(t *testing.T) {
+	// A data store of the user in ~/.config/tanzu would keep being used until it is migrated
+	originalHome := xdg.Home
+	defer func() { xdg.Home = originalHome }()
+	xdg.Home = t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", "")
+	t.Setenv(constants.ConfigVariableConfigHome, "")
+
 	// Verify that the data store path is in the .config directory (not the .cache directory)
 	path := getDataStorePath()
 	assert.Contains(t, path, ".config")
+
+	// Verify that the data store follows the relocated configuration directory
+	configHome := filepath.Join(t.TempDir(), "config")
+	t.Setenv(constants.ConfigVariableConfigHome, configHome)
+	assert.Equal(t, filepath.Join(configHome, "tanzu", constants.DataStoreFileName), getDataStorePath())
+}
+
+func TestDataStoreBackupAndRecovery(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "data_store_test")
+	assert.Nil(t, err)
+	defer os.RemoveAll(tmpDir)
+
+	dsFile := filepath.Join(tmpDir, ".data-store.yaml")
+	os.Setenv("TEST_CUSTOM_DATA_STORE_FILE", dsFile)
+	defer os.Unsetenv("TEST_CUSTOM_DATA_STORE_FILE")
+
+	assert.Nil(t, SetDataStoreValue("key1", "value1"))
+	assert.Nil(t, SetDataStoreValue("key2", "value2"))
+
+	// No temporary file is left behind
+	matches, err := filepath.Glob(filepath.Join(tmpDir, "*.tmp"))
+	assert.Nil(t, err)
+	assert.Empty(t, matches)
+
+	// The backup holds the previous content
+	backup, err := os.ReadFile(dsFile + ".bak")
+	assert.Nil(t, err)
+	assert.Contains(t, string(backup), "key1: value1")
+	assert.NotContains(t, string(backup), "key2")
+
+	// Corrupt the data store file; the backup is used instead
+	assert.Nil(t, os.WriteFile(dsFile, []byte("key1: [invalid"), 0644))
+	var value string
+	assert.Nil(t, GetDataStoreValue("key1", &value))
+	assert.Equal(t, "value1", value)
+
+	// The next write repairs the data store file and does not backup the corrupted content
+	assert.Nil(t, SetDataStoreValue("key3", "value3"))
+	content, err := os.ReadFile(dsFile)
+	assert.Nil(t, err)
+	assert.Contains(t, string(content), "key1: value1")
+	assert.Contains(t, string(content), "key3: value3")
+	backup, err = os.ReadFile(dsFile + ".bak")
+	assert.Nil(t, err)
+	assert.NotContains(t, string(backup), "invalid")
+
+	// Without a valid backup, a corrupted data store is reported
+	assert.Nil(t, os.WriteFile(dsFile, []byte("key1: [invalid"), 0644))
+	assert.Nil(t, os.Remove(dsFile+".bak"))
+	assert.NotNil(t, GetDataStoreValue("key1", &value))
+}
+
+func TestDataStoreWritersAreSerialized(t *testing.T) {
+	dsFile := filepath.Join(t.TempDir(), ".data-store.yaml")
+	os.Setenv("TEST_CUSTOM_DATA_STORE_FILE", dsFile)
+	defer os.Unsetenv("TEST_CUSTOM_DATA_STORE_FILE")
+
+	assert.Nil(t, SetDataStoreValue("key1", "value1"))
+
+	// Another process holds the write lock of the data store
+	lock, err := utils.LockFile(dsFile)
+	assert.Nil(t, err)
+	content, err := os.ReadFile(dsFile)
+	assert.Nil(t, err)
+	assert.Contains(t, string(content), "key1: value1")
+
+	done := make(chan error)
+	go func() {
+		done <- SetDataStoreValue("key2", "value2")
+	}()
+	select {
+	case <-done:
+		t.Fatal("the data store was modified while it was locked")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	assert.Nil(t, utils.WriteFileAtomic(dsFile, append(content, []byte("key3: value3\n")...), 0o600))
+	lock.Unlock()
+	assert.Nil(t, <-done)
+
+	// No modification is lost
+	var value string
+	assert.Nil(t, GetDataStoreValue("key2", &value))
+	assert.Nil(t, GetDataStoreValue("key3", &value))
+	assert.Equal(t, "value3", value)
 }
@@ -0,0 +1,98 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package datastore
+
+import (
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// expirationsKey is the data store key under which the expiration times of the
+// keys that were set with a TTL are stored
+const expirationsKey = "expirations"
+
+// SetDataStoreValueWithTTL sets the value of the key in the data store for the
+// specified duration.  Once the TTL has expired, the key is treated as if it
+// did not exist and is removed from the data store on the next write.
+// This allows features that must only act periodically to rely on the presence
+// of a key instead of comparing timestamps.
+func SetDataStoreValueWithTTL(key string, value interface{}, ttl time.Duration) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+
+	content, err := getDataStoreContent(true)
+	if err != nil {
+		return err
+	}
+
+	if content == nil {
+		content = make(dataStoreContent)
+	}
+	content[key] = value
+	setExpiration(content, key, time.Now().Add(ttl))
+
+	return saveAndClose(content)
+}
+
+// getExpirations returns the expiration times stored in the data store
+func getExpirations(content dataStoreContent) map[string]time.Time {
+	expirations := make(map[string]time.Time)
+	res, ok := content[expirationsKey]
+	if !ok {
+		return expirations
+	}
+
+	yamlBytes, err := yaml.Marshal(res)
+	if err == nil {
+		_ = yaml.Unmarshal(yamlBytes, &expirations)
+	}
+	return expirations
+}
+
+// setExpiration sets the expiration time of the key.  A zero expiration
+// time removes any expiration for the key.
+func setExpiration(content dataStoreContent, key string, expiration time.Time) {
+	expirations := getExpirations(content)
+	if expiration.IsZero() {
+		delete(expirations, key)
+	} else {
+		expirations[key] = expiration
+	}
+
+	if len(expirations) == 0 {
+		delete(content, expirationsKey)
+	} else {
+		content[expirationsKey] = expirations
+	}
+}
+
+// isExpired returns true if the key was set with a TTL that has expired
+func isExpired(content dataStoreContent, key string) bool {
+	expiration, ok := getExpirations(content)[key]
+	return ok && time.Now().After(expiration)
+}
+
+// purgeExpiredKeys removes all keys whose TTL has expired from the data store content
+func purgeExpiredKeys(content dataStoreContent) {
+	expirations := getExpirations(content)
+	if len(expirations) == 0 {
+		return
+	}
+
+	now := time.Now()
+	for key, expiration := range expirations {
+		if now.After(expiration) {
+			delete(content, key)
+			delete(expirations, key)
+		}
+	}
+
+	if len(expirations) == 0 {
+		delete(content, expirationsKey)
+	} else {
+		content[expirationsKey] = expirations
+	}
+}
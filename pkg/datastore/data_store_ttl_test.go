@@ -0,0 +1,56 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package datastore
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetDataStoreValueWithTTL(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "data_store_test")
+	assert.Nil(t, err)
+	defer os.RemoveAll(tmpDir)
+
+	os.Setenv("TEST_CUSTOM_DATA_STORE_FILE", filepath.Join(tmpDir, ".data-store.yaml"))
+	defer os.Unsetenv("TEST_CUSTOM_DATA_STORE_FILE")
+
+	// A key with a TTL that has not expired can be read
+	assert.Nil(t, SetDataStoreValueWithTTL("validKey", "value1", time.Hour))
+	var value string
+	assert.Nil(t, GetDataStoreValue("validKey", &value))
+	assert.Equal(t, "value1", value)
+
+	// A key with an expired TTL is treated as missing
+	assert.Nil(t, SetDataStoreValueWithTTL("expiredKey", "value2", -time.Second))
+	value = ""
+	assert.NotNil(t, GetDataStoreValue("expiredKey", &value))
+	assert.Equal(t, "", value)
+
+	// The expired key is removed from the data store on the next write
+	assert.Nil(t, SetDataStoreValue("otherKey", "value3"))
+	content, err := getDataStoreContent(false)
+	assert.Nil(t, err)
+	assert.NotContains(t, content, "expiredKey")
+	assert.Contains(t, content, "validKey")
+	assert.Equal(t, 1, len(getExpirations(content)))
+
+	// Setting a key without a TTL removes its expiration
+	assert.Nil(t, SetDataStoreValue("validKey", "value4"))
+	content, err = getDataStoreContent(false)
+	assert.Nil(t, err)
+	assert.NotContains(t, content, expirationsKey)
+
+	// Deleting a key removes its expiration
+	assert.Nil(t, SetDataStoreValueWithTTL("validKey", "value5", time.Hour))
+	assert.Nil(t, DeleteDataStoreValue("validKey"))
+	content, err = getDataStoreContent(false)
+	assert.Nil(t, err)
+	assert.NotContains(t, content, "validKey")
+	assert.NotContains(t, content, expirationsKey)
+}
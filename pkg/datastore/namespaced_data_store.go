@@ -0,0 +1,216 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package datastore
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// The namespaced data store allows plugins and CLI subsystems to persist small
+// pieces of state without having to manage their own files and without risking
+// a collision with the keys used by other components.  Plugins should use their
+// plugin name as namespace.
+// All namespaces are stored under a single key of the data store file.
+
+const (
+	// namespacesKey is the data store key under which all namespaces are stored
+	namespacesKey = "namespaces"
+
+	// NamespaceQuotaBytes is the maximum size of the yaml encoded content of a single namespace
+	NamespaceQuotaBytes = 64 * 1024
+)
+
+// namespaceRegex restricts the namespace names to a safe set of characters
+var namespaceRegex = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9._-]*$`)
+
+// GetNamespacedDataStoreValue reads the data store and returns the value
+// for the given key of the given namespace. The value is unmarshalled
+// into the out parameter. The out parameter must be a non-nil pointer to a value.
+// If the key does not exist, the out parameter is not modified and an error is returned.
+func GetNamespacedDataStoreValue(namespace, key string, out interface{}) error {
+	if err := validateNamespace(namespace); err != nil {
+		return err
+	}
+	v := reflect.ValueOf(out)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return fmt.Errorf("out must be a pointer to a value")
+	}
+
+	content, err := getDataStoreContent(false)
+	if err != nil || content == nil {
+		return err
+	}
+
+	res, ok := getNamespaceContent(content, namespace)[key]
+	if !ok {
+		return fmt.Errorf("key %s not found in namespace %s of the data store", key, namespace)
+	}
+
+	yamlBytes, err := yaml.Marshal(res)
+	if err == nil {
+		err = yaml.Unmarshal(yamlBytes, out)
+	}
+	return err
+}
+
+// GetNamespacedDataStoreContent returns all the keys and values of the given namespace of the data store.
+func GetNamespacedDataStoreContent(namespace string) (map[string]interface{}, error) {
+	if err := validateNamespace(namespace); err != nil {
+		return nil, err
+	}
+
+	content, err := getDataStoreContent(false)
+	if err != nil {
+		return nil, err
+	}
+	return getNamespaceContent(content, namespace), nil
+}
+
+// GetDataStoreNamespaces returns the sorted list of namespaces of the data store.
+func GetDataStoreNamespaces() ([]string, error) {
+	content, err := getDataStoreContent(false)
+	if err != nil {
+		return nil, err
+	}
+
+	namespaces := toMap(content[namespacesKey])
+	result := make([]string, 0, len(namespaces))
+	for namespace := range namespaces {
+		result = append(result, namespace)
+	}
+	sort.Strings(result)
+	return result, nil
+}
+
+// SetNamespacedDataStoreValue sets the value of the key of the given namespace in the data store.
+// An error is returned if the content of the namespace would exceed NamespaceQuotaBytes.
+func SetNamespacedDataStoreValue(namespace, key string, value interface{}) error {
+	if err := validateNamespace(namespace); err != nil {
+		return err
+	}
+
+	content, err := getDataStoreContent(true)
+	if err != nil {
+		return err
+	}
+	if content == nil {
+		content = make(dataStoreContent)
+	}
+
+	// Work on a copy so the data store is left untouched if the quota is exceeded
+	nsContent := make(map[string]interface{})
+	for k, v := range getNamespaceContent(content, namespace) {
+		nsContent[k] = v
+	}
+	nsContent[key] = value
+
+	nsBytes, err := yaml.Marshal(nsContent)
+	if err != nil {
+		_ = saveAndClose(content)
+		return errors.Wrap(err, "failed to encode the value")
+	}
+	if len(nsBytes) > NamespaceQuotaBytes {
+		_ = saveAndClose(content)
+		return errors.Errorf("cannot store key %s: namespace %s of the data store would exceed its quota of %d bytes", key, namespace, NamespaceQuotaBytes)
+	}
+
+	setNamespaceContent(content, namespace, nsContent)
+	return saveAndClose(content)
+}
+
+// DeleteNamespacedDataStoreValue deletes the key and value of the given namespace from the data store.
+func DeleteNamespacedDataStoreValue(namespace, key string) error {
+	if err := validateNamespace(namespace); err != nil {
+		return err
+	}
+
+	content, err := getDataStoreContent(true)
+	if err != nil {
+		return err
+	}
+
+	nsContent := getNamespaceContent(content, namespace)
+	if _, present := nsContent[key]; !present {
+		_ = saveAndClose(content)
+		return fmt.Errorf("key %s not found in namespace %s of the data store", key, namespace)
+	}
+	delete(nsContent, key)
+	setNamespaceContent(content, namespace, nsContent)
+
+	return saveAndClose(content)
+}
+
+// DeleteDataStoreNamespace deletes the given namespace and all its keys from the data store.
+// It is not an error to delete a namespace that does not exist.
+func DeleteDataStoreNamespace(namespace string) error {
+	if err := validateNamespace(namespace); err != nil {
+		return err
+	}
+
+	content, err := getDataStoreContent(true)
+	if err != nil {
+		return err
+	}
+	if content == nil {
+		content = make(dataStoreContent)
+	}
+
+	setNamespaceContent(content, namespace, nil)
+	return saveAndClose(content)
+}
+
+func validateNamespace(namespace string) error {
+	if !namespaceRegex.MatchString(namespace) {
+		return errors.Errorf("invalid data store namespace %q", namespace)
+	}
+	return nil
+}
+
+// getNamespaceContent returns the content of the namespace or an empty map if it does not exist
+func getNamespaceContent(content dataStoreContent, namespace string) map[string]interface{} {
+	namespaces := toMap(content[namespacesKey])
+	nsContent := toMap(namespaces[namespace])
+	if nsContent == nil {
+		nsContent = make(map[string]interface{})
+	}
+	return nsContent
+}
+
+// toMap returns the value if it is a map, or nil.  The maps of the data store file are
+// decoded with the type of the data store content, while the maps set by the CLI are not.
+func toMap(value interface{}) map[string]interface{} {
+	switch m := value.(type) {
+	case map[string]interface{}:
+		return m
+	case dataStoreContent:
+		return m
+	}
+	return nil
+}
+
+// setNamespaceContent sets the content of the namespace, removing the namespace if it is empty
+func setNamespaceContent(content dataStoreContent, namespace string, nsContent map[string]interface{}) {
+	namespaces := toMap(content[namespacesKey])
+	if namespaces == nil {
+		namespaces = make(map[string]interface{})
+	}
+
+	if len(nsContent) == 0 {
+		delete(namespaces, namespace)
+	} else {
+		namespaces[namespace] = nsContent
+	}
+
+	if len(namespaces) == 0 {
+		delete(content, namespacesKey)
+	} else {
+		content[namespacesKey] = namespaces
+	}
+}
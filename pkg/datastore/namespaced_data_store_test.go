@@ -0,0 +1,91 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package datastore
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNamespacedDataStoreValue(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "data_store_test")
+	assert.Nil(t, err)
+	defer os.RemoveAll(tmpDir)
+
+	os.Setenv("TEST_CUSTOM_DATA_STORE_FILE", filepath.Join(tmpDir, ".data-store.yaml"))
+	defer os.Unsetenv("TEST_CUSTOM_DATA_STORE_FILE")
+
+	// Keys of different namespaces and of the global data store don't collide
+	assert.Nil(t, SetDataStoreValue("testKey", "global"))
+	assert.Nil(t, SetNamespacedDataStoreValue("plugin1", "testKey", "value1"))
+	assert.Nil(t, SetNamespacedDataStoreValue("plugin2", "testKey", map[string]string{"sub": "value2"}))
+
+	var value string
+	assert.Nil(t, GetDataStoreValue("testKey", &value))
+	assert.Equal(t, "global", value)
+	assert.Nil(t, GetNamespacedDataStoreValue("plugin1", "testKey", &value))
+	assert.Equal(t, "value1", value)
+	var mapValue map[string]string
+	assert.Nil(t, GetNamespacedDataStoreValue("plugin2", "testKey", &mapValue))
+	assert.Equal(t, map[string]string{"sub": "value2"}, mapValue)
+
+	// The key under which the namespaces are stored is reserved
+	assert.NotNil(t, SetDataStoreValue(namespacesKey, "value"))
+	assert.NotNil(t, DeleteDataStoreValue(namespacesKey))
+	assert.Nil(t, GetNamespacedDataStoreValue("plugin1", "testKey", &value))
+
+	// Missing keys and namespaces
+	assert.NotNil(t, GetNamespacedDataStoreValue("plugin1", "missingKey", &value))
+	assert.NotNil(t, GetNamespacedDataStoreValue("plugin3", "testKey", &value))
+
+	// Invalid namespaces and parameters
+	assert.NotNil(t, SetNamespacedDataStoreValue("", "testKey", "value"))
+	assert.NotNil(t, SetNamespacedDataStoreValue("plugin/1", "testKey", "value"))
+	assert.NotNil(t, GetNamespacedDataStoreValue("plugin1", "testKey", value))
+
+	// Deleting a key only affects its namespace
+	assert.Nil(t, DeleteNamespacedDataStoreValue("plugin1", "testKey"))
+	assert.NotNil(t, GetNamespacedDataStoreValue("plugin1", "testKey", &value))
+	assert.NotNil(t, DeleteNamespacedDataStoreValue("plugin1", "testKey"))
+	assert.Nil(t, GetNamespacedDataStoreValue("plugin2", "testKey", &mapValue))
+	assert.Nil(t, GetDataStoreValue("testKey", &value))
+	assert.Equal(t, "global", value)
+
+	// Deleting a namespace removes all its keys
+	assert.Nil(t, DeleteDataStoreNamespace("plugin2"))
+	assert.NotNil(t, GetNamespacedDataStoreValue("plugin2", "testKey", &mapValue))
+	assert.Nil(t, DeleteDataStoreNamespace("plugin2"))
+}
+
+func TestNamespacedDataStoreQuota(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "data_store_test")
+	assert.Nil(t, err)
+	defer os.RemoveAll(tmpDir)
+
+	os.Setenv("TEST_CUSTOM_DATA_STORE_FILE", filepath.Join(tmpDir, ".data-store.yaml"))
+	defer os.Unsetenv("TEST_CUSTOM_DATA_STORE_FILE")
+
+	halfQuota := strings.Repeat("a", NamespaceQuotaBytes/2)
+	assert.Nil(t, SetNamespacedDataStoreValue("plugin1", "key1", halfQuota))
+
+	// The quota is per namespace
+	assert.Nil(t, SetNamespacedDataStoreValue("plugin2", "key1", halfQuota))
+
+	err = SetNamespacedDataStoreValue("plugin1", "key2", halfQuota)
+	assert.ErrorContains(t, err, "quota")
+
+	// The data store is left untouched when the quota is exceeded
+	var value string
+	assert.NotNil(t, GetNamespacedDataStoreValue("plugin1", "key2", &value))
+	assert.Nil(t, GetNamespacedDataStoreValue("plugin1", "key1", &value))
+	assert.Equal(t, halfQuota, value)
+
+	// Replacing an existing value is measured against the new content
+	assert.Nil(t, SetNamespacedDataStoreValue("plugin1", "key1", "small"))
+	assert.Nil(t, SetNamespacedDataStoreValue("plugin1", "key2", halfQuota))
+}
@@ -0,0 +1,110 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package discovery
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/centralconfig"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/common"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+)
+
+// The central configuration of a discovery source is stored in the cache along with
+// the plugin inventory of that source and is therefore refreshed as part of the
+// plugin inventory.  The functions below allow to explicitly refresh it and to
+// refresh it automatically once its TTL has expired.
+
+// RefreshCentralConfigForSource forces the refresh of the plugin inventory of the
+// specified discovery source, including its central configuration.
+// Note that if the digest of the inventory image has not changed, the cached data
+// is still up-to-date and is not downloaded again.
+func RefreshCentralConfigForSource(source configtypes.PluginDiscovery) error {
+	name, _, err := getDiscoverySourceNameAndURL(source)
+	if err != nil {
+		return err
+	}
+
+	if err := RefreshDiscoveryDatabaseForSource(source, WithForceRefresh()); err != nil {
+		return err
+	}
+
+	// Mark the central configuration as refreshed now, even if it was not downloaded
+	// again because it had not changed
+	now := time.Now()
+	_ = os.Chtimes(getCentralConfigFilePath(name), now, now)
+	return nil
+}
+
+// RefreshStaleCentralConfigs refreshes the central configuration of every discovery
+// source for which the central configuration has not been refreshed since its TTL.
+// Nothing is refreshed when the TTL is 0, which disables the automatic refresh.
+func RefreshStaleCentralConfigs(sources []configtypes.PluginDiscovery) error {
+	var errorList []error
+	for _, source := range getStaleCentralConfigSources(sources) {
+		if err := RefreshCentralConfigForSource(source); err != nil {
+			errorList = append(errorList, err)
+		}
+	}
+	return kerrors.NewAggregate(errorList)
+}
+
+// HasStaleCentralConfig returns true if the central configuration of any of the discovery
+// sources has not been refreshed since its TTL.  It does not access the network.
+func HasStaleCentralConfig(sources []configtypes.PluginDiscovery) bool {
+	return len(getStaleCentralConfigSources(sources)) > 0
+}
+
+func getStaleCentralConfigSources(sources []configtypes.PluginDiscovery) []configtypes.PluginDiscovery {
+	ttl := getCentralConfigRefreshTTL()
+	if ttl == 0 {
+		return nil
+	}
+
+	var staleSources []configtypes.PluginDiscovery
+	for _, source := range sources {
+		name, _, err := getDiscoverySourceNameAndURL(source)
+		if err != nil {
+			continue
+		}
+		if isCentralConfigStale(getCentralConfigFilePath(name), ttl) {
+			staleSources = append(staleSources, source)
+		}
+	}
+	return staleSources
+}
+
+// isCentralConfigStale returns true if the central configuration file was last refreshed
+// longer than the TTL ago.  A missing central configuration file is not considered stale
+// as it will be downloaded along with the plugin inventory when it is first needed.
+func isCentralConfigStale(centralConfigFile string, ttl time.Duration) bool {
+	stat, err := os.Stat(centralConfigFile)
+	if err != nil {
+		return false
+	}
+	return time.Since(stat.ModTime()) > ttl
+}
+
+// getCentralConfigRefreshTTL returns the TTL of the central configuration,
+// which can be overridden by the user.  A TTL of 0 disables the automatic refresh.
+func getCentralConfigRefreshTTL() time.Duration {
+	seconds := centralconfig.DefaultCentralConfigRefreshTTLSeconds
+	if ttlOverride := os.Getenv(constants.ConfigVariableCentralConfigRefreshTTLSeconds); ttlOverride != "" {
+		if ttlOverrideValue, err := strconv.Atoi(ttlOverride); err == nil && ttlOverrideValue >= 0 {
+			seconds = ttlOverrideValue
+		}
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+func getCentralConfigFilePath(discoveryName string) string {
+	return filepath.Join(common.DefaultCacheDir, common.PluginInventoryDirName, discoveryName, constants.CentralConfigFileName)
+}
@@ -0,0 +1,85 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package discovery
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/centralconfig"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/common"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
+)
+
+func Test_isCentralConfigStale(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := os.MkdirTemp("", "central-config-refresh")
+	assert.Nil(err)
+	defer os.RemoveAll(dir)
+
+	centralConfigFile := filepath.Join(dir, constants.CentralConfigFileName)
+
+	// A missing file is not stale as it will be downloaded with the plugin inventory
+	assert.False(isCentralConfigStale(centralConfigFile, time.Hour))
+
+	assert.Nil(os.WriteFile(centralConfigFile, []byte("testKey: testValue"), 0644))
+	assert.False(isCentralConfigStale(centralConfigFile, time.Hour))
+
+	oldTime := time.Now().Add(-2 * time.Hour)
+	assert.Nil(os.Chtimes(centralConfigFile, oldTime, oldTime))
+	assert.True(isCentralConfigStale(centralConfigFile, time.Hour))
+}
+
+func Test_getCentralConfigRefreshTTL(t *testing.T) {
+	assert := assert.New(t)
+
+	defaultTTL := time.Duration(centralconfig.DefaultCentralConfigRefreshTTLSeconds) * time.Second
+	assert.Equal(defaultTTL, getCentralConfigRefreshTTL())
+
+	os.Setenv(constants.ConfigVariableCentralConfigRefreshTTLSeconds, "60")
+	defer os.Unsetenv(constants.ConfigVariableCentralConfigRefreshTTLSeconds)
+	assert.Equal(time.Minute, getCentralConfigRefreshTTL())
+
+	os.Setenv(constants.ConfigVariableCentralConfigRefreshTTLSeconds, "0")
+	assert.Equal(time.Duration(0), getCentralConfigRefreshTTL())
+
+	os.Setenv(constants.ConfigVariableCentralConfigRefreshTTLSeconds, "invalid")
+	assert.Equal(defaultTTL, getCentralConfigRefreshTTL())
+
+	os.Setenv(constants.ConfigVariableCentralConfigRefreshTTLSeconds, "-1")
+	assert.Equal(defaultTTL, getCentralConfigRefreshTTL())
+}
+
+func TestHasStaleCentralConfig(t *testing.T) {
+	assert := assert.New(t)
+
+	originalCacheDir := common.DefaultCacheDir
+	common.DefaultCacheDir = t.TempDir()
+	defer func() { common.DefaultCacheDir = originalCacheDir }()
+
+	sources := []configtypes.PluginDiscovery{{OCI: &configtypes.OCIDiscovery{Name: "default", Image: "example.com/inventory:latest"}}}
+	assert.False(HasStaleCentralConfig(sources))
+
+	centralConfigFile := getCentralConfigFilePath("default")
+	assert.Nil(os.MkdirAll(filepath.Dir(centralConfigFile), 0755))
+	assert.Nil(os.WriteFile(centralConfigFile, []byte("testKey: testValue"), 0644))
+	assert.False(HasStaleCentralConfig(sources))
+
+	oldTime := time.Now().Add(-2 * time.Duration(centralconfig.DefaultCentralConfigRefreshTTLSeconds) * time.Second)
+	assert.Nil(os.Chtimes(centralConfigFile, oldTime, oldTime))
+	assert.True(HasStaleCentralConfig(sources))
+
+	// A TTL of 0 disables the refresh
+	os.Setenv(constants.ConfigVariableCentralConfigRefreshTTLSeconds, "0")
+	defer os.Unsetenv(constants.ConfigVariableCentralConfigRefreshTTLSeconds)
+	assert.False(HasStaleCentralConfig(sources))
+	assert.Nil(RefreshStaleCentralConfigs(sources))
+}
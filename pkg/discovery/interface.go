@@ -12,12 +12,15 @@ import (
 	"errors"
 	"time"
 
+	"github.com/vmware-tanzu/tanzu-cli/pkg/cosignhelper/sigverifier"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/plugininventory"
 	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
 )
 
 var defaultTimeout = 5 * time.Second
 
+//go:generate counterfeiter -o ./fakes/discovery_fake.go --fake-name Discovery . Discovery
+
 // Discovery is the interface to fetch the list of available plugins
 type Discovery interface {
 	// Name of the repository.
@@ -45,6 +48,10 @@ type DiscoveryOpts struct {
 	ForceInvalidation       bool // ForceInvalidation used to force invalidation of the plugin data
 	PluginDiscoveryCriteria *PluginDiscoveryCriteria
 	GroupDiscoveryCriteria  *GroupDiscoveryCriteria
+	CacheDir                string // CacheDir is the directory caching the plugin data, the CLI cache directory if empty
+	// SignaturePolicy is the signature policy used to verify the plugin data,
+	// the one recorded for the discovery source if empty
+	SignaturePolicy sigverifier.SignaturePolicy
 }
 
 type DiscoveryOptions func(options *DiscoveryOpts)
@@ -73,6 +80,21 @@ func WithForceInvalidation() DiscoveryOptions {
 	}
 }
 
+// WithCacheDir used to specify the directory in which the plugin inventory data is cached
+func WithCacheDir(dir string) DiscoveryOptions {
+	return func(o *DiscoveryOpts) {
+		o.CacheDir = dir
+	}
+}
+
+// WithSignaturePolicy used to verify the plugin inventory data with the specified signature
+// policy instead of the one recorded for the discovery source, e.g. before recording it
+func WithSignaturePolicy(policy sigverifier.SignaturePolicy) DiscoveryOptions {
+	return func(o *DiscoveryOpts) {
+		o.SignaturePolicy = policy
+	}
+}
+
 // WithPluginDiscoveryCriteria used to specify the plugin discovery criteria
 func WithPluginDiscoveryCriteria(criteria *PluginDiscoveryCriteria) DiscoveryOptions {
 	return func(o *DiscoveryOpts) {
@@ -109,18 +131,39 @@ type PluginDiscoveryCriteria struct {
 // GroupDiscoveryCriteria provides criteria to look for
 // plugin groups in a discovery.
 type GroupDiscoveryCriteria struct {
-	// Vendor of the group
+	// Vendor of the group.  Can contain '*' wildcards.
 	Vendor string
-	// Publisher of the group
+	// Publisher of the group.  Can contain '*' wildcards.
 	Publisher string
-	// Name of the group
+	// Name of the group.  Can contain '*' wildcards.
 	Name string
 	// Version is the version for the group
 	Version string
 }
 
+// DiscoveryFactory creates the Discovery of a plugin discovery source
+type DiscoveryFactory func(pd configtypes.PluginDiscovery, options ...DiscoveryOptions) (Discovery, error)
+
+// discoveryFactory is the factory used by CreateDiscoveryFromV1alpha1
+var discoveryFactory DiscoveryFactory = DefaultDiscoveryFactory
+
+// SetDiscoveryFactory sets the factory used to create the Discovery of the
+// plugin discovery sources. Setting a nil factory restores the default one.
+func SetDiscoveryFactory(factory DiscoveryFactory) {
+	if factory == nil {
+		factory = DefaultDiscoveryFactory
+	}
+	discoveryFactory = factory
+}
+
 // CreateDiscoveryFromV1alpha1 creates discovery interface from v1alpha1 API
 func CreateDiscoveryFromV1alpha1(pd configtypes.PluginDiscovery, options ...DiscoveryOptions) (Discovery, error) {
+	return discoveryFactory(pd, options...)
+}
+
+// DefaultDiscoveryFactory creates the Discovery of the OCI, local, kubernetes
+// and REST plugin discovery sources
+func DefaultDiscoveryFactory(pd configtypes.PluginDiscovery, options ...DiscoveryOptions) (Discovery, error) {
 	switch {
 	case pd.OCI != nil:
 		// Only the OCI Discovery currently supports a criteria
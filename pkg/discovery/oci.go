@@ -9,6 +9,8 @@ import (
 	"path/filepath"
 	"strconv"
 
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+
 	"github.com/vmware-tanzu/tanzu-cli/pkg/common"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/plugininventory"
 )
@@ -21,7 +23,7 @@ func NewOCIDiscovery(name, image string, options ...DiscoveryOptions) Discovery
 		option(opts)
 	}
 
-	discovery := newDBBackedOCIDiscovery(name, image)
+	discovery := newDBBackedOCIDiscovery(name, image, opts.CacheDir)
 	discovery.pluginCriteria = opts.PluginDiscoveryCriteria
 	discovery.useLocalCacheOnly = opts.UseLocalCacheOnly
 	// NOTE: the use of TEST_TANZU_CLI_USE_DB_CACHE_ONLY is for testing only
@@ -30,6 +32,7 @@ func NewOCIDiscovery(name, image string, options ...DiscoveryOptions) Discovery
 	}
 	discovery.forceRefresh = opts.ForceRefresh
 	discovery.forceInvalidation = opts.ForceInvalidation
+	discovery.signaturePolicy = opts.SignaturePolicy
 
 	return discovery
 }
@@ -42,7 +45,7 @@ func NewOCIGroupDiscovery(name, image string, options ...DiscoveryOptions) Group
 		option(opts)
 	}
 
-	discovery := newDBBackedOCIDiscovery(name, image)
+	discovery := newDBBackedOCIDiscovery(name, image, opts.CacheDir)
 	discovery.groupCriteria = opts.GroupDiscoveryCriteria
 	discovery.useLocalCacheOnly = opts.UseLocalCacheOnly
 	// NOTE: the use of TEST_TANZU_CLI_USE_DB_CACHE_ONLY is for testing only
@@ -51,20 +54,24 @@ func NewOCIGroupDiscovery(name, image string, options ...DiscoveryOptions) Group
 	}
 	discovery.forceRefresh = opts.ForceRefresh
 	discovery.forceInvalidation = opts.ForceInvalidation
+	discovery.signaturePolicy = opts.SignaturePolicy
 
 	return discovery
 }
 
-func newDBBackedOCIDiscovery(name, image string) *DBBackedOCIDiscovery {
+func newDBBackedOCIDiscovery(name, image, cacheDir string) *DBBackedOCIDiscovery {
 	// The plugin inventory uses relative image URIs to be future-proof.
 	// Determine the image prefix from the main image.
 	// E.g., if the main image is at project.registry.vmware.com/tanzu-cli/plugins/plugin-inventory:latest
 	// then the image prefix should be project.registry.vmware.com/tanzu-cli/plugins/
 	imagePrefix := path.Dir(image)
 	// The data for the inventory is stored in the cache
-	pluginDataDir := filepath.Join(common.DefaultCacheDir, common.PluginInventoryDirName, name)
+	if cacheDir == "" {
+		cacheDir = common.DefaultCacheDir
+	}
+	pluginDataDir := filepath.Join(cacheDir, common.PluginInventoryDirName, name)
 
-	inventory := plugininventory.NewSQLiteInventory(filepath.Join(pluginDataDir, plugininventory.SQliteDBFileName), imagePrefix)
+	inventory := plugininventory.GetInventoryCache(filepath.Join(pluginDataDir, plugininventory.SQliteDBFileName), imagePrefix)
 	return &DBBackedOCIDiscovery{
 		name:          name,
 		image:         image,
@@ -72,3 +79,13 @@ func newDBBackedOCIDiscovery(name, image string) *DBBackedOCIDiscovery {
 		inventory:     inventory,
 	}
 }
+
+// GetInventoryCache returns the cache of the plugin inventory downloaded for the
+// discovery source, or nil if the discovery source has no plugin inventory.
+// The cache is shared with the discoveries of the same source.
+func GetInventoryCache(pd configtypes.PluginDiscovery) *plugininventory.InventoryCache {
+	if pd.OCI == nil {
+		return nil
+	}
+	return newDBBackedOCIDiscovery(pd.OCI.Name, pd.OCI.Image, "").getInventory()
+}
@@ -20,6 +20,7 @@ import (
 	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/cosignhelper/sigverifier"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/plugininventory"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/profiling"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/utils"
 	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
 )
@@ -49,14 +50,18 @@ type DBBackedOCIDiscovery struct {
 	// forceInvalidation enables to force the invalidation of the cache which will
 	// in turn trigger a full download of the inventory data
 	forceInvalidation bool
+	// signaturePolicy is the signature policy used to verify the inventory image,
+	// the one recorded for the discovery source if empty
+	signaturePolicy sigverifier.SignaturePolicy
 	// pluginDataDir is the location where the plugin data will be stored once
 	// extracted from the OCI image
 	pluginDataDir string
-	// inventory is the pluginInventory to be used by this discovery.
-	inventory plugininventory.PluginInventory
+	// inventory is the cache of the plugin inventory to be used by this discovery,
+	// shared with the other discoveries of the same inventory database.
+	inventory *plugininventory.InventoryCache
 }
 
-func (od *DBBackedOCIDiscovery) getInventory() plugininventory.PluginInventory {
+func (od *DBBackedOCIDiscovery) getInventory() *plugininventory.InventoryCache {
 	return od.inventory
 }
 
@@ -105,29 +110,20 @@ func (od *DBBackedOCIDiscovery) GetGroups() ([]*plugininventory.PluginGroup, err
 }
 
 func (od *DBBackedOCIDiscovery) listPluginsFromInventory() ([]Discovered, error) {
-	var pluginEntries []*plugininventory.PluginInventoryEntry
-	var err error
-
 	shouldIncludeHidden, _ := strconv.ParseBool(os.Getenv(constants.ConfigVariableIncludeDeactivatedPluginsForTesting))
-	if od.pluginCriteria == nil {
-		pluginEntries, err = od.getInventory().GetPlugins(&plugininventory.PluginInventoryFilter{
-			IncludeHidden: shouldIncludeHidden,
-		})
-		if err != nil {
-			return nil, err
-		}
-	} else {
-		pluginEntries, err = od.getInventory().GetPlugins(&plugininventory.PluginInventoryFilter{
-			Name:          od.pluginCriteria.Name,
-			Target:        od.pluginCriteria.Target,
-			Version:       od.pluginCriteria.Version,
-			OS:            od.pluginCriteria.OS,
-			Arch:          od.pluginCriteria.Arch,
-			IncludeHidden: shouldIncludeHidden,
-		})
-		if err != nil {
-			return nil, err
-		}
+	criteria := plugininventory.PluginCriteria{
+		IncludeHidden: shouldIncludeHidden,
+	}
+	if od.pluginCriteria != nil {
+		criteria.Name = od.pluginCriteria.Name
+		criteria.Target = od.pluginCriteria.Target
+		criteria.Version = od.pluginCriteria.Version
+		criteria.OS = od.pluginCriteria.OS
+		criteria.Arch = od.pluginCriteria.Arch
+	}
+	pluginEntries, err := od.getInventory().GetPlugins(criteria)
+	if err != nil {
+		return nil, err
 	}
 
 	var discoveredPlugins []Discovered
@@ -155,6 +151,9 @@ func (od *DBBackedOCIDiscovery) listPluginsFromInventory() ([]Discovered, error)
 			DiscoveryType:      common.DiscoveryTypeOCI,
 			Target:             entry.Target,
 			Status:             common.PluginStatusNotInstalled, // Not set yet
+			Vendor:             entry.Vendor,
+			Publisher:          entry.Publisher,
+			Advisories:         entry.Advisories,
 		}
 		discoveredPlugins = append(discoveredPlugins, plugin)
 	}
@@ -163,25 +162,23 @@ func (od *DBBackedOCIDiscovery) listPluginsFromInventory() ([]Discovered, error)
 
 func (od *DBBackedOCIDiscovery) listGroupsFromInventory() ([]*plugininventory.PluginGroup, error) {
 	shouldIncludeHidden, _ := strconv.ParseBool(os.Getenv(constants.ConfigVariableIncludeDeactivatedPluginsForTesting))
-
-	if od.groupCriteria == nil {
-		return od.getInventory().GetPluginGroups(plugininventory.PluginGroupFilter{
-			IncludeHidden: shouldIncludeHidden,
-		})
-	}
-
-	return od.getInventory().GetPluginGroups(plugininventory.PluginGroupFilter{
-		Vendor:        od.groupCriteria.Vendor,
-		Publisher:     od.groupCriteria.Publisher,
-		Name:          od.groupCriteria.Name,
-		Version:       od.groupCriteria.Version,
+	criteria := plugininventory.GroupCriteria{
 		IncludeHidden: shouldIncludeHidden,
-	})
+	}
+	if od.groupCriteria != nil {
+		criteria.Vendor = od.groupCriteria.Vendor
+		criteria.Publisher = od.groupCriteria.Publisher
+		criteria.Name = od.groupCriteria.Name
+		criteria.Version = od.groupCriteria.Version
+	}
+	return od.getInventory().GetGroups(criteria)
 }
 
 // fetchInventoryImage downloads the OCI image containing the information about the
 // inventory of this discovery and stores it in the cache directory.
 func (od *DBBackedOCIDiscovery) fetchInventoryImage() error {
+	defer profiling.Track(profiling.PhaseDiscovery)()
+
 	if !od.forceInvalidation && !od.forceRefresh && !od.cacheTTLExpired() {
 		// If we refreshed the inventory image recently, don't refresh again.
 		// The inventory image does not need to be up-to-date by the second.
@@ -193,12 +190,27 @@ func (od *DBBackedOCIDiscovery) fetchInventoryImage() error {
 		return nil
 	}
 
+	// Don't contact a discovery source which failed recently, so that every command does
+	// not wait for it, unless the refresh is explicitly requested.  Its cached plugin
+	// inventory, if any, is used until the end of its cooldown.
+	if !od.forceInvalidation && !od.forceRefresh {
+		if health := GetSourceHealth(od.image); health != nil {
+			if _, err := os.Stat(filepath.Join(od.pluginDataDir, plugininventory.SQliteDBFileName)); err == nil {
+				log.V(6).Infof("discovery source %q is unhealthy until %s, using its cached plugin inventory", od.name, health.RetryAfter.Format(time.RFC3339))
+				return nil
+			}
+			return errors.Errorf("discovery source %q is unavailable until %s: %s", od.name, health.RetryAfter.Format(time.RFC3339), health.Reason)
+		}
+	}
+
 	// check the cache to see if downloaded plugin inventory database is up-to-date or not
 	// by comparing the image digests
 	newCacheHashFileForInventoryImage, newCacheHashFileForMetadataImage, err := od.checkImageCache()
 	if err != nil {
+		markSourceUnhealthy(od.name, od.image, err)
 		return err
 	}
+	markSourceHealthy(od.image)
 
 	if newCacheHashFileForInventoryImage == "" && newCacheHashFileForMetadataImage == "" {
 		// The cache can be re-used. We are done.
@@ -210,7 +222,11 @@ func (od *DBBackedOCIDiscovery) fetchInventoryImage() error {
 	log.Infof("Reading plugin inventory for %q, this will take a few seconds.", od.image)
 
 	// Verify the inventory image signature before downloading the plugin inventory database
-	err = sigverifier.VerifyInventoryImageSignature(od.image)
+	signaturePolicy := od.signaturePolicy
+	if signaturePolicy == "" {
+		signaturePolicy = GetSourceSignaturePolicy(od.name)
+	}
+	signatureStatus, err := sigverifier.CheckInventoryImageSignature(od.image, signaturePolicy)
 	if err != nil {
 		return err
 	}
@@ -222,6 +238,8 @@ func (od *DBBackedOCIDiscovery) fetchInventoryImage() error {
 		return err
 	}
 
+	recordSourceSignature(od.image, signatureStatus)
+
 	// Now that the new DB has been downloaded, we can reset the TTL.
 	// We do this because it is possible that only the metadata digest has changed,
 	// so we must reset the TTL on the main digest file.
@@ -259,6 +277,14 @@ func (od *DBBackedOCIDiscovery) fetchInventoryImage() error {
 // metadata image to get the 'plugin_inventory_metadata.db' and update the 'plugin_inventory.db'
 // based on the 'plugin_inventory_metadata.db'
 func (od *DBBackedOCIDiscovery) downloadCentralRepositoryData() error {
+	// Prevent other CLI processes from updating the cache of this discovery at the same time.
+	// The lock file is kept outside the cache directory since that directory can be deleted.
+	lock, err := utils.LockFile(od.pluginDataDir)
+	if err != nil {
+		return err
+	}
+	defer lock.Unlock()
+
 	tempDir1, err := os.MkdirTemp("", "")
 	if err != nil {
 		return errors.Wrap(err, "unable to create temp directory")
@@ -272,7 +298,9 @@ func (od *DBBackedOCIDiscovery) downloadCentralRepositoryData() error {
 
 	// Download the central repo OCI image and save it to tempDir1
 	if err := carvelhelpers.DownloadImageAndSaveFilesToDir(od.image, tempDir1); err != nil {
-		return errors.Wrapf(err, "failed to download OCI image from discovery '%s'", od.Name())
+		err = errors.Wrapf(err, "failed to download OCI image from discovery '%s'", od.Name())
+		markSourceUnhealthy(od.name, od.image, err)
+		return err
 	}
 
 	err = od.setupPluginInventory(tempDir1, tempDir2)
@@ -302,7 +330,7 @@ func (od *DBBackedOCIDiscovery) setupCentralConfig(sourceDir string) {
 		}
 	} else {
 		// The file exists, copy it to the pluginDataDir
-		if err = utils.CopyFile(sourceCentralConfigPath, destCentralConfigPath); err != nil {
+		if err = utils.CopyFileAtomic(sourceCentralConfigPath, destCentralConfigPath); err != nil {
 			// Don't fail just log a warning, as the central config file is not critical.
 			log.V(6).Warningf("unable to copy central config file: %v", err)
 		}
@@ -324,8 +352,19 @@ func (od *DBBackedOCIDiscovery) setupPluginInventory(inventoryDir, metadataDir s
 		}
 	}
 
+	// Older plugin inventory databases were published without indexes.
+	// Queries still work without them, only slower, so don't fail.
+	if err := plugininventory.CreateIndexes(inventoryDBFilePath); err != nil {
+		log.V(6).Warningf("unable to create the indexes of the plugin inventory: %v", err)
+	}
+
 	// Copy the inventory database file from temp directory to pluginDataDir
-	return utils.CopyFile(inventoryDBFilePath, filepath.Join(od.pluginDataDir, plugininventory.SQliteDBFileName))
+	if err := utils.CopyFileAtomic(inventoryDBFilePath, filepath.Join(od.pluginDataDir, plugininventory.SQliteDBFileName)); err != nil {
+		return err
+	}
+	// The content of the inventory was read from the previous database
+	od.getInventory().Invalidate()
+	return nil
 }
 
 // checkImageCache will get the plugin inventory image digest as well as
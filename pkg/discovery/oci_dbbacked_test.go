@@ -57,6 +57,9 @@ func (stub *stubInventory) UpdatePluginActivationState(_ *plugininventory.Plugin
 func (stub *stubInventory) UpdatePluginGroupActivationState(_ *plugininventory.PluginGroup) error {
 	return nil
 }
+func (stub *stubInventory) InsertPluginAdvisory(_ *plugininventory.PluginAdvisory) error {
+	return nil
+}
 
 var _ = Describe("Unit tests for DB-backed OCI discovery", func() {
 	var (
@@ -95,7 +98,7 @@ var _ = Describe("Unit tests for DB-backed OCI discovery", func() {
 
 				// Inject the stub inventory and data dir
 				dbDiscovery.pluginDataDir = tmpDir
-				dbDiscovery.inventory = &stubInventory{}
+				dbDiscovery.inventory = plugininventory.NewInventoryCache(&stubInventory{})
 
 				plugins, err := dbDiscovery.listPluginsFromInventory()
 				Expect(plugins).To(BeNil())
@@ -113,7 +116,7 @@ var _ = Describe("Unit tests for DB-backed OCI discovery", func() {
 
 				// Inject the stub inventory and data dir
 				dbDiscovery.pluginDataDir = tmpDir
-				dbDiscovery.inventory = &stubInventory{}
+				dbDiscovery.inventory = plugininventory.NewInventoryCache(&stubInventory{})
 
 				err = os.Setenv(constants.ConfigVariableIncludeDeactivatedPluginsForTesting, "true")
 				defer os.Unsetenv(constants.ConfigVariableIncludeDeactivatedPluginsForTesting)
@@ -151,7 +154,7 @@ var _ = Describe("Unit tests for DB-backed OCI discovery", func() {
 
 				// Inject the stub inventory and data dir
 				dbDiscovery.pluginDataDir = tmpDir
-				dbDiscovery.inventory = &stubInventory{}
+				dbDiscovery.inventory = plugininventory.NewInventoryCache(&stubInventory{})
 
 				plugins, err := dbDiscovery.listPluginsFromInventory()
 				Expect(plugins).To(BeNil())
@@ -181,7 +184,7 @@ var _ = Describe("Unit tests for DB-backed OCI discovery", func() {
 
 				// Inject the stub inventory and data dir
 				dbDiscovery.pluginDataDir = tmpDir
-				dbDiscovery.inventory = &stubInventory{}
+				dbDiscovery.inventory = plugininventory.NewInventoryCache(&stubInventory{})
 
 				err = os.Setenv(constants.ConfigVariableIncludeDeactivatedPluginsForTesting, "true")
 				defer os.Unsetenv(constants.ConfigVariableIncludeDeactivatedPluginsForTesting)
@@ -232,7 +235,7 @@ var _ = Describe("Unit tests for DB-backed OCI discovery", func() {
 
 				// Inject the stub inventory and data dir
 				dbDiscovery.pluginDataDir = tmpDir
-				dbDiscovery.inventory = &stubInventory{}
+				dbDiscovery.inventory = plugininventory.NewInventoryCache(&stubInventory{})
 
 				groups, err := dbDiscovery.listGroupsFromInventory()
 				Expect(groups).To(BeNil())
@@ -250,7 +253,7 @@ var _ = Describe("Unit tests for DB-backed OCI discovery", func() {
 
 				// Inject the stub inventory and data dir
 				dbDiscovery.pluginDataDir = tmpDir
-				dbDiscovery.inventory = &stubInventory{}
+				dbDiscovery.inventory = plugininventory.NewInventoryCache(&stubInventory{})
 
 				err = os.Setenv(constants.ConfigVariableIncludeDeactivatedPluginsForTesting, "true")
 				defer os.Unsetenv(constants.ConfigVariableIncludeDeactivatedPluginsForTesting)
@@ -284,7 +287,7 @@ var _ = Describe("Unit tests for DB-backed OCI discovery", func() {
 
 				// Inject the stub inventory and data dir
 				dbDiscovery.pluginDataDir = tmpDir
-				dbDiscovery.inventory = &stubInventory{}
+				dbDiscovery.inventory = plugininventory.NewInventoryCache(&stubInventory{})
 
 				groups, err := dbDiscovery.listGroupsFromInventory()
 				Expect(groups).To(BeNil())
@@ -310,7 +313,7 @@ var _ = Describe("Unit tests for DB-backed OCI discovery", func() {
 
 				// Inject the stub inventory and data dir
 				dbDiscovery.pluginDataDir = tmpDir
-				dbDiscovery.inventory = &stubInventory{}
+				dbDiscovery.inventory = plugininventory.NewInventoryCache(&stubInventory{})
 
 				err = os.Setenv(constants.ConfigVariableIncludeDeactivatedPluginsForTesting, "true")
 				defer os.Unsetenv(constants.ConfigVariableIncludeDeactivatedPluginsForTesting)
@@ -0,0 +1,97 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package discovery
+
+import (
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/datastore"
+)
+
+const (
+	// unhealthySourceKeyPrefix is the prefix of the data store keys recording the
+	// discovery sources which are unhealthy, followed by the image of the source
+	unhealthySourceKeyPrefix = "unhealthyDiscoverySource."
+
+	// defaultSourceCooldownSeconds is the default duration during which an
+	// unhealthy discovery source is not contacted
+	defaultSourceCooldownSeconds = 5 * 60
+	// rateLimitedSourceCooldownFactor is the factor applied to the cooldown of
+	// a discovery source which is unhealthy because it is rate limiting the CLI
+	rateLimitedSourceCooldownFactor = 3
+)
+
+// SourceHealth describes why a discovery source is unhealthy.
+// While a discovery source is unhealthy, it is not contacted:
+// its cached plugin inventory is used, if any, until its cooldown ends.
+type SourceHealth struct {
+	// Name is the name of the discovery source
+	Name string `yaml:"name"`
+	// Reason is the error which made the discovery source unhealthy
+	Reason string `yaml:"reason"`
+	// RateLimited is true if the discovery source is rate limiting the CLI
+	RateLimited bool `yaml:"rateLimited"`
+	// RetryAfter is the end of the cooldown of the discovery source
+	RetryAfter time.Time `yaml:"retryAfter"`
+}
+
+// GetSourceHealth returns the health of the discovery source with the specified
+// image, or nil if the discovery source is healthy.
+func GetSourceHealth(image string) *SourceHealth {
+	var health SourceHealth
+	// No error is returned if the data store does not exist yet, in which case
+	// no end of cooldown is read
+	if err := datastore.GetDataStoreValue(unhealthySourceKeyPrefix+image, &health); err != nil || health.RetryAfter.IsZero() {
+		return nil
+	}
+	return &health
+}
+
+// getSourceCooldown returns the duration during which an unhealthy discovery source is not contacted
+func getSourceCooldown() time.Duration {
+	cooldown := defaultSourceCooldownSeconds
+	if seconds, err := strconv.Atoi(os.Getenv(constants.ConfigVariableDiscoverySourceCooldownSeconds)); err == nil && seconds >= 0 {
+		cooldown = seconds
+	}
+	return time.Duration(cooldown) * time.Second
+}
+
+// markSourceUnhealthy records that the discovery source is unhealthy
+// because of the specified error, for the duration of its cooldown
+func markSourceUnhealthy(name, image string, err error) {
+	cooldown := getSourceCooldown()
+	if cooldown == 0 {
+		return
+	}
+	rateLimited := isRateLimitedError(err)
+	if rateLimited {
+		cooldown *= rateLimitedSourceCooldownFactor
+	}
+	_ = datastore.SetDataStoreValueWithTTL(unhealthySourceKeyPrefix+image, SourceHealth{
+		Name:        name,
+		Reason:      err.Error(),
+		RateLimited: rateLimited,
+		RetryAfter:  time.Now().Add(cooldown),
+	}, cooldown)
+}
+
+// markSourceHealthy records that the discovery source is healthy again
+func markSourceHealthy(image string) {
+	if GetSourceHealth(image) != nil {
+		_ = datastore.DeleteDataStoreValue(unhealthySourceKeyPrefix + image)
+	}
+}
+
+// isRateLimitedError returns true if the error was returned by a registry
+// which is rate limiting the requests of the CLI
+func isRateLimitedError(err error) bool {
+	msg := err.Error()
+	return strings.Contains(msg, "TOOMANYREQUESTS") ||
+		strings.Contains(msg, strconv.Itoa(http.StatusTooManyRequests)+" "+http.StatusText(http.StatusTooManyRequests))
+}
@@ -0,0 +1,79 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package discovery
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/plugininventory"
+)
+
+var _ = Describe("Discovery source health", func() {
+	const image = "example.com/test/image:latest"
+	var tmpDir string
+
+	BeforeEach(func() {
+		var err error
+		tmpDir, err = os.MkdirTemp("", "source-health")
+		Expect(err).ToNot(HaveOccurred())
+		os.Setenv("TEST_CUSTOM_DATA_STORE_FILE", filepath.Join(tmpDir, "data-store.yaml"))
+	})
+
+	AfterEach(func() {
+		os.Unsetenv("TEST_CUSTOM_DATA_STORE_FILE")
+		os.Unsetenv(constants.ConfigVariableDiscoverySourceCooldownSeconds)
+		os.RemoveAll(tmpDir)
+	})
+
+	It("should record an unhealthy source until it is healthy again", func() {
+		Expect(GetSourceHealth(image)).To(BeNil())
+
+		markSourceUnhealthy("test", image, errors.New("connection refused"))
+		health := GetSourceHealth(image)
+		Expect(health).ToNot(BeNil())
+		Expect(health.Name).To(Equal("test"))
+		Expect(health.Reason).To(Equal("connection refused"))
+		Expect(health.RateLimited).To(BeFalse())
+		Expect(health.RetryAfter).To(BeTemporally("~", time.Now().Add(defaultSourceCooldownSeconds*time.Second), time.Minute))
+
+		markSourceHealthy(image)
+		Expect(GetSourceHealth(image)).To(BeNil())
+	})
+
+	It("should extend the cooldown of a rate limiting source", func() {
+		markSourceUnhealthy("test", image, errors.New("GET https://example.com/v2/: TOOMANYREQUESTS: too many requests"))
+		health := GetSourceHealth(image)
+		Expect(health).ToNot(BeNil())
+		Expect(health.RateLimited).To(BeTrue())
+		Expect(health.RetryAfter).To(BeTemporally("~", time.Now().Add(rateLimitedSourceCooldownFactor*defaultSourceCooldownSeconds*time.Second), time.Minute))
+	})
+
+	It("should not record an unhealthy source when the cooldown is disabled", func() {
+		os.Setenv(constants.ConfigVariableDiscoverySourceCooldownSeconds, "0")
+		markSourceUnhealthy("test", image, errors.New("connection refused"))
+		Expect(GetSourceHealth(image)).To(BeNil())
+	})
+
+	It("should not contact an unhealthy source", func() {
+		markSourceUnhealthy("test", image, errors.New("connection refused"))
+		dbDiscovery := newDBBackedOCIDiscovery("test", image, "")
+		dbDiscovery.pluginDataDir = tmpDir
+
+		err := dbDiscovery.fetchInventoryImage()
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring(`discovery source "test" is unavailable until`))
+		Expect(err.Error()).To(ContainSubstring("connection refused"))
+
+		// The cached plugin inventory is used, if any
+		Expect(os.WriteFile(filepath.Join(tmpDir, plugininventory.SQliteDBFileName), nil, 0600)).To(Succeed())
+		Expect(dbDiscovery.fetchInventoryImage()).To(Succeed())
+	})
+})
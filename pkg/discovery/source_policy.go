@@ -0,0 +1,90 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package discovery
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/cosignhelper/sigverifier"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/datastore"
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
+)
+
+// sourceSignaturePolicyKeyPrefix is the prefix of the data store keys recording the
+// signature policy of the discovery sources, followed by the name of the source.
+// The policy is recorded by name so that it is kept when the image of the source changes.
+const sourceSignaturePolicyKeyPrefix = "discoverySourceSignaturePolicy."
+
+// GetSourceSignaturePolicy returns the signature policy of the discovery source with
+// the specified name.  The signature is required if no valid policy is recorded.
+func GetSourceSignaturePolicy(name string) sigverifier.SignaturePolicy {
+	var value string
+	if err := datastore.GetDataStoreValue(sourceSignaturePolicyKeyPrefix+name, &value); err != nil {
+		return sigverifier.SignaturePolicyRequire
+	}
+	policy, err := sigverifier.ParseSignaturePolicy(value)
+	if err != nil {
+		log.Warningf("ignoring the signature policy of discovery source %q: %v", name, err)
+		return sigverifier.SignaturePolicyRequire
+	}
+	return policy
+}
+
+// SetSourceSignaturePolicy records the signature policy of the discovery source with the specified name
+func SetSourceSignaturePolicy(name string, policy sigverifier.SignaturePolicy) error {
+	if policy == sigverifier.SignaturePolicyRequire {
+		// The default policy does not need to be recorded
+		return DeleteSourceSignaturePolicy(name)
+	}
+	return datastore.SetDataStoreValue(sourceSignaturePolicyKeyPrefix+name, string(policy))
+}
+
+// DeleteSourceSignaturePolicy removes the signature policy of the discovery source with
+// the specified name, which restores the default policy requiring the signature
+func DeleteSourceSignaturePolicy(name string) error {
+	var value string
+	if err := datastore.GetDataStoreValue(sourceSignaturePolicyKeyPrefix+name, &value); err != nil || value == "" {
+		// No policy is recorded
+		return nil
+	}
+	return datastore.DeleteDataStoreValue(sourceSignaturePolicyKeyPrefix + name)
+}
+
+// CheckSourceSignature verifies, before installing plugins from the discovery source with
+// the specified name and image, that the signature of its cached plugin inventory was
+// verified as per its signature policy.  If the plugin inventory was cached under a more
+// permissive policy, its signature is verified again.  A warning is printed if the
+// plugins are installed from a plugin inventory whose signature was not verified.
+func CheckSourceSignature(name, image string) error {
+	policy := GetSourceSignaturePolicy(name)
+
+	var status sigverifier.SignatureStatus
+	_ = datastore.GetDataStoreValue(sourceSignatureKeyPrefix+image, &status)
+
+	if policy == sigverifier.SignaturePolicyRequire &&
+		(status == sigverifier.SignatureStatusUnverified ||
+			status == sigverifier.SignatureStatusSkipped && !sigverifier.IsSignatureVerificationSkipped(image)) {
+		var err error
+		if status, err = sigverifier.CheckInventoryImageSignature(image, policy); err != nil {
+			return err
+		}
+		recordSourceSignature(image, status)
+	}
+
+	// An unknown status comes from a plugin inventory cached by an older CLI,
+	// which always required the signature
+	if status == sigverifier.SignatureStatusVerified || status == "" {
+		return nil
+	}
+	// Print the message directly to stderr without using the log library
+	// to make sure the user sees the warning even if the logs are disabled
+	if skip, _ := strconv.ParseBool(os.Getenv(constants.SuppressSkipSignatureVerificationWarning)); !skip {
+		msg := fmt.Sprintf("Installing from discovery source %q whose plugin inventory signature is %s", name, status)
+		fmt.Fprintf(os.Stderr, "%s%s\n", log.GetLogTypeIndicator(log.LogTypeWARN), msg)
+	}
+	return nil
+}
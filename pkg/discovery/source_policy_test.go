@@ -0,0 +1,77 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package discovery
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/cosignhelper/sigverifier"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/datastore"
+)
+
+var _ = Describe("Discovery source signature policy", func() {
+	const (
+		name  = "test-discovery"
+		image = "example.com/test/image:latest"
+	)
+	var tmpDir string
+
+	BeforeEach(func() {
+		var err error
+		tmpDir, err = os.MkdirTemp("", "source-policy")
+		Expect(err).ToNot(HaveOccurred())
+		os.Setenv("TEST_CUSTOM_DATA_STORE_FILE", filepath.Join(tmpDir, "data-store.yaml"))
+		os.Setenv(constants.SuppressSkipSignatureVerificationWarning, "true")
+	})
+
+	AfterEach(func() {
+		os.Unsetenv("TEST_CUSTOM_DATA_STORE_FILE")
+		os.Unsetenv(constants.SuppressSkipSignatureVerificationWarning)
+		os.RemoveAll(tmpDir)
+	})
+
+	It("should require the signature of a discovery source without a policy", func() {
+		Expect(GetSourceSignaturePolicy(name)).To(Equal(sigverifier.SignaturePolicyRequire))
+		Expect(DeleteSourceSignaturePolicy(name)).To(Succeed())
+		Expect(SetSourceSignaturePolicy(name, sigverifier.SignaturePolicyRequire)).To(Succeed())
+	})
+
+	It("should keep the policy of a discovery source by name", func() {
+		Expect(SetSourceSignaturePolicy(name, sigverifier.SignaturePolicyWarn)).To(Succeed())
+		Expect(GetSourceSignaturePolicy(name)).To(Equal(sigverifier.SignaturePolicyWarn))
+		Expect(GetSourceSignaturePolicy("other")).To(Equal(sigverifier.SignaturePolicyRequire))
+
+		// The default policy is not recorded
+		Expect(SetSourceSignaturePolicy(name, sigverifier.SignaturePolicyRequire)).To(Succeed())
+		var value string
+		Expect(datastore.GetDataStoreValue(sourceSignaturePolicyKeyPrefix+name, &value)).ToNot(Succeed())
+	})
+
+	It("should require the signature when the recorded policy is invalid", func() {
+		Expect(datastore.SetDataStoreValue(sourceSignaturePolicyKeyPrefix+name, "invalid")).To(Succeed())
+		Expect(GetSourceSignaturePolicy(name)).To(Equal(sigverifier.SignaturePolicyRequire))
+	})
+
+	It("should allow installing from a discovery source verified as per its policy", func() {
+		recordSourceSignature(image, sigverifier.SignatureStatusVerified)
+		Expect(CheckSourceSignature(name, image)).To(Succeed())
+
+		Expect(SetSourceSignaturePolicy(name, sigverifier.SignaturePolicyNone)).To(Succeed())
+		recordSourceSignature(image, sigverifier.SignatureStatusSkipped)
+		Expect(CheckSourceSignature(name, image)).To(Succeed())
+	})
+
+	It("should allow installing from a discovery source of the skip list", func() {
+		os.Setenv(constants.PluginDiscoveryImageSignatureVerificationSkipList, image)
+		defer os.Unsetenv(constants.PluginDiscoveryImageSignatureVerificationSkipList)
+
+		recordSourceSignature(image, sigverifier.SignatureStatusSkipped)
+		Expect(CheckSourceSignature(name, image)).To(Succeed())
+	})
+})
@@ -0,0 +1,96 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package discovery
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/common"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/cosignhelper/sigverifier"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/datastore"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/plugininventory"
+)
+
+// sourceSignatureKeyPrefix is the prefix of the data store keys recording the outcome
+// of the signature verification of the discovery sources, followed by the image of the source
+const sourceSignatureKeyPrefix = "discoverySourceSignature."
+
+// SourceStatus describes the state of the plugin inventory cache of a discovery source
+type SourceStatus struct {
+	// LastRefresh is the last time the discovery source was successfully contacted,
+	// or the zero time if it never was
+	LastRefresh time.Time
+	// InventoryUpdated is the time the cached plugin inventory was downloaded,
+	// or the zero time if there is no cached plugin inventory
+	InventoryUpdated time.Time
+	// Signature is the outcome of the signature verification of the cached plugin inventory,
+	// or empty if it is unknown
+	Signature sigverifier.SignatureStatus
+	// Health is the health of the discovery source, or nil if it is healthy
+	Health *SourceHealth
+}
+
+// GetSourceStatus returns the status of the discovery source with the specified name and image
+// from its plugin inventory cache and the data store.  It does not contact the discovery source.
+func GetSourceStatus(name, image string) *SourceStatus {
+	pluginDataDir := filepath.Join(common.DefaultCacheDir, common.PluginInventoryDirName, name)
+	status := &SourceStatus{
+		LastRefresh: getLastRefresh(pluginDataDir, image),
+		Health:      GetSourceHealth(image),
+	}
+
+	// The plugin inventory cache may belong to a previous image of the discovery source,
+	// in which case it is not relevant
+	if !status.LastRefresh.IsZero() {
+		if stat, err := os.Stat(filepath.Join(pluginDataDir, plugininventory.SQliteDBFileName)); err == nil {
+			status.InventoryUpdated = stat.ModTime()
+		}
+		_ = datastore.GetDataStoreValue(sourceSignatureKeyPrefix+image, &status.Signature)
+	}
+	return status
+}
+
+// getLastRefresh returns the modification time of the digest file of the plugin inventory
+// cache, which is reset every time the discovery source is successfully contacted,
+// or the zero time if the cache does not belong to the specified image
+func getLastRefresh(pluginDataDir, image string) time.Time {
+	matches, _ := filepath.Glob(filepath.Join(pluginDataDir, "digest.*"))
+	if len(matches) != 1 {
+		return time.Time{}
+	}
+	file, err := os.Open(matches[0])
+	if err != nil {
+		return time.Time{}
+	}
+	defer file.Close()
+
+	// The digest file contains the image of the discovery source
+	scanner := bufio.NewScanner(file)
+	if !scanner.Scan() || scanner.Text() != image {
+		return time.Time{}
+	}
+	stat, err := file.Stat()
+	if err != nil {
+		return time.Time{}
+	}
+	return stat.ModTime()
+}
+
+// recordSourceSignature records the outcome of the signature verification of
+// the plugin inventory downloaded from the discovery source
+func recordSourceSignature(image string, status sigverifier.SignatureStatus) {
+	_ = datastore.SetDataStoreValue(sourceSignatureKeyPrefix+image, status)
+}
+
+// ResetSourceCache removes the plugin inventory cache of the discovery source with the
+// specified name and image, as well as its health and signature records, so that its
+// plugin inventory is downloaded again from scratch by the next refresh
+func ResetSourceCache(name, image string) error {
+	markSourceHealthy(image)
+	_ = datastore.DeleteDataStoreValue(sourceSignatureKeyPrefix + image)
+	return os.RemoveAll(filepath.Join(common.DefaultCacheDir, common.PluginInventoryDirName, name))
+}
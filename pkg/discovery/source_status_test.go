@@ -0,0 +1,80 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package discovery
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/common"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/cosignhelper/sigverifier"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/plugininventory"
+)
+
+var _ = Describe("Discovery source status", func() {
+	const (
+		name  = "test-discovery"
+		image = "example.com/test/image:latest"
+	)
+	var (
+		tmpDir          string
+		pluginDataDir   string
+		originalDataDir string
+	)
+
+	BeforeEach(func() {
+		var err error
+		tmpDir, err = os.MkdirTemp("", "source-status")
+		Expect(err).ToNot(HaveOccurred())
+		os.Setenv("TEST_CUSTOM_DATA_STORE_FILE", filepath.Join(tmpDir, "data-store.yaml"))
+		originalDataDir = common.DefaultCacheDir
+		common.DefaultCacheDir = tmpDir
+		pluginDataDir = filepath.Join(tmpDir, common.PluginInventoryDirName, name)
+		Expect(os.MkdirAll(pluginDataDir, 0755)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		common.DefaultCacheDir = originalDataDir
+		os.Unsetenv("TEST_CUSTOM_DATA_STORE_FILE")
+		os.RemoveAll(tmpDir)
+	})
+
+	It("should return an empty status for a discovery source which was never refreshed", func() {
+		status := GetSourceStatus(name, image)
+		Expect(status.LastRefresh.IsZero()).To(BeTrue())
+		Expect(status.InventoryUpdated.IsZero()).To(BeTrue())
+		Expect(status.Signature).To(BeEmpty())
+		Expect(status.Health).To(BeNil())
+	})
+
+	It("should return the status of the plugin inventory cache", func() {
+		lastRefresh := time.Now().Add(-time.Hour).Truncate(time.Second)
+		inventoryUpdated := time.Now().Add(-2 * time.Hour).Truncate(time.Second)
+		digestFile := filepath.Join(pluginDataDir, "digest.1234")
+		Expect(os.WriteFile(digestFile, []byte(image), 0600)).To(Succeed())
+		Expect(os.Chtimes(digestFile, lastRefresh, lastRefresh)).To(Succeed())
+		dbFile := filepath.Join(pluginDataDir, plugininventory.SQliteDBFileName)
+		Expect(os.WriteFile(dbFile, nil, 0600)).To(Succeed())
+		Expect(os.Chtimes(dbFile, inventoryUpdated, inventoryUpdated)).To(Succeed())
+		recordSourceSignature(image, sigverifier.SignatureStatusVerified)
+
+		status := GetSourceStatus(name, image)
+		Expect(status.LastRefresh).To(BeTemporally("==", lastRefresh))
+		Expect(status.InventoryUpdated).To(BeTemporally("==", inventoryUpdated))
+		Expect(status.Signature).To(Equal(sigverifier.SignatureStatusVerified))
+	})
+
+	It("should ignore the plugin inventory cache of a previous image", func() {
+		Expect(os.WriteFile(filepath.Join(pluginDataDir, "digest.1234"), []byte("example.com/previous/image:latest"), 0600)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(pluginDataDir, plugininventory.SQliteDBFileName), nil, 0600)).To(Succeed())
+
+		status := GetSourceStatus(name, image)
+		Expect(status.LastRefresh.IsZero()).To(BeTrue())
+		Expect(status.InventoryUpdated.IsZero()).To(BeTrue())
+	})
+})
@@ -7,6 +7,7 @@ import (
 	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
 
 	"github.com/vmware-tanzu/tanzu-cli/pkg/distribution"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/plugininventory"
 )
 
 // Discovered defines discovered plugin resource
@@ -61,6 +62,16 @@ type Discovered struct {
 
 	// Status is the installed/uninstalled status of the plugin.
 	Status string
+
+	// Vendor of the plugin, only known for plugins discovered from a plugin inventory.
+	Vendor string
+
+	// Publisher of the plugin, only known for plugins discovered from a plugin inventory.
+	Publisher string
+
+	// Advisories contains the security advisories affecting each version of the plugin,
+	// only known for plugins discovered from a plugin inventory.
+	Advisories map[string][]*plugininventory.PluginAdvisory
 }
 
 // DiscoveredSorter sorts discovered objects.
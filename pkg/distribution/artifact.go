@@ -6,16 +6,26 @@ package distribution
 import (
 	"github.com/pkg/errors"
 
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
+
 	cliv1alpha1 "github.com/vmware-tanzu/tanzu-cli/apis/cli/v1alpha1"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/artifact"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
 )
 
 // Artifact points to an individual plugin binary specific to a version and
 // platform.
 type Artifact struct {
 	// Image is a fully qualified OCI image for the plugin binary.
+	// Referencing the image by digest, i.e. `image@sha256:<digest>`, is
+	// preferred as it is immune to the image tag being overwritten.
 	Image string
 
+	// CompressedImage is a fully qualified OCI image for the plugin binary
+	// compressed using zstd. When set, it is preferred over Image as it is
+	// smaller to download.
+	CompressedImage string
+
 	// AssetURI is a URI of the plugin binary.
 	URI string
 
@@ -25,8 +35,22 @@ type Artifact struct {
 	// OS of the plugin binary in `GOOS` format.
 	OS string
 
-	// Arch of the plugin binary in `GOARCH` format.
+	// Arch of the plugin binary in `GOARCH` format, or "universal" for
+	// a binary that supports all the architectures of its OS.
 	Arch string
+
+	// MinCLIVersion is the minimum version of the Tanzu CLI required
+	// by the plugin binary, or an empty string if there is none.
+	MinCLIVersion string
+
+	// KubernetesVersions is the semantic version constraint of the Kubernetes
+	// versions supported by the plugin binary, e.g. ">=1.26.0, <1.30.0", or an
+	// empty string if the plugin binary does not depend on a Kubernetes version.
+	KubernetesVersions string
+
+	// PluginRuntimeVersion is the version of the Tanzu Plugin Runtime
+	// the plugin binary was built with, or an empty string if unknown.
+	PluginRuntimeVersion string
 }
 
 // ArtifactList contains an Artifact object for every supported platform of a
@@ -36,7 +60,9 @@ type ArtifactList []Artifact
 // Artifacts contains an artifact list for every supported version.
 type Artifacts map[string]ArtifactList
 
-// GetArtifact returns Artifact object
+// GetArtifact returns Artifact object.
+// If a universal binary is available for the specified os it is preferred
+// over the binary specific to the specified arch.
 func (aMap Artifacts) GetArtifact(version, os, arch string) (Artifact, error) {
 	err := errors.Errorf(
 		"could not find the artifact for version:%s, os:%s, arch:%s",
@@ -50,10 +76,20 @@ func (aMap Artifacts) GetArtifact(version, os, arch string) (Artifact, error) {
 		return Artifact{}, err
 	}
 
-	for _, a := range aList {
-		if a.OS == os && a.Arch == arch {
-			return a, nil
+	var found *Artifact
+	for i := range aList {
+		if aList[i].OS != os {
+			continue
 		}
+		if aList[i].Arch == cli.UniversalArch {
+			return aList[i], nil
+		}
+		if aList[i].Arch == arch && found == nil {
+			found = &aList[i]
+		}
+	}
+	if found != nil {
+		return *found, nil
 	}
 	return Artifact{}, err
 }
@@ -65,6 +101,15 @@ func (aMap Artifacts) Fetch(version, os, arch string) ([]byte, error) {
 		return nil, err
 	}
 
+	if a.CompressedImage != "" {
+		b, err := artifact.NewCompressedOCIArtifact(a.CompressedImage).Fetch()
+		if err == nil || a.Image == "" {
+			return b, err
+		}
+		// The compressed image is an optimization, e.g., it is not part of
+		// older air-gapped repositories, so fallback to the uncompressed image
+		log.V(6).Infof("unable to fetch the compressed plugin binary %q, fetching %q instead: %v", a.CompressedImage, a.Image, err)
+	}
 	if a.Image != "" {
 		return artifact.NewOCIArtifact(a.Image).Fetch()
 	}
@@ -129,7 +174,7 @@ func ArtifactFromK8sV1alpha1(a cliv1alpha1.Artifact) Artifact { //nolint:gocriti
 
 // ArtifactListFromK8sV1alpha1 returns ArtifactList from k8sV1alpha1
 func ArtifactListFromK8sV1alpha1(l cliv1alpha1.ArtifactList) ArtifactList {
-	aList := make(ArtifactList, len(l))
+	aList := make(ArtifactList, 0, len(l))
 	for _, a := range l {
 		aList = append(aList, ArtifactFromK8sV1alpha1(a))
 	}
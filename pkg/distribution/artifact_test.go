@@ -66,6 +66,23 @@ var _ = Describe("Unit tests for distribution", func() {
 			Expect(artifact.URI).To(Equal(expectedArtifact.URI))
 		})
 
+		var _ = It("prefers the universal binary when one is available", func() {
+			darwinAMD64 := Artifact{Image: "image-amd64", OS: "darwin", Arch: "amd64"}
+			darwinUniversal := Artifact{Image: "image-universal", OS: "darwin", Arch: "universal"}
+			universalArtifacts := Artifacts{"1.0.0": ArtifactList{darwinAMD64, darwinUniversal}}
+
+			artifact, err := universalArtifacts.GetArtifact("1.0.0", "darwin", "amd64")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(artifact).To(Equal(darwinUniversal))
+
+			artifact, err = universalArtifacts.GetArtifact("1.0.0", "darwin", "arm64")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(artifact).To(Equal(darwinUniversal))
+
+			_, err = universalArtifacts.GetArtifact("1.0.0", "linux", "amd64")
+			Expect(err).To(HaveOccurred())
+		})
+
 		var _ = It("when version does not exist in artifact keys", func() {
 			artifact, err := sampleArtifacts.GetArtifact("2.0.0", "", "")
 			expectedArtifact := Artifact{}
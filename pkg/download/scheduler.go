@@ -0,0 +1,140 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package download schedules the downloads of the CLI so that they share
+// a maximum number of parallel transfers and an optional bandwidth limit.
+package download
+
+import (
+	"context"
+	"io"
+	"os"
+	"strconv"
+	"sync"
+
+	"golang.org/x/time/rate"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
+)
+
+const (
+	// defaultMaxParallelTransfers is the default maximum number of parallel transfers
+	defaultMaxParallelTransfers = 4
+	// maxThrottledRead is the maximum number of bytes read at once from a throttled
+	// stream, which is also the burst allowed by the bandwidth limit
+	maxThrottledRead = 32 * 1024
+)
+
+var (
+	defaultScheduler     *Scheduler
+	defaultSchedulerOnce sync.Once
+)
+
+// Scheduler limits the number of transfers running in parallel and,
+// optionally, the bandwidth they use altogether
+type Scheduler struct {
+	transfers chan struct{}
+	limiter   *rate.Limiter
+}
+
+// NewScheduler creates a Scheduler allowing the specified number of parallel
+// transfers and, if bytesPerSecond is positive, limiting their bandwidth
+func NewScheduler(maxParallelTransfers, bytesPerSecond int) *Scheduler {
+	if maxParallelTransfers < 1 {
+		maxParallelTransfers = 1
+	}
+	s := &Scheduler{
+		transfers: make(chan struct{}, maxParallelTransfers),
+	}
+	if bytesPerSecond > 0 {
+		s.limiter = rate.NewLimiter(rate.Limit(bytesPerSecond), maxThrottledRead)
+	}
+	return s
+}
+
+// DefaultScheduler returns the Scheduler shared by all the downloads of the process.
+// It is configured by the TANZU_CLI_MAX_PARALLEL_DOWNLOADS and
+// TANZU_CLI_DOWNLOAD_BANDWIDTH_LIMIT_KBPS environment variables.
+func DefaultScheduler() *Scheduler {
+	defaultSchedulerOnce.Do(func() {
+		maxParallelTransfers := defaultMaxParallelTransfers
+		if n, err := strconv.Atoi(os.Getenv(constants.ConfigVariableMaxParallelDownloads)); err == nil && n > 0 {
+			maxParallelTransfers = n
+		}
+		bytesPerSecond := 0
+		if kbps, err := strconv.Atoi(os.Getenv(constants.ConfigVariableDownloadBandwidthLimitKBps)); err == nil && kbps > 0 {
+			bytesPerSecond = kbps * 1024
+		}
+		defaultScheduler = NewScheduler(maxParallelTransfers, bytesPerSecond)
+	})
+	return defaultScheduler
+}
+
+// MaxParallelTransfers returns the maximum number of transfers running in parallel
+func (s *Scheduler) MaxParallelTransfers() int {
+	return cap(s.transfers)
+}
+
+// Run runs the tasks, at most MaxParallelTransfers of them at the same time,
+// and returns their errors in the order of the tasks once they are all done
+func (s *Scheduler) Run(tasks ...func() error) []error {
+	errs := make([]error, len(tasks))
+	semaphore := make(chan struct{}, s.MaxParallelTransfers())
+	var wg sync.WaitGroup
+	for i := range tasks {
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func(i int) {
+			defer func() {
+				<-semaphore
+				wg.Done()
+			}()
+			errs[i] = tasks[i]()
+		}(i)
+	}
+	wg.Wait()
+	return errs
+}
+
+// StartTransfer waits until a transfer can start and returns the function
+// to call once it is done.  Transfers are distinct from the tasks of Run,
+// so a task can start transfers without exhausting them.
+func (s *Scheduler) StartTransfer(ctx context.Context) (func(), error) {
+	select {
+	case s.transfers <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	var once sync.Once
+	return func() {
+		once.Do(func() { <-s.transfers })
+	}, nil
+}
+
+// Throttle returns a reader which reads from r within the bandwidth limit, if any
+func (s *Scheduler) Throttle(ctx context.Context, r io.ReadCloser) io.ReadCloser {
+	if s.limiter == nil {
+		return r
+	}
+	return &throttledReader{ctx: ctx, ReadCloser: r, limiter: s.limiter}
+}
+
+type throttledReader struct {
+	io.ReadCloser
+	ctx     context.Context
+	limiter *rate.Limiter
+}
+
+// Read implements io.Reader
+func (r *throttledReader) Read(p []byte) (int, error) {
+	if len(p) > maxThrottledRead {
+		p = p[:maxThrottledRead]
+	}
+	n, err := r.ReadCloser.Read(p)
+	if n > 0 {
+		if waitErr := r.limiter.WaitN(r.ctx, n); waitErr != nil && err == nil {
+			err = waitErr
+		}
+	}
+	return n, err
+}
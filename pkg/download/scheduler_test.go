@@ -0,0 +1,96 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package download
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunLimitsParallelTasks(t *testing.T) {
+	s := NewScheduler(2, 0)
+
+	var mutex sync.Mutex
+	running, maxRunning := 0, 0
+	tasks := make([]func() error, 6)
+	for i := range tasks {
+		tasks[i] = func() error {
+			mutex.Lock()
+			running++
+			if running > maxRunning {
+				maxRunning = running
+			}
+			mutex.Unlock()
+
+			time.Sleep(10 * time.Millisecond)
+
+			mutex.Lock()
+			running--
+			mutex.Unlock()
+			if i == 3 {
+				return errors.New("task failed")
+			}
+			return nil
+		}
+	}
+
+	errs := s.Run(tasks...)
+	assert.Equal(t, 2, maxRunning)
+	assert.Len(t, errs, 6)
+	for i, err := range errs {
+		if i == 3 {
+			assert.EqualError(t, err, "task failed")
+		} else {
+			assert.Nil(t, err)
+		}
+	}
+}
+
+func TestStartTransfer(t *testing.T) {
+	s := NewScheduler(1, 0)
+	assert.Equal(t, 1, s.MaxParallelTransfers())
+
+	done, err := s.StartTransfer(context.Background())
+	assert.Nil(t, err)
+
+	// No other transfer can start until the first one is done
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = s.StartTransfer(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// Ending a transfer more than once only frees one transfer
+	done()
+	done()
+	done, err = s.StartTransfer(context.Background())
+	assert.Nil(t, err)
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = s.StartTransfer(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	done()
+}
+
+func TestThrottle(t *testing.T) {
+	data := bytes.Repeat([]byte("a"), 4*maxThrottledRead)
+	r := io.NopCloser(bytes.NewReader(data))
+
+	// Without bandwidth limit, the reader is not throttled
+	assert.Equal(t, r, NewScheduler(1, 0).Throttle(context.Background(), r))
+
+	// The first read uses the burst, the others wait for the bandwidth limit
+	s := NewScheduler(1, 8*maxThrottledRead)
+	start := time.Now()
+	b, err := io.ReadAll(s.Throttle(context.Background(), r))
+	assert.Nil(t, err)
+	assert.Equal(t, data, b)
+	assert.GreaterOrEqual(t, time.Since(start), 300*time.Millisecond)
+}
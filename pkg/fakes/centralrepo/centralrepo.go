@@ -0,0 +1,263 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package centralrepo implements an in-process test central repository of
+// plugins for unit tests, which does not require docker, imgpkg or sqlite3
+package centralrepo
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	gocontainerregistry "github.com/google/go-containerregistry/pkg/registry"
+	"github.com/pkg/errors"
+
+	ctlimg "github.com/vmware-tanzu/carvel-imgpkg/pkg/imgpkg/registry"
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/distribution"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/plugininventory"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/registry"
+)
+
+const (
+	// repoPath is the path of the images of the repository within the registry
+	repoPath = "tanzu-cli/plugins"
+	// inventoryImageName is the name of the plugin inventory image
+	inventoryImageName = "plugin-inventory"
+
+	defaultVendor    = "vmware"
+	defaultPublisher = "test"
+)
+
+// fakePluginScript is a minimal plugin binary answering the 'info' command
+const fakePluginScript = `#!/bin/bash
+
+# Minimally Viable Dummy Tanzu CLI 'Plugin'
+
+info() {
+   cat << EOF
+{
+  "name": "__NAME__",
+  "target": "__TARGET__",
+  "description": "__DESCRIPTION__",
+  "version": "__VERSION__",
+  "buildSHA": "01234567",
+  "group": "System",
+  "hidden": false,
+  "aliases": [],
+  "completionType": 0
+}
+EOF
+  exit 0
+}
+
+case "$1" in
+    info)  $1 "$@";;
+    *) cat << EOF
+Stub plugin "__NAME__" "__VERSION__" for "__TARGET__"
+EOF
+       ;;
+esac
+`
+
+// Plugin describes a plugin to publish to the test central repository
+type Plugin struct {
+	// Name of the plugin
+	Name string
+	// Target of the plugin
+	Target configtypes.Target
+	// Description of the plugin
+	Description string
+	// Vendor of the plugin, "vmware" if not set
+	Vendor string
+	// Publisher of the plugin, "test" if not set
+	Publisher string
+	// Hidden tells whether the plugin is hidden
+	Hidden bool
+	// Versions of the plugin to publish
+	Versions []string
+	// Platforms of the plugin binaries in the <os>/<arch> format,
+	// the platform running the tests if not set
+	Platforms []string
+}
+
+// Repository is an in-process test central repository served by
+// a registry listening on the loopback interface
+type Repository struct {
+	server   *httptest.Server
+	host     string
+	registry registry.Registry
+	workDir  string
+	plugins  []*plugininventory.PluginInventoryEntry
+	groups   []*plugininventory.PluginGroup
+}
+
+// New starts a test central repository.
+// Close must be called to stop the repository once it is no longer used.
+func New() (*Repository, error) {
+	server := httptest.NewServer(gocontainerregistry.New())
+	u, err := url.Parse(server.URL)
+	if err != nil {
+		server.Close()
+		return nil, err
+	}
+	reg, err := registry.New(&ctlimg.Opts{Anon: true, Insecure: true})
+	if err != nil {
+		server.Close()
+		return nil, err
+	}
+	workDir, err := os.MkdirTemp("", "test-central-repo")
+	if err != nil {
+		server.Close()
+		return nil, errors.Wrap(err, "unable to create temporary directory")
+	}
+	return &Repository{
+		server:   server,
+		host:     u.Host,
+		registry: reg,
+		workDir:  workDir,
+	}, nil
+}
+
+// Close stops the test central repository
+func (r *Repository) Close() {
+	r.server.Close()
+	os.RemoveAll(r.workDir)
+}
+
+// ImagePrefix returns the prefix of the images of the repository,
+// e.g. 127.0.0.1:45678/tanzu-cli/plugins
+func (r *Repository) ImagePrefix() string {
+	return r.host + "/" + repoPath
+}
+
+// AddPlugin publishes the binaries of the versions of the plugin to the repository.
+// The plugin is only part of the plugin inventory images published afterwards.
+func (r *Repository) AddPlugin(p *Plugin) error {
+	entry := &plugininventory.PluginInventoryEntry{
+		Name:        p.Name,
+		Target:      p.Target,
+		Description: p.Description,
+		Vendor:      p.Vendor,
+		Publisher:   p.Publisher,
+		Hidden:      p.Hidden,
+		Artifacts:   distribution.Artifacts{},
+	}
+	if entry.Vendor == "" {
+		entry.Vendor = defaultVendor
+	}
+	if entry.Publisher == "" {
+		entry.Publisher = defaultPublisher
+	}
+	if entry.Description == "" {
+		entry.Description = p.Name + " functionality"
+	}
+	platforms := p.Platforms
+	if len(platforms) == 0 {
+		platforms = []string{runtime.GOOS + "/" + runtime.GOARCH}
+	}
+
+	for _, version := range p.Versions {
+		for _, platform := range platforms {
+			osName, arch, found := strings.Cut(platform, "/")
+			if !found {
+				return errors.Errorf("invalid platform %q, expected <os>/<arch>", platform)
+			}
+			binary := FakePluginBinary(p.Name, string(p.Target), entry.Description, version)
+			// The image URIs of the plugin inventory are relative to the image prefix
+			uri := fmt.Sprintf("%s/%s/%s/%s/%s/%s:%s", entry.Vendor, entry.Publisher, osName, arch, p.Target, p.Name, version)
+			if err := r.pushFile(uri, "tanzu-"+p.Name, binary); err != nil {
+				return errors.Wrapf(err, "unable to publish plugin %q version %q for %s", p.Name, version, platform)
+			}
+			entry.Artifacts[version] = append(entry.Artifacts[version], distribution.Artifact{
+				Image:  uri,
+				Digest: fmt.Sprintf("%x", sha256.Sum256(binary)),
+				OS:     osName,
+				Arch:   arch,
+			})
+		}
+	}
+	r.plugins = append(r.plugins, entry)
+	return nil
+}
+
+// AddPluginGroup adds the plugin group to the plugin inventory images published afterwards
+func (r *Repository) AddPluginGroup(pg *plugininventory.PluginGroup) {
+	r.groups = append(r.groups, pg)
+}
+
+// PublishInventory publishes a plugin inventory image with the plugins and plugin groups
+// added so far, using the specified tag, and returns the reference of the image
+func (r *Repository) PublishInventory(tag string) (string, error) {
+	dir, err := os.MkdirTemp(r.workDir, "inventory")
+	if err != nil {
+		return "", errors.Wrap(err, "unable to create temporary directory")
+	}
+	dbFile := filepath.Join(dir, plugininventory.SQliteDBFileName)
+	inventory := plugininventory.NewSQLiteInventory(dbFile, r.ImagePrefix())
+	if err := inventory.CreateSchema(); err != nil {
+		return "", err
+	}
+	for _, p := range r.plugins {
+		if err := inventory.InsertPlugin(p); err != nil {
+			return "", err
+		}
+	}
+	for _, pg := range r.groups {
+		if err := inventory.InsertPluginGroup(pg, true); err != nil {
+			return "", err
+		}
+	}
+
+	image := fmt.Sprintf("%s/%s:%s", r.ImagePrefix(), inventoryImageName, tag)
+	if err := r.registry.PushImage(image, []string{dbFile}); err != nil {
+		return "", errors.Wrapf(err, "unable to publish the plugin inventory image %q", image)
+	}
+	return image, nil
+}
+
+// DiscoverySource returns the plugin discovery source for the plugin inventory image.
+// As the image is not signed, the tests must skip the verification of its signature,
+// e.g. with the discovery.WithSignaturePolicy(sigverifier.SignaturePolicyNone) option.
+func (r *Repository) DiscoverySource(name, image string) configtypes.PluginDiscovery {
+	return configtypes.PluginDiscovery{
+		OCI: &configtypes.OCIDiscovery{
+			Name:  name,
+			Image: image,
+		},
+	}
+}
+
+// pushFile publishes an image containing only the file with the specified
+// content to the repository, using the image URI relative to the image prefix
+func (r *Repository) pushFile(uri, fileName string, content []byte) error {
+	dir, err := os.MkdirTemp(r.workDir, "image")
+	if err != nil {
+		return errors.Wrap(err, "unable to create temporary directory")
+	}
+	defer os.RemoveAll(dir)
+
+	filePath := filepath.Join(dir, fileName)
+	if err := os.WriteFile(filePath, content, 0755); err != nil {
+		return err
+	}
+	return r.registry.PushImage(r.ImagePrefix()+"/"+uri, []string{filePath})
+}
+
+// FakePluginBinary returns the content of a fake plugin binary which
+// describes the plugin when invoked with the 'info' command
+func FakePluginBinary(name, target, description, version string) []byte {
+	return []byte(strings.NewReplacer(
+		"__NAME__", name,
+		"__TARGET__", target,
+		"__DESCRIPTION__", description,
+		"__VERSION__", version,
+	).Replace(fakePluginScript))
+}
@@ -0,0 +1,87 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package centralrepo
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/common"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/cosignhelper/sigverifier"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/discovery"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/plugininventory"
+)
+
+func TestRepository(t *testing.T) {
+	assert := assert.New(t)
+
+	origCacheDir := common.DefaultCacheDir
+	common.DefaultCacheDir = t.TempDir()
+	defer func() { common.DefaultCacheDir = origCacheDir }()
+
+	repo, err := New()
+	assert.Nil(err)
+	defer repo.Close()
+
+	assert.Nil(repo.AddPlugin(&Plugin{
+		Name:     "cluster",
+		Target:   configtypes.TargetK8s,
+		Versions: []string{"v1.0.0", "v1.1.0"},
+	}))
+	assert.Nil(repo.AddPlugin(&Plugin{
+		Name:      "feature",
+		Target:    configtypes.TargetGlobal,
+		Versions:  []string{"v0.1.0"},
+		Platforms: []string{"linux/amd64", "darwin/arm64"},
+	}))
+	repo.AddPluginGroup(&plugininventory.PluginGroup{
+		Vendor:      "vmware",
+		Publisher:   "test",
+		Name:        "default",
+		Description: "Default plugins",
+		Versions: map[string][]*plugininventory.PluginGroupPluginEntry{
+			"v1.0.0": {
+				{PluginIdentifier: plugininventory.PluginIdentifier{Name: "cluster", Target: configtypes.TargetK8s, Version: "v1.0.0"}},
+			},
+		},
+	})
+	assert.NotNil(repo.AddPlugin(&Plugin{Name: "invalid", Versions: []string{"v1.0.0"}, Platforms: []string{"linux"}}))
+
+	image, err := repo.PublishInventory("latest")
+	assert.Nil(err)
+	assert.Equal(repo.ImagePrefix()+"/plugin-inventory:latest", image)
+
+	source := repo.DiscoverySource("test", image)
+	// The inventory of the repository is not signed
+	pluginDiscovery := discovery.NewOCIDiscovery(source.OCI.Name, source.OCI.Image, discovery.WithSignaturePolicy(sigverifier.SignaturePolicyNone))
+	plugins, err := pluginDiscovery.List()
+	assert.Nil(err)
+	assert.Len(plugins, 2)
+
+	for i := range plugins {
+		switch plugins[i].Name {
+		case "cluster":
+			assert.Equal("v1.1.0", plugins[i].RecommendedVersion)
+			assert.ElementsMatch([]string{"v1.0.0", "v1.1.0"}, plugins[i].SupportedVersions)
+
+			// The binaries of the plugins can be downloaded from the repository
+			binary, err := plugins[i].Distribution.Fetch("v1.0.0", runtime.GOOS, runtime.GOARCH)
+			assert.Nil(err)
+			assert.Equal(FakePluginBinary("cluster", string(configtypes.TargetK8s), "cluster functionality", "v1.0.0"), binary)
+		case "feature":
+			assert.Equal("v0.1.0", plugins[i].RecommendedVersion)
+		default:
+			t.Errorf("unexpected plugin %q", plugins[i].Name)
+		}
+	}
+
+	groups, err := discovery.NewOCIGroupDiscovery(source.OCI.Name, source.OCI.Image).GetGroups()
+	assert.Nil(err)
+	assert.Len(groups, 1)
+	assert.Equal("default", groups[0].Name)
+}
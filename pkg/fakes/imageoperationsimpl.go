@@ -44,6 +44,18 @@ type ImageOperationsImpl struct {
 	downloadImageAndSaveFilesToDirReturnsOnCall map[int]struct {
 		result1 error
 	}
+	DownloadImageToOCILayoutStub        func(string, string) error
+	downloadImageToOCILayoutMutex       sync.RWMutex
+	downloadImageToOCILayoutArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	downloadImageToOCILayoutReturns struct {
+		result1 error
+	}
+	downloadImageToOCILayoutReturnsOnCall map[int]struct {
+		result1 error
+	}
 	GetFileDigestFromImageStub        func(string, string) (string, error)
 	getFileDigestFromImageMutex       sync.RWMutex
 	getFileDigestFromImageArgsForCall []struct {
@@ -86,6 +98,19 @@ type ImageOperationsImpl struct {
 		result2 string
 		result3 error
 	}
+	ListImageTagsStub        func(string) ([]string, error)
+	listImageTagsMutex       sync.RWMutex
+	listImageTagsArgsForCall []struct {
+		arg1 string
+	}
+	listImageTagsReturns struct {
+		result1 []string
+		result2 error
+	}
+	listImageTagsReturnsOnCall map[int]struct {
+		result1 []string
+		result2 error
+	}
 	PushImageStub        func(string, []string) error
 	pushImageMutex       sync.RWMutex
 	pushImageArgsForCall []struct {
@@ -109,6 +134,18 @@ type ImageOperationsImpl struct {
 	resolveImageReturnsOnCall map[int]struct {
 		result1 error
 	}
+	UploadImageFromOCILayoutStub        func(string, string) error
+	uploadImageFromOCILayoutMutex       sync.RWMutex
+	uploadImageFromOCILayoutArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	uploadImageFromOCILayoutReturns struct {
+		result1 error
+	}
+	uploadImageFromOCILayoutReturnsOnCall map[int]struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -299,6 +336,68 @@ func (fake *ImageOperationsImpl) DownloadImageAndSaveFilesToDirReturnsOnCall(i i
 	}{result1}
 }
 
+func (fake *ImageOperationsImpl) DownloadImageToOCILayout(arg1 string, arg2 string) error {
+	fake.downloadImageToOCILayoutMutex.Lock()
+	ret, specificReturn := fake.downloadImageToOCILayoutReturnsOnCall[len(fake.downloadImageToOCILayoutArgsForCall)]
+	fake.downloadImageToOCILayoutArgsForCall = append(fake.downloadImageToOCILayoutArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.DownloadImageToOCILayoutStub
+	fakeReturns := fake.downloadImageToOCILayoutReturns
+	fake.recordInvocation("DownloadImageToOCILayout", []interface{}{arg1, arg2})
+	fake.downloadImageToOCILayoutMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *ImageOperationsImpl) DownloadImageToOCILayoutCallCount() int {
+	fake.downloadImageToOCILayoutMutex.RLock()
+	defer fake.downloadImageToOCILayoutMutex.RUnlock()
+	return len(fake.downloadImageToOCILayoutArgsForCall)
+}
+
+func (fake *ImageOperationsImpl) DownloadImageToOCILayoutCalls(stub func(string, string) error) {
+	fake.downloadImageToOCILayoutMutex.Lock()
+	defer fake.downloadImageToOCILayoutMutex.Unlock()
+	fake.DownloadImageToOCILayoutStub = stub
+}
+
+func (fake *ImageOperationsImpl) DownloadImageToOCILayoutArgsForCall(i int) (string, string) {
+	fake.downloadImageToOCILayoutMutex.RLock()
+	defer fake.downloadImageToOCILayoutMutex.RUnlock()
+	argsForCall := fake.downloadImageToOCILayoutArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *ImageOperationsImpl) DownloadImageToOCILayoutReturns(result1 error) {
+	fake.downloadImageToOCILayoutMutex.Lock()
+	defer fake.downloadImageToOCILayoutMutex.Unlock()
+	fake.DownloadImageToOCILayoutStub = nil
+	fake.downloadImageToOCILayoutReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *ImageOperationsImpl) DownloadImageToOCILayoutReturnsOnCall(i int, result1 error) {
+	fake.downloadImageToOCILayoutMutex.Lock()
+	defer fake.downloadImageToOCILayoutMutex.Unlock()
+	fake.DownloadImageToOCILayoutStub = nil
+	if fake.downloadImageToOCILayoutReturnsOnCall == nil {
+		fake.downloadImageToOCILayoutReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.downloadImageToOCILayoutReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *ImageOperationsImpl) GetFileDigestFromImage(arg1 string, arg2 string) (string, error) {
 	fake.getFileDigestFromImageMutex.Lock()
 	ret, specificReturn := fake.getFileDigestFromImageReturnsOnCall[len(fake.getFileDigestFromImageArgsForCall)]
@@ -495,6 +594,70 @@ func (fake *ImageOperationsImpl) GetImageDigestReturnsOnCall(i int, result1 stri
 	}{result1, result2, result3}
 }
 
+func (fake *ImageOperationsImpl) ListImageTags(arg1 string) ([]string, error) {
+	fake.listImageTagsMutex.Lock()
+	ret, specificReturn := fake.listImageTagsReturnsOnCall[len(fake.listImageTagsArgsForCall)]
+	fake.listImageTagsArgsForCall = append(fake.listImageTagsArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.ListImageTagsStub
+	fakeReturns := fake.listImageTagsReturns
+	fake.recordInvocation("ListImageTags", []interface{}{arg1})
+	fake.listImageTagsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ImageOperationsImpl) ListImageTagsCallCount() int {
+	fake.listImageTagsMutex.RLock()
+	defer fake.listImageTagsMutex.RUnlock()
+	return len(fake.listImageTagsArgsForCall)
+}
+
+func (fake *ImageOperationsImpl) ListImageTagsCalls(stub func(string) ([]string, error)) {
+	fake.listImageTagsMutex.Lock()
+	defer fake.listImageTagsMutex.Unlock()
+	fake.ListImageTagsStub = stub
+}
+
+func (fake *ImageOperationsImpl) ListImageTagsArgsForCall(i int) string {
+	fake.listImageTagsMutex.RLock()
+	defer fake.listImageTagsMutex.RUnlock()
+	argsForCall := fake.listImageTagsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *ImageOperationsImpl) ListImageTagsReturns(result1 []string, result2 error) {
+	fake.listImageTagsMutex.Lock()
+	defer fake.listImageTagsMutex.Unlock()
+	fake.ListImageTagsStub = nil
+	fake.listImageTagsReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ImageOperationsImpl) ListImageTagsReturnsOnCall(i int, result1 []string, result2 error) {
+	fake.listImageTagsMutex.Lock()
+	defer fake.listImageTagsMutex.Unlock()
+	fake.ListImageTagsStub = nil
+	if fake.listImageTagsReturnsOnCall == nil {
+		fake.listImageTagsReturnsOnCall = make(map[int]struct {
+			result1 []string
+			result2 error
+		})
+	}
+	fake.listImageTagsReturnsOnCall[i] = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *ImageOperationsImpl) PushImage(arg1 string, arg2 []string) error {
 	var arg2Copy []string
 	if arg2 != nil {
@@ -623,6 +786,68 @@ func (fake *ImageOperationsImpl) ResolveImageReturnsOnCall(i int, result1 error)
 	}{result1}
 }
 
+func (fake *ImageOperationsImpl) UploadImageFromOCILayout(arg1 string, arg2 string) error {
+	fake.uploadImageFromOCILayoutMutex.Lock()
+	ret, specificReturn := fake.uploadImageFromOCILayoutReturnsOnCall[len(fake.uploadImageFromOCILayoutArgsForCall)]
+	fake.uploadImageFromOCILayoutArgsForCall = append(fake.uploadImageFromOCILayoutArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.UploadImageFromOCILayoutStub
+	fakeReturns := fake.uploadImageFromOCILayoutReturns
+	fake.recordInvocation("UploadImageFromOCILayout", []interface{}{arg1, arg2})
+	fake.uploadImageFromOCILayoutMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *ImageOperationsImpl) UploadImageFromOCILayoutCallCount() int {
+	fake.uploadImageFromOCILayoutMutex.RLock()
+	defer fake.uploadImageFromOCILayoutMutex.RUnlock()
+	return len(fake.uploadImageFromOCILayoutArgsForCall)
+}
+
+func (fake *ImageOperationsImpl) UploadImageFromOCILayoutCalls(stub func(string, string) error) {
+	fake.uploadImageFromOCILayoutMutex.Lock()
+	defer fake.uploadImageFromOCILayoutMutex.Unlock()
+	fake.UploadImageFromOCILayoutStub = stub
+}
+
+func (fake *ImageOperationsImpl) UploadImageFromOCILayoutArgsForCall(i int) (string, string) {
+	fake.uploadImageFromOCILayoutMutex.RLock()
+	defer fake.uploadImageFromOCILayoutMutex.RUnlock()
+	argsForCall := fake.uploadImageFromOCILayoutArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *ImageOperationsImpl) UploadImageFromOCILayoutReturns(result1 error) {
+	fake.uploadImageFromOCILayoutMutex.Lock()
+	defer fake.uploadImageFromOCILayoutMutex.Unlock()
+	fake.UploadImageFromOCILayoutStub = nil
+	fake.uploadImageFromOCILayoutReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *ImageOperationsImpl) UploadImageFromOCILayoutReturnsOnCall(i int, result1 error) {
+	fake.uploadImageFromOCILayoutMutex.Lock()
+	defer fake.uploadImageFromOCILayoutMutex.Unlock()
+	fake.UploadImageFromOCILayoutStub = nil
+	if fake.uploadImageFromOCILayoutReturnsOnCall == nil {
+		fake.uploadImageFromOCILayoutReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.uploadImageFromOCILayoutReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *ImageOperationsImpl) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -632,16 +857,22 @@ func (fake *ImageOperationsImpl) Invocations() map[string][][]interface{} {
 	defer fake.copyImageToTarMutex.RUnlock()
 	fake.downloadImageAndSaveFilesToDirMutex.RLock()
 	defer fake.downloadImageAndSaveFilesToDirMutex.RUnlock()
+	fake.downloadImageToOCILayoutMutex.RLock()
+	defer fake.downloadImageToOCILayoutMutex.RUnlock()
 	fake.getFileDigestFromImageMutex.RLock()
 	defer fake.getFileDigestFromImageMutex.RUnlock()
 	fake.getFilesMapFromImageMutex.RLock()
 	defer fake.getFilesMapFromImageMutex.RUnlock()
 	fake.getImageDigestMutex.RLock()
 	defer fake.getImageDigestMutex.RUnlock()
+	fake.listImageTagsMutex.RLock()
+	defer fake.listImageTagsMutex.RUnlock()
 	fake.pushImageMutex.RLock()
 	defer fake.pushImageMutex.RUnlock()
 	fake.resolveImageMutex.RLock()
 	defer fake.resolveImageMutex.RUnlock()
+	fake.uploadImageFromOCILayoutMutex.RLock()
+	defer fake.uploadImageFromOCILayoutMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
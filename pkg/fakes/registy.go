@@ -56,6 +56,18 @@ type Registry struct {
 	downloadImageReturnsOnCall map[int]struct {
 		result1 error
 	}
+	DownloadImageToOCILayoutStub        func(string, string) error
+	downloadImageToOCILayoutMutex       sync.RWMutex
+	downloadImageToOCILayoutArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	downloadImageToOCILayoutReturns struct {
+		result1 error
+	}
+	downloadImageToOCILayoutReturnsOnCall map[int]struct {
+		result1 error
+	}
 	GetFileStub        func(string, string) ([]byte, error)
 	getFileMutex       sync.RWMutex
 	getFileArgsForCall []struct {
@@ -134,6 +146,18 @@ type Registry struct {
 	resolveImageReturnsOnCall map[int]struct {
 		result1 error
 	}
+	UploadImageFromOCILayoutStub        func(string, string) error
+	uploadImageFromOCILayoutMutex       sync.RWMutex
+	uploadImageFromOCILayoutArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	uploadImageFromOCILayoutReturns struct {
+		result1 error
+	}
+	uploadImageFromOCILayoutReturnsOnCall map[int]struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -386,6 +410,68 @@ func (fake *Registry) DownloadImageReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *Registry) DownloadImageToOCILayout(arg1 string, arg2 string) error {
+	fake.downloadImageToOCILayoutMutex.Lock()
+	ret, specificReturn := fake.downloadImageToOCILayoutReturnsOnCall[len(fake.downloadImageToOCILayoutArgsForCall)]
+	fake.downloadImageToOCILayoutArgsForCall = append(fake.downloadImageToOCILayoutArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.DownloadImageToOCILayoutStub
+	fakeReturns := fake.downloadImageToOCILayoutReturns
+	fake.recordInvocation("DownloadImageToOCILayout", []interface{}{arg1, arg2})
+	fake.downloadImageToOCILayoutMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *Registry) DownloadImageToOCILayoutCallCount() int {
+	fake.downloadImageToOCILayoutMutex.RLock()
+	defer fake.downloadImageToOCILayoutMutex.RUnlock()
+	return len(fake.downloadImageToOCILayoutArgsForCall)
+}
+
+func (fake *Registry) DownloadImageToOCILayoutCalls(stub func(string, string) error) {
+	fake.downloadImageToOCILayoutMutex.Lock()
+	defer fake.downloadImageToOCILayoutMutex.Unlock()
+	fake.DownloadImageToOCILayoutStub = stub
+}
+
+func (fake *Registry) DownloadImageToOCILayoutArgsForCall(i int) (string, string) {
+	fake.downloadImageToOCILayoutMutex.RLock()
+	defer fake.downloadImageToOCILayoutMutex.RUnlock()
+	argsForCall := fake.downloadImageToOCILayoutArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *Registry) DownloadImageToOCILayoutReturns(result1 error) {
+	fake.downloadImageToOCILayoutMutex.Lock()
+	defer fake.downloadImageToOCILayoutMutex.Unlock()
+	fake.DownloadImageToOCILayoutStub = nil
+	fake.downloadImageToOCILayoutReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Registry) DownloadImageToOCILayoutReturnsOnCall(i int, result1 error) {
+	fake.downloadImageToOCILayoutMutex.Lock()
+	defer fake.downloadImageToOCILayoutMutex.Unlock()
+	fake.DownloadImageToOCILayoutStub = nil
+	if fake.downloadImageToOCILayoutReturnsOnCall == nil {
+		fake.downloadImageToOCILayoutReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.downloadImageToOCILayoutReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *Registry) GetFile(arg1 string, arg2 string) ([]byte, error) {
 	fake.getFileMutex.Lock()
 	ret, specificReturn := fake.getFileReturnsOnCall[len(fake.getFileArgsForCall)]
@@ -774,6 +860,68 @@ func (fake *Registry) ResolveImageReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *Registry) UploadImageFromOCILayout(arg1 string, arg2 string) error {
+	fake.uploadImageFromOCILayoutMutex.Lock()
+	ret, specificReturn := fake.uploadImageFromOCILayoutReturnsOnCall[len(fake.uploadImageFromOCILayoutArgsForCall)]
+	fake.uploadImageFromOCILayoutArgsForCall = append(fake.uploadImageFromOCILayoutArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.UploadImageFromOCILayoutStub
+	fakeReturns := fake.uploadImageFromOCILayoutReturns
+	fake.recordInvocation("UploadImageFromOCILayout", []interface{}{arg1, arg2})
+	fake.uploadImageFromOCILayoutMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *Registry) UploadImageFromOCILayoutCallCount() int {
+	fake.uploadImageFromOCILayoutMutex.RLock()
+	defer fake.uploadImageFromOCILayoutMutex.RUnlock()
+	return len(fake.uploadImageFromOCILayoutArgsForCall)
+}
+
+func (fake *Registry) UploadImageFromOCILayoutCalls(stub func(string, string) error) {
+	fake.uploadImageFromOCILayoutMutex.Lock()
+	defer fake.uploadImageFromOCILayoutMutex.Unlock()
+	fake.UploadImageFromOCILayoutStub = stub
+}
+
+func (fake *Registry) UploadImageFromOCILayoutArgsForCall(i int) (string, string) {
+	fake.uploadImageFromOCILayoutMutex.RLock()
+	defer fake.uploadImageFromOCILayoutMutex.RUnlock()
+	argsForCall := fake.uploadImageFromOCILayoutArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *Registry) UploadImageFromOCILayoutReturns(result1 error) {
+	fake.uploadImageFromOCILayoutMutex.Lock()
+	defer fake.uploadImageFromOCILayoutMutex.Unlock()
+	fake.UploadImageFromOCILayoutStub = nil
+	fake.uploadImageFromOCILayoutReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Registry) UploadImageFromOCILayoutReturnsOnCall(i int, result1 error) {
+	fake.uploadImageFromOCILayoutMutex.Lock()
+	defer fake.uploadImageFromOCILayoutMutex.Unlock()
+	fake.UploadImageFromOCILayoutStub = nil
+	if fake.uploadImageFromOCILayoutReturnsOnCall == nil {
+		fake.uploadImageFromOCILayoutReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.uploadImageFromOCILayoutReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *Registry) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -785,6 +933,8 @@ func (fake *Registry) Invocations() map[string][][]interface{} {
 	defer fake.downloadBundleMutex.RUnlock()
 	fake.downloadImageMutex.RLock()
 	defer fake.downloadImageMutex.RUnlock()
+	fake.downloadImageToOCILayoutMutex.RLock()
+	defer fake.downloadImageToOCILayoutMutex.RUnlock()
 	fake.getFileMutex.RLock()
 	defer fake.getFileMutex.RUnlock()
 	fake.getFilesMutex.RLock()
@@ -797,6 +947,8 @@ func (fake *Registry) Invocations() map[string][][]interface{} {
 	defer fake.pushImageMutex.RUnlock()
 	fake.resolveImageMutex.RLock()
 	defer fake.resolveImageMutex.RUnlock()
+	fake.uploadImageFromOCILayoutMutex.RLock()
+	defer fake.uploadImageFromOCILayoutMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -0,0 +1,9 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package fips reports whether the CLI runs in FIPS mode.
+//
+// The CLI runs in FIPS mode when built with GOEXPERIMENT=boringcrypto, in which
+// case all the digest, signature and TLS operations use the FIPS 140-2 validated
+// BoringCrypto module and TLS is restricted to FIPS-approved settings.
+package fips
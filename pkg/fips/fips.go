@@ -0,0 +1,11 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !boringcrypto
+
+package fips
+
+// Enabled returns true if the CLI runs in FIPS mode
+func Enabled() bool {
+	return false
+}
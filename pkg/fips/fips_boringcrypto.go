@@ -0,0 +1,17 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build boringcrypto
+
+package fips
+
+import (
+	"crypto/boring"
+	// Restrict all TLS configurations to FIPS-approved settings
+	_ "crypto/tls/fipsonly"
+)
+
+// Enabled returns true if the CLI runs in FIPS mode
+func Enabled() bool {
+	return boring.Enabled()
+}
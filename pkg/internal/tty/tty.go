@@ -0,0 +1,25 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package tty checks whether the CLI is used interactively, from a terminal,
+// so that the user can be prompted and notified.
+package tty
+
+import (
+	"os"
+
+	"golang.org/x/term"
+
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/component"
+)
+
+// CanPrompt returns true if the user can be prompted, i.e. if prompting is
+// enabled and stdin is attached to a terminal
+var CanPrompt = func() bool {
+	return component.IsTTYEnabled() && term.IsTerminal(int(os.Stdin.Fd()))
+}
+
+// IsOutputTerminal returns true if both stdout and stderr are attached to a terminal
+var IsOutputTerminal = func() bool {
+	return term.IsTerminal(int(os.Stdout.Fd())) && term.IsTerminal(int(os.Stderr.Fd()))
+}
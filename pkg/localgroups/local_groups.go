@@ -0,0 +1,171 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package localgroups manages the plugin groups defined locally by the user,
+// which allow installing a set of plugins without publishing a plugin group
+// to a plugin repository.
+package localgroups
+
+import (
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/common"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/utils"
+)
+
+// LocalGroupPrefix is the prefix identifying a local plugin group, e.g., "local:mygroup"
+const LocalGroupPrefix = "local:"
+
+// LocalGroupPlugin is a plugin of a local plugin group
+type LocalGroupPlugin struct {
+	Name string `json:"name" yaml:"name"`
+	// Target of the plugin, which can be omitted if the plugin name
+	// is only available for a single target
+	Target string `json:"target,omitempty" yaml:"target,omitempty"`
+	// Version of the plugin, the latest version being used if omitted
+	Version string `json:"version,omitempty" yaml:"version,omitempty"`
+}
+
+// LocalGroup is a plugin group defined locally by the user
+type LocalGroup struct {
+	Description string             `json:"description,omitempty" yaml:"description,omitempty"`
+	Plugins     []LocalGroupPlugin `json:"plugins" yaml:"plugins"`
+}
+
+type localGroupsContent struct {
+	Groups map[string]LocalGroup `yaml:"groups,omitempty"`
+}
+
+// ParseLocalGroupID returns the name of the local plugin group and true if
+// the group identifier refers to a local plugin group, e.g., "local:mygroup"
+func ParseLocalGroupID(groupID string) (string, bool) {
+	if !strings.HasPrefix(groupID, LocalGroupPrefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(groupID, LocalGroupPrefix), true
+}
+
+// GetLocalGroup returns the local plugin group of the specified name
+func GetLocalGroup(name string) (*LocalGroup, error) {
+	content, err := readContent()
+	if err != nil {
+		return nil, err
+	}
+	group, exists := content.Groups[name]
+	if !exists {
+		return nil, errors.Errorf("local plugin group %q cannot be found", name)
+	}
+	return &group, nil
+}
+
+// GetAllLocalGroups returns the local plugin groups, by name
+func GetAllLocalGroups() (map[string]LocalGroup, error) {
+	content, err := readContent()
+	if err != nil {
+		return nil, err
+	}
+	return content.Groups, nil
+}
+
+// GetLocalGroupNames returns the sorted names of the local plugin groups
+func GetLocalGroupNames() ([]string, error) {
+	content, err := readContent()
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(content.Groups))
+	for name := range content.Groups {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// SetLocalGroup creates or replaces the local plugin group of the specified name
+func SetLocalGroup(name string, group *LocalGroup) error {
+	if name == "" || strings.ContainsAny(name, ":/") {
+		return errors.Errorf("invalid local plugin group name %q", name)
+	}
+	if len(group.Plugins) == 0 {
+		return errors.Errorf("the local plugin group %q must contain at least one plugin", name)
+	}
+	for _, p := range group.Plugins {
+		if p.Name == "" {
+			return errors.Errorf("the plugins of the local plugin group %q must have a name", name)
+		}
+	}
+	return updateContent(func(content *localGroupsContent) error {
+		if content.Groups == nil {
+			content.Groups = make(map[string]LocalGroup)
+		}
+		content.Groups[name] = *group
+		return nil
+	})
+}
+
+// DeleteLocalGroup removes the local plugin group of the specified name
+func DeleteLocalGroup(name string) error {
+	return updateContent(func(content *localGroupsContent) error {
+		if _, exists := content.Groups[name]; !exists {
+			return errors.Errorf("local plugin group %q cannot be found", name)
+		}
+		delete(content.Groups, name)
+		return nil
+	})
+}
+
+// updateContent applies the update to the local plugin groups while holding
+// the lock of the local plugin groups file
+func updateContent(update func(*localGroupsContent) error) error {
+	path := getLocalGroupsPath()
+	lock, err := utils.LockFile(path)
+	if err != nil {
+		return err
+	}
+	defer lock.Unlock()
+
+	content, err := readContent()
+	if err != nil {
+		return err
+	}
+	if err := update(content); err != nil {
+		return err
+	}
+
+	b, err := yaml.Marshal(content)
+	if err != nil {
+		return errors.Wrap(err, "could not encode the local plugin groups")
+	}
+	return utils.WriteFileAtomic(path, b, 0600)
+}
+
+func readContent() (*localGroupsContent, error) {
+	content := &localGroupsContent{}
+	b, err := utils.ReadFile(getLocalGroupsPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return content, nil
+		}
+		return nil, err
+	}
+	if err := yaml.Unmarshal(b, content); err != nil {
+		return nil, errors.Wrap(err, "could not decode the local plugin groups file")
+	}
+	return content, nil
+}
+
+func getLocalGroupsPath() string {
+	// NOTE: TEST_CUSTOM_LOCAL_PLUGIN_GROUPS_FILE is only for test purpose
+	customFile := os.Getenv("TEST_CUSTOM_LOCAL_PLUGIN_GROUPS_FILE")
+	if customFile != "" {
+		return customFile
+	}
+
+	return common.CLIConfigFilePath(constants.LocalPluginGroupsFileName)
+}
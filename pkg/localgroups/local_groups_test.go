@@ -0,0 +1,74 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package localgroups
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
+)
+
+func TestLocalGroups(t *testing.T) {
+	assert := assert.New(t)
+
+	t.Setenv("TEST_CUSTOM_LOCAL_PLUGIN_GROUPS_FILE", filepath.Join(t.TempDir(), constants.LocalPluginGroupsFileName))
+
+	// No groups when the file does not exist
+	names, err := GetLocalGroupNames()
+	assert.Nil(err)
+	assert.Empty(names)
+	_, err = GetLocalGroup("team")
+	assert.ErrorContains(err, `local plugin group "team" cannot be found`)
+
+	team := &LocalGroup{
+		Description: "Plugins of the team",
+		Plugins: []LocalGroupPlugin{
+			{Name: "cluster", Target: "kubernetes", Version: "v1.0.0"},
+			{Name: "package"},
+		},
+	}
+	assert.Nil(SetLocalGroup("team", team))
+	assert.Nil(SetLocalGroup("ops", &LocalGroup{Plugins: []LocalGroupPlugin{{Name: "secret"}}}))
+
+	group, err := GetLocalGroup("team")
+	assert.Nil(err)
+	assert.Equal(team, group)
+
+	names, err = GetLocalGroupNames()
+	assert.Nil(err)
+	assert.Equal([]string{"ops", "team"}, names)
+
+	// Setting a group replaces it
+	assert.Nil(SetLocalGroup("team", &LocalGroup{Plugins: []LocalGroupPlugin{{Name: "apps"}}}))
+	group, err = GetLocalGroup("team")
+	assert.Nil(err)
+	assert.Equal([]LocalGroupPlugin{{Name: "apps"}}, group.Plugins)
+	assert.Empty(group.Description)
+
+	// Invalid groups are rejected
+	assert.NotNil(SetLocalGroup("my:group", team))
+	assert.NotNil(SetLocalGroup("empty", &LocalGroup{}))
+	assert.NotNil(SetLocalGroup("unnamed", &LocalGroup{Plugins: []LocalGroupPlugin{{Version: "v1.0.0"}}}))
+
+	assert.Nil(DeleteLocalGroup("team"))
+	assert.ErrorContains(DeleteLocalGroup("team"), `local plugin group "team" cannot be found`)
+	groups, err := GetAllLocalGroups()
+	assert.Nil(err)
+	assert.Equal(1, len(groups))
+	assert.Contains(groups, "ops")
+}
+
+func TestParseLocalGroupID(t *testing.T) {
+	assert := assert.New(t)
+
+	name, isLocal := ParseLocalGroupID("local:team")
+	assert.True(isLocal)
+	assert.Equal("team", name)
+
+	_, isLocal = ParseLocalGroupID("vmware-tkg/default:v1.0.0")
+	assert.False(isLocal)
+}
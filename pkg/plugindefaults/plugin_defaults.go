@@ -0,0 +1,175 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package plugindefaults manages the default flags and environment variables
+// configured by the user for each plugin, which the CLI injects when invoking the plugin.
+package plugindefaults
+
+import (
+	"os"
+	"sort"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/common"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/utils"
+)
+
+// PluginDefaults are the defaults used when invoking a plugin
+type PluginDefaults struct {
+	// Flags are passed to the plugin after the command path, before the flags
+	// specified by the user, which allows the user to override them
+	Flags []string `json:"flags,omitempty" yaml:"flags,omitempty"`
+	// Env are the environment variables set for the plugin, unless they
+	// are already set in the environment of the CLI
+	Env map[string]string `json:"env,omitempty" yaml:"env,omitempty"`
+}
+
+// IsEmpty returns true if there are no defaults
+func (d *PluginDefaults) IsEmpty() bool {
+	return len(d.Flags) == 0 && len(d.Env) == 0
+}
+
+type pluginDefaultsContent struct {
+	Plugins map[string]PluginDefaults `yaml:"plugins,omitempty"`
+}
+
+// GetAllPluginDefaults returns the defaults of all the plugins, by plugin name
+func GetAllPluginDefaults() (map[string]PluginDefaults, error) {
+	content, err := readContent()
+	if err != nil {
+		return nil, err
+	}
+	return content.Plugins, nil
+}
+
+// GetPluginDefaults returns the defaults of the plugin, which are empty
+// if none are configured
+func GetPluginDefaults(pluginName string) (PluginDefaults, error) {
+	content, err := readContent()
+	if err != nil {
+		return PluginDefaults{}, err
+	}
+	return content.Plugins[pluginName], nil
+}
+
+// GetPluginNames returns the sorted names of the plugins which have defaults
+func GetPluginNames() ([]string, error) {
+	content, err := readContent()
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(content.Plugins))
+	for name := range content.Plugins {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// SetPluginDefaultFlags replaces the default flags of the plugin
+func SetPluginDefaultFlags(pluginName string, flags []string) error {
+	return updatePluginDefaults(pluginName, func(d *PluginDefaults) {
+		d.Flags = flags
+	})
+}
+
+// SetPluginDefaultEnv sets default environment variables of the plugin,
+// keeping the other variables already set
+func SetPluginDefaultEnv(pluginName string, env map[string]string) error {
+	return updatePluginDefaults(pluginName, func(d *PluginDefaults) {
+		if d.Env == nil {
+			d.Env = make(map[string]string, len(env))
+		}
+		for k, v := range env {
+			d.Env[k] = v
+		}
+	})
+}
+
+// DeletePluginDefaultFlags removes the default flags of the plugin
+func DeletePluginDefaultFlags(pluginName string) error {
+	return updatePluginDefaults(pluginName, func(d *PluginDefaults) {
+		d.Flags = nil
+	})
+}
+
+// DeletePluginDefaultEnv removes the specified default environment variables of the plugin
+func DeletePluginDefaultEnv(pluginName string, keys []string) error {
+	return updatePluginDefaults(pluginName, func(d *PluginDefaults) {
+		for _, k := range keys {
+			delete(d.Env, k)
+		}
+	})
+}
+
+// DeletePluginDefaults removes all the defaults of the plugin
+func DeletePluginDefaults(pluginName string) error {
+	return updatePluginDefaults(pluginName, func(d *PluginDefaults) {
+		*d = PluginDefaults{}
+	})
+}
+
+// updatePluginDefaults applies the update to the defaults of the plugin while holding
+// the lock of the plugin defaults file.  Plugins left without defaults are removed.
+func updatePluginDefaults(pluginName string, update func(*PluginDefaults)) error {
+	if pluginName == "" {
+		return errors.New("the plugin name cannot be empty")
+	}
+
+	path := getPluginDefaultsPath()
+	lock, err := utils.LockFile(path)
+	if err != nil {
+		return err
+	}
+	defer lock.Unlock()
+
+	content, err := readContent()
+	if err != nil {
+		return err
+	}
+
+	defaults := content.Plugins[pluginName]
+	update(&defaults)
+	if defaults.IsEmpty() {
+		delete(content.Plugins, pluginName)
+	} else {
+		if content.Plugins == nil {
+			content.Plugins = make(map[string]PluginDefaults)
+		}
+		content.Plugins[pluginName] = defaults
+	}
+
+	b, err := yaml.Marshal(content)
+	if err != nil {
+		return errors.Wrap(err, "could not encode the plugin defaults")
+	}
+	return utils.WriteFileAtomic(path, b, 0600)
+}
+
+func readContent() (*pluginDefaultsContent, error) {
+	content := &pluginDefaultsContent{}
+	b, err := utils.ReadFile(getPluginDefaultsPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return content, nil
+		}
+		return nil, err
+	}
+	if err := yaml.Unmarshal(b, content); err != nil {
+		return nil, errors.Wrap(err, "could not decode the plugin defaults file")
+	}
+	return content, nil
+}
+
+func getPluginDefaultsPath() string {
+	// NOTE: TEST_CUSTOM_PLUGIN_DEFAULTS_FILE is only for test purpose
+	customFile := os.Getenv("TEST_CUSTOM_PLUGIN_DEFAULTS_FILE")
+	if customFile != "" {
+		return customFile
+	}
+
+	return common.CLIConfigFilePath(constants.PluginDefaultsFileName)
+}
@@ -0,0 +1,66 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package plugindefaults
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
+)
+
+func TestPluginDefaults(t *testing.T) {
+	assert := assert.New(t)
+
+	t.Setenv("TEST_CUSTOM_PLUGIN_DEFAULTS_FILE", filepath.Join(t.TempDir(), constants.PluginDefaultsFileName))
+
+	// No defaults when the file does not exist
+	defaults, err := GetPluginDefaults("cluster")
+	assert.Nil(err)
+	assert.True(defaults.IsEmpty())
+
+	err = SetPluginDefaultFlags("cluster", []string{"--kubeconfig=/tmp/kubeconfig"})
+	assert.Nil(err)
+	err = SetPluginDefaultEnv("cluster", map[string]string{"FOO": "foo", "BAR": "bar"})
+	assert.Nil(err)
+	err = SetPluginDefaultEnv("package", map[string]string{"BAZ": "baz"})
+	assert.Nil(err)
+
+	defaults, err = GetPluginDefaults("cluster")
+	assert.Nil(err)
+	assert.Equal([]string{"--kubeconfig=/tmp/kubeconfig"}, defaults.Flags)
+	assert.Equal(map[string]string{"FOO": "foo", "BAR": "bar"}, defaults.Env)
+
+	names, err := GetPluginNames()
+	assert.Nil(err)
+	assert.Equal([]string{"cluster", "package"}, names)
+
+	// Setting environment variables keeps the existing ones
+	err = SetPluginDefaultEnv("cluster", map[string]string{"FOO": "newfoo"})
+	assert.Nil(err)
+	defaults, err = GetPluginDefaults("cluster")
+	assert.Nil(err)
+	assert.Equal(map[string]string{"FOO": "newfoo", "BAR": "bar"}, defaults.Env)
+
+	err = DeletePluginDefaultEnv("cluster", []string{"FOO"})
+	assert.Nil(err)
+	err = DeletePluginDefaultFlags("cluster")
+	assert.Nil(err)
+	defaults, err = GetPluginDefaults("cluster")
+	assert.Nil(err)
+	assert.Empty(defaults.Flags)
+	assert.Equal(map[string]string{"BAR": "bar"}, defaults.Env)
+
+	// Plugins left without defaults are removed
+	err = DeletePluginDefaults("cluster")
+	assert.Nil(err)
+	all, err := GetAllPluginDefaults()
+	assert.Nil(err)
+	assert.Equal(map[string]PluginDefaults{"package": {Env: map[string]string{"BAZ": "baz"}}}, all)
+
+	err = SetPluginDefaultFlags("", []string{"--foo"})
+	assert.NotNil(err)
+}
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS "PluginAdvisories" (
+		"PluginName"         TEXT NOT NULL,
+		"Target"             TEXT NOT NULL,
+		"Version"            TEXT NOT NULL,
+		"AdvisoryID"         TEXT NOT NULL,
+		"Severity"           TEXT NOT NULL,
+		"Summary"            TEXT NOT NULL,
+		"URL"                TEXT NOT NULL,
+		"FixedVersion"       TEXT NOT NULL,
+		PRIMARY KEY("PluginName", "Target", "Version", "AdvisoryID")
+);
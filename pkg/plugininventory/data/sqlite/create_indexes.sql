@@ -0,0 +1,3 @@
+CREATE INDEX IF NOT EXISTS "PluginBinariesByNameTargetVersion" ON "PluginBinaries" ("PluginName", "Target", "Version");
+
+CREATE INDEX IF NOT EXISTS "PluginBinariesByVendorPublisher" ON "PluginBinaries" ("Vendor", "Publisher");
@@ -0,0 +1,248 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package plugininventory
+
+import (
+	"os"
+	"sync"
+	"time"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/distribution"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/utils"
+)
+
+// PluginCriteria specifies the plugins to look for in an InventoryCache.
+// An empty field matches any value.
+type PluginCriteria struct {
+	// Name of the plugin
+	Name string
+	// Target of the plugin
+	Target configtypes.Target
+	// Version of the plugin, cli.VersionLatest being the recommended version
+	Version string
+	// OS of the plugin binary in `GOOS` format
+	OS string
+	// Arch of the plugin binary in `GOARCH` format
+	Arch string
+	// IncludeHidden indicates if hidden plugins should be included
+	IncludeHidden bool
+}
+
+// GroupCriteria specifies the plugin groups to look for in an InventoryCache.
+// An empty field matches any value.
+type GroupCriteria struct {
+	// Vendor of the group.  Can contain '*' wildcards.
+	Vendor string
+	// Publisher of the group.  Can contain '*' wildcards.
+	Publisher string
+	// Name of the group.  Can contain '*' wildcards.
+	Name string
+	// Version of the group
+	Version string
+	// IncludeHidden indicates if hidden plugin groups should be included
+	IncludeHidden bool
+}
+
+// InventoryCache owns the plugin inventory database downloaded in the cache for
+// a discovery source and answers typed queries about its content.
+// The results of the queries are kept in memory until the database file changes,
+// so that the command, shell completion and plugin manager code running in the
+// same process can query the inventory repeatedly without reading it again.
+type InventoryCache struct {
+	// inventoryFile is the database file, empty if the inventory is not backed by a file
+	inventoryFile string
+	inventory     PluginInventory
+
+	mutex sync.Mutex
+	// modTime and size identify the content of the database file the results were read from
+	modTime time.Time
+	size    int64
+	plugins map[PluginCriteria][]*PluginInventoryEntry
+	groups  map[GroupCriteria][]*PluginGroup
+}
+
+var (
+	inventoryCachesMutex sync.Mutex
+	// inventoryCaches are the caches of the database files, shared within the process
+	inventoryCaches = map[string]*InventoryCache{}
+)
+
+// GetInventoryCache returns the cache of the SQLite plugin inventory database found at
+// 'inventoryFile', the same cache being returned for the same database file and prefix.
+func GetInventoryCache(inventoryFile, prefix string) *InventoryCache {
+	inventoryCachesMutex.Lock()
+	defer inventoryCachesMutex.Unlock()
+
+	key := prefix + "|" + inventoryFile
+	if c, found := inventoryCaches[key]; found {
+		return c
+	}
+	c := NewInventoryCache(NewSQLiteInventory(inventoryFile, prefix))
+	c.inventoryFile = inventoryFile
+	inventoryCaches[key] = c
+	return c
+}
+
+// NewInventoryCache returns a new cache of the specified plugin inventory.
+// As the cache cannot detect changes of such an inventory, Invalidate()
+// must be called once the inventory is modified.
+func NewInventoryCache(inventory PluginInventory) *InventoryCache {
+	return &InventoryCache{inventory: inventory}
+}
+
+// Inventory returns the plugin inventory, e.g., to modify it
+func (c *InventoryCache) Inventory() PluginInventory {
+	return c.inventory
+}
+
+// Invalidate drops the results kept in memory, which will be read again from the inventory
+func (c *InventoryCache) Invalidate() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.invalidate()
+}
+
+func (c *InventoryCache) invalidate() {
+	c.plugins = nil
+	c.groups = nil
+}
+
+// checkInventoryFile invalidates the cache if the database file was replaced or modified,
+// e.g., when the inventory was refreshed.  The caller must hold the mutex.
+func (c *InventoryCache) checkInventoryFile() {
+	if c.inventoryFile == "" {
+		return
+	}
+	var modTime time.Time
+	var size int64
+	if info, err := os.Stat(c.inventoryFile); err == nil {
+		modTime, size = info.ModTime(), info.Size()
+	}
+	if !modTime.Equal(c.modTime) || size != c.size {
+		c.invalidate()
+		c.modTime, c.size = modTime, size
+	}
+}
+
+// GetPlugins returns the plugins of the inventory matching the criteria
+func (c *InventoryCache) GetPlugins(criteria PluginCriteria) ([]*PluginInventoryEntry, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.checkInventoryFile()
+	plugins, found := c.plugins[criteria]
+	if !found {
+		var err error
+		plugins, err = c.inventory.GetPlugins(&PluginInventoryFilter{
+			Name:          criteria.Name,
+			Target:        criteria.Target,
+			Version:       criteria.Version,
+			OS:            criteria.OS,
+			Arch:          criteria.Arch,
+			IncludeHidden: criteria.IncludeHidden,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if c.plugins == nil {
+			c.plugins = map[PluginCriteria][]*PluginInventoryEntry{}
+		}
+		c.plugins[criteria] = plugins
+	}
+
+	// The callers own the returned entries, e.g., to merge the entries
+	// of different sources, so they must not share the cached ones
+	result := make([]*PluginInventoryEntry, 0, len(plugins))
+	for _, p := range plugins {
+		result = append(result, copyPluginInventoryEntry(p))
+	}
+	return result, nil
+}
+
+// GetGroups returns the plugin groups of the inventory matching the criteria
+func (c *InventoryCache) GetGroups(criteria GroupCriteria) ([]*PluginGroup, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.checkInventoryFile()
+	groups, found := c.groups[criteria]
+	if !found {
+		var err error
+		groups, err = c.inventory.GetPluginGroups(PluginGroupFilter{
+			Vendor:        criteria.Vendor,
+			Publisher:     criteria.Publisher,
+			Name:          criteria.Name,
+			Version:       criteria.Version,
+			IncludeHidden: criteria.IncludeHidden,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if c.groups == nil {
+			c.groups = map[GroupCriteria][]*PluginGroup{}
+		}
+		c.groups[criteria] = groups
+	}
+
+	result := make([]*PluginGroup, 0, len(groups))
+	for _, pg := range groups {
+		result = append(result, copyPluginGroup(pg))
+	}
+	return result, nil
+}
+
+// GetAllVersions returns the versions, sorted in ascending order, of the plugins
+// of the inventory matching the criteria, ignoring the version of the criteria
+func (c *InventoryCache) GetAllVersions(criteria PluginCriteria) ([]string, error) {
+	criteria.Version = ""
+	plugins, err := c.GetPlugins(criteria)
+	if err != nil {
+		return nil, err
+	}
+
+	encountered := map[string]bool{}
+	var versions []string
+	for _, p := range plugins {
+		for v := range p.Artifacts {
+			if !encountered[v] {
+				encountered[v] = true
+				versions = append(versions, v)
+			}
+		}
+	}
+	if err := utils.SortVersions(versions); err != nil {
+		return nil, err
+	}
+	return versions, nil
+}
+
+func copyPluginInventoryEntry(p *PluginInventoryEntry) *PluginInventoryEntry {
+	entry := *p
+	if p.Artifacts != nil {
+		entry.Artifacts = make(distribution.Artifacts, len(p.Artifacts))
+		for v, artifacts := range p.Artifacts {
+			entry.Artifacts[v] = artifacts
+		}
+	}
+	if p.Advisories != nil {
+		entry.Advisories = make(map[string][]*PluginAdvisory, len(p.Advisories))
+		for v, advisories := range p.Advisories {
+			entry.Advisories[v] = advisories
+		}
+	}
+	return &entry
+}
+
+func copyPluginGroup(pg *PluginGroup) *PluginGroup {
+	group := *pg
+	if pg.Versions != nil {
+		group.Versions = make(map[string][]*PluginGroupPluginEntry, len(pg.Versions))
+		for v, plugins := range pg.Versions {
+			group.Versions[v] = plugins
+		}
+	}
+	return &group
+}
@@ -0,0 +1,139 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package plugininventory
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/distribution"
+)
+
+// countingInventory is a PluginInventory counting the queries it receives
+type countingInventory struct {
+	PluginInventory
+	pluginQueries []PluginInventoryFilter
+	groupQueries  []PluginGroupFilter
+}
+
+func (ci *countingInventory) GetPlugins(filter *PluginInventoryFilter) ([]*PluginInventoryEntry, error) {
+	ci.pluginQueries = append(ci.pluginQueries, *filter)
+	return []*PluginInventoryEntry{
+		{
+			Name:   "cluster",
+			Target: configtypes.TargetK8s,
+			Artifacts: distribution.Artifacts{
+				"v1.10.0": {},
+				"v1.2.0":  {},
+			},
+		},
+		{
+			Name:   "cluster",
+			Target: configtypes.TargetTMC,
+			Artifacts: distribution.Artifacts{
+				"v1.2.0": {},
+				"v0.9.0": {},
+			},
+		},
+	}, nil
+}
+
+func (ci *countingInventory) GetPluginGroups(filter PluginGroupFilter) ([]*PluginGroup, error) {
+	ci.groupQueries = append(ci.groupQueries, filter)
+	return []*PluginGroup{
+		{
+			Vendor:             "vmware",
+			Publisher:          "tkg",
+			Name:               "default",
+			RecommendedVersion: "v2.1.0",
+			Versions:           map[string][]*PluginGroupPluginEntry{"v2.1.0": {}},
+		},
+	}, nil
+}
+
+func TestInventoryCacheQueries(t *testing.T) {
+	assert := assert.New(t)
+
+	inventory := &countingInventory{}
+	cache := NewInventoryCache(inventory)
+
+	criteria := PluginCriteria{Name: "cluster", OS: "linux", Arch: "amd64"}
+	plugins, err := cache.GetPlugins(criteria)
+	assert.Nil(err)
+	assert.Len(plugins, 2)
+	assert.Equal([]PluginInventoryFilter{{Name: "cluster", OS: "linux", Arch: "amd64"}}, inventory.pluginQueries)
+
+	// The same query is answered from memory and the callers cannot modify the cached entries
+	plugins[0].Artifacts["v9.9.9"] = nil
+	plugins, err = cache.GetPlugins(criteria)
+	assert.Nil(err)
+	assert.Len(plugins[0].Artifacts, 2)
+	assert.Len(inventory.pluginQueries, 1)
+
+	// A different query reads the inventory
+	_, err = cache.GetPlugins(PluginCriteria{Name: "cluster", IncludeHidden: true})
+	assert.Nil(err)
+	assert.Len(inventory.pluginQueries, 2)
+
+	// All the versions of the plugins are returned, sorted
+	versions, err := cache.GetAllVersions(PluginCriteria{Name: "cluster", Version: "v1.2.0"})
+	assert.Nil(err)
+	assert.Equal([]string{"v0.9.0", "v1.2.0", "v1.10.0"}, versions)
+	assert.Equal(PluginInventoryFilter{Name: "cluster"}, inventory.pluginQueries[2])
+
+	groups, err := cache.GetGroups(GroupCriteria{Vendor: "vmware"})
+	assert.Nil(err)
+	assert.Len(groups, 1)
+	groups[0].Versions["v9.9.9"] = nil
+	groups, err = cache.GetGroups(GroupCriteria{Vendor: "vmware"})
+	assert.Nil(err)
+	assert.Len(groups[0].Versions, 1)
+	assert.Len(inventory.groupQueries, 1)
+
+	// Once invalidated, the inventory is read again
+	cache.Invalidate()
+	_, err = cache.GetPlugins(criteria)
+	assert.Nil(err)
+	_, err = cache.GetGroups(GroupCriteria{Vendor: "vmware"})
+	assert.Nil(err)
+	assert.Len(inventory.pluginQueries, 4)
+	assert.Len(inventory.groupQueries, 2)
+}
+
+func TestInventoryCacheInvalidatedWhenFileChanges(t *testing.T) {
+	assert := assert.New(t)
+
+	dbFile := filepath.Join(t.TempDir(), SQliteDBFileName)
+	assert.Nil(os.WriteFile(dbFile, []byte("db"), 0o600))
+
+	inventory := &countingInventory{}
+	cache := NewInventoryCache(inventory)
+	cache.inventoryFile = dbFile
+
+	_, err := cache.GetPlugins(PluginCriteria{})
+	assert.Nil(err)
+	_, err = cache.GetPlugins(PluginCriteria{})
+	assert.Nil(err)
+	assert.Len(inventory.pluginQueries, 1)
+
+	// A refreshed database replaces the file
+	assert.Nil(os.WriteFile(dbFile, []byte("refreshed db"), 0o600))
+	_, err = cache.GetPlugins(PluginCriteria{})
+	assert.Nil(err)
+	assert.Len(inventory.pluginQueries, 2)
+}
+
+func TestGetInventoryCacheIsShared(t *testing.T) {
+	assert := assert.New(t)
+
+	dbFile := filepath.Join(t.TempDir(), SQliteDBFileName)
+	cache := GetInventoryCache(dbFile, "example.com/tanzu")
+	assert.Same(cache, GetInventoryCache(dbFile, "example.com/tanzu"))
+	assert.NotSame(cache, GetInventoryCache(dbFile, "example.com/other"))
+}
@@ -45,6 +45,10 @@ type PluginInventory interface {
 
 	// UpdatePluginGroupActivationState updates plugin-group metadata to activate or deactivate the plugin-group
 	UpdatePluginGroupActivationState(*PluginGroup) error
+
+	// InsertPluginAdvisory inserts a security advisory affecting a plugin version to the inventory,
+	// replacing the advisory of the same ID for that plugin version if it exists
+	InsertPluginAdvisory(*PluginAdvisory) error
 }
 
 // PluginInventoryEntry represents the inventory information
@@ -69,6 +73,24 @@ type PluginInventoryEntry struct {
 	Hidden bool
 	// Artifacts contains an artifact list for every available version.
 	Artifacts distribution.Artifacts
+	// Advisories contains the security advisories affecting each version, if any.
+	Advisories map[string][]*PluginAdvisory
+}
+
+// PluginAdvisory represents a security advisory, such as a CVE, affecting a plugin version
+type PluginAdvisory struct {
+	// The plugin version affected by the advisory
+	PluginIdentifier
+	// ID of the advisory, e.g., "CVE-2024-12345"
+	ID string
+	// Severity of the advisory, e.g., "critical", "high", "medium" or "low"
+	Severity string
+	// Summary of the advisory
+	Summary string
+	// URL providing the details of the advisory
+	URL string
+	// FixedVersion is the first version of the plugin fixing the advisory, if any
+	FixedVersion string
 }
 
 // PluginInventoryFilter allows to specify different criteria for
@@ -83,6 +105,7 @@ type PluginInventoryFilter struct {
 	// OS of the plugin binary in `GOOS` format.
 	OS string
 	// Arch of the plugin binary in `GOARCH` format.
+	// Universal binaries match any Arch.
 	Arch string
 	// Publisher of the plugins to look for
 	Publisher string
@@ -198,11 +221,11 @@ func PluginGroupIdentifierFromID(id string) *PluginGroupIdentifier {
 // PluginGroupFilter allows to specify different criteria for
 // looking up plugin group entries.
 type PluginGroupFilter struct {
-	// Vendor of the group to look for
+	// Vendor of the group to look for.  Can contain '*' wildcards.
 	Vendor string
-	// Publisher of the group to look for
+	// Publisher of the group to look for.  Can contain '*' wildcards.
 	Publisher string
-	// Name of the group to look for
+	// Name of the group to look for.  Can contain '*' wildcards.
 	Name string
 	// Version of the group
 	Version string
@@ -31,4 +31,17 @@ type PluginInventoryMetadata interface {
 	// on the plugin inventory metadata database by deleting entries that don't
 	// exists in plugin inventory metadata database
 	UpdatePluginInventoryDatabase(pluginInventoryDBFilePath string) error
+
+	// DiffInventoryMetadataDatabase compares the entries of the inventory metadata
+	// database with the entries of the specified inventory metadata database
+	DiffInventoryMetadataDatabase(otherMetadataDBFilePath string) (*InventoryMetadataDiff, error)
+}
+
+// InventoryMetadataDiff counts the plugins and plugin groups of an inventory metadata
+// database which already exist in another inventory metadata database, and the ones which don't
+type InventoryMetadataDiff struct {
+	ExistingPlugins      int
+	NewPlugins           int
+	ExistingPluginGroups int
+	NewPluginGroups      int
 }
@@ -0,0 +1,65 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package plugininventory
+
+import (
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/buildinfo"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/utils"
+)
+
+// IncludePreReleases returns true if pre-release versions should be considered when resolving
+// the latest version of the specified plugin or plugin-group.
+// The behavior is controlled by the TANZU_CLI_INCLUDE_PRERELEASE_PLUGINS variable which can be
+// "true", "false" or a comma-separated list of plugin names or plugin-group IDs.  When the variable
+// is not set, pre-release versions are only considered if the CLI itself is a pre-release.
+func IncludePreReleases(name string) bool {
+	setting := strings.TrimSpace(os.Getenv(constants.ConfigVariableIncludePreReleasePlugins))
+	if setting == "" {
+		return utils.IsPreRelease(buildinfo.Version)
+	}
+	if include, err := strconv.ParseBool(setting); err == nil {
+		return include
+	}
+	for _, n := range strings.Split(setting, ",") {
+		if strings.TrimSpace(n) == name {
+			return true
+		}
+	}
+	return false
+}
+
+// LatestVersion returns the latest of the versions, which must be sorted in ascending order.
+// Unless includePreReleases is true, pre-release versions are ignored, except if there are
+// only pre-release versions, in which case the most recent one is returned.
+func LatestVersion(sortedVersions []string, includePreReleases bool) string {
+	if len(sortedVersions) == 0 {
+		return ""
+	}
+	if !includePreReleases {
+		for i := len(sortedVersions) - 1; i >= 0; i-- {
+			if !utils.IsPreRelease(sortedVersions[i]) {
+				return sortedVersions[i]
+			}
+		}
+	}
+	return sortedVersions[len(sortedVersions)-1]
+}
+
+// UpgradeVersion returns the version to upgrade the installed version to, among the versions
+// which must be sorted in ascending order.  Pre-release versions are considered if
+// includePreReleases is true or if the installed version is itself a pre-release.
+// A version older than the installed one is never returned: the installed version is
+// returned if none of the versions is more recent.
+func UpgradeVersion(sortedVersions []string, installedVersion string, includePreReleases bool) string {
+	latest := LatestVersion(sortedVersions, includePreReleases || utils.IsPreRelease(installedVersion))
+	if latest == "" || latest == installedVersion || utils.IsNewVersion(installedVersion, latest) {
+		return installedVersion
+	}
+	return latest
+}
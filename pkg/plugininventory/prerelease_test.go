@@ -0,0 +1,95 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package plugininventory
+
+import (
+	"os"
+	"testing"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/buildinfo"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
+)
+
+func TestIncludePreReleases(t *testing.T) {
+	origVersion := buildinfo.Version
+	defer func() { buildinfo.Version = origVersion }()
+
+	tests := []struct {
+		name       string
+		cliVersion string
+		setting    string
+		plugin     string
+		expected   bool
+	}{
+		{name: "stable CLI without setting", cliVersion: "v1.2.0", plugin: "cluster", expected: false},
+		{name: "pre-release CLI without setting", cliVersion: "v1.3.0-beta.1", plugin: "cluster", expected: true},
+		{name: "setting to true", cliVersion: "v1.2.0", setting: "true", plugin: "cluster", expected: true},
+		{name: "setting to false with pre-release CLI", cliVersion: "v1.3.0-beta.1", setting: "false", plugin: "cluster", expected: false},
+		{name: "plugin in the list", cliVersion: "v1.2.0", setting: "package, cluster", plugin: "cluster", expected: true},
+		{name: "plugin not in the list", cliVersion: "v1.3.0-beta.1", setting: "package,vmware-tkg/default", plugin: "cluster", expected: false},
+		{name: "group in the list", cliVersion: "v1.2.0", setting: "package,vmware-tkg/default", plugin: "vmware-tkg/default", expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buildinfo.Version = tt.cliVersion
+			os.Setenv(constants.ConfigVariableIncludePreReleasePlugins, tt.setting)
+			defer os.Unsetenv(constants.ConfigVariableIncludePreReleasePlugins)
+
+			if got := IncludePreReleases(tt.plugin); got != tt.expected {
+				t.Errorf("IncludePreReleases(%q) = %v, want %v", tt.plugin, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestLatestVersion(t *testing.T) {
+	tests := []struct {
+		name               string
+		versions           []string
+		includePreReleases bool
+		expected           string
+	}{
+		{name: "no versions", versions: nil, expected: ""},
+		{name: "latest is stable", versions: []string{"v1.0.0-beta.1", "v1.0.0", "v1.1.0"}, expected: "v1.1.0"},
+		{name: "latest is a pre-release", versions: []string{"v1.0.0", "v1.1.0-beta.1"}, expected: "v1.0.0"},
+		{name: "latest is a pre-release which is included", versions: []string{"v1.0.0", "v1.1.0-beta.1"}, includePreReleases: true, expected: "v1.1.0-beta.1"},
+		{name: "only pre-releases", versions: []string{"v0.0.1-dev", "v0.0.2-dev"}, expected: "v0.0.2-dev"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := LatestVersion(tt.versions, tt.includePreReleases); got != tt.expected {
+				t.Errorf("LatestVersion() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestUpgradeVersion(t *testing.T) {
+	tests := []struct {
+		name               string
+		versions           []string
+		installed          string
+		includePreReleases bool
+		expected           string
+	}{
+		{name: "newer stable version", versions: []string{"v1.0.0", "v1.1.0", "v1.2.0-beta.1"}, installed: "v1.0.0", expected: "v1.1.0"},
+		{name: "no newer version", versions: []string{"v1.0.0", "v1.1.0"}, installed: "v1.1.0", expected: "v1.1.0"},
+		{name: "installed pre-release newer than the latest stable", versions: []string{"v1.9.0", "v2.0.0-beta.1"}, installed: "v2.0.0-beta.1", expected: "v2.0.0-beta.1"},
+		{name: "installed pre-release with a newer pre-release", versions: []string{"v1.9.0", "v2.0.0-beta.1", "v2.0.0-beta.2"}, installed: "v2.0.0-beta.1", expected: "v2.0.0-beta.2"},
+		{name: "installed pre-release with its stable release", versions: []string{"v1.9.0", "v2.0.0-beta.1", "v2.0.0"}, installed: "v2.0.0-beta.1", expected: "v2.0.0"},
+		{name: "installed version no longer available", versions: []string{"v1.0.0"}, installed: "v1.5.0", expected: "v1.5.0"},
+		{name: "no versions", versions: nil, installed: "v1.0.0", expected: "v1.0.0"},
+		{name: "nothing installed", versions: []string{"v1.0.0", "v1.1.0-beta.1"}, installed: "", expected: "v1.0.0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := UpgradeVersion(tt.versions, tt.installed, tt.includePreReleases); got != tt.expected {
+				t.Errorf("UpgradeVersion() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
@@ -39,13 +39,46 @@ const (
 	SQliteDBFileName = "plugin_inventory.db"
 
 	// pluginSelectClause is the SELECT section of the SQL query to be used when querying the inventory DB.
-	pluginSelectClause = "SELECT PluginName,Target,RecommendedVersion,Version,Hidden,Description,Publisher,Vendor,OS,Architecture,Digest,URI FROM PluginBinaries"
+	// The last selected columns are the compressedURIColumn, the minCLIVersionColumn, the
+	// kubernetesVersionsColumn and the pluginRuntimeVersionColumn, or an empty string for
+	// databases which don't have them.
+	pluginSelectClause = "SELECT PluginName,Target,RecommendedVersion,Version,Hidden,Description,Publisher,Vendor,OS,Architecture,Digest,URI,%s,%s,%s,%s FROM PluginBinaries"
+
+	// compressedURIColumn is the column of the PluginBinaries table with the URI of the
+	// zstd-compressed plugin binary, if any.  This column is optional and is only added
+	// to the table when the first compressed plugin binary is inserted; older CLIs ignore
+	// it and keep using the uncompressed binary of the URI column.
+	compressedURIColumn = "CompressedURI"
+
+	// minCLIVersionColumn is the column of the PluginBinaries table with the minimum
+	// version of the Tanzu CLI required by the plugin binary, if any.  Like the
+	// compressedURIColumn, it is only added to the table when first needed.
+	minCLIVersionColumn = "MinCLIVersion"
+
+	// kubernetesVersionsColumn is the column of the PluginBinaries table with the semantic
+	// version constraint of the Kubernetes versions supported by the plugin binary, if any.
+	// Like the compressedURIColumn, it is only added to the table when first needed.
+	kubernetesVersionsColumn = "KubernetesVersions"
+
+	// pluginRuntimeVersionColumn is the column of the PluginBinaries table with the version
+	// of the Tanzu Plugin Runtime the plugin binary was built with, if known.  It is also
+	// only added to the table when first needed.
+	pluginRuntimeVersionColumn = "PluginRuntimeVersion"
 
 	// pluginOrderClause is the ORDER section of the SQL query to be used when querying the inventory DB.
 	// It MUST be used, as the order of the results is required by the functions processing the results.
 	// The column order must also match the order used in getPluginNextRow().
 	pluginOrderClause = "ORDER BY PluginName,Target,Version"
 
+	// pluginInsertStatement inserts a row in the PluginBinaries table.
+	// The columns are listed explicitly so that columns can be added to the table.
+	pluginInsertStatement = "INSERT INTO PluginBinaries (PluginName,Target,RecommendedVersion,Version,Hidden,Description,Publisher,Vendor,OS,Architecture,Digest,URI) VALUES(?,?,?,?,?,?,?,?,?,?,?,?);"
+
+	// optionalPluginInsertStatement inserts a row which sets optional columns in the PluginBinaries table.
+	// The first %s is the list of optional columns and the second %s the matching placeholders,
+	// each of them preceded by a comma.
+	optionalPluginInsertStatement = "INSERT INTO PluginBinaries (PluginName,Target,RecommendedVersion,Version,Hidden,Description,Publisher,Vendor,OS,Architecture,Digest,URI%s) VALUES(?,?,?,?,?,?,?,?,?,?,?,?%s);"
+
 	// groupSelectClause is the SELECT section of the query used to extract plugin groups from the PluginGroups table
 	groupSelectClause = "SELECT Vendor,Publisher,GroupName,GroupVersion,Description,PluginName,Target,PluginVersion,Mandatory,Hidden FROM PluginGroups"
 
@@ -53,6 +86,20 @@ const (
 	// It MUST be used, as the order of the results is required by the functions processing the results.
 	// The column order must also match the order used in getGroupNextRow().
 	groupOrderClause = "ORDER by Vendor,Publisher,GroupName,GroupVersion,PluginName,Target"
+
+	// groupInsertStatement inserts a row in the PluginGroups table.
+	// The columns are listed explicitly so that columns can be added to the table.
+	groupInsertStatement = "INSERT INTO PluginGroups (Vendor,Publisher,GroupName,GroupVersion,Description,PluginName,Target,PluginVersion,Mandatory,Hidden) VALUES(?,?,?,?,?,?,?,?,?,?);"
+
+	// advisoriesTable is the table of the security advisories of the plugin versions.  It is
+	// optional and is only created when the first advisory is inserted; older CLIs ignore it.
+	advisoriesTable = "PluginAdvisories"
+
+	// advisorySelectClause is the SELECT section of the query used to extract the advisories from the PluginAdvisories table
+	advisorySelectClause = "SELECT PluginName,Target,Version,AdvisoryID,Severity,Summary,URL,FixedVersion FROM PluginAdvisories"
+
+	// advisoryInsertStatement inserts or replaces a row in the PluginAdvisories table.
+	advisoryInsertStatement = "INSERT OR REPLACE INTO PluginAdvisories (PluginName,Target,Version,AdvisoryID,Severity,Summary,URL,FixedVersion) VALUES(?,?,?,?,?,?,?,?);"
 )
 
 // Structure of each row of the PluginBinaries table within the SQLite database
@@ -69,6 +116,10 @@ type pluginDBRow struct {
 	arch               string
 	digest             string
 	uri                string
+	compressedURI      string
+	minCLIVersion      string
+	kubernetesVersions string
+	runtimeVersion     string
 }
 
 // Structure of each row of the PluginGroups table within the SQLite database
@@ -179,7 +230,7 @@ func (b *SQLiteInventory) getPluginsFromDB(filter *PluginInventoryFilter) ([]*Pl
 		return []*PluginInventoryEntry{}, err
 	}
 
-	whereClause, err := createPluginWhereClause(filter)
+	whereClause, args, err := createPluginWhereClause(filter)
 	if err != nil {
 		return nil, err
 	}
@@ -187,29 +238,99 @@ func (b *SQLiteInventory) getPluginsFromDB(filter *PluginInventoryFilter) ([]*Pl
 	// Build the final query with the SELECT, WHERE and ORDER clauses.
 	// The ORDER clause is essential because the parsing algorithm of extractPluginsFromRows()
 	// assumes that ordering.
-	dbQuery := fmt.Sprintf("%s %s %s", pluginSelectClause, whereClause, pluginOrderClause)
-	rows, err := db.Query(dbQuery)
+	dbQuery := fmt.Sprintf("%s %s %s",
+		fmt.Sprintf(pluginSelectClause, optionalPluginColumn(db, compressedURIColumn), optionalPluginColumn(db, minCLIVersionColumn),
+			optionalPluginColumn(db, kubernetesVersionsColumn), optionalPluginColumn(db, pluginRuntimeVersionColumn)),
+		whereClause, pluginOrderClause)
+	rows, err := db.Query(dbQuery, args...)
 	if err != nil {
 		return nil, errors.Wrapf(err, "unable to setup DB query for DB at '%s'", b.inventoryFile)
 	}
+	plugins, err := b.extractPluginsFromRows(rows)
+	rows.Close()
+	if err != nil {
+		return plugins, err
+	}
+
+	return plugins, setPluginAdvisories(db, filter, plugins)
+}
+
+// hasTable returns whether the database has the table
+func hasTable(db *sql.DB, table string) bool {
+	var count int
+	err := db.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE type='table' AND name=?", table).Scan(&count)
+	return err == nil && count > 0
+}
+
+// setPluginAdvisories sets the advisories of the plugins from the PluginAdvisories table,
+// for databases which have it
+func setPluginAdvisories(db *sql.DB, filter *PluginInventoryFilter, plugins []*PluginInventoryEntry) error {
+	if len(plugins) == 0 || !hasTable(db, advisoriesTable) {
+		return nil
+	}
+
+	var conditions []string
+	var args []interface{}
+	if filter.Name != "" {
+		conditions = append(conditions, "PluginName=?")
+		args = append(args, filter.Name)
+	}
+	if filter.Target != "" {
+		conditions = append(conditions, "Target=?")
+		args = append(args, string(filter.Target))
+	}
+
+	rows, err := db.Query(fmt.Sprintf("%s %s", advisorySelectClause, whereClauseFromConditions(conditions)), args...)
+	if err != nil {
+		return errors.Wrap(err, "unable to query the plugin advisories")
+	}
 	defer rows.Close()
 
-	return b.extractPluginsFromRows(rows)
+	pluginsByID := make(map[string]*PluginInventoryEntry, len(plugins))
+	for _, p := range plugins {
+		pluginsByID[catalog.PluginNameTarget(p.Name, p.Target)] = p
+	}
+	for rows.Next() {
+		var target string
+		advisory := &PluginAdvisory{}
+		err := rows.Scan(&advisory.Name, &target, &advisory.Version, &advisory.ID, &advisory.Severity, &advisory.Summary, &advisory.URL, &advisory.FixedVersion)
+		if err != nil {
+			return errors.Wrap(err, "unable to read the plugin advisories")
+		}
+		advisory.Target = configtypes.StringToTarget(strings.ToLower(target))
+
+		p, exists := pluginsByID[catalog.PluginNameTarget(advisory.Name, advisory.Target)]
+		if !exists {
+			continue
+		}
+		if _, exists := p.Artifacts[advisory.Version]; !exists {
+			continue
+		}
+		if p.Advisories == nil {
+			p.Advisories = make(map[string][]*PluginAdvisory)
+		}
+		p.Advisories[advisory.Version] = append(p.Advisories[advisory.Version], advisory)
+	}
+	return rows.Err()
 }
 
-// createPluginWhereClause parses the filter and creates the WHERE clause for the DB query.
+// createPluginWhereClause parses the filter and creates the WHERE clause for the DB query,
+// along with the values of its parameters.
 //
 //nolint:unparam
-func createPluginWhereClause(filter *PluginInventoryFilter) (string, error) {
-	var whereClause string
+func createPluginWhereClause(filter *PluginInventoryFilter) (string, []interface{}, error) {
+	var conditions []string
+	var args []interface{}
 
 	// If there is a filter, create a WHERE clause for the query.
 	if filter != nil {
 		if filter.Name != "" {
-			whereClause = fmt.Sprintf("%s PluginName='%s' AND", whereClause, filter.Name)
+			conditions = append(conditions, "PluginName=?")
+			args = append(args, filter.Name)
 		}
 		if filter.Target != "" {
-			whereClause = fmt.Sprintf("%s Target='%s' AND", whereClause, string(filter.Target))
+			conditions = append(conditions, "Target=?")
+			args = append(args, string(filter.Target))
 		}
 		if filter.Version != "" {
 			if filter.Version == cli.VersionLatest {
@@ -221,41 +342,65 @@ func createPluginWhereClause(filter *PluginInventoryFilter) (string, error) {
 				// This implies that the query below will never be triggered.
 				// We leave it in to prepare for the time when the repositories will have a
 				// RecommendedVersion column with correct values.
-				whereClause = fmt.Sprintf("%s Version=RecommendedVersion AND", whereClause)
+				conditions = append(conditions, "Version=RecommendedVersion")
 			} else {
 				// We want a specific version of the plugin or the plugin version that matches vMAJOR or vMAJOR.MINOR pattern
-				// In following condition, "Version Like '%s.%%'" condition should handle the cases where only major or major.minor version is specified
+				// In following condition, "Version LIKE 'VERSION.%'" condition should handle the cases where only major or major.minor version is specified
 				// e.g. If specified version is `v1` it matches with all versions like v1.MINOR.PATCH
 				//      If specified version is `v1.2` it matches will all versions like v1.2.PATCH
-				// And "Version='%s'" condition will try to match with the exact same match for the version
-				whereClause = fmt.Sprintf("%[1]s ( Version Like '%[2]s.%%' OR Version='%[2]s' ) AND", whereClause, filter.Version)
+				// And "Version=VERSION" condition will try to match with the exact same match for the version
+				conditions = append(conditions, "( Version LIKE ? OR Version=? )")
+				args = append(args, filter.Version+".%", filter.Version)
 			}
 		}
 		if !filter.IncludeHidden {
 			// Unless we want to also get the hidden plugins, we only request the ones that are not hidden
-			whereClause = fmt.Sprintf("%s Hidden='false' AND", whereClause)
+			conditions = append(conditions, "Hidden='false'")
 		}
 		if filter.OS != "" {
-			whereClause = fmt.Sprintf("%s OS='%s' AND", whereClause, filter.OS)
+			conditions = append(conditions, "OS=?")
+			args = append(args, filter.OS)
 		}
 		if filter.Arch != "" {
-			whereClause = fmt.Sprintf("%s Architecture='%s' AND", whereClause, filter.Arch)
+			// Universal binaries support every architecture of their OS so they also match
+			conditions = append(conditions, "( Architecture=? OR Architecture=? )")
+			args = append(args, filter.Arch, cli.UniversalArch)
 		}
 		if filter.Publisher != "" {
-			whereClause = fmt.Sprintf("%s Publisher='%s' AND", whereClause, filter.Publisher)
+			conditions = append(conditions, "Publisher=?")
+			args = append(args, filter.Publisher)
 		}
 		if filter.Vendor != "" {
-			whereClause = fmt.Sprintf("%s Vendor='%s' AND", whereClause, filter.Vendor)
+			conditions = append(conditions, "Vendor=?")
+			args = append(args, filter.Vendor)
 		}
+	}
+	return whereClauseFromConditions(conditions), args, nil
+}
 
-		if whereClause != "" {
-			// Remove the last added "AND"
-			whereClause = strings.TrimSuffix(whereClause, "AND")
-			// Add the "WHERE" keyword
-			whereClause = fmt.Sprintf("WHERE %s", whereClause)
-		}
+// hasPluginColumn returns whether the PluginBinaries table has the column
+func hasPluginColumn(db *sql.DB, column string) bool {
+	var count int
+	err := db.QueryRow("SELECT COUNT(*) FROM pragma_table_info('PluginBinaries') WHERE name=?", column).Scan(&count)
+	return err == nil && count > 0
+}
+
+// optionalPluginColumn returns the column to select for an optional column of the
+// PluginBinaries table, or an empty string for databases which don't have it
+func optionalPluginColumn(db *sql.DB, column string) string {
+	if hasPluginColumn(db, column) {
+		return column
 	}
-	return whereClause, nil
+	return "''"
+}
+
+// whereClauseFromConditions returns the WHERE clause requiring all the conditions,
+// or an empty string if there is no condition
+func whereClauseFromConditions(conditions []string) string {
+	if len(conditions) == 0 {
+		return ""
+	}
+	return "WHERE " + strings.Join(conditions, " AND ")
 }
 
 // extractPluginsFromRows loops through all DB rows and builds an array
@@ -336,6 +481,12 @@ func (b *SQLiteInventory) extractPluginsFromRows(rows *sql.Rows) ([]*PluginInven
 			OS:     row.os,
 			Arch:   row.arch,
 		}
+		if row.compressedURI != "" {
+			artifact.CompressedImage = fmt.Sprintf("%s/%s", b.uriPrefix, row.compressedURI)
+		}
+		artifact.MinCLIVersion = row.minCLIVersion
+		artifact.KubernetesVersions = row.kubernetesVersions
+		artifact.PluginRuntimeVersion = row.runtimeVersion
 		artifactList = append(artifactList, artifact)
 	}
 	// Don't forget to store the very last plugin we were building
@@ -368,7 +519,7 @@ func (b *SQLiteInventory) getGroupsFromDB(filter PluginGroupFilter) ([]*PluginGr
 		return []*PluginGroup{}, err
 	}
 
-	whereClause, err := createGroupWhereClause(filter)
+	whereClause, args, err := createGroupWhereClause(filter)
 	if err != nil {
 		return nil, err
 	}
@@ -377,7 +528,7 @@ func (b *SQLiteInventory) getGroupsFromDB(filter PluginGroupFilter) ([]*PluginGr
 	// The ORDER clause is essential because the parsing algorithm of extractGroupsFromRows()
 	// assumes that ordering.
 	dbQuery := fmt.Sprintf("%s %s %s", groupSelectClause, whereClause, groupOrderClause)
-	rows, err := db.Query(dbQuery)
+	rows, err := db.Query(dbQuery, args...)
 	if err != nil {
 		return nil, errors.Wrapf(err, "unable to setup DB query for DB at '%s' for groups", b.inventoryFile)
 	}
@@ -386,44 +537,53 @@ func (b *SQLiteInventory) getGroupsFromDB(filter PluginGroupFilter) ([]*PluginGr
 	return b.extractGroupsFromRows(rows)
 }
 
-// createGroupWhereClause parses the filter and creates the WHERE clause for the DB query for groups.
+// createGroupWhereClause parses the filter and creates the WHERE clause for the DB query for groups,
+// along with the values of its parameters.
 //
 //nolint:unparam
-func createGroupWhereClause(filter PluginGroupFilter) (string, error) {
-	var whereClause string
+func createGroupWhereClause(filter PluginGroupFilter) (string, []interface{}, error) {
+	var conditions []string
+	var args []interface{}
 
 	// If there is a filter, create a WHERE clause for the query.
 	if filter.Name != "" {
-		whereClause = fmt.Sprintf("%s GroupName='%s' AND", whereClause, filter.Name)
+		conditions = append(conditions, createMatchCondition("GroupName", filter.Name))
+		args = append(args, filter.Name)
 	}
 	if filter.Version != "" {
 		// We want a specific version or the version that matches vMAJOR or vMAJOR.MINOR pattern
-		// In following condition, "GroupVersion Like '%s.%%'" condition should handle the cases
+		// In following condition, "GroupVersion LIKE 'VERSION.%'" condition should handle the cases
 		// where only major or major.minor version is specified
 		// e.g. If specified version is `v1` it matches with all versions like v1.MINOR.PATCH
 		//      If specified version is `v1.2` it matches will all versions like v1.2.PATCH
-		// And "GroupVersion='%s'" condition will try to match with the exact same match for the version
-		whereClause = fmt.Sprintf("%[1]s ( GroupVersion Like '%[2]s.%%' OR GroupVersion='%[2]s' ) AND", whereClause, filter.Version)
+		// And "GroupVersion=VERSION" condition will try to match with the exact same match for the version
+		conditions = append(conditions, "( GroupVersion LIKE ? OR GroupVersion=? )")
+		args = append(args, filter.Version+".%", filter.Version)
 	}
 	if !filter.IncludeHidden {
 		// Unless we want to also get the hidden plugins, we only request the ones that are not hidden
-		whereClause = fmt.Sprintf("%s Hidden='false' AND", whereClause)
+		conditions = append(conditions, "Hidden='false'")
 	}
 	if filter.Publisher != "" {
-		whereClause = fmt.Sprintf("%s Publisher='%s' AND", whereClause, filter.Publisher)
+		conditions = append(conditions, createMatchCondition("Publisher", filter.Publisher))
+		args = append(args, filter.Publisher)
 	}
 	if filter.Vendor != "" {
-		whereClause = fmt.Sprintf("%s Vendor='%s' AND", whereClause, filter.Vendor)
+		conditions = append(conditions, createMatchCondition("Vendor", filter.Vendor))
+		args = append(args, filter.Vendor)
 	}
 
-	if whereClause != "" {
-		// Remove the last added "AND"
-		whereClause = strings.TrimSuffix(whereClause, "AND")
-		// Add the "WHERE" keyword
-		whereClause = fmt.Sprintf("WHERE %s", whereClause)
-	}
+	return whereClauseFromConditions(conditions), args, nil
+}
 
-	return whereClause, nil
+// createMatchCondition returns the condition matching the value of the parameter for the column.
+// If the value contains the '*' wildcard, which matches any sequence of characters,
+// the GLOB operator is used; GLOB is case-sensitive like the '=' operator.
+func createMatchCondition(column, value string) string {
+	if strings.Contains(value, "*") {
+		return column + " GLOB ?"
+	}
+	return column + "=?"
 }
 
 // extractGroupsFromRows loops through all DB rows and builds an array
@@ -523,6 +683,10 @@ func getPluginNextRow(rows *sql.Rows) (*pluginDBRow, error) {
 		&row.arch,
 		&row.digest,
 		&row.uri,
+		&row.compressedURI,
+		&row.minCLIVersion,
+		&row.kubernetesVersions,
+		&row.runtimeVersion,
 	)
 	return &row, err
 }
@@ -561,7 +725,7 @@ func appendPlugin(allPlugins []*PluginInventoryEntry, plugin *PluginInventoryEnt
 		if err := utils.SortVersions(versions); err != nil {
 			fmt.Fprintf(os.Stderr, "error parsing versions for plugin %s: %v\n", plugin.Name, err)
 		}
-		plugin.RecommendedVersion = versions[len(versions)-1]
+		plugin.RecommendedVersion = LatestVersion(versions, IncludePreReleases(plugin.Name))
 	}
 	allPlugins = append(allPlugins, plugin)
 	return allPlugins
@@ -581,7 +745,7 @@ func appendGroup(allGroups []*PluginGroup, group *PluginGroup, versionDesc map[s
 		if err := utils.SortVersions(versions); err != nil {
 			fmt.Fprintf(os.Stderr, "error parsing versions for group %s: %v\n", PluginGroupToID(group), err)
 		}
-		group.RecommendedVersion = versions[len(versions)-1]
+		group.RecommendedVersion = LatestVersion(versions, IncludePreReleases(PluginGroupToID(group)))
 		// Set the description to the one specified by the latest version found for the group
 		group.Description = versionDesc[group.RecommendedVersion]
 	}
@@ -603,6 +767,33 @@ func (b *SQLiteInventory) CreateSchema() error {
 		return errors.Wrap(err, "error while creating tables to the database")
 	}
 
+	_, err = db.Exec(CreateIndexesSchema)
+	if err != nil {
+		return errors.Wrap(err, "error while creating indexes to the database")
+	}
+
+	_, err = db.Exec(CreateAdvisoriesTableSchema)
+	if err != nil {
+		return errors.Wrap(err, "error while creating the advisories table to the database")
+	}
+
+	return nil
+}
+
+// CreateIndexes creates the indexes speeding up the queries of the plugin inventory
+// database if they are missing, which is the case for databases published before the
+// indexes were part of the schema.
+func CreateIndexes(inventoryFile string) error {
+	db, err := sql.Open("sqlite", inventoryFile)
+	if err != nil {
+		return errors.Wrapf(err, "failed to open the DB at '%s'", inventoryFile)
+	}
+	defer db.Close()
+
+	_, err = db.Exec(CreateIndexesSchema)
+	if err != nil {
+		return errors.Wrapf(err, "error while creating indexes to the DB at '%s'", inventoryFile)
+	}
 	return nil
 }
 
@@ -614,6 +805,10 @@ func (b *SQLiteInventory) InsertPlugin(pluginInventoryEntry *PluginInventoryEntr
 	}
 	defer db.Close()
 
+	if err := ensureOptionalPluginColumns(db, pluginInventoryEntry); err != nil {
+		return err
+	}
+
 	for version, artifacts := range pluginInventoryEntry.Artifacts {
 		for _, a := range artifacts {
 			row := pluginDBRow{
@@ -629,9 +824,40 @@ func (b *SQLiteInventory) InsertPlugin(pluginInventoryEntry *PluginInventoryEntr
 				arch:               a.Arch,
 				digest:             a.Digest,
 				uri:                a.Image,
+				compressedURI:      a.CompressedImage,
+				minCLIVersion:      a.MinCLIVersion,
+				kubernetesVersions: a.KubernetesVersions,
+				runtimeVersion:     a.PluginRuntimeVersion,
+			}
+
+			values := []interface{}{row.name, row.target, row.recommendedVersion, row.version, row.hidden, row.description, row.publisher, row.vendor, row.os, row.arch, row.digest, row.uri}
+			optionalColumns, optionalPlaceholders := "", ""
+			if row.compressedURI != "" {
+				optionalColumns += "," + compressedURIColumn
+				optionalPlaceholders += ",?"
+				values = append(values, row.compressedURI)
+			}
+			if row.minCLIVersion != "" {
+				optionalColumns += "," + minCLIVersionColumn
+				optionalPlaceholders += ",?"
+				values = append(values, row.minCLIVersion)
+			}
+			if row.kubernetesVersions != "" {
+				optionalColumns += "," + kubernetesVersionsColumn
+				optionalPlaceholders += ",?"
+				values = append(values, row.kubernetesVersions)
+			}
+			if row.runtimeVersion != "" {
+				optionalColumns += "," + pluginRuntimeVersionColumn
+				optionalPlaceholders += ",?"
+				values = append(values, row.runtimeVersion)
 			}
 
-			_, err = db.Exec("INSERT INTO PluginBinaries VALUES(?,?,?,?,?,?,?,?,?,?,?,?);", row.name, row.target, row.recommendedVersion, row.version, row.hidden, row.description, row.publisher, row.vendor, row.os, row.arch, row.digest, row.uri)
+			if optionalColumns == "" {
+				_, err = db.Exec(pluginInsertStatement, values...)
+			} else {
+				_, err = db.Exec(fmt.Sprintf(optionalPluginInsertStatement, optionalColumns, optionalPlaceholders), values...)
+			}
 			if err != nil {
 				return errors.Wrapf(err, "unable to insert plugin row %v", row)
 			}
@@ -643,6 +869,57 @@ func (b *SQLiteInventory) InsertPlugin(pluginInventoryEntry *PluginInventoryEntr
 	return nil
 }
 
+// ensureOptionalPluginColumns adds the optional columns used by the plugin to the
+// PluginBinaries table if the inventory database predates these columns
+func ensureOptionalPluginColumns(db *sql.DB, pluginInventoryEntry *PluginInventoryEntry) error {
+	hasCompressedBinary := false
+	hasMinCLIVersion := false
+	hasKubernetesVersions := false
+	hasRuntimeVersion := false
+	for _, artifacts := range pluginInventoryEntry.Artifacts {
+		for _, a := range artifacts {
+			hasCompressedBinary = hasCompressedBinary || a.CompressedImage != ""
+			hasMinCLIVersion = hasMinCLIVersion || a.MinCLIVersion != ""
+			hasKubernetesVersions = hasKubernetesVersions || a.KubernetesVersions != ""
+			hasRuntimeVersion = hasRuntimeVersion || a.PluginRuntimeVersion != ""
+		}
+	}
+	if hasCompressedBinary {
+		if err := ensurePluginColumn(db, compressedURIColumn); err != nil {
+			return err
+		}
+	}
+	if hasMinCLIVersion {
+		if err := ensurePluginColumn(db, minCLIVersionColumn); err != nil {
+			return err
+		}
+	}
+	if hasKubernetesVersions {
+		if err := ensurePluginColumn(db, kubernetesVersionsColumn); err != nil {
+			return err
+		}
+	}
+	if hasRuntimeVersion {
+		if err := ensurePluginColumn(db, pluginRuntimeVersionColumn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ensurePluginColumn adds the specified optional column to the PluginBinaries table
+// if it is not already present
+func ensurePluginColumn(db *sql.DB, column string) error {
+	if hasPluginColumn(db, column) {
+		return nil
+	}
+	_, err := db.Exec(fmt.Sprintf("ALTER TABLE PluginBinaries ADD COLUMN %s TEXT NOT NULL DEFAULT '';", column))
+	if err != nil {
+		return errors.Wrapf(err, "unable to add the %s column to the inventory database", column)
+	}
+	return nil
+}
+
 // InsertPluginGroup inserts plugin-group to the inventory
 // specifying override will delete the existing plugin-group and add new one
 func (b *SQLiteInventory) InsertPluginGroup(pg *PluginGroup, override bool) error { //nolint:gocyclo
@@ -721,7 +998,7 @@ func (b *SQLiteInventory) InsertPluginGroup(pg *PluginGroup, override bool) erro
 				mandatory:     strconv.FormatBool(pi.Mandatory),
 				hidden:        strconv.FormatBool(pg.Hidden),
 			}
-			_, err = db.Exec("INSERT INTO PluginGroups VALUES(?,?,?,?,?,?,?,?,?,?);", row.vendor, row.publisher, row.groupName, row.groupVersion, row.description, row.pluginName, row.target, row.pluginVersion, row.mandatory, row.hidden)
+			_, err = db.Exec(groupInsertStatement, row.vendor, row.publisher, row.groupName, row.groupVersion, row.description, row.pluginName, row.target, row.pluginVersion, row.mandatory, row.hidden)
 			if err != nil {
 				return errors.Wrapf(err, "unable to insert plugin-group row %v", row)
 			}
@@ -732,6 +1009,42 @@ func (b *SQLiteInventory) InsertPluginGroup(pg *PluginGroup, override bool) erro
 	return nil
 }
 
+// InsertPluginAdvisory inserts a security advisory affecting a plugin version to the inventory,
+// replacing the advisory of the same ID for that plugin version if it exists.  The
+// PluginAdvisories table is added to the database if needed.
+func (b *SQLiteInventory) InsertPluginAdvisory(advisory *PluginAdvisory) error {
+	if advisory.Name == "" || advisory.Version == "" || advisory.ID == "" {
+		return errors.New("the plugin name, the plugin version and the ID of an advisory are required")
+	}
+
+	// Verify that the plugin version exists in the database, deactivated or not
+	pie, err := b.GetPlugins(&PluginInventoryFilter{Name: advisory.Name, Target: advisory.Target, Version: advisory.Version, IncludeHidden: true})
+	if err != nil {
+		return errors.Wrap(err, "error while verifying existence of the plugin in the database")
+	} else if len(pie) == 0 {
+		return errors.Errorf("specified plugin 'name:%s', 'target:%s', 'version:%s' is not present in the database", advisory.Name, advisory.Target, advisory.Version)
+	}
+
+	db, err := sql.Open("sqlite", b.inventoryFile)
+	if err != nil {
+		return errors.Wrapf(err, "failed to open the DB from '%s' file", b.inventoryFile)
+	}
+	defer db.Close()
+
+	_, err = db.Exec(CreateAdvisoriesTableSchema)
+	if err != nil {
+		return errors.Wrap(err, "error while creating the advisories table to the database")
+	}
+
+	_, err = db.Exec(advisoryInsertStatement, advisory.Name, string(advisory.Target), advisory.Version, advisory.ID, advisory.Severity, advisory.Summary, advisory.URL, advisory.FixedVersion)
+	if err != nil {
+		return errors.Wrapf(err, "unable to insert advisory %q of plugin '%s:%s'", advisory.ID, advisory.Name, advisory.Version)
+	}
+	// Write sql statement logs if required
+	writeSQLStatementLogs(fmt.Sprintf("INSERT OR REPLACE INTO PluginAdvisories VALUES(%v,%v,%v,%v,%v,%v,%v,%v);\n", advisory.Name, advisory.Target, advisory.Version, advisory.ID, advisory.Severity, advisory.Summary, advisory.URL, advisory.FixedVersion))
+	return nil
+}
+
 // UpdatePluginActivationState updates plugin metadata to activate or deactivate plugin
 func (b *SQLiteInventory) UpdatePluginActivationState(pluginInventoryEntry *PluginInventoryEntry) error {
 	db, err := sql.Open("sqlite", b.inventoryFile)
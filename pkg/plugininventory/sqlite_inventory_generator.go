@@ -0,0 +1,155 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package plugininventory
+
+import (
+	"crypto/sha256"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+)
+
+// generatedPluginTargets are the targets assigned in turn to the generated plugins
+var generatedPluginTargets = []configtypes.Target{
+	configtypes.TargetGlobal,
+	configtypes.TargetK8s,
+	configtypes.TargetTMC,
+	configtypes.TargetOperations,
+}
+
+// GeneratorOptions configures the content of a plugin inventory database
+// generated for scale testing
+type GeneratorOptions struct {
+	// Plugins is the number of plugins
+	Plugins int
+	// VersionsPerPlugin is the number of versions of each plugin
+	VersionsPerPlugin int
+	// Platforms are the <os>/<arch> platforms of the binaries of each plugin version
+	Platforms []string
+	// Groups is the number of plugin groups
+	Groups int
+	// PluginsPerGroup is the number of plugins of each plugin group
+	PluginsPerGroup int
+	// Vendor of the plugins and plugin groups
+	Vendor string
+	// Publisher of the plugins and plugin groups
+	Publisher string
+}
+
+// GenerateInventoryDB creates a plugin inventory database in the file with
+// generated plugins and plugin groups, to measure the performance of the
+// operations on large inventories.
+// The rows are inserted within a single transaction as inserting them one
+// at a time, like InsertPlugin does, is too slow for millions of rows.
+// The generated plugins cannot be installed as no binary is published.
+func GenerateInventoryDB(inventoryFile string, opts *GeneratorOptions) error {
+	if opts.Plugins <= 0 || opts.VersionsPerPlugin <= 0 || len(opts.Platforms) == 0 {
+		return errors.New("the number of plugins, the number of versions and the platforms must be specified")
+	}
+	if opts.PluginsPerGroup > opts.Plugins {
+		return errors.Errorf("plugin groups cannot contain more than the %d generated plugins", opts.Plugins)
+	}
+	vendor, publisher := opts.Vendor, opts.Publisher
+	if vendor == "" {
+		vendor = "vmware"
+	}
+	if publisher == "" {
+		publisher = "scale"
+	}
+
+	if err := NewSQLiteInventory(inventoryFile, "").CreateSchema(); err != nil {
+		return err
+	}
+	db, err := sql.Open("sqlite", inventoryFile)
+	if err != nil {
+		return errors.Wrapf(err, "failed to open the DB from '%s' file", inventoryFile)
+	}
+	defer db.Close()
+
+	tx, err := db.Begin()
+	if err != nil {
+		return errors.Wrap(err, "unable to start a transaction")
+	}
+	if err := insertGeneratedPlugins(tx, opts, vendor, publisher); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	if err := insertGeneratedPluginGroups(tx, opts, vendor, publisher); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return errors.Wrap(tx.Commit(), "unable to commit the generated rows")
+}
+
+func insertGeneratedPlugins(tx *sql.Tx, opts *GeneratorOptions, vendor, publisher string) error {
+	stmt, err := tx.Prepare(pluginInsertStatement)
+	if err != nil {
+		return errors.Wrap(err, "unable to prepare the plugin insertion")
+	}
+	defer stmt.Close()
+
+	for p := 0; p < opts.Plugins; p++ {
+		name := generatedPluginName(p)
+		target := string(generatedPluginTarget(p))
+		description := fmt.Sprintf("Generated plugin %s for scale testing", name)
+		for v := 0; v < opts.VersionsPerPlugin; v++ {
+			version := generatedPluginVersion(v)
+			for _, platform := range opts.Platforms {
+				osName, arch, found := strings.Cut(platform, "/")
+				if !found {
+					return errors.Errorf("invalid platform %q, expected <os>/<arch>", platform)
+				}
+				uri := fmt.Sprintf("%s/%s/%s/%s/%s/%s:%s", vendor, publisher, osName, arch, target, name, version)
+				digest := fmt.Sprintf("%x", sha256.Sum256([]byte(uri)))
+				_, err = stmt.Exec(name, target, "", version, "false", description, publisher, vendor, osName, arch, digest, uri)
+				if err != nil {
+					return errors.Wrapf(err, "unable to insert plugin %q version %q for %s", name, version, platform)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func insertGeneratedPluginGroups(tx *sql.Tx, opts *GeneratorOptions, vendor, publisher string) error {
+	if opts.Groups <= 0 || opts.PluginsPerGroup <= 0 {
+		return nil
+	}
+	stmt, err := tx.Prepare(groupInsertStatement)
+	if err != nil {
+		return errors.Wrap(err, "unable to prepare the plugin group insertion")
+	}
+	defer stmt.Close()
+
+	// Each plugin group includes the latest version of consecutive plugins
+	latestVersion := generatedPluginVersion(opts.VersionsPerPlugin - 1)
+	for g := 0; g < opts.Groups; g++ {
+		groupName := fmt.Sprintf("group%05d", g)
+		description := fmt.Sprintf("Generated plugin group %s for scale testing", groupName)
+		for i := 0; i < opts.PluginsPerGroup; i++ {
+			p := (g*opts.PluginsPerGroup + i) % opts.Plugins
+			_, err = stmt.Exec(vendor, publisher, groupName, "v1.0.0", description, generatedPluginName(p), string(generatedPluginTarget(p)), latestVersion, "false", "false")
+			if err != nil {
+				return errors.Wrapf(err, "unable to insert plugin group %q", groupName)
+			}
+		}
+	}
+	return nil
+}
+
+func generatedPluginName(index int) string {
+	return fmt.Sprintf("plugin%05d", index)
+}
+
+func generatedPluginTarget(index int) configtypes.Target {
+	return generatedPluginTargets[index%len(generatedPluginTargets)]
+}
+
+func generatedPluginVersion(index int) string {
+	return fmt.Sprintf("v1.%d.0", index)
+}
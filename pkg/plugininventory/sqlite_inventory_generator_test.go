@@ -0,0 +1,136 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package plugininventory
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+)
+
+var _ = Describe("Generation of plugin inventory databases", func() {
+	var (
+		tmpDir string
+		dbFile string
+	)
+	BeforeEach(func() {
+		var err error
+		tmpDir, err = os.MkdirTemp(os.TempDir(), "")
+		Expect(err).To(BeNil())
+		dbFile = filepath.Join(tmpDir, SQliteDBFileName)
+	})
+	AfterEach(func() {
+		os.RemoveAll(tmpDir)
+	})
+
+	It("should generate the requested plugins and plugin groups", func() {
+		err := GenerateInventoryDB(dbFile, &GeneratorOptions{
+			Plugins:           10,
+			VersionsPerPlugin: 3,
+			Platforms:         []string{"linux/amd64", "darwin/arm64"},
+			Groups:            2,
+			PluginsPerGroup:   4,
+		})
+		Expect(err).To(BeNil())
+
+		inventory := NewSQLiteInventory(dbFile, "example.com/tanzu-cli/plugins")
+		plugins, err := inventory.GetAllPlugins()
+		Expect(err).To(BeNil())
+		Expect(plugins).To(HaveLen(10))
+		for _, p := range plugins {
+			Expect(p.RecommendedVersion).To(Equal("v1.2.0"))
+			Expect(p.Artifacts).To(HaveLen(3))
+			Expect(p.Artifacts["v1.0.0"]).To(HaveLen(2))
+		}
+
+		plugins, err = inventory.GetPlugins(&PluginInventoryFilter{Name: "plugin00001", Target: types.TargetK8s})
+		Expect(err).To(BeNil())
+		Expect(plugins).To(HaveLen(1))
+
+		groups, err := inventory.GetPluginGroups(PluginGroupFilter{})
+		Expect(err).To(BeNil())
+		Expect(groups).To(HaveLen(2))
+		Expect(groups[0].Versions["v1.0.0"]).To(HaveLen(4))
+	})
+	It("should fail for invalid options", func() {
+		err := GenerateInventoryDB(dbFile, &GeneratorOptions{Plugins: 10, VersionsPerPlugin: 1})
+		Expect(err).ToNot(BeNil())
+
+		err = GenerateInventoryDB(dbFile, &GeneratorOptions{Plugins: 1, VersionsPerPlugin: 1, Platforms: []string{"linux"}})
+		Expect(err).ToNot(BeNil())
+		Expect(err.Error()).To(ContainSubstring("invalid platform"))
+	})
+})
+
+// benchmarkInventorySize returns the number of plugins of the inventories of
+// the benchmarks, which can be increased through TANZU_CLI_BENCHMARK_INVENTORY_PLUGINS
+// to measure the performance for a full-size central repository, e.g. 10000 plugins
+func benchmarkInventorySize() int {
+	if size, err := strconv.Atoi(os.Getenv("TANZU_CLI_BENCHMARK_INVENTORY_PLUGINS")); err == nil && size > 0 {
+		return size
+	}
+	return 500
+}
+
+func generateBenchmarkInventory(b *testing.B) PluginInventory {
+	b.Helper()
+	dbFile := filepath.Join(b.TempDir(), SQliteDBFileName)
+	err := GenerateInventoryDB(dbFile, &GeneratorOptions{
+		Plugins:           benchmarkInventorySize(),
+		VersionsPerPlugin: 20,
+		Platforms:         []string{"linux/amd64", "linux/arm64", "darwin/amd64", "darwin/arm64", "windows/amd64", "windows/arm64", "linux/386", "windows/386"},
+		Groups:            20,
+		PluginsPerGroup:   25,
+	})
+	if err != nil {
+		b.Fatal(err)
+	}
+	return NewSQLiteInventory(dbFile, "example.com/tanzu-cli/plugins")
+}
+
+func BenchmarkGetAllPlugins(b *testing.B) {
+	inventory := generateBenchmarkInventory(b)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := inventory.GetAllPlugins(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkGetPluginsByName(b *testing.B) {
+	inventory := generateBenchmarkInventory(b)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := inventory.GetPlugins(&PluginInventoryFilter{Name: "plugin00001", Target: types.TargetK8s}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkGetPluginsForPlatform(b *testing.B) {
+	inventory := generateBenchmarkInventory(b)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := inventory.GetPlugins(&PluginInventoryFilter{OS: "linux", Arch: "amd64"}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkGetPluginGroups(b *testing.B) {
+	inventory := generateBenchmarkInventory(b)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := inventory.GetPluginGroups(PluginGroupFilter{}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
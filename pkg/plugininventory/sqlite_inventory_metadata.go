@@ -121,3 +121,36 @@ func (b *SQLiteInventoryMetadata) UpdatePluginInventoryDatabase(pluginInventoryD
 	}
 	return nil
 }
+
+// DiffInventoryMetadataDatabase compares the entries of the AvailablePluginBinaries and
+// AvailablePluginGroups tables with the ones of the specified inventory metadata database
+func (b *SQLiteInventoryMetadata) DiffInventoryMetadataDatabase(otherMetadataDBFilePath string) (*InventoryMetadataDiff, error) {
+	db, err := sql.Open("sqlite", b.inventoryMetadataDBFile)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to open the DB from '%s' file", b.inventoryMetadataDBFile)
+	}
+	defer db.Close()
+	// The attached database is only visible to the connection which attached it
+	db.SetMaxOpenConns(1)
+
+	_, err = db.Exec("ATTACH ? as otherMetadataDB;", otherMetadataDBFilePath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to attach the DB from '%s' file", otherMetadataDBFilePath)
+	}
+
+	diffQuery := `SELECT
+	(SELECT COUNT(*) FROM AvailablePluginBinaries),
+	(SELECT COUNT(*) FROM AvailablePluginBinaries a WHERE EXISTS (SELECT 1 FROM otherMetadataDB.AvailablePluginBinaries b WHERE b.PluginName = a.PluginName AND b.Target = a.Target AND b.Version = a.Version)),
+	(SELECT COUNT(*) FROM AvailablePluginGroups),
+	(SELECT COUNT(*) FROM AvailablePluginGroups a WHERE EXISTS (SELECT 1 FROM otherMetadataDB.AvailablePluginGroups b WHERE b.Vendor = a.Vendor AND b.Publisher = a.Publisher AND b.GroupName = a.GroupName AND b.GroupVersion = a.GroupVersion));`
+
+	var plugins, pluginGroups int
+	diff := &InventoryMetadataDiff{}
+	err = db.QueryRow(diffQuery).Scan(&plugins, &diff.ExistingPlugins, &pluginGroups, &diff.ExistingPluginGroups)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to execute the query %v", diffQuery)
+	}
+	diff.NewPlugins = plugins - diff.ExistingPlugins
+	diff.NewPluginGroups = pluginGroups - diff.ExistingPluginGroups
+	return diff, nil
+}
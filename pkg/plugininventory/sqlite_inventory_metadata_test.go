@@ -375,6 +375,57 @@ var _ = Describe("Unit tests for plugin inventory metadata", func() {
 		})
 	})
 
+	Describe("Diff Inventory Metadata Database", func() {
+		Context("when the other database does not have tables created", func() {
+			BeforeEach(func() {
+				metadataInventory, _ = createInventoryMetadataDB(true)
+				_, additionalMetadataInventoryFilePath = createInventoryMetadataDB(false)
+			})
+			AfterEach(func() {
+				os.RemoveAll(tmpDir1)
+				os.RemoveAll(tmpDir2)
+			})
+			It("should return an error", func() {
+				_, err = metadataInventory.DiffInventoryMetadataDatabase(additionalMetadataInventoryFilePath)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("unable to execute the query"))
+			})
+		})
+
+		Context("when both inventory metadata databases have some overlap of plugins and plugin groups", func() {
+			BeforeEach(func() {
+				metadataInventory, _ = createInventoryMetadataDB(true)
+				additionalMetadataInventory, additionalMetadataInventoryFilePath = createInventoryMetadataDB(true)
+
+				err = metadataInventory.InsertPluginIdentifier(&pluginIdentifier1)
+				Expect(err).NotTo(HaveOccurred())
+				err = metadataInventory.InsertPluginIdentifier(&pluginIdentifier2)
+				Expect(err).NotTo(HaveOccurred())
+				err = metadataInventory.InsertPluginGroupIdentifier(&pluginGroupIdentifier1)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = additionalMetadataInventory.InsertPluginIdentifier(&pluginIdentifier2)
+				Expect(err).NotTo(HaveOccurred())
+				err = additionalMetadataInventory.InsertPluginGroupIdentifier(&pluginGroupIdentifier2)
+				Expect(err).NotTo(HaveOccurred())
+			})
+			AfterEach(func() {
+				os.RemoveAll(tmpDir1)
+				os.RemoveAll(tmpDir2)
+			})
+			It("should count the existing and new plugins and plugin groups", func() {
+				diff, err := metadataInventory.DiffInventoryMetadataDatabase(additionalMetadataInventoryFilePath)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(*diff).To(Equal(InventoryMetadataDiff{
+					ExistingPlugins:      1,
+					NewPlugins:           1,
+					ExistingPluginGroups: 0,
+					NewPluginGroups:      1,
+				}))
+			})
+		})
+	})
+
 	Describe("Update Plugin Inventory Database based on Metadata Database", func() {
 		Context("when plugin inventory database provided is invalid and does not have tables created", func() {
 			BeforeEach(func() {
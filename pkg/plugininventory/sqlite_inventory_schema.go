@@ -14,6 +14,20 @@ var (
 	//go:embed data/sqlite/create_tables.sql
 	createTablesSchema string
 
+	// CreateIndexesSchema defines the indexes speeding up the queries of the sqlite database.
+	// The indexes are not part of CreateTablesSchema so they can be added to databases
+	// published before they were defined.
+	CreateIndexesSchema = strings.TrimSpace(createIndexesSchema)
+	//go:embed data/sqlite/create_indexes.sql
+	createIndexesSchema string
+
+	// CreateAdvisoriesTableSchema defines the table of the security advisories of the plugin versions.
+	// The table is not part of CreateTablesSchema so it can be added to databases published before
+	// it was defined, when the first advisory is inserted.
+	CreateAdvisoriesTableSchema = strings.TrimSpace(createAdvisoriesTableSchema)
+	//go:embed data/sqlite/create_advisories_table.sql
+	createAdvisoriesTableSchema string
+
 	// PluginInventoryMetadataCreateTablesSchema defines the database schema to create sqlite database for available plugins
 	PluginInventoryMetadataCreateTablesSchema = strings.TrimSpace(pluginInventoryMetadataCreateTablesSchema)
 	//go:embed data/sqlite/plugin_inventory_metadata_tables.sql
@@ -617,6 +617,42 @@ var _ = Describe("Unit tests for plugin inventory", func() {
 					Expect(a.Image).To(Equal(tmpDir + "/vmware/tkg/darwin/amd64/k8s/management-cluster:v0.28.0"))
 				})
 			})
+			Context("When getting a plugin for an os/arch which has a universal binary", func() {
+				It("should return both the universal and the arch specific binaries", func() {
+					db, err := sql.Open("sqlite", dbFile.Name())
+					Expect(err).To(BeNil())
+					defer db.Close()
+					_, err = db.Exec(`INSERT INTO PluginBinaries VALUES('management-cluster','kubernetes','v0.28.0','v0.28.0','false','Kubernetes management cluster operations','tkg','vmware','darwin','universal','4444444444','vmware/tkg/darwin/universal/k8s/management-cluster:v0.28.0');`)
+					Expect(err).To(BeNil(), "failed to create plugin for testing")
+
+					plugins, err := inventory.GetPlugins(&PluginInventoryFilter{
+						Name:    "management-cluster",
+						Target:  "kubernetes",
+						Version: cli.VersionLatest,
+						OS:      "darwin",
+						Arch:    "arm64",
+					})
+					Expect(err).ToNot(HaveOccurred())
+					Expect(len(plugins)).To(Equal(1))
+
+					artifactList := plugins[0].Artifacts["v0.28.0"]
+					Expect(len(artifactList)).To(Equal(1))
+					Expect(artifactList[0].OS).To(Equal("darwin"))
+					Expect(artifactList[0].Arch).To(Equal(cli.UniversalArch))
+					Expect(artifactList[0].Digest).To(Equal("4444444444"))
+
+					plugins, err = inventory.GetPlugins(&PluginInventoryFilter{
+						Name:    "management-cluster",
+						Target:  "kubernetes",
+						Version: cli.VersionLatest,
+						OS:      "darwin",
+						Arch:    "amd64",
+					})
+					Expect(err).ToNot(HaveOccurred())
+					Expect(len(plugins)).To(Equal(1))
+					Expect(len(plugins[0].Artifacts["v0.28.0"])).To(Equal(2))
+				})
+			})
 			Context("When getting plugins by vendor", func() {
 				It("should return a list of one plugin with no error", func() {
 					plugins, err := inventory.GetPlugins(&PluginInventoryFilter{
@@ -638,6 +674,39 @@ var _ = Describe("Unit tests for plugin inventory", func() {
 					Expect(p.Publisher).To(Equal("tkg"))
 				})
 			})
+			Context("When getting plugins with filter values containing quotes", func() {
+				It("should bind the values instead of failing the query", func() {
+					plugins, err := inventory.GetPlugins(&PluginInventoryFilter{
+						Name:   "management-cluster' OR '1'='1",
+						Vendor: "vm'ware",
+					})
+					Expect(err).ToNot(HaveOccurred())
+					Expect(plugins).To(BeEmpty())
+
+					groups, err := inventory.GetPluginGroups(PluginGroupFilter{Name: "o'brien*"})
+					Expect(err).ToNot(HaveOccurred())
+					Expect(groups).To(BeEmpty())
+				})
+			})
+			Context("When creating the indexes of the DB", func() {
+				It("should add the missing indexes", func() {
+					Expect(CreateIndexes(dbFile.Name())).To(Succeed())
+
+					db, err := sql.Open("sqlite", dbFile.Name())
+					Expect(err).To(BeNil())
+					defer db.Close()
+
+					var count int
+					err = db.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE type='index' AND name IN ('PluginBinariesByNameTargetVersion','PluginBinariesByVendorPublisher')").Scan(&count)
+					Expect(err).To(BeNil())
+					Expect(count).To(Equal(2))
+
+					// The indexes don't change the results of the queries
+					plugins, err := inventory.GetPlugins(&PluginInventoryFilter{Vendor: "vmware"})
+					Expect(err).ToNot(HaveOccurred())
+					Expect(plugins).To(HaveLen(1))
+				})
+			})
 			Context("When getting plugins by publisher", func() {
 				It("should return a list of one plugin with no error", func() {
 					plugins, err := inventory.GetPlugins(&PluginInventoryFilter{
@@ -1073,6 +1142,28 @@ var _ = Describe("Unit tests for plugin inventory", func() {
 					Expect(plugins[j].Version).To(Equal("v0.2.0"))
 				})
 			})
+			Context("When getting groups using wildcards", func() {
+				It("should return the matching groups with no error", func() {
+					groups, err := inventory.GetPluginGroups(PluginGroupFilter{Vendor: "indep*"})
+					Expect(err).ToNot(HaveOccurred())
+					Expect(len(groups)).To(Equal(1))
+					Expect(groups[0].Vendor).To(Equal("independent"))
+					Expect(groups[0].Name).To(Equal("mygroup"))
+
+					groups, err = inventory.GetPluginGroups(PluginGroupFilter{Publisher: "*", Name: "*group"})
+					Expect(err).ToNot(HaveOccurred())
+					Expect(len(groups)).To(Equal(1))
+					Expect(groups[0].Name).To(Equal("mygroup"))
+
+					groups, err = inventory.GetPluginGroups(PluginGroupFilter{Vendor: "*", Publisher: "*", Name: "*"})
+					Expect(err).ToNot(HaveOccurred())
+					Expect(len(groups)).To(Equal(2))
+
+					groups, err = inventory.GetPluginGroups(PluginGroupFilter{Vendor: "VMware*"})
+					Expect(err).ToNot(HaveOccurred())
+					Expect(len(groups)).To(Equal(0))
+				})
+			})
 			Context("When getting all groups including hidden ones", func() {
 				It("should return a list of three groups with no error", func() {
 					groups, err := inventory.GetPluginGroups(PluginGroupFilter{IncludeHidden: true})
@@ -1321,6 +1412,260 @@ var _ = Describe("Unit tests for plugin inventory", func() {
 				Expect(len(plugins)).To(Equal(0))
 			})
 		})
+		Context("When inserting plugins with compressed binaries", func() {
+			It("should add the compressed URI column and return the compressed images", func() {
+				// Plugins without compressed binaries don't need the column
+				err = inventory.InsertPlugin(&piEntry2)
+				Expect(err).To(BeNil(), "failed to insert plugin2")
+
+				db, err := sql.Open("sqlite", dbFile.Name())
+				Expect(err).To(BeNil())
+				defer db.Close()
+				Expect(hasPluginColumn(db, compressedURIColumn)).To(BeFalse())
+
+				compressedEntry := PluginInventoryEntry{
+					Name:        "compressed-plugin",
+					Target:      types.TargetGlobal,
+					Description: "Plugin with a compressed binary",
+					Publisher:   "tkg",
+					Vendor:      "vmware",
+					Artifacts: distribution.Artifacts{
+						"v1.0.0": []distribution.Artifact{
+							{
+								OS:              "linux",
+								Arch:            "amd64",
+								Digest:          "4444444444",
+								Image:           "vmware/tkg/linux/amd64/global/compressed-plugin:v1.0.0",
+								CompressedImage: "vmware/tkg/linux/amd64/global/compressed-plugin/zstd:v1.0.0",
+							},
+						},
+					},
+				}
+				err = inventory.InsertPlugin(&compressedEntry)
+				Expect(err).To(BeNil(), "failed to insert the plugin with a compressed binary")
+				Expect(hasPluginColumn(db, compressedURIColumn)).To(BeTrue())
+
+				plugins, err := inventory.GetPlugins(&PluginInventoryFilter{Name: "compressed-plugin"})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(plugins).To(HaveLen(1))
+				a := plugins[0].Artifacts["v1.0.0"]
+				Expect(a).To(HaveLen(1))
+				Expect(a[0].Image).To(Equal(tmpDir + "/vmware/tkg/linux/amd64/global/compressed-plugin:v1.0.0"))
+				Expect(a[0].CompressedImage).To(Equal(tmpDir + "/vmware/tkg/linux/amd64/global/compressed-plugin/zstd:v1.0.0"))
+
+				// Plugins inserted before the column was added have no compressed binary
+				plugins, err = inventory.GetPlugins(&PluginInventoryFilter{Name: "isolated-cluster"})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(plugins).To(HaveLen(1))
+				Expect(plugins[0].Artifacts["v1.2.3"][0].CompressedImage).To(BeEmpty())
+			})
+		})
+		Context("When inserting plugins supporting specific Kubernetes versions", func() {
+			It("should add the Kubernetes versions column and return the Kubernetes versions", func() {
+				err = inventory.InsertPlugin(&piEntry2)
+				Expect(err).To(BeNil(), "failed to insert plugin2")
+
+				db, err := sql.Open("sqlite", dbFile.Name())
+				Expect(err).To(BeNil())
+				defer db.Close()
+				Expect(hasPluginColumn(db, kubernetesVersionsColumn)).To(BeFalse())
+
+				k8sEntry := PluginInventoryEntry{
+					Name:        "k8s-plugin",
+					Target:      types.TargetK8s,
+					Description: "Plugin supporting specific Kubernetes versions",
+					Publisher:   "tkg",
+					Vendor:      "vmware",
+					Artifacts: distribution.Artifacts{
+						"v1.0.0": []distribution.Artifact{
+							{
+								OS:                 "linux",
+								Arch:               "amd64",
+								Digest:             "5555555555",
+								Image:              "vmware/tkg/linux/amd64/kubernetes/k8s-plugin:v1.0.0",
+								KubernetesVersions: ">=1.26.0, <1.30.0",
+							},
+						},
+					},
+				}
+				err = inventory.InsertPlugin(&k8sEntry)
+				Expect(err).To(BeNil(), "failed to insert the plugin supporting specific Kubernetes versions")
+				Expect(hasPluginColumn(db, kubernetesVersionsColumn)).To(BeTrue())
+				Expect(hasPluginColumn(db, compressedURIColumn)).To(BeFalse())
+
+				plugins, err := inventory.GetPlugins(&PluginInventoryFilter{Name: "k8s-plugin"})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(plugins).To(HaveLen(1))
+				a := plugins[0].Artifacts["v1.0.0"]
+				Expect(a).To(HaveLen(1))
+				Expect(a[0].KubernetesVersions).To(Equal(">=1.26.0, <1.30.0"))
+				Expect(a[0].CompressedImage).To(BeEmpty())
+
+				// Plugins inserted before the column was added support any Kubernetes version
+				plugins, err = inventory.GetPlugins(&PluginInventoryFilter{Name: "isolated-cluster"})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(plugins).To(HaveLen(1))
+				Expect(plugins[0].Artifacts["v1.2.3"][0].KubernetesVersions).To(BeEmpty())
+			})
+		})
+		Context("When inserting plugins requiring a minimum CLI version", func() {
+			It("should add the minimum CLI version column and return the minimum CLI versions", func() {
+				err = inventory.InsertPlugin(&piEntry2)
+				Expect(err).To(BeNil(), "failed to insert plugin2")
+
+				db, err := sql.Open("sqlite", dbFile.Name())
+				Expect(err).To(BeNil())
+				defer db.Close()
+				Expect(hasPluginColumn(db, minCLIVersionColumn)).To(BeFalse())
+
+				minCLIVersionEntry := PluginInventoryEntry{
+					Name:        "recent-plugin",
+					Target:      types.TargetGlobal,
+					Description: "Plugin requiring a recent CLI",
+					Publisher:   "tkg",
+					Vendor:      "vmware",
+					Artifacts: distribution.Artifacts{
+						"v1.0.0": []distribution.Artifact{
+							{
+								OS:            "linux",
+								Arch:          "amd64",
+								Digest:        "5555555555",
+								Image:         "vmware/tkg/linux/amd64/global/recent-plugin:v1.0.0",
+								MinCLIVersion: "v1.3.0",
+							},
+						},
+					},
+				}
+				err = inventory.InsertPlugin(&minCLIVersionEntry)
+				Expect(err).To(BeNil(), "failed to insert the plugin requiring a minimum CLI version")
+				Expect(hasPluginColumn(db, minCLIVersionColumn)).To(BeTrue())
+				Expect(hasPluginColumn(db, compressedURIColumn)).To(BeFalse())
+
+				plugins, err := inventory.GetPlugins(&PluginInventoryFilter{Name: "recent-plugin"})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(plugins).To(HaveLen(1))
+				a := plugins[0].Artifacts["v1.0.0"]
+				Expect(a).To(HaveLen(1))
+				Expect(a[0].MinCLIVersion).To(Equal("v1.3.0"))
+				Expect(a[0].CompressedImage).To(BeEmpty())
+
+				// Plugins inserted before the column was added have no minimum CLI version
+				plugins, err = inventory.GetPlugins(&PluginInventoryFilter{Name: "isolated-cluster"})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(plugins).To(HaveLen(1))
+				Expect(plugins[0].Artifacts["v1.2.3"][0].MinCLIVersion).To(BeEmpty())
+			})
+		})
+		Context("When inserting plugins with their plugin runtime version", func() {
+			It("should add the plugin runtime version column and return the plugin runtime versions", func() {
+				err = inventory.InsertPlugin(&piEntry2)
+				Expect(err).To(BeNil(), "failed to insert plugin2")
+
+				db, err := sql.Open("sqlite", dbFile.Name())
+				Expect(err).To(BeNil())
+				defer db.Close()
+				Expect(hasPluginColumn(db, pluginRuntimeVersionColumn)).To(BeFalse())
+
+				runtimeVersionEntry := PluginInventoryEntry{
+					Name:        "runtime-plugin",
+					Target:      types.TargetGlobal,
+					Description: "Plugin with a known plugin runtime version",
+					Publisher:   "tkg",
+					Vendor:      "vmware",
+					Artifacts: distribution.Artifacts{
+						"v1.0.0": []distribution.Artifact{
+							{
+								OS:                   "linux",
+								Arch:                 "amd64",
+								Digest:               "6666666666",
+								Image:                "vmware/tkg/linux/amd64/global/runtime-plugin:v1.0.0",
+								PluginRuntimeVersion: "v1.4.7",
+							},
+						},
+					},
+				}
+				err = inventory.InsertPlugin(&runtimeVersionEntry)
+				Expect(err).To(BeNil(), "failed to insert the plugin with a plugin runtime version")
+				Expect(hasPluginColumn(db, pluginRuntimeVersionColumn)).To(BeTrue())
+
+				plugins, err := inventory.GetPlugins(&PluginInventoryFilter{Name: "runtime-plugin"})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(plugins).To(HaveLen(1))
+				Expect(plugins[0].Artifacts["v1.0.0"][0].PluginRuntimeVersion).To(Equal("v1.4.7"))
+
+				plugins, err = inventory.GetPlugins(&PluginInventoryFilter{Name: "isolated-cluster"})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(plugins).To(HaveLen(1))
+				Expect(plugins[0].Artifacts["v1.2.3"][0].PluginRuntimeVersion).To(BeEmpty())
+			})
+		})
+		Context("When inserting plugin advisories", func() {
+			It("should add the advisories table if missing and return the advisories of the plugin versions", func() {
+				err = inventory.InsertPlugin(&piEntry1)
+				Expect(err).To(BeNil(), "failed to insert plugin1")
+				err = inventory.InsertPlugin(&piEntry2)
+				Expect(err).To(BeNil(), "failed to insert plugin2")
+
+				// Databases published before advisories were supported don't have the table
+				db, err := sql.Open("sqlite", dbFile.Name())
+				Expect(err).To(BeNil())
+				defer db.Close()
+				_, err = db.Exec("DROP TABLE PluginAdvisories;")
+				Expect(err).To(BeNil())
+				Expect(hasTable(db, advisoriesTable)).To(BeFalse())
+
+				plugins, err := inventory.GetPlugins(&PluginInventoryFilter{Name: "management-cluster", Target: types.TargetK8s})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(plugins).To(HaveLen(1))
+				Expect(plugins[0].Advisories).To(BeEmpty())
+
+				advisory := &PluginAdvisory{
+					PluginIdentifier: PluginIdentifier{Name: "management-cluster", Target: types.TargetK8s, Version: "v0.28.0"},
+					ID:               "CVE-2024-12345",
+					Severity:         "high",
+					Summary:          "Credentials are logged",
+					URL:              "https://example.com/CVE-2024-12345",
+					FixedVersion:     "v0.28.1",
+				}
+				err = inventory.InsertPluginAdvisory(advisory)
+				Expect(err).To(BeNil(), "failed to insert the advisory")
+				Expect(hasTable(db, advisoriesTable)).To(BeTrue())
+
+				// Inserting the same advisory replaces it
+				advisory.Severity = "critical"
+				err = inventory.InsertPluginAdvisory(advisory)
+				Expect(err).To(BeNil(), "failed to replace the advisory")
+
+				plugins, err = inventory.GetPlugins(&PluginInventoryFilter{Name: "management-cluster", Target: types.TargetK8s})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(plugins).To(HaveLen(1))
+				Expect(plugins[0].Advisories).To(HaveLen(1))
+				Expect(plugins[0].Advisories["v0.28.0"]).To(Equal([]*PluginAdvisory{advisory}))
+
+				// Other plugins are not affected
+				plugins, err = inventory.GetPlugins(&PluginInventoryFilter{Name: "isolated-cluster"})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(plugins).To(HaveLen(1))
+				Expect(plugins[0].Advisories).To(BeEmpty())
+			})
+			It("should return an error if the plugin version doesn't exist", func() {
+				err = inventory.InsertPlugin(&piEntry1)
+				Expect(err).To(BeNil(), "failed to insert plugin1")
+
+				err = inventory.InsertPluginAdvisory(&PluginAdvisory{
+					PluginIdentifier: PluginIdentifier{Name: "management-cluster", Target: types.TargetK8s, Version: "v9.9.9"},
+					ID:               "CVE-2024-12345",
+				})
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(ContainSubstring("specified plugin 'name:management-cluster', 'target:kubernetes', 'version:v9.9.9' is not present in the database"))
+
+				err = inventory.InsertPluginAdvisory(&PluginAdvisory{
+					PluginIdentifier: PluginIdentifier{Name: "management-cluster", Target: types.TargetK8s, Version: "v0.28.0"},
+				})
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(ContainSubstring("the ID of an advisory are required"))
+			})
+		})
 		Context("When inserting a plugin which already exists in the database", func() {
 			BeforeEach(func() {
 				err = inventory.InsertPlugin(&piEntry1)
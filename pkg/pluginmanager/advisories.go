@@ -0,0 +1,97 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package pluginmanager
+
+import (
+	"fmt"
+	"strings"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/discovery"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/plugininventory"
+)
+
+// The publishers of the plugins can record in the plugin inventory the security advisories,
+// such as CVEs, affecting some plugin versions.  The CLI warns the user when installing such
+// versions or when such versions are installed, without preventing their use; the installation
+// policy can be used by administrators to prevent installing them.
+
+// GetPluginAdvisories returns the security advisories of each version of the plugin
+// found in the cache of the plugin inventories.  The target is optional.
+func GetPluginAdvisories(pluginName string, target configtypes.Target) (map[string][]*plugininventory.PluginAdvisory, error) {
+	criteria := &discovery.PluginDiscoveryCriteria{
+		Name:   pluginName,
+		Target: target,
+	}
+	plugins, err := DiscoverStandalonePlugins(discovery.WithPluginDiscoveryCriteria(criteria), discovery.WithUseLocalCacheOnly())
+	if err != nil {
+		return nil, err
+	}
+
+	advisories := make(map[string][]*plugininventory.PluginAdvisory)
+	for i := range plugins {
+		for version, versionAdvisories := range plugins[i].Advisories {
+			advisories[version] = append(advisories[version], versionAdvisories...)
+		}
+	}
+	return advisories, nil
+}
+
+// GetInstalledPluginsAdvisories returns the security advisories affecting the installed
+// version of the plugins, indexed by the name:target of the plugins.  The cache of the plugin
+// inventories is used so that this does not trigger any network access.
+func GetInstalledPluginsAdvisories(installedPlugins []cli.PluginInfo) (map[string][]*plugininventory.PluginAdvisory, error) {
+	plugins, err := DiscoverStandalonePlugins(discovery.WithUseLocalCacheOnly())
+	if err != nil {
+		return nil, err
+	}
+
+	advisories := make(map[string][]*plugininventory.PluginAdvisory)
+	for i := range installedPlugins {
+		for j := range plugins {
+			if installedPlugins[i].Name == plugins[j].Name && installedPlugins[i].Target == plugins[j].Target {
+				if versionAdvisories := plugins[j].Advisories[installedPlugins[i].Version]; len(versionAdvisories) > 0 {
+					key := fmt.Sprintf("%s:%s", installedPlugins[i].Name, installedPlugins[i].Target)
+					advisories[key] = versionAdvisories
+				}
+				break
+			}
+		}
+	}
+	return advisories, nil
+}
+
+// FormatAdvisories returns a one-line description of the advisories, e.g.,
+// "CVE-2024-12345 (high, fixed in v1.2.1), CVE-2024-23456 (low)"
+func FormatAdvisories(advisories []*plugininventory.PluginAdvisory) string {
+	descriptions := make([]string, 0, len(advisories))
+	for _, a := range advisories {
+		var details []string
+		if a.Severity != "" {
+			details = append(details, a.Severity)
+		}
+		if a.FixedVersion != "" {
+			details = append(details, "fixed in "+a.FixedVersion)
+		}
+		if len(details) == 0 {
+			descriptions = append(descriptions, a.ID)
+		} else {
+			descriptions = append(descriptions, fmt.Sprintf("%s (%s)", a.ID, strings.Join(details, ", ")))
+		}
+	}
+	return strings.Join(descriptions, ", ")
+}
+
+// warnAboutAdvisories prints a warning if security advisories affect the plugin version being installed
+func warnAboutAdvisories(p *discovery.Discovered, version string) {
+	advisories := p.Advisories[version]
+	if len(advisories) == 0 {
+		return
+	}
+	log.Warningf("plugin '%s:%s' for target '%s' is affected by security advisories: %s. Use 'tanzu plugin describe %s --target %s --advisories' for details",
+		p.Name, version, p.Target, FormatAdvisories(advisories), p.Name, p.Target)
+}
@@ -0,0 +1,51 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package pluginmanager
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/discovery"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/distribution"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/plugininventory"
+)
+
+func TestFormatAdvisories(t *testing.T) {
+	advisories := []*plugininventory.PluginAdvisory{
+		{ID: "CVE-2024-12345", Severity: "high", FixedVersion: "v1.2.1"},
+		{ID: "CVE-2024-23456", Severity: "low"},
+		{ID: "GHSA-xxxx"},
+	}
+	assert.Equal(t, "CVE-2024-12345 (high, fixed in v1.2.1), CVE-2024-23456 (low), GHSA-xxxx", FormatAdvisories(advisories))
+	assert.Equal(t, "", FormatAdvisories(nil))
+}
+
+func TestMergePluginEntriesAdvisories(t *testing.T) {
+	advisory := &plugininventory.PluginAdvisory{
+		PluginIdentifier: plugininventory.PluginIdentifier{Name: "cluster", Target: configtypes.TargetK8s, Version: "v1.1.0"},
+		ID:               "CVE-2024-12345",
+	}
+	plugin1 := &discovery.Discovered{
+		Name:              "cluster",
+		Target:            configtypes.TargetK8s,
+		SupportedVersions: []string{"v1.0.0"},
+		Distribution:      distribution.Artifacts{"v1.0.0": nil},
+	}
+	plugin2 := &discovery.Discovered{
+		Name:              "cluster",
+		Target:            configtypes.TargetK8s,
+		SupportedVersions: []string{"v1.1.0"},
+		Distribution:      distribution.Artifacts{"v1.1.0": nil},
+		Advisories:        map[string][]*plugininventory.PluginAdvisory{"v1.1.0": {advisory}},
+	}
+
+	merged := mergePluginEntries(plugin1, plugin2)
+	assert.Equal(t, []string{"v1.0.0", "v1.1.0"}, merged.SupportedVersions)
+	assert.Equal(t, []*plugininventory.PluginAdvisory{advisory}, merged.Advisories["v1.1.0"])
+	assert.Empty(t, merged.Advisories["v1.0.0"])
+}
@@ -0,0 +1,109 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package pluginmanager
+
+import (
+	"slices"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/catalog"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/common"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/discovery"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/pluginsupplier"
+)
+
+// The plugins recommended by a context are installed as standalone plugins when the context
+// is created, used or synced.  The catalog records which of these plugins were installed for
+// the context so that they can be uninstalled when the context is deleted.  The plugins which
+// were installed independently of any context are never recorded, and therefore never
+// uninstalled along with a context.  A plugin which the user explicitly installs after a
+// context installed it is no longer recorded either.
+
+// OnContextPluginsSynced is the hook run once the plugins recommended by a context have been
+// synced. It records the plugins installed for the context, along with the recommended plugins
+// already installed for other contexts, which the context now shares with them.
+// The recommended plugins must have the installation status they had before the sync.
+func OnContextPluginsSynced(contextName string, recommended, installed []discovery.Discovered) error {
+	var pluginKeys []string
+	for i := range installed {
+		pluginKeys = append(pluginKeys, catalog.PluginNameTarget(installed[i].Name, installed[i].Target))
+	}
+	for i := range recommended {
+		key := catalog.PluginNameTarget(recommended[i].Name, recommended[i].Target)
+		if recommended[i].Status != common.PluginStatusNotInstalled && catalog.IsContextPlugin(key) {
+			pluginKeys = append(pluginKeys, key)
+		}
+	}
+	return catalog.AddContextPlugins(contextName, pluginKeys)
+}
+
+// GetContextPluginsToUninstall returns the installed plugins which are uninstalled along with
+// the context, i.e. the ones installed for the context and not needed by any other context
+func GetContextPluginsToUninstall(contextName string) ([]cli.PluginInfo, error) {
+	pluginKeys, err := catalog.GetOrphanedContextPlugins(contextName)
+	if err != nil {
+		return nil, err
+	}
+	return getInstalledPluginsByKeys(pluginKeys)
+}
+
+// OnContextDeleted is the hook run when a context is deleted. It forgets the plugins installed
+// for the context and, unless keepPlugins is set, uninstalls the ones not needed by any other
+// context, which it returns. The plugins which are kept remain installed as standalone plugins.
+func OnContextDeleted(contextName string, keepPlugins bool) ([]cli.PluginInfo, error) {
+	pluginKeys, err := catalog.RemoveContextPlugins(contextName)
+	if err != nil || keepPlugins {
+		return nil, err
+	}
+	plugins, err := getInstalledPluginsByKeys(pluginKeys)
+	if err != nil || len(plugins) == 0 {
+		return nil, err
+	}
+
+	for i := range plugins {
+		// Delete the plugins from the command tree cache which would be consumed by telemetry
+		deletePluginFromCommandTreeCache(&plugins[i])
+	}
+	return plugins, managerConfig{}.doDeletePluginsFromCatalog(plugins)
+}
+
+// OnPluginInstalledByUser is the hook run once the user explicitly installed a plugin.
+// The plugin is no longer recorded as installed for any context, so that it remains
+// installed when the contexts which recommended it are deleted.  If the target is
+// unknown, the plugin is forgotten for all of its targets.
+func OnPluginInstalledByUser(pluginName string, target configtypes.Target) error {
+	installedPlugins, err := pluginsupplier.GetInstalledPlugins()
+	if err != nil {
+		return err
+	}
+
+	var pluginKeys []string
+	for i := range installedPlugins {
+		if installedPlugins[i].Name == pluginName && (target == configtypes.TargetUnknown || installedPlugins[i].Target == target) {
+			pluginKeys = append(pluginKeys, catalog.PluginNameTarget(installedPlugins[i].Name, installedPlugins[i].Target))
+		}
+	}
+	return catalog.ForgetContextPlugins(pluginKeys)
+}
+
+// getInstalledPluginsByKeys returns the installed plugins identified by their name and target
+func getInstalledPluginsByKeys(pluginKeys []string) ([]cli.PluginInfo, error) {
+	if len(pluginKeys) == 0 {
+		return nil, nil
+	}
+	installedPlugins, err := pluginsupplier.GetInstalledPlugins()
+	if err != nil {
+		return nil, err
+	}
+
+	var plugins []cli.PluginInfo
+	for i := range installedPlugins {
+		if slices.Contains(pluginKeys, catalog.PluginNameTarget(installedPlugins[i].Name, installedPlugins[i].Target)) {
+			plugins = append(plugins, installedPlugins[i])
+		}
+	}
+	return plugins, nil
+}
@@ -0,0 +1,105 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package pluginmanager
+
+import (
+	"os/exec"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/catalog"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/common"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/discovery"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/pluginsupplier"
+)
+
+func TestContextPluginsLifecycle(t *testing.T) {
+	assertions := assert.New(t)
+
+	defer setupPluginSourceForTesting()()
+	setupTestPluginCatalog()
+
+	// "cluster" was installed for ctx1, "feature" was installed independently of any context
+	recommended := []discovery.Discovered{
+		{Name: "cluster", Target: configtypes.TargetK8s, Status: common.PluginStatusNotInstalled},
+		{Name: "feature", Target: configtypes.TargetK8s, Status: common.PluginStatusInstalled},
+	}
+	assertions.Nil(OnContextPluginsSynced("ctx1", recommended, recommended[:1]))
+	// ctx2 shares "cluster" with ctx1 and also recommends "feature"
+	recommended[0].Status = common.PluginStatusInstalled
+	assertions.Nil(OnContextPluginsSynced("ctx2", recommended, nil))
+	assertions.False(catalog.IsContextPlugin(catalog.PluginNameTarget("feature", configtypes.TargetK8s)))
+
+	// The plugins shared with another context are not uninstalled
+	plugins, err := GetContextPluginsToUninstall("ctx1")
+	assertions.Nil(err)
+	assertions.Empty(plugins)
+	plugins, err = OnContextDeleted("ctx1", false)
+	assertions.Nil(err)
+	assertions.Empty(plugins)
+	assertions.True(checkPluginIsInstalled("cluster", configtypes.TargetK8s))
+
+	plugins, err = GetContextPluginsToUninstall("ctx2")
+	assertions.Nil(err)
+	assertions.Equal(1, len(plugins))
+	assertions.Equal("cluster", plugins[0].Name)
+
+	plugins, err = OnContextDeleted("ctx2", false)
+	assertions.Nil(err)
+	assertions.Equal(1, len(plugins))
+	assertions.False(checkPluginIsInstalled("cluster", configtypes.TargetK8s))
+	assertions.True(checkPluginIsInstalled("cluster", configtypes.TargetTMC))
+	assertions.True(checkPluginIsInstalled("feature", configtypes.TargetK8s))
+}
+
+func TestContextPluginsKeptOnContextDeletion(t *testing.T) {
+	assertions := assert.New(t)
+
+	defer setupPluginSourceForTesting()()
+	setupTestPluginCatalog()
+
+	installed := []discovery.Discovered{{Name: "secret", Target: configtypes.TargetK8s, Status: common.PluginStatusNotInstalled}}
+	assertions.Nil(OnContextPluginsSynced("ctx1", installed, installed))
+
+	// The kept plugins remain installed as standalone plugins
+	plugins, err := OnContextDeleted("ctx1", true)
+	assertions.Nil(err)
+	assertions.Empty(plugins)
+	assertions.True(checkPluginIsInstalled("secret", configtypes.TargetK8s))
+	assertions.False(catalog.IsContextPlugin(catalog.PluginNameTarget("secret", configtypes.TargetK8s)))
+}
+
+func TestContextPluginInstalledByUserKeptOnContextDeletion(t *testing.T) {
+	assertions := assert.New(t)
+
+	defer setupPluginSourceForTesting()()
+	execCommand = fakeInfoExecCommand
+	defer func() { execCommand = exec.Command }()
+
+	// The plugin is installed through the context
+	assertions.Nil(InstallStandalonePlugin("login", "v0.2.0", configtypes.TargetUnknown))
+	installedPlugins, err := pluginsupplier.GetInstalledPlugins()
+	assertions.Nil(err)
+	assertions.Equal(1, len(installedPlugins))
+	installed := []discovery.Discovered{{Name: "login", Target: installedPlugins[0].Target, Status: common.PluginStatusNotInstalled}}
+	assertions.Nil(OnContextPluginsSynced("ctx1", installed, installed))
+	assertions.True(catalog.IsContextPlugin(catalog.PluginNameTarget("login", installedPlugins[0].Target)))
+
+	// The user then installs the plugin explicitly
+	assertions.Nil(InstallStandalonePlugin("login", "v0.20.0", configtypes.TargetUnknown))
+	assertions.Nil(OnPluginInstalledByUser("login", configtypes.TargetUnknown))
+	assertions.False(catalog.IsContextPlugin(catalog.PluginNameTarget("login", installedPlugins[0].Target)))
+
+	// The plugin survives the deletion of the context
+	plugins, err := OnContextDeleted("ctx1", false)
+	assertions.Nil(err)
+	assertions.Empty(plugins)
+	installedPlugins, err = pluginsupplier.GetInstalledPlugins()
+	assertions.Nil(err)
+	assertions.Equal(1, len(installedPlugins))
+	assertions.Equal("v0.20.0", installedPlugins[0].Version)
+}
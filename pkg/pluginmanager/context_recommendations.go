@@ -0,0 +1,146 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package pluginmanager
+
+import (
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/catalog"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/centralconfig"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/common"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/discovery"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/plugininventory"
+)
+
+// Besides the plugins recommended by a context itself, the central configuration can recommend
+// plugins and plugin groups to every context of a given type, e.g.:
+//
+//	cli.core.tanzu_cli_context_recommended_plugins:
+//	  mission-control:
+//	    groups:
+//	    - vmware-tmc/default
+//	  kubernetes:
+//	    plugins:
+//	    - name: package
+//	      version: v1.2.0
+//
+// The context types are the ones shown by "tanzu context list", "k8s" and "tmc" also being accepted.
+// The plugins are resolved from the local cache of the plugin discovery sources, like the central
+// configuration itself, and are only recommended if they are not already recommended by the context.
+
+// contextTypeAliases maps the short names of the context types to the context types
+var contextTypeAliases = map[string]configtypes.ContextType{
+	"k8s": configtypes.ContextTypeK8s,
+	"tmc": configtypes.ContextTypeTMC,
+}
+
+// getCentralConfigRecommendedPlugins returns the plugins the central configuration
+// recommends to every context of the type of the specified context
+func getCentralConfigRecommendedPlugins(context *configtypes.Context) []discovery.Discovered {
+	recommendations := centralconfig.ContextTypeToRecommendedPluginsMap{}
+	if !centralconfig.GetObject(centralconfig.KeyContextRecommendedPlugins, &recommendations) {
+		return nil
+	}
+
+	var plugins []discovery.Discovered
+	for contextType, recommended := range recommendations {
+		if alias, exists := contextTypeAliases[contextType]; exists {
+			contextType = string(alias)
+		}
+		if configtypes.ContextType(contextType) != context.ContextType {
+			continue
+		}
+
+		target := getContextTypeTarget(context.ContextType)
+		for _, p := range recommended.Plugins {
+			pluginTarget := target
+			if p.Target != "" {
+				pluginTarget = configtypes.StringToTarget(p.Target)
+			}
+			if plugin := resolveContextRecommendedPlugin(p.Name, pluginTarget, p.Version); plugin != nil {
+				plugins = append(plugins, *plugin)
+			}
+		}
+		for _, groupID := range recommended.Groups {
+			plugins = append(plugins, resolveContextRecommendedGroup(groupID)...)
+		}
+	}
+
+	for i := range plugins {
+		plugins[i].Scope = common.PluginScopeContext
+		plugins[i].Status = common.PluginStatusNotInstalled
+		plugins[i].ContextName = context.Name
+	}
+	return plugins
+}
+
+// resolveContextRecommendedPlugin returns the specified plugin, with its recommended version set to the
+// latest version matching the specified one, or nil if it cannot be found in the discovery sources
+func resolveContextRecommendedPlugin(name string, target configtypes.Target, version string) *discovery.Discovered {
+	criteria := &discovery.PluginDiscoveryCriteria{
+		Name:    name,
+		Target:  target,
+		Version: version,
+		OS:      cli.GOOS,
+		Arch:    cli.GOARCH,
+	}
+	matchedPlugins, err := DiscoverStandalonePlugins(discovery.WithPluginDiscoveryCriteria(criteria), discovery.WithUseLocalCacheOnly())
+	if err != nil || len(matchedPlugins) != 1 {
+		log.V(6).Infof("unable to find plugin '%s:%s' recommended by the central configuration", name, target)
+		return nil
+	}
+	return &matchedPlugins[0]
+}
+
+// resolveContextRecommendedGroup returns the mandatory plugins of the specified plugin group
+func resolveContextRecommendedGroup(groupIDAndVersion string) []discovery.Discovered {
+	groupIdentifier := plugininventory.PluginGroupIdentifierFromID(groupIDAndVersion)
+	if groupIdentifier == nil {
+		log.V(6).Infof("invalid plugin group '%s' recommended by the central configuration", groupIDAndVersion)
+		return nil
+	}
+	if groupIdentifier.Version == "" {
+		groupIdentifier.Version = cli.VersionLatest
+	}
+	groups, err := GetCachedPluginGroups(plugininventory.GroupCriteria{
+		Vendor:    groupIdentifier.Vendor,
+		Publisher: groupIdentifier.Publisher,
+		Name:      groupIdentifier.Name,
+		Version:   groupIdentifier.Version,
+	})
+	if err != nil || len(groups) == 0 {
+		log.V(6).Infof("unable to find plugin group '%s' recommended by the central configuration", groupIDAndVersion)
+		return nil
+	}
+
+	var plugins []discovery.Discovered
+	for _, entry := range groups[0].Versions[groups[0].RecommendedVersion] {
+		if !entry.Mandatory {
+			continue
+		}
+		if plugin := resolveContextRecommendedPlugin(entry.Name, entry.Target, entry.Version); plugin != nil {
+			plugins = append(plugins, *plugin)
+		}
+	}
+	return plugins
+}
+
+// mergeContextRecommendedPlugins adds to the plugins recommended by a context the additional
+// recommended plugins which the context does not already recommend
+func mergeContextRecommendedPlugins(contextPlugins, additionalPlugins []discovery.Discovered) []discovery.Discovered {
+	recommended := make(map[string]bool, len(contextPlugins))
+	for i := range contextPlugins {
+		recommended[catalog.PluginNameTarget(contextPlugins[i].Name, contextPlugins[i].Target)] = true
+	}
+	for i := range additionalPlugins {
+		key := catalog.PluginNameTarget(additionalPlugins[i].Name, additionalPlugins[i].Target)
+		if !recommended[key] {
+			recommended[key] = true
+			contextPlugins = append(contextPlugins, additionalPlugins[i])
+		}
+	}
+	return contextPlugins
+}
@@ -0,0 +1,90 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package pluginmanager
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/centralconfig"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/centralconfig/fakes"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/common"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/discovery"
+)
+
+func TestGetCentralConfigRecommendedPlugins(t *testing.T) {
+	assertions := assert.New(t)
+
+	defer setupPluginSourceForTesting()()
+
+	fakeCentralConfig := &fakes.CentralConfig{}
+	fakeCentralConfig.GetCentralConfigEntryStub = func(key string, out interface{}) error {
+		// Other entries, such as the vendor policy, are read when discovering the plugins
+		if key != centralconfig.KeyContextRecommendedPlugins {
+			return &centralconfig.KeyNotFoundError{Key: key}
+		}
+		*(out.(*centralconfig.ContextTypeToRecommendedPluginsMap)) = centralconfig.ContextTypeToRecommendedPluginsMap{
+			"tmc": {
+				Plugins: []centralconfig.ContextRecommendedPlugin{{Name: "cluster"}},
+			},
+			"kubernetes": {
+				Plugins: []centralconfig.ContextRecommendedPlugin{{Name: "login", Target: "global", Version: "v0.2.0"}, {Name: "unknown"}},
+				Groups:  []string{"vmware-test/default:v1.6.0"},
+			},
+		}
+		return nil
+	}
+	originalReader := centralconfig.DefaultCentralConfigReader
+	centralconfig.DefaultCentralConfigReader = fakeCentralConfig
+	defer func() { centralconfig.DefaultCentralConfigReader = originalReader }()
+
+	// The plugins of the short context type name are recommended with the target of the context type
+	plugins := getCentralConfigRecommendedPlugins(&configtypes.Context{Name: "tmc-ctx", ContextType: configtypes.ContextTypeTMC})
+	assertions.Equal(1, len(plugins))
+	assertions.Equal("cluster", plugins[0].Name)
+	assertions.Equal(configtypes.TargetTMC, plugins[0].Target)
+	assertions.Equal("v0.2.0", plugins[0].RecommendedVersion)
+	assertions.Equal("tmc-ctx", plugins[0].ContextName)
+	assertions.Equal(common.PluginScopeContext, plugins[0].Scope)
+
+	// The mandatory plugins of the groups are recommended, the unknown plugins are ignored
+	plugins = getCentralConfigRecommendedPlugins(&configtypes.Context{Name: "k8s-ctx", ContextType: configtypes.ContextTypeK8s})
+	login := findDiscoveredPlugin(plugins, "login", configtypes.TargetGlobal)
+	assertions.NotNil(login)
+	assertions.Equal("v0.2.0", login.RecommendedVersion)
+	feature := findDiscoveredPlugin(plugins, "feature", configtypes.TargetK8s)
+	assertions.NotNil(feature)
+	assertions.Equal("v0.2.0", feature.RecommendedVersion)
+	assertions.NotNil(findDiscoveredPlugin(plugins, "management-cluster", configtypes.TargetK8s))
+	assertions.Nil(findDiscoveredPlugin(plugins, "unknown", configtypes.TargetK8s))
+	// The optional plugins of the groups are not recommended
+	assertions.Nil(findDiscoveredPlugin(plugins, "cluster", configtypes.TargetK8s))
+
+	// Nothing is recommended to the other context types
+	assertions.Empty(getCentralConfigRecommendedPlugins(&configtypes.Context{Name: "tanzu-ctx", ContextType: configtypes.ContextTypeTanzu}))
+}
+
+func TestMergeContextRecommendedPlugins(t *testing.T) {
+	assertions := assert.New(t)
+
+	contextPlugins := []discovery.Discovered{
+		{Name: "cluster", Target: configtypes.TargetK8s, RecommendedVersion: "v1.0.0"},
+	}
+	additionalPlugins := []discovery.Discovered{
+		{Name: "cluster", Target: configtypes.TargetK8s, RecommendedVersion: "v2.0.0"},
+		{Name: "cluster", Target: configtypes.TargetTMC, RecommendedVersion: "v2.0.0"},
+		{Name: "apps", Target: configtypes.TargetK8s, RecommendedVersion: "v0.1.0"},
+		{Name: "apps", Target: configtypes.TargetK8s, RecommendedVersion: "v0.2.0"},
+	}
+
+	merged := mergeContextRecommendedPlugins(contextPlugins, additionalPlugins)
+	assertions.Equal(3, len(merged))
+	// The version recommended by the context takes precedence
+	assertions.Equal("v1.0.0", findDiscoveredPlugin(merged, "cluster", configtypes.TargetK8s).RecommendedVersion)
+	assertions.NotNil(findDiscoveredPlugin(merged, "cluster", configtypes.TargetTMC))
+	assertions.Equal("v0.1.0", findDiscoveredPlugin(merged, "apps", configtypes.TargetK8s).RecommendedVersion)
+}
@@ -0,0 +1,131 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package pluginmanager
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/centralconfig"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/discovery"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/utils"
+)
+
+// The installation policy prevents installing plugin versions which are too old or which
+// are known to be defective.  It is read from the central configuration, e.g.:
+//
+//	cli.core.tanzu_cli_plugin_installation_policy:
+//	  action: block
+//	  maxVersionsBehind: 10
+//	  minimumVersions:
+//	  - name: cluster
+//	    target: kubernetes
+//	    version: v1.2.0
+//	  revokedVersions:
+//	  - name: package
+//	    versions: [v1.3.0, v1.3.1]
+//	    reason: CVE-2024-12345
+//
+// Administrators can install such versions anyway using the --ignore-installation-policy flag
+// or the TANZU_CLI_IGNORE_PLUGIN_INSTALLATION_POLICY variable, in which case a warning is printed.
+
+const installationPolicyActionWarn = "warn"
+
+// ignoreInstallationPolicy is set when the installation policy should only print warnings
+var ignoreInstallationPolicy bool
+
+// SetIgnoreInstallationPolicy sets whether the plugin installation policy should only print
+// warnings instead of preventing installations. It returns a function restoring the previous value.
+func SetIgnoreInstallationPolicy(ignore bool) func() {
+	previous := ignoreInstallationPolicy
+	ignoreInstallationPolicy = ignore
+	return func() { ignoreInstallationPolicy = previous }
+}
+
+func isInstallationPolicyIgnored() bool {
+	if ignoreInstallationPolicy {
+		return true
+	}
+	ignore, _ := strconv.ParseBool(os.Getenv(constants.IgnorePluginInstallationPolicy))
+	return ignore
+}
+
+// policyAppliesToPlugin returns true if a policy entry of the specified name and target applies to the plugin
+func policyAppliesToPlugin(name, target string, p *discovery.Discovered) bool {
+	if name != p.Name {
+		return false
+	}
+	return target == "" || configtypes.StringToTarget(strings.ToLower(target)) == p.Target
+}
+
+// getInstallationPolicyViolations returns the reasons why the installation policy
+// does not allow installing the specified version of the plugin
+func getInstallationPolicyViolations(policy *centralconfig.PluginInstallationPolicy, p *discovery.Discovered, version string) []string {
+	var violations []string
+
+	for _, revoked := range policy.RevokedVersions {
+		if !policyAppliesToPlugin(revoked.Name, revoked.Target, p) {
+			continue
+		}
+		for _, v := range revoked.Versions {
+			if v == version {
+				msg := "the version has been revoked"
+				if revoked.Reason != "" {
+					msg = fmt.Sprintf("%s (%s)", msg, revoked.Reason)
+				}
+				violations = append(violations, msg)
+			}
+		}
+	}
+
+	for _, minimum := range policy.MinimumVersions {
+		if policyAppliesToPlugin(minimum.Name, minimum.Target, p) && utils.IsNewVersion(minimum.Version, version) {
+			violations = append(violations, fmt.Sprintf("the minimum version allowed is %s", minimum.Version))
+		}
+	}
+
+	if policy.MaxVersionsBehind > 0 {
+		newerVersions := 0
+		for _, v := range p.SupportedVersions {
+			if utils.IsNewVersion(v, version) {
+				newerVersions++
+			}
+		}
+		if newerVersions > policy.MaxVersionsBehind {
+			violations = append(violations, fmt.Sprintf("%d more recent versions are available while at most %d are allowed", newerVersions, policy.MaxVersionsBehind))
+		}
+	}
+
+	return violations
+}
+
+// verifyInstallationPolicy verifies that the installation policy of the central configuration
+// allows installing the specified version of the plugin.  If the policy action is "warn" or
+// if the policy is ignored, a warning is printed instead of returning an error.
+func verifyInstallationPolicy(p *discovery.Discovered, version string) error {
+	policy := centralconfig.PluginInstallationPolicy{}
+	if !centralconfig.GetObject(centralconfig.KeyPluginInstallationPolicy, &policy) {
+		return nil
+	}
+
+	violations := getInstallationPolicyViolations(&policy, p, version)
+	if len(violations) == 0 {
+		return nil
+	}
+
+	msg := fmt.Sprintf("the installation policy does not allow installing plugin '%s:%s' for target '%s': %s", p.Name, version, p.Target, strings.Join(violations, ", "))
+	if strings.EqualFold(policy.Action, installationPolicyActionWarn) || isInstallationPolicyIgnored() {
+		log.Warningf("%s", msg)
+		return nil
+	}
+	return errors.Errorf("%s. Please install a more recent version, or use the --ignore-installation-policy flag to install the plugin anyway", msg)
+}
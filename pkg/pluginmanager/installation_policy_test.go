@@ -0,0 +1,116 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package pluginmanager
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/centralconfig"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/centralconfig/fakes"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/discovery"
+)
+
+func TestGetInstallationPolicyViolations(t *testing.T) {
+	policy := &centralconfig.PluginInstallationPolicy{
+		MaxVersionsBehind: 2,
+		MinimumVersions: []centralconfig.PluginMinimumVersion{
+			{Name: "cluster", Target: "k8s", Version: "v1.1.0"},
+		},
+		RevokedVersions: []centralconfig.PluginRevokedVersions{
+			{Name: "cluster", Versions: []string{"v1.2.0"}, Reason: "CVE-2024-12345"},
+		},
+	}
+	plugin := &discovery.Discovered{
+		Name:              "cluster",
+		Target:            configtypes.TargetK8s,
+		SupportedVersions: []string{"v1.0.0", "v1.1.0", "v1.2.0", "v1.3.0", "v1.4.0"},
+	}
+
+	tests := []struct {
+		test     string
+		plugin   *discovery.Discovered
+		version  string
+		expected []string
+	}{
+		{
+			test:     "compliant version",
+			plugin:   plugin,
+			version:  "v1.3.0",
+			expected: nil,
+		},
+		{
+			test:     "revoked version",
+			plugin:   plugin,
+			version:  "v1.2.0",
+			expected: []string{"the version has been revoked (CVE-2024-12345)"},
+		},
+		{
+			test:    "version older than the minimum version and too old",
+			plugin:  plugin,
+			version: "v1.0.0",
+			expected: []string{
+				"the minimum version allowed is v1.1.0",
+				"4 more recent versions are available while at most 2 are allowed",
+			},
+		},
+		{
+			test:     "minimum version of another target",
+			plugin:   &discovery.Discovered{Name: "cluster", Target: configtypes.TargetTMC, SupportedVersions: []string{"v1.0.0"}},
+			version:  "v1.0.0",
+			expected: nil,
+		},
+	}
+
+	for _, spec := range tests {
+		t.Run(spec.test, func(t *testing.T) {
+			assert.Equal(t, spec.expected, getInstallationPolicyViolations(policy, spec.plugin, spec.version))
+		})
+	}
+}
+
+func TestVerifyInstallationPolicy(t *testing.T) {
+	assertions := assert.New(t)
+
+	policy := centralconfig.PluginInstallationPolicy{
+		RevokedVersions: []centralconfig.PluginRevokedVersions{
+			{Name: "cluster", Versions: []string{"v1.2.0"}},
+		},
+	}
+	fakeCentralConfig := &fakes.CentralConfig{}
+	fakeCentralConfig.GetCentralConfigEntryStub = func(key string, out interface{}) error {
+		if key != centralconfig.KeyPluginInstallationPolicy {
+			return &centralconfig.KeyNotFoundError{Key: key}
+		}
+		*(out.(*centralconfig.PluginInstallationPolicy)) = policy
+		return nil
+	}
+	originalReader := centralconfig.DefaultCentralConfigReader
+	centralconfig.DefaultCentralConfigReader = fakeCentralConfig
+	defer func() { centralconfig.DefaultCentralConfigReader = originalReader }()
+
+	plugin := &discovery.Discovered{Name: "cluster", Target: configtypes.TargetK8s}
+	assertions.Nil(verifyInstallationPolicy(plugin, "v1.3.0"))
+	err := verifyInstallationPolicy(plugin, "v1.2.0")
+	assertions.ErrorContains(err, "the installation policy does not allow installing plugin 'cluster:v1.2.0' for target 'kubernetes': the version has been revoked")
+	assertions.ErrorContains(err, "--ignore-installation-policy")
+
+	// The policy can be ignored using the flag or the variable
+	restore := SetIgnoreInstallationPolicy(true)
+	assertions.Nil(verifyInstallationPolicy(plugin, "v1.2.0"))
+	restore()
+	assertions.NotNil(verifyInstallationPolicy(plugin, "v1.2.0"))
+
+	t.Setenv(constants.IgnorePluginInstallationPolicy, "true")
+	assertions.Nil(verifyInstallationPolicy(plugin, "v1.2.0"))
+	t.Setenv(constants.IgnorePluginInstallationPolicy, "")
+
+	// A policy whose action is "warn" does not prevent the installation
+	policy.Action = "warn"
+	assertions.Nil(verifyInstallationPolicy(plugin, "v1.2.0"))
+}
@@ -0,0 +1,183 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package pluginmanager
+
+import (
+	"os"
+	"strconv"
+
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/discovery"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/distribution"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/plugininventory"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/utils"
+)
+
+// getInventoryCaches returns the caches of the plugin inventories of the
+// discovery sources, in the order in which the sources are searched
+func getInventoryCaches() ([]*plugininventory.InventoryCache, error) {
+	discoveries, err := getPluginDiscoveries()
+	if err != nil {
+		return nil, err
+	}
+	if len(discoveries) == 0 {
+		return nil, errors.New(errorNoDiscoverySourcesFound)
+	}
+
+	var caches []*plugininventory.InventoryCache
+	for _, d := range discoveries {
+		if cache := discovery.GetInventoryCache(d); cache != nil {
+			caches = append(caches, cache)
+		}
+	}
+	return caches, nil
+}
+
+// includeHiddenForTesting returns true if the hidden plugins and groups must be included
+func includeHiddenForTesting() bool {
+	includeHidden, _ := strconv.ParseBool(os.Getenv(constants.ConfigVariableIncludeDeactivatedPluginsForTesting))
+	return includeHidden
+}
+
+// GetCachedPlugins returns the plugins matching the criteria found in the plugin inventories
+// already downloaded for the discovery sources, which are not refreshed, and allowed by the
+// vendor policy. A plugin found in more than one source is only returned once, with the
+// versions of all the sources. As for the discovered plugins, the plugins found are returned
+// along with an aggregated error of the sources which could not be read.
+func GetCachedPlugins(criteria plugininventory.PluginCriteria) ([]*plugininventory.PluginInventoryEntry, error) {
+	caches, err := getInventoryCaches()
+	if err != nil {
+		return nil, err
+	}
+	criteria.IncludeHidden = criteria.IncludeHidden || includeHiddenForTesting()
+
+	policy := getVendorPolicy()
+	var plugins []*plugininventory.PluginInventoryEntry
+	pluginsByKey := map[string]*plugininventory.PluginInventoryEntry{}
+	errorList := make([]error, 0)
+	for _, cache := range caches {
+		entries, err := cache.GetPlugins(criteria)
+		if err != nil {
+			errorList = append(errorList, err)
+			continue
+		}
+		for _, entry := range entries {
+			if !policy.isPluginAllowed(entry.Vendor, entry.Publisher, entry.Name) {
+				continue
+			}
+			// As for the discovered plugins, the first source found has priority and plugins
+			// with the `k8s` and `none` targets are considered the same for backward compatibility
+			target := entry.Target
+			if target == configtypes.TargetUnknown {
+				target = configtypes.TargetK8s
+			}
+			key := entry.Name + "_" + string(target)
+			existing, found := pluginsByKey[key]
+			if !found {
+				pluginsByKey[key] = entry
+				plugins = append(plugins, entry)
+				continue
+			}
+			mergePluginInventoryEntries(existing, entry)
+		}
+	}
+	return plugins, kerrors.NewAggregate(errorList)
+}
+
+// mergePluginInventoryEntries adds to the first entry the versions of the second entry it does not have
+func mergePluginInventoryEntries(entry1, entry2 *plugininventory.PluginInventoryEntry) {
+	if entry1.Target == configtypes.TargetUnknown {
+		entry1.Target = entry2.Target
+	}
+	if entry1.Artifacts == nil {
+		entry1.Artifacts = distribution.Artifacts{}
+	}
+	for version, artifacts := range entry2.Artifacts {
+		if _, exists := entry1.Artifacts[version]; exists {
+			continue
+		}
+		entry1.Artifacts[version] = artifacts
+		if advisories := entry2.Advisories[version]; len(advisories) > 0 {
+			if entry1.Advisories == nil {
+				entry1.Advisories = map[string][]*plugininventory.PluginAdvisory{}
+			}
+			entry1.Advisories[version] = advisories
+		}
+	}
+}
+
+// GetCachedPluginVersions returns the versions, sorted in ascending order, of the plugins
+// matching the criteria found in the plugin inventories already downloaded for the discovery
+// sources and allowed by the vendor policy, ignoring the version of the criteria
+func GetCachedPluginVersions(criteria plugininventory.PluginCriteria) ([]string, error) {
+	caches, err := getInventoryCaches()
+	if err != nil {
+		return nil, err
+	}
+	criteria.IncludeHidden = criteria.IncludeHidden || includeHiddenForTesting()
+	criteria.Version = ""
+
+	policy := getVendorPolicy()
+	encountered := map[string]bool{}
+	var versions []string
+	errorList := make([]error, 0)
+	for _, cache := range caches {
+		entries, err := cache.GetPlugins(criteria)
+		if err != nil {
+			errorList = append(errorList, err)
+			continue
+		}
+		allowed := false
+		for _, entry := range entries {
+			allowed = allowed || policy.isPluginAllowed(entry.Vendor, entry.Publisher, entry.Name)
+		}
+		if !allowed {
+			continue
+		}
+
+		cacheVersions, err := cache.GetAllVersions(criteria)
+		if err != nil {
+			errorList = append(errorList, err)
+			continue
+		}
+		for _, v := range cacheVersions {
+			if !encountered[v] {
+				encountered[v] = true
+				versions = append(versions, v)
+			}
+		}
+	}
+	if err := utils.SortVersions(versions); err != nil {
+		errorList = append(errorList, err)
+	}
+	return versions, kerrors.NewAggregate(errorList)
+}
+
+// GetCachedPluginGroups returns the plugin groups matching the criteria found in the plugin
+// inventories already downloaded for the discovery sources, which are not refreshed, and
+// allowed by the vendor policy
+func GetCachedPluginGroups(criteria plugininventory.GroupCriteria) ([]*plugininventory.PluginGroup, error) {
+	caches, err := getInventoryCaches()
+	if err != nil {
+		return nil, err
+	}
+	criteria.IncludeHidden = criteria.IncludeHidden || includeHiddenForTesting()
+
+	var groups []*plugininventory.PluginGroup
+	errorList := make([]error, 0)
+	for _, cache := range caches {
+		cacheGroups, err := cache.GetGroups(criteria)
+		if err != nil {
+			errorList = append(errorList, err)
+			continue
+		}
+		groups = append(groups, cacheGroups...)
+	}
+	return filterGroupsByVendorPolicy(mergeDuplicateGroups(groups)), kerrors.NewAggregate(errorList)
+}
@@ -0,0 +1,57 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package pluginmanager
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/discovery"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/plugininventory"
+)
+
+func TestGetCachedPluginsMatchesDiscovery(t *testing.T) {
+	assertions := assert.New(t)
+
+	defer setupPluginSourceForTesting()()
+
+	discovered, err := DiscoverStandalonePlugins(discovery.WithUseLocalCacheOnly())
+	assertions.Nil(err)
+	assertions.NotEmpty(discovered)
+
+	cached, err := GetCachedPlugins(plugininventory.PluginCriteria{})
+	assertions.Nil(err)
+	assertions.Equal(len(discovered), len(cached))
+
+	for i := range discovered {
+		criteria := plugininventory.PluginCriteria{Name: discovered[i].Name, Target: discovered[i].Target}
+		plugins, err := GetCachedPlugins(criteria)
+		assertions.Nil(err)
+		assertions.Len(plugins, 1)
+		assertions.Equal(discovered[i].RecommendedVersion, plugins[0].RecommendedVersion)
+
+		versions, err := GetCachedPluginVersions(criteria)
+		assertions.Nil(err)
+		assertions.Equal(discovered[i].SupportedVersions, versions)
+	}
+}
+
+func TestGetCachedPluginGroupsMatchesDiscovery(t *testing.T) {
+	assertions := assert.New(t)
+
+	defer setupPluginSourceForTesting()()
+
+	discovered, err := DiscoverPluginGroups(discovery.WithUseLocalCacheOnly())
+	assertions.Nil(err)
+	assertions.NotEmpty(discovered)
+
+	cached, err := GetCachedPluginGroups(plugininventory.GroupCriteria{})
+	assertions.Nil(err)
+
+	sort.Sort(plugininventory.PluginGroupSorter(discovered))
+	sort.Sort(plugininventory.PluginGroupSorter(cached))
+	assertions.Equal(discovered, cached)
+}
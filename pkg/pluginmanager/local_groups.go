@@ -0,0 +1,61 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package pluginmanager
+
+import (
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/localgroups"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/plugininventory"
+)
+
+// GetLocalPluginGroup returns the local plugin group of the specified name as a plugin group.
+// A local plugin group is not versioned, its plugins are all mandatory and the plugins
+// without a version are installed using their latest version.
+func GetLocalPluginGroup(name string) (*plugininventory.PluginGroup, error) {
+	localGroup, err := localgroups.GetLocalGroup(name)
+	if err != nil {
+		return nil, err
+	}
+
+	plugins := make([]*plugininventory.PluginGroupPluginEntry, 0, len(localGroup.Plugins))
+	for _, p := range localGroup.Plugins {
+		version := p.Version
+		if version == "" {
+			version = cli.VersionLatest
+		}
+		plugins = append(plugins, &plugininventory.PluginGroupPluginEntry{
+			PluginIdentifier: plugininventory.PluginIdentifier{
+				Name:    p.Name,
+				Target:  configtypes.StringToTarget(p.Target),
+				Version: version,
+			},
+			Mandatory: true,
+		})
+	}
+
+	return &plugininventory.PluginGroup{
+		Name:               name,
+		Description:        localGroup.Description,
+		RecommendedVersion: cli.VersionLatest,
+		Versions:           map[string][]*plugininventory.PluginGroupPluginEntry{cli.VersionLatest: plugins},
+	}, nil
+}
+
+// InstallPluginsFromLocalGroup installs either the specified plugin or all the plugins of the
+// local plugin group.  Installing the plugins of a local plugin group again also installs the
+// versions of the plugins specified by the group if they were modified.
+func InstallPluginsFromLocalGroup(pluginName, groupName string) error {
+	pg, err := GetLocalPluginGroup(groupName)
+	if err != nil {
+		return err
+	}
+	groupID := localgroups.LocalGroupPrefix + groupName
+	log.Infof("Installing plugins from local plugin group '%s'", groupID)
+
+	_, err = InstallPluginsFromGivenPluginGroup(pluginName, groupID, pg)
+	return err
+}
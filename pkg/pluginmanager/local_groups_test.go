@@ -0,0 +1,60 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package pluginmanager
+
+import (
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/localgroups"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/pluginsupplier"
+)
+
+func TestInstallPluginsFromLocalGroup(t *testing.T) {
+	assertions := assert.New(t)
+
+	defer setupPluginSourceForTesting()()
+	execCommand = fakeInfoExecCommand
+	defer func() { execCommand = exec.Command }()
+
+	t.Setenv("TEST_CUSTOM_LOCAL_PLUGIN_GROUPS_FILE", filepath.Join(t.TempDir(), "local-plugin-groups.yaml"))
+	err := localgroups.SetLocalGroup("team", &localgroups.LocalGroup{
+		Plugins: []localgroups.LocalGroupPlugin{
+			{Name: "login", Version: "v0.2.0"},
+			{Name: "feature", Target: "k8s"},
+		},
+	})
+	assertions.Nil(err)
+
+	pg, err := GetLocalPluginGroup("team")
+	assertions.Nil(err)
+	assertions.Equal(2, len(pg.Versions[pg.RecommendedVersion]))
+	assertions.Equal(cli.VersionLatest, pg.Versions[pg.RecommendedVersion][1].Version)
+	assertions.Equal(configtypes.TargetK8s, pg.Versions[pg.RecommendedVersion][1].Target)
+
+	// Install a single plugin of the group
+	assertions.Nil(InstallPluginsFromLocalGroup("feature", "team"))
+	assertions.True(checkPluginIsInstalled("feature", configtypes.TargetK8s))
+	assertions.False(checkPluginIsInstalled("login", configtypes.TargetGlobal))
+
+	// Install all the plugins of the group
+	assertions.Nil(InstallPluginsFromLocalGroup(cli.AllPlugins, "team"))
+	installedPlugins, err := pluginsupplier.GetInstalledPlugins()
+	assertions.Nil(err)
+	assertions.Equal(2, len(installedPlugins))
+	pd := findPluginInfo(installedPlugins, "login", configtypes.TargetGlobal)
+	assertions.NotNil(pd)
+	assertions.Equal("v0.2.0", pd.Version)
+
+	err = InstallPluginsFromLocalGroup("cluster", "team")
+	assertions.ErrorContains(err, "plugin 'cluster' is not part of the group 'local:team'")
+	err = InstallPluginsFromLocalGroup(cli.AllPlugins, "unknown")
+	assertions.ErrorContains(err, `local plugin group "unknown" cannot be found`)
+}
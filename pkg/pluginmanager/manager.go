@@ -11,7 +11,11 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"runtime"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/Masterminds/semver"
 	"github.com/pkg/errors"
@@ -25,10 +29,12 @@ import (
 
 	cliv1alpha1 "github.com/vmware-tanzu/tanzu-cli/apis/cli/v1alpha1"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/artifact"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/buildinfo"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/catalog"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/common"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/config"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/discovery"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/distribution"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/plugincmdtree"
@@ -62,6 +68,9 @@ type DeletePluginOptions struct {
 	Target      configtypes.Target
 	PluginName  string
 	ForceDelete bool
+	// Confirm asks the user to confirm the uninstallation, unless ForceDelete is set.
+	// component.AskForConfirmation is used if it is not specified.
+	Confirm func(message string) error
 }
 
 // discoverSpecificPlugins returns all plugins that match the specified criteria from all PluginDiscovery sources,
@@ -111,14 +120,20 @@ func discoverSpecificPluginGroups(pd []configtypes.PluginDiscovery, options ...d
 
 // DiscoverStandalonePlugins returns the available standalone plugins
 func DiscoverStandalonePlugins(options ...discovery.DiscoveryOptions) ([]discovery.Discovered, error) {
-	discoveries, err := getPluginDiscoveries()
+	return managerConfig{}.discoverStandalonePlugins(options...)
+}
+
+// discoverStandalonePlugins returns the standalone plugins available from the discovery sources of the configuration
+func (mc managerConfig) discoverStandalonePlugins(options ...discovery.DiscoveryOptions) ([]discovery.Discovered, error) {
+	discoveries, err := mc.getPluginDiscoveries()
 	if err != nil {
 		return nil, err
 	} else if len(discoveries) == 0 {
 		return nil, errors.New(errorNoDiscoverySourcesFound)
 	}
 
-	plugins, err := discoverSpecificPlugins(discoveries, options...)
+	plugins, err := discoverSpecificPlugins(discoveries, mc.discoveryOptions(options...)...)
+	plugins = filterPluginsByVendorPolicy(plugins)
 	for i := range plugins {
 		plugins[i].Scope = common.PluginScopeStandalone
 		plugins[i].Status = common.PluginStatusNotInstalled
@@ -140,7 +155,7 @@ func DiscoverPluginGroups(options ...discovery.DiscoveryOptions) ([]*plugininven
 	if err != nil {
 		return nil, err
 	}
-	return groups, err
+	return filterGroupsByVendorPolicy(groups), err
 }
 
 // GetAdditionalTestPluginDiscoveries returns an array of plugin discoveries that
@@ -201,13 +216,7 @@ func discoverServerPluginsForGivenContexts(contexts []*configtypes.Context) ([]d
 			discoveredPlugins[i].ContextName = context.Name
 
 			// Associate Target of the plugin based on the Context Type of the Context
-			switch context.ContextType {
-			case configtypes.ContextTypeTMC:
-				discoveredPlugins[i].Target = configtypes.TargetTMC
-			default:
-				// All other context types are associated with the kubernetes target
-				discoveredPlugins[i].Target = configtypes.TargetK8s
-			}
+			discoveredPlugins[i].Target = getContextTypeTarget(context.ContextType)
 
 			// It is possible that server recommends shortened plugin version of format vMAJOR or vMAJOR.MINOR
 			// in that case, try to find the latest available version of the plugin that matches with the given recommended version
@@ -219,11 +228,23 @@ func discoverServerPluginsForGivenContexts(contexts []*configtypes.Context) ([]d
 		// Remove older plugins from the discoveredPlugins list when there are duplicates
 		// this can be possible if a same plugin gets discovered from different kubernetes namespaces
 		discoveredPlugins = removeOldPluginsWhenDuplicates(discoveredPlugins)
+		// The plugins recommended by the context take precedence over the ones
+		// the central configuration recommends to all the contexts of its type
+		discoveredPlugins = mergeContextRecommendedPlugins(discoveredPlugins, getCentralConfigRecommendedPlugins(context))
 		plugins = append(plugins, discoveredPlugins...)
 	}
 	return plugins, kerrors.NewAggregate(errList)
 }
 
+// getContextTypeTarget returns the target of the plugins recommended by the contexts of the given type
+func getContextTypeTarget(contextType configtypes.ContextType) configtypes.Target {
+	if contextType == configtypes.ContextTypeTMC {
+		return configtypes.TargetTMC
+	}
+	// All other context types are associated with the kubernetes target
+	return configtypes.TargetK8s
+}
+
 func getMatchingRecommendedVersionOfPlugin(pluginName string, pluginTarget configtypes.Target, version string) string {
 	criteria := &discovery.PluginDiscoveryCriteria{
 		Name:    pluginName,
@@ -292,6 +313,12 @@ func mergePluginEntries(plugin1, plugin2 *discovery.Discovered) *discovery.Disco
 		if !exists {
 			artifacts1[version] = artifacts2[version]
 			plugin1.SupportedVersions = append(plugin1.SupportedVersions, version)
+			if advisories := plugin2.Advisories[version]; len(advisories) > 0 {
+				if plugin1.Advisories == nil {
+					plugin1.Advisories = make(map[string][]*plugininventory.PluginAdvisory)
+				}
+				plugin1.Advisories[version] = advisories
+			}
 		}
 	}
 	plugin1.Distribution = artifacts1
@@ -299,7 +326,7 @@ func mergePluginEntries(plugin1, plugin2 *discovery.Discovered) *discovery.Disco
 
 	// Set the recommended version to the highest version
 	if len(plugin1.SupportedVersions) > 0 {
-		plugin1.RecommendedVersion = plugin1.SupportedVersions[len(plugin1.SupportedVersions)-1]
+		plugin1.RecommendedVersion = plugininventory.LatestVersion(plugin1.SupportedVersions, plugininventory.IncludePreReleases(plugin1.Name))
 	}
 
 	// Keep the following fields from the first plugin found
@@ -362,7 +389,7 @@ func mergeGroupEntries(group1, group2 *plugininventory.PluginGroup) *plugininven
 		_ = utils.SortVersions(latestVersions)
 
 		// Set the recommended version and the description to the ones from the highest version group
-		if group2.RecommendedVersion == latestVersions[1] {
+		if group2.RecommendedVersion == plugininventory.LatestVersion(latestVersions, plugininventory.IncludePreReleases(plugininventory.PluginGroupToID(group1))) {
 			// If it is group2 that has the highest version, replace the RecommendedVersion and Description
 			group1.RecommendedVersion = group2.RecommendedVersion
 			group1.Description = group2.Description
@@ -420,7 +447,12 @@ func setAvailablePluginsStatus(availablePlugins []discovery.Discovered, installe
 
 // DescribePlugin describes a plugin.
 func DescribePlugin(pluginName string, target configtypes.Target) (info *cli.PluginInfo, err error) {
-	plugins, err := pluginsupplier.GetInstalledPlugins()
+	return managerConfig{}.describeInstalledPlugin(pluginName, target)
+}
+
+// describeInstalledPlugin describes a plugin installed in the catalog of the configuration
+func (mc managerConfig) describeInstalledPlugin(pluginName string, target configtypes.Target) (*cli.PluginInfo, error) {
+	plugins, err := mc.getInstalledPlugins()
 	if err != nil {
 		return nil, err
 	}
@@ -449,7 +481,11 @@ func DescribePlugin(pluginName string, target configtypes.Target) (info *cli.Plu
 		}
 	}
 
-	return nil, errors.Errorf(missingTargetStr, pluginName)
+	targets := make([]configtypes.Target, len(matchedPlugins))
+	for i := range matchedPlugins {
+		targets[i] = matchedPlugins[i].Target
+	}
+	return nil, ambiguousTargetError(pluginName, targets)
 }
 
 // InitializePlugin initializes the plugin configuration
@@ -472,16 +508,17 @@ func InitializePlugin(plugin *cli.PluginInfo) error {
 
 // InstallStandalonePlugin installs a plugin by name, version and target as a standalone plugin.
 func InstallStandalonePlugin(pluginName, version string, target configtypes.Target) error {
-	return installPlugin(pluginName, version, target, "")
+	return managerConfig{}.installPlugin(pluginName, version, target, "", false)
 }
 
 // installs a plugin by name, version and target.
 // If the contextName is not empty, it implies the plugin is a context-scope plugin, otherwise
 // we are installing a standalone plugin.
+// If upgrade is true, the plugin is never downgraded from its installed version.
 //
 //nolint:gocyclo
-func installPlugin(pluginName, version string, target configtypes.Target, contextName string) error {
-	discoveries, err := getPluginDiscoveries()
+func (mc managerConfig) installPlugin(pluginName, version string, target configtypes.Target, contextName string, upgrade bool) error {
+	discoveries, err := mc.getPluginDiscoveries()
 	if err != nil {
 		return err
 	}
@@ -495,34 +532,46 @@ func installPlugin(pluginName, version string, target configtypes.Target, contex
 		OS:      cli.GOOS,
 		Arch:    cli.GOARCH,
 	}
+	// A version constraint such as "~1.2" is resolved against all the available versions
+	versionConstraint := utils.ParseVersionConstraint(version)
+	if versionConstraint != nil {
+		criteria.Version = ""
+	}
+	// An upgrade to the latest version is resolved against all the available versions
+	// as it depends on the installed version
+	if upgrade && (version == "" || version == cli.VersionLatest) {
+		criteria.Version = ""
+	}
 	errorList := make([]error, 0)
-	availablePlugins, err := discoverSpecificPlugins(discoveries, discovery.WithPluginDiscoveryCriteria(criteria))
+	availablePlugins, err := discoverSpecificPlugins(discoveries, mc.discoveryOptions(discovery.WithPluginDiscoveryCriteria(criteria))...)
 	if err != nil {
 		errorList = append(errorList, err)
 	}
+	availablePlugins = filterPluginsByVersionConstraint(availablePlugins, versionConstraint)
 
 	// If we cannot find the plugin for ARM64, let's fallback to AMD64 for Darwin and Windows.
 	// This leverages Apples Rosetta emulator and Windows 11 emulator until plugins
 	// are all available for ARM64.  Note that this approach cannot be used on Linux since there
-	// is no such emulator.
+	// is no such emulator.  The fallback can be deactivated using a feature flag.
 	if len(availablePlugins) == 0 &&
-		(cli.BuildArch() == cli.DarwinARM64 || cli.BuildArch() == cli.WinARM64) {
+		(cli.BuildArch() == cli.DarwinARM64 || cli.BuildArch() == cli.WinARM64) &&
+		isAMD64FallbackActivated() {
 		// Pretend we are on a AMD64 machine so that we can find the plugin.
-		switch cli.BuildArch() {
-		case cli.DarwinARM64:
-			cli.SetArch(cli.DarwinAMD64)
-			criteria.Arch = cli.DarwinAMD64.Arch()
-			defer cli.SetArch(cli.DarwinARM64) // Go back to ARM64 once the plugin is installed
-		case cli.WinARM64:
-			cli.SetArch(cli.WinAMD64)
-			criteria.Arch = cli.WinAMD64.Arch()
-			defer cli.SetArch(cli.WinARM64) // Go back to ARM64 once the plugin is installed
-		}
-
-		availablePlugins, err = discoverSpecificPlugins(discoveries, discovery.WithPluginDiscoveryCriteria(criteria))
+		arm64Arch := cli.BuildArch()
+		amd64Arch := cli.Arch(fmt.Sprintf("%s_amd64", arm64Arch.OS()))
+		cli.SetArch(amd64Arch)
+		criteria.Arch = amd64Arch.Arch()
+		defer cli.SetArch(arm64Arch) // Go back to ARM64 once the plugin is installed
+
+		availablePlugins, err = discoverSpecificPlugins(discoveries, mc.discoveryOptions(discovery.WithPluginDiscoveryCriteria(criteria))...)
 		if err != nil {
 			errorList = append(errorList, err)
 		}
+		availablePlugins = filterPluginsByVersionConstraint(availablePlugins, versionConstraint)
+		if len(availablePlugins) > 0 {
+			log.Warningf("plugin '%s' is not available for %s, installing its %s binary which will run using emulation. "+
+				"To prevent this, run 'tanzu config set %s false'", pluginName, arm64Arch, amd64Arch, constants.FeatureAMD64Fallback)
+		}
 	}
 
 	if len(availablePlugins) == 0 {
@@ -558,31 +607,148 @@ func installPlugin(pluginName, version string, target configtypes.Target, contex
 	}
 
 	if len(matchedPlugins) == 1 {
-		return installOrUpgradePlugin(&matchedPlugins[0], matchedPlugins[0].RecommendedVersion, false)
+		return mc.installMatchedPlugin(&matchedPlugins[0], version, upgrade)
 	}
 
 	for i := range matchedPlugins {
 		if matchedPlugins[i].Target == target {
-			return installOrUpgradePlugin(&matchedPlugins[i], matchedPlugins[i].RecommendedVersion, false)
+			return mc.installMatchedPlugin(&matchedPlugins[i], version, upgrade)
+		}
+	}
+
+	// The plugin exists for multiple targets; ask the user to choose one if possible
+	targets := make([]configtypes.Target, len(matchedPlugins))
+	for i := range matchedPlugins {
+		targets[i] = matchedPlugins[i].Target
+	}
+	chosenTarget, err := resolveAmbiguousTarget(pluginName, targets, true)
+	if err != nil {
+		errorList = append(errorList, err)
+		return kerrors.NewAggregate(errorList)
+	}
+	for i := range matchedPlugins {
+		if matchedPlugins[i].Target == chosenTarget {
+			return mc.installMatchedPlugin(&matchedPlugins[i], version, upgrade)
 		}
 	}
-	errorList = append(errorList, errors.Errorf(missingTargetStr, pluginName))
+	errorList = append(errorList, ambiguousTargetError(pluginName, targets))
 	return kerrors.NewAggregate(errorList)
 }
 
+// installMatchedPlugin installs the recommended version of the discovered plugin or,
+// when upgrading, the version returned by getUpgradeVersion
+func (mc managerConfig) installMatchedPlugin(p *discovery.Discovered, requestedVersion string, upgrade bool) error {
+	if !upgrade {
+		return mc.installOrUpgradePlugin(p, p.RecommendedVersion, false)
+	}
+
+	installedVersion := mc.getInstalledVersion(p.Name, p.Target)
+	version, err := getUpgradeVersion(p, requestedVersion, installedVersion)
+	if err != nil {
+		return err
+	}
+	if version == installedVersion && !isExactVersion(requestedVersion) {
+		log.Infof("Plugin '%s' for target '%s' is already at its most recent version '%s'", p.Name, p.Target, installedVersion)
+		return nil
+	}
+	return mc.installOrUpgradePlugin(p, version, false)
+}
+
+// getUpgradeVersion returns the version to upgrade the discovered plugin to from its installed
+// version, which is empty if the plugin is not installed.  A version constraint or the latest
+// version are resolved to the installed version if no more recent version is available.
+// An error is returned if the requested version is older than the installed version.
+func getUpgradeVersion(p *discovery.Discovered, requestedVersion, installedVersion string) (string, error) {
+	if installedVersion == "" {
+		return p.RecommendedVersion, nil
+	}
+	if isExactVersion(requestedVersion) {
+		if utils.IsNewVersion(installedVersion, p.RecommendedVersion) {
+			return "", errors.Errorf("plugin '%s' version '%s' is older than the installed version '%s'. Use 'tanzu plugin install %s --version %s' to downgrade the plugin",
+				p.Name, p.RecommendedVersion, installedVersion, p.Name, p.RecommendedVersion)
+		}
+		return p.RecommendedVersion, nil
+	}
+
+	versions := slices.Clone(p.SupportedVersions)
+	_ = utils.SortVersions(versions)
+	return plugininventory.UpgradeVersion(versions, installedVersion, plugininventory.IncludePreReleases(p.Name)), nil
+}
+
+// isExactVersion returns true if the version is neither the latest version nor a version constraint
+func isExactVersion(version string) bool {
+	return version != "" && version != cli.VersionLatest && utils.ParseVersionConstraint(version) == nil
+}
+
+// getInstalledVersion returns the most recent installed version of the plugin,
+// or an empty string if the plugin is not installed
+func (mc managerConfig) getInstalledVersion(pluginName string, target configtypes.Target) string {
+	installedPlugins, err := mc.getInstalledPlugins()
+	if err != nil {
+		return ""
+	}
+	installedVersion := ""
+	for i := range installedPlugins {
+		if installedPlugins[i].Name == pluginName && installedPlugins[i].Target == target &&
+			(installedVersion == "" || utils.IsNewVersion(installedPlugins[i].Version, installedVersion)) {
+			installedVersion = installedPlugins[i].Version
+		}
+	}
+	return installedVersion
+}
+
+// filterPluginsByVersionConstraint only keeps the versions of the plugins which satisfy the version
+// constraint and sets the recommended version of each plugin to the highest of those versions.
+// Plugins that have no version satisfying the constraint are removed.
+// If the constraint is nil, the plugins are returned unchanged.
+func filterPluginsByVersionConstraint(plugins []discovery.Discovered, constraint *semver.Constraints) []discovery.Discovered {
+	if constraint == nil {
+		return plugins
+	}
+
+	var filteredPlugins []discovery.Discovered
+	for i := range plugins {
+		versions := utils.VersionsMatchingConstraint(plugins[i].SupportedVersions, constraint)
+		if len(versions) == 0 {
+			continue
+		}
+		_ = utils.SortVersions(versions)
+
+		if artifacts, ok := plugins[i].Distribution.(distribution.Artifacts); ok {
+			filteredArtifacts := distribution.Artifacts{}
+			for _, v := range versions {
+				filteredArtifacts[v] = artifacts[v]
+			}
+			plugins[i].Distribution = filteredArtifacts
+		}
+		plugins[i].SupportedVersions = versions
+		plugins[i].RecommendedVersion = plugininventory.LatestVersion(versions, plugininventory.IncludePreReleases(plugins[i].Name))
+		filteredPlugins = append(filteredPlugins, plugins[i])
+	}
+	return filteredPlugins
+}
+
 // UpgradePlugin upgrades a plugin from the given repository.
+// The plugin is never downgraded: if no version more recent than the installed one is
+// available, the plugin is left unchanged, and requesting an older version is an error.
+// Pre-release versions are considered if the installed version is a pre-release.
 func UpgradePlugin(pluginName, version string, target configtypes.Target) error {
 	// Upgrade is only triggered from a manual user operation.
 	// This means a plugin is installed manually, which means it is installed as a standalone plugin.
-	return InstallStandalonePlugin(pluginName, version, target)
+	return managerConfig{}.installPlugin(pluginName, version, target, "", true)
 }
 
 // InstallPluginsFromGroup installs either the specified plugin or all plugins from the specified group version.
 // If the group version is not specified, the latest available version will be used.
 // The group identifier including the version used is returned.
 func InstallPluginsFromGroup(pluginName, groupIDAndVersion string, options ...PluginManagerOptions) (string, error) {
+	return managerConfig{}.installPluginsFromGroup(pluginName, groupIDAndVersion, options...)
+}
+
+// installPluginsFromGroup installs the plugins of the group found in the discovery sources of the configuration
+func (mc managerConfig) installPluginsFromGroup(pluginName, groupIDAndVersion string, options ...PluginManagerOptions) (string, error) {
 	// get plugins from the specific plugin group
-	pg, err := GetPluginGroup(groupIDAndVersion, options...)
+	pg, err := mc.getPluginGroup(groupIDAndVersion, options...)
 	if err != nil {
 		return "", err
 	}
@@ -593,21 +759,27 @@ func InstallPluginsFromGroup(pluginName, groupIDAndVersion string, options ...Pl
 	groupIDAndVersion = fmt.Sprintf("%s-%s/%s:%s", pg.Vendor, pg.Publisher, pg.Name, pg.RecommendedVersion)
 	log.Infof("Installing plugins from plugin group '%s'", groupIDAndVersion)
 
-	return InstallPluginsFromGivenPluginGroup(pluginName, groupIDAndVersion, pg)
+	return mc.installPluginsFromGivenPluginGroup(pluginName, groupIDAndVersion, pg)
 }
 
 // InstallPluginsFromGivenPluginGroup installs either the specified plugin or all plugins from given plugin group plugins.
 func InstallPluginsFromGivenPluginGroup(pluginName, groupIDAndVersion string, pg *plugininventory.PluginGroup) (string, error) {
+	return managerConfig{}.installPluginsFromGivenPluginGroup(pluginName, groupIDAndVersion, pg)
+}
+
+// installPluginsFromGivenPluginGroup installs the plugins of the given plugin group in the locations of the configuration
+func (mc managerConfig) installPluginsFromGivenPluginGroup(pluginName, groupIDAndVersion string, pg *plugininventory.PluginGroup) (string, error) {
 	numErrors := 0
 	numInstalled := 0
 	mandatoryPluginsExist := false
 	pluginExist := false
+	mc.prefetchPluginsOfGroup(pluginName, pg.Versions[pg.RecommendedVersion])
 	for _, plugin := range pg.Versions[pg.RecommendedVersion] {
 		if pluginName == cli.AllPlugins || pluginName == plugin.Name {
 			pluginExist = true
 			if plugin.Mandatory {
 				mandatoryPluginsExist = true
-				err := InstallStandalonePlugin(plugin.Name, plugin.Version, plugin.Target)
+				err := mc.installPlugin(plugin.Name, plugin.Version, plugin.Target, "", false)
 				if err != nil {
 					numErrors++
 					log.Warningf("unable to install plugin '%s': %v", plugin.Name, err.Error())
@@ -642,6 +814,11 @@ func InstallPluginsFromGivenPluginGroup(pluginName, groupIDAndVersion string, pg
 
 // GetPluginGroup returns the plugin group for the specified groupIDAndVersion.
 func GetPluginGroup(groupIDAndVersion string, options ...PluginManagerOptions) (*plugininventory.PluginGroup, error) {
+	return managerConfig{}.getPluginGroup(groupIDAndVersion, options...)
+}
+
+// getPluginGroup returns the plugin group found in the discovery sources of the configuration
+func (mc managerConfig) getPluginGroup(groupIDAndVersion string, options ...PluginManagerOptions) (*plugininventory.PluginGroup, error) {
 	// Initialize plugin manager options and enable logs by default
 	opts := NewPluginManagerOpts()
 	for _, option := range options {
@@ -652,7 +829,7 @@ func GetPluginGroup(groupIDAndVersion string, options ...PluginManagerOptions) (
 	opts.SetLogMode()
 	defer opts.ResetLogMode()
 
-	discoveries, err := getPluginDiscoveries()
+	discoveries, err := mc.getPluginDiscoveries()
 	if err != nil {
 		return nil, err
 	}
@@ -675,7 +852,7 @@ func GetPluginGroup(groupIDAndVersion string, options ...PluginManagerOptions) (
 		Version:   groupIdentifier.Version,
 	}
 
-	groups, err := discoverSpecificPluginGroups(discoveries, discovery.WithGroupDiscoveryCriteria(criteria))
+	groups, err := discoverSpecificPluginGroups(discoveries, mc.discoveryOptions(discovery.WithGroupDiscoveryCriteria(criteria))...)
 	if err != nil {
 		return nil, err
 	}
@@ -715,21 +892,40 @@ func getPluginInstallationMessage(p *discovery.Discovered, version string, isPlu
 	return installingMsg, installedMsg, errorMsg
 }
 
-func installOrUpgradePlugin(p *discovery.Discovered, version string, installTestPlugin bool) error {
+func (mc managerConfig) installOrUpgradePlugin(p *discovery.Discovered, version string, installTestPlugin bool) error {
 	// If the version requested was the RecommendedVersion, we should set it explicitly
 	if version == "" || version == cli.VersionLatest {
 		version = p.RecommendedVersion
 	}
 
+	if err := verifyVendorPolicy(p); err != nil {
+		return err
+	}
+	if err := mc.verifySourceSignaturePolicy(p); err != nil {
+		return err
+	}
+	if err := verifyInstallationPolicy(p, version); err != nil {
+		return err
+	}
+	warnAboutAdvisories(p, version)
+	if err := verifyMinCLIVersion(p, version); err != nil {
+		return err
+	}
+	// Recent inventories record the plugin runtime version of the plugins,
+	// which allows verifying it before downloading the plugin
+	if err := verifyPluginRuntimeVersion(p.Name, getArtifactPluginRuntimeVersion(p, version)); err != nil {
+		return err
+	}
+
 	var isPluginAlreadyInstalled bool
 	var plugin *cli.PluginInfo
 	if !installTestPlugin {
 		// If we need to install the test plugin we know we are doing a local
 		// installation.  In that case, we don't use the cache as the binary is
 		// already local to the machine.
-		plugin = getPluginFromCache(p, version)
+		plugin = mc.getPluginFromCache(p, version)
 		if p.ContextName == "" {
-			isPluginAlreadyInstalled = pluginsupplier.IsPluginInstalled(p.Name, p.Target, version)
+			isPluginAlreadyInstalled = pluginsupplier.IsPluginInstalledInCatalog(mc.store, p.Name, p.Target, version)
 		}
 	}
 
@@ -750,57 +946,83 @@ func installOrUpgradePlugin(p *discovery.Discovered, version string, installTest
 		log.Info(installingMsg)
 	}
 
-	pluginErr := verifyInstallAndInitializePlugin(plugin, p, version, installTestPlugin)
+	pluginErr := mc.verifyInstallAndInitializePlugin(plugin, p, version, installTestPlugin)
 	if pluginErr == nil && spinner != nil {
 		spinner.SetFinalText(installedMsg, log.LogTypeINFO)
 	}
 	return pluginErr
 }
 
-func verifyInstallAndInitializePlugin(plugin *cli.PluginInfo, p *discovery.Discovered, version string, installTestPlugin bool) error {
+func (mc managerConfig) verifyInstallAndInitializePlugin(plugin *cli.PluginInfo, p *discovery.Discovered, version string, installTestPlugin bool) error {
 	if plugin == nil {
 		binary, err := fetchAndVerifyPlugin(p, version)
 		if err != nil {
 			return err
 		}
 
-		plugin, err = installAndDescribePlugin(p, version, binary)
+		plugin, err = mc.installAndDescribePlugin(p, version, binary)
 		if err != nil {
 			return err
 		}
 	}
+	if getArtifactPluginRuntimeVersion(p, version) == "" {
+		// The plugin runtime version was not known before installing the plugin
+		if err := verifyPluginRuntimeVersion(plugin.Name, plugin.PluginRuntimeVersion); err != nil {
+			return err
+		}
+	}
 	if installTestPlugin {
 		if err := doInstallTestPlugin(p, plugin.InstallationPath, version); err != nil {
 			return err
 		}
 	}
-	return updatePluginInfoAndInitializePlugin(p, plugin)
+	if err := mc.updatePluginInfoAndInitializePlugin(p, plugin); err != nil {
+		return err
+	}
+	mc.applyPluginRetentionPolicy(plugin)
+	return nil
+}
+
+func (mc managerConfig) getPluginFromCache(p *discovery.Discovered, version string) *cli.PluginInfo {
+	pluginPath, pluginArtifact := mc.findCachedPluginBinary(p, version)
+	if pluginPath == "" {
+		return nil
+	}
+
+	plugin, err := describePlugin(p, version, pluginPath)
+	if err != nil {
+		return nil
+	}
+	recordPluginInstallMetadata(plugin, pluginArtifact, pluginArtifact.Digest)
+	return plugin
 }
 
-func getPluginFromCache(p *discovery.Discovered, version string) *cli.PluginInfo {
+// findCachedPluginBinary returns the path of the binary of the plugin version, along
+// with its artifact, if the binary is present already, or an empty path otherwise
+func (mc managerConfig) findCachedPluginBinary(p *discovery.Discovered, version string) (string, *distribution.Artifact) {
 	pluginArtifact, err := p.Distribution.DescribeArtifact(version, cli.GOOS, cli.GOARCH)
 	if err != nil {
-		return nil
+		return "", nil
 	}
 
 	// TODO(khouzam): We should not be checking the presence of the binary directly here,
 	// as it bypasses the plugin catalog abstraction.  Instead, we should ask the plugin
 	// catalog to know if the plugin binary is present already.
 	pluginFileName := fmt.Sprintf("%s_%s_%s", version, pluginArtifact.Digest, p.Target)
-	pluginPath := filepath.Join(common.DefaultPluginRoot, p.Name, pluginFileName)
-
 	if cli.BuildArch().IsWindows() {
-		pluginPath += exe
-	}
-	if _, err = os.Stat(pluginPath); err != nil {
-		return nil
+		pluginFileName += exe
 	}
 
-	plugin, err := describePlugin(p, pluginPath)
-	if err != nil {
-		return nil
+	// The binaries provisioned in the system plugin directories take precedence over
+	// the ones of the user's plugin root
+	pluginPath := findSystemPluginBinary(p.Name, pluginFileName, pluginArtifact.Digest)
+	if pluginPath == "" {
+		pluginPath = filepath.Join(mc.pluginRoot(), p.Name, pluginFileName)
+		if _, err = os.Stat(pluginPath); err != nil {
+			return "", nil
+		}
 	}
-	return plugin
+	return pluginPath, &pluginArtifact
 }
 
 func fetchAndVerifyPlugin(p *discovery.Discovered, version string) ([]byte, error) {
@@ -827,34 +1049,70 @@ func fetchAndVerifyPlugin(p *discovery.Discovered, version string) ([]byte, erro
 	return b, nil
 }
 
-func installAndDescribePlugin(p *discovery.Discovered, version string, binary []byte) (*cli.PluginInfo, error) {
-	pluginFileName := fmt.Sprintf("%s_%x_%s", version, sha256.Sum256(binary), p.Target)
-	pluginPath := filepath.Join(common.DefaultPluginRoot, p.Name, pluginFileName)
+func (mc managerConfig) installAndDescribePlugin(p *discovery.Discovered, version string, binary []byte) (*cli.PluginInfo, error) {
+	pluginPath, digest, err := mc.savePluginBinary(p, version, binary)
+	if err != nil {
+		return nil, err
+	}
 
-	if err := os.MkdirAll(filepath.Dir(pluginPath), os.ModePerm); err != nil {
+	plugin, err := describePlugin(p, version, pluginPath)
+	if err != nil {
 		return nil, err
 	}
+	pluginArtifact, err := p.Distribution.DescribeArtifact(version, cli.GOOS, cli.GOARCH)
+	if err != nil {
+		return nil, err
+	}
+	recordPluginInstallMetadata(plugin, &pluginArtifact, digest)
+	return plugin, nil
+}
+
+// savePluginBinary saves the binary of the plugin version in the plugin root,
+// where it is found by getPluginFromCache, and returns its path and digest
+func (mc managerConfig) savePluginBinary(p *discovery.Discovered, version string, binary []byte) (string, string, error) {
+	digest := fmt.Sprintf("%x", sha256.Sum256(binary))
+	pluginFileName := fmt.Sprintf("%s_%s_%s", version, digest, p.Target)
+	pluginPath := filepath.Join(mc.pluginRoot(), p.Name, pluginFileName)
+
+	if err := os.MkdirAll(filepath.Dir(pluginPath), os.ModePerm); err != nil {
+		return "", "", err
+	}
 
 	if cli.BuildArch().IsWindows() {
 		pluginPath += exe
 	}
 
 	if err := os.WriteFile(pluginPath, binary, 0755); err != nil {
-		return nil, errors.Wrap(err, "could not write file")
+		return "", "", errors.Wrap(err, "could not write file")
 	}
-
-	return describePlugin(p, pluginPath)
+	return pluginPath, digest, nil
 }
 
-func describePlugin(p *discovery.Discovered, pluginPath string) (*cli.PluginInfo, error) {
-	bytesInfo, err := execCommand(pluginPath, "info").Output()
-	if err != nil {
-		return nil, errors.Wrapf(err, "could not describe plugin %q", p.Name)
-	}
+// recordPluginInstallMetadata records in the plugin information the digest of the
+// installed binary, the image it was installed from and the time of installation
+func recordPluginInstallMetadata(plugin *cli.PluginInfo, pluginArtifact *distribution.Artifact, digest string) {
+	installedAt := time.Now().UTC()
+	plugin.Digest = digest
+	plugin.Image = pluginArtifact.Image
+	plugin.InstalledAt = &installedAt
+}
 
+func describePlugin(p *discovery.Discovered, version, pluginPath string) (*cli.PluginInfo, error) {
 	var plugin cli.PluginInfo
-	if err = json.Unmarshal(bytesInfo, &plugin); err != nil {
-		return nil, errors.Wrapf(err, "could not unmarshal plugin %q description", p.Name)
+	if canExecutePlugins() {
+		bytesInfo, err := execCommand(pluginPath, "info").Output()
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not describe plugin %q", p.Name)
+		}
+		if err = json.Unmarshal(bytesInfo, &plugin); err != nil {
+			return nil, errors.Wrapf(err, "could not unmarshal plugin %q description", p.Name)
+		}
+	} else {
+		// The plugin binary is for a platform the host cannot run, so the
+		// plugin is described using the information from the discovery
+		plugin.Name = p.Name
+		plugin.Description = p.Description
+		plugin.Version = version
 	}
 	plugin.InstallationPath = pluginPath
 	plugin.Discovery = p.Source
@@ -869,6 +1127,38 @@ func describePlugin(p *discovery.Discovered, pluginPath string) (*cli.PluginInfo
 	return &plugin, nil
 }
 
+// isAMD64FallbackActivated returns true unless the fallback to AMD64 plugin binaries
+// on ARM64 machines was explicitly deactivated using the feature flag
+func isAMD64FallbackActivated() bool {
+	cfg, err := configlib.GetClientConfig()
+	if err != nil {
+		return true
+	}
+	plugin, flag, err := cfg.SplitFeaturePath(constants.FeatureAMD64Fallback)
+	if err != nil || cfg.ClientOptions == nil || cfg.ClientOptions.Features == nil ||
+		cfg.ClientOptions.Features[plugin][flag] == "" {
+		// The fallback is activated by default
+		return true
+	}
+	activated, err := cfg.IsConfigFeatureActivated(constants.FeatureAMD64Fallback)
+	return err != nil || activated
+}
+
+// canExecutePlugins returns false if the plugins being installed are for a platform that
+// the host cannot run, which can happen when the OS or architecture of the plugins is overridden
+// using cli.SetArch().
+func canExecutePlugins() bool {
+	if cli.GOOS != runtime.GOOS {
+		return false
+	}
+	if cli.GOARCH == runtime.GOARCH || cli.BuildArch().IsUniversal() {
+		return true
+	}
+	// Darwin and Windows ARM64 machines can run AMD64 binaries through emulation
+	return runtime.GOARCH == "arm64" && cli.GOARCH == "amd64" &&
+		(runtime.GOOS == "darwin" || runtime.GOOS == "windows")
+}
+
 func doInstallTestPlugin(p *discovery.Discovered, pluginPath, version string) error {
 	log.Infof("Installing test plugin for '%v:%v'", p.Name, version)
 	binary, err := p.Distribution.FetchTest(version, cli.GOOS, cli.GOARCH)
@@ -888,8 +1178,8 @@ func doInstallTestPlugin(p *discovery.Discovered, pluginPath, version string) er
 	return nil
 }
 
-func updatePluginInfoAndInitializePlugin(p *discovery.Discovered, plugin *cli.PluginInfo) error {
-	c, err := catalog.NewContextCatalogUpdater(p.ContextName)
+func (mc managerConfig) updatePluginInfoAndInitializePlugin(p *discovery.Discovered, plugin *cli.PluginInfo) error {
+	c, err := mc.store.NewContextCatalogUpdater(p.ContextName)
 	if err != nil {
 		return err
 	}
@@ -902,6 +1192,10 @@ func updatePluginInfoAndInitializePlugin(p *discovery.Discovered, plugin *cli.Pl
 	// `addPluginToCommandTreeCache` invocations which is not what we want.
 	c.Unlock()
 
+	if !canExecutePlugins() {
+		// The plugin cannot be initialized on this host
+		return nil
+	}
 	if err := InitializePlugin(plugin); err != nil {
 		log.Infof("could not initialize plugin after installing: %v", err.Error())
 	}
@@ -927,7 +1221,7 @@ func deletePluginFromCommandTreeCache(plugin *cli.PluginInfo) {
 	}
 }
 
-func matchPluginsForDeletion(options DeletePluginOptions) ([]cli.PluginInfo, error) {
+func (mc managerConfig) matchPluginsForDeletion(options DeletePluginOptions) ([]cli.PluginInfo, error) {
 	var matchedPlugins []cli.PluginInfo
 	catalogNames, err := configlib.GetAllActiveContextsList()
 	if err != nil {
@@ -938,7 +1232,7 @@ func matchPluginsForDeletion(options DeletePluginOptions) ([]cli.PluginInfo, err
 	catalogNames = append(catalogNames, "")
 
 	for _, serverName := range catalogNames {
-		c, err := catalog.NewContextCatalog(serverName)
+		c, err := mc.store.NewContextCatalog(serverName)
 		if err != nil {
 			continue
 		}
@@ -955,10 +1249,15 @@ func matchPluginsForDeletion(options DeletePluginOptions) ([]cli.PluginInfo, err
 }
 
 // DeletePlugin deletes a plugin.
+func DeletePlugin(options DeletePluginOptions) error {
+	return managerConfig{}.deletePlugin(options)
+}
+
+// deletePlugin deletes a plugin from the catalog of the configuration
 //
 //nolint:gocyclo
-func DeletePlugin(options DeletePluginOptions) error {
-	matchedPlugins, err := matchPluginsForDeletion(options)
+func (mc managerConfig) deletePlugin(options DeletePluginOptions) error {
+	matchedPlugins, err := mc.matchPluginsForDeletion(options)
 	if err != nil {
 		return err
 	}
@@ -979,35 +1278,46 @@ func DeletePlugin(options DeletePluginOptions) error {
 	// It is possible that the catalog contains two entries for a name/target combination:
 	// a context-scope installation and a standalone installation.  We need to delete both in this case.
 	// If all matched plugins are from the same target, this is when we can still delete them all.
+	// If the plugins are from different targets, the user must choose one of them.
 	var uniqueTarget configtypes.Target
 	if options.PluginName != cli.AllPlugins {
 		uniqueTarget = matchedPlugins[0].Target
+		targets := make([]configtypes.Target, len(matchedPlugins))
+		isAmbiguous := false
 		for i := range matchedPlugins {
+			targets[i] = matchedPlugins[i].Target
 			if matchedPlugins[i].Target != uniqueTarget {
-				return errors.Errorf(missingTargetStr, options.PluginName)
+				isAmbiguous = true
+			}
+		}
+		if isAmbiguous {
+			// Don't prompt when the user asked not to be prompted
+			uniqueTarget, err = resolveAmbiguousTarget(options.PluginName, targets, !options.ForceDelete)
+			if err != nil {
+				return err
 			}
+			matchedPlugins = filterPluginsByTarget(matchedPlugins, uniqueTarget)
 		}
 	}
 
 	if !options.ForceDelete {
+		confirm := options.Confirm
+		if confirm == nil {
+			confirm = component.AskForConfirmation
+		}
+
+		var message string
 		if options.PluginName == cli.AllPlugins {
 			if options.Target == configtypes.TargetUnknown {
-				if err := component.AskForConfirmation("All plugins will be uninstalled. Are you sure?"); err != nil {
-					return err
-				}
+				message = "All plugins will be uninstalled. Are you sure?"
 			} else {
-				if err := component.AskForConfirmation(
-					fmt.Sprintf("All plugins for target '%s' will be uninstalled. Are you sure?",
-						string(options.Target))); err != nil {
-					return err
-				}
+				message = fmt.Sprintf("All plugins for target '%s' will be uninstalled. Are you sure?", string(options.Target))
 			}
 		} else {
-			if err := component.AskForConfirmation(
-				fmt.Sprintf("Uninstalling plugin '%s' for target '%s'. Are you sure?",
-					options.PluginName, string(uniqueTarget))); err != nil {
-				return err
-			}
+			message = fmt.Sprintf("Uninstalling plugin '%s' for target '%s'. Are you sure?", options.PluginName, string(uniqueTarget))
+		}
+		if err := confirm(message); err != nil {
+			return err
 		}
 	}
 
@@ -1017,12 +1327,23 @@ func DeletePlugin(options DeletePluginOptions) error {
 	}
 
 	// Delete the plugins that match from the catalog
-	return doDeletePluginsFromCatalog(matchedPlugins)
+	return mc.doDeletePluginsFromCatalog(matchedPlugins)
 
 	// TODO: delete the plugin binary if it is not used by any server
 }
 
-func doDeletePluginsFromCatalog(plugins []cli.PluginInfo) error {
+// filterPluginsByTarget returns the plugins that are for the specified target
+func filterPluginsByTarget(plugins []cli.PluginInfo, target configtypes.Target) []cli.PluginInfo {
+	var filtered []cli.PluginInfo
+	for i := range plugins {
+		if plugins[i].Target == target {
+			filtered = append(filtered, plugins[i])
+		}
+	}
+	return filtered
+}
+
+func (mc managerConfig) doDeletePluginsFromCatalog(plugins []cli.PluginInfo) error {
 	errList := make([]error, 0)
 
 	catalogNames, err := configlib.GetAllActiveContextsList()
@@ -1037,7 +1358,7 @@ func doDeletePluginsFromCatalog(plugins []cli.PluginInfo) error {
 		// If we create more than one catalog at a time, then, when we delete the plugin
 		// in one catalog, the next catalog will put it back since that catalog
 		// was created before the plugin was deleted.
-		c, err := catalog.NewContextCatalogUpdater(n)
+		c, err := mc.store.NewContextCatalogUpdater(n)
 		if err != nil {
 			continue
 		}
@@ -1113,13 +1434,13 @@ func InstallPluginsFromLocalSource(pluginName, version string, target configtype
 	}
 
 	if len(matchedPlugins) == 1 {
-		return installOrUpgradePlugin(&matchedPlugins[0], version, installTestPlugin)
+		return managerConfig{}.installOrUpgradePlugin(&matchedPlugins[0], version, installTestPlugin)
 	}
 
 	for i := range matchedPlugins {
 		// Install all plugins otherwise include all matching plugins
 		if pluginName == cli.AllPlugins || matchedPlugins[i].Target == target {
-			err = installOrUpgradePlugin(&matchedPlugins[i], version, installTestPlugin)
+			err = managerConfig{}.installOrUpgradePlugin(&matchedPlugins[i], version, installTestPlugin)
 			if err != nil {
 				errList = append(errList, err)
 			}
@@ -1197,21 +1518,26 @@ func discoverPluginsFromLocalSource(localPath string) ([]discovery.Discovered, e
 
 // Clean deletes all plugins and tests.
 func Clean() error {
+	return managerConfig{}.clean()
+}
+
+// clean deletes all the plugins of the locations of the configuration
+func (mc managerConfig) clean() error {
 	errorList := make([]error, 0)
 
 	// Clean the plugin catalog
-	if err := catalog.CleanCatalogCache(); err != nil {
+	if err := mc.store.CleanCatalogCache(); err != nil {
 		errorList = append(errorList, errors.Wrapf(err, "Failed to clean the catalog cache"))
 	}
 
 	// Clean plugin inventory cache
-	pluginDataDir := filepath.Join(common.DefaultCacheDir, common.PluginInventoryDirName)
+	pluginDataDir := filepath.Join(mc.cacheDir(), common.PluginInventoryDirName)
 	if err := os.RemoveAll(pluginDataDir); err != nil {
 		errorList = append(errorList, errors.Wrapf(err, "Failed to clean the plugin inventory cache"))
 	}
 
 	// Remove all plugin binaries
-	if err := os.RemoveAll(common.DefaultPluginRoot); err != nil {
+	if err := os.RemoveAll(mc.pluginRoot()); err != nil {
 		errorList = append(errorList, errors.Wrapf(err, "Failed to clean the plugin binaries"))
 	}
 
@@ -1378,6 +1704,66 @@ func verifyPluginPreDownload(p *discovery.Discovered, version string) error {
 	return errors.Errorf("no download information available for artifact \"%s:%s:%s:%s\"", p.Name, p.RecommendedVersion, cli.GOOS, cli.GOARCH)
 }
 
+// verifySourceSignaturePolicy verifies that the plugin can be installed from its
+// discovery source as per the signature policy of the discovery source
+func (mc managerConfig) verifySourceSignaturePolicy(p *discovery.Discovered) error {
+	sources, _ := mc.getPluginDiscoveries()
+	for _, source := range sources {
+		if source.OCI != nil && source.OCI.Name == p.Source {
+			return discovery.CheckSourceSignature(source.OCI.Name, source.OCI.Image)
+		}
+	}
+	// Plugins from other sources, e.g. local ones, have no signature policy
+	return nil
+}
+
+// verifyMinCLIVersion verifies that the running CLI is not older than the minimum CLI
+// version required by the plugin version.  If the TANZU_CLI_SKIP_MIN_CLI_VERSION_CHECK
+// variable is set, a warning is printed instead of returning an error.
+func verifyMinCLIVersion(p *discovery.Discovered, version string) error {
+	if p.Distribution == nil {
+		return nil
+	}
+	artifactInfo, err := p.Distribution.DescribeArtifact(version, cli.GOOS, cli.GOARCH)
+	if err != nil || !utils.IsNewVersion(artifactInfo.MinCLIVersion, buildinfo.Version) {
+		// A missing artifact is reported when fetching the plugin
+		return nil
+	}
+
+	msg := fmt.Sprintf("plugin '%s:%s' requires Tanzu CLI version %s or later, but the current version is %s", p.Name, version, artifactInfo.MinCLIVersion, buildinfo.Version)
+	if skip, _ := strconv.ParseBool(os.Getenv(constants.SkipMinCLIVersionCheck)); skip {
+		log.Warningf("%s", msg)
+		return nil
+	}
+	return errors.Errorf("%s. Please upgrade the Tanzu CLI or set %s=true to install the plugin anyway", msg, constants.SkipMinCLIVersionCheck)
+}
+
+// getArtifactPluginRuntimeVersion returns the plugin runtime version recorded in the
+// discovery for the plugin version, or an empty string if it is unknown
+func getArtifactPluginRuntimeVersion(p *discovery.Discovered, version string) string {
+	if p.Distribution == nil {
+		return ""
+	}
+	artifactInfo, err := p.Distribution.DescribeArtifact(version, cli.GOOS, cli.GOARCH)
+	if err != nil {
+		return ""
+	}
+	return artifactInfo.PluginRuntimeVersion
+}
+
+// verifyPluginRuntimeVersion verifies that the plugin runtime version of a plugin is
+// supported by the CLI, and warns if some features of the plugin may not work
+func verifyPluginRuntimeVersion(pluginName, pluginRuntimeVersion string) error {
+	warning, err := cli.VerifyPluginRuntimeVersion(pluginName, pluginRuntimeVersion)
+	if err != nil {
+		return err
+	}
+	if warning != "" {
+		log.Warning(warning)
+	}
+	return nil
+}
+
 // verifyRegistry verifies the authenticity of the registry from where cli is
 // trying to download the plugins by comparing it with the list of trusted registries
 func verifyRegistry(image string) error {
@@ -1435,9 +1821,19 @@ func verifyPluginPostDownload(p *discovery.Discovered, srcDigest string, b []byt
 }
 
 // getPluginDiscoveries returns the plugin discoveries found in the configuration file.
+func getPluginDiscoveries() ([]configtypes.PluginDiscovery, error) {
+	return managerConfig{}.getPluginDiscoveries()
+}
+
+// getPluginDiscoveries returns the discovery sources of the configuration if any,
+// or the plugin discoveries found in the configuration file otherwise.
 //
 //nolint:unparam
-func getPluginDiscoveries() ([]configtypes.PluginDiscovery, error) {
+func (mc managerConfig) getPluginDiscoveries() ([]configtypes.PluginDiscovery, error) {
+	if mc.discoverySources != nil {
+		return mc.discoverySources, nil
+	}
+
 	// Look for testing discoveries.  Those should be stored and searched AFTER the central repo.
 	testDiscoveries := GetAdditionalTestPluginDiscoveries()
 
@@ -1486,15 +1882,13 @@ func IsPluginsFromPluginGroupInstalled(name, version string, options ...PluginMa
 		groupIdentifier.Version = cli.VersionLatest
 	}
 
-	// Create the discovery criteria for the plugin group.
-	discoveryCriteria := &discovery.GroupDiscoveryCriteria{
+	// Look for the plugin groups that match the criteria in the cache.
+	groups, err := GetCachedPluginGroups(plugininventory.GroupCriteria{
 		Vendor:    groupIdentifier.Vendor,
 		Publisher: groupIdentifier.Publisher,
 		Name:      groupIdentifier.Name,
 		Version:   groupIdentifier.Version,
-	}
-	// Discover the plugin groups that match the criteria.
-	groups, err := DiscoverPluginGroups(discovery.WithGroupDiscoveryCriteria(discoveryCriteria), discovery.WithUseLocalCacheOnly())
+	})
 	if err != nil {
 		// If there's an error, wrap it with additional context and return.
 		return false, false, fmt.Errorf("failed to discover plugin groups: %w", err)
@@ -0,0 +1,74 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package pluginmanager
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/common"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/config"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/discovery"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/plugininventory"
+)
+
+// setupBenchmarkPluginSource replaces the plugin inventory of the test
+// plugin source with a generated inventory of the number of plugins
+func setupBenchmarkPluginSource(b *testing.B, plugins int) {
+	b.Helper()
+	b.Cleanup(setupPluginSourceForTesting())
+
+	dbFile := filepath.Join(common.DefaultCacheDir, common.PluginInventoryDirName, config.DefaultStandaloneDiscoveryName, plugininventory.SQliteDBFileName)
+	if err := os.Remove(dbFile); err != nil {
+		b.Fatal(err)
+	}
+	err := plugininventory.GenerateInventoryDB(dbFile, &plugininventory.GeneratorOptions{
+		Plugins:           plugins,
+		VersionsPerPlugin: 10,
+		Platforms:         []string{"linux/amd64", "darwin/amd64", "darwin/arm64", "windows/amd64"},
+		Groups:            10,
+		PluginsPerGroup:   20,
+	})
+	if err != nil {
+		b.Fatal(err)
+	}
+}
+
+// BenchmarkDiscoverStandalonePlugins measures the discovery of the plugins
+// of the plugin source, as done by 'tanzu plugin search'
+func BenchmarkDiscoverStandalonePlugins(b *testing.B) {
+	setupBenchmarkPluginSource(b, 500)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := DiscoverStandalonePlugins(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkDiscoverStandalonePluginByName measures the discovery of a single
+// plugin of the plugin source, as done by 'tanzu plugin install'
+func BenchmarkDiscoverStandalonePluginByName(b *testing.B) {
+	setupBenchmarkPluginSource(b, 500)
+	criteria := discovery.WithPluginDiscoveryCriteria(&discovery.PluginDiscoveryCriteria{Name: "plugin00001"})
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := DiscoverStandalonePlugins(criteria); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkDiscoverPluginGroups measures the discovery of the plugin groups
+// of the plugin source, as done by 'tanzu plugin group search'
+func BenchmarkDiscoverPluginGroups(b *testing.B) {
+	setupBenchmarkPluginSource(b, 500)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := DiscoverPluginGroups(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -9,14 +9,18 @@ import (
 	"os/exec"
 	"path/filepath"
 	"reflect"
+	"runtime"
 	"strings"
 	"testing"
 
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 
+	configlib "github.com/vmware-tanzu/tanzu-plugin-runtime/config"
 	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
 
+	"github.com/vmware-tanzu/tanzu-cli/pkg/buildinfo"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/catalog"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/common"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/config"
@@ -276,6 +280,30 @@ func Test_InstallStandalonePlugin(t *testing.T) {
 	assertions.Equal("login", installedPlugins[0].Name)
 	assertions.Equal("v0.2.0", installedPlugins[0].Version)
 
+	// Install login (standalone) plugin with a version range
+	// Make sure it installs the latest version satisfying the range
+	// among available versions (v0.2.0, v0.2.0-beta.1, v0.20.0)
+	err = InstallStandalonePlugin("login", ">=0.2.1 <1.0.0", configtypes.TargetUnknown)
+	assertions.Nil(err)
+	installedPlugins, err = pluginsupplier.GetInstalledPlugins()
+	assertions.Nil(err)
+	assertions.Equal(1, len(installedPlugins))
+	assertions.Equal("v0.20.0", installedPlugins[0].Version)
+
+	// Install login (standalone) plugin with a tilde range
+	// Make sure it does not install the pre-release v0.2.0-beta.1 or v0.20.0
+	err = InstallStandalonePlugin("login", "~0.2", configtypes.TargetUnknown)
+	assertions.Nil(err)
+	installedPlugins, err = pluginsupplier.GetInstalledPlugins()
+	assertions.Nil(err)
+	assertions.Equal(1, len(installedPlugins))
+	assertions.Equal("v0.2.0", installedPlugins[0].Version)
+
+	// Try installing login (standalone) plugin with a version range no version satisfies
+	err = InstallStandalonePlugin("login", ">v1.0.0", configtypes.TargetUnknown)
+	assertions.NotNil(err)
+	assertions.Contains(err.Error(), "unable to find plugin 'login' matching version '>v1.0.0'")
+
 	// Try installing myplugin plugin with no context-type and no specific version
 	err = InstallStandalonePlugin("myplugin", cli.VersionLatest, configtypes.TargetUnknown)
 	assertions.NotNil(err)
@@ -330,6 +358,17 @@ func Test_InstallStandalonePlugin(t *testing.T) {
 	// Now reset to the real machine architecture
 	cli.SetArch(realArch)
 
+	// When on Darwin ARM64 with the AMD64 fallback deactivated, installing a plugin
+	// that is only available for Darwin AMD64 fails
+	assertions.Nil(configlib.SetFeature("global", "amd64-fallback", "false"))
+	cli.SetArch(cli.DarwinARM64)
+	err = InstallStandalonePlugin("pluginnoarmdarwin", "v1.0.0", configtypes.TargetUnknown)
+	assertions.NotNil(err)
+	assertions.Contains(err.Error(), "unable to find plugin 'pluginnoarmdarwin' matching version 'v1.0.0'")
+	assertions.Equal(cli.DarwinARM64, cli.BuildArch())
+	cli.SetArch(realArch)
+	assertions.Nil(configlib.SetFeature("global", "amd64-fallback", "true"))
+
 	// When on Windows ARM64, try installing a plugin that is only available for Windows AMD64
 	// and see that it still gets installed (it will use AMD64)
 	//
@@ -1030,6 +1069,147 @@ func TestVerifyPluginPostDownload(t *testing.T) {
 	}
 }
 
+func TestGetUpgradeVersion(t *testing.T) {
+	p := &discovery.Discovered{
+		Name:               "cluster",
+		Target:             configtypes.TargetK8s,
+		SupportedVersions:  []string{"v2.0.0-beta.1", "v1.8.0", "v1.9.0"},
+		RecommendedVersion: "v1.9.0",
+	}
+
+	tests := []struct {
+		name             string
+		requestedVersion string
+		installedVersion string
+		expected         string
+		expectErr        bool
+	}{
+		{name: "not installed", requestedVersion: cli.VersionLatest, expected: "v1.9.0"},
+		{name: "older stable installed", requestedVersion: cli.VersionLatest, installedVersion: "v1.8.0", expected: "v1.9.0"},
+		{name: "pre-release more recent than the latest stable installed", requestedVersion: cli.VersionLatest, installedVersion: "v2.0.0-beta.1", expected: "v2.0.0-beta.1"},
+		{name: "constraint with pre-release installed", requestedVersion: ">=1.0.0", installedVersion: "v2.0.0-beta.1", expected: "v2.0.0-beta.1"},
+		{name: "older version requested", requestedVersion: "v1.9.0", installedVersion: "v2.0.0-beta.1", expectErr: true},
+		{name: "same version requested", requestedVersion: "v1.9.0", installedVersion: "v1.9.0", expected: "v1.9.0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			version, err := getUpgradeVersion(p, tt.requestedVersion, tt.installedVersion)
+			if tt.expectErr {
+				assert.ErrorContains(t, err, "is older than the installed version")
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expected, version)
+		})
+	}
+}
+
+func TestUpgradeDoesNotDowngradePreRelease(t *testing.T) {
+	assert := assert.New(t)
+
+	mc := managerConfig{store: catalog.Store{CacheDir: t.TempDir(), PluginRoot: t.TempDir()}}
+	c, err := mc.store.NewContextCatalogUpdater("")
+	assert.Nil(err)
+	assert.Nil(c.Upsert(&cli.PluginInfo{
+		Name:             "cluster",
+		Version:          "v2.0.0-beta.1",
+		Target:           configtypes.TargetK8s,
+		InstallationPath: filepath.Join(mc.store.PluginRoot, "cluster", "v2.0.0-beta.1_digest_kubernetes"),
+	}))
+	c.Unlock()
+
+	// v1.9.0 is the latest stable version but the installed pre-release is more recent.
+	// The plugin has no distribution, so installing any version would fail.
+	p := &discovery.Discovered{
+		Name:               "cluster",
+		Target:             configtypes.TargetK8s,
+		SupportedVersions:  []string{"v1.9.0", "v2.0.0-beta.1"},
+		RecommendedVersion: "v1.9.0",
+	}
+	assert.Nil(mc.installMatchedPlugin(p, cli.VersionLatest, true))
+
+	plugins, err := mc.getInstalledPlugins()
+	assert.Nil(err)
+	assert.Len(plugins, 1)
+	assert.Equal("v2.0.0-beta.1", plugins[0].Version)
+
+	// Explicitly requesting the older version is refused
+	p.SupportedVersions = []string{"v1.9.0"}
+	assert.ErrorContains(mc.installMatchedPlugin(p, "v1.9.0", true), "is older than the installed version")
+}
+
+func TestVerifyMinCLIVersion(t *testing.T) {
+	origVersion := buildinfo.Version
+	defer func() { buildinfo.Version = origVersion }()
+
+	newDiscovered := func(minCLIVersion string) *discovery.Discovered {
+		return &discovery.Discovered{
+			Name: "login",
+			Distribution: distribution.Artifacts{
+				"v1.0.0": []distribution.Artifact{
+					{OS: cli.GOOS, Arch: cli.GOARCH, Image: "example.com/login:v1.0.0", MinCLIVersion: minCLIVersion},
+				},
+			},
+		}
+	}
+
+	tcs := []struct {
+		name          string
+		cliVersion    string
+		minCLIVersion string
+		skipCheck     bool
+		err           string
+	}{
+		{
+			name:       "no minimum CLI version",
+			cliVersion: "v1.2.0",
+		},
+		{
+			name:          "CLI version is the minimum CLI version",
+			cliVersion:    "v1.3.0",
+			minCLIVersion: "v1.3.0",
+		},
+		{
+			name:          "CLI version is newer than the minimum CLI version",
+			cliVersion:    "v1.4.0",
+			minCLIVersion: "v1.3.0",
+		},
+		{
+			name:          "CLI version cannot be parsed",
+			cliVersion:    "dev",
+			minCLIVersion: "v1.3.0",
+		},
+		{
+			name:          "CLI version is older than the minimum CLI version",
+			cliVersion:    "v1.2.0",
+			minCLIVersion: "v1.3.0",
+			err:           "plugin 'login:v1.0.0' requires Tanzu CLI version v1.3.0 or later, but the current version is v1.2.0. Please upgrade the Tanzu CLI or set TANZU_CLI_SKIP_MIN_CLI_VERSION_CHECK=true to install the plugin anyway",
+		},
+		{
+			name:          "CLI version is older than the minimum CLI version but the check is skipped",
+			cliVersion:    "v1.2.0",
+			minCLIVersion: "v1.3.0",
+			skipCheck:     true,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			buildinfo.Version = tc.cliVersion
+			if tc.skipCheck {
+				t.Setenv(constants.SkipMinCLIVersionCheck, "true")
+			}
+
+			err := verifyMinCLIVersion(newDiscovered(tc.minCLIVersion), "v1.0.0")
+			if tc.err != "" {
+				assert.EqualError(t, err, tc.err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
 func TestHelperProcess(_ *testing.T) {
 	if os.Getenv("GO_WANT_HELPER_PROCESS") != "1" {
 		return
@@ -1801,3 +1981,43 @@ func TestRemoveOldPluginsWhenDuplicates(t *testing.T) {
 		})
 	}
 }
+
+func TestCanExecutePlugins(t *testing.T) {
+	assertions := assert.New(t)
+
+	realArch := cli.BuildArch()
+	defer cli.SetArch(realArch)
+
+	assertions.True(canExecutePlugins())
+
+	// A different OS cannot be run
+	if runtime.GOOS == "linux" {
+		cli.SetArch(cli.Arch("darwin_" + runtime.GOARCH))
+	} else {
+		cli.SetArch(cli.Arch("linux_" + runtime.GOARCH))
+	}
+	assertions.False(canExecutePlugins())
+
+	// AMD64 binaries can only be run on ARM64 machines for Darwin and Windows
+	cli.SetArch(cli.Arch(runtime.GOOS + "_amd64"))
+	assertions.Equal(runtime.GOARCH == "amd64" || runtime.GOOS != "linux", canExecutePlugins())
+
+	// Universal binaries can be run on any architecture of their OS
+	cli.SetArch(cli.DarwinUniversal)
+	assertions.Equal(runtime.GOOS == "darwin", canExecutePlugins())
+}
+
+func TestIsAMD64FallbackActivated(t *testing.T) {
+	assertions := assert.New(t)
+
+	defer setupPluginSourceForTesting()()
+
+	// The fallback is activated by default
+	assertions.True(isAMD64FallbackActivated())
+
+	assertions.Nil(configlib.SetFeature("global", "amd64-fallback", "false"))
+	assertions.False(isAMD64FallbackActivated())
+
+	assertions.Nil(configlib.SetFeature("global", "amd64-fallback", "true"))
+	assertions.True(isAMD64FallbackActivated())
+}
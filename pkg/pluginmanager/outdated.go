@@ -0,0 +1,73 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package pluginmanager
+
+import (
+	"sort"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/discovery"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/plugininventory"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/pluginsupplier"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/utils"
+)
+
+// OutdatedPluginInfo describes how an installed plugin compares with the plugin
+// versions available in the plugin sources
+type OutdatedPluginInfo struct {
+	Name             string
+	Target           string
+	InstalledVersion string
+	// LatestVersion is the most recent version available in the plugin sources,
+	// empty if the plugin is not found in the plugin sources
+	LatestVersion    string
+	InstallationPath string
+	// Outdated is set when the latest version is more recent than the installed version
+	Outdated bool
+	// Advisories are the security advisories affecting the installed version
+	Advisories []*plugininventory.PluginAdvisory
+}
+
+// GetOutdatedPluginsInfo compares each installed plugin with the versions of the
+// plugin available in the plugin sources, sorted by name and target
+func GetOutdatedPluginsInfo() ([]OutdatedPluginInfo, error) {
+	installedPlugins, err := pluginsupplier.GetInstalledPlugins()
+	if err != nil {
+		return nil, err
+	}
+	availablePlugins, err := DiscoverStandalonePlugins()
+	if err != nil {
+		return nil, err
+	}
+	return getOutdatedPluginsInfo(installedPlugins, availablePlugins), nil
+}
+
+func getOutdatedPluginsInfo(installedPlugins []cli.PluginInfo, availablePlugins []discovery.Discovered) []OutdatedPluginInfo {
+	infos := make([]OutdatedPluginInfo, 0, len(installedPlugins))
+	for i := range installedPlugins {
+		info := OutdatedPluginInfo{
+			Name:             installedPlugins[i].Name,
+			Target:           string(installedPlugins[i].Target),
+			InstalledVersion: installedPlugins[i].Version,
+			InstallationPath: installedPlugins[i].InstallationPath,
+		}
+		for j := range availablePlugins {
+			if installedPlugins[i].Name == availablePlugins[j].Name && installedPlugins[i].Target == availablePlugins[j].Target {
+				info.LatestVersion = availablePlugins[j].RecommendedVersion
+				info.Outdated = info.LatestVersion != "" && utils.IsNewVersion(info.LatestVersion, info.InstalledVersion)
+				info.Advisories = availablePlugins[j].Advisories[info.InstalledVersion]
+				break
+			}
+		}
+		infos = append(infos, info)
+	}
+
+	sort.Slice(infos, func(i, j int) bool {
+		if infos[i].Name != infos[j].Name {
+			return infos[i].Name < infos[j].Name
+		}
+		return infos[i].Target < infos[j].Target
+	})
+	return infos
+}
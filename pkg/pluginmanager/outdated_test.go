@@ -0,0 +1,41 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package pluginmanager
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/discovery"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/plugininventory"
+)
+
+func TestGetOutdatedPluginsInfo(t *testing.T) {
+	advisory := &plugininventory.PluginAdvisory{ID: "CVE-2024-12345"}
+	installedPlugins := []cli.PluginInfo{
+		{Name: "secret", Target: configtypes.TargetK8s, Version: "v1.0.0"},
+		{Name: "cluster", Target: configtypes.TargetTMC, Version: "v1.0.0"},
+		{Name: "cluster", Target: configtypes.TargetK8s, Version: "v1.0.0"},
+		{Name: "local", Target: configtypes.TargetGlobal, Version: "v0.1.0"},
+	}
+	availablePlugins := []discovery.Discovered{
+		{Name: "cluster", Target: configtypes.TargetK8s, RecommendedVersion: "v1.1.0"},
+		{Name: "cluster", Target: configtypes.TargetTMC, RecommendedVersion: "v1.0.0"},
+		{
+			Name: "secret", Target: configtypes.TargetK8s, RecommendedVersion: "v1.0.0",
+			Advisories: map[string][]*plugininventory.PluginAdvisory{"v1.0.0": {advisory}},
+		},
+	}
+
+	assert.Equal(t, []OutdatedPluginInfo{
+		{Name: "cluster", Target: "kubernetes", InstalledVersion: "v1.0.0", LatestVersion: "v1.1.0", Outdated: true},
+		{Name: "cluster", Target: "mission-control", InstalledVersion: "v1.0.0", LatestVersion: "v1.0.0"},
+		{Name: "local", Target: "global", InstalledVersion: "v0.1.0"},
+		{Name: "secret", Target: "kubernetes", InstalledVersion: "v1.0.0", LatestVersion: "v1.0.0", Advisories: []*plugininventory.PluginAdvisory{advisory}},
+	}, getOutdatedPluginsInfo(installedPlugins, availablePlugins))
+}
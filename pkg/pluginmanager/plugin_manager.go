@@ -0,0 +1,198 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package pluginmanager
+
+import (
+	"io"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/catalog"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/common"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/discovery"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/pluginsupplier"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/utils"
+)
+
+// PluginManager drives the lifecycle of plugins for Go programs embedding
+// the plugin management of the Tanzu CLI.
+// Unlike the package-level functions, which use the locations and the
+// discovery sources of the CLI configuration, a PluginManager uses the
+// ones it was created with.
+type PluginManager struct {
+	config      managerConfig
+	output      io.Writer
+	concurrency int
+}
+
+// Option configures a PluginManager
+type Option func(pm *PluginManager)
+
+// WithPluginRoot sets the directory in which the plugin binaries are installed
+func WithPluginRoot(dir string) Option {
+	return func(pm *PluginManager) {
+		pm.config.store.PluginRoot = dir
+	}
+}
+
+// WithCacheDir sets the directory holding the plugin catalog and the
+// cache of the plugin inventories
+func WithCacheDir(dir string) Option {
+	return func(pm *PluginManager) {
+		pm.config.store.CacheDir = dir
+	}
+}
+
+// WithDiscoverySources sets the discovery sources from which plugins are
+// discovered, instead of the ones of the CLI configuration
+func WithDiscoverySources(sources ...configtypes.PluginDiscovery) Option {
+	return func(pm *PluginManager) {
+		pm.config.discoverySources = sources
+	}
+}
+
+// WithOutput sets the writer to which the messages of the operations are written
+func WithOutput(w io.Writer) Option {
+	return func(pm *PluginManager) {
+		pm.output = w
+	}
+}
+
+// WithConcurrency sets the maximum number of plugins processed concurrently
+// by the operations which support it, like the verification of the plugins.
+// Installations remain sequential as they are not safe to run concurrently.
+func WithConcurrency(n int) Option {
+	return func(pm *PluginManager) {
+		pm.concurrency = n
+	}
+}
+
+// NewPluginManager creates a PluginManager with the provided options.
+// By default, it uses the same locations and discovery sources as the CLI.
+func NewPluginManager(opts ...Option) *PluginManager {
+	pm := &PluginManager{
+		concurrency: 1,
+	}
+	for _, opt := range opts {
+		opt(pm)
+	}
+	return pm
+}
+
+// run runs the operation with the messages written to the output of the PluginManager
+func (pm *PluginManager) run(operation func() error) error {
+	if pm.output != nil {
+		defer utils.SetLogWriters(pm.output, pm.output)()
+	}
+	return operation()
+}
+
+// DiscoverPlugins returns the plugins available from the discovery sources
+func (pm *PluginManager) DiscoverPlugins(options ...discovery.DiscoveryOptions) (plugins []discovery.Discovered, err error) {
+	err = pm.run(func() error {
+		plugins, err = pm.config.discoverStandalonePlugins(options...)
+		return err
+	})
+	return plugins, err
+}
+
+// ListInstalledPlugins returns the installed plugins
+func (pm *PluginManager) ListInstalledPlugins() (plugins []cli.PluginInfo, err error) {
+	err = pm.run(func() error {
+		plugins, err = pm.config.getInstalledPlugins()
+		return err
+	})
+	return plugins, err
+}
+
+// DescribePlugin describes an installed plugin
+func (pm *PluginManager) DescribePlugin(pluginName string, target configtypes.Target) (info *cli.PluginInfo, err error) {
+	err = pm.run(func() error {
+		info, err = pm.config.describeInstalledPlugin(pluginName, target)
+		return err
+	})
+	return info, err
+}
+
+// InstallPlugin installs a plugin from the discovery sources
+func (pm *PluginManager) InstallPlugin(pluginName, version string, target configtypes.Target) error {
+	return pm.run(func() error {
+		return pm.config.installPlugin(pluginName, version, target, "", false)
+	})
+}
+
+// InstallPluginsFromGroup installs either the specified plugin or all plugins from the specified plugin group
+func (pm *PluginManager) InstallPluginsFromGroup(pluginName, groupIDAndVersion string) (groupWithVersion string, err error) {
+	err = pm.run(func() error {
+		groupWithVersion, err = pm.config.installPluginsFromGroup(pluginName, groupIDAndVersion)
+		return err
+	})
+	return groupWithVersion, err
+}
+
+// UpgradePlugin upgrades a plugin from the discovery sources without ever downgrading it
+func (pm *PluginManager) UpgradePlugin(pluginName, version string, target configtypes.Target) error {
+	return pm.run(func() error {
+		return pm.config.installPlugin(pluginName, version, target, "", true)
+	})
+}
+
+// DeletePlugin deletes a plugin
+func (pm *PluginManager) DeletePlugin(options DeletePluginOptions) error {
+	return pm.run(func() error {
+		return pm.config.deletePlugin(options)
+	})
+}
+
+// VerifyInstalledPlugins verifies the binaries of the installed plugins
+func (pm *PluginManager) VerifyInstalledPlugins(checkPluginSources bool) (results []PluginVerificationResult, err error) {
+	err = pm.run(func() error {
+		results, err = pm.config.verifyInstalledPlugins(checkPluginSources, pm.concurrency)
+		return err
+	})
+	return results, err
+}
+
+// Clean deletes all the installed plugins and the plugin catalog
+func (pm *PluginManager) Clean() error {
+	return pm.run(pm.config.clean)
+}
+
+// managerConfig holds the locations and the discovery sources used by the
+// operations on plugins.  Its zero value uses the ones of the CLI.
+type managerConfig struct {
+	store            catalog.Store
+	discoverySources []configtypes.PluginDiscovery
+}
+
+// pluginRoot returns the directory in which the plugin binaries are installed
+func (mc managerConfig) pluginRoot() string {
+	if mc.store.PluginRoot != "" {
+		return mc.store.PluginRoot
+	}
+	return common.DefaultPluginRoot
+}
+
+// cacheDir returns the directory holding the plugin catalog and the cache of the plugin inventories
+func (mc managerConfig) cacheDir() string {
+	if mc.store.CacheDir != "" {
+		return mc.store.CacheDir
+	}
+	return common.DefaultCacheDir
+}
+
+// discoveryOptions returns the options of the discoveries, completed so that
+// the plugin inventories are cached in the cache directory of the configuration
+func (mc managerConfig) discoveryOptions(options ...discovery.DiscoveryOptions) []discovery.DiscoveryOptions {
+	if mc.store.CacheDir == "" {
+		return options
+	}
+	return append(options, discovery.WithCacheDir(mc.store.CacheDir))
+}
+
+// getInstalledPlugins returns the installed plugins of the catalog of the configuration
+func (mc managerConfig) getInstalledPlugins() ([]cli.PluginInfo, error) {
+	return pluginsupplier.GetInstalledPluginsFromCatalog(mc.store)
+}
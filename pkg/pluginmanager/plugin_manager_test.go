@@ -0,0 +1,141 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package pluginmanager
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/common"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/pluginsupplier"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/utils"
+)
+
+func TestPluginManagerOptions(t *testing.T) {
+	assert := assert.New(t)
+
+	pluginRoot := t.TempDir()
+	cacheDir := t.TempDir()
+	sources := []configtypes.PluginDiscovery{
+		{OCI: &configtypes.OCIDiscovery{Name: "default", Image: "example.com/tanzu-cli/plugins/plugin-inventory:latest"}},
+	}
+	var out bytes.Buffer
+	pm := NewPluginManager(
+		WithPluginRoot(pluginRoot),
+		WithCacheDir(cacheDir),
+		WithDiscoverySources(sources...),
+		WithOutput(&out),
+		WithConcurrency(4),
+	)
+	assert.Equal(4, pm.concurrency)
+
+	assert.Equal(pluginRoot, pm.config.pluginRoot())
+	assert.Equal(cacheDir, pm.config.cacheDir())
+	discoveries, err := pm.config.getPluginDiscoveries()
+	assert.Nil(err)
+	assert.Equal(sources, discoveries)
+
+	// The package-level state is left unchanged
+	origPluginRoot, origCacheDir := common.DefaultPluginRoot, common.DefaultCacheDir
+	err = pm.run(func() error {
+		assert.Equal(origPluginRoot, common.DefaultPluginRoot)
+		assert.Equal(origCacheDir, common.DefaultCacheDir)
+		return nil
+	})
+	assert.Nil(err)
+}
+
+func TestPluginManagerRestoresOutput(t *testing.T) {
+	assert := assert.New(t)
+
+	var embedderOut, out bytes.Buffer
+	restore := utils.SetLogWriters(&embedderOut, nil)
+	defer restore()
+
+	pm := NewPluginManager(WithOutput(&out))
+	err := pm.run(func() error {
+		log.Outputf("from the plugin manager")
+		return nil
+	})
+	assert.Nil(err)
+	log.Outputf("from the embedder")
+
+	assert.Contains(out.String(), "from the plugin manager")
+	assert.NotContains(out.String(), "from the embedder")
+	assert.Contains(embedderOut.String(), "from the embedder")
+}
+
+func TestPluginManagerVerifyInstalledPlugins(t *testing.T) {
+	assert := assert.New(t)
+
+	pluginRoot := t.TempDir()
+	cacheDir := t.TempDir()
+	pm := NewPluginManager(WithPluginRoot(pluginRoot), WithCacheDir(cacheDir), WithConcurrency(2))
+
+	// Install fake plugins in the catalog of the PluginManager
+	err := func() error {
+		c, err := pm.config.store.NewContextCatalogUpdater("")
+		if err != nil {
+			return err
+		}
+		defer c.Unlock()
+		for _, name := range []string{"fake1", "fake2", "fake3"} {
+			binary := []byte(name)
+			digest := fmt.Sprintf("%x", sha256.Sum256(binary))
+			pluginPath := filepath.Join(pluginRoot, name, "v1.0.0_"+digest+"_global")
+			if err := os.MkdirAll(filepath.Dir(pluginPath), 0755); err != nil {
+				return err
+			}
+			if err := os.WriteFile(pluginPath, binary, 0755); err != nil {
+				return err
+			}
+			if err := c.Upsert(&cli.PluginInfo{
+				Name:             name,
+				Version:          "v1.0.0",
+				Target:           configtypes.TargetGlobal,
+				InstallationPath: pluginPath,
+				Digest:           digest,
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}()
+	assert.Nil(err)
+
+	plugins, err := pm.ListInstalledPlugins()
+	assert.Nil(err)
+	assert.Len(plugins, 3)
+
+	// The plugins of the PluginManager are not installed for the CLI
+	for i := range plugins {
+		assert.False(pluginsupplier.IsPluginInstalled(plugins[i].Name, plugins[i].Target, plugins[i].Version))
+	}
+
+	// Tamper with one of the plugin binaries
+	pd, err := pm.DescribePlugin("fake2", configtypes.TargetGlobal)
+	assert.Nil(err)
+	assert.Nil(os.WriteFile(pd.InstallationPath, []byte("tampered"), 0755))
+
+	results, err := pm.VerifyInstalledPlugins(false)
+	assert.Nil(err)
+	assert.Len(results, 3)
+	for i := range results {
+		if results[i].Name == "fake2" {
+			assert.Equal(PluginVerificationStatusTampered, results[i].Status)
+		} else {
+			assert.Equal(PluginVerificationStatusOK, results[i].Status)
+		}
+	}
+}
@@ -0,0 +1,283 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package pluginmanager
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Masterminds/semver"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/catalog"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
+)
+
+// The binary of each plugin version installed is saved in the plugin root as
+// <pluginRoot>/<name>/<version>_<digest>_<target>, and is kept when another version
+// of the plugin is installed so that switching back to it is instant.  The number
+// of versions kept for each plugin is limited by the plugin retention policy.
+
+// pluginBinary is a binary of a plugin version saved in the plugin root
+type pluginBinary struct {
+	name    string
+	target  string
+	version string
+	path    string
+	// size is the size in bytes of the binary along with its test plugin binary
+	size int64
+}
+
+// PluginDiskUsage describes the binaries of a plugin kept in the plugin root
+type PluginDiskUsage struct {
+	Name   string
+	Target configtypes.Target
+	// InstalledVersions are the versions of the plugin currently installed
+	InstalledVersions []string
+	// Versions are the versions of the plugin kept on disk, most recent first
+	Versions []string
+	// Size is the size in bytes of all the binaries of the plugin
+	Size int64
+}
+
+// GetPluginRetainVersions returns the number of versions of each plugin whose binaries
+// are kept on disk, as configured by TANZU_CLI_PLUGIN_RETAIN_VERSIONS.
+// Zero means that all the versions are kept.
+func GetPluginRetainVersions() int {
+	n, err := strconv.Atoi(os.Getenv(constants.ConfigVariablePluginRetainVersions))
+	if err != nil || n < 0 {
+		return 0
+	}
+	return n
+}
+
+// GetPluginDiskUsage returns the disk usage of the binaries of each plugin kept in the plugin root
+func GetPluginDiskUsage() ([]PluginDiskUsage, error) {
+	binaries, err := managerConfig{}.listPluginBinaries()
+	if err != nil {
+		return nil, err
+	}
+	installedPaths, err := catalog.GetInstallationPaths()
+	if err != nil {
+		return nil, err
+	}
+
+	var usages []PluginDiskUsage
+	for _, group := range groupPluginBinaries(binaries) {
+		usage := PluginDiskUsage{Name: group[0].name, Target: configtypes.Target(group[0].target)}
+		for i := range group {
+			usage.Size += group[i].size
+			if installedPaths[group[i].path] && !slices.Contains(usage.InstalledVersions, group[i].version) {
+				usage.InstalledVersions = append(usage.InstalledVersions, group[i].version)
+			}
+			if !slices.Contains(usage.Versions, group[i].version) {
+				usage.Versions = append(usage.Versions, group[i].version)
+			}
+		}
+		usages = append(usages, usage)
+	}
+	return usages, nil
+}
+
+// CleanOldPluginVersions removes the binaries of the plugin versions which are not installed,
+// except for the most recent ones kept by the plugin retention policy.
+// It returns the number of bytes freed.
+func CleanOldPluginVersions() (int64, error) {
+	return managerConfig{}.removeOldPluginVersions(GetPluginRetainVersions(), func(*pluginBinary) bool { return true })
+}
+
+// applyPluginRetentionPolicy removes the binaries of the versions of the plugin exceeding
+// the number of versions kept by the plugin retention policy, if one is configured
+func (mc managerConfig) applyPluginRetentionPolicy(p *cli.PluginInfo) {
+	retain := GetPluginRetainVersions()
+	if retain == 0 {
+		return
+	}
+	_, err := mc.removeOldPluginVersions(retain, func(b *pluginBinary) bool {
+		return b.name == p.Name && b.target == string(p.Target)
+	})
+	if err != nil {
+		log.V(6).Warningf("unable to remove the older versions of plugin %q: %v", p.Name, err)
+	}
+}
+
+// removeOldPluginVersions removes the binaries of the plugins selected by the filter, keeping
+// the installed ones and the most recent ones up to the specified number of versions per plugin
+func (mc managerConfig) removeOldPluginVersions(retain int, filter func(*pluginBinary) bool) (int64, error) {
+	binaries, err := mc.listPluginBinaries()
+	if err != nil {
+		return 0, err
+	}
+	installedPaths, err := mc.store.GetInstallationPaths()
+	if err != nil {
+		return 0, err
+	}
+
+	var freed int64
+	errorList := make([]error, 0)
+	for _, group := range groupPluginBinaries(binaries) {
+		if !filter(&group[0]) {
+			continue
+		}
+		for _, b := range selectBinariesToRemove(group, installedPaths, retain) {
+			if err := os.Remove(b.path); err != nil {
+				errorList = append(errorList, err)
+				continue
+			}
+			testPluginPath := cli.TestPluginPathFromPluginPath(b.path)
+			if err := os.Remove(testPluginPath); err != nil && !os.IsNotExist(err) {
+				errorList = append(errorList, err)
+			}
+			log.V(6).Infof("removed binary %q of plugin %q", b.path, b.name)
+			freed += b.size
+		}
+	}
+	return freed, kerrors.NewAggregate(errorList)
+}
+
+// selectBinariesToRemove returns the binaries of a plugin which are neither installed nor
+// among the most recent ones, the installed binaries counting toward the versions retained
+func selectBinariesToRemove(group []pluginBinary, installedPaths map[string]bool, retain int) []pluginBinary {
+	retained := make(map[string]bool)
+	for i := range group {
+		if installedPaths[group[i].path] {
+			retained[group[i].version] = true
+		}
+	}
+	// The group is sorted from the most recent version
+	for i := range group {
+		if len(retained) >= retain {
+			break
+		}
+		retained[group[i].version] = true
+	}
+
+	var toRemove []pluginBinary
+	for i := range group {
+		if installedPaths[group[i].path] || (retained[group[i].version] && !hasInstalledBinary(group, installedPaths, group[i].version)) {
+			continue
+		}
+		toRemove = append(toRemove, group[i])
+	}
+	return toRemove
+}
+
+// hasInstalledBinary returns true if one of the binaries of the version is installed,
+// in which case the other binaries of that version, with other digests, are not needed
+func hasInstalledBinary(group []pluginBinary, installedPaths map[string]bool, version string) bool {
+	for i := range group {
+		if group[i].version == version && installedPaths[group[i].path] {
+			return true
+		}
+	}
+	return false
+}
+
+// listPluginBinaries returns the plugin binaries saved in the plugin root
+func (mc managerConfig) listPluginBinaries() ([]pluginBinary, error) {
+	pluginDirs, err := os.ReadDir(mc.pluginRoot())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var binaries []pluginBinary
+	for _, pluginDir := range pluginDirs {
+		if !pluginDir.IsDir() {
+			continue
+		}
+		dir := filepath.Join(mc.pluginRoot(), pluginDir.Name())
+		files, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, file := range files {
+			version, target, ok := parsePluginBinaryName(file.Name())
+			if file.IsDir() || !ok {
+				continue
+			}
+			path := filepath.Join(dir, file.Name())
+			binaries = append(binaries, pluginBinary{
+				name:    pluginDir.Name(),
+				target:  target,
+				version: version,
+				path:    path,
+				size:    fileSize(path) + fileSize(cli.TestPluginPathFromPluginPath(path)),
+			})
+		}
+	}
+	return binaries, nil
+}
+
+// parsePluginBinaryName returns the version and target of a plugin binary
+// from its <version>_<digest>_<target> file name
+func parsePluginBinaryName(fileName string) (version, target string, ok bool) {
+	if strings.HasPrefix(fileName, "test-") {
+		return "", "", false
+	}
+	parts := strings.Split(strings.TrimSuffix(fileName, exe), "_")
+	if len(parts) != 3 || parts[0] == "" {
+		return "", "", false
+	}
+	return parts[0], parts[2], true
+}
+
+// groupPluginBinaries groups the binaries by plugin name and target, each
+// group being sorted from the most recent version to the oldest one
+func groupPluginBinaries(binaries []pluginBinary) [][]pluginBinary {
+	groupIndex := make(map[string]int)
+	var groups [][]pluginBinary
+	for i := range binaries {
+		key := catalog.PluginNameTarget(binaries[i].name, configtypes.Target(binaries[i].target))
+		idx, exists := groupIndex[key]
+		if !exists {
+			idx = len(groups)
+			groupIndex[key] = idx
+			groups = append(groups, nil)
+		}
+		groups[idx] = append(groups[idx], binaries[i])
+	}
+
+	for _, group := range groups {
+		sort.SliceStable(group, func(i, j int) bool {
+			return isMoreRecentVersion(group[i].version, group[j].version)
+		})
+	}
+	sort.SliceStable(groups, func(i, j int) bool {
+		if groups[i][0].name != groups[j][0].name {
+			return groups[i][0].name < groups[j][0].name
+		}
+		return groups[i][0].target < groups[j][0].target
+	})
+	return groups
+}
+
+// isMoreRecentVersion returns true if v1 has a higher precedence than v2,
+// the versions which are not semantic versions coming last
+func isMoreRecentVersion(v1, v2 string) bool {
+	sv1, err1 := semver.NewVersion(v1)
+	sv2, err2 := semver.NewVersion(v2)
+	if err1 != nil || err2 != nil {
+		return err1 == nil && err2 != nil
+	}
+	return sv1.GreaterThan(sv2)
+}
+
+func fileSize(path string) int64 {
+	info, err := os.Stat(path)
+	if err != nil {
+		return 0
+	}
+	return info.Size()
+}
@@ -0,0 +1,130 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package pluginmanager
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/catalog"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/common"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
+)
+
+// setupPluginVersionsForTesting creates the binaries of several versions of the "cluster"
+// plugin in a temporary plugin root, v1.1.0 being installed, and returns their paths by version
+func setupPluginVersionsForTesting(t *testing.T) map[string]string {
+	originalPluginRoot := common.DefaultPluginRoot
+	originalCacheDir := common.DefaultCacheDir
+	t.Cleanup(func() {
+		common.DefaultPluginRoot = originalPluginRoot
+		common.DefaultCacheDir = originalCacheDir
+	})
+	common.DefaultPluginRoot = filepath.Join(t.TempDir(), "plugins")
+	common.DefaultCacheDir = t.TempDir()
+
+	paths := make(map[string]string)
+	dir := filepath.Join(common.DefaultPluginRoot, "cluster")
+	assert.Nil(t, os.MkdirAll(dir, 0755))
+	for _, version := range []string{"v1.0.0", "v1.2.0", "v1.1.0", "v0.9.0"} {
+		paths[version] = filepath.Join(dir, version+"_0123456789abcdef_"+string(configtypes.TargetK8s))
+		assert.Nil(t, os.WriteFile(paths[version], []byte("binary of "+version), 0755))
+	}
+	// The test plugin binaries are accounted with their plugin binary
+	assert.Nil(t, os.WriteFile(cli.TestPluginPathFromPluginPath(paths["v0.9.0"]), []byte("test binary"), 0755))
+	// Files which are not plugin binaries are ignored
+	assert.Nil(t, os.WriteFile(filepath.Join(dir, "README"), []byte("readme"), 0644))
+
+	c, err := catalog.NewContextCatalogUpdater("")
+	assert.Nil(t, err)
+	assert.Nil(t, c.Upsert(&cli.PluginInfo{Name: "cluster", Target: configtypes.TargetK8s, Version: "v1.1.0", InstallationPath: paths["v1.1.0"]}))
+	c.Unlock()
+
+	return paths
+}
+
+func TestGetPluginDiskUsage(t *testing.T) {
+	setupPluginVersionsForTesting(t)
+
+	usages, err := GetPluginDiskUsage()
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(usages))
+	assert.Equal(t, "cluster", usages[0].Name)
+	assert.Equal(t, configtypes.TargetK8s, usages[0].Target)
+	assert.Equal(t, []string{"v1.1.0"}, usages[0].InstalledVersions)
+	assert.Equal(t, []string{"v1.2.0", "v1.1.0", "v1.0.0", "v0.9.0"}, usages[0].Versions)
+	assert.Equal(t, int64(4*len("binary of v1.0.0")+len("test binary")), usages[0].Size)
+}
+
+func TestCleanOldPluginVersions(t *testing.T) {
+	paths := setupPluginVersionsForTesting(t)
+
+	// The installed version counts toward the retained versions
+	os.Setenv(constants.ConfigVariablePluginRetainVersions, "2")
+	defer os.Unsetenv(constants.ConfigVariablePluginRetainVersions)
+	freed, err := CleanOldPluginVersions()
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2*len("binary of v1.0.0")+len("test binary")), freed)
+	assert.FileExists(t, paths["v1.2.0"])
+	assert.FileExists(t, paths["v1.1.0"])
+	assert.NoFileExists(t, paths["v1.0.0"])
+	assert.NoFileExists(t, paths["v0.9.0"])
+	assert.NoFileExists(t, cli.TestPluginPathFromPluginPath(paths["v0.9.0"]))
+
+	// Without a retention policy, only the installed version is kept
+	os.Unsetenv(constants.ConfigVariablePluginRetainVersions)
+	_, err = CleanOldPluginVersions()
+	assert.Nil(t, err)
+	assert.NoFileExists(t, paths["v1.2.0"])
+	assert.FileExists(t, paths["v1.1.0"])
+}
+
+func TestApplyPluginRetentionPolicy(t *testing.T) {
+	paths := setupPluginVersionsForTesting(t)
+	installed := &cli.PluginInfo{Name: "cluster", Target: configtypes.TargetK8s}
+
+	// All the versions are kept by default
+	managerConfig{}.applyPluginRetentionPolicy(installed)
+	for _, path := range paths {
+		assert.FileExists(t, path)
+	}
+
+	os.Setenv(constants.ConfigVariablePluginRetainVersions, "3")
+	defer os.Unsetenv(constants.ConfigVariablePluginRetainVersions)
+
+	// The binaries of other plugins are not affected
+	managerConfig{}.applyPluginRetentionPolicy(&cli.PluginInfo{Name: "cluster", Target: configtypes.TargetGlobal})
+	for _, path := range paths {
+		assert.FileExists(t, path)
+	}
+
+	managerConfig{}.applyPluginRetentionPolicy(installed)
+	assert.FileExists(t, paths["v1.2.0"])
+	assert.FileExists(t, paths["v1.1.0"])
+	assert.FileExists(t, paths["v1.0.0"])
+	assert.NoFileExists(t, paths["v0.9.0"])
+}
+
+func TestParsePluginBinaryName(t *testing.T) {
+	version, target, ok := parsePluginBinaryName("v1.0.0_0123456789abcdef_kubernetes")
+	assert.True(t, ok)
+	assert.Equal(t, "v1.0.0", version)
+	assert.Equal(t, "kubernetes", target)
+
+	version, target, ok = parsePluginBinaryName("v1.0.0-beta.1_0123456789abcdef_global.exe")
+	assert.True(t, ok)
+	assert.Equal(t, "v1.0.0-beta.1", version)
+	assert.Equal(t, "global", target)
+
+	_, _, ok = parsePluginBinaryName("test-v1.0.0_0123456789abcdef_global")
+	assert.False(t, ok)
+	_, _, ok = parsePluginBinaryName("README")
+	assert.False(t, ok)
+}
@@ -0,0 +1,97 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package pluginmanager
+
+import (
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/discovery"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/download"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/plugininventory"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/utils"
+)
+
+// PrefetchPlugins downloads the binaries of the recommended versions of the plugins
+// which are not present already, in parallel within the limits of the download
+// scheduler, and saves them where the installation finds them.  The installation of
+// the plugins, which remains sequential, then uses these binaries instead of downloading
+// them one after the other.  Failures are ignored as the installation of the plugin
+// downloads it again and reports the error.
+func PrefetchPlugins(plugins []discovery.Discovered) {
+	managerConfig{}.prefetchPlugins(plugins, download.DefaultScheduler())
+}
+
+func (mc managerConfig) prefetchPlugins(plugins []discovery.Discovered, scheduler *download.Scheduler) {
+	var pluginsToFetch []*discovery.Discovered
+	for i := range plugins {
+		if plugins[i].Distribution == nil || plugins[i].RecommendedVersion == "" {
+			continue
+		}
+		if pluginPath, _ := mc.findCachedPluginBinary(&plugins[i], plugins[i].RecommendedVersion); pluginPath != "" {
+			continue
+		}
+		pluginsToFetch = append(pluginsToFetch, &plugins[i])
+	}
+	// A single plugin is downloaded by its installation just as fast
+	if len(pluginsToFetch) < 2 {
+		return
+	}
+
+	tasks := make([]func() error, len(pluginsToFetch))
+	for i, p := range pluginsToFetch {
+		tasks[i] = func() error {
+			binary, err := fetchAndVerifyPlugin(p, p.RecommendedVersion)
+			if err != nil {
+				return err
+			}
+			_, _, err = mc.savePluginBinary(p, p.RecommendedVersion, binary)
+			return err
+		}
+	}
+	for i, err := range scheduler.Run(tasks...) {
+		if err != nil {
+			log.V(6).Infof("unable to prefetch plugin '%s:%s': %v", pluginsToFetch[i].Name, pluginsToFetch[i].RecommendedVersion, err)
+		}
+	}
+}
+
+// prefetchPluginsOfGroup prefetches the mandatory plugins of a plugin group which
+// are about to be installed, either all of them or only the specified plugin
+func (mc managerConfig) prefetchPluginsOfGroup(pluginName string, groupPlugins []*plugininventory.PluginGroupPluginEntry) {
+	discoveries, err := mc.getPluginDiscoveries()
+	if err != nil || len(discoveries) == 0 {
+		return
+	}
+
+	// The plugins are discovered sequentially as the discoveries share the cache of the plugin inventories
+	var plugins []discovery.Discovered
+	for _, plugin := range groupPlugins {
+		if !plugin.Mandatory || (pluginName != cli.AllPlugins && pluginName != plugin.Name) {
+			continue
+		}
+		// A version constraint is resolved by the installation of the plugin
+		if utils.ParseVersionConstraint(plugin.Version) != nil {
+			continue
+		}
+		criteria := &discovery.PluginDiscoveryCriteria{
+			Name:    plugin.Name,
+			Target:  plugin.Target,
+			Version: plugin.Version,
+			OS:      cli.GOOS,
+			Arch:    cli.GOARCH,
+		}
+		discovered, err := discoverSpecificPlugins(discoveries, mc.discoveryOptions(discovery.WithPluginDiscoveryCriteria(criteria))...)
+		if err != nil {
+			continue
+		}
+		discovered = mergeDuplicatePlugins(discovered)
+		for i := range discovered {
+			if discovered[i].Name == plugin.Name && discovered[i].Target == plugin.Target {
+				plugins = append(plugins, discovered[i])
+			}
+		}
+	}
+	mc.prefetchPlugins(plugins, download.DefaultScheduler())
+}
@@ -0,0 +1,84 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package pluginmanager
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/common"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/discovery"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/distribution"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/download"
+)
+
+// localPluginForPrefetch returns a plugin whose binary is the specified content in a local file
+func localPluginForPrefetch(t *testing.T, name string, content []byte) discovery.Discovered {
+	binaryPath := filepath.Join(t.TempDir(), name)
+	assert.Nil(t, os.WriteFile(binaryPath, content, 0755))
+	return discovery.Discovered{
+		Name:               name,
+		Target:             configtypes.TargetK8s,
+		RecommendedVersion: "v1.0.0",
+		Distribution: distribution.Artifacts{
+			"v1.0.0": []distribution.Artifact{
+				{
+					URI:    binaryPath,
+					Digest: fmt.Sprintf("%x", sha256.Sum256(content)),
+					OS:     cli.GOOS,
+					Arch:   cli.GOARCH,
+				},
+			},
+		},
+	}
+}
+
+func TestPrefetchPlugins(t *testing.T) {
+	origPluginRoot := common.DefaultPluginRoot
+	defer func() { common.DefaultPluginRoot = origPluginRoot }()
+	common.DefaultPluginRoot = t.TempDir()
+
+	plugins := []discovery.Discovered{
+		localPluginForPrefetch(t, "foo", []byte("foo binary")),
+		localPluginForPrefetch(t, "bar", []byte("bar binary")),
+		localPluginForPrefetch(t, "baz", []byte("baz binary")),
+	}
+	// The binary of this plugin does not match its digest, so it is not saved
+	plugins[2].Distribution.(distribution.Artifacts)["v1.0.0"][0].Digest = "invalid"
+
+	managerConfig{}.prefetchPlugins(plugins, download.NewScheduler(2, 0))
+
+	for i, content := range []string{"foo binary", "bar binary"} {
+		pluginPath, _ := managerConfig{}.findCachedPluginBinary(&plugins[i], "v1.0.0")
+		assert.NotEmpty(t, pluginPath)
+		b, err := os.ReadFile(pluginPath)
+		assert.Nil(t, err)
+		assert.Equal(t, content, string(b))
+	}
+	pluginPath, _ := managerConfig{}.findCachedPluginBinary(&plugins[2], "v1.0.0")
+	assert.Empty(t, pluginPath)
+}
+
+func TestPrefetchPluginsSkipsSinglePlugin(t *testing.T) {
+	origPluginRoot := common.DefaultPluginRoot
+	defer func() { common.DefaultPluginRoot = origPluginRoot }()
+	common.DefaultPluginRoot = t.TempDir()
+
+	plugins := []discovery.Discovered{
+		localPluginForPrefetch(t, "foo", []byte("foo binary")),
+	}
+	managerConfig{}.prefetchPlugins(plugins, download.NewScheduler(2, 0))
+
+	// A single plugin is downloaded by its installation instead
+	pluginPath, _ := managerConfig{}.findCachedPluginBinary(&plugins[0], "v1.0.0")
+	assert.Empty(t, pluginPath)
+}
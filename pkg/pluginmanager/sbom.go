@@ -0,0 +1,75 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package pluginmanager
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"os"
+
+	"github.com/pkg/errors"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/discovery"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/sbom"
+)
+
+// GetPluginSBOM returns the SBOM of an installed plugin along with its format.
+// The SBOM is retrieved from the registry where the installed plugin binary
+// was published, using the OCI referrers of the plugin image.
+func GetPluginSBOM(pluginName string, target configtypes.Target) ([]byte, sbom.Format, error) {
+	pd, err := DescribePlugin(pluginName, target)
+	if err != nil {
+		return nil, "", err
+	}
+
+	binary, err := os.ReadFile(pd.InstallationPath)
+	if err != nil {
+		return nil, "", errors.Wrapf(err, "unable to read the binary of plugin '%s'", pluginName)
+	}
+	digest := fmt.Sprintf("%x", sha256.Sum256(binary))
+
+	image, err := findPluginImage(pd, digest)
+	if err != nil {
+		return nil, "", err
+	}
+
+	options, err := sbom.RemoteOptions(image)
+	if err != nil {
+		return nil, "", err
+	}
+	return sbom.Fetch(image, options...)
+}
+
+// findPluginImage returns the image from which the installed plugin binary
+// with the specified digest was downloaded.
+func findPluginImage(pd *cli.PluginInfo, digest string) (string, error) {
+	discoveries, err := getPluginDiscoveries()
+	if err != nil {
+		return "", err
+	}
+	criteria := &discovery.PluginDiscoveryCriteria{
+		Name:    pd.Name,
+		Target:  pd.Target,
+		Version: pd.Version,
+		OS:      cli.GOOS,
+	}
+	availablePlugins, err := discoverSpecificPlugins(discoveries, discovery.WithPluginDiscoveryCriteria(criteria))
+	if err != nil {
+		return "", err
+	}
+
+	// The installed binary may be the AMD64 one if it was installed through the AMD64 fallback
+	for i := range availablePlugins {
+		for _, arch := range []string{cli.GOARCH, "amd64"} {
+			artifact, err := availablePlugins[i].Distribution.DescribeArtifact(pd.Version, cli.GOOS, arch)
+			if err == nil && artifact.Digest == digest && artifact.Image != "" {
+				return artifact.Image, nil
+			}
+		}
+	}
+	return "", errors.Errorf("unable to find the image of plugin '%s' version '%s' for target '%s' in the plugin sources", pd.Name, pd.Version, pd.Target)
+}
@@ -0,0 +1,60 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package pluginmanager
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/common"
+)
+
+// An administrator can provision plugin binaries in read-only system plugin directories
+// (see common.SystemPluginDirs()) so that the users of a shared machine don't each have to
+// download the same plugins.  The system plugin directories use the same layout as the
+// plugin root directory, which means they can be populated by running "tanzu plugin install"
+// with TANZU_CLI_PLUGIN_ROOT set to the system plugin directory.
+// When installing a plugin, the CLI uses the binary of a system plugin directory, if present,
+// instead of downloading it.  The binary is used in place and is never modified or deleted.
+
+// findSystemPluginBinary returns the path of the plugin binary with the specified file name
+// found in the system plugin directories, or an empty string if there is none.
+// Since the system plugin directories are populated outside the control of the CLI,
+// a binary is only used if its digest matches the expected one.
+func findSystemPluginBinary(pluginName, pluginFileName, digest string) string {
+	for _, dir := range common.SystemPluginDirs() {
+		pluginPath := filepath.Join(dir, pluginName, pluginFileName)
+		if _, err := os.Stat(pluginPath); err != nil {
+			continue
+		}
+
+		actualDigest, err := fileDigest(pluginPath)
+		if err != nil || actualDigest != digest {
+			log.V(6).Warningf("ignoring system plugin binary %q as its digest does not match the expected digest %q", pluginPath, digest)
+			continue
+		}
+		return pluginPath
+	}
+	return ""
+}
+
+// fileDigest returns the hex encoded sha256 digest of the file
+func fileDigest(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", h.Sum(nil)), nil
+}
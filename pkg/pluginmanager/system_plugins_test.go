@@ -0,0 +1,44 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package pluginmanager
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
+)
+
+func TestFindSystemPluginBinary(t *testing.T) {
+	dir1 := t.TempDir()
+	dir2 := t.TempDir()
+	os.Setenv(constants.ConfigVariableSystemPluginDirs, dir1+string(os.PathListSeparator)+dir2)
+	defer os.Unsetenv(constants.ConfigVariableSystemPluginDirs)
+
+	binary := []byte("plugin binary")
+	digest := fmt.Sprintf("%x", sha256.Sum256(binary))
+	fileName := "v1.0.0_" + digest + "_global"
+
+	// Not provisioned
+	assert.Equal(t, "", findSystemPluginBinary("fake", fileName, digest))
+
+	// Provisioned in the second directory
+	assert.Nil(t, os.MkdirAll(filepath.Join(dir2, "fake"), 0755))
+	assert.Nil(t, os.WriteFile(filepath.Join(dir2, "fake", fileName), binary, 0755))
+	assert.Equal(t, filepath.Join(dir2, "fake", fileName), findSystemPluginBinary("fake", fileName, digest))
+
+	// A binary whose content does not match the digest is ignored
+	assert.Nil(t, os.MkdirAll(filepath.Join(dir1, "fake"), 0755))
+	assert.Nil(t, os.WriteFile(filepath.Join(dir1, "fake", fileName), []byte("tampered"), 0755))
+	assert.Equal(t, filepath.Join(dir2, "fake", fileName), findSystemPluginBinary("fake", fileName, digest))
+
+	// The system plugin directories can be disabled
+	os.Setenv(constants.ConfigVariableSystemPluginDirs, "")
+	assert.Equal(t, "", findSystemPluginBinary("fake", fileName, digest))
+}
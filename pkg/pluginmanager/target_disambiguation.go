@@ -0,0 +1,83 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package pluginmanager
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/component"
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/internal/tty"
+)
+
+// promptForTarget asks the user to choose the target of the plugin among the specified targets
+var promptForTarget = func(pluginName string, targets []configtypes.Target) (configtypes.Target, error) {
+	options := make([]string, len(targets))
+	for i := range targets {
+		options[i] = string(targets[i])
+	}
+
+	var choice string
+	err := component.Prompt(
+		&component.PromptConfig{
+			Message: fmt.Sprintf("Plugin '%s' exists for multiple targets. Which target do you want to use", pluginName),
+			Options: options,
+			Default: options[0],
+		},
+		&choice,
+		component.WithStdio(os.Stdin, os.Stderr, os.Stderr),
+	)
+	if err != nil {
+		return configtypes.TargetUnknown, err
+	}
+	return configtypes.StringToTarget(choice), nil
+}
+
+// resolveAmbiguousTarget is used when the plugin name matches plugins of more than one
+// target and no target was specified.  When allowed and running in an interactive terminal,
+// the user is asked to choose the target; otherwise an error listing the possible targets
+// and explaining how to use the `--target` flag is returned.
+func resolveAmbiguousTarget(pluginName string, targets []configtypes.Target, allowPrompt bool) (configtypes.Target, error) {
+	targets = uniqueSortedTargets(targets)
+	if !allowPrompt || !tty.CanPrompt() {
+		return configtypes.TargetUnknown, ambiguousTargetError(pluginName, targets)
+	}
+
+	return promptForTarget(pluginName, targets)
+}
+
+// ambiguousTargetError returns the error explaining that the plugin exists for multiple targets
+func ambiguousTargetError(pluginName string, targets []configtypes.Target) error {
+	targets = uniqueSortedTargets(targets)
+	if len(targets) == 0 {
+		return errors.Errorf(missingTargetStr, pluginName)
+	}
+
+	targetStrs := make([]string, len(targets))
+	for i := range targets {
+		targetStrs[i] = string(targets[i])
+	}
+	return errors.Errorf(missingTargetStr+". The plugin exists for the following targets: %s (e.g., `--target %s`)",
+		pluginName, strings.Join(targetStrs, ", "), targetStrs[0])
+}
+
+// uniqueSortedTargets returns the specified targets without duplicates, in a stable order
+func uniqueSortedTargets(targets []configtypes.Target) []configtypes.Target {
+	seen := make(map[configtypes.Target]bool, len(targets))
+	result := make([]configtypes.Target, 0, len(targets))
+	for _, t := range targets {
+		if !seen[t] {
+			seen[t] = true
+			result = append(result, t)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i] < result[j] })
+	return result
+}
@@ -0,0 +1,73 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package pluginmanager
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/internal/tty"
+)
+
+func TestAmbiguousTargetError(t *testing.T) {
+	assertions := assert.New(t)
+
+	err := ambiguousTargetError("cluster", []configtypes.Target{configtypes.TargetTMC, configtypes.TargetK8s, configtypes.TargetTMC})
+	assertions.Contains(err.Error(), fmt.Sprintf(missingTargetStr, "cluster"))
+	assertions.Contains(err.Error(), "The plugin exists for the following targets: kubernetes, mission-control (e.g., `--target kubernetes`)")
+
+	err = ambiguousTargetError("cluster", nil)
+	assertions.Equal(fmt.Sprintf(missingTargetStr, "cluster"), err.Error())
+}
+
+func TestResolveAmbiguousTarget(t *testing.T) {
+	assertions := assert.New(t)
+
+	origIsInteractive := tty.CanPrompt
+	origPromptForTarget := promptForTarget
+	defer func() {
+		tty.CanPrompt = origIsInteractive
+		promptForTarget = origPromptForTarget
+	}()
+
+	var promptedTargets []configtypes.Target
+	promptForTarget = func(_ string, targets []configtypes.Target) (configtypes.Target, error) {
+		promptedTargets = targets
+		return configtypes.TargetTMC, nil
+	}
+	targets := []configtypes.Target{configtypes.TargetTMC, configtypes.TargetK8s}
+
+	// Without a terminal, the user cannot be prompted
+	tty.CanPrompt = func() bool { return false }
+	target, err := resolveAmbiguousTarget("cluster", targets, true)
+	assertions.NotNil(err)
+	assertions.Contains(err.Error(), fmt.Sprintf(missingTargetStr, "cluster"))
+	assertions.Equal(configtypes.TargetUnknown, target)
+	assertions.Nil(promptedTargets)
+
+	// With a terminal but when prompting is not allowed
+	tty.CanPrompt = func() bool { return true }
+	target, err = resolveAmbiguousTarget("cluster", targets, false)
+	assertions.NotNil(err)
+	assertions.Equal(configtypes.TargetUnknown, target)
+	assertions.Nil(promptedTargets)
+
+	// With a terminal, the user chooses the target
+	target, err = resolveAmbiguousTarget("cluster", targets, true)
+	assertions.Nil(err)
+	assertions.Equal(configtypes.TargetTMC, target)
+	assertions.Equal([]configtypes.Target{configtypes.TargetK8s, configtypes.TargetTMC}, promptedTargets)
+
+	// The prompt is interrupted
+	promptForTarget = func(_ string, _ []configtypes.Target) (configtypes.Target, error) {
+		return configtypes.TargetUnknown, errors.New("interrupt")
+	}
+	_, err = resolveAmbiguousTarget("cluster", targets, true)
+	assertions.ErrorContains(err, "interrupt")
+}
@@ -0,0 +1,184 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package pluginmanager
+
+import (
+	"os"
+	"path"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/centralconfig"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/discovery"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/plugininventory"
+)
+
+// The vendor policy restricts the plugins which can be searched and installed from the plugin
+// repositories.  It is read from the central configuration, e.g.:
+//
+//	cli.core.tanzu_cli_plugin_vendor_policy:
+//	  allow:
+//	  - vmware-*
+//	  deny:
+//	  - vmware-tap/secret
+//
+// and each list can be replaced locally using the TANZU_CLI_PLUGIN_VENDOR_ALLOW_LIST and
+// TANZU_CLI_PLUGIN_VENDOR_DENY_LIST variables.  Plugins whose vendor is unknown, such as
+// plugins discovered from a local source or recommended by a context, are not restricted.
+
+// vendorPattern is a vendor-publisher/name pattern of the vendor policy
+type vendorPattern struct {
+	vendor    string
+	publisher string
+	name      string
+}
+
+// parseVendorPattern parses a vendor-publisher/name pattern, the publisher
+// and the name being "*" when omitted
+func parseVendorPattern(pattern string) vendorPattern {
+	vp := vendorPattern{publisher: "*", name: "*"}
+	vendorPublisher, name, found := strings.Cut(strings.TrimSpace(pattern), "/")
+	if found && name != "" {
+		vp.name = name
+	}
+	vendor, publisher, found := strings.Cut(vendorPublisher, "-")
+	vp.vendor = vendor
+	if found && publisher != "" {
+		vp.publisher = publisher
+	}
+	return vp
+}
+
+func matchPattern(pattern, value string) bool {
+	matched, err := path.Match(pattern, value)
+	return err == nil && matched
+}
+
+func (vp vendorPattern) matchesVendorPublisher(vendor, publisher string) bool {
+	return matchPattern(vp.vendor, vendor) && matchPattern(vp.publisher, publisher)
+}
+
+func (vp vendorPattern) matches(vendor, publisher, name string) bool {
+	return vp.matchesVendorPublisher(vendor, publisher) && matchPattern(vp.name, name)
+}
+
+// vendorPolicy is the vendor policy in effect
+type vendorPolicy struct {
+	allow []vendorPattern
+	deny  []vendorPattern
+}
+
+// getVendorPolicy returns the vendor policy of the central configuration,
+// with the lists overridden by the local environment variables
+func getVendorPolicy() *vendorPolicy {
+	centralPolicy := centralconfig.PluginVendorPolicy{}
+	centralconfig.GetObject(centralconfig.KeyPluginVendorPolicy, &centralPolicy)
+
+	return &vendorPolicy{
+		allow: getVendorPatterns(constants.PluginVendorAllowList, centralPolicy.Allow),
+		deny:  getVendorPatterns(constants.PluginVendorDenyList, centralPolicy.Deny),
+	}
+}
+
+// getVendorPatterns returns the patterns of the environment variable if it is set,
+// or else the patterns of the central configuration
+func getVendorPatterns(envVar string, centralPatterns []string) []vendorPattern {
+	patterns := centralPatterns
+	if value := strings.TrimSpace(os.Getenv(envVar)); value != "" {
+		patterns = strings.Split(value, ",")
+	}
+
+	var vendorPatterns []vendorPattern
+	for _, pattern := range patterns {
+		if strings.TrimSpace(pattern) != "" {
+			vendorPatterns = append(vendorPatterns, parseVendorPattern(pattern))
+		}
+	}
+	return vendorPatterns
+}
+
+// isPluginAllowed returns true if the plugin matches no deny pattern and,
+// if there is an allow list, matches one of its patterns
+func (policy *vendorPolicy) isPluginAllowed(vendor, publisher, name string) bool {
+	if vendor == "" {
+		return true
+	}
+	for _, vp := range policy.deny {
+		if vp.matches(vendor, publisher, name) {
+			return false
+		}
+	}
+	if len(policy.allow) == 0 {
+		return true
+	}
+	for _, vp := range policy.allow {
+		if vp.matches(vendor, publisher, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// isGroupAllowed returns true if some plugins of the vendor and publisher of the
+// plugin group are allowed. A group is only hidden if all its plugins are denied,
+// the plugins of a visible group being verified when they are installed.
+func (policy *vendorPolicy) isGroupAllowed(vendor, publisher string) bool {
+	if vendor == "" {
+		return true
+	}
+	for _, vp := range policy.deny {
+		if vp.name == "*" && vp.matchesVendorPublisher(vendor, publisher) {
+			return false
+		}
+	}
+	if len(policy.allow) == 0 {
+		return true
+	}
+	for _, vp := range policy.allow {
+		if vp.matchesVendorPublisher(vendor, publisher) {
+			return true
+		}
+	}
+	return false
+}
+
+// verifyVendorPolicy returns an error if the vendor policy does not allow installing the plugin
+func verifyVendorPolicy(p *discovery.Discovered) error {
+	if !getVendorPolicy().isPluginAllowed(p.Vendor, p.Publisher, p.Name) {
+		return errors.Errorf("plugin '%s' of '%s-%s' cannot be installed as it is not allowed by the plugin vendor policy", p.Name, p.Vendor, p.Publisher)
+	}
+	return nil
+}
+
+// filterPluginsByVendorPolicy removes the plugins which the vendor policy does not allow installing
+func filterPluginsByVendorPolicy(plugins []discovery.Discovered) []discovery.Discovered {
+	policy := getVendorPolicy()
+	var allowed []discovery.Discovered
+	for i := range plugins {
+		if policy.isPluginAllowed(plugins[i].Vendor, plugins[i].Publisher, plugins[i].Name) {
+			allowed = append(allowed, plugins[i])
+		} else {
+			log.V(6).Infof("plugin '%s' of '%s-%s' is hidden by the plugin vendor policy", plugins[i].Name, plugins[i].Vendor, plugins[i].Publisher)
+		}
+	}
+	return allowed
+}
+
+// filterGroupsByVendorPolicy removes the plugin groups whose plugins the vendor policy does not allow installing
+func filterGroupsByVendorPolicy(groups []*plugininventory.PluginGroup) []*plugininventory.PluginGroup {
+	policy := getVendorPolicy()
+	var allowed []*plugininventory.PluginGroup
+	for _, pg := range groups {
+		if policy.isGroupAllowed(pg.Vendor, pg.Publisher) {
+			allowed = append(allowed, pg)
+		} else {
+			log.V(6).Infof("plugin group '%s' is hidden by the plugin vendor policy", plugininventory.PluginGroupToID(pg))
+		}
+	}
+	return allowed
+}
@@ -0,0 +1,136 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package pluginmanager
+
+import (
+	"os/exec"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
+)
+
+func TestVendorPolicy(t *testing.T) {
+	tests := []struct {
+		test           string
+		allow          []string
+		deny           []string
+		vendor         string
+		publisher      string
+		name           string
+		expectedPlugin bool
+		expectedGroup  bool
+	}{
+		{
+			test:           "no policy",
+			vendor:         "vmware",
+			publisher:      "tkg",
+			name:           "cluster",
+			expectedPlugin: true,
+			expectedGroup:  true,
+		},
+		{
+			test:           "unknown vendor is not restricted",
+			allow:          []string{"vmware-tkg"},
+			name:           "cluster",
+			expectedPlugin: true,
+			expectedGroup:  true,
+		},
+		{
+			test:           "allowed vendor",
+			allow:          []string{"vmware"},
+			vendor:         "vmware",
+			publisher:      "tkg",
+			name:           "cluster",
+			expectedPlugin: true,
+			expectedGroup:  true,
+		},
+		{
+			test:           "vendor not in the allow list",
+			allow:          []string{"vmware-*", "acme-tools/*"},
+			vendor:         "other",
+			publisher:      "tkg",
+			name:           "cluster",
+			expectedPlugin: false,
+			expectedGroup:  false,
+		},
+		{
+			test:           "only some plugins of the publisher are allowed",
+			allow:          []string{"vmware-tkg/cluster"},
+			vendor:         "vmware",
+			publisher:      "tkg",
+			name:           "package",
+			expectedPlugin: false,
+			expectedGroup:  true,
+		},
+		{
+			test:           "denied plugin",
+			deny:           []string{"vmware-tkg/pack*"},
+			vendor:         "vmware",
+			publisher:      "tkg",
+			name:           "package",
+			expectedPlugin: false,
+			expectedGroup:  true,
+		},
+		{
+			test:           "denied publisher of an allowed vendor",
+			allow:          []string{"vmware"},
+			deny:           []string{"vmware-tap"},
+			vendor:         "vmware",
+			publisher:      "tap",
+			name:           "apps",
+			expectedPlugin: false,
+			expectedGroup:  false,
+		},
+	}
+
+	for _, spec := range tests {
+		t.Run(spec.test, func(t *testing.T) {
+			policy := &vendorPolicy{}
+			for _, p := range spec.allow {
+				policy.allow = append(policy.allow, parseVendorPattern(p))
+			}
+			for _, p := range spec.deny {
+				policy.deny = append(policy.deny, parseVendorPattern(p))
+			}
+
+			assert.Equal(t, spec.expectedPlugin, policy.isPluginAllowed(spec.vendor, spec.publisher, spec.name))
+			assert.Equal(t, spec.expectedGroup, policy.isGroupAllowed(spec.vendor, spec.publisher))
+		})
+	}
+}
+
+func TestVendorPolicyEnforcement(t *testing.T) {
+	assertions := assert.New(t)
+
+	defer setupPluginSourceForTesting()()
+	execCommand = fakeInfoExecCommand
+	defer func() { execCommand = exec.Command }()
+
+	// The plugins of the test plugin source are published by "vmware-test"
+	t.Setenv(constants.PluginVendorDenyList, "vmware-test/login")
+
+	plugins, err := DiscoverStandalonePlugins()
+	assertions.Nil(err)
+	assertions.NotEmpty(plugins)
+	for i := range plugins {
+		assertions.NotEqual("login", plugins[i].Name)
+	}
+
+	err = InstallStandalonePlugin("login", "v0.2.0", configtypes.TargetGlobal)
+	assertions.ErrorContains(err, "plugin 'login' of 'vmware-test' cannot be installed as it is not allowed by the plugin vendor policy")
+	assertions.Nil(InstallStandalonePlugin("feature", "v0.2.0", configtypes.TargetK8s))
+
+	t.Setenv(constants.PluginVendorDenyList, "")
+	t.Setenv(constants.PluginVendorAllowList, "acme")
+	plugins, err = DiscoverStandalonePlugins()
+	assertions.Nil(err)
+	assertions.Empty(plugins)
+	groups, err := DiscoverPluginGroups()
+	assertions.Nil(err)
+	assertions.Empty(groups)
+}
@@ -0,0 +1,159 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package pluginmanager
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/discovery"
+)
+
+const (
+	// PluginVerificationStatusOK indicates the plugin binary matches the digest recorded at installation
+	PluginVerificationStatusOK = "ok"
+	// PluginVerificationStatusTampered indicates the plugin binary was modified or removed after installation
+	PluginVerificationStatusTampered = "tampered"
+	// PluginVerificationStatusStale indicates the plugin binary no longer matches the one published in the plugin sources
+	PluginVerificationStatusStale = "stale"
+	// PluginVerificationStatusUnknown indicates the plugin binary cannot be verified
+	PluginVerificationStatusUnknown = "unknown"
+)
+
+// PluginVerificationResult is the result of the verification of an installed plugin binary
+type PluginVerificationResult struct {
+	Name             string `json:"name" yaml:"name"`
+	Target           string `json:"target" yaml:"target"`
+	Version          string `json:"version" yaml:"version"`
+	InstallationPath string `json:"installationPath" yaml:"installationPath"`
+	Status           string `json:"status" yaml:"status"`
+	Details          string `json:"details" yaml:"details"`
+}
+
+// VerifyInstalledPlugins re-computes the digest of the binary of each installed
+// plugin and compares it with the digest recorded when the plugin was installed.
+// If checkPluginSources is true, the digest is also compared with the digest of
+// the same plugin version published in the configured plugin sources.
+func VerifyInstalledPlugins(checkPluginSources bool) ([]PluginVerificationResult, error) {
+	return managerConfig{}.verifyInstalledPlugins(checkPluginSources, 1)
+}
+
+// verifyInstalledPlugins verifies the installed plugins, computing the digests
+// of up to the specified number of plugin binaries concurrently
+func (mc managerConfig) verifyInstalledPlugins(checkPluginSources bool, concurrency int) ([]PluginVerificationResult, error) {
+	installedPlugins, err := mc.getInstalledPlugins()
+	if err != nil {
+		return nil, err
+	}
+
+	// The plugin sources are queried sequentially as they share the cache of the plugin inventories
+	publishedDigests := make([][]string, len(installedPlugins))
+	if checkPluginSources {
+		for i := range installedPlugins {
+			publishedDigests[i], err = mc.getPublishedPluginDigests(&installedPlugins[i])
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	results := make([]PluginVerificationResult, len(installedPlugins))
+	semaphore := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i := range installedPlugins {
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func(i int) {
+			defer func() {
+				<-semaphore
+				wg.Done()
+			}()
+			results[i] = verifyPluginBinary(&installedPlugins[i], publishedDigests[i], checkPluginSources)
+		}(i)
+	}
+	wg.Wait()
+	return results, nil
+}
+
+// verifyPluginBinary verifies the binary of the installed plugin against the digest
+// recorded at installation and, if checkPublished is true, against the published digests
+func verifyPluginBinary(pd *cli.PluginInfo, publishedDigests []string, checkPublished bool) PluginVerificationResult {
+	result := PluginVerificationResult{
+		Name:             pd.Name,
+		Target:           string(pd.Target),
+		Version:          pd.Version,
+		InstallationPath: pd.InstallationPath,
+	}
+
+	digest, err := fileDigest(pd.InstallationPath)
+	if err != nil {
+		result.Status = PluginVerificationStatusTampered
+		result.Details = fmt.Sprintf("unable to read the plugin binary: %v", err)
+		return result
+	}
+
+	switch {
+	case pd.Digest == "":
+		result.Status = PluginVerificationStatusUnknown
+		result.Details = "no digest was recorded when the plugin was installed"
+		return result
+	case pd.Digest != digest:
+		result.Status = PluginVerificationStatusTampered
+		result.Details = fmt.Sprintf("the digest of the plugin binary is %q but %q was recorded at installation", digest, pd.Digest)
+		return result
+	}
+
+	if checkPublished {
+		if len(publishedDigests) == 0 {
+			result.Status = PluginVerificationStatusUnknown
+			result.Details = "the plugin version was not found in the plugin sources"
+			return result
+		}
+		if !slices.Contains(publishedDigests, digest) {
+			result.Status = PluginVerificationStatusStale
+			result.Details = fmt.Sprintf("the digest of the plugin binary is %q but the plugin sources publish %q", digest, strings.Join(publishedDigests, ", "))
+			return result
+		}
+	}
+
+	result.Status = PluginVerificationStatusOK
+	return result
+}
+
+// getPublishedPluginDigests returns the digests of the binaries of the installed
+// plugin version published in the plugin sources for the current OS
+func (mc managerConfig) getPublishedPluginDigests(pd *cli.PluginInfo) ([]string, error) {
+	discoveries, err := mc.getPluginDiscoveries()
+	if err != nil {
+		return nil, err
+	}
+	criteria := &discovery.PluginDiscoveryCriteria{
+		Name:    pd.Name,
+		Target:  pd.Target,
+		Version: pd.Version,
+		OS:      cli.GOOS,
+	}
+	availablePlugins, err := discoverSpecificPlugins(discoveries, mc.discoveryOptions(discovery.WithPluginDiscoveryCriteria(criteria))...)
+	if err != nil {
+		return nil, err
+	}
+
+	// The installed binary may be the AMD64 one if it was installed through the AMD64 fallback
+	var digests []string
+	for i := range availablePlugins {
+		for _, arch := range []string{cli.GOARCH, "amd64"} {
+			artifact, err := availablePlugins[i].Distribution.DescribeArtifact(pd.Version, cli.GOOS, arch)
+			if err == nil && artifact.Digest != "" && !slices.Contains(digests, artifact.Digest) {
+				digests = append(digests, artifact.Digest)
+			}
+		}
+	}
+	return digests, nil
+}
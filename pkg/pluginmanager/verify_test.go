@@ -0,0 +1,100 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package pluginmanager
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
+)
+
+func TestVerifyPluginBinary(t *testing.T) {
+	binary := []byte("plugin binary")
+	digest := fmt.Sprintf("%x", sha256.Sum256(binary))
+	otherDigest := fmt.Sprintf("%x", sha256.Sum256([]byte("other binary")))
+
+	dir := t.TempDir()
+	pluginPath := filepath.Join(dir, "v1.0.0_"+digest+"_global")
+	assert.Nil(t, os.WriteFile(pluginPath, binary, 0755))
+
+	pd := &cli.PluginInfo{
+		Name:             "fake",
+		Target:           configtypes.TargetGlobal,
+		Version:          "v1.0.0",
+		InstallationPath: pluginPath,
+		Digest:           digest,
+	}
+
+	tcs := []struct {
+		name             string
+		recordedDigest   string
+		installationPath string
+		publishedDigests []string
+		checkPublished   bool
+		expectedStatus   string
+	}{
+		{
+			name:           "binary matches the recorded digest",
+			recordedDigest: digest,
+			expectedStatus: PluginVerificationStatusOK,
+		},
+		{
+			name:           "binary does not match the recorded digest",
+			recordedDigest: otherDigest,
+			expectedStatus: PluginVerificationStatusTampered,
+		},
+		{
+			name:             "binary was removed",
+			recordedDigest:   digest,
+			installationPath: filepath.Join(dir, "missing"),
+			expectedStatus:   PluginVerificationStatusTampered,
+		},
+		{
+			name:           "no digest recorded",
+			expectedStatus: PluginVerificationStatusUnknown,
+		},
+		{
+			name:             "binary matches the published digest",
+			recordedDigest:   digest,
+			publishedDigests: []string{otherDigest, digest},
+			checkPublished:   true,
+			expectedStatus:   PluginVerificationStatusOK,
+		},
+		{
+			name:             "binary does not match the published digest",
+			recordedDigest:   digest,
+			publishedDigests: []string{otherDigest},
+			checkPublished:   true,
+			expectedStatus:   PluginVerificationStatusStale,
+		},
+		{
+			name:           "plugin not found in the plugin sources",
+			recordedDigest: digest,
+			checkPublished: true,
+			expectedStatus: PluginVerificationStatusUnknown,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			p := *pd
+			p.Digest = tc.recordedDigest
+			if tc.installationPath != "" {
+				p.InstallationPath = tc.installationPath
+			}
+			result := verifyPluginBinary(&p, tc.publishedDigests, tc.checkPublished)
+			assert.Equal(t, tc.expectedStatus, result.Status)
+			assert.Equal(t, "fake", result.Name)
+			assert.Equal(t, p.InstallationPath, result.InstallationPath)
+		})
+	}
+}
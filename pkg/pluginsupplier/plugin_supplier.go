@@ -15,13 +15,19 @@ import (
 
 // GetInstalledPlugins return the installed plugins
 func GetInstalledPlugins() ([]cli.PluginInfo, error) {
+	return GetInstalledPluginsFromCatalog(catalog.Store{})
+}
+
+// GetInstalledPluginsFromCatalog returns the plugins installed according to the catalog of the store
+func GetInstalledPluginsFromCatalog(store catalog.Store) ([]cli.PluginInfo, error) {
 	// Migrate context-scoped plugins as standalone plugin if required
 	// TODO(anujc): Think on how to invoke this function just once after the newer version
 	// of the CLI gets installed as we just need to do this migration once
-	catalog.MigrateContextPluginsAsStandaloneIfNeeded()
+	store.MigrateContextPluginsAsStandaloneIfNeeded()
+	store.MigratePluginInstallMetadataIfNeeded()
 
 	// Get all the standalone plugins found in the catalog
-	standAloneCatalog, err := catalog.NewContextCatalog("")
+	standAloneCatalog, err := store.NewContextCatalog("")
 	if err != nil {
 		return nil, err
 	}
@@ -81,8 +87,13 @@ func IsPluginActive(pi *cli.PluginInfo) bool {
 
 // IsPluginInstalled returns true if plugin is already installed
 func IsPluginInstalled(name string, target configtypes.Target, version string) bool {
+	return IsPluginInstalledInCatalog(catalog.Store{}, name, target, version)
+}
+
+// IsPluginInstalledInCatalog returns true if the plugin is installed according to the catalog of the store
+func IsPluginInstalledInCatalog(store catalog.Store, name string, target configtypes.Target, version string) bool {
 	// Check if the plugin is already installed, if installed skip the installation of the plugin
-	installedPlugins, err := GetInstalledPlugins()
+	installedPlugins, err := GetInstalledPluginsFromCatalog(store)
 	if err == nil {
 		for i := range installedPlugins {
 			if installedPlugins[i].Name == name &&
@@ -0,0 +1,38 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package pluginsupplier
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/common"
+)
+
+// BenchmarkGetInstalledPlugins measures the listing of the installed plugins,
+// which is done by every invocation of the CLI to build its commands
+func BenchmarkGetInstalledPlugins(b *testing.B) {
+	origCacheDir := common.DefaultCacheDir
+	common.DefaultCacheDir = b.TempDir()
+	defer func() { common.DefaultCacheDir = origCacheDir }()
+
+	for i := 0; i < 50; i++ {
+		if _, err := fakeInstallPlugin("", fmt.Sprintf("plugin%02d", i), types.TargetK8s, "v1.0.0"); err != nil {
+			b.Fatal(err)
+		}
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		plugins, err := GetInstalledPlugins()
+		if err != nil {
+			b.Fatal(err)
+		}
+		if len(plugins) != 50 {
+			b.Fatal("unexpected number of installed plugins")
+		}
+	}
+}
@@ -274,6 +274,8 @@ var _ = Describe("GetInstalledPlugins (standalone plugins)", func() {
 			os.RemoveAll(configFileNG.Name())
 		})
 
+		// The digest of the plugins installed by older CLI versions is recorded from their installation path,
+		// which only includes it along with the target
 		It("should find the installed standalone plugin along with server plugins with server plugins migrated in catalog", func() {
 			installedStandalonePlugins, err := GetInstalledPlugins()
 			Expect(err).ToNot(HaveOccurred())
@@ -285,7 +287,7 @@ var _ = Describe("GetInstalledPlugins (standalone plugins)", func() {
 					Description:                  "cluster functionality",
 					Version:                      "v0.0.1",
 					BuildSHA:                     "01234567",
-					Digest:                       "",
+					Digest:                       "2ddee7c0a8ecbef610a651bc8d83657fd3438f1038e817b4a7d44f2d0b3bac72",
 					Group:                        plugin.SystemCmdGroup,
 					DocURL:                       "",
 					Hidden:                       false,
@@ -311,7 +313,7 @@ var _ = Describe("GetInstalledPlugins (standalone plugins)", func() {
 					Description:                  "IAM Policies for tmc resources",
 					Version:                      "v0.0.1",
 					BuildSHA:                     "01234567",
-					Digest:                       "",
+					Digest:                       "2de17ef20dfb00dd8bcf5cb61cbce3cbddcd0a71fba858817343188c093cef7c",
 					Group:                        plugin.ManageCmdGroup,
 					DocURL:                       "",
 					Hidden:                       false,
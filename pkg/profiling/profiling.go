@@ -0,0 +1,174 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package profiling measures where the time of a CLI command is spent, to help
+// diagnose slow commands.  It is only active when enabled, e.g. by the --profile flag.
+package profiling
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/pprof"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// The phases of a command which are measured
+const (
+	PhaseConfigLoad = "config load"
+	PhaseCatalog    = "catalog"
+	PhaseDiscovery  = "discovery"
+	PhasePluginExec = "plugin exec"
+)
+
+// CPUProfileFileName and HeapProfileFileName are the names of the pprof
+// profiles written in the profile directory
+const (
+	CPUProfileFileName  = "cpu.pprof"
+	HeapProfileFileName = "heap.pprof"
+)
+
+type phaseTiming struct {
+	name     string
+	duration time.Duration
+	calls    int
+}
+
+var (
+	lock       sync.Mutex
+	enabled    bool
+	start      time.Time
+	profileDir string
+	cpuProfile *os.File
+	// phases keeps the phases in the order in which they were first measured
+	phases []*phaseTiming
+)
+
+// Enable starts measuring the time spent in the phases of the command.
+// If dir is not empty, a CPU profile is also recorded in that directory until
+// Stop is called.
+func Enable(dir string) error {
+	lock.Lock()
+	defer lock.Unlock()
+
+	enabled = true
+	start = time.Now()
+	phases = nil
+	if dir == "" {
+		return nil
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return errors.Wrapf(err, "unable to create the profile directory %q", dir)
+	}
+	f, err := os.Create(filepath.Join(dir, CPUProfileFileName))
+	if err != nil {
+		return errors.Wrap(err, "unable to create the CPU profile")
+	}
+	if err := pprof.StartCPUProfile(f); err != nil {
+		f.Close()
+		return errors.Wrap(err, "unable to start the CPU profile")
+	}
+	profileDir = dir
+	cpuProfile = f
+	return nil
+}
+
+// Enabled returns true if the command is being profiled
+func Enabled() bool {
+	lock.Lock()
+	defer lock.Unlock()
+	return enabled
+}
+
+// Track starts measuring the specified phase and returns the function
+// which ends the measurement, e.g.:
+//
+//	defer profiling.Track(profiling.PhaseCatalog)()
+func Track(phase string) func() {
+	if !Enabled() {
+		return func() {}
+	}
+	phaseStart := time.Now()
+	return func() {
+		record(phase, time.Since(phaseStart))
+	}
+}
+
+func record(phase string, duration time.Duration) {
+	lock.Lock()
+	defer lock.Unlock()
+
+	for _, p := range phases {
+		if p.name == phase {
+			p.duration += duration
+			p.calls++
+			return
+		}
+	}
+	phases = append(phases, &phaseTiming{name: phase, duration: duration, calls: 1})
+}
+
+// Stop ends the profiling, writes the pprof profiles if requested and
+// reports where the time of the command was spent
+func Stop(w io.Writer) error {
+	lock.Lock()
+	defer lock.Unlock()
+
+	if !enabled {
+		return nil
+	}
+	enabled = false
+	total := time.Since(start)
+
+	fmt.Fprintf(w, "\nProfile of the command (total %s):\n", total.Round(time.Millisecond))
+	for _, p := range phases {
+		calls := ""
+		if p.calls > 1 {
+			calls = fmt.Sprintf(" (%d calls)", p.calls)
+		}
+		fmt.Fprintf(w, "  %-12s %10s %5.1f%%%s\n", p.name, p.duration.Round(time.Microsecond), percentage(p.duration, total), calls)
+	}
+
+	if cpuProfile == nil {
+		return nil
+	}
+	pprof.StopCPUProfile()
+	err := cpuProfile.Close()
+	cpuProfile = nil
+	if err != nil {
+		return errors.Wrap(err, "unable to write the CPU profile")
+	}
+	if err := writeHeapProfile(filepath.Join(profileDir, HeapProfileFileName)); err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "The CPU and heap profiles were written to %q\n", profileDir)
+	return nil
+}
+
+func writeHeapProfile(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return errors.Wrap(err, "unable to create the heap profile")
+	}
+	defer f.Close()
+
+	// Get up-to-date statistics
+	runtime.GC()
+	if err := pprof.WriteHeapProfile(f); err != nil {
+		return errors.Wrap(err, "unable to write the heap profile")
+	}
+	return nil
+}
+
+func percentage(d, total time.Duration) float64 {
+	if total <= 0 {
+		return 0
+	}
+	return float64(d) * 100 / float64(total)
+}
@@ -0,0 +1,63 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package profiling
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrackWhenDisabled(t *testing.T) {
+	assert := assert.New(t)
+
+	Track(PhaseCatalog)()
+	assert.False(Enabled())
+
+	var out bytes.Buffer
+	assert.Nil(Stop(&out))
+	assert.Empty(out.String())
+}
+
+func TestProfiling(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Nil(Enable(""))
+	assert.True(Enabled())
+
+	stop := Track(PhaseConfigLoad)
+	time.Sleep(2 * time.Millisecond)
+	stop()
+	Track(PhaseCatalog)()
+	Track(PhaseCatalog)()
+
+	var out bytes.Buffer
+	assert.Nil(Stop(&out))
+	assert.False(Enabled())
+
+	assert.Contains(out.String(), "Profile of the command (total ")
+	assert.Regexp(`(?m)^  config load +\d`, out.String())
+	assert.Regexp(`(?m)^  catalog .*\(2 calls\)$`, out.String())
+	assert.NotContains(out.String(), PhaseDiscovery)
+	assert.NotContains(out.String(), "profiles were written")
+}
+
+func TestProfilingWithPprof(t *testing.T) {
+	assert := assert.New(t)
+
+	dir := filepath.Join(t.TempDir(), "profile")
+	assert.Nil(Enable(dir))
+	Track(PhasePluginExec)()
+
+	var out bytes.Buffer
+	assert.Nil(Stop(&out))
+
+	assert.Contains(out.String(), "plugin exec")
+	assert.Contains(out.String(), "The CPU and heap profiles were written to")
+	assert.FileExists(filepath.Join(dir, CPUProfileFileName))
+	assert.FileExists(filepath.Join(dir, HeapProfileFileName))
+}
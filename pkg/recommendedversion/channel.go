@@ -0,0 +1,71 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package recommendedversion
+
+import (
+	"os"
+	"strings"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
+)
+
+// Release channels of the Tanzu CLI
+const (
+	// ChannelStable is the default channel and only contains GA releases
+	ChannelStable = "stable"
+	// ChannelBeta contains the beta and release candidate builds
+	ChannelBeta = "beta"
+	// ChannelNightly contains the nightly builds
+	ChannelNightly = "nightly"
+)
+
+// SupportedChannels is the list of release channels a user can select,
+// from the most stable to the least stable
+var SupportedChannels = []string{ChannelStable, ChannelBeta, ChannelNightly}
+
+// channelRank returns the position of the channel in the list of supported channels,
+// or -1 if the channel is not supported.  A channel includes the versions of every
+// channel of lower or equal rank.
+func channelRank(channel string) int {
+	for i, c := range SupportedChannels {
+		if c == channel {
+			return i
+		}
+	}
+	return -1
+}
+
+// IsSupportedChannel returns true if the specified channel is one of the supported release channels
+func IsSupportedChannel(channel string) bool {
+	return channelRank(channel) >= 0
+}
+
+// GetReleaseChannel returns the release channel selected by the user.
+// The stable channel is returned if no channel, or an unsupported channel, is configured.
+func GetReleaseChannel() string {
+	channel := strings.ToLower(strings.TrimSpace(os.Getenv(constants.ConfigVariableReleaseChannel)))
+	if !IsSupportedChannel(channel) {
+		return ChannelStable
+	}
+	return channel
+}
+
+// filterVersionsForChannel returns the versions that are available on the specified channel.
+// Channels are inclusive: the nightly channel includes the beta versions, which itself
+// includes the stable versions.  Versions that do not specify a channel are considered
+// part of the stable channel, and versions of an unsupported channel are ignored.
+func filterVersionsForChannel(versions []RecommendedVersion, channel string) []string {
+	rank := channelRank(channel)
+	var result []string
+	for _, rv := range versions {
+		versionChannel := strings.ToLower(strings.TrimSpace(rv.Channel))
+		if versionChannel == "" {
+			versionChannel = ChannelStable
+		}
+		if versionRank := channelRank(versionChannel); versionRank >= 0 && versionRank <= rank {
+			result = append(result, rv.Version)
+		}
+	}
+	return result
+}
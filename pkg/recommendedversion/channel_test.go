@@ -0,0 +1,50 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package recommendedversion
+
+import (
+	"os"
+	"testing"
+
+	"github.com/tj/assert"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
+)
+
+func TestGetReleaseChannel(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		expected string
+	}{
+		{name: "Not set", value: "", expected: ChannelStable},
+		{name: "Stable", value: "stable", expected: ChannelStable},
+		{name: "Beta", value: "beta", expected: ChannelBeta},
+		{name: "Nightly with spaces and uppercase", value: " Nightly ", expected: ChannelNightly},
+		{name: "Unsupported", value: "weekly", expected: ChannelStable},
+	}
+
+	defer os.Unsetenv(constants.ConfigVariableReleaseChannel)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			os.Setenv(constants.ConfigVariableReleaseChannel, tt.value)
+			assert.Equal(t, tt.expected, GetReleaseChannel())
+		})
+	}
+}
+
+func TestFilterVersionsForChannel(t *testing.T) {
+	versions := []RecommendedVersion{
+		{Version: "v1.5.0-beta.0", Channel: "beta"},
+		{Version: "v1.5.0-dev.20241001", Channel: "nightly"},
+		{Version: "v1.4.4", Channel: "stable"},
+		{Version: "v1.3.3"},
+		{Version: "v1.6.0-alpha.0", Channel: "weekly"},
+	}
+
+	// Each channel also includes the versions of the more stable channels
+	assert.Equal(t, []string{"v1.4.4", "v1.3.3"}, filterVersionsForChannel(versions, ChannelStable))
+	assert.Equal(t, []string{"v1.5.0-beta.0", "v1.4.4", "v1.3.3"}, filterVersionsForChannel(versions, ChannelBeta))
+	assert.Equal(t, []string{"v1.5.0-beta.0", "v1.5.0-dev.20241001", "v1.4.4", "v1.3.3"}, filterVersionsForChannel(versions, ChannelNightly))
+}
@@ -0,0 +1,99 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package recommendedversion
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/datastore"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/discovery"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/pluginmanager"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/pluginsupplier"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/utils"
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
+)
+
+const (
+	// dataStoreLastPluginUpdateCheckKey is the data store key used to store the last
+	// time the plugin update check was done
+	dataStoreLastPluginUpdateCheckKey = "lastPluginUpdateCheck"
+	pluginUpdateCheckDelaySeconds     = 24 * 60 * 60 // 24 hours
+)
+
+// CheckPluginUpdates compares the versions of the installed plugins with the versions
+// recommended by the plugin inventory and prints a notice to the user if some plugins
+// can be updated.  The inventory is read from the cache only, so this check does not
+// trigger any network access.
+// Once the check is done, whether or not a notice is printed, the next check is only
+// done after 24 hours.
+func CheckPluginUpdates(cmd *cobra.Command) {
+	if isRecommendationSuppressed() || !shouldCheckPluginUpdates() {
+		return
+	}
+
+	// Save the time of the check whatever its outcome so that the installed plugins
+	// and the inventory cache are not read again at every command
+	defer func() { _ = datastore.SetDataStoreValue(dataStoreLastPluginUpdateCheckKey, time.Now()) }()
+
+	installedPlugins, err := pluginsupplier.GetInstalledPlugins()
+	if err != nil {
+		log.V(7).Error(err, "error reading the installed plugins")
+		return
+	}
+
+	availablePlugins, err := pluginmanager.DiscoverStandalonePlugins(discovery.WithUseLocalCacheOnly())
+	if err != nil {
+		log.V(7).Error(err, "error reading the plugin inventory cache")
+		return
+	}
+
+	printPluginUpdatesNotice(cmd.ErrOrStderr(), countPluginsWithUpdates(installedPlugins, availablePlugins))
+}
+
+// countPluginsWithUpdates returns the number of installed plugins for which
+// the recommended version found in the inventory is newer than the installed version
+func countPluginsWithUpdates(installedPlugins []cli.PluginInfo, availablePlugins []discovery.Discovered) int {
+	count := 0
+	for i := range installedPlugins {
+		for j := range availablePlugins {
+			if installedPlugins[i].Name == availablePlugins[j].Name &&
+				installedPlugins[i].Target == availablePlugins[j].Target {
+				if availablePlugins[j].RecommendedVersion != "" &&
+					utils.IsNewVersion(availablePlugins[j].RecommendedVersion, installedPlugins[i].Version) {
+					count++
+				}
+				break
+			}
+		}
+	}
+	return count
+}
+
+func getPluginUpdateCheckDelayInSeconds() int {
+	return getDelayInSeconds(constants.ConfigVariablePluginUpdateCheckDelayDays, pluginUpdateCheckDelaySeconds)
+}
+
+func shouldCheckPluginUpdates() bool {
+	return isCheckDue(getPluginUpdateCheckDelayInSeconds(), dataStoreLastPluginUpdateCheckKey)
+}
+
+func printPluginUpdatesNotice(writer io.Writer, count int) {
+	if count == 0 {
+		return
+	}
+
+	plural := "s"
+	if count == 1 {
+		plural = ""
+	}
+	fmt.Fprintf(writer, "\nNote: Updates are available for %d installed plugin%s, use 'tanzu plugin upgrade PLUGIN_NAME' to update "+
+		"(printed at most once per %s, set %s to adjust).\n",
+		count, plural, formatDelay(getPluginUpdateCheckDelayInSeconds()), constants.ConfigVariablePluginUpdateCheckDelayDays)
+}
@@ -0,0 +1,104 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package recommendedversion
+
+import (
+	"bytes"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/tj/assert"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/datastore"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/discovery"
+)
+
+func TestCountPluginsWithUpdates(t *testing.T) {
+	installed := []cli.PluginInfo{
+		{Name: "cluster", Target: configtypes.TargetK8s, Version: "v1.0.0"},
+		{Name: "cluster", Target: configtypes.TargetTMC, Version: "v1.0.0"},
+		{Name: "package", Target: configtypes.TargetK8s, Version: "v2.0.0"},
+		{Name: "local-only", Target: configtypes.TargetGlobal, Version: "v0.1.0"},
+		{Name: "newer", Target: configtypes.TargetGlobal, Version: "v3.1.0"},
+	}
+	available := []discovery.Discovered{
+		{Name: "cluster", Target: configtypes.TargetK8s, RecommendedVersion: "v1.1.0"},
+		{Name: "cluster", Target: configtypes.TargetTMC, RecommendedVersion: "v1.0.1"},
+		{Name: "package", Target: configtypes.TargetK8s, RecommendedVersion: "v2.0.0"},
+		{Name: "newer", Target: configtypes.TargetGlobal, RecommendedVersion: "v3.0.0"},
+	}
+
+	assert.Equal(t, 2, countPluginsWithUpdates(installed, available))
+	assert.Equal(t, 0, countPluginsWithUpdates(installed, nil))
+	assert.Equal(t, 0, countPluginsWithUpdates(nil, available))
+}
+
+func TestPrintPluginUpdatesNotice(t *testing.T) {
+	tmpDataStoreFile, _ := os.CreateTemp("", "data-store.yaml")
+	defer os.RemoveAll(tmpDataStoreFile.Name())
+	os.Setenv("TEST_CUSTOM_DATA_STORE_FILE", tmpDataStoreFile.Name())
+	defer os.Unsetenv("TEST_CUSTOM_DATA_STORE_FILE")
+
+	// No notice when there are no updates
+	var buf bytes.Buffer
+	printPluginUpdatesNotice(&buf, 0)
+	assert.Empty(t, buf.String())
+
+	printPluginUpdatesNotice(&buf, 1)
+	assert.Contains(t, buf.String(), "Updates are available for 1 installed plugin,")
+
+	buf.Reset()
+	printPluginUpdatesNotice(&buf, 3)
+	assert.Contains(t, buf.String(), "Updates are available for 3 installed plugins,")
+	assert.Contains(t, buf.String(), constants.ConfigVariablePluginUpdateCheckDelayDays)
+}
+
+func TestCheckPluginUpdatesRecordsEveryCheck(t *testing.T) {
+	tmpDataStoreFile, _ := os.CreateTemp("", "data-store.yaml")
+	defer os.RemoveAll(tmpDataStoreFile.Name())
+	os.Setenv("TEST_CUSTOM_DATA_STORE_FILE", tmpDataStoreFile.Name())
+	defer os.Unsetenv("TEST_CUSTOM_DATA_STORE_FILE")
+
+	// No plugin is installed and no discovery source is configured
+	os.Setenv("TEST_CUSTOM_CATALOG_CACHE_DIR", t.TempDir())
+	defer os.Unsetenv("TEST_CUSTOM_CATALOG_CACHE_DIR")
+	configFile, _ := os.CreateTemp("", "config")
+	defer os.RemoveAll(configFile.Name())
+	os.Setenv("TANZU_CONFIG", configFile.Name())
+	defer os.Unsetenv("TANZU_CONFIG")
+	configFileNG, _ := os.CreateTemp("", "config_ng")
+	defer os.RemoveAll(configFileNG.Name())
+	os.Setenv("TANZU_CONFIG_NEXT_GEN", configFileNG.Name())
+	defer os.Unsetenv("TANZU_CONFIG_NEXT_GEN")
+
+	os.Setenv(constants.ConfigVariableSuppressVersionRecommendation, "false")
+	defer os.Unsetenv(constants.ConfigVariableSuppressVersionRecommendation)
+
+	assert.True(t, shouldCheckPluginUpdates())
+
+	// The time of the check is saved even if no plugin can be updated
+	var buf bytes.Buffer
+	cmd := &cobra.Command{}
+	cmd.SetErr(&buf)
+	CheckPluginUpdates(cmd)
+	assert.Empty(t, buf.String())
+
+	var timestamp time.Time
+	err := datastore.GetDataStoreValue(dataStoreLastPluginUpdateCheckKey, &timestamp)
+	assert.Nil(t, err)
+	assert.WithinDuration(t, time.Now(), timestamp, 1*time.Second)
+	assert.False(t, shouldCheckPluginUpdates())
+
+	// Disabling the check
+	os.Setenv(constants.ConfigVariablePluginUpdateCheckDelayDays, "0")
+	defer os.Unsetenv(constants.ConfigVariablePluginUpdateCheckDelayDays)
+	_ = datastore.DeleteDataStoreValue(dataStoreLastPluginUpdateCheckKey)
+	assert.False(t, shouldCheckPluginUpdates())
+}
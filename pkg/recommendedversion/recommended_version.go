@@ -3,7 +3,8 @@
 
 // Package recommendedversion is used to check for
 // the currently recommended versions of the Tanzu CLI
-// and inform the user if they are using an outdated version.
+// and of its installed plugins and inform the user if
+// they are using an outdated version.
 package recommendedversion
 
 import (
@@ -14,12 +15,14 @@ import (
 	"strings"
 	"time"
 
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 
 	"github.com/vmware-tanzu/tanzu-cli/pkg/buildinfo"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/centralconfig"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/datastore"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/selfupdate"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/utils"
 	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
 )
@@ -30,6 +33,9 @@ import (
 // in the central configuration and read back.
 type RecommendedVersion struct {
 	Version string `yaml:"version" json:"version"`
+	// Channel is the release channel the version is published on.
+	// An empty channel means the version is part of the stable channel.
+	Channel string `yaml:"channel,omitempty" json:"channel,omitempty"`
 }
 
 // dataStoreLastVersionCheckKey is the data store key used to store the last
@@ -40,35 +46,33 @@ const (
 	recommendedVersionCheckDelaySeconds = 24 * 60 * 60 // 24 hours
 )
 
+// detectInstallMethod returns the method that was used to install the running CLI.
+// It is a variable so that it can be overridden for unit tests.
+var detectInstallMethod = func() selfupdate.InstallMethod {
+	executable, err := os.Executable()
+	if err != nil {
+		return selfupdate.InstallMethodDirect
+	}
+	return selfupdate.DetectInstallMethod(executable)
+}
+
 // CheckRecommendedCLIVersion checks the recommended versions of the Tanzu CLI
 // and prints recommendations to the user if they are using an outdated version.
 // Once recommendations are printed to the user, the next check is only done after 24 hours.
+// No recommendation is printed when the CLI is not used interactively.
 func CheckRecommendedCLIVersion(cmd *cobra.Command) {
-	if !shouldCheckVersion() {
+	if isRecommendationSuppressed() || !shouldCheckVersion() {
 		return
 	}
 
-	// Get the recommended versions from the default central configuration
-	var versionStruct []RecommendedVersion
-	err := centralconfig.DefaultCentralConfigReader.GetCentralConfigEntry(centralConfigRecommendedVersionsKey, &versionStruct)
+	recommendedVersions, err := getRecommendedVersions()
 	if err != nil {
 		log.V(7).Error(err, "error reading recommended versions from central config")
 		return
 	}
 
-	// Convert to a string array for easier processing since there is nothing else in the struct
-	var recommendedVersions []string
-	for _, rv := range versionStruct {
-		recommendedVersions = append(recommendedVersions, rv.Version)
-	}
-	recommendedVersions, err = sortRecommendedVersionsDescending(recommendedVersions)
-	if err != nil {
-		log.V(7).Error(err, "failed to sort recommended versions")
-		return
-	}
-
 	currentVersion := buildinfo.Version
-	includePreReleases := utils.IsPreRelease(currentVersion)
+	includePreReleases := shouldIncludePreReleases(currentVersion)
 	major := findRecommendedMajorVersion(recommendedVersions, currentVersion, includePreReleases)
 	minor := findRecommendedMinorVersion(recommendedVersions, currentVersion, includePreReleases)
 	patch := findRecommendedPatchVersion(recommendedVersions, currentVersion, includePreReleases)
@@ -76,6 +80,46 @@ func CheckRecommendedCLIVersion(cmd *cobra.Command) {
 	printVersionRecommendations(cmd.ErrOrStderr(), currentVersion, major, minor, patch)
 }
 
+// GetRecommendedCLIVersion returns the most recent recommended version of the
+// Tanzu CLI that has the same major version as the currently running CLI.
+// If the current version is already the most recent recommended version,
+// an empty string is returned.
+func GetRecommendedCLIVersion() (string, error) {
+	recommendedVersions, err := getRecommendedVersions()
+	if err != nil {
+		return "", err
+	}
+
+	currentVersion := buildinfo.Version
+	includePreReleases := shouldIncludePreReleases(currentVersion)
+
+	// The minor recommendation, when present, is always more recent than the patch recommendation
+	if minor := findRecommendedMinorVersion(recommendedVersions, currentVersion, includePreReleases); minor != "" {
+		return minor, nil
+	}
+	return findRecommendedPatchVersion(recommendedVersions, currentVersion, includePreReleases), nil
+}
+
+// getRecommendedVersions reads the recommended versions of the selected release channel
+// from the default central configuration and returns them sorted in descending order of semver
+func getRecommendedVersions() ([]string, error) {
+	var versionStruct []RecommendedVersion
+	if !centralconfig.GetObject(centralConfigRecommendedVersionsKey, &versionStruct) {
+		return nil, errors.New("no recommended versions found in the central configuration")
+	}
+
+	// Only keep the versions of the channel selected by the user
+	recommendedVersions := filterVersionsForChannel(versionStruct, GetReleaseChannel())
+	return sortRecommendedVersionsDescending(recommendedVersions)
+}
+
+// shouldIncludePreReleases returns true if pre-release versions can be recommended.
+// This is the case when the current version is itself a pre-release or when the
+// user has selected a channel other than the stable one.
+func shouldIncludePreReleases(currentVersion string) bool {
+	return utils.IsPreRelease(currentVersion) || GetReleaseChannel() != ChannelStable
+}
+
 // findRecommendedMajorVersion will return the recommended major version from the list of
 // recommended versions. If the current version is already at the most recent major version,
 // it will return an empty string.
@@ -190,8 +234,14 @@ func sortRecommendedVersionsDescending(recommendedVersions []string) ([]string,
 }
 
 func getRecommendationDelayInSeconds() int {
-	delay := recommendedVersionCheckDelaySeconds
-	delayOverride := os.Getenv(constants.ConfigVariableRecommendVersionDelayDays)
+	return getDelayInSeconds(constants.ConfigVariableRecommendVersionDelayDays, recommendedVersionCheckDelaySeconds)
+}
+
+// getDelayInSeconds returns the delay configured in days through the specified
+// variable, converted to seconds, or the default delay if the variable is not set.
+func getDelayInSeconds(delayVariable string, defaultDelay int) int {
+	delay := defaultDelay
+	delayOverride := os.Getenv(delayVariable)
 	if delayOverride != "" {
 		delayOverrideValue, err := strconv.Atoi(delayOverride)
 		if err == nil {
@@ -209,15 +259,20 @@ func getRecommendationDelayInSeconds() int {
 }
 
 func shouldCheckVersion() bool {
-	delay := getRecommendationDelayInSeconds()
+	return isCheckDue(getRecommendationDelayInSeconds(), dataStoreLastVersionCheckKey)
+}
+
+// isCheckDue returns true if more than the specified delay has passed since the
+// time stored in the data store under the specified key.
+func isCheckDue(delay int, lastCheckKey string) bool {
 	if delay == 0 {
-		// The user has disabled the version check
+		// The user has disabled the check
 		return false
 	}
 
-	// Get the last time the version check was done
+	// Get the last time the check was done
 	var lastCheck time.Time
-	err := datastore.GetDataStoreValue(dataStoreLastVersionCheckKey, &lastCheck)
+	err := datastore.GetDataStoreValue(lastCheckKey, &lastCheck)
 	if err != nil {
 		return true
 	}
@@ -225,6 +280,15 @@ func shouldCheckVersion() bool {
 	return time.Since(lastCheck) > time.Duration(delay)*time.Second
 }
 
+// formatDelay returns a human readable representation of a delay in seconds
+func formatDelay(delay int) string {
+	if delay >= 60*60 {
+		// If the delay is more than an hour, show the delay in hours
+		return fmt.Sprintf("%d hours", delay/60/60)
+	}
+	return fmt.Sprintf("%d seconds", delay)
+}
+
 func printVersionRecommendations(writer io.Writer, currentVersion, _, minor, patch string) {
 	// Only print the message for minor and patch versions.
 	// We don't print anything for major versions because they are breaking changes
@@ -254,16 +318,9 @@ func printVersionRecommendations(writer io.Writer, currentVersion, _, minor, pat
 		}
 	}
 
-	fmt.Fprintf(writer, "\nPlease refer to these instructions for upgrading: https://github.com/vmware-tanzu/tanzu-cli/blob/main/docs/quickstart/install.md.\n")
+	fmt.Fprintf(writer, "\nTo upgrade, %s\n", detectInstallMethod().UpgradeInstructions())
 
-	delay := getRecommendationDelayInSeconds()
-	var delayStr string
-	if delay >= 60*60 {
-		// If the delay is more than an hour, show the delay in hours
-		delayStr = fmt.Sprintf("%d hours", delay/60/60)
-	} else {
-		delayStr = fmt.Sprintf("%d seconds", delay)
-	}
+	delayStr := formatDelay(getRecommendationDelayInSeconds())
 	fmt.Fprintf(writer, "\nThis message will print at most once per %s until you update the CLI.\n"+
 		"Set %s to adjust this period (0 to disable).\n",
 		delayStr, constants.ConfigVariableRecommendVersionDelayDays)
@@ -12,8 +12,12 @@ import (
 
 	"github.com/tj/assert"
 
+	"github.com/vmware-tanzu/tanzu-cli/pkg/buildinfo"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/centralconfig"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/centralconfig/fakes"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/datastore"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/selfupdate"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/utils"
 )
 
@@ -498,6 +502,8 @@ func TestPrintVersionRecommendations(t *testing.T) {
 				}
 				// Check that the variable to override is mentioned
 				assert.Contains(buf.String(), constants.ConfigVariableRecommendVersionDelayDays)
+				// Check that the upgrade command is provided
+				assert.Contains(buf.String(), "To upgrade, "+detectInstallMethod().UpgradeInstructions())
 			}
 
 			// Check that the timestamp is updated
@@ -513,6 +519,45 @@ func TestPrintVersionRecommendations(t *testing.T) {
 	}
 }
 
+func TestPrintVersionRecommendationsUpgradeCommand(t *testing.T) {
+	tmpDataStoreFile, _ := os.CreateTemp("", "data-store.yaml")
+	defer os.RemoveAll(tmpDataStoreFile.Name())
+	os.Setenv("TEST_CUSTOM_DATA_STORE_FILE", tmpDataStoreFile.Name())
+	defer os.Unsetenv("TEST_CUSTOM_DATA_STORE_FILE")
+
+	originalDetectInstallMethod := detectInstallMethod
+	defer func() { detectInstallMethod = originalDetectInstallMethod }()
+
+	for _, method := range []selfupdate.InstallMethod{
+		selfupdate.InstallMethodDirect,
+		selfupdate.InstallMethodHomebrew,
+		selfupdate.InstallMethodChocolatey,
+		selfupdate.InstallMethodApt,
+		selfupdate.InstallMethodYum,
+	} {
+		t.Run(string(method), func(t *testing.T) {
+			detectInstallMethod = func() selfupdate.InstallMethod { return method }
+
+			var buf bytes.Buffer
+			printVersionRecommendations(&buf, "v1.3.0", "", "v1.4.3", "v1.3.3")
+			assert.Contains(t, buf.String(), "To upgrade, "+method.UpgradeInstructions())
+		})
+	}
+
+	// Without the location of the binaries, "tanzu update" cannot be suggested
+	originalReader := centralconfig.DefaultCentralConfigReader
+	defer func() { centralconfig.DefaultCentralConfigReader = originalReader }()
+	fakeCentralConfig := &fakes.CentralConfig{}
+	fakeCentralConfig.GetCentralConfigEntryReturns(&centralconfig.KeyNotFoundError{Key: "cli.core.cli_binary_location"})
+	centralconfig.DefaultCentralConfigReader = fakeCentralConfig
+	detectInstallMethod = func() selfupdate.InstallMethod { return selfupdate.InstallMethodDirect }
+
+	var buf bytes.Buffer
+	printVersionRecommendations(&buf, "v1.3.0", "", "v1.4.3", "v1.3.3")
+	assert.Contains(t, buf.String(), "To upgrade, follow the download instructions at https://")
+	assert.NotContains(t, buf.String(), "tanzu update")
+}
+
 func arraysAreEqual(a []string, b []string) bool {
 	if len(a) != len(b) {
 		return false
@@ -524,3 +569,84 @@ func arraysAreEqual(a []string, b []string) bool {
 	}
 	return true
 }
+
+func TestGetRecommendedCLIVersion(t *testing.T) {
+	tests := []struct {
+		name        string
+		recommended []RecommendedVersion
+		current     string
+		channel     string
+		expected    string
+	}{
+		{
+			name:        "Newer minor and patch",
+			recommended: []RecommendedVersion{{Version: "v2.0.2"}, {Version: "v1.4.4"}, {Version: "v1.3.3"}},
+			current:     "v1.3.0",
+			expected:    "v1.4.4",
+		},
+		{
+			name:        "Newer patch only",
+			recommended: []RecommendedVersion{{Version: "v2.0.2"}, {Version: "v1.4.4"}, {Version: "v1.3.3"}},
+			current:     "v1.4.1",
+			expected:    "v1.4.4",
+		},
+		{
+			name:        "Already at recommended version",
+			recommended: []RecommendedVersion{{Version: "v2.0.2"}, {Version: "v1.4.4"}, {Version: "v1.3.3"}},
+			current:     "v1.4.4",
+			expected:    "",
+		},
+		{
+			name:        "Beta channel",
+			recommended: []RecommendedVersion{{Version: "v1.5.0-beta.0", Channel: "beta"}, {Version: "v1.4.4"}, {Version: "v1.3.3"}},
+			current:     "v1.4.4",
+			channel:     "beta",
+			expected:    "v1.5.0-beta.0",
+		},
+		{
+			name:        "Beta versions are not recommended on the stable channel",
+			recommended: []RecommendedVersion{{Version: "v1.5.0-beta.0", Channel: "beta"}, {Version: "v1.4.4"}, {Version: "v1.3.3"}},
+			current:     "v1.4.0",
+			expected:    "v1.4.4",
+		},
+		{
+			name:        "Nightly channel",
+			recommended: []RecommendedVersion{{Version: "v1.5.0-dev.20241001", Channel: "nightly"}, {Version: "v1.4.4"}, {Version: "v1.3.3"}},
+			current:     "v1.3.0",
+			channel:     "nightly",
+			expected:    "v1.5.0-dev.20241001",
+		},
+		{
+			name:        "Stable patch is recommended on the beta channel",
+			recommended: []RecommendedVersion{{Version: "v1.4.4"}, {Version: "v1.3.3"}},
+			current:     "v1.4.1",
+			channel:     "beta",
+			expected:    "v1.4.4",
+		},
+	}
+
+	originalReader := centralconfig.DefaultCentralConfigReader
+	originalVersion := buildinfo.Version
+	defer func() {
+		centralconfig.DefaultCentralConfigReader = originalReader
+		buildinfo.Version = originalVersion
+	}()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeCentralConfig := &fakes.CentralConfig{}
+			fakeCentralConfig.GetCentralConfigEntryStub = func(key string, out interface{}) error {
+				*(out.(*[]RecommendedVersion)) = tt.recommended
+				return nil
+			}
+			centralconfig.DefaultCentralConfigReader = fakeCentralConfig
+			buildinfo.Version = tt.current
+			os.Setenv(constants.ConfigVariableReleaseChannel, tt.channel)
+			defer os.Unsetenv(constants.ConfigVariableReleaseChannel)
+
+			got, err := GetRecommendedCLIVersion()
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
@@ -0,0 +1,51 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package recommendedversion
+
+import (
+	"os"
+	"strconv"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/internal/tty"
+)
+
+// ciEnvVariables are environment variables set by common CI systems.
+// The presence of any of them indicates the CLI is not being used interactively.
+var ciEnvVariables = []string{
+	"CI",
+	"BUILD_NUMBER",
+	"BUILDKITE",
+	"CIRCLECI",
+	"GITHUB_ACTIONS",
+	"GITLAB_CI",
+	"JENKINS_URL",
+	"TEAMCITY_VERSION",
+	"TF_BUILD",
+}
+
+// isRunningInCI returns true if one of the well-known CI environment variables is set
+func isRunningInCI() bool {
+	for _, v := range ciEnvVariables {
+		if os.Getenv(v) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// isRecommendationSuppressed returns true if the recommendation banner must not be printed.
+// The user can permanently suppress the banner by setting TANZU_CLI_SUPPRESS_VERSION_RECOMMENDATION
+// to true, or force it to be printed by setting it to false.  If the variable is not set,
+// the banner is suppressed when the CLI is not used interactively so as not to pollute
+// the output of scripts.
+func isRecommendationSuppressed() bool {
+	if value := os.Getenv(constants.ConfigVariableSuppressVersionRecommendation); value != "" {
+		suppress, err := strconv.ParseBool(value)
+		if err == nil {
+			return suppress
+		}
+	}
+	return isRunningInCI() || !tty.IsOutputTerminal()
+}
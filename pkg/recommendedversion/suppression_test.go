@@ -0,0 +1,57 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package recommendedversion
+
+import (
+	"os"
+	"testing"
+
+	"github.com/tj/assert"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/internal/tty"
+)
+
+func TestIsRecommendationSuppressed(t *testing.T) {
+	tests := []struct {
+		name        string
+		interactive bool
+		ciVariable  string
+		suppress    string
+		expected    bool
+	}{
+		{name: "Interactive", interactive: true, expected: false},
+		{name: "Not interactive", interactive: false, expected: true},
+		{name: "Interactive in CI", interactive: true, ciVariable: "GITHUB_ACTIONS", expected: true},
+		{name: "Suppressed permanently", interactive: true, suppress: "true", expected: true},
+		{name: "Forced when not interactive", interactive: false, suppress: "false", expected: false},
+		{name: "Forced in CI", interactive: true, ciVariable: "CI", suppress: "false", expected: false},
+		{name: "Invalid suppression value", interactive: true, suppress: "maybe", expected: false},
+	}
+
+	originalIsInteractive := tty.IsOutputTerminal
+	defer func() { tty.IsOutputTerminal = originalIsInteractive }()
+
+	// Make sure the environment of the test itself does not interfere
+	for _, v := range ciEnvVariables {
+		if value, found := os.LookupEnv(v); found {
+			os.Unsetenv(v)
+			defer os.Setenv(v, value)
+		}
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tty.IsOutputTerminal = func() bool { return tt.interactive }
+			if tt.ciVariable != "" {
+				os.Setenv(tt.ciVariable, "true")
+				defer os.Unsetenv(tt.ciVariable)
+			}
+			os.Setenv(constants.ConfigVariableSuppressVersionRecommendation, tt.suppress)
+			defer os.Unsetenv(constants.ConfigVariableSuppressVersionRecommendation)
+
+			assert.Equal(t, tt.expected, isRecommendationSuppressed())
+		})
+	}
+}
@@ -6,7 +6,12 @@ package registry
 import (
 	"archive/tar"
 	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
 	"io"
+	"net/http"
+	"os"
 
 	"github.com/cppforlife/go-cli-ui/ui"
 	regname "github.com/google/go-containerregistry/pkg/name"
@@ -16,24 +21,105 @@ import (
 	"github.com/vmware-tanzu/carvel-imgpkg/pkg/imgpkg/bundle"
 	"github.com/vmware-tanzu/carvel-imgpkg/pkg/imgpkg/cmd"
 	ctlimg "github.com/vmware-tanzu/carvel-imgpkg/pkg/imgpkg/registry"
+	tprlog "github.com/vmware-tanzu/tanzu-plugin-runtime/log"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/download"
 )
 
+// maxDownloadAttempts is the number of attempts to download the files of an image
+// when resumable downloads are enabled, each attempt resuming the previous one
+const maxDownloadAttempts = 3
+
 type registry struct {
 	opts     *ctlimg.Opts
 	registry ctlimg.Registry
+	// downloadsCacheDir is the directory where the partial downloads are persisted
+	// to be resumed.  Downloads are not resumable if empty.
+	downloadsCacheDir string
+	// scheduler limits the parallel transfers and their bandwidth, if set
+	scheduler *download.Scheduler
+}
+
+// Option configures the registry client
+type Option func(r *registry)
+
+// WithResumableDownloads persists the blobs being downloaded in the specified
+// directory so that a failed download resumes where it stopped when retried
+func WithResumableDownloads(cacheDir string) Option {
+	return func(r *registry) {
+		r.downloadsCacheDir = cacheDir
+	}
+}
+
+// WithDownloadScheduler downloads the blobs within the limits of the scheduler,
+// which can be shared with other registry clients
+func WithDownloadScheduler(scheduler *download.Scheduler) Option {
+	return func(r *registry) {
+		r.scheduler = scheduler
+	}
 }
 
 // New instantiates a new Registry
-func New(opts *ctlimg.Opts) (Registry, error) {
-	reg, err := ctlimg.NewSimpleRegistry(*opts)
+func New(opts *ctlimg.Opts, options ...Option) (Registry, error) {
+	r := &registry{opts: opts}
+	for _, option := range options {
+		option(r)
+	}
+
+	var reg *ctlimg.SimpleRegistry
+	var err error
+	if r.downloadsCacheDir == "" && r.scheduler == nil {
+		reg, err = ctlimg.NewSimpleRegistry(*opts)
+	} else {
+		var httpTrans *http.Transport
+		if httpTrans, err = newHTTPTransport(opts); err == nil {
+			reg, err = ctlimg.NewSimpleRegistryWithTransport(*opts, r.wrapTransport(httpTrans))
+		}
+	}
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to initialize registry client")
 	}
+	r.registry = reg
+	return r, nil
+}
+
+// wrapTransport wraps the HTTP transport to schedule and resume the downloads as configured.
+// The scheduling is closest to the network so that resuming a download only
+// replays its partial download from the disk outside of the bandwidth limit.
+func (r *registry) wrapTransport(httpTrans http.RoundTripper) http.RoundTripper {
+	rt := httpTrans
+	if r.scheduler != nil {
+		rt = newScheduledTransport(rt, r.scheduler)
+	}
+	if r.downloadsCacheDir != "" {
+		rt = newResumableBlobTransport(rt, r.downloadsCacheDir)
+	}
+	return rt
+}
 
-	return &registry{
-		opts:     opts,
-		registry: reg,
-	}, nil
+// newHTTPTransport creates the same HTTP transport as the imgpkg registry client
+func newHTTPTransport(opts *ctlimg.Opts) (*http.Transport, error) {
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		return nil, err
+	}
+	for _, path := range opts.CACertPaths {
+		if certs, err := os.ReadFile(path); err != nil {
+			return nil, errors.Wrapf(err, "failed reading CA certificates from '%s'", path)
+		} else if ok := pool.AppendCertsFromPEM(certs); !ok {
+			return nil, fmt.Errorf("failed adding CA certificates from '%s'", path)
+		}
+	}
+
+	clonedDefaultTransport := http.DefaultTransport.(*http.Transport).Clone()
+	clonedDefaultTransport.ForceAttemptHTTP2 = false
+	clonedDefaultTransport.ResponseHeaderTimeout = opts.ResponseHeaderTimeout
+	// #nosec G402
+	clonedDefaultTransport.TLSClientConfig = &tls.Config{
+		RootCAs:            pool,
+		InsecureSkipVerify: !opts.VerifyCerts,
+	}
+	return clonedDefaultTransport, nil
 }
 
 // ListImageTags lists all tags of the given image.
@@ -130,7 +216,18 @@ func (r *registry) GetFiles(imageWithTag string) (map[string][]byte, error) {
 		return nil, err
 	}
 
-	return getAllFilesContentFromImage(img)
+	if r.downloadsCacheDir == "" {
+		return getAllFilesContentFromImage(img)
+	}
+	// The partial downloads are resumed by the next attempt
+	var files map[string][]byte
+	for attempt := 1; attempt <= maxDownloadAttempts; attempt++ {
+		if files, err = getAllFilesContentFromImage(img); err == nil {
+			return files, nil
+		}
+		tprlog.V(6).Infof("Attempt %d to download the files of image %q failed: %v", attempt, imageWithTag, err)
+	}
+	return nil, err
 }
 
 func getAllFilesContentFromImage(image regv1.Image) (map[string][]byte, error) {
@@ -21,11 +21,15 @@ import (
 	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/configpaths"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/fips"
 	configlib "github.com/vmware-tanzu/tanzu-plugin-runtime/config"
 	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
 	tprlog "github.com/vmware-tanzu/tanzu-plugin-runtime/log"
 )
 
+// fipsEnabled is a variable to allow overriding it in tests
+var fipsEnabled = fips.Enabled
+
 type CertOptions struct {
 	CACertPaths    []string
 	SkipCertVerify bool
@@ -86,6 +90,11 @@ func updateRegistryCertOptions(cert *configtypes.Cert, registryCertOpts *CertOpt
 		return err
 	}
 
+	// Skipping the certificate verification or using plain HTTP is not allowed in FIPS mode
+	if fipsEnabled() && (registryCertOpts.SkipCertVerify || registryCertOpts.Insecure) {
+		return errors.New("the skip-cert-verify and insecure configuration of the registry cannot be used as the CLI runs in FIPS mode")
+	}
+
 	return nil
 }
 
@@ -12,6 +12,7 @@ import (
 
 	"github.com/vmware-tanzu/tanzu-cli/pkg/configpaths"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/fips"
 	configlib "github.com/vmware-tanzu/tanzu-plugin-runtime/config"
 	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
 )
@@ -113,6 +114,14 @@ var _ = Describe("GetRegistryCertOptions", func() {
 				Expect(certOptions.Insecure).To(Equal(false))
 
 			})
+			It("should return an error for the skipCertVerify and Insecure options when running in FIPS mode", func() {
+				fipsEnabled = func() bool { return true }
+				defer func() { fipsEnabled = fips.Enabled }()
+
+				_, err := GetRegistryCertOptions(testHost)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("the skip-cert-verify and insecure configuration of the registry cannot be used as the CLI runs in FIPS mode"))
+			})
 		})
 
 	})
@@ -27,6 +27,12 @@ type Registry interface {
 	// CopyImageFromTar publishes the image to destination repository from specified tar file
 	// This is equivalent to `imgpkg copy --tar <file> --to-repo <dest-repo>` command
 	CopyImageFromTar(sourceTarFile, destImageRepo string) error
+	// DownloadImageToOCILayout downloads the image or image index as a tar file
+	// of a standard OCI image layout
+	DownloadImageToOCILayout(imageWithTag, destTarFile string) error
+	// UploadImageFromOCILayout publishes the image or image index of the tar file
+	// of a standard OCI image layout to the specified location
+	UploadImageFromOCILayout(sourceTarFile, destImageWithTag string) error
 	// PushImage publishes the image to the specified location
 	// This is equivalent to `imgpkg push -i <image> -f <filepath>`
 	PushImage(imageWithTag string, filePaths []string) error
@@ -0,0 +1,54 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package registry
+
+import (
+	"os"
+	"strings"
+
+	tprlog "github.com/vmware-tanzu/tanzu-plugin-runtime/log"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
+)
+
+// GetRegistryMirrors returns the registry mirrors configured through the
+// TANZU_CLI_REGISTRY_MIRRORS variable, keyed by the registry or repository
+// path prefix being mirrored
+func GetRegistryMirrors() map[string]string {
+	mirrors := map[string]string{}
+	for _, mapping := range strings.Split(os.Getenv(constants.RegistryMirrors), ",") {
+		mapping = strings.TrimSpace(mapping)
+		if mapping == "" {
+			continue
+		}
+		source, mirror, found := strings.Cut(mapping, "=")
+		source = strings.TrimSuffix(strings.TrimSpace(source), "/")
+		mirror = strings.TrimSuffix(strings.TrimSpace(mirror), "/")
+		if !found || source == "" || mirror == "" {
+			tprlog.Warningf("ignoring invalid registry mirror configuration %q, expected <registry>=<mirror>", mapping)
+			continue
+		}
+		mirrors[source] = mirror
+	}
+	return mirrors
+}
+
+// ResolveImageMirror returns the image to pull from the mirror configured for the
+// registry of the specified image, or the image itself if no mirror is configured.
+// When several mirrored prefixes match the image, the longest one is used.
+func ResolveImageMirror(image string) string {
+	image = strings.TrimSpace(image)
+	matchedSource := ""
+	mirror := ""
+	for source, m := range GetRegistryMirrors() {
+		if strings.HasPrefix(image, source+"/") && len(source) > len(matchedSource) {
+			matchedSource = source
+			mirror = m
+		}
+	}
+	if matchedSource == "" {
+		return image
+	}
+	return mirror + strings.TrimPrefix(image, matchedSource)
+}
@@ -0,0 +1,42 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package registry
+
+import (
+	"os"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
+)
+
+var _ = Describe("ResolveImageMirror() tests", func() {
+	AfterEach(func() {
+		os.Unsetenv(constants.RegistryMirrors)
+	})
+
+	It("should return the image when no mirror is configured", func() {
+		Expect(ResolveImageMirror("projects.registry.vmware.com/tanzu_cli/plugins/plugin-inventory:latest")).To(Equal("projects.registry.vmware.com/tanzu_cli/plugins/plugin-inventory:latest"))
+	})
+	It("should return the image from the mirror of its registry", func() {
+		os.Setenv(constants.RegistryMirrors, "projects.registry.vmware.com=harbor.example.com/vmware-proxy/, other.example.com=mirror.example.com")
+		Expect(ResolveImageMirror("projects.registry.vmware.com/tanzu_cli/plugins/plugin-inventory:latest")).To(Equal("harbor.example.com/vmware-proxy/tanzu_cli/plugins/plugin-inventory:latest"))
+		Expect(ResolveImageMirror("other.example.com/foo@sha256:1234")).To(Equal("mirror.example.com/foo@sha256:1234"))
+		Expect(ResolveImageMirror("unmirrored.example.com/foo:v1")).To(Equal("unmirrored.example.com/foo:v1"))
+	})
+	It("should use the mirror of the longest matching repository prefix", func() {
+		os.Setenv(constants.RegistryMirrors, "projects.registry.vmware.com=harbor.example.com/all,projects.registry.vmware.com/tanzu_cli=harbor.example.com/cli")
+		Expect(ResolveImageMirror("projects.registry.vmware.com/tanzu_cli/plugins/plugin-inventory:latest")).To(Equal("harbor.example.com/cli/plugins/plugin-inventory:latest"))
+		Expect(ResolveImageMirror("projects.registry.vmware.com/tkg/foo:v1")).To(Equal("harbor.example.com/all/tkg/foo:v1"))
+	})
+	It("should not match a registry which only shares a prefix of its host name", func() {
+		os.Setenv(constants.RegistryMirrors, "registry.example.com=mirror.example.com")
+		Expect(ResolveImageMirror("registry.example.com.evil/foo:v1")).To(Equal("registry.example.com.evil/foo:v1"))
+	})
+	It("should ignore invalid mappings", func() {
+		os.Setenv(constants.RegistryMirrors, "registry.example.com,=mirror.example.com")
+		Expect(GetRegistryMirrors()).To(BeEmpty())
+	})
+})
@@ -0,0 +1,202 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package registry
+
+import (
+	"archive/tar"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	regname "github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/layout"
+	"github.com/pkg/errors"
+)
+
+// ociRefNameAnnotation is the annotation of the OCI image layout index
+// recording the reference of the image
+const ociRefNameAnnotation = "org.opencontainers.image.ref.name"
+
+// DownloadImageToOCILayout downloads the image or image index as a tar file
+// of a standard OCI image layout
+func (r *registry) DownloadImageToOCILayout(imageWithTag, destTarFile string) error {
+	ref, err := regname.ParseReference(imageWithTag, regname.WeakValidation)
+	if err != nil {
+		return err
+	}
+	desc, err := r.registry.Get(ref)
+	if err != nil {
+		return errors.Wrapf(err, "unable to find image %q", imageWithTag)
+	}
+
+	layoutDir, err := os.MkdirTemp("", "")
+	if err != nil {
+		return errors.Wrap(err, "unable to create temporary directory")
+	}
+	defer os.RemoveAll(layoutDir)
+
+	layoutPath, err := layout.Write(layoutDir, empty.Index)
+	if err != nil {
+		return errors.Wrap(err, "unable to initialize the OCI image layout")
+	}
+	annotations := layout.WithAnnotations(map[string]string{ociRefNameAnnotation: ref.Identifier()})
+	if desc.MediaType.IsIndex() {
+		index, err := r.registry.Index(ref)
+		if err != nil {
+			return err
+		}
+		err = layoutPath.AppendIndex(index, annotations)
+		if err != nil {
+			return errors.Wrapf(err, "unable to write image index %q to the OCI image layout", imageWithTag)
+		}
+	} else {
+		image, err := r.registry.Image(ref)
+		if err != nil {
+			return err
+		}
+		err = layoutPath.AppendImage(image, annotations)
+		if err != nil {
+			return errors.Wrapf(err, "unable to write image %q to the OCI image layout", imageWithTag)
+		}
+	}
+
+	return tarDir(layoutDir, destTarFile)
+}
+
+// UploadImageFromOCILayout publishes the image or image index of the tar file
+// of a standard OCI image layout to the specified location
+func (r *registry) UploadImageFromOCILayout(sourceTarFile, destImageWithTag string) error {
+	ref, err := regname.ParseReference(destImageWithTag, regname.WeakValidation)
+	if err != nil {
+		return err
+	}
+
+	layoutDir, err := os.MkdirTemp("", "")
+	if err != nil {
+		return errors.Wrap(err, "unable to create temporary directory")
+	}
+	defer os.RemoveAll(layoutDir)
+
+	err = untarToDir(sourceTarFile, layoutDir)
+	if err != nil {
+		return errors.Wrapf(err, "unable to extract %q", sourceTarFile)
+	}
+	layoutIndex, err := layout.ImageIndexFromPath(layoutDir)
+	if err != nil {
+		return errors.Wrapf(err, "%q is not a valid OCI image layout", sourceTarFile)
+	}
+	indexManifest, err := layoutIndex.IndexManifest()
+	if err != nil {
+		return err
+	}
+	if len(indexManifest.Manifests) != 1 {
+		return errors.Errorf("the OCI image layout %q is required to contain only 1 image, but found %v", sourceTarFile, len(indexManifest.Manifests))
+	}
+
+	desc := indexManifest.Manifests[0]
+	if desc.MediaType.IsIndex() {
+		index, err := layoutIndex.ImageIndex(desc.Digest)
+		if err != nil {
+			return err
+		}
+		return r.registry.WriteIndex(ref, index)
+	}
+	image, err := layoutIndex.Image(desc.Digest)
+	if err != nil {
+		return err
+	}
+	return r.registry.WriteImage(ref, image, nil)
+}
+
+// tarDir writes the content of the directory to the tar file
+func tarDir(srcDir, destTarFile string) error {
+	f, err := os.Create(destTarFile)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	tw := tar.NewWriter(f)
+	err = filepath.Walk(srcDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		relPath, err := filepath.Rel(srcDir, path)
+		if err != nil || relPath == "." {
+			return err
+		}
+		header, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		header.Name = filepath.ToSlash(relPath)
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		file, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		_, err = io.Copy(tw, file)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	return tw.Close()
+}
+
+// untarToDir extracts the regular files and directories of the tar file to the directory
+func untarToDir(srcTarFile, destDir string) error {
+	f, err := os.Open(srcTarFile)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	tr := tar.NewReader(f)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		target := filepath.Join(destDir, filepath.FromSlash(header.Name)) // #nosec G305
+		if !strings.HasPrefix(target, filepath.Clean(destDir)+string(os.PathSeparator)) {
+			return errors.Errorf("invalid file path %q in tar file", header.Name)
+		}
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := writeFileFromReader(target, tr); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func writeFileFromReader(path string, r io.Reader) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = io.Copy(f, r) // #nosec G110
+	return err
+}
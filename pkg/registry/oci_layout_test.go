@@ -0,0 +1,79 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package registry
+
+import (
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	regname "github.com/google/go-containerregistry/pkg/name"
+	gocontainerregistry "github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	ctlimg "github.com/vmware-tanzu/carvel-imgpkg/pkg/imgpkg/registry"
+)
+
+var _ = Describe("OCI image layout", func() {
+	var (
+		server  *httptest.Server
+		host    string
+		reg     Registry
+		tempDir string
+	)
+
+	BeforeEach(func() {
+		server = httptest.NewServer(gocontainerregistry.New())
+		u, err := url.Parse(server.URL)
+		Expect(err).To(BeNil())
+		host = u.Host
+
+		reg, err = New(&ctlimg.Opts{Anon: true, Insecure: true})
+		Expect(err).To(BeNil())
+
+		tempDir, err = os.MkdirTemp("", "")
+		Expect(err).To(BeNil())
+	})
+	AfterEach(func() {
+		server.Close()
+		os.RemoveAll(tempDir)
+	})
+
+	It("should round trip an image through an OCI image layout tar file", func() {
+		image, err := random.Image(1024, 2)
+		Expect(err).To(BeNil())
+		sourceRef, err := regname.ParseReference(host + "/test/source:v1")
+		Expect(err).To(BeNil())
+		Expect(remote.Write(sourceRef, image)).To(Succeed())
+
+		tarFile := filepath.Join(tempDir, "image.tar")
+		Expect(reg.DownloadImageToOCILayout(sourceRef.String(), tarFile)).To(Succeed())
+		Expect(tarFile).To(BeAnExistingFile())
+
+		destImage := host + "/test/dest:v1"
+		Expect(reg.UploadImageFromOCILayout(tarFile, destImage)).To(Succeed())
+
+		expectedDigest, err := image.Digest()
+		Expect(err).To(BeNil())
+		_, hex, err := reg.GetImageDigest(destImage)
+		Expect(err).To(BeNil())
+		Expect(hex).To(Equal(expectedDigest.Hex))
+	})
+
+	It("should return an error when the tar file is not an OCI image layout", func() {
+		contentDir := filepath.Join(tempDir, "content")
+		Expect(os.MkdirAll(contentDir, 0755)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(contentDir, "file"), []byte("content"), 0644)).To(Succeed())
+		tarFile := filepath.Join(tempDir, "invalid.tar")
+		Expect(tarDir(contentDir, tarFile)).To(Succeed())
+
+		err := reg.UploadImageFromOCILayout(tarFile, host+"/test/dest:v1")
+		Expect(err).NotTo(BeNil())
+		Expect(err.Error()).To(ContainSubstring("is not a valid OCI image layout"))
+	})
+})
@@ -0,0 +1,308 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package registry
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	regv1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/pkg/errors"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/utils"
+)
+
+const (
+	// partialDownloadSuffix is the suffix of the files of the partial downloads
+	partialDownloadSuffix = ".partial"
+	// minResumableBlobSize is the size from which the blobs are persisted while being
+	// downloaded; smaller blobs are quickly downloaded again if the download fails
+	minResumableBlobSize = 1024 * 1024
+	// maxPartialDownloadAge is how long a partial download is kept to be resumed
+	maxPartialDownloadAge = 7 * 24 * time.Hour
+	// maxRedirects is the maximum number of redirects followed to download a blob
+	maxRedirects = 10
+)
+
+// blobPathRegexp matches the path of the registry API to get a blob, capturing its digest
+var blobPathRegexp = regexp.MustCompile(`^/v2/.+/blobs/(sha256:[a-f0-9]{64})$`)
+
+// contentRangeRegexp matches the Content-Range header of a partial response,
+// capturing the first byte position and the total size, which can be unknown
+var contentRangeRegexp = regexp.MustCompile(`^bytes (\d+)-\d+/(\d+|\*)$`)
+
+// resumableBlobTransport is an http.RoundTripper which persists the blobs being downloaded
+// from a registry in a cache directory, keyed by their digest.  If a download fails, e.g.
+// because of a poor network link, the next attempt to download the blob resumes it using
+// a range request instead of restarting from zero.  The partial download is removed once
+// complete, after verifying the digest of the blob.
+//
+// The transport is the base transport of the registry client, below the authentication
+// and retry transports, so the requests it receives are already authenticated.
+type resumableBlobTransport struct {
+	base     http.RoundTripper
+	cacheDir string
+}
+
+func newResumableBlobTransport(base http.RoundTripper, cacheDir string) *resumableBlobTransport {
+	pruneStalePartialDownloads(cacheDir)
+	return &resumableBlobTransport{
+		base:     base,
+		cacheDir: cacheDir,
+	}
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *resumableBlobTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	m := blobPathRegexp.FindStringSubmatch(req.URL.Path)
+	if req.Method != http.MethodGet || m == nil || req.Header.Get("Range") != "" {
+		return t.base.RoundTrip(req)
+	}
+	digest, err := regv1.NewHash(m[1])
+	if err != nil {
+		return t.base.RoundTrip(req)
+	}
+	partialPath := filepath.Join(t.cacheDir, digest.Algorithm+"-"+digest.Hex+partialDownloadSuffix)
+
+	// The lock prevents two processes from writing the same partial download.
+	// It is held until the body of the response is closed.
+	lock, err := utils.LockFile(partialPath)
+	if err != nil {
+		// Resuming downloads is an optimization, download without it
+		return t.base.RoundTrip(req)
+	}
+	resp, err := t.resume(req, digest, partialPath, lock)
+	if err != nil {
+		lock.Unlock()
+	}
+	return resp, err
+}
+
+// resume downloads the blob, resuming its partial download if any
+func (t *resumableBlobTransport) resume(req *http.Request, digest regv1.Hash, partialPath string, lock *utils.FileLock) (*http.Response, error) {
+	var offset int64
+	if info, err := os.Stat(partialPath); err == nil {
+		offset = info.Size()
+	}
+
+	resp, err := t.get(req, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	total := resp.ContentLength
+	if offset > 0 {
+		start, size, resumed := parseContentRange(resp)
+		switch {
+		case resumed && start == offset:
+			total = size
+		case resp.StatusCode == http.StatusOK:
+			// The registry does not support range requests, restart from zero
+			offset = 0
+		default:
+			// The partial download is invalid, e.g. it is bigger than the blob, restart from zero
+			resp.Body.Close()
+			offset = 0
+			if resp, err = t.get(req, 0); err != nil {
+				return nil, err
+			}
+			total = resp.ContentLength
+		}
+	}
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusPartialContent {
+		// Let the registry client handle the error, e.g. to authenticate
+		lock.Unlock()
+		return resp, nil
+	}
+	if offset == 0 && total >= 0 && total < minResumableBlobSize {
+		lock.Unlock()
+		_ = os.Remove(partialPath)
+		return resp, nil
+	}
+
+	body, err := newResumableBody(resp.Body, digest, partialPath, offset, lock)
+	if err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+
+	// The registry client expects the full blob
+	resumedResp := *resp
+	resumedResp.StatusCode = http.StatusOK
+	resumedResp.Status = http.StatusText(http.StatusOK)
+	resumedResp.Header = resp.Header.Clone()
+	resumedResp.Header.Del("Content-Range")
+	resumedResp.ContentLength = total
+	if total >= 0 {
+		resumedResp.Header.Set("Content-Length", strconv.FormatInt(total, 10))
+	} else {
+		resumedResp.Header.Del("Content-Length")
+	}
+	resumedResp.Body = body
+	return &resumedResp, nil
+}
+
+// get sends the request for the blob starting at the specified offset.
+// Redirects are followed here rather than by the HTTP client so that the
+// range request is also sent to the storage the registry redirects to.
+func (t *resumableBlobTransport) get(req *http.Request, offset int64) (*http.Response, error) {
+	r := req.Clone(req.Context())
+	for redirects := 0; ; redirects++ {
+		if offset > 0 {
+			r.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+		}
+		resp, err := t.base.RoundTrip(r)
+		if err != nil || !isRedirect(resp.StatusCode) || redirects == maxRedirects {
+			return resp, err
+		}
+		location, err := resp.Location()
+		resp.Body.Close()
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid redirect while downloading blob")
+		}
+
+		next := r.Clone(r.Context())
+		next.URL = location
+		next.Host = ""
+		if location.Host != r.URL.Host {
+			// Don't send the registry credentials to another host
+			next.Header.Del("Authorization")
+		}
+		r = next
+	}
+}
+
+func isRedirect(statusCode int) bool {
+	switch statusCode {
+	case http.StatusMovedPermanently, http.StatusFound, http.StatusSeeOther, http.StatusTemporaryRedirect, http.StatusPermanentRedirect:
+		return true
+	}
+	return false
+}
+
+// parseContentRange returns the first byte position of a partial response and the
+// total size of the blob, or -1 if unknown, and whether the response is partial
+func parseContentRange(resp *http.Response) (int64, int64, bool) {
+	if resp.StatusCode != http.StatusPartialContent {
+		return 0, 0, false
+	}
+	m := contentRangeRegexp.FindStringSubmatch(resp.Header.Get("Content-Range"))
+	if m == nil {
+		return 0, 0, false
+	}
+	start, err := strconv.ParseInt(m[1], 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	total := int64(-1)
+	if m[2] != "*" {
+		if total, err = strconv.ParseInt(m[2], 10, 64); err != nil {
+			return 0, 0, false
+		}
+	}
+	return start, total, true
+}
+
+// resumableBody is the body of the response for a blob, made of the partial download
+// followed by the rest of the blob, which is appended to the partial download as it is read
+type resumableBody struct {
+	reader  io.Reader
+	remote  io.ReadCloser
+	partial *os.File
+	written *os.File
+	hash    hash.Hash
+	digest  regv1.Hash
+	path    string
+	lock    *utils.FileLock
+}
+
+func newResumableBody(remote io.ReadCloser, digest regv1.Hash, partialPath string, offset int64, lock *utils.FileLock) (*resumableBody, error) {
+	flags := os.O_CREATE | os.O_WRONLY | os.O_APPEND
+	if offset == 0 {
+		flags |= os.O_TRUNC
+	}
+	if err := os.MkdirAll(filepath.Dir(partialPath), 0755); err != nil {
+		return nil, errors.Wrap(err, "unable to create the directory of the partial downloads")
+	}
+	written, err := os.OpenFile(partialPath, flags, 0600)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to open the partial download %q", partialPath)
+	}
+
+	b := &resumableBody{
+		remote:  remote,
+		written: written,
+		hash:    sha256.New(),
+		digest:  digest,
+		path:    partialPath,
+		lock:    lock,
+	}
+	b.reader = io.TeeReader(remote, written)
+	if offset > 0 {
+		if b.partial, err = os.Open(partialPath); err != nil {
+			written.Close()
+			return nil, errors.Wrapf(err, "unable to open the partial download %q", partialPath)
+		}
+		b.reader = io.MultiReader(io.LimitReader(b.partial, offset), b.reader)
+	}
+	return b, nil
+}
+
+// Read implements io.Reader
+func (b *resumableBody) Read(p []byte) (int, error) {
+	n, err := b.reader.Read(p)
+	b.hash.Write(p[:n])
+	if err != io.EOF {
+		return n, err
+	}
+
+	// The download is complete so the partial download is not needed anymore
+	b.written.Close()
+	_ = os.Remove(b.path)
+	if actual := fmt.Sprintf("%x", b.hash.Sum(nil)); actual != b.digest.Hex {
+		return n, errors.Errorf("the digest of the downloaded blob sha256:%s does not match the expected digest %s", actual, b.digest)
+	}
+	return n, io.EOF
+}
+
+// Close implements io.Closer.  If the blob was not completely read,
+// the partial download is kept so that the next download resumes it.
+func (b *resumableBody) Close() error {
+	err := b.remote.Close()
+	b.written.Close()
+	if b.partial != nil {
+		b.partial.Close()
+	}
+	b.lock.Unlock()
+	return err
+}
+
+// pruneStalePartialDownloads removes the partial downloads which have not been resumed for a while
+func pruneStalePartialDownloads(cacheDir string) {
+	entries, err := os.ReadDir(cacheDir)
+	if err != nil {
+		return
+	}
+	for _, entry := range entries {
+		if !strings.HasSuffix(entry.Name(), partialDownloadSuffix) {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil || time.Since(info.ModTime()) < maxPartialDownloadAge {
+			continue
+		}
+		_ = os.Remove(filepath.Join(cacheDir, entry.Name()))
+		_ = os.Remove(filepath.Join(cacheDir, entry.Name()+".lock"))
+	}
+}
@@ -0,0 +1,143 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package registry
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("resumableBlobTransport tests", func() {
+	var (
+		blob       []byte
+		digest     string
+		cacheDir   string
+		server     *httptest.Server
+		mutex      sync.Mutex
+		ranges     []string
+		truncateAt int
+	)
+
+	BeforeEach(func() {
+		blob = make([]byte, 3*minResumableBlobSize)
+		_, err := rand.Read(blob)
+		Expect(err).ToNot(HaveOccurred())
+		digest = fmt.Sprintf("sha256:%x", sha256.Sum256(blob))
+
+		cacheDir, err = os.MkdirTemp("", "downloads")
+		Expect(err).ToNot(HaveOccurred())
+
+		ranges = nil
+		truncateAt = 0
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mutex.Lock()
+			ranges = append(ranges, r.Header.Get("Range"))
+			truncate := truncateAt
+			truncateAt = 0
+			mutex.Unlock()
+
+			if truncate > 0 {
+				// Simulate a connection which breaks in the middle of the download
+				w.Header().Set("Content-Length", strconv.Itoa(len(blob)))
+				_, _ = w.Write(blob[:truncate])
+				panic(http.ErrAbortHandler)
+			}
+			http.ServeContent(w, r, "", time.Time{}, bytes.NewReader(blob))
+		}))
+	})
+
+	AfterEach(func() {
+		server.Close()
+		os.RemoveAll(cacheDir)
+	})
+
+	download := func(t http.RoundTripper) ([]byte, error) {
+		req, err := http.NewRequest(http.MethodGet, server.URL+"/v2/test/plugin/blobs/"+digest, http.NoBody)
+		Expect(err).ToNot(HaveOccurred())
+		resp, err := t.RoundTrip(req)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+		Expect(resp.ContentLength).To(Equal(int64(len(blob))))
+		return io.ReadAll(resp.Body)
+	}
+
+	It("should resume a failed download and verify its digest", func() {
+		t := newResumableBlobTransport(http.DefaultTransport, cacheDir)
+		truncateAt = 2 * minResumableBlobSize
+
+		_, err := download(t)
+		Expect(err).To(HaveOccurred())
+		info, err := os.Stat(filepath.Join(cacheDir, "sha256-"+digest[len("sha256:"):]+partialDownloadSuffix))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(info.Size()).To(Equal(int64(2 * minResumableBlobSize)))
+
+		data, err := download(t)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(data).To(Equal(blob))
+		Expect(ranges).To(Equal([]string{"", fmt.Sprintf("bytes=%d-", 2*minResumableBlobSize)}))
+
+		// The partial download is removed once complete
+		entries, err := filepath.Glob(filepath.Join(cacheDir, "*"+partialDownloadSuffix))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(entries).To(BeEmpty())
+	})
+
+	It("should fail if the resumed download does not match the digest", func() {
+		t := newResumableBlobTransport(http.DefaultTransport, cacheDir)
+		partialPath := filepath.Join(cacheDir, "sha256-"+digest[len("sha256:"):]+partialDownloadSuffix)
+		corrupted := bytes.Repeat([]byte{0}, minResumableBlobSize)
+		Expect(os.WriteFile(partialPath, corrupted, 0600)).To(Succeed())
+
+		_, err := download(t)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("does not match the expected digest"))
+
+		// The corrupted partial download is removed so the next download restarts from zero
+		_, err = os.Stat(partialPath)
+		Expect(os.IsNotExist(err)).To(BeTrue())
+		data, err := download(t)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(data).To(Equal(blob))
+	})
+
+	It("should not persist small blobs", func() {
+		blob = blob[:1024]
+		digest = fmt.Sprintf("sha256:%x", sha256.Sum256(blob))
+		t := newResumableBlobTransport(http.DefaultTransport, cacheDir)
+
+		data, err := download(t)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(data).To(Equal(blob))
+		entries, err := filepath.Glob(filepath.Join(cacheDir, "*"+partialDownloadSuffix))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(entries).To(BeEmpty())
+	})
+
+	It("should remove stale partial downloads", func() {
+		stalePath := filepath.Join(cacheDir, "sha256-stale"+partialDownloadSuffix)
+		Expect(os.WriteFile(stalePath, []byte("partial"), 0600)).To(Succeed())
+		old := time.Now().Add(-2 * maxPartialDownloadAge)
+		Expect(os.Chtimes(stalePath, old, old)).To(Succeed())
+
+		newResumableBlobTransport(http.DefaultTransport, cacheDir)
+		_, err := os.Stat(stalePath)
+		Expect(os.IsNotExist(err)).To(BeTrue())
+	})
+})
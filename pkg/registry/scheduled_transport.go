@@ -0,0 +1,49 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package registry
+
+import (
+	"net/http"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/download"
+)
+
+// scheduledTransport is an http.RoundTripper which requests the blobs within
+// the limits of a download scheduler: a blob request only starts when the
+// scheduler allows a transfer and its body is read within the bandwidth limit, if any.
+// The transfer ends once the response is received rather than when its body is
+// closed, as a caller keeping more bodies open than the parallel transfers allowed
+// would otherwise wait forever for a transfer to end.
+// The other requests, e.g. for manifests, are small and are not scheduled.
+type scheduledTransport struct {
+	base      http.RoundTripper
+	scheduler *download.Scheduler
+}
+
+func newScheduledTransport(base http.RoundTripper, scheduler *download.Scheduler) *scheduledTransport {
+	return &scheduledTransport{
+		base:      base,
+		scheduler: scheduler,
+	}
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *scheduledTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet || !blobPathRegexp.MatchString(req.URL.Path) {
+		return t.base.RoundTrip(req)
+	}
+
+	done, err := t.scheduler.StartTransfer(req.Context())
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = t.scheduler.Throttle(req.Context(), resp.Body)
+	return resp, nil
+}
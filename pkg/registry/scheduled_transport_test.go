@@ -0,0 +1,102 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package registry
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/download"
+)
+
+var _ = Describe("scheduledTransport tests", func() {
+	const blobPath = "/v2/test/plugin/blobs/sha256:0000000000000000000000000000000000000000000000000000000000000000"
+	var (
+		server    *httptest.Server
+		scheduler *download.Scheduler
+		t         http.RoundTripper
+	)
+
+	BeforeEach(func() {
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte("content"))
+		}))
+		scheduler = download.NewScheduler(1, 0)
+		t = newScheduledTransport(http.DefaultTransport, scheduler)
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	get := func(path string) *http.Response {
+		req, err := http.NewRequest(http.MethodGet, server.URL+path, http.NoBody)
+		Expect(err).ToNot(HaveOccurred())
+		resp, err := t.RoundTrip(req)
+		Expect(err).ToNot(HaveOccurred())
+		return resp
+	}
+
+	transferCanStart := func() bool {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		done, err := scheduler.StartTransfer(ctx)
+		if err != nil {
+			return false
+		}
+		done()
+		return true
+	}
+
+	It("should end the transfer of a blob once its response is received", func() {
+		resp := get(blobPath)
+		defer resp.Body.Close()
+		Expect(transferCanStart()).To(BeTrue())
+
+		b, err := io.ReadAll(resp.Body)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(b)).To(Equal("content"))
+	})
+
+	It("should not block a caller keeping more blob bodies open than the parallel transfers", func() {
+		first := get(blobPath)
+		defer first.Body.Close()
+		second := get(blobPath)
+		defer second.Body.Close()
+
+		for _, resp := range []*http.Response{first, second} {
+			b, err := io.ReadAll(resp.Body)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(b)).To(Equal("content"))
+		}
+	})
+
+	It("should wait for a transfer to start before requesting a blob", func() {
+		done, err := scheduler.StartTransfer(context.Background())
+		Expect(err).ToNot(HaveOccurred())
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+blobPath, http.NoBody)
+		Expect(err).ToNot(HaveOccurred())
+		_, err = t.RoundTrip(req)
+		Expect(err).To(MatchError(context.DeadlineExceeded))
+
+		done()
+		resp := get(blobPath)
+		defer resp.Body.Close()
+	})
+
+	It("should not schedule the requests other than for blobs", func() {
+		resp := get("/v2/test/plugin/manifests/latest")
+		defer resp.Body.Close()
+		Expect(transferCanStart()).To(BeTrue())
+	})
+})
@@ -0,0 +1,165 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package sbom
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+type spdxDocument struct {
+	SPDXVersion       string             `json:"spdxVersion"`
+	DataLicense       string             `json:"dataLicense"`
+	SPDXID            string             `json:"SPDXID"`
+	Name              string             `json:"name"`
+	DocumentNamespace string             `json:"documentNamespace"`
+	CreationInfo      spdxCreationInfo   `json:"creationInfo"`
+	Packages          []spdxPackage      `json:"packages"`
+	Relationships     []spdxRelationship `json:"relationships"`
+}
+
+type spdxCreationInfo struct {
+	Created  string   `json:"created"`
+	Creators []string `json:"creators"`
+}
+
+type spdxPackage struct {
+	Name             string            `json:"name"`
+	SPDXID           string            `json:"SPDXID"`
+	VersionInfo      string            `json:"versionInfo"`
+	DownloadLocation string            `json:"downloadLocation"`
+	FilesAnalyzed    bool              `json:"filesAnalyzed"`
+	ExternalRefs     []spdxExternalRef `json:"externalRefs"`
+}
+
+type spdxExternalRef struct {
+	ReferenceCategory string `json:"referenceCategory"`
+	ReferenceType     string `json:"referenceType"`
+	ReferenceLocator  string `json:"referenceLocator"`
+}
+
+type spdxRelationship struct {
+	SPDXElementID      string `json:"spdxElementId"`
+	RelationshipType   string `json:"relationshipType"`
+	RelatedSPDXElement string `json:"relatedSpdxElement"`
+}
+
+func newSPDXPackage(id string, c component) spdxPackage {
+	return spdxPackage{
+		Name:             c.path,
+		SPDXID:           id,
+		VersionInfo:      c.version,
+		DownloadLocation: "NOASSERTION",
+		ExternalRefs: []spdxExternalRef{
+			{
+				ReferenceCategory: "PACKAGE-MANAGER",
+				ReferenceType:     "purl",
+				ReferenceLocator:  c.purl(),
+			},
+		},
+	}
+}
+
+// generateSPDX returns an SPDX 2.3 document describing the binary.
+func generateSPDX(name, digest string, main component, deps []component, created time.Time) ([]byte, error) {
+	const mainID = "SPDXRef-Package-main"
+
+	doc := spdxDocument{
+		SPDXVersion: "SPDX-2.3",
+		DataLicense: "CC0-1.0",
+		SPDXID:      "SPDXRef-DOCUMENT",
+		Name:        fmt.Sprintf("%s-%s", name, main.version),
+		// The digest makes the namespace unique to this binary while keeping it reproducible
+		DocumentNamespace: fmt.Sprintf("https://spdx.org/spdxdocs/%s-%s-%s", name, main.version, digest),
+		CreationInfo: spdxCreationInfo{
+			Created:  created.UTC().Format(time.RFC3339),
+			Creators: []string{"Tool: " + toolName},
+		},
+		Packages: []spdxPackage{newSPDXPackage(mainID, main)},
+		Relationships: []spdxRelationship{
+			{
+				SPDXElementID:      "SPDXRef-DOCUMENT",
+				RelationshipType:   "DESCRIBES",
+				RelatedSPDXElement: mainID,
+			},
+		},
+	}
+	for i, dep := range deps {
+		id := fmt.Sprintf("SPDXRef-Package-%d", i)
+		doc.Packages = append(doc.Packages, newSPDXPackage(id, dep))
+		doc.Relationships = append(doc.Relationships, spdxRelationship{
+			SPDXElementID:      mainID,
+			RelationshipType:   "DEPENDS_ON",
+			RelatedSPDXElement: id,
+		})
+	}
+	return json.MarshalIndent(doc, "", "  ")
+}
+
+type cycloneDXDocument struct {
+	BOMFormat    string                `json:"bomFormat"`
+	SpecVersion  string                `json:"specVersion"`
+	Version      int                   `json:"version"`
+	Metadata     cycloneDXMetadata     `json:"metadata"`
+	Components   []cycloneDXComponent  `json:"components"`
+	Dependencies []cycloneDXDependency `json:"dependencies"`
+}
+
+type cycloneDXMetadata struct {
+	Timestamp string             `json:"timestamp"`
+	Tools     []cycloneDXTool    `json:"tools"`
+	Component cycloneDXComponent `json:"component"`
+}
+
+type cycloneDXTool struct {
+	Name string `json:"name"`
+}
+
+type cycloneDXComponent struct {
+	BOMRef  string `json:"bom-ref"`
+	Type    string `json:"type"`
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	PURL    string `json:"purl"`
+}
+
+type cycloneDXDependency struct {
+	Ref       string   `json:"ref"`
+	DependsOn []string `json:"dependsOn"`
+}
+
+// generateCycloneDX returns a CycloneDX 1.5 document describing the binary.
+func generateCycloneDX(name string, main component, deps []component, created time.Time) ([]byte, error) {
+	mainComponent := cycloneDXComponent{
+		BOMRef:  main.purl(),
+		Type:    "application",
+		Name:    name,
+		Version: main.version,
+		PURL:    main.purl(),
+	}
+	doc := cycloneDXDocument{
+		BOMFormat:   "CycloneDX",
+		SpecVersion: "1.5",
+		Version:     1,
+		Metadata: cycloneDXMetadata{
+			Timestamp: created.UTC().Format(time.RFC3339),
+			Tools:     []cycloneDXTool{{Name: toolName}},
+			Component: mainComponent,
+		},
+		Components:   []cycloneDXComponent{},
+		Dependencies: []cycloneDXDependency{{Ref: mainComponent.BOMRef, DependsOn: []string{}}},
+	}
+	for _, dep := range deps {
+		doc.Components = append(doc.Components, cycloneDXComponent{
+			BOMRef:  dep.purl(),
+			Type:    "library",
+			Name:    dep.path,
+			Version: dep.version,
+			PURL:    dep.purl(),
+		})
+		doc.Dependencies[0].DependsOn = append(doc.Dependencies[0].DependsOn, dep.purl())
+	}
+	return json.MarshalIndent(doc, "", "  ")
+}
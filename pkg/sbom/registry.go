@@ -0,0 +1,151 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package sbom
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/pkg/errors"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/registry"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/utils"
+)
+
+// Attach publishes the SBOM as an OCI artifact referring to the specified image,
+// so that it can be discovered using the OCI referrers API.
+func Attach(image string, content []byte, format Format, options ...remote.Option) error {
+	ref, err := name.ParseReference(image)
+	if err != nil {
+		return err
+	}
+	subject, err := remote.Head(ref, options...)
+	if err != nil {
+		return errors.Wrapf(err, "unable to find image %q", image)
+	}
+
+	mediaType := types.MediaType(format.MediaType())
+	artifact := mutate.MediaType(empty.Image, types.OCIManifestSchema1)
+	// The media type of the config is used as the artifact type of the referrer
+	artifact = mutate.ConfigMediaType(artifact, mediaType)
+	artifact, err = mutate.Append(artifact, mutate.Addendum{
+		Layer:       static.NewLayer(content, mediaType),
+		Annotations: map[string]string{"org.opencontainers.image.title": format.FileName()},
+	})
+	if err != nil {
+		return err
+	}
+	artifact = mutate.Subject(artifact, *subject).(v1.Image)
+
+	digest, err := artifact.Digest()
+	if err != nil {
+		return err
+	}
+	return remote.Write(ref.Context().Digest(digest.String()), artifact, options...)
+}
+
+// Fetch returns the SBOM attached to the specified image as well as its format.
+func Fetch(image string, options ...remote.Option) ([]byte, Format, error) {
+	ref, err := name.ParseReference(image)
+	if err != nil {
+		return nil, "", err
+	}
+	subject, err := remote.Head(ref, options...)
+	if err != nil {
+		return nil, "", errors.Wrapf(err, "unable to find image %q", image)
+	}
+
+	referrers, err := remote.Referrers(ref.Context().Digest(subject.Digest.String()), options...)
+	if err != nil {
+		return nil, "", errors.Wrapf(err, "unable to get the artifacts referring to image %q", image)
+	}
+	index, err := referrers.IndexManifest()
+	if err != nil {
+		return nil, "", err
+	}
+
+	for _, desc := range index.Manifests {
+		format := formatFromMediaType(desc.ArtifactType)
+		if format == "" {
+			continue
+		}
+		artifact, err := remote.Image(ref.Context().Digest(desc.Digest.String()), options...)
+		if err != nil {
+			return nil, "", err
+		}
+		layers, err := artifact.Layers()
+		if err != nil {
+			return nil, "", err
+		}
+		if len(layers) != 1 {
+			return nil, "", errors.Errorf("the SBOM artifact of image %q is required to have only 1 layer, but found %v", image, len(layers))
+		}
+		reader, err := layers[0].Uncompressed()
+		if err != nil {
+			return nil, "", err
+		}
+		defer reader.Close()
+
+		content, err := io.ReadAll(reader)
+		if err != nil {
+			return nil, "", err
+		}
+		return content, format, nil
+	}
+	return nil, "", errors.Errorf("no SBOM is attached to image %q", image)
+}
+
+// RemoteOptions returns the options to access the registry of the specified image,
+// taking into account the certificate configuration of that registry and whether
+// it requires authentication.
+func RemoteOptions(image string) ([]remote.Option, error) {
+	registryHost, err := registry.GetRegistryName(image)
+	if err != nil {
+		return nil, err
+	}
+	certOptions, err := registry.GetRegistryCertOptions(registryHost)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to get the registry certificate configuration")
+	}
+
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		return nil, err
+	}
+	for _, path := range certOptions.CACertPaths {
+		certs, err := os.ReadFile(path)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed reading CA certificates from '%s'", path)
+		}
+		if ok := pool.AppendCertsFromPEM(certs); !ok {
+			return nil, errors.Errorf("failed adding CA certificates from '%s'", path)
+		}
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// #nosec G402
+	transport.TLSClientConfig = &tls.Config{
+		RootCAs:            pool,
+		InsecureSkipVerify: certOptions.SkipCertVerify,
+	}
+
+	options := []remote.Option{remote.WithTransport(transport)}
+	authenticatedRegistries := strings.Split(os.Getenv(constants.AuthenticatedRegistry), ",")
+	if utils.ContainsRegistry(authenticatedRegistries, registryHost) {
+		options = append(options, remote.WithAuthFromKeychain(authn.DefaultKeychain))
+	}
+	return options, nil
+}
@@ -0,0 +1,57 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package sbom
+
+import (
+	"fmt"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	gocontainerregistry "github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAttachAndFetch(t *testing.T) {
+	assertions := assert.New(t)
+
+	server := httptest.NewServer(gocontainerregistry.New())
+	defer server.Close()
+	u, err := url.Parse(server.URL)
+	assertions.Nil(err)
+
+	image := fmt.Sprintf("%s/vmware/tkg/linux/amd64/global/foo:v1.0.0", u.Host)
+	ref, err := name.ParseReference(image)
+	assertions.Nil(err)
+	img, err := random.Image(1024, 1)
+	assertions.Nil(err)
+	assertions.Nil(remote.Write(ref, img))
+
+	// No SBOM attached yet
+	_, _, err = Fetch(image)
+	assertions.NotNil(err)
+	assertions.Contains(err.Error(), "no SBOM is attached to image")
+
+	content := []byte(`{"spdxVersion":"SPDX-2.3"}`)
+	assertions.Nil(Attach(image, content, FormatSPDX))
+
+	fetched, format, err := Fetch(image)
+	assertions.Nil(err)
+	assertions.Equal(FormatSPDX, format)
+	assertions.Equal(content, fetched)
+
+	// The image itself is unchanged
+	digest, err := img.Digest()
+	assertions.Nil(err)
+	desc, err := remote.Head(ref)
+	assertions.Nil(err)
+	assertions.Equal(digest, desc.Digest)
+
+	// Attaching to a missing image fails
+	err = Attach(fmt.Sprintf("%s/vmware/tkg/missing:v1.0.0", u.Host), content, FormatSPDX)
+	assertions.NotNil(err)
+}
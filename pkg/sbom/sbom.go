@@ -0,0 +1,123 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package sbom generates, publishes and retrieves the Software Bill of Materials (SBOM)
+// of plugin binaries.
+package sbom
+
+import (
+	"crypto/sha256"
+	"debug/buildinfo"
+	"fmt"
+	"os"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Format is the format of an SBOM.
+type Format string
+
+const (
+	// FormatSPDX is the SPDX format in JSON.
+	FormatSPDX Format = "spdx"
+	// FormatCycloneDX is the CycloneDX format in JSON.
+	FormatCycloneDX Format = "cyclonedx"
+
+	// MediaTypeSPDX is the media type of SPDX SBOMs.
+	MediaTypeSPDX = "application/spdx+json"
+	// MediaTypeCycloneDX is the media type of CycloneDX SBOMs.
+	MediaTypeCycloneDX = "application/vnd.cyclonedx+json"
+
+	toolName = "tanzu-builder"
+)
+
+// SupportedFormats are the SBOM formats that can be generated.
+var SupportedFormats = []Format{FormatSPDX, FormatCycloneDX}
+
+// ParseFormat returns the Format matching the specified string.
+func ParseFormat(format string) (Format, error) {
+	for _, f := range SupportedFormats {
+		if string(f) == strings.ToLower(format) {
+			return f, nil
+		}
+	}
+	return "", errors.Errorf("unsupported SBOM format %q, must be one of: %s, %s", format, FormatSPDX, FormatCycloneDX)
+}
+
+// formatFromMediaType returns the Format of the specified media type or
+// an empty Format if the media type is not one of an SBOM.
+func formatFromMediaType(mediaType string) Format {
+	for _, f := range SupportedFormats {
+		if f.MediaType() == mediaType {
+			return f
+		}
+	}
+	return ""
+}
+
+// MediaType returns the media type of the SBOM format.
+func (f Format) MediaType() string {
+	if f == FormatCycloneDX {
+		return MediaTypeCycloneDX
+	}
+	return MediaTypeSPDX
+}
+
+// FileName returns the name of the file in which an SBOM of this format is stored.
+func (f Format) FileName() string {
+	if f == FormatCycloneDX {
+		return "sbom.cdx.json"
+	}
+	return "sbom.spdx.json"
+}
+
+// component is a Go module included in a binary.
+type component struct {
+	path    string
+	version string
+}
+
+func (c component) purl() string {
+	return fmt.Sprintf("pkg:golang/%s@%s", c.path, c.version)
+}
+
+// Generate returns the SBOM of a Go binary in the specified format.
+// The SBOM lists the Go modules as well as the Go standard library the binary
+// was built from.  The 'created' time is stored in the SBOM as its creation time.
+func Generate(binaryPath, name, version string, format Format, created time.Time) ([]byte, error) {
+	info, err := buildinfo.ReadFile(binaryPath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to read the build information of %q", binaryPath)
+	}
+
+	binary, err := os.ReadFile(binaryPath)
+	if err != nil {
+		return nil, err
+	}
+	digest := fmt.Sprintf("%x", sha256.Sum256(binary))
+
+	main := component{path: info.Main.Path, version: version}
+	deps := []component{{path: "stdlib", version: info.GoVersion}}
+	for _, dep := range info.Deps {
+		deps = append(deps, moduleComponent(dep))
+	}
+
+	switch format {
+	case FormatSPDX:
+		return generateSPDX(name, digest, main, deps, created)
+	case FormatCycloneDX:
+		return generateCycloneDX(name, main, deps, created)
+	}
+	return nil, errors.Errorf("unsupported SBOM format %q", format)
+}
+
+// moduleComponent returns the component of a module, taking any replacement into account.
+func moduleComponent(m *debug.Module) component {
+	if m.Replace != nil {
+		m = m.Replace
+	}
+	return component{path: m.Path, version: m.Version}
+}
@@ -0,0 +1,88 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package sbom
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseFormat(t *testing.T) {
+	assertions := assert.New(t)
+
+	format, err := ParseFormat("spdx")
+	assertions.Nil(err)
+	assertions.Equal(FormatSPDX, format)
+
+	format, err = ParseFormat("CycloneDX")
+	assertions.Nil(err)
+	assertions.Equal(FormatCycloneDX, format)
+
+	_, err = ParseFormat("swid")
+	assertions.NotNil(err)
+	assertions.Contains(err.Error(), "unsupported SBOM format \"swid\"")
+}
+
+func TestFormatFromMediaType(t *testing.T) {
+	assertions := assert.New(t)
+
+	assertions.Equal(FormatSPDX, formatFromMediaType(MediaTypeSPDX))
+	assertions.Equal(FormatCycloneDX, formatFromMediaType(MediaTypeCycloneDX))
+	assertions.Equal(Format(""), formatFromMediaType("application/vnd.oci.image.config.v1+json"))
+}
+
+func TestGenerate(t *testing.T) {
+	assertions := assert.New(t)
+
+	// The test binary is itself a Go binary containing build information
+	binaryPath, err := os.Executable()
+	assertions.Nil(err)
+	created := time.Unix(1700000000, 0)
+
+	b, err := Generate(binaryPath, "foo", "v1.0.0", FormatSPDX, created)
+	assertions.Nil(err)
+	var spdx spdxDocument
+	assertions.Nil(json.Unmarshal(b, &spdx))
+	assertions.Equal("SPDX-2.3", spdx.SPDXVersion)
+	assertions.Equal("foo-v1.0.0", spdx.Name)
+	assertions.Equal("2023-11-14T22:13:20Z", spdx.CreationInfo.Created)
+	assertions.Equal("v1.0.0", spdx.Packages[0].VersionInfo)
+	assertions.Equal("stdlib", spdx.Packages[1].Name)
+	assertions.Equal(len(spdx.Packages), len(spdx.Relationships))
+	assertions.Contains(packageNames(spdx), "github.com/stretchr/testify")
+
+	// Generating the SBOM of the same binary gives the same result
+	b2, err := Generate(binaryPath, "foo", "v1.0.0", FormatSPDX, created)
+	assertions.Nil(err)
+	assertions.Equal(b, b2)
+
+	b, err = Generate(binaryPath, "foo", "v1.0.0", FormatCycloneDX, created)
+	assertions.Nil(err)
+	var cdx cycloneDXDocument
+	assertions.Nil(json.Unmarshal(b, &cdx))
+	assertions.Equal("CycloneDX", cdx.BOMFormat)
+	assertions.Equal("foo", cdx.Metadata.Component.Name)
+	assertions.Equal("2023-11-14T22:13:20Z", cdx.Metadata.Timestamp)
+	assertions.Equal(len(cdx.Components), len(cdx.Dependencies[0].DependsOn))
+	assertions.Equal("stdlib", cdx.Components[0].Name)
+
+	// A file which is not a Go binary has no SBOM
+	notBinary := filepath.Join(t.TempDir(), "not-a-binary")
+	assertions.Nil(os.WriteFile(notBinary, []byte("not a binary"), 0600))
+	_, err = Generate(notBinary, "foo", "v1.0.0", FormatSPDX, created)
+	assertions.NotNil(err)
+}
+
+func packageNames(doc spdxDocument) []string {
+	var names []string
+	for _, p := range doc.Packages {
+		names = append(names, p.Name)
+	}
+	return names
+}
@@ -0,0 +1,122 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package selfupdate
+
+import (
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/utils"
+)
+
+// InstallMethod represents the way the Tanzu CLI was installed on the machine
+type InstallMethod string
+
+const (
+	// InstallMethodDirect means the CLI binary was installed by the user directly
+	// and can therefore be replaced in place
+	InstallMethodDirect InstallMethod = "direct"
+	// InstallMethodHomebrew means the CLI binary is managed by Homebrew
+	InstallMethodHomebrew InstallMethod = "homebrew"
+	// InstallMethodChocolatey means the CLI binary is managed by Chocolatey
+	InstallMethodChocolatey InstallMethod = "chocolatey"
+	// InstallMethodApt means the CLI binary is managed by the apt/deb package manager
+	InstallMethodApt InstallMethod = "apt"
+	// InstallMethodYum means the CLI binary is managed by the yum/dnf/rpm package manager
+	InstallMethodYum InstallMethod = "yum"
+)
+
+const (
+	// linuxPackageBinaryDir is where the deb and rpm packages install the CLI binary
+	linuxPackageBinaryDir = "/usr/bin"
+	// dpkgInfoDir is where dpkg keeps the list of files installed by each package
+	dpkgInfoDir = "/var/lib/dpkg/info"
+	// yumRepoFile is the repository file the installation instructions ask yum users to create
+	yumRepoFile = "/etc/yum.repos.d/tanzu-cli.repo"
+	// installInstructionsURL is where the instructions to download and install the CLI are published
+	installInstructionsURL = "https://github.com/vmware-tanzu/tanzu-cli/blob/main/docs/quickstart/install.md"
+)
+
+// debPackageNames are the names of the deb packages that can provide the CLI
+var debPackageNames = []string{"tanzu-cli", "tanzu-cli-unstable"}
+
+// DetectInstallMethod returns the method that was used to install the CLI
+// binary located at the specified path.  The detection is based on the
+// well-known installation directories of the supported package managers.
+func DetectInstallMethod(executablePath string) InstallMethod {
+	resolvedPath, err := filepath.EvalSymlinks(executablePath)
+	if err != nil {
+		resolvedPath = executablePath
+	}
+	return detectInstallMethod(runtime.GOOS, resolvedPath, utils.PathExists)
+}
+
+// detectInstallMethod implements DetectInstallMethod for the specified OS.
+// The pathExists function is used to check for the package manager metadata.
+func detectInstallMethod(goos, executablePath string, pathExists func(string) bool) InstallMethod {
+	// Normalize the path so the same matching works for every OS
+	normalizedPath := strings.ToLower(strings.ReplaceAll(executablePath, `\`, "/"))
+
+	switch goos {
+	case "windows":
+		if strings.Contains(normalizedPath, "/chocolatey/") {
+			return InstallMethodChocolatey
+		}
+	case "darwin", "linux":
+		if strings.Contains(normalizedPath, "/cellar/") ||
+			strings.Contains(normalizedPath, "/homebrew/") ||
+			strings.Contains(normalizedPath, "/linuxbrew/") {
+			return InstallMethodHomebrew
+		}
+		if goos == "linux" && filepath.Dir(normalizedPath) == linuxPackageBinaryDir {
+			for _, pkg := range debPackageNames {
+				if pathExists(filepath.Join(dpkgInfoDir, pkg+".list")) {
+					return InstallMethodApt
+				}
+			}
+			if pathExists(yumRepoFile) {
+				return InstallMethodYum
+			}
+		}
+	}
+	return InstallMethodDirect
+}
+
+// IsManaged returns true if the CLI binary is managed by a package manager
+// and therefore must not be replaced in place.
+func (m InstallMethod) IsManaged() bool {
+	return m != InstallMethodDirect
+}
+
+// UpgradeInstructions returns what the user should do to upgrade the CLI for this
+// installation method: the exact command to run if there is one, otherwise where
+// to find the instructions to download the CLI.
+func (m InstallMethod) UpgradeInstructions() string {
+	if command := m.UpgradeCommand(); command != "" {
+		return "run: " + command
+	}
+	return "follow the download instructions at " + installInstructionsURL
+}
+
+// UpgradeCommand returns the exact command the user should run to upgrade the CLI
+// for this installation method.  An empty string is returned if the CLI was installed
+// directly and the central configuration does not provide the location of the
+// binaries that "tanzu update" needs.
+func (m InstallMethod) UpgradeCommand() string {
+	switch m {
+	case InstallMethodHomebrew:
+		return "brew update && brew upgrade tanzu-cli"
+	case InstallMethodChocolatey:
+		return "choco upgrade tanzu-cli"
+	case InstallMethodApt:
+		return "sudo apt update && sudo apt upgrade -y tanzu-cli"
+	case InstallMethodYum:
+		return "sudo yum update -y tanzu-cli"
+	}
+	if IsSelfUpdateConfigured() {
+		return "tanzu update"
+	}
+	return ""
+}
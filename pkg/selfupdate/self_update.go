@@ -0,0 +1,230 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package selfupdate implements the logic to update the Tanzu CLI
+// binary in place to a recommended version.
+package selfupdate
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"text/template"
+
+	"github.com/pkg/errors"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/artifact"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/centralconfig"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/cosignhelper/sigverifier"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/utils"
+)
+
+// centralConfigCLIBinaryLocationKey is the central configuration key under which
+// the location of the CLI binaries, of their checksums and of the signature of
+// the checksums are published.
+const centralConfigCLIBinaryLocationKey = "cli.core.cli_binary_location"
+
+// BinaryLocation describes where the CLI binaries of a given version can be downloaded.
+// The URLs are go templates which can use the {{.Version}}, {{.OS}} and {{.Arch}} fields.
+// It is the format that must be stored in the central configuration.
+type BinaryLocation struct {
+	// BinaryURL is the URL of the CLI binary itself
+	BinaryURL string `yaml:"binaryURL" json:"binaryURL"`
+	// ChecksumsURL is the URL of a file containing the sha256 checksums of the binaries
+	// in the format "<sha256>  <binary file name>", one binary per line
+	ChecksumsURL string `yaml:"checksumsURL" json:"checksumsURL"`
+	// SignatureURL is the URL of the base64 encoded cosign signature of the checksums
+	// file, as produced by "cosign sign-blob"
+	SignatureURL string `yaml:"signatureURL" json:"signatureURL"`
+}
+
+// verifySignature verifies the signature of the checksums file
+var verifySignature = sigverifier.VerifyCLIBinarySignature
+
+// urlTemplateValues are the values available to the BinaryLocation URL templates
+type urlTemplateValues struct {
+	Version string
+	OS      string
+	Arch    string
+}
+
+// UpdateOptions are the options used to update the CLI
+type UpdateOptions struct {
+	// Version is the version of the CLI to update to
+	Version string
+	// ExecutablePath is the path of the CLI binary to replace
+	ExecutablePath string
+	// Out is where progress messages are printed
+	Out io.Writer
+}
+
+// UpdateCLI downloads the specified version of the CLI for the current platform,
+// verifies the signature of the published checksums and the digest of the binary, and replaces the CLI executable in place.
+// If the CLI is managed by a package manager, the executable is not replaced and
+// an error providing the proper upgrade instructions is returned instead.
+func UpdateCLI(o *UpdateOptions) error {
+	if o.Version == "" {
+		return errors.New("no version specified for the update")
+	}
+
+	method := DetectInstallMethod(o.ExecutablePath)
+	if method.IsManaged() {
+		return errors.Errorf("the Tanzu CLI is managed by %s and cannot be updated in place. Please run: %s", method, method.UpgradeCommand())
+	}
+
+	location, err := getBinaryLocation()
+	if err != nil {
+		return err
+	}
+
+	values := urlTemplateValues{Version: o.Version, OS: runtime.GOOS, Arch: runtime.GOARCH}
+	binaryURL, err := renderURL(location.BinaryURL, values)
+	if err != nil {
+		return err
+	}
+	checksumsURL, err := renderURL(location.ChecksumsURL, values)
+	if err != nil {
+		return err
+	}
+	signatureURL, err := renderURL(location.SignatureURL, values)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(o.Out, "Downloading the Tanzu CLI %s from %s\n", o.Version, binaryURL)
+	binary, err := fetch(binaryURL)
+	if err != nil {
+		return errors.Wrapf(err, "unable to download the Tanzu CLI %s", o.Version)
+	}
+	checksums, err := fetch(checksumsURL)
+	if err != nil {
+		return errors.Wrapf(err, "unable to download the checksums of the Tanzu CLI %s", o.Version)
+	}
+	signature, err := fetch(signatureURL)
+	if err != nil {
+		return errors.Wrapf(err, "unable to download the signature of the checksums of the Tanzu CLI %s", o.Version)
+	}
+
+	// The checksums must be trusted before being used to verify the binary
+	if err := verifySignature(checksums, signature); err != nil {
+		return err
+	}
+	if err := verifyDigest(binary, checksums, path.Base(binaryURL)); err != nil {
+		return err
+	}
+
+	if err := replaceExecutable(o.ExecutablePath, binary); err != nil {
+		return errors.Wrapf(err, "unable to replace the Tanzu CLI executable %q", o.ExecutablePath)
+	}
+	fmt.Fprintf(o.Out, "The Tanzu CLI has been updated to version %s\n", o.Version)
+	return nil
+}
+
+// IsSelfUpdateConfigured returns true if the default central configuration provides
+// the location of the CLI binaries, without which the CLI cannot update itself
+func IsSelfUpdateConfigured() bool {
+	_, err := getBinaryLocation()
+	return err == nil
+}
+
+// getBinaryLocation reads the location of the CLI binaries from the default central configuration
+func getBinaryLocation() (*BinaryLocation, error) {
+	location := &BinaryLocation{}
+	if !centralconfig.GetObject(centralConfigCLIBinaryLocationKey, location) {
+		return nil, errors.New("unable to find the location of the Tanzu CLI binaries in the central configuration")
+	}
+	if location.BinaryURL == "" || location.ChecksumsURL == "" || location.SignatureURL == "" {
+		return nil, errors.Errorf("the central configuration entry %q is incomplete", centralConfigCLIBinaryLocationKey)
+	}
+	return location, nil
+}
+
+// renderURL renders the specified URL template using the specified values
+func renderURL(urlTemplate string, values urlTemplateValues) (string, error) {
+	tmpl, err := template.New("url").Option("missingkey=error").Parse(urlTemplate)
+	if err != nil {
+		return "", errors.Wrapf(err, "invalid URL template %q", urlTemplate)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, values); err != nil {
+		return "", errors.Wrapf(err, "invalid URL template %q", urlTemplate)
+	}
+	return buf.String(), nil
+}
+
+func fetch(uri string) ([]byte, error) {
+	a, err := artifact.NewURIArtifact(uri)
+	if err != nil {
+		return nil, err
+	}
+	return a.Fetch()
+}
+
+// verifyDigest verifies that the SHA256 digest of the binary matches the
+// digest published for the specified file name in the checksums content
+func verifyDigest(binary, checksums []byte, fileName string) error {
+	expectedDigest := ""
+	scanner := bufio.NewScanner(bytes.NewReader(checksums))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		// Some tools prefix the file name with '*' to indicate binary mode
+		if len(fields) == 2 && strings.TrimPrefix(fields[1], "*") == fileName {
+			expectedDigest = strings.ToLower(fields[0])
+			break
+		}
+	}
+	if expectedDigest == "" {
+		return errors.Errorf("no checksum published for %q", fileName)
+	}
+
+	actualDigest := fmt.Sprintf("%x", sha256.Sum256(binary))
+	if actualDigest != expectedDigest {
+		return errors.Errorf("the downloaded binary %q has been corrupted. expected digest: %s, actual digest: %s", fileName, expectedDigest, actualDigest)
+	}
+	return nil
+}
+
+// replaceExecutable replaces the executable at the specified path with the new binary.
+// The new binary is first written next to the executable so that the final rename
+// is done within the same file system.
+func replaceExecutable(executablePath string, binary []byte) error {
+	info, err := os.Stat(executablePath)
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Dir(executablePath)
+	newFile := filepath.Join(dir, "."+filepath.Base(executablePath)+".new")
+	if err := os.WriteFile(newFile, binary, info.Mode().Perm()|0o100); err != nil {
+		return err
+	}
+
+	// Windows does not allow replacing a running executable but does allow renaming it
+	oldFile := filepath.Join(dir, "."+filepath.Base(executablePath)+".old")
+	_ = os.Remove(oldFile)
+	if err := os.Rename(executablePath, oldFile); err != nil {
+		_ = os.Remove(newFile)
+		return err
+	}
+
+	if err := os.Rename(newFile, executablePath); err != nil {
+		// Restore the original executable
+		_ = os.Rename(oldFile, executablePath)
+		_ = os.Remove(newFile)
+		return err
+	}
+
+	// This will fail on Windows as the old executable is still running; it will
+	// be cleaned up on the next update
+	if utils.PathExists(oldFile) {
+		_ = os.Remove(oldFile)
+	}
+	return nil
+}
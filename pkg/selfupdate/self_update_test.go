@@ -0,0 +1,179 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package selfupdate
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/centralconfig"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/centralconfig/fakes"
+)
+
+func TestDetectInstallMethod(t *testing.T) {
+	tests := []struct {
+		name          string
+		goos          string
+		path          string
+		existingPaths []string
+		expected      InstallMethod
+	}{
+		{name: "homebrew on arm64 mac", goos: "darwin", path: "/opt/homebrew/Cellar/tanzu-cli/1.5.0/bin/tanzu", expected: InstallMethodHomebrew},
+		{name: "homebrew on amd64 mac", goos: "darwin", path: "/usr/local/Cellar/tanzu-cli/1.5.0/bin/tanzu", expected: InstallMethodHomebrew},
+		{name: "direct download on mac", goos: "darwin", path: "/usr/local/bin/tanzu", expected: InstallMethodDirect},
+		{name: "homebrew on linux", goos: "linux", path: "/home/linuxbrew/.linuxbrew/bin/tanzu", expected: InstallMethodHomebrew},
+		{name: "apt on linux", goos: "linux", path: "/usr/bin/tanzu", existingPaths: []string{"/var/lib/dpkg/info/tanzu-cli.list"}, expected: InstallMethodApt},
+		{name: "apt pre-release on linux", goos: "linux", path: "/usr/bin/tanzu", existingPaths: []string{"/var/lib/dpkg/info/tanzu-cli-unstable.list"}, expected: InstallMethodApt},
+		{name: "yum on linux", goos: "linux", path: "/usr/bin/tanzu", existingPaths: []string{"/etc/yum.repos.d/tanzu-cli.repo"}, expected: InstallMethodYum},
+		{name: "direct download in /usr/bin on linux", goos: "linux", path: "/usr/bin/tanzu", expected: InstallMethodDirect},
+		{name: "direct download on linux", goos: "linux", path: "/usr/local/bin/tanzu", existingPaths: []string{"/var/lib/dpkg/info/tanzu-cli.list"}, expected: InstallMethodDirect},
+		{name: "chocolatey on windows", goos: "windows", path: `C:\ProgramData\chocolatey\bin\tanzu.exe`, expected: InstallMethodChocolatey},
+		{name: "direct download on windows", goos: "windows", path: `C:\Program Files\tanzu\tanzu.exe`, expected: InstallMethodDirect},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pathExists := func(p string) bool {
+				for _, existing := range tt.existingPaths {
+					if filepath.ToSlash(p) == existing {
+						return true
+					}
+				}
+				return false
+			}
+			assert.Equal(t, tt.expected, detectInstallMethod(tt.goos, tt.path, pathExists))
+		})
+	}
+}
+
+func TestUpgradeCommand(t *testing.T) {
+	originalReader := centralconfig.DefaultCentralConfigReader
+	defer func() { centralconfig.DefaultCentralConfigReader = originalReader }()
+
+	// "tanzu update" is only suggested if the location of the binaries is configured
+	fakeCentralConfig := &fakes.CentralConfig{}
+	fakeCentralConfig.GetCentralConfigEntryReturns(&centralconfig.KeyNotFoundError{Key: centralConfigCLIBinaryLocationKey})
+	centralconfig.DefaultCentralConfigReader = fakeCentralConfig
+	assert.Equal(t, "", InstallMethodDirect.UpgradeCommand())
+	assert.Equal(t, "follow the download instructions at "+installInstructionsURL, InstallMethodDirect.UpgradeInstructions())
+
+	fakeCentralConfig = &fakes.CentralConfig{}
+	fakeCentralConfig.GetCentralConfigEntryStub = func(key string, out interface{}) error {
+		location := out.(*BinaryLocation)
+		location.BinaryURL = "https://example.com/{{.Version}}/tanzu-cli-{{.OS}}_{{.Arch}}"
+		location.ChecksumsURL = "https://example.com/{{.Version}}/checksums.txt"
+		location.SignatureURL = "https://example.com/{{.Version}}/checksums.txt.sig"
+		return nil
+	}
+	centralconfig.DefaultCentralConfigReader = fakeCentralConfig
+	assert.Equal(t, "tanzu update", InstallMethodDirect.UpgradeCommand())
+	assert.Equal(t, "run: tanzu update", InstallMethodDirect.UpgradeInstructions())
+
+	assert.Equal(t, "run: choco upgrade tanzu-cli", InstallMethodChocolatey.UpgradeInstructions())
+	assert.Equal(t, "brew update && brew upgrade tanzu-cli", InstallMethodHomebrew.UpgradeCommand())
+	assert.Equal(t, "choco upgrade tanzu-cli", InstallMethodChocolatey.UpgradeCommand())
+	assert.Equal(t, "sudo apt update && sudo apt upgrade -y tanzu-cli", InstallMethodApt.UpgradeCommand())
+	assert.Equal(t, "sudo yum update -y tanzu-cli", InstallMethodYum.UpgradeCommand())
+	assert.False(t, InstallMethodDirect.IsManaged())
+	assert.True(t, InstallMethodApt.IsManaged())
+}
+
+func TestRenderURL(t *testing.T) {
+	values := urlTemplateValues{Version: "v1.5.0", OS: "linux", Arch: "amd64"}
+
+	url, err := renderURL("https://example.com/{{.Version}}/tanzu-cli-{{.OS}}_{{.Arch}}", values)
+	assert.NoError(t, err)
+	assert.Equal(t, "https://example.com/v1.5.0/tanzu-cli-linux_amd64", url)
+
+	_, err = renderURL("https://example.com/{{.Version", values)
+	assert.Error(t, err)
+}
+
+func TestVerifyDigest(t *testing.T) {
+	binary := []byte("tanzu binary")
+	digest := fmt.Sprintf("%x", sha256.Sum256(binary))
+
+	checksums := []byte(fmt.Sprintf("0123  tanzu-cli-darwin_amd64\n%s  tanzu-cli-linux_amd64\n", digest))
+	assert.NoError(t, verifyDigest(binary, checksums, "tanzu-cli-linux_amd64"))
+
+	err := verifyDigest(binary, checksums, "tanzu-cli-darwin_amd64")
+	assert.ErrorContains(t, err, "corrupted")
+
+	err = verifyDigest(binary, checksums, "tanzu-cli-windows_amd64.exe")
+	assert.ErrorContains(t, err, "no checksum published")
+}
+
+func TestUpdateCLI(t *testing.T) {
+	dir, err := os.MkdirTemp("", "selfupdate")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	// Publish a new binary and its checksum in a local directory
+	newBinary := []byte("new tanzu binary")
+	binaryName := fmt.Sprintf("tanzu-cli-%s_%s", runtime.GOOS, runtime.GOARCH)
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "v1.5.0"), 0o755))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "v1.5.0", binaryName), newBinary, 0o644))
+	checksums := fmt.Sprintf("%x  %s\n", sha256.Sum256(newBinary), binaryName)
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "v1.5.0", "checksums.txt"), []byte(checksums), 0o644))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "v1.5.0", "checksums.txt.sig"), []byte("signature"), 0o644))
+
+	executable := filepath.Join(dir, "tanzu")
+	assert.NoError(t, os.WriteFile(executable, []byte("old tanzu binary"), 0o755))
+
+	fakeCentralConfig := &fakes.CentralConfig{}
+	fakeCentralConfig.GetCentralConfigEntryStub = func(key string, out interface{}) error {
+		location := out.(*BinaryLocation)
+		location.BinaryURL = filepath.Join(dir, "{{.Version}}", "tanzu-cli-{{.OS}}_{{.Arch}}")
+		location.ChecksumsURL = filepath.Join(dir, "{{.Version}}", "checksums.txt")
+		location.SignatureURL = filepath.Join(dir, "{{.Version}}", "checksums.txt.sig")
+		return nil
+	}
+	originalReader := centralconfig.DefaultCentralConfigReader
+	centralconfig.DefaultCentralConfigReader = fakeCentralConfig
+	defer func() { centralconfig.DefaultCentralConfigReader = originalReader }()
+
+	originalVerifySignature := verifySignature
+	defer func() { verifySignature = originalVerifySignature }()
+	signatureValid := false
+	verifySignature = func(checksumsContent, signature []byte) error {
+		assert.Equal(t, []byte(checksums), checksumsContent)
+		assert.Equal(t, []byte("signature"), signature)
+		if !signatureValid {
+			return errors.New("invalid signature")
+		}
+		return nil
+	}
+
+	// The executable must be left untouched if the signature cannot be verified
+	var out bytes.Buffer
+	err = UpdateCLI(&UpdateOptions{Version: "v1.5.0", ExecutablePath: executable, Out: &out})
+	assert.ErrorContains(t, err, "invalid signature")
+	content, err := os.ReadFile(executable)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("old tanzu binary"), content)
+
+	signatureValid = true
+	err = UpdateCLI(&UpdateOptions{Version: "v1.5.0", ExecutablePath: executable, Out: &out})
+	assert.NoError(t, err)
+	assert.Contains(t, out.String(), "updated to version v1.5.0")
+
+	content, err = os.ReadFile(executable)
+	assert.NoError(t, err)
+	assert.Equal(t, newBinary, content)
+
+	// A version without published binaries must leave the executable untouched
+	err = UpdateCLI(&UpdateOptions{Version: "v9.9.9", ExecutablePath: executable, Out: &out})
+	assert.Error(t, err)
+	content, err = os.ReadFile(executable)
+	assert.NoError(t, err)
+	assert.Equal(t, newBinary, content)
+}
@@ -0,0 +1,217 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/interfaces"
+)
+
+const (
+	defaultDownloadTimeout       = 120 * time.Second
+	defaultDownloadRetryInterval = 2 * time.Second
+	partialDownloadSuffix        = ".part"
+)
+
+// DownloadOptions configures the download of a file
+type DownloadOptions struct {
+	// HTTPClient is the client used for the requests, http.DefaultClient if not set
+	HTTPClient interfaces.HTTPClient
+	// Timeout is the timeout of each download attempt, 120 seconds if not set
+	Timeout time.Duration
+	// Retries is the number of times a failed download is retried
+	Retries int
+	// RetryInterval is the time to wait before retrying, 2 seconds if not set
+	RetryInterval time.Duration
+	// SHA256 is the expected sha256 checksum of the file, not verified if empty
+	SHA256 string
+	// Progress is called with the number of bytes downloaded so far and the
+	// total size of the file, which is -1 if unknown
+	Progress func(downloaded, total int64)
+}
+
+// httpStatusError is returned when the server does not answer with the expected status code
+type httpStatusError struct {
+	url        string
+	statusCode int
+}
+
+func (e *httpStatusError) Error() string {
+	return fmt.Sprintf("error while downloading %q; received status code: %d", e.url, e.statusCode)
+}
+
+// DownloadFile downloads the file at the url to the destination path
+func DownloadFile(url, destPath string) error {
+	return DownloadFileWithContext(context.Background(), url, destPath, nil)
+}
+
+// DownloadFileWithContext downloads the file at the url to the destination path.
+// Failed downloads are retried as configured by the options and resume from the
+// data already downloaded when the server supports Range requests.
+// The destination file is only created once the complete file is downloaded
+// and its checksum, if specified, is verified.
+func DownloadFileWithContext(ctx context.Context, url, destPath string, opts *DownloadOptions) error {
+	o := DownloadOptions{}
+	if opts != nil {
+		o = *opts
+	}
+	if o.HTTPClient == nil {
+		o.HTTPClient = http.DefaultClient
+	}
+	if o.Timeout == 0 {
+		o.Timeout = defaultDownloadTimeout
+	}
+	if o.RetryInterval == 0 {
+		o.RetryInterval = defaultDownloadRetryInterval
+	}
+
+	if err := os.MkdirAll(filepath.Dir(destPath), os.ModePerm); err != nil {
+		return err
+	}
+	partPath := destPath + partialDownloadSuffix
+	if err := os.Remove(partPath); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	defer os.Remove(partPath)
+
+	var err error
+	for attempt := 0; attempt <= o.Retries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(o.RetryInterval):
+			}
+		}
+		err = downloadAttempt(ctx, url, partPath, &o)
+		if err == nil || ctx.Err() != nil || !isRetryableDownloadError(err) {
+			break
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	if o.SHA256 != "" {
+		checksum, err := fileSHA256(partPath)
+		if err != nil {
+			return err
+		}
+		if checksum != o.SHA256 {
+			return errors.Errorf("the sha256 checksum of %q is %q but %q was expected", url, checksum, o.SHA256)
+		}
+	}
+	return os.Rename(partPath, destPath)
+}
+
+// downloadAttempt downloads the file, resuming from the content of the
+// partially downloaded file if any
+func downloadAttempt(ctx context.Context, url, partPath string, o *DownloadOptions) error {
+	ctx, cancel := context.WithTimeout(ctx, o.Timeout)
+	defer cancel()
+
+	var offset int64
+	if info, err := os.Stat(partPath); err == nil {
+		offset = info.Size()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, http.NoBody)
+	if err != nil {
+		return err
+	}
+	if offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
+	res, err := o.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	flags := os.O_CREATE | os.O_WRONLY
+	switch res.StatusCode {
+	case http.StatusPartialContent:
+		flags |= os.O_APPEND
+	case http.StatusOK:
+		// The server does not support Range requests, start over
+		flags |= os.O_TRUNC
+		offset = 0
+	case http.StatusRequestedRangeNotSatisfiable:
+		// The partial content cannot be resumed, start over on the next attempt
+		if err := os.Remove(partPath); err != nil {
+			return err
+		}
+		return &httpStatusError{url: url, statusCode: res.StatusCode}
+	default:
+		return &httpStatusError{url: url, statusCode: res.StatusCode}
+	}
+
+	f, err := os.OpenFile(partPath, flags, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	total := int64(-1)
+	if res.ContentLength >= 0 {
+		total = offset + res.ContentLength
+	}
+	var w io.Writer = f
+	if o.Progress != nil {
+		w = &progressWriter{writer: f, downloaded: offset, total: total, progress: o.Progress}
+	}
+	_, err = io.Copy(w, res.Body)
+	return err
+}
+
+// isRetryableDownloadError returns false for errors which are not expected
+// to be resolved by retrying the download
+func isRetryableDownloadError(err error) bool {
+	var statusErr *httpStatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.statusCode >= http.StatusInternalServerError ||
+			statusErr.statusCode == http.StatusTooManyRequests ||
+			statusErr.statusCode == http.StatusRequestedRangeNotSatisfiable
+	}
+	return true
+}
+
+func fileSHA256(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", h.Sum(nil)), nil
+}
+
+// progressWriter reports the progress of the data written
+type progressWriter struct {
+	writer     io.Writer
+	downloaded int64
+	total      int64
+	progress   func(downloaded, total int64)
+}
+
+func (pw *progressWriter) Write(p []byte) (int, error) {
+	n, err := pw.writer.Write(p)
+	pw.downloaded += int64(n)
+	pw.progress(pw.downloaded, pw.total)
+	return n, err
+}
@@ -0,0 +1,135 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const downloadContent = "0123456789abcdefghijklmnopqrstuvwxyz"
+
+func downloadChecksum() string {
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(downloadContent)))
+}
+
+func TestDownloadFile(t *testing.T) {
+	assert := assert.New(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(downloadContent))
+	}))
+	defer server.Close()
+
+	destPath := filepath.Join(t.TempDir(), "dir", "file")
+	assert.NoError(DownloadFile(server.URL, destPath))
+	content, err := os.ReadFile(destPath)
+	assert.NoError(err)
+	assert.Equal(downloadContent, string(content))
+}
+
+func TestDownloadFileWithContextChecksum(t *testing.T) {
+	assert := assert.New(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(downloadContent))
+	}))
+	defer server.Close()
+
+	destPath := filepath.Join(t.TempDir(), "file")
+	err := DownloadFileWithContext(context.Background(), server.URL, destPath, &DownloadOptions{SHA256: downloadChecksum()})
+	assert.NoError(err)
+
+	destPath = filepath.Join(t.TempDir(), "file")
+	err = DownloadFileWithContext(context.Background(), server.URL, destPath, &DownloadOptions{SHA256: "invalid"})
+	assert.Error(err)
+	assert.Contains(err.Error(), "sha256 checksum")
+	assert.False(PathExists(destPath))
+	assert.False(PathExists(destPath + partialDownloadSuffix))
+}
+
+func TestDownloadFileWithContextRetriesAndResumes(t *testing.T) {
+	assert := assert.New(t)
+
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		switch attempts {
+		case 1:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case 2:
+			// Send half of the content before the connection is closed
+			w.Header().Set("Content-Length", strconv.Itoa(len(downloadContent)))
+			_, _ = w.Write([]byte(downloadContent[:len(downloadContent)/2]))
+		default:
+			offset, err := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(r.Header.Get("Range"), "bytes="), "-"))
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			w.WriteHeader(http.StatusPartialContent)
+			_, _ = w.Write([]byte(downloadContent[offset:]))
+		}
+	}))
+	defer server.Close()
+
+	var lastDownloaded int64
+	destPath := filepath.Join(t.TempDir(), "file")
+	err := DownloadFileWithContext(context.Background(), server.URL, destPath, &DownloadOptions{
+		Retries:       3,
+		RetryInterval: time.Millisecond,
+		SHA256:        downloadChecksum(),
+		Progress:      func(downloaded, _ int64) { lastDownloaded = downloaded },
+	})
+	assert.NoError(err)
+	assert.Equal(3, attempts)
+	assert.Equal(int64(len(downloadContent)), lastDownloaded)
+	content, err := os.ReadFile(destPath)
+	assert.NoError(err)
+	assert.Equal(downloadContent, string(content))
+}
+
+func TestDownloadFileWithContextDoesNotRetryClientErrors(t *testing.T) {
+	assert := assert.New(t)
+
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	err := DownloadFileWithContext(context.Background(), server.URL, filepath.Join(t.TempDir(), "file"), &DownloadOptions{
+		Retries:       3,
+		RetryInterval: time.Millisecond,
+	})
+	assert.Error(err)
+	assert.Contains(err.Error(), "received status code: 404")
+	assert.Equal(1, attempts)
+}
+
+func TestDownloadFileWithContextCancelled(t *testing.T) {
+	assert := assert.New(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := DownloadFileWithContext(ctx, server.URL, filepath.Join(t.TempDir(), "file"), &DownloadOptions{Retries: 3})
+	assert.ErrorIs(err, context.Canceled)
+}
@@ -0,0 +1,141 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/rogpeppe/go-internal/lockedfile"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
+)
+
+// The functions of this file allow different CLI processes to safely share files
+// such as the data store, the catalog and the plugin inventory cache.
+// A file is never modified in place: its new content is written to a temporary
+// file which then replaces the original file atomically.  This means readers never
+// need to lock the file, and writers must use the lock of the file to serialize
+// their modifications.  On Windows, opening or replacing a file can temporarily
+// fail with a sharing violation if another process is accessing it, so such
+// operations are retried.
+
+const (
+	// sharingViolationRetryTimeout is how long an operation failing because of
+	// a sharing violation is retried
+	sharingViolationRetryTimeout = 5 * time.Second
+	// sharingViolationRetryInterval is the delay between two retries
+	sharingViolationRetryInterval = 50 * time.Millisecond
+)
+
+// FileLock is an exclusive lock between processes that is associated with a file.
+// The lock is held on a separate "<file>.lock" file so that the file itself can be
+// replaced while the lock is held.
+type FileLock struct {
+	unlock func()
+}
+
+// LockFile acquires the exclusive lock associated with the file at the specified path,
+// waiting for other processes to release it if needed.  The directory of the file is
+// created if it does not exist.  The lock must be released using Unlock().
+func LockFile(path string) (*FileLock, error) {
+	lockPath := LongPath(path + ".lock")
+	if err := os.MkdirAll(filepath.Dir(lockPath), 0755); err != nil {
+		return nil, errors.Wrapf(err, "unable to create the directory of the lock file %q", lockPath)
+	}
+
+	var unlock func()
+	err := retryOnSharingViolation(func() error {
+		var err error
+		unlock, err = lockedfile.MutexAt(lockPath).Lock()
+		return err
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to lock the file %q", path)
+	}
+	return &FileLock{unlock: unlock}, nil
+}
+
+// Unlock releases the lock.  It is safe to call Unlock more than once.
+func (l *FileLock) Unlock() {
+	if l != nil && l.unlock != nil {
+		l.unlock()
+		l.unlock = nil
+	}
+}
+
+// ReadFile reads the file at the specified path, retrying on sharing violations.
+func ReadFile(path string) ([]byte, error) {
+	var b []byte
+	err := retryOnSharingViolation(func() error {
+		var err error
+		b, err = os.ReadFile(LongPath(path))
+		return err
+	})
+	return b, err
+}
+
+// renameFile replaces the file at the new path by the file at the old path.
+// Tests replace it to simulate the CLI being interrupted before the file is replaced.
+var renameFile = os.Rename
+
+// WriteFileAtomic writes the data to the file at the specified path by first writing it
+// to a temporary file in the same directory and then renaming it.  This guarantees the
+// file is never left partially written.  The directory of the file is created if missing.
+func WriteFileAtomic(path string, data []byte, perm os.FileMode) error {
+	longPath := LongPath(path)
+	dir := filepath.Dir(longPath)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	tmpFile, err := os.CreateTemp(dir, filepath.Base(longPath)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmpFile.Name())
+
+	_, err = tmpFile.Write(data)
+	if err == nil {
+		err = tmpFile.Sync()
+	}
+	if closeErr := tmpFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chmod(tmpFile.Name(), perm)
+	}
+	if err != nil {
+		return err
+	}
+
+	return retryOnSharingViolation(func() error {
+		return renameFile(tmpFile.Name(), longPath)
+	})
+}
+
+// CopyFileAtomic copies the source file to the destination file, replacing the
+// destination file atomically
+func CopyFileAtomic(sourceFile, destFile string) error {
+	input, err := ReadFile(sourceFile)
+	if err != nil {
+		return err
+	}
+	return WriteFileAtomic(destFile, input, constants.ConfigFilePermissions)
+}
+
+// retryOnSharingViolation calls the operation until it does not fail with
+// a sharing violation or until the retry timeout is reached
+func retryOnSharingViolation(op func() error) error {
+	deadline := time.Now().Add(sharingViolationRetryTimeout)
+	for {
+		err := op()
+		if err == nil || !isSharingViolation(err) || time.Now().After(deadline) {
+			return err
+		}
+		time.Sleep(sharingViolationRetryInterval)
+	}
+}
@@ -0,0 +1,18 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !windows
+
+package utils
+
+// isSharingViolation returns true if the error was caused by another process accessing the file.
+// Only Windows prevents accessing a file which is being used by another process.
+var isSharingViolation = func(_ error) bool {
+	return false
+}
+
+// LongPath returns a version of the path that is not limited in length.
+// Only Windows limits the length of paths.
+func LongPath(path string) string {
+	return path
+}
@@ -0,0 +1,164 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLockFile(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	// The directory of the file is created and a separate lock file is used
+	path := filepath.Join(tmpDir, "subdir", "file.yaml")
+	lock, err := LockFile(path)
+	assert.Nil(t, err)
+	assert.True(t, PathExists(path+".lock"))
+	assert.False(t, PathExists(path))
+
+	lock.Unlock()
+	// Unlocking more than once is allowed
+	lock.Unlock()
+}
+
+func TestLockFileSerializesWriters(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "counter")
+	assert.Nil(t, WriteFileAtomic(path, []byte(""), 0o600))
+
+	const writers = 10
+	var wg sync.WaitGroup
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			lock, err := LockFile(path)
+			if !assert.Nil(t, err) {
+				return
+			}
+			defer lock.Unlock()
+
+			b, err := ReadFile(path)
+			assert.Nil(t, err)
+			assert.Nil(t, WriteFileAtomic(path, append(b, 'x'), 0o600))
+		}()
+	}
+	wg.Wait()
+
+	b, err := ReadFile(path)
+	assert.Nil(t, err)
+	assert.Equal(t, strings.Repeat("x", writers), string(b))
+}
+
+func TestWriteFileAtomic(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "subdir", "file.yaml")
+	assert.Nil(t, WriteFileAtomic(path, []byte("first"), 0o600))
+	assert.Nil(t, WriteFileAtomic(path, []byte("second"), 0o600))
+
+	b, err := ReadFile(path)
+	assert.Nil(t, err)
+	assert.Equal(t, "second", string(b))
+
+	// No temporary file is left behind
+	entries, err := os.ReadDir(filepath.Dir(path))
+	assert.Nil(t, err)
+	assert.Len(t, entries, 1)
+}
+
+func TestWriteFileAtomicFailureKeepsPreviousContent(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "file.yaml")
+	assert.Nil(t, WriteFileAtomic(path, []byte("previous content"), 0o600))
+
+	// The write is interrupted before the file is replaced
+	originalRenameFile := renameFile
+	defer func() { renameFile = originalRenameFile }()
+	renameFile = func(oldpath, newpath string) error {
+		return errors.New("interrupted")
+	}
+	assert.NotNil(t, WriteFileAtomic(path, []byte("new"), 0o600))
+
+	// The file still has its previous content and no temporary file is left behind
+	b, err := ReadFile(path)
+	assert.Nil(t, err)
+	assert.Equal(t, "previous content", string(b))
+	entries, err := os.ReadDir(filepath.Dir(path))
+	assert.Nil(t, err)
+	assert.Len(t, entries, 1)
+}
+
+func TestCopyFileAtomic(t *testing.T) {
+	tmpDir := t.TempDir()
+	src := filepath.Join(tmpDir, "src")
+	dst := filepath.Join(tmpDir, "dst", "file")
+	assert.Nil(t, os.WriteFile(src, []byte("content"), 0o600))
+	assert.Nil(t, CopyFileAtomic(src, dst))
+
+	b, err := ReadFile(dst)
+	assert.Nil(t, err)
+	assert.Equal(t, "content", string(b))
+
+	assert.NotNil(t, CopyFileAtomic(filepath.Join(tmpDir, "missing"), dst))
+}
+
+func TestRetryOnSharingViolation(t *testing.T) {
+	errSharingViolation := errors.New("sharing violation")
+	originalIsSharingViolation := isSharingViolation
+	defer func() { isSharingViolation = originalIsSharingViolation }()
+	isSharingViolation = func(err error) bool {
+		return errors.Is(err, errSharingViolation)
+	}
+
+	// Retries until the sharing violation is resolved
+	attempts := 0
+	err := retryOnSharingViolation(func() error {
+		attempts++
+		if attempts < 3 {
+			return errSharingViolation
+		}
+		return nil
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 3, attempts)
+
+	// Other errors are not retried
+	attempts = 0
+	otherErr := errors.New("other error")
+	err = retryOnSharingViolation(func() error {
+		attempts++
+		return otherErr
+	})
+	assert.Equal(t, otherErr, err)
+	assert.Equal(t, 1, attempts)
+}
+
+func TestLongPath(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	// Short paths are not modified
+	path := filepath.Join(tmpDir, "file")
+	assert.Equal(t, path, LongPath(path))
+
+	// Files with long paths can be locked, written and read
+	path = tmpDir
+	for len(path) < 300 {
+		path = filepath.Join(path, strings.Repeat("d", 50))
+	}
+	path = filepath.Join(path, "file")
+
+	lock, err := LockFile(path)
+	assert.Nil(t, err)
+	defer lock.Unlock()
+
+	assert.Nil(t, WriteFileAtomic(path, []byte("content"), 0o600))
+	b, err := ReadFile(path)
+	assert.Nil(t, err)
+	assert.Equal(t, "content", string(b))
+}
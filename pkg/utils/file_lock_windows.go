@@ -0,0 +1,48 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"syscall"
+)
+
+const (
+	errorSharingViolation syscall.Errno = 32
+	errorLockViolation    syscall.Errno = 33
+
+	// maxPath is the maximum length of a path for most Windows APIs unless
+	// the path uses the extended-length prefix
+	maxPath = 260
+	// extendedLengthPrefix allows paths longer than maxPath
+	extendedLengthPrefix = `\\?\`
+)
+
+// isSharingViolation returns true if the error was caused by another process accessing the file.
+// Access denied errors are not retried as they are most often caused by missing permissions.
+var isSharingViolation = func(err error) bool {
+	var errno syscall.Errno
+	if !errors.As(err, &errno) {
+		return false
+	}
+	return errno == errorSharingViolation || errno == errorLockViolation
+}
+
+// LongPath returns a version of the path that is not limited to MAX_PATH characters.
+func LongPath(path string) string {
+	if len(path) < maxPath || strings.HasPrefix(path, extendedLengthPrefix) {
+		return path
+	}
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return path
+	}
+	if strings.HasPrefix(absPath, `\\`) {
+		// UNC path
+		return extendedLengthPrefix + `UNC\` + strings.TrimPrefix(absPath, `\\`)
+	}
+	return extendedLengthPrefix + absPath
+}
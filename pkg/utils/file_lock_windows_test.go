@@ -0,0 +1,29 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"strings"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLongPathWindows(t *testing.T) {
+	path := `C:\` + strings.Repeat(`d\`, 200) + "file"
+	assert.Equal(t, `\\?\`+path, LongPath(path))
+	assert.Equal(t, `\\?\`+path, LongPath(`\\?\`+path))
+
+	uncPath := `\\server\share\` + strings.Repeat(`d\`, 200) + "file"
+	assert.Equal(t, `\\?\UNC\server\share\`+strings.Repeat(`d\`, 200)+"file", LongPath(uncPath))
+}
+
+func TestIsSharingViolationWindows(t *testing.T) {
+	assert.True(t, isSharingViolation(syscall.Errno(32)))
+	assert.True(t, isSharingViolation(syscall.Errno(33)))
+	assert.False(t, isSharingViolation(syscall.Errno(2)))
+	// Access denied is most often caused by missing permissions and must not be retried
+	assert.False(t, isSharingViolation(syscall.Errno(5)))
+}
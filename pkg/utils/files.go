@@ -4,6 +4,8 @@
 package utils
 
 import (
+	"io"
+	"io/fs"
 	"os"
 	"path/filepath"
 
@@ -32,11 +34,23 @@ func SaveFile(filePath string, data []byte) error {
 }
 
 // CopyFile copies source file to dest file
+// The content is streamed and the permissions of the source file are preserved.
+// If the source file is a symlink, the file it points to is copied.
 func CopyFile(sourceFile, destFile string) error {
-	input, err := os.ReadFile(sourceFile)
+	input, err := os.Open(sourceFile)
 	if err != nil {
 		return err
 	}
+	defer input.Close()
+
+	info, err := input.Stat()
+	if err != nil {
+		return err
+	}
+	if !info.Mode().IsRegular() {
+		return errors.Errorf("unable to copy '%s' which is not a regular file", sourceFile)
+	}
+
 	dirName := filepath.Dir(destFile)
 	if _, serr := os.Stat(dirName); serr != nil {
 		merr := os.MkdirAll(dirName, os.ModePerm)
@@ -44,8 +58,24 @@ func CopyFile(sourceFile, destFile string) error {
 			return merr
 		}
 	}
-	err = os.WriteFile(destFile, input, constants.ConfigFilePermissions)
-	return err
+
+	output, err := os.OpenFile(destFile, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, info.Mode().Perm())
+	if err != nil {
+		return err
+	}
+	defer output.Close()
+
+	if _, err := io.Copy(output, input); err != nil {
+		return errors.Wrapf(err, "unable to copy '%s' to '%s'", sourceFile, destFile)
+	}
+	// The permissions are not updated by OpenFile if the dest file already exists
+	if err := output.Chmod(info.Mode().Perm()); err != nil {
+		return err
+	}
+	if err := output.Sync(); err != nil {
+		return err
+	}
+	return output.Close()
 }
 
 // PathExists returns true if file/directory exists otherwise returns false
@@ -85,3 +115,54 @@ func AppendFile(filePath string, data []byte) error {
 	}
 	return nil
 }
+
+// rename renames a file or directory, it is a variable so that it can be replaced by tests
+var rename = os.Rename
+
+// MoveDir moves the source directory to the dest directory, whose parent directory is created
+// if needed.  Renaming a directory is not possible across file systems, in which case the
+// directory is copied and the source directory is then removed.
+func MoveDir(sourceDir, destDir string) error {
+	if err := os.MkdirAll(filepath.Dir(destDir), 0755); err != nil {
+		return err
+	}
+	err := rename(sourceDir, destDir)
+	if err == nil || !isCrossDeviceError(err) {
+		return err
+	}
+
+	if err := CopyDir(sourceDir, destDir); err != nil {
+		// Don't leave a partial copy behind, which would prevent moving the directory later on
+		_ = os.RemoveAll(destDir)
+		return err
+	}
+	return os.RemoveAll(sourceDir)
+}
+
+// CopyDir recursively copies the source directory to the dest directory, which is created
+// if needed.  The permissions of the files are preserved and symlinks are copied as symlinks.
+func CopyDir(sourceDir, destDir string) error {
+	return filepath.WalkDir(sourceDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		relPath, err := filepath.Rel(sourceDir, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(destDir, relPath)
+
+		switch {
+		case d.IsDir():
+			return os.MkdirAll(target, 0755)
+		case d.Type()&fs.ModeSymlink != 0:
+			link, err := os.Readlink(path)
+			if err != nil {
+				return err
+			}
+			return os.Symlink(link, target)
+		default:
+			return CopyFile(path, target)
+		}
+	})
+}
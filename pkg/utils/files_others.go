@@ -0,0 +1,17 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !windows
+
+package utils
+
+import (
+	"errors"
+	"syscall"
+)
+
+// isCrossDeviceError returns true if the error was caused by renaming
+// a file or directory to a different file system
+var isCrossDeviceError = func(err error) bool {
+	return errors.Is(err, syscall.EXDEV)
+}
@@ -4,10 +4,16 @@
 package utils
 
 import (
+	"bytes"
+	"errors"
 	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/assert"
 )
 
 var _ = Describe("Unit tests for the files utils", func() {
@@ -40,3 +46,113 @@ var _ = Describe("Unit tests for the files utils", func() {
 		})
 	})
 })
+
+func TestCopyFile(t *testing.T) {
+	assert := assert.New(t)
+	dir := t.TempDir()
+
+	source := filepath.Join(dir, "source")
+	assert.NoError(os.WriteFile(source, []byte("content"), 0755))
+
+	dest := filepath.Join(dir, "subdir", "dest")
+	assert.NoError(CopyFile(source, dest))
+	content, err := os.ReadFile(dest)
+	assert.NoError(err)
+	assert.Equal("content", string(content))
+	if runtime.GOOS != "windows" {
+		info, err := os.Stat(dest)
+		assert.NoError(err)
+		assert.Equal(os.FileMode(0755), info.Mode().Perm())
+	}
+
+	// Copying over an existing file replaces its content and permissions
+	assert.NoError(os.WriteFile(source, []byte("new"), 0600))
+	assert.NoError(os.Chmod(source, 0600))
+	assert.NoError(CopyFile(source, dest))
+	content, err = os.ReadFile(dest)
+	assert.NoError(err)
+	assert.Equal("new", string(content))
+	if runtime.GOOS != "windows" {
+		info, err := os.Stat(dest)
+		assert.NoError(err)
+		assert.Equal(os.FileMode(0600), info.Mode().Perm())
+	}
+}
+
+func TestCopyFileLargeFile(t *testing.T) {
+	assert := assert.New(t)
+	dir := t.TempDir()
+
+	data := bytes.Repeat([]byte("0123456789abcdef"), 4*1024*1024)
+	source := filepath.Join(dir, "source")
+	assert.NoError(os.WriteFile(source, data, 0644))
+
+	dest := filepath.Join(dir, "dest")
+	assert.NoError(CopyFile(source, dest))
+	content, err := os.ReadFile(dest)
+	assert.NoError(err)
+	assert.True(bytes.Equal(data, content))
+}
+
+func TestCopyFileSymlink(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("creating symlinks requires privileges on windows")
+	}
+	assert := assert.New(t)
+	dir := t.TempDir()
+
+	target := filepath.Join(dir, "target")
+	assert.NoError(os.WriteFile(target, []byte("content"), 0755))
+	link := filepath.Join(dir, "link")
+	assert.NoError(os.Symlink(target, link))
+
+	dest := filepath.Join(dir, "dest")
+	assert.NoError(CopyFile(link, dest))
+	info, err := os.Lstat(dest)
+	assert.NoError(err)
+	assert.True(info.Mode().IsRegular())
+	assert.Equal(os.FileMode(0755), info.Mode().Perm())
+	content, err := os.ReadFile(dest)
+	assert.NoError(err)
+	assert.Equal("content", string(content))
+
+	// Copying a directory is not supported
+	assert.Error(CopyFile(dir, filepath.Join(dir, "destdir")))
+}
+
+func TestCopyDir(t *testing.T) {
+	srcDir := filepath.Join(t.TempDir(), "src")
+	assert.Nil(t, os.MkdirAll(filepath.Join(srcDir, "sub"), 0755))
+	assert.Nil(t, os.WriteFile(filepath.Join(srcDir, "file"), []byte("file"), 0600))
+	assert.Nil(t, os.WriteFile(filepath.Join(srcDir, "sub", "binary"), []byte("binary"), 0755))
+
+	destDir := filepath.Join(t.TempDir(), "dest")
+	assert.Nil(t, CopyDir(srcDir, destDir))
+
+	b, err := os.ReadFile(filepath.Join(destDir, "sub", "binary"))
+	assert.Nil(t, err)
+	assert.Equal(t, "binary", string(b))
+	if runtime.GOOS != "windows" {
+		info, err := os.Stat(filepath.Join(destDir, "sub", "binary"))
+		assert.Nil(t, err)
+		assert.Equal(t, os.FileMode(0755), info.Mode().Perm())
+	}
+	assert.FileExists(t, filepath.Join(srcDir, "file"))
+}
+
+func TestMoveDirAcrossFileSystems(t *testing.T) {
+	originalRename, originalIsCrossDeviceError := rename, isCrossDeviceError
+	defer func() { rename, isCrossDeviceError = originalRename, originalIsCrossDeviceError }()
+	// Simulate a destination on another file system
+	rename = func(_, _ string) error { return errors.New("invalid cross-device link") }
+	isCrossDeviceError = func(_ error) bool { return true }
+
+	srcDir := filepath.Join(t.TempDir(), "src")
+	assert.Nil(t, os.MkdirAll(srcDir, 0755))
+	assert.Nil(t, os.WriteFile(filepath.Join(srcDir, "file"), []byte("file"), 0600))
+
+	destDir := filepath.Join(t.TempDir(), "dest")
+	assert.Nil(t, MoveDir(srcDir, destDir))
+	assert.FileExists(t, filepath.Join(destDir, "file"))
+	assert.False(t, PathExists(srcDir))
+}
@@ -0,0 +1,18 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"errors"
+	"syscall"
+)
+
+const errorNotSameDevice syscall.Errno = 17
+
+// isCrossDeviceError returns true if the error was caused by renaming
+// a file or directory to a different volume
+var isCrossDeviceError = func(err error) bool {
+	var errno syscall.Errno
+	return errors.As(err, &errno) && errno == errorNotSameDevice
+}
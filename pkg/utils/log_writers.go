@@ -0,0 +1,47 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"io"
+	"os"
+	"sync"
+
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
+)
+
+// logWriters are the writers currently used by the log package, which does not expose them
+var logWriters = struct {
+	sync.Mutex
+	stdout io.Writer
+	stderr io.Writer
+}{stdout: os.Stdout, stderr: os.Stderr}
+
+// SetLogWriters sets the writers of the log package, a nil writer being left unchanged,
+// and returns a function restoring the previous writers.  As the log package does not
+// expose its writers, they must be set through this function to be restored correctly.
+func SetLogWriters(stdout, stderr io.Writer) (restore func()) {
+	logWriters.Lock()
+	defer logWriters.Unlock()
+
+	previousStdout, previousStderr := logWriters.stdout, logWriters.stderr
+	setLogWriters(stdout, stderr)
+	return func() {
+		logWriters.Lock()
+		defer logWriters.Unlock()
+		setLogWriters(previousStdout, previousStderr)
+	}
+}
+
+// setLogWriters sets the writers of the log package.  The caller must hold the mutex.
+func setLogWriters(stdout, stderr io.Writer) {
+	if stdout != nil {
+		logWriters.stdout = stdout
+		log.SetStdout(stdout)
+	}
+	if stderr != nil {
+		logWriters.stderr = stderr
+		log.SetStderr(stderr)
+	}
+}
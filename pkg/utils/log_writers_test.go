@@ -0,0 +1,34 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
+)
+
+func TestSetLogWriters(t *testing.T) {
+	var embedderOut, embedderErr, out bytes.Buffer
+
+	restoreEmbedder := SetLogWriters(&embedderOut, &embedderErr)
+	defer restoreEmbedder()
+
+	// Only the stdout writer is replaced
+	restore := SetLogWriters(&out, nil)
+	log.Outputf("to out")
+	log.Info("to embedder stderr")
+	restore()
+
+	// The previous writers are restored
+	log.Outputf("to embedder out")
+
+	assert.Contains(t, out.String(), "to out")
+	assert.Contains(t, embedderErr.String(), "to embedder stderr")
+	assert.Contains(t, embedderOut.String(), "to embedder out")
+	assert.NotContains(t, embedderOut.String(), "to out")
+}
@@ -5,11 +5,20 @@
 package utils
 
 import (
+	"regexp"
 	"sort"
+	"strings"
 
 	"github.com/Masterminds/semver"
 )
 
+var (
+	// versionRegex matches a specific version or a partial version such as v1 or v1.2
+	versionRegex = regexp.MustCompile(`^v?\d+(\.\d+){0,2}(-[0-9A-Za-z.-]+)?(\+[0-9A-Za-z.-]+)?$`)
+	// constraintSeparatorRegex matches the whitespace separating two constraints which must all be satisfied
+	constraintSeparatorRegex = regexp.MustCompile(`([^\s,|])\s+([<>=!~^])`)
+)
+
 // SortVersions sorts the supported version strings in ascending semver 2.0 order.
 func SortVersions(vStrArr []string) error {
 	vArr := make([]*semver.Version, len(vStrArr))
@@ -78,3 +87,32 @@ func IsSameMinor(v1str, v2str string) bool {
 	}
 	return v1.Major() == v2.Major() && v1.Minor() == v2.Minor()
 }
+
+// ParseVersionConstraint parses a semver range constraint such as "~1.2", "^1.2.3" or ">=1.0.0 <2.0.0".
+// Constraints which must all be satisfied can be separated by a comma or a space.
+// It returns nil if the string is a specific or partial version (e.g., v1.2.3 or v1.2),
+// or if it is not a valid constraint.
+func ParseVersionConstraint(constraintStr string) *semver.Constraints {
+	constraintStr = strings.TrimSpace(constraintStr)
+	if constraintStr == "" || versionRegex.MatchString(constraintStr) {
+		return nil
+	}
+	constraint, err := semver.NewConstraint(constraintSeparatorRegex.ReplaceAllString(constraintStr, "$1,$2"))
+	if err != nil {
+		return nil
+	}
+	return constraint
+}
+
+// VersionsMatchingConstraint returns the versions which satisfy the constraint,
+// preserving their order.  Versions which cannot be parsed are ignored.
+func VersionsMatchingConstraint(versions []string, constraint *semver.Constraints) []string {
+	var matching []string
+	for _, vStr := range versions {
+		v, err := semver.NewVersion(vStr)
+		if err == nil && constraint.Check(v) {
+			matching = append(matching, vStr)
+		}
+	}
+	return matching
+}
@@ -360,3 +360,66 @@ func TestIsSameMinor(t *testing.T) {
 		})
 	}
 }
+
+func TestParseVersionConstraint(t *testing.T) {
+	tests := []struct {
+		constraint     string
+		isConstraint   bool
+		versions       []string
+		expectedResult []string
+	}{
+		{constraint: "", isConstraint: false},
+		{constraint: "latest", isConstraint: false},
+		{constraint: "v1.2.3", isConstraint: false},
+		{constraint: "v1.2", isConstraint: false},
+		{constraint: "v1", isConstraint: false},
+		{constraint: "v1.2.3-beta.1", isConstraint: false},
+		{constraint: "invalid", isConstraint: false},
+		{
+			constraint:     "~1.2",
+			isConstraint:   true,
+			versions:       []string{"v1.1.9", "v1.2.0", "v1.2.5", "v1.3.0"},
+			expectedResult: []string{"v1.2.0", "v1.2.5"},
+		},
+		{
+			constraint:     "^v1.2.0",
+			isConstraint:   true,
+			versions:       []string{"v1.1.9", "v1.2.0", "v1.9.0", "v2.0.0"},
+			expectedResult: []string{"v1.2.0", "v1.9.0"},
+		},
+		{
+			constraint:     ">=1.0.0 <2.0.0",
+			isConstraint:   true,
+			versions:       []string{"v0.9.0", "v1.0.0", "v1.5.0", "v2.0.0"},
+			expectedResult: []string{"v1.0.0", "v1.5.0"},
+		},
+		{
+			constraint:     ">= 1.0.0, < 2.0.0",
+			isConstraint:   true,
+			versions:       []string{"v0.9.0", "v1.0.0", "v1.5.0", "v2.0.0"},
+			expectedResult: []string{"v1.0.0", "v1.5.0"},
+		},
+		{
+			constraint:     "1.2.x || >=3.0.0",
+			isConstraint:   true,
+			versions:       []string{"v1.2.1", "v1.3.0", "v3.1.0", "invalid"},
+			expectedResult: []string{"v1.2.1", "v3.1.0"},
+		},
+		{
+			constraint:     ">v5.0.0",
+			isConstraint:   true,
+			versions:       []string{"v1.0.0", "v2.0.0"},
+			expectedResult: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.constraint, func(t *testing.T) {
+			constraint := ParseVersionConstraint(tt.constraint)
+			assert.Equal(t, tt.isConstraint, constraint != nil)
+			if constraint != nil {
+				assert.Equal(t, tt.expectedResult, VersionsMatchingConstraint(tt.versions, constraint))
+			}
+		})
+	}
+}
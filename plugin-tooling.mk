@@ -39,6 +39,16 @@ else
 PLUGIN_DEBUG=false
 endif
 
+# To build reproducible plugin binaries use PLUGIN_REPRODUCIBLE=1
+# The build date then defaults to the date of the last commit unless SOURCE_DATE_EPOCH is set
+ifeq ($(strip $(PLUGIN_REPRODUCIBLE)),1)
+PLUGIN_REPRODUCIBLE_BUILD=true
+SOURCE_DATE_EPOCH ?= $(shell git log -1 --format=%ct)
+export SOURCE_DATE_EPOCH
+else
+PLUGIN_REPRODUCIBLE_BUILD=false
+endif
+
 # Add supported OS-ARCHITECTURE combinations here
 PLUGIN_BUILD_OS_ARCH ?= linux-amd64 windows-amd64 darwin-amd64 linux-arm64 darwin-arm64 windows-arm64  
 
@@ -120,7 +130,8 @@ plugin-build-%:
 		--os-arch $(OS)_$(ARCH) \
 		--match "$(PLUGIN_NAME)" \
 		--plugin-scope-association-file $(PLUGIN_SCOPE_ASSOCIATION_FILE) \
-		--debug-symbols=$(PLUGIN_DEBUG)
+		--debug-symbols=$(PLUGIN_DEBUG) \
+		--reproducible=$(PLUGIN_REPRODUCIBLE_BUILD)
 
 .PHONY: plugin-build-packages
 plugin-build-packages:  ## Build plugin packages
@@ -50,6 +50,11 @@ var _ = framework.CLICoreDescribe("[Tests:E2E][Feature:Recommended-version]", fu
 	)
 	BeforeEach(func() {
 		tf = framework.NewFramework()
+		// The tests do not run interactively, so force the recommendations to be printed
+		os.Setenv("TANZU_CLI_SUPPRESS_VERSION_RECOMMENDATION", "false")
+	})
+	AfterEach(func() {
+		os.Unsetenv("TANZU_CLI_SUPPRESS_VERSION_RECOMMENDATION")
 	})
 	Context("tests for the recommended version feature", func() {
 		When("there is no data store", func() {
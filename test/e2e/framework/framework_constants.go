@@ -33,7 +33,7 @@ const (
 	// Plugin commands
 	UpdatePluginSource                  = "%s plugin source update %s --uri %s"
 	ListPluginSourcesWithJSONOutputFlag = "%s plugin source list -o json"
-	DeletePluginSource                  = "%s plugin source delete %s"
+	DeletePluginSource                  = "%s plugin source delete %s --yes"
 	InitPluginDiscoverySource           = "%s plugin source init"
 	ListPluginsCmdWithJSONOutputFlag    = "%s plugin list -o json"
 	SearchPluginsCmd                    = "%s plugin search"
@@ -44,7 +44,7 @@ const (
 	InstallAllPluginsFromGroupCmd       = "%s plugin install --group %s"
 	DescribePluginCmd                   = "%s plugin describe %s"
 	UninstallPLuginCmd                  = "%s plugin uninstall %s --yes"
-	CleanPluginsCmd                     = "%s plugin clean"
+	CleanPluginsCmd                     = "%s plugin clean --yes"
 	pluginSyncCmd                       = "%s plugin sync"
 	PluginDownloadBundleCmd             = "%s plugin download-bundle"
 	PluginUploadBundleCmd               = "%s plugin upload-bundle"